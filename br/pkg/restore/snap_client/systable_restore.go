@@ -122,6 +122,9 @@ var unRecoverableTable = map[string]map[string]struct{}{
 		"tidb_mdl_view": {},
 
 		"tidb_pitr_id_map": {},
+
+		// Table ID is recorded in the column `record_key` so that the table cannot be recovered simply.
+		"tidb_changefeed_log": {},
 	},
 	"sys": {
 		// replace into view is not supported now
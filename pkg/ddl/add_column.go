@@ -16,6 +16,7 @@ package ddl
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -547,6 +548,10 @@ func columnDefToCol(ctx *metabuild.Context, offset int, colDef *ast.ColumnDef, o
 					return nil, nil, errors.Trace(err)
 				}
 				col.Dependences = dependColNames
+			case ast.ColumnOptionSRID:
+				if err = setColumnSRID(col, v); err != nil {
+					return nil, nil, errors.Trace(err)
+				}
 			case ast.ColumnOptionCollate:
 				if field_types.HasCharset(colDef.Tp) {
 					col.FieldType.SetCollate(v.StrValue)
@@ -830,8 +835,8 @@ func getDefaultValue(ctx exprctx.BuildContext, col *table.Column, option *ast.Co
 	}
 
 	if v.Kind() == types.KindBinaryLiteral || v.Kind() == types.KindMysqlBit {
-		if types.IsTypeBlob(tp) || tp == mysql.TypeJSON || tp == mysql.TypeTiDBVectorFloat32 {
-			// BLOB/TEXT/JSON column cannot have a default value.
+		if types.IsTypeBlob(tp) || tp == mysql.TypeJSON || tp == mysql.TypeTiDBVectorFloat32 || tp == mysql.TypeGeometry {
+			// BLOB/TEXT/JSON/GEOMETRY column cannot have a default value.
 			// Skip the unnecessary decode procedure.
 			return v.GetString(), false, err
 		}
@@ -923,6 +928,20 @@ func setColumnComment(ctx expression.BuildContext, col *table.Column, option *as
 	return errors.Trace(err)
 }
 
+// setColumnSRID sets the SRID of the spatial column, the SRID must be of a
+// known spatial reference system.
+func setColumnSRID(col *table.Column, option *ast.ColumnOption) error {
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrWrongUsage.GenWithStackByArgs("SRID", "non-geometry column")
+	}
+	srid := option.Expr.(*driver.ValueExpr).GetUint64()
+	if _, ok := types.GetSpatialReferenceSystem(uint32(srid)); srid > math.MaxUint32 || !ok {
+		return types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	col.SetSRID(uint32(srid))
+	return nil
+}
+
 func processAndCheckDefaultValueAndColumn(ctx expression.BuildContext, col *table.Column,
 	outPriKeyConstraint *ast.Constraint, hasDefaultValue, setOnUpdateNow, hasNullFlag bool) error {
 	processDefaultValue(col, hasDefaultValue, setOnUpdateNow)
@@ -1194,6 +1213,10 @@ func checkColumnDefaultValue(ctx exprctx.BuildContext, col *table.Column, value
 		// Note that expression default is still supported.
 		return hasDefaultValue, value, errors.Errorf("VECTOR column '%-.192s' can't have a literal default. Use expression default instead: ((VEC_FROM_TEXT('...')))", col.Name.O)
 	}
+	if value != nil && col.GetType() == mysql.TypeGeometry {
+		// In any SQL mode we don't allow GEOMETRY column to have a literal default.
+		return hasDefaultValue, value, dbterror.ErrBlobCantHaveDefault.GenWithStackByArgs(col.Name.O)
+	}
 	if value != nil && (col.GetType() == mysql.TypeJSON ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
 		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+func onCreateChangefeed(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
+	args, err := model.GetCreateChangefeedArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	cf := args.Changefeed
+	if tblInfo.FindChangefeed(cf.Name.L) >= 0 {
+		if args.IfNotExists {
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrChangefeedExists.GenWithStackByArgs(cf.Name.O)
+	}
+	// The ID is allocated by the job, so that it's not reused by a retried statement.
+	if cf.ID, err = jobCtx.metaMut.GenGlobalID(); err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo.Changefeeds = append(tblInfo.Changefeeds, cf)
+
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropChangefeed(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
+	args, err := model.GetDropChangefeedArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	idx := tblInfo.FindChangefeed(args.Name.L)
+	if idx < 0 {
+		if args.IfExists {
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrChangefeedNotExists.GenWithStackByArgs(args.Name.O)
+	}
+	tblInfo.Changefeeds = slices.Delete(tblInfo.Changefeeds, idx, idx+1)
+
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
@@ -286,7 +286,10 @@ func isDroppableColumn(tblInfo *model.TableInfo, colName ast.CIStr) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	if err = checkColumnWithIndexInclude(tblInfo, colName); err != nil {
+		return err
+	}
+	return checkColumnWithIndexCondition(tblInfo, colName, true)
 }
 
 func onSetDefaultValue(jobCtx *jobContext, job *model.Job) (ver int64, _ error) {
@@ -381,6 +384,9 @@ func updateNewIdxColsNameOffset(changingIdxs []*model.IndexInfo,
 				SetIdxColNameOffset(col, changingCol)
 			}
 		}
+		if col := idx.FindIncludeColumnByName(oldName.L); col != nil {
+			SetIdxColNameOffset(col, changingCol)
+		}
 	}
 }
 
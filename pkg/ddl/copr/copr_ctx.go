@@ -15,6 +15,8 @@
 package copr
 
 import (
+	"slices"
+
 	"github.com/pingcap/errors"
 	distsqlctx "github.com/pingcap/tidb/pkg/distsql/context"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -170,7 +172,8 @@ func NewCopContextSingleIndex(
 	idxInfo *model.IndexInfo,
 	requestSource string,
 ) (*CopContextSingleIndex, error) {
-	base, err := NewCopContextBase(exprCtx, distSQLCtx, pushDownFlags, tblInfo, idxInfo.Columns, requestSource)
+	idxCols := appendIncludeColumns(appendConditionColumns(idxInfo.Columns, idxInfo, tblInfo), idxInfo)
+	base, err := NewCopContextBase(exprCtx, distSQLCtx, pushDownFlags, tblInfo, idxCols, requestSource)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +223,10 @@ func NewCopContextMultiIndex(
 			}
 		}
 	}
+	for _, idxInfo := range allIdxInfo {
+		allIdxCols = appendConditionColumns(allIdxCols, idxInfo, tblInfo)
+		allIdxCols = appendIncludeColumns(allIdxCols, idxInfo)
+	}
 
 	base, err := NewCopContextBase(exprCtx, distSQLCtx, pushDownFlags, tblInfo, allIdxCols, requestSource)
 	if err != nil {
@@ -262,6 +269,36 @@ func (c *CopContextMultiIndex) IndexInfo(indexID int64) *model.IndexInfo {
 	return nil
 }
 
+// appendConditionColumns appends the columns referenced by the condition of a
+// partial index, which are needed to filter the rows to be indexed.
+func appendConditionColumns(
+	idxCols []*model.IndexColumn,
+	idxInfo *model.IndexInfo,
+	tblInfo *model.TableInfo,
+) []*model.IndexColumn {
+	if !idxInfo.HasCondition() {
+		return idxCols
+	}
+	cols := slices.Clip(idxCols)
+	for _, name := range idxInfo.ConditionColumns {
+		col := model.FindColumnInfo(tblInfo.Columns, name.L)
+		if col == nil {
+			continue
+		}
+		cols = append(cols, &model.IndexColumn{Name: col.Name, Offset: col.Offset, Length: types.UnspecifiedLength})
+	}
+	return cols
+}
+
+// appendIncludeColumns appends the included columns of an index, whose values
+// are stored in the index value.
+func appendIncludeColumns(idxCols []*model.IndexColumn, idxInfo *model.IndexInfo) []*model.IndexColumn {
+	if !idxInfo.HasIncludeColumns() {
+		return idxCols
+	}
+	return append(slices.Clip(idxCols), idxInfo.IncludeColumns...)
+}
+
 func fillUsedColumns(
 	usedCols map[int64]struct{},
 	idxCols []*model.IndexColumn,
@@ -294,8 +331,8 @@ func resolveIndicesForIndex(
 	idxInfo *model.IndexInfo,
 	tblInfo *model.TableInfo,
 ) []int {
-	offsets := make([]int, 0, len(idxInfo.Columns))
-	for _, idxCol := range idxInfo.Columns {
+	offsets := make([]int, 0, len(idxInfo.Columns)+len(idxInfo.IncludeColumns))
+	for _, idxCol := range slices.Concat(idxInfo.Columns, idxInfo.IncludeColumns) {
 		hid := tblInfo.Columns[idxCol.Offset].ID
 		for j, col := range outputCols {
 			if col.ID == hid {
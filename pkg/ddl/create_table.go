@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"unicode/utf8"
@@ -889,7 +890,8 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: ast.NewCIStr(op.StrValue),
 			}
-		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+			ast.TableOptionTTLAction, ast.TableOptionTTLArchiveTarget:
 			if ttlOptionsHandled {
 				continue
 			}
@@ -907,6 +909,13 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 				if ttlJobInterval != nil {
 					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 				}
+				ttlAction, ttlArchiveTarget := getTTLActionInOptions(options)
+				if ttlAction != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+				}
+				if ttlArchiveTarget != nil {
+					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TARGET"))
+				}
 			}
 
 			tbInfo.TTLInfo = ttlInfo
@@ -1179,6 +1188,8 @@ func BuildTableInfoWithLike(ident ast.Ident, referTblInfo *model.TableInfo, s *a
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
+	// The changefeeds are identified by their IDs, they can't be shared by tables.
+	tblInfo.Changefeeds = nil
 	tblInfo.TableCacheStatusType = model.TableCacheStatusDisable
 	// Ignore TiFlash replicas for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
@@ -1242,7 +1253,7 @@ func BuildTableInfo(
 		}
 
 		var hiddenCols []*model.ColumnInfo
-		if constr.Tp != ast.ConstraintVector {
+		if constr.Tp != ast.ConstraintVector && constr.Tp != ast.ConstraintSpatial && constr.Tp != ast.ConstraintFulltext {
 			// Build hidden columns if necessary.
 			hiddenCols, err = buildHiddenColumnInfoWithCheck(ctx, constr.Keys, ast.NewCIStr(constr.Name), tbInfo, tblColumns)
 			if err != nil {
@@ -1282,6 +1293,9 @@ func BuildTableInfo(
 			continue
 		}
 		if constr.Tp == ast.ConstraintPrimaryKey {
+			if err := checkIndexWithoutCondition(ast.NewCIStr(mysql.PrimaryKeyName), constr.Option); err != nil {
+				return nil, err
+			}
 			lastCol, err := CheckPKOnGeneratedColumn(tbInfo, constr.Keys)
 			if err != nil {
 				return nil, err
@@ -1311,7 +1325,33 @@ func BuildTableInfo(
 		}
 
 		if constr.Tp == ast.ConstraintFulltext {
-			ctx.AppendWarning(dbterror.ErrTableCantHandleFt.FastGenByArgs())
+			idxInfo, err := BuildFullTextIndexInfo(tbInfo, ast.NewCIStr(constr.Name), constr.Keys, constr.Option, model.StatePublic)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			_, err = validateCommentLength(ctx.GetExprCtx().GetEvalCtx().ErrCtx(), ctx.GetSQLMode(), idxInfo.Name.String(), &idxInfo.Comment, dbterror.ErrTooLongIndexComment)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			idxInfo.ID = AllocateIndexID(tbInfo)
+			tbInfo.Indices = append(tbInfo.Indices, idxInfo)
+			continue
+		}
+
+		if constr.Tp == ast.ConstraintSpatial {
+			idxInfo, err := BuildSpatialIndexInfo(tbInfo, ast.NewCIStr(constr.Name), constr.Keys, constr.Option, model.StatePublic)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			_, err = validateCommentLength(ctx.GetExprCtx().GetEvalCtx().ErrCtx(), ctx.GetSQLMode(), idxInfo.Name.String(), &idxInfo.Comment, dbterror.ErrTooLongIndexComment)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if warn := checkSpatialIndexUsable(tbInfo, idxInfo); warn != nil {
+				ctx.AppendWarning(warn)
+			}
+			idxInfo.ID = AllocateIndexID(tbInfo)
+			tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 			continue
 		}
 
@@ -1620,3 +1660,133 @@ func BuildViewInfo(s *ast.CreateViewStmt) (*model.ViewInfo, error) {
 	return &model.ViewInfo{Definer: s.Definer, Algorithm: s.Algorithm,
 		Security: s.Security, SelectStmt: sb.String(), CheckOption: s.CheckOption, Cols: nil}, nil
 }
+
+// BuildMaterializedViewInfo builds a MaterializedViewInfo from CreateMaterializedViewStmt.
+// The base table is the table read by the view, it's nil if the view doesn't
+// read a single table.
+func BuildMaterializedViewInfo(s *ast.CreateMaterializedViewStmt, baseTable *model.TableInfo) (*model.MaterializedViewInfo, error) {
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := s.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return nil, err
+	}
+	mvInfo := &model.MaterializedViewInfo{Definition: sb.String()}
+	tn := MaterializedViewBaseTable(s.Select)
+	if tn == nil || baseTable == nil {
+		return mvInfo, nil
+	}
+	sel := s.Select.(*ast.SelectStmt)
+	mvInfo.BaseSchema, mvInfo.BaseTable = tn.Schema, tn.Name
+	mvInfo.BaseKeys, mvInfo.KeyOffsets, mvInfo.Incremental = materializedViewKeys(sel, baseTable)
+	return mvInfo, nil
+}
+
+// MaterializedViewBaseTable returns the table if the SELECT statement of the
+// materialized view only reads the table, otherwise it returns nil.
+func MaterializedViewBaseTable(node ast.StmtNode) *ast.TableName {
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.From == nil || sel.With != nil || sel.From.TableRefs.Right != nil {
+		return nil
+	}
+	ts, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.AsOf != nil || tn.TableSample != nil {
+		return nil
+	}
+	if hasSubqueryOrWindow(sel) {
+		return nil
+	}
+	return tn
+}
+
+type subqueryOrWindowFinder struct {
+	found bool
+}
+
+func (f *subqueryOrWindowFinder) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.WindowFuncExpr:
+		f.found = true
+		return in, true
+	}
+	return in, false
+}
+
+func (f *subqueryOrWindowFinder) Leave(in ast.Node) (ast.Node, bool) {
+	return in, !f.found
+}
+
+func hasSubqueryOrWindow(sel *ast.SelectStmt) bool {
+	f := &subqueryOrWindowFinder{}
+	sel.Accept(f)
+	return f.found
+}
+
+// materializedViewKeys returns the columns grouping the base table and the
+// offsets of the view columns storing them. The view can be refreshed by
+// groups only if all the GROUP BY items are columns of the base table selected
+// by the view.
+func materializedViewKeys(sel *ast.SelectStmt, baseTable *model.TableInfo) (keys []ast.CIStr, offsets []int, ok bool) {
+	if sel.GroupBy == nil || sel.GroupBy.Rollup || sel.Distinct || sel.Limit != nil || len(sel.WindowSpecs) > 0 {
+		return nil, nil, false
+	}
+	fields := sel.Fields.Fields
+	for _, item := range sel.GroupBy.Items {
+		expr := item.Expr
+		switch x := expr.(type) {
+		case *ast.PositionExpr:
+			if x.P != nil || x.N < 1 || x.N > len(fields) {
+				return nil, nil, false
+			}
+			expr = fields[x.N-1].Expr
+		case *ast.ColumnNameExpr:
+			// The column of the table takes precedence over the alias in GROUP BY.
+			if model.FindColumnInfo(baseTable.Columns, x.Name.Name.L) == nil {
+				for _, f := range fields {
+					if f.AsName.L == x.Name.Name.L {
+						expr = f.Expr
+						break
+					}
+				}
+			}
+		}
+		colExpr, isCol := expr.(*ast.ColumnNameExpr)
+		if !isCol {
+			return nil, nil, false
+		}
+		col := model.FindColumnInfo(baseTable.Columns, colExpr.Name.Name.L)
+		if col == nil || !isMaterializedViewKeyType(&col.FieldType) {
+			return nil, nil, false
+		}
+		if slices.ContainsFunc(keys, func(k ast.CIStr) bool { return k.L == col.Name.L }) {
+			continue
+		}
+		offset := slices.IndexFunc(fields, func(f *ast.SelectField) bool {
+			c, isCol := f.Expr.(*ast.ColumnNameExpr)
+			return isCol && c.Name.Name.L == col.Name.L
+		})
+		if offset < 0 {
+			return nil, nil, false
+		}
+		keys = append(keys, col.Name)
+		offsets = append(offsets, offset)
+	}
+	return keys, offsets, true
+}
+
+// isMaterializedViewKeyType checks whether the values of the type can be
+// compared by `<=>` exactly after they're logged.
+func isMaterializedViewKeyType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear,
+		mysql.TypeFloat, mysql.TypeDouble, mysql.TypeNewDecimal,
+		mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp, mysql.TypeDuration:
+		return true
+	}
+	return false
+}
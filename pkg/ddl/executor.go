@@ -23,6 +23,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -58,6 +59,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/statistics"
 	"github.com/pingcap/tidb/pkg/statistics/handle"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/table/tables"
@@ -109,10 +111,12 @@ type Executor interface {
 	DropSchema(ctx sessionctx.Context, stmt *ast.DropDatabaseStmt) error
 	CreateTable(ctx sessionctx.Context, stmt *ast.CreateTableStmt) error
 	CreateView(ctx sessionctx.Context, stmt *ast.CreateViewStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
 	DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error)
 	RecoverTable(ctx sessionctx.Context, recoverTableInfo *model.RecoverTableInfo) (err error)
 	RecoverSchema(ctx sessionctx.Context, recoverSchemaInfo *model.RecoverSchemaInfo) error
 	DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error)
+	DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) (err error)
 	CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) error
 	DropIndex(ctx sessionctx.Context, stmt *ast.DropIndexStmt) error
 	AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast.AlterTableStmt) error
@@ -121,6 +125,12 @@ type Executor interface {
 	LockTables(ctx sessionctx.Context, stmt *ast.LockTablesStmt) error
 	UnlockTables(ctx sessionctx.Context, lockedTables []model.TableLockTpInfo) error
 	AlterTableMode(ctx sessionctx.Context, args *model.AlterTableModeArgs) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt, trigger *model.TriggerInfo) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	CreateChangefeed(ctx sessionctx.Context, stmt *ast.CreateChangefeedStmt, cf *model.ChangefeedInfo) error
+	DropChangefeed(ctx sessionctx.Context, stmt *ast.DropChangefeedStmt) error
+	CreateStatistics(ctx sessionctx.Context, stmt *ast.CreateStatisticsStmt) error
+	DropStatistics(ctx sessionctx.Context, stmt *ast.DropStatisticsStmt) error
 	CleanupTableLock(ctx sessionctx.Context, tables []*ast.TableName) error
 	UpdateTableReplicaInfo(ctx sessionctx.Context, physicalID int64, available bool) error
 	RepairTable(ctx sessionctx.Context, createStmt *ast.CreateTableStmt) error
@@ -1526,6 +1536,70 @@ func (e *executor) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt) (er
 	return e.CreateTableWithInfo(ctx, s.ViewName.Schema, tbInfo, nil, WithOnExist(onExist))
 }
 
+// mviewKeyIndexName is the name of the index on the group keys of a materialized view.
+const mviewKeyIndexName = "idx_mview_keys"
+
+// CreateMaterializedView creates a table to store the rows of the view, the
+// rows are filled by refreshing the view.
+func (e *executor) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) (err error) {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	if len(s.Cols) != len(s.ColTypes) {
+		return dbterror.ErrViewWrongList
+	}
+	var baseTable *model.TableInfo
+	if tn := MaterializedViewBaseTable(s.Select); tn != nil {
+		tbl, err := is.TableByName(e.ctx, tn.Schema, tn.Name)
+		if err != nil {
+			return err
+		}
+		if tbl.Meta().IsBaseTable() && tbl.Meta().TempTableType == model.TempTableNone {
+			baseTable = tbl.Meta()
+		}
+	}
+	mvInfo, err := BuildMaterializedViewInfo(s, baseTable)
+	if err != nil {
+		return err
+	}
+
+	createStmt := &ast.CreateTableStmt{
+		Table: &ast.TableName{Schema: s.ViewName.Schema, Name: s.ViewName.Name},
+		Cols:  make([]*ast.ColumnDef, len(s.Cols)),
+	}
+	for i, col := range s.Cols {
+		createStmt.Cols[i] = &ast.ColumnDef{Name: &ast.ColumnName{Name: col}, Tp: s.ColTypes[i].Clone()}
+	}
+	// Index the group keys to refresh the view incrementally.
+	var keys []*ast.IndexPartSpecification
+	for _, offset := range mvInfo.KeyOffsets {
+		if !types.IsTypeBlob(s.ColTypes[offset].GetType()) {
+			keys = append(keys, &ast.IndexPartSpecification{Column: &ast.ColumnName{Name: s.Cols[offset]}, Length: types.UnspecifiedLength})
+		}
+	}
+	if len(keys) > 0 {
+		createStmt.Constraints = append(createStmt.Constraints, &ast.Constraint{Tp: ast.ConstraintIndex, Name: mviewKeyIndexName, Keys: keys})
+	}
+
+	metaBuildCtx := NewMetaBuildContextWithSctx(ctx)
+	tbInfo, err := BuildTableInfoWithStmt(metaBuildCtx, createStmt, schema.Charset, schema.Collate, schema.PlacementPolicyRef)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkTableInfoValidWithStmt(metaBuildCtx, tbInfo, createStmt); err != nil {
+		return err
+	}
+	tbInfo.MaterializedView = mvInfo
+
+	onExist := OnExistError
+	if s.IfNotExists {
+		onExist = OnExistIgnore
+	}
+	return e.CreateTableWithInfo(ctx, schema.Name, tbInfo, nil, WithOnExist(onExist))
+}
+
 func checkCharsetAndCollation(cs string, co string) error {
 	if !charset.ValidCharsetAndCollation(cs, co) {
 		return dbterror.ErrUnknownCharacterSet.GenWithStackByArgs(cs)
@@ -1815,7 +1889,11 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 			case ast.ConstraintPrimaryKey:
 				err = e.CreatePrimaryKey(sctx, ident, ast.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = e.createIndex(sctx, ident, ast.IndexKeyTypeFullText, ast.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
+			case ast.ConstraintSpatial:
+				err = e.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, ast.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
 			case ast.ConstraintCheck:
 				if !vardef.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errCheckConstraintIsOff)
@@ -1891,10 +1969,12 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 				case ast.TableOptionEngineAttribute:
 					err = dbterror.ErrUnsupportedEngineAttribute
 				case ast.TableOptionRowFormat:
-				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+					ast.TableOptionTTLAction, ast.TableOptionTTLArchiveTarget:
 					var ttlInfo *model.TTLInfo
 					var ttlEnable *bool
 					var ttlJobInterval *string
+					var ttlAction, ttlArchiveTarget *string
 
 					if ttlOptionsHandled {
 						continue
@@ -1903,7 +1983,8 @@ func (e *executor) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt
 					if err != nil {
 						return err
 					}
-					err = e.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
+					ttlAction, ttlArchiveTarget = getTTLActionInOptions(spec.Options)
+					err = e.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval, ttlAction, ttlArchiveTarget)
 
 					ttlOptionsHandled = true
 				default:
@@ -3362,6 +3443,12 @@ func (e *executor) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *a
 	if oldColName.L == newColName.L {
 		return nil
 	}
+	if err = checkRenameColumnWithTTLCondition(tbl.Meta(), oldCol.Name); err != nil {
+		return err
+	}
+	if err = checkColumnWithIndexCondition(tbl.Meta(), oldCol.Name, false); err != nil {
+		return err
+	}
 	if newColName.L == model.ExtraHandleName.L {
 		return dbterror.ErrWrongColumnName.GenWithStackByArgs(newColName.L)
 	}
@@ -3702,8 +3789,11 @@ func (e *executor) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast
 // `.Enable`. If the `.TTLInfo` in the table info is empty, this function will return an error.
 // When `ttlInfo` is nil, and `ttlCronJobSchedule` is not, it will use the original `.TTLInfo` in the table info and modify the
 // `.JobInterval`. If the `.TTLInfo` in the table info is empty, this function will return an error.
+// When `ttlInfo` is nil, and `ttlAction` or `ttlArchiveTarget` is not, it will use the original `.TTLInfo` in the table
+// info and modify the `.Action` or `.ArchiveTarget`. The modified archive settings are validated before submitting the job.
 // When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`.
-func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string) error {
+func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string,
+	ttlAction *string, ttlArchiveTarget *string) error {
 	is := e.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -3721,6 +3811,16 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 
 	var job *model.Job
 	if ttlInfo != nil {
+		// the archive settings which are not set explicitly are kept in the job, so they should be checked together
+		if oldTTLInfo := tblInfo.TTLInfo; oldTTLInfo != nil {
+			ttlInfo = ttlInfo.Clone()
+			if ttlAction == nil {
+				ttlInfo.Action = oldTTLInfo.Action
+			}
+			if ttlArchiveTarget == nil {
+				ttlInfo.ArchiveTarget = oldTTLInfo.ArchiveTarget
+			}
+		}
 		tblInfo.TTLInfo = ttlInfo
 		err = checkTTLInfoValid(ident.Schema, tblInfo, is)
 		if err != nil {
@@ -3734,6 +3834,23 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 			if ttlCronJobSchedule != nil {
 				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
 			}
+			if ttlAction != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+			}
+			if ttlArchiveTarget != nil {
+				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TARGET"))
+			}
+		} else if ttlAction != nil || ttlArchiveTarget != nil {
+			tblInfo.TTLInfo = tblInfo.TTLInfo.Clone()
+			if ttlAction != nil {
+				tblInfo.TTLInfo.Action = *ttlAction
+			}
+			if ttlArchiveTarget != nil {
+				tblInfo.TTLInfo.ArchiveTarget = *ttlArchiveTarget
+			}
+			if err = checkTTLArchiveTarget(ident.Schema, tblInfo, is); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -3752,6 +3869,8 @@ func (e *executor) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.I
 		TTLInfo:            ttlInfo,
 		TTLEnable:          ttlEnable,
 		TTLCronJobSchedule: ttlCronJobSchedule,
+		TTLAction:          ttlAction,
+		TTLArchiveTarget:   ttlArchiveTarget,
 	}
 	err = e.doDDLJob2(ctx, job, args)
 	return errors.Trace(err)
@@ -3828,13 +3947,30 @@ func checkTiFlashReplicaCount(ctx sessionctx.Context, replicaCount uint64) error
 
 // AlterTableAddStatistics registers extended statistics for a table.
 func (e *executor) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, stats *ast.StatisticsSpec, ifNotExists bool) error {
+	return e.addExtendedStats(ctx, ident, stats.StatsName, []uint8{stats.StatsType}, stats.Columns, ifNotExists)
+}
+
+// CreateStatistics registers extended statistics for a table by CREATE STATISTICS.
+func (e *executor) CreateStatistics(ctx sessionctx.Context, stmt *ast.CreateStatisticsStmt) error {
+	ident := ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name}
+	return e.addExtendedStats(ctx, ident, stmt.StatsName, stmt.StatsTypes, stmt.Columns, stmt.IfNotExists)
+}
+
+// addExtendedStats registers extended statistics of the kinds for the columns. The correlation is kept
+// in its own type, while the other kinds are collected together as StatsTypeMultiColumn.
+func (e *executor) addExtendedStats(ctx sessionctx.Context, ident ast.Ident, statsName string, kinds []uint8, columns []*ast.ColumnName, ifNotExists bool) error {
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
+	statsType := ast.StatsTypeMultiColumn
+	if slices.Contains(kinds, ast.StatsTypeCorrelation) {
+		if len(kinds) > 1 {
+			return errors.New("Correlation statistics type can't be combined with other statistics types")
+		}
+		statsType = ast.StatsTypeCorrelation
 	}
+	slices.Sort(kinds)
+	kinds = slices.Compact(kinds)
 	_, tbl, err := e.getSchemaAndTableByIdent(ident)
 	if err != nil {
 		return err
@@ -3846,12 +3982,12 @@ func (e *executor) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ide
 	colIDs := make([]int64, 0, 2)
 	colIDSet := make(map[int64]struct{}, 2)
 	// Check whether columns exist.
-	for _, colName := range stats.Columns {
+	for _, colName := range columns {
 		col := table.FindCol(tbl.VisibleCols(), colName.Name.L)
 		if col == nil {
 			return infoschema.ErrColumnNotExists.GenWithStackByArgs(colName.Name, ident.Name)
 		}
-		if stats.StatsType == ast.StatsTypeCorrelation && tblInfo.PKIsHandle && mysql.HasPriKeyFlag(col.GetFlag()) {
+		if statsType == ast.StatsTypeCorrelation && tblInfo.PKIsHandle && mysql.HasPriKeyFlag(col.GetFlag()) {
 			ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackError("No need to create correlation statistics on the integer primary key column"))
 			return nil
 		}
@@ -3861,17 +3997,16 @@ func (e *executor) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ide
 		colIDSet[col.ID] = struct{}{}
 		colIDs = append(colIDs, col.ID)
 	}
-	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
+	if len(colIDs) != 2 && statsType == ast.StatsTypeCorrelation {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
-		return errors.New("Only support Cardinality statistics type on at least 2 columns")
+	if statsType == ast.StatsTypeMultiColumn && (len(colIDs) < 2 || len(colIDs) > statistics.MaxMultiColumnStatsColumns) {
+		return errors.Errorf("Only support Cardinality, Dependency and MCV statistics types on 2 to %d columns", statistics.MaxMultiColumnStatsColumns)
 	}
-	// TODO: check whether covering index exists for cardinality / dependency types.
 
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
-	return e.statsHandle.InsertExtendedStats(stats.StatsName, colIDs, int(stats.StatsType), tblInfo.ID, ifNotExists)
+	return e.statsHandle.InsertExtendedStats(statsName, colIDs, int(statsType), kinds, tblInfo.ID, ifNotExists)
 }
 
 // AlterTableDropStatistics logically deletes extended statistics for a table.
@@ -3889,6 +4024,41 @@ func (e *executor) AlterTableDropStatistics(ctx sessionctx.Context, ident ast.Id
 	return e.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists)
 }
 
+// DropStatistics logically deletes extended statistics by DROP STATISTICS. The name of extended statistics
+// is only unique in a table, so it's looked up in the tables of the current schema.
+func (e *executor) DropStatistics(ctx sessionctx.Context, stmt *ast.DropStatisticsStmt) error {
+	if !ctx.GetSessionVars().EnableExtendedStats {
+		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
+	}
+	schemaName := ast.NewCIStr(ctx.GetSessionVars().CurrentDB)
+	tblInfos, err := e.infoCache.GetLatest().SchemaTableInfos(e.ctx, schemaName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	rows, _, err := ctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(internalCtx, nil,
+		"SELECT table_id FROM mysql.stats_extended WHERE name = %? and status in (%?, %?)",
+		stmt.StatsName, statistics.ExtendedStatsInited, statistics.ExtendedStatsAnalyzed)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tableIDs := make([]int64, 0, 1)
+	for _, row := range rows {
+		tableID := row.GetInt64(0)
+		if slices.ContainsFunc(tblInfos, func(tblInfo *model.TableInfo) bool { return tblInfo.ID == tableID }) {
+			tableIDs = append(tableIDs, tableID)
+		}
+	}
+	switch len(tableIDs) {
+	case 0:
+		return errors.Errorf("extended statistics '%s' does not exist", stmt.StatsName)
+	case 1:
+		return e.statsHandle.MarkExtendedStatsDeleted(stmt.StatsName, tableIDs[0], false)
+	default:
+		return errors.Errorf("extended statistics '%s' exists in several tables, use ALTER TABLE ... DROP STATS_EXTENDED instead", stmt.StatsName)
+	}
+}
+
 // UpdateTableReplicaInfo updates the table flash replica infos.
 func (e *executor) UpdateTableReplicaInfo(ctx sessionctx.Context, physicalID int64, available bool) error {
 	is := e.infoCache.GetLatest()
@@ -4098,6 +4268,7 @@ const (
 	tableObject objectType = iota
 	viewObject
 	sequenceObject
+	materializedViewObject
 )
 
 // dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE.
@@ -4139,6 +4310,9 @@ func (e *executor) dropTableObject(
 	case sequenceObject:
 		dropExistErr = infoschema.ErrSequenceDropExists
 		jobType = model.ActionDropSequence
+	case materializedViewObject:
+		dropExistErr = infoschema.ErrTableDropExists
+		jobType = model.ActionDropTable
 	}
 	for _, tn := range objects {
 		fullti := ast.Ident{Schema: tn.Schema, Name: tn.Name}
@@ -4169,7 +4343,7 @@ func (e *executor) dropTableObject(
 		}
 		switch tableObjectType {
 		case tableObject:
-			if !tableInfo.Meta().IsBaseTable() {
+			if !tableInfo.Meta().IsBaseTable() || tableInfo.Meta().IsMaterializedView() {
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
@@ -4204,6 +4378,10 @@ func (e *executor) dropTableObject(
 				}
 				return err
 			}
+		case materializedViewObject:
+			if !tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "MATERIALIZED VIEW")
+			}
 		}
 
 		job := &model.Job{
@@ -4264,13 +4442,18 @@ func (e *executor) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (er
 	return e.dropTableObject(ctx, stmt.Tables, stmt.IfExists, viewObject)
 }
 
+// DropMaterializedView will proceed even if some view in the list does not exists.
+func (e *executor) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) (err error) {
+	return e.dropTableObject(ctx, stmt.Views, stmt.IfExists, materializedViewObject)
+}
+
 func (e *executor) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	schema, tb, err := e.getSchemaAndTableByIdent(ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
 	tblInfo := tb.Meta()
-	if tblInfo.IsView() || tblInfo.IsSequence() {
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.IsMaterializedView() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tblInfo.Name.O)
 	}
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
@@ -4585,6 +4768,9 @@ func (e *executor) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexN
 		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key is not supported. " +
 			"Please consider adding NONCLUSTERED primary key instead")
 	}
+	if err := checkIndexWithoutCondition(ast.NewCIStr(mysql.PrimaryKeyName), indexOption); err != nil {
+		return err
+	}
 	schema, t, err := e.getSchemaAndTableByIdent(ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -4746,6 +4932,9 @@ func checkTableTypeForColumnarIndex(tblInfo *model.TableInfo) error {
 
 func (e *executor) createColumnarIndex(ctx sessionctx.Context, ti ast.Ident, indexName ast.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool, columnarIndexType model.ColumnarIndexType) error {
+	if err := checkIndexWithoutCondition(indexName, indexOption); err != nil {
+		return err
+	}
 	schema, t, err := e.getSchemaAndTableByIdent(ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -4815,6 +5004,94 @@ func (e *executor) createColumnarIndex(ctx sessionctx.Context, ti ast.Ident, ind
 	return errors.Trace(err)
 }
 
+// createSearchIndex creates a spatial or full-text index.
+func (e *executor) createSearchIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName ast.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
+	fullText := keyType == ast.IndexKeyTypeFullText
+	if err := checkIndexWithoutCondition(indexName, indexOption); err != nil {
+		return err
+	}
+	schema, t, err := e.getSchemaAndTableByIdent(ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tblInfo := t.Meta()
+	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
+		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
+	}
+	// Check the columns first, the spatial and full-text indexes can't be built on expressions.
+	if fullText {
+		_, err = buildFullTextIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	} else {
+		_, err = buildSpatialIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	metaBuildCtx := NewMetaBuildContextWithSctx(ctx)
+	indexName, _, err = checkIndexNameAndColumns(metaBuildCtx, t, indexName, indexPartSpecifications, model.ColumnarIndexTypeNA, ifNotExists)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(indexName.L) == 0 {
+		// The index already exists and if_not_exists flag is true.
+		return nil
+	}
+
+	// May be truncate comment here, when index comment too long and sql_mode is't strict.
+	if indexOption != nil {
+		sessionVars := ctx.GetSessionVars()
+		if _, err = validateCommentLength(sessionVars.StmtCtx.ErrCtx(), sessionVars.SQLMode, indexName.String(), &indexOption.Comment, dbterror.ErrTooLongIndexComment); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// Check before the job is put to the queue.
+	if fullText {
+		_, err = BuildFullTextIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption, model.StatePublic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	} else {
+		idxInfo, err := BuildSpatialIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption, model.StatePublic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if warn := checkSpatialIndexUsable(tblInfo, idxInfo); warn != nil {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(warn)
+		}
+	}
+
+	job := buildAddIndexJobWithoutTypeAndArgs(ctx, schema, t)
+	job.Version = model.GetJobVerInUse()
+	job.Type = model.ActionAddIndex
+	job.CDCWriteSource = ctx.GetSessionVars().CDCWriteSource
+
+	err = initJobReorgMetaFromVariables(job, ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	args := &model.ModifyIndexArgs{
+		IndexArgs: []*model.IndexArg{{
+			IndexName:               indexName,
+			IndexPartSpecifications: indexPartSpecifications,
+			IndexOption:             indexOption,
+			Spatial:                 !fullText,
+			FullText:                fullText,
+		}},
+		OpType: model.OpAddIndex,
+	}
+
+	err = e.doDDLJob2(ctx, job, args)
+	// key exists, but if_not_exists flags is true, so we ignore this error.
+	if dbterror.ErrDupKeyName.Equal(err) && ifNotExists {
+		ctx.GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func buildAddIndexJobWithoutTypeAndArgs(ctx sessionctx.Context, schema *model.DBInfo, t table.Table) *model.Job {
 	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
 	job := &model.Job{
@@ -4861,10 +5138,9 @@ func (*executor) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableNa
 
 func (e *executor) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName ast.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
 	switch keyType {
 	case ast.IndexKeyTypeFullText, ast.IndexKeyTypeSpatial:
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+		return e.createSearchIndex(ctx, ti, keyType, indexName, indexPartSpecifications, indexOption, ifNotExists)
 	case ast.IndexKeyTypeColumnar:
 		return dbterror.ErrUnsupportedAddColumnarIndex.FastGenByArgs("not currently supported")
 		// return e.createColumnarIndex(ctx, ti, indexName, indexPartSpecifications, indexOption, ifNotExists, model.ColumnarIndexTypeInverted)
@@ -4905,6 +5181,15 @@ func (e *executor) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast
 		return err
 	}
 
+	var conditionExpr string
+	if indexOption != nil && indexOption.Condition != nil {
+		condIdxInfo := &model.IndexInfo{Name: indexName}
+		if err = buildIndexCondition(tblInfo, condIdxInfo, indexOption.Condition); err != nil {
+			return errors.Trace(err)
+		}
+		conditionExpr = condIdxInfo.ConditionExprString
+	}
+
 	// May be truncate comment here, when index comment too long and sql_mode is't strict.
 	if indexOption != nil {
 		sessionVars := ctx.GetSessionVars()
@@ -4950,6 +5235,7 @@ func (e *executor) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast
 			HiddenCols:              hiddenCols,
 			Global:                  global,
 			SplitOpt:                splitOpt,
+			ConditionExpr:           conditionExpr,
 		}},
 		OpType: model.OpAddIndex,
 	}
@@ -5745,6 +6031,196 @@ func (e *executor) AlterTableMode(sctx sessionctx.Context, args *model.AlterTabl
 	return errors.Trace(err)
 }
 
+// CreateTrigger creates a trigger on the table, the trigger is stored in the table info.
+func (e *executor) CreateTrigger(sctx sessionctx.Context, stmt *ast.CreateTriggerStmt, trigger *model.TriggerInfo) error {
+	if stmt.Trigger.Schema.L != "" && stmt.Trigger.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema.GenWithStackByArgs()
+	}
+	schema, t, err := e.getSchemaAndTableByIdent(ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrNoTriggersOnSystemSchema.GenWithStackByArgs()
+	}
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.IsMaterializedView() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(schema.Name.O + "." + tblInfo.Name.O)
+	}
+	// Trigger names are unique in the schema.
+	tblInfos, err := e.infoCache.GetLatest().SchemaTableInfos(e.ctx, schema.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, info := range tblInfos {
+		if info.FindTrigger(trigger.Name.L) < 0 {
+			continue
+		}
+		err = dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		if stmt.IfNotExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	args := &model.CreateTriggerArgs{Trigger: trigger, IfNotExists: stmt.IfNotExists}
+	if stmt.Order != nil {
+		args.Order, args.Precedes = stmt.Order.OtherTrigger, stmt.Order.Precedes
+		if _, err = triggerPosition(tblInfo, trigger, args.Order, args.Precedes); err != nil {
+			return err
+		}
+	}
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		TableID:        tblInfo.ID,
+		Type:           model.ActionCreateTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        sctx.GetSessionVars().SQLMode,
+	}
+	err = e.doDDLJob2(sctx, job, args)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops the trigger, the table of the trigger is found by the trigger name.
+func (e *executor) DropTrigger(sctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.Trigger.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Trigger.Schema)
+	}
+	tblInfos, err := is.SchemaTableInfos(e.ctx, schema.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var tblInfo *model.TableInfo
+	for _, info := range tblInfos {
+		if info.FindTrigger(stmt.Trigger.Name.L) >= 0 {
+			tblInfo = info
+			break
+		}
+	}
+	if tblInfo == nil {
+		err = dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+		if stmt.IfExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		TableID:        tblInfo.ID,
+		Type:           model.ActionDropTrigger,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        sctx.GetSessionVars().SQLMode,
+	}
+	args := &model.DropTriggerArgs{Name: stmt.Trigger.Name, IfExists: stmt.IfExists}
+	err = e.doDDLJob2(sctx, job, args)
+	return errors.Trace(err)
+}
+
+// CreateChangefeed creates a changefeed of the table, the changefeed is stored in the table info.
+func (e *executor) CreateChangefeed(sctx sessionctx.Context, stmt *ast.CreateChangefeedStmt, cf *model.ChangefeedInfo) error {
+	if stmt.Changefeed.Schema.L != "" && stmt.Changefeed.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrInvalidChangefeed.GenWithStackByArgs(cf.Name.O, "the changefeed must be in the schema of the table")
+	}
+	schema, t, err := e.getSchemaAndTableByIdent(ast.Ident{Schema: stmt.Table.Schema, Name: stmt.Table.Name})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrInvalidChangefeed.GenWithStackByArgs(cf.Name.O, "the table is a system table")
+	}
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrInvalidChangefeed.GenWithStackByArgs(cf.Name.O, "the table is a view, a sequence or a temporary table")
+	}
+	// Changefeed names are unique in the schema.
+	tblInfos, err := e.infoCache.GetLatest().SchemaTableInfos(e.ctx, schema.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, info := range tblInfos {
+		if info.FindChangefeed(cf.Name.L) < 0 {
+			continue
+		}
+		err = dbterror.ErrChangefeedExists.GenWithStackByArgs(cf.Name.O)
+		if stmt.IfNotExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		TableID:        tblInfo.ID,
+		Type:           model.ActionCreateChangefeed,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        sctx.GetSessionVars().SQLMode,
+	}
+	args := &model.CreateChangefeedArgs{Changefeed: cf, IfNotExists: stmt.IfNotExists}
+	err = e.doDDLJob2(sctx, job, args)
+	return errors.Trace(err)
+}
+
+// DropChangefeed drops the changefeed, the table of the changefeed is found by the changefeed name.
+func (e *executor) DropChangefeed(sctx sessionctx.Context, stmt *ast.DropChangefeedStmt) error {
+	is := e.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(stmt.Changefeed.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Changefeed.Schema)
+	}
+	tblInfos, err := is.SchemaTableInfos(e.ctx, schema.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var tblInfo *model.TableInfo
+	for _, info := range tblInfos {
+		if info.FindChangefeed(stmt.Changefeed.Name.L) >= 0 {
+			tblInfo = info
+			break
+		}
+	}
+	if tblInfo == nil {
+		err = dbterror.ErrChangefeedNotExists.GenWithStackByArgs(stmt.Changefeed.Name.O)
+		if stmt.IfExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		Version:        model.GetJobVerInUse(),
+		SchemaID:       schema.ID,
+		SchemaName:     schema.Name.L,
+		TableName:      tblInfo.Name.L,
+		TableID:        tblInfo.ID,
+		Type:           model.ActionDropChangefeed,
+		BinlogInfo:     &model.HistoryInfo{},
+		CDCWriteSource: sctx.GetSessionVars().CDCWriteSource,
+		SQLMode:        sctx.GetSessionVars().SQLMode,
+	}
+	args := &model.DropChangefeedArgs{Name: stmt.Changefeed.Name, IfExists: stmt.IfExists}
+	err = e.doDDLJob2(sctx, job, args)
+	return errors.Trace(err)
+}
+
 func throwErrIfInMemOrSysDB(ctx sessionctx.Context, dbLowerName string) error {
 	if util.IsMemOrSysDB(dbLowerName) {
 		if ctx.GetSessionVars().User != nil {
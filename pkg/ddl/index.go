@@ -46,6 +46,8 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errctx"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/expression/exprstatic"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/lightning/backend"
@@ -57,6 +59,7 @@ import (
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx"
@@ -97,6 +100,9 @@ func buildIndexColumns(ctx *metabuild.Context, columns []*model.ColumnInfo, inde
 	var col *model.ColumnInfo
 	var mvIndex bool
 	maxIndexLength := config.GetGlobalConfig().MaxIndexLength
+	// The descending key parts are encoded with a datum flag that only TiDB and unistore can decode,
+	// TiKV, TiFlash and the tools can't read them yet, so they are rejected on TiKV.
+	descSupported := config.GetGlobalConfig().Store != config.StoreTypeTiKV
 	// The sum of length of all index columns.
 	sumLength := 0
 	for _, ip := range indexPartSpecifications {
@@ -116,8 +122,14 @@ func buildIndexColumns(ctx *metabuild.Context, columns []*model.ColumnInfo, inde
 			if mvIndex {
 				return nil, false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("more than one multi-valued key part per index")
 			}
+			if ip.Desc {
+				return nil, false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("descending multi-valued key part")
+			}
 			mvIndex = true
 		}
+		if ip.Desc && !descSupported && columnarIndexType == model.ColumnarIndexTypeNA {
+			return nil, false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("descending index key part on TiKV")
+		}
 		indexColLen := ip.Length
 		if indexColLen != types.UnspecifiedLength &&
 			types.IsTypeChar(col.FieldType.GetType()) &&
@@ -152,6 +164,8 @@ func buildIndexColumns(ctx *metabuild.Context, columns []*model.ColumnInfo, inde
 			Name:   col.Name,
 			Offset: col.Offset,
 			Length: indexColLen,
+			// The order of columnar indexes is decided by the storage engine.
+			Desc: ip.Desc && columnarIndexType == model.ColumnarIndexTypeNA,
 		})
 	}
 
@@ -222,6 +236,14 @@ func checkIndexColumn(col *model.ColumnInfo, indexColumnLen int, suppressTooLong
 		return errors.Trace(dbterror.ErrJSONUsedAsKey.GenWithStackByArgs(col.Name.O))
 	}
 
+	// Geometry column cannot be used in a normal index.
+	if col.FieldType.GetType() == mysql.TypeGeometry {
+		if col.Hidden {
+			return dbterror.ErrFunctionalIndexOnJSONOrGeometryFunction
+		}
+		return errors.Trace(dbterror.ErrBlobKeyWithoutLength.GenWithStackByArgs(col.Name.O))
+	}
+
 	// Vector column cannot index, for now.
 	if col.FieldType.GetType() == mysql.TypeTiDBVectorFloat32 {
 		if col.Hidden {
@@ -364,6 +386,12 @@ func BuildIndexInfo(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if isPrimary && tblInfo.IsCommonHandle {
+		// The clustered index is encoded as the row key, which is always in ascending order.
+		for _, ic := range idxInfo.Columns {
+			ic.Desc = false
+		}
+	}
 
 	if indexOption != nil {
 		idxInfo.Comment = indexOption.Comment
@@ -379,6 +407,23 @@ func BuildIndexInfo(
 			idxInfo.Tp = indexOption.Tp
 		}
 		idxInfo.Global = indexOption.Global
+		if indexOption.Condition != nil {
+			if isPrimary || columnarIndexType != model.ColumnarIndexTypeNA {
+				return nil, dbterror.ErrInvalidPartialIndex.GenWithStackByArgs(indexName.O, "only secondary indexes can have a condition")
+			}
+			if err = buildIndexCondition(tblInfo, idxInfo, indexOption.Condition); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if len(indexOption.IncludeColumns) > 0 {
+			if isPrimary || columnarIndexType != model.ColumnarIndexTypeNA || idxInfo.MVIndex {
+				return nil, dbterror.ErrInvalidIncludeColumn.GenWithStackByArgs(indexOption.IncludeColumns[0].Name.O,
+					indexName.O, "only secondary indexes can include columns")
+			}
+			if idxInfo.IncludeColumns, err = buildIncludeColumns(tblInfo, idxInfo, indexOption.IncludeColumns); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 	} else {
 		// Use btree as default index type.
 		idxInfo.Tp = ast.IndexTypeBtree
@@ -387,6 +432,289 @@ func BuildIndexInfo(
 	return idxInfo, nil
 }
 
+// buildIndexCondition validates the `WHERE` predicate of a partial index and
+// records it, together with the columns it depends on, in idxInfo.
+func buildIndexCondition(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, cond ast.ExprNode) error {
+	var c illegalFunctionChecker
+	cond.Accept(&c)
+	if c.hasIllegalFunc || c.hasAggFunc || c.hasWindowFunc || c.hasCastArrayFunc {
+		return dbterror.ErrInvalidPartialIndex.GenWithStackByArgs(idxInfo.Name.O,
+			"the condition must be deterministic and can't contain subqueries, variables or aggregate functions")
+	}
+	if c.otherErr != nil {
+		return errors.Trace(c.otherErr)
+	}
+
+	colNames := FindColumnNamesInExpr(cond)
+	condCols := make([]ast.CIStr, 0, len(colNames))
+	for _, colName := range colNames {
+		col := model.FindColumnInfo(tblInfo.Columns, colName.Name.L)
+		if col == nil || col.Hidden {
+			return infoschema.ErrColumnNotExists.GenWithStackByArgs(colName.Name, tblInfo.Name)
+		}
+		if !slices.ContainsFunc(condCols, func(name ast.CIStr) bool { return name.L == col.Name.L }) {
+			condCols = append(condCols, col.Name)
+		}
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+	if err := cond.Restore(format.NewRestoreCtx(restoreFlags, &sb)); err != nil {
+		return errors.Trace(err)
+	}
+	// Make sure the condition can be evaluated against the rows of the table.
+	if _, err := expression.ParseSimpleExpr(exprstatic.NewExprContext(), sb.String(), expression.WithTableInfo("", tblInfo)); err != nil {
+		return dbterror.ErrInvalidPartialIndex.GenWithStackByArgs(idxInfo.Name.O, err.Error())
+	}
+	idxInfo.ConditionExprString = sb.String()
+	idxInfo.ConditionColumns = condCols
+	return nil
+}
+
+// buildIncludeColumns builds the included columns of an index. Their values are
+// stored in the index value rather than the index key, so they can't be the key
+// columns of the index.
+func buildIncludeColumns(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, colNames []*ast.ColumnName) ([]*model.IndexColumn, error) {
+	includeCols := make([]*model.IndexColumn, 0, len(colNames))
+	for _, colName := range colNames {
+		col := model.FindColumnInfo(tblInfo.Columns, colName.Name.L)
+		if col == nil || col.Hidden {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStackByArgs(colName.Name)
+		}
+		if idxInfo.FindColumnByName(col.Name.L) != nil {
+			return nil, dbterror.ErrInvalidIncludeColumn.GenWithStackByArgs(col.Name.O, idxInfo.Name.O,
+				"it is already a key column of the index")
+		}
+		if slices.ContainsFunc(includeCols, func(ic *model.IndexColumn) bool { return ic.Name.L == col.Name.L }) {
+			return nil, dbterror.ErrInvalidIncludeColumn.GenWithStackByArgs(col.Name.O, idxInfo.Name.O,
+				"it is included more than once")
+		}
+		includeCols = append(includeCols, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return includeCols, nil
+}
+
+// checkColumnWithIndexInclude checks whether the column is included by an index.
+// The included columns are encoded in the index values, so such a column can't be
+// dropped or have its data changed.
+func checkColumnWithIndexInclude(tblInfo *model.TableInfo, colName ast.CIStr) error {
+	for _, idx := range tblInfo.Indices {
+		if idx.FindIncludeColumnByName(colName.L) != nil {
+			return dbterror.ErrDependentByIncludeColumn.GenWithStackByArgs(colName.O, idx.Name.O)
+		}
+	}
+	return nil
+}
+
+// checkColumnWithIndexCondition checks whether the column is referenced by the
+// condition of a partial index. The condition is stored as a string, so such a
+// column can't be dropped or renamed. When dropping, the single-column indexes
+// dropped together with the column are ignored.
+func checkColumnWithIndexCondition(tblInfo *model.TableInfo, colName ast.CIStr, dropping bool) error {
+	for _, idx := range tblInfo.Indices {
+		if dropping && len(idx.Columns) == 1 && idx.Columns[0].Name.L == colName.L {
+			continue
+		}
+		for _, name := range idx.ConditionColumns {
+			if name.L == colName.L {
+				return dbterror.ErrDependentByPartialIndex.GenWithStackByArgs(colName.O)
+			}
+		}
+	}
+	return nil
+}
+
+// checkIndexWithoutCondition returns an error if the index, which can't be a
+// partial or covering index, is given a `WHERE` predicate or `INCLUDE` columns.
+func checkIndexWithoutCondition(indexName ast.CIStr, indexOption *ast.IndexOption) error {
+	if indexOption != nil && indexOption.Condition != nil {
+		return dbterror.ErrInvalidPartialIndex.GenWithStackByArgs(indexName.O, "only secondary indexes can have a condition")
+	}
+	if indexOption != nil && len(indexOption.IncludeColumns) > 0 {
+		return dbterror.ErrInvalidIncludeColumn.GenWithStackByArgs(indexOption.IncludeColumns[0].Name.O,
+			indexName.O, "only secondary indexes can include columns")
+	}
+	return nil
+}
+
+// checkSpatialIndexColumn checks whether the column can be used in a spatial index.
+func checkSpatialIndexColumn(col *model.ColumnInfo) error {
+	if col.FieldType.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrSpatialMustHaveGeomCol
+	}
+	if !mysql.HasNotNullFlag(col.GetFlag()) {
+		return dbterror.ErrSpatialCantHaveNull
+	}
+	return nil
+}
+
+// buildSpatialIndexColumns builds the index column of the spatial index, which
+// must be a single NOT NULL geometry column.
+func buildSpatialIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	if len(indexPartSpecifications) != 1 {
+		return nil, infoschema.ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Expr != nil {
+		return nil, dbterror.ErrSpatialFunctionalIndex
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if err := checkSpatialIndexColumn(col); err != nil {
+		return nil, err
+	}
+	if ip.Length != types.UnspecifiedLength {
+		return nil, errors.Trace(dbterror.ErrIncorrectPrefixKey)
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
+// BuildSpatialIndexInfo builds a new IndexInfo of the spatial index.
+func BuildSpatialIndexInfo(
+	tblInfo *model.TableInfo,
+	indexName ast.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+	state model.SchemaState,
+) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := checkIndexWithoutCondition(indexName, indexOption); err != nil {
+		return nil, err
+	}
+	idxInfo := &model.IndexInfo{
+		Name:    indexName,
+		State:   state,
+		Tp:      ast.IndexTypeRtree,
+		Spatial: true,
+	}
+	var err error
+	idxInfo.Columns, err = buildSpatialIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if indexOption != nil {
+		if indexOption.Tp != ast.IndexTypeInvalid && indexOption.Tp != ast.IndexTypeRtree {
+			return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("SPATIAL index only supports RTREE index type")
+		}
+		if indexOption.Global {
+			return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("create a global spatial index")
+		}
+		idxInfo.Comment = indexOption.Comment
+		idxInfo.Invisible = indexOption.Visibility == ast.IndexVisibilityInvisible
+	}
+	return idxInfo, nil
+}
+
+// checkSpatialIndexUsable returns a warning if the spatial index can't be used
+// by the optimizer, which only uses the spatial index on a column with an SRID
+// attribute.
+func checkSpatialIndexUsable(tblInfo *model.TableInfo, idxInfo *model.IndexInfo) error {
+	col := tblInfo.Columns[idxInfo.Columns[0].Offset]
+	if _, ok := col.GetSRID(); !ok {
+		return dbterror.ErrSpatialUselessIndex.FastGenByArgs(col.Name.O)
+	}
+	return nil
+}
+
+// checkFullTextIndexColumn checks whether the column can be used in a full-text index.
+func checkFullTextIndexColumn(col *model.ColumnInfo) error {
+	switch col.GetType() {
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString,
+		mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if col.GetCharset() != charset.CharsetBin {
+			return nil
+		}
+	}
+	return dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+}
+
+// buildFullTextIndexColumns builds the index columns of the full-text index,
+// which must be non-binary string columns.
+func buildFullTextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxCols := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrFulltextFunctionalIndex
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if err := checkFullTextIndexColumn(col); err != nil {
+			return nil, err
+		}
+		if ip.Length != types.UnspecifiedLength {
+			return nil, errors.Trace(dbterror.ErrIncorrectPrefixKey)
+		}
+		idxCols = append(idxCols, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxCols, nil
+}
+
+// BuildFullTextIndexInfo builds a new IndexInfo of the full-text index.
+func BuildFullTextIndexInfo(
+	tblInfo *model.TableInfo,
+	indexName ast.CIStr,
+	indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption,
+	state model.SchemaState,
+) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tblInfo.Partition != nil {
+		return nil, dbterror.ErrFulltextNotSupportedWithPartitioning
+	}
+	if err := checkIndexWithoutCondition(indexName, indexOption); err != nil {
+		return nil, err
+	}
+	idxInfo := &model.IndexInfo{
+		Name:         indexName,
+		State:        state,
+		FullTextInfo: &model.FullTextIndexInfo{ParserType: model.FullTextParserTypeStandard},
+	}
+	var err error
+	idxInfo.Columns, err = buildFullTextIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if indexOption != nil {
+		if indexOption.Tp != ast.IndexTypeInvalid {
+			return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT index doesn't support %s index type", indexOption.Tp)
+		}
+		switch indexOption.ParserName.L {
+		case "":
+		case string(model.FullTextParserTypeNgram):
+			idxInfo.FullTextInfo.ParserType = model.FullTextParserTypeNgram
+		default:
+			return nil, dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT parser %s is not supported", indexOption.ParserName.O)
+		}
+		if indexOption.Global {
+			return nil, dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("create a global full-text index")
+		}
+		idxInfo.Comment = indexOption.Comment
+		idxInfo.Invisible = indexOption.Visibility == ast.IndexVisibilityInvisible
+	}
+	return idxInfo, nil
+}
+
 func buildVectorInfoWithCheck(indexPartSpecifications []*ast.IndexPartSpecification,
 	tblInfo *model.TableInfo) (*model.VectorIndexInfo, string, error) {
 	if len(indexPartSpecifications) != 1 {
@@ -687,17 +1015,38 @@ func checkAndBuildIndexInfo(
 	if err = checkAddColumnTooManyColumns(len(tblInfo.Columns)); err != nil {
 		return nil, errors.Trace(err)
 	}
-	indexInfo, err = BuildIndexInfo(
-		nil,
-		tblInfo,
-		args.IndexName,
-		isPK,
-		args.Unique,
-		columnarIndexType,
-		args.IndexPartSpecifications,
-		args.IndexOption,
-		model.StateNone,
-	)
+	indexOption := args.IndexOption
+	if args.ConditionExpr != "" {
+		// The condition expression can't be marshaled into the job arguments,
+		// so it is carried as a string and parsed back here.
+		cond, err := generatedexpr.ParseExpression(args.ConditionExpr)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		opt := ast.IndexOption{}
+		if indexOption != nil {
+			opt = *indexOption
+		}
+		opt.Condition = cond
+		indexOption = &opt
+	}
+	if args.Spatial {
+		indexInfo, err = BuildSpatialIndexInfo(tblInfo, args.IndexName, args.IndexPartSpecifications, indexOption, model.StateNone)
+	} else if args.FullText {
+		indexInfo, err = BuildFullTextIndexInfo(tblInfo, args.IndexName, args.IndexPartSpecifications, indexOption, model.StateNone)
+	} else {
+		indexInfo, err = BuildIndexInfo(
+			nil,
+			tblInfo,
+			args.IndexName,
+			isPK,
+			args.Unique,
+			columnarIndexType,
+			args.IndexPartSpecifications,
+			indexOption,
+			model.StateNone,
+		)
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -1952,9 +2301,14 @@ func (w *baseIndexWorker) getIndexRecord(idxInfo *model.IndexInfo, handle kv.Han
 			}
 		}
 	})
-	idxVal := make([]types.Datum, len(idxInfo.Columns))
+	idxCols := idxInfo.Columns
+	if idxInfo.HasIncludeColumns() {
+		// The values of the included columns follow the values of the index columns.
+		idxCols = append(slices.Clip(idxCols), idxInfo.IncludeColumns...)
+	}
+	idxVal := make([]types.Datum, len(idxCols))
 	var err error
-	for j, v := range idxInfo.Columns {
+	for j, v := range idxCols {
 		col := cols[v.Offset]
 		idxColumnVal, ok := w.rowMap[col.ID]
 		if ok {
@@ -1974,6 +2328,25 @@ func (w *baseIndexWorker) getIndexRecord(idxInfo *model.IndexInfo, handle kv.Han
 	return idxRecord, nil
 }
 
+// meetPartialCondition checks whether the row decoded into w.rowMap satisfies
+// the condition of the partial index.
+func (w *baseIndexWorker) meetPartialCondition(index table.Index) (bool, error) {
+	cols := w.table.WritableCols()
+	row := make([]types.Datum, len(w.table.Meta().Columns))
+	for _, col := range cols {
+		val, ok := w.rowMap[col.ID]
+		if !ok {
+			var err error
+			val, err = tables.GetColDefaultValue(w.exprCtx, col, w.defaultVals)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+		}
+		row[col.Offset] = val
+	}
+	return index.MeetPartialCondition(w.exprCtx.GetEvalCtx(), row)
+}
+
 func (w *baseIndexWorker) cleanRowMap() {
 	for id := range w.rowMap {
 		delete(w.rowMap, id)
@@ -2033,6 +2406,15 @@ func (w *baseIndexWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBac
 				if err1 != nil {
 					return false, errors.Trace(err1)
 				}
+				if index.Meta().HasCondition() {
+					meet, err1 := w.meetPartialCondition(index)
+					if err1 != nil {
+						return false, errors.Trace(err1)
+					}
+					// The row is not covered by the partial index. Keep the record as a
+					// placeholder so that `idxRecords[i]` still belongs to `indexes[i%len(indexes)]`.
+					idxRecord.skip = !meet
+				}
 				w.idxRecords = append(w.idxRecords, idxRecord)
 			}
 			// If there are generated column, rowDecoder will use column value that not in idxInfo.Columns to calculate
@@ -2090,9 +2472,9 @@ func (w *addIndexTxnWorker) batchCheckUniqueKey(txn kv.Transaction, idxRecords [
 	cnt := 0
 	for i, record := range idxRecords {
 		idx := w.indexes[i%len(w.indexes)]
-		if !idx.Meta().Unique {
-			// non-unique key need not to check, use `nil` as a placeholder to keep
-			// `idxRecords[i]` belonging to `indexes[i%len(indexes)]`.
+		if !idx.Meta().Unique || record.skip {
+			// non-unique key and the row not covered by a partial index need not to check,
+			// use `nil` as a placeholder to keep `idxRecords[i]` belonging to `indexes[i%len(indexes)]`.
 			w.batchCheckKeys = append(w.batchCheckKeys, nil)
 			w.batchCheckValues = append(w.batchCheckValues, nil)
 			w.distinctCheckFlags = append(w.distinctCheckFlags, false)
@@ -2219,6 +2601,12 @@ func writeChunkToLocal(
 	if restore {
 		restoreDataBuf = make([]types.Datum, len(c.HandleOutputOffsets))
 	}
+	// condRow holds the columns referenced by the conditions of partial indexes,
+	// laid out by the column offsets of the table.
+	var condRow []types.Datum
+	if slices.ContainsFunc(indexes, func(idx table.Index) bool { return idx.Meta().HasCondition() }) {
+		condRow = make([]types.Datum, len(c.TableInfo.Columns))
+	}
 	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
 		handleDataBuf := ExtractDatumByOffsets(ectx, row, c.HandleOutputOffsets, c.ExprColumnInfos, handleDataBuf)
 		if restore {
@@ -2231,11 +2619,25 @@ func writeChunkToLocal(
 		if err != nil {
 			return 0, nil, errors.Trace(err)
 		}
+		for j, col := range c.ColumnInfos {
+			if condRow != nil && col.ID != model.ExtraHandleID {
+				condRow[col.Offset] = row.GetDatum(j, c.FieldTypes[j])
+			}
+		}
 		for i, index := range indexes {
+			if index.Meta().HasCondition() {
+				meet, err := index.MeetPartialCondition(ectx, condRow)
+				if err != nil {
+					return 0, nil, errors.Trace(err)
+				}
+				if !meet {
+					continue
+				}
+			}
 			idxID := index.Meta().ID
 			idxDataBuf = ExtractDatumByOffsets(ectx,
 				row, copCtx.IndexColumnOutputOffsets(idxID), c.ExprColumnInfos, idxDataBuf)
-			idxData := idxDataBuf[:len(index.Meta().Columns)]
+			idxData := idxDataBuf[:len(index.Meta().Columns)+len(index.Meta().IncludeColumns)]
 			var rsData []types.Datum
 			if needRestoreForIndexes[i] {
 				rsData = getRestoreData(c.TableInfo, copCtx.IndexInfo(idxID), c.PrimaryKeyInfo, restoreDataBuf)
@@ -2254,7 +2656,7 @@ func writeChunkToLocal(
 func maxIndexColumnCount(indexes []table.Index) int {
 	maxCnt := 0
 	for _, idx := range indexes {
-		colCnt := len(idx.Meta().Columns)
+		colCnt := len(idx.Meta().Columns) + len(idx.Meta().IncludeColumns)
 		if colCnt > maxCnt {
 			maxCnt = colCnt
 		}
@@ -967,6 +967,14 @@ func (w *worker) runOneJobStep(
 		ver, err = onUnlockTables(jobCtx, job)
 	case model.ActionAlterTableMode:
 		ver, err = onAlterTableMode(jobCtx, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(jobCtx, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(jobCtx, job)
+	case model.ActionCreateChangefeed:
+		ver, err = onCreateChangefeed(jobCtx, job)
+	case model.ActionDropChangefeed:
+		ver, err = onDropChangefeed(jobCtx, job)
 	case model.ActionSetTiFlashReplica:
 		ver, err = w.onSetTableFlashReplica(jobCtx, job)
 	case model.ActionUpdateTiFlashReplicaStatus:
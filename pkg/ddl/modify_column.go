@@ -736,6 +736,12 @@ func GetModifiableColumnJob(
 
 	// If we want to rename the column name, we need to check whether it already exists.
 	if newColName.L != originalColName.L {
+		if err = checkRenameColumnWithTTLCondition(t.Meta(), originalColName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err = checkColumnWithIndexCondition(t.Meta(), originalColName, false); err != nil {
+			return nil, errors.Trace(err)
+		}
 		c := table.FindCol(t.Cols(), newColName.L)
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
@@ -815,6 +821,9 @@ func GetModifiableColumnJob(
 		if hasColumnarIndexColumn(t.Meta(), col.ColumnInfo) {
 			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("vector indexes on the column")
 		}
+		if err = checkColumnWithIndexInclude(t.Meta(), col.Name); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	// Check that the column change does not affect the partitioning column
@@ -1025,6 +1034,12 @@ func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 			}
 		case mysql.TypeTiDBVectorFloat32:
 			return newCol.GetFlen() != types.UnspecifiedLength && oldCol.GetFlen() != newCol.GetFlen()
+		case mysql.TypeGeometry:
+			// The existing geometries must be checked against the new subtype and SRID.
+			oldSRID, oldHasSRID := oldCol.GetSRID()
+			newSRID, newHasSRID := newCol.GetSRID()
+			return (newCol.GetGeometryType() != types.GeometryTypeGeometry && oldCol.GetGeometryType() != newCol.GetGeometryType()) ||
+				(newHasSRID && (!oldHasSRID || oldSRID != newSRID))
 		}
 
 		return needTruncationOrToggleSign()
@@ -1134,6 +1149,12 @@ func checkColumnWithIndexConstraint(tbInfo *model.TableInfo, originalCol, newCol
 		if !modified {
 			return
 		}
+		if indexInfo.Spatial {
+			return checkSpatialIndexColumn(newCol)
+		}
+		if indexInfo.FullTextInfo != nil {
+			return checkFullTextIndexColumn(newCol)
+		}
 		err = checkIndexInModifiableColumns(columns, indexInfo.Columns, indexInfo.GetColumnarIndexType())
 		if err != nil {
 			return
@@ -1275,6 +1296,10 @@ func ProcessModifyColumnOptions(ctx sessionctx.Context, col *table.Column, optio
 			}
 		case ast.ColumnOptionCollate:
 			col.SetCollate(opt.StrValue)
+		case ast.ColumnOptionSRID:
+			if err = setColumnSRID(col, opt); err != nil {
+				return errors.Trace(err)
+			}
 		case ast.ColumnOptionReference:
 			return errors.Trace(dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("can't modify with references"))
 		case ast.ColumnOptionFulltext:
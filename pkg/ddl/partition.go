@@ -505,6 +505,11 @@ func buildTablePartitionInfo(ctx *metabuild.Context, s *ast.PartitionOptions, tb
 	if s == nil {
 		return nil
 	}
+	for _, idx := range tbInfo.Indices {
+		if idx.FullTextInfo != nil {
+			return dbterror.ErrFulltextNotSupportedWithPartitioning
+		}
+	}
 
 	var enable bool
 	switch s.Tp {
@@ -404,6 +404,46 @@ func (d *Checker) AlterTableMode(ctx sessionctx.Context, args *model.AlterTableM
 	return d.realExecutor.AlterTableMode(ctx, args)
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	return d.realExecutor.CreateMaterializedView(ctx, stmt)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	return d.realExecutor.DropMaterializedView(ctx, stmt)
+}
+
+// CreateTrigger implements the DDL interface.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt, trigger *model.TriggerInfo) error {
+	return d.realExecutor.CreateTrigger(ctx, stmt, trigger)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realExecutor.DropTrigger(ctx, stmt)
+}
+
+// CreateChangefeed implements the DDL interface.
+func (d *Checker) CreateChangefeed(ctx sessionctx.Context, stmt *ast.CreateChangefeedStmt, cf *model.ChangefeedInfo) error {
+	return d.realExecutor.CreateChangefeed(ctx, stmt, cf)
+}
+
+// DropChangefeed implements the DDL interface.
+func (d *Checker) DropChangefeed(ctx sessionctx.Context, stmt *ast.DropChangefeedStmt) error {
+	return d.realExecutor.DropChangefeed(ctx, stmt)
+}
+
+// CreateStatistics implements the DDL interface.
+func (d *Checker) CreateStatistics(ctx sessionctx.Context, stmt *ast.CreateStatisticsStmt) error {
+	return d.realExecutor.CreateStatistics(ctx, stmt)
+}
+
+// DropStatistics implements the DDL interface.
+func (d *Checker) DropStatistics(ctx sessionctx.Context, stmt *ast.DropStatisticsStmt) error {
+	return d.realExecutor.DropStatistics(ctx, stmt)
+}
+
 // CleanupTableLock implements the DDL interface.
 func (d *Checker) CleanupTableLock(ctx sessionctx.Context, tables []*ast.TableName) error {
 	return d.realExecutor.CleanupTableLock(ctx, tables)
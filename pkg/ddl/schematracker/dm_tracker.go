@@ -411,6 +411,21 @@ func (d *SchemaTracker) createIndex(
 		return dbterror.ErrDupKeyName.GenWithStack("index already exist %s", indexName)
 	}
 
+	if keyType == ast.IndexKeyTypeSpatial || keyType == ast.IndexKeyTypeFullText {
+		var indexInfo *model.IndexInfo
+		if keyType == ast.IndexKeyTypeSpatial {
+			indexInfo, err = ddl.BuildSpatialIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption, model.StatePublic)
+		} else {
+			indexInfo, err = ddl.BuildFullTextIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption, model.StatePublic)
+		}
+		if err != nil {
+			return err
+		}
+		indexInfo.ID = ddl.AllocateIndexID(tblInfo)
+		tblInfo.Indices = append(tblInfo.Indices, indexInfo)
+		return nil
+	}
+
 	hiddenCols, err := ddl.BuildHiddenColumnInfo(ddl.NewMetaBuildContextWithSctx(ctx), indexPartSpecifications, indexName, t.Meta(), t.Cols())
 	if err != nil {
 		return err
@@ -938,10 +953,15 @@ func (d *SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
 				err = d.createIndex(sctx, ident, ast.IndexKeyTypeUnique, ast.NewCIStr(constr.Name),
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, ast.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
+			case ast.ConstraintFulltext:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, ast.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, ast.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintForeignKey,
-				ast.ConstraintFulltext,
 				ast.ConstraintCheck:
 			default:
 				// Nothing to do now.
@@ -1123,6 +1143,46 @@ func (*SchemaTracker) AlterTableMode(_ sessionctx.Context, _ *model.AlterTableMo
 	return nil
 }
 
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt, _ *model.TriggerInfo) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// CreateChangefeed implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateChangefeed(_ sessionctx.Context, _ *ast.CreateChangefeedStmt, _ *model.ChangefeedInfo) error {
+	return nil
+}
+
+// DropChangefeed implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropChangefeed(_ sessionctx.Context, _ *ast.DropChangefeedStmt) error {
+	return nil
+}
+
+// CreateStatistics implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) CreateStatistics(_ sessionctx.Context, _ *ast.CreateStatisticsStmt) error {
+	return nil
+}
+
+// DropStatistics implements the DDL interface, it's no-op in DM's case.
+func (*SchemaTracker) DropStatistics(_ sessionctx.Context, _ *ast.DropStatisticsStmt) error {
+	return nil
+}
+
 // CleanupTableLock implements the DDL interface, it's no-op in DM's case.
 func (*SchemaTracker) CleanupTableLock(_ sessionctx.Context, _ []*ast.TableName) error {
 	return nil
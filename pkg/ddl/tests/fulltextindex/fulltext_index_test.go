@@ -0,0 +1,161 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltextindex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreateFullTextIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (a int, fulltext index(a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t (a varbinary(10), fulltext index(a))", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t (a text, fulltext index((lower(a))))", errno.ErrFulltextFunctionalIndex)
+	tk.MustGetErrCode("create table t (a text, fulltext index(a(10)))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("create table t (a text, fulltext index(b))", errno.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("create table t (a text, fulltext index(a) with parser mecab)", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t (a int, b text, fulltext index(b)) partition by hash(a) partitions 2", errno.ErrFulltextNotSupportedWithPartitioning)
+
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft(title, body) comment 'search')")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `title` varchar(100) DEFAULT NULL,\n" +
+		"  `body` text DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `ft` (`title`,`body`) COMMENT 'search'\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select index_name, index_type from information_schema.statistics where table_name = 't' and index_name = 'ft'").
+		Check(testkit.Rows("ft FULLTEXT", "ft FULLTEXT"))
+
+	// The full-text index column can't be changed to a non-string column.
+	tk.MustGetErrCode("alter table t modify body int", errno.ErrBadFtColumn)
+	tk.MustExec("alter table t modify body longtext")
+	tk.MustGetErrCode("alter table t partition by hash(id) partitions 2", errno.ErrFulltextNotSupportedWithPartitioning)
+
+	tk.MustExec("create table t2 (a char(10), b text)")
+	tk.MustExec("create fulltext index fa on t2(a)")
+	tk.MustExec("alter table t2 add fulltext index fb(b) with parser ngram")
+	tk.MustQuery("show create table t2").Check(testkit.Rows("t2 CREATE TABLE `t2` (\n" +
+		"  `a` char(10) DEFAULT NULL,\n" +
+		"  `b` text DEFAULT NULL,\n" +
+		"  FULLTEXT KEY `fa` (`a`),\n" +
+		"  FULLTEXT KEY `fb` (`b`) /*!50100 WITH PARSER `ngram` */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t2").CheckAt([]int{2, 10}, testkit.Rows("fa FULLTEXT", "fb FULLTEXT"))
+	tk.MustExec("create fulltext index if not exists fa on t2(a)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 Duplicate key name 'fa'"))
+	tk.MustGetErrCode("create fulltext index fa2 on t2(a) using btree", errno.ErrUnsupportedDDLOperation)
+
+	tk.MustExec("create table t3 (a text, fulltext key (a))")
+	tk.MustExec("alter table t3 add fulltext key (a)")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("show create table t3").Check(testkit.Rows("t3 CREATE TABLE `t3` (\n" +
+		"  `a` text DEFAULT NULL,\n" +
+		"  FULLTEXT KEY `a` (`a`),\n" +
+		"  FULLTEXT KEY `a_2` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+}
+
+func TestFullTextIndexQuery(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	words := []string{"tidb", "mysql", "database", "distributed", "transaction", "storage", "engine", "index", "query", "optimizer"}
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text)")
+	values := make([]string, 0, 200)
+	for i := range 200 {
+		title := words[i%len(words)] + " " + words[i/len(words)%len(words)]
+		body := fmt.Sprintf("%s %s %s", words[i*7%len(words)], words[i*3%len(words)], strings.Repeat(words[i%3]+" ", i%4))
+		if i%9 == 0 {
+			body = "数据库系统 " + body
+		}
+		values = append(values, fmt.Sprintf("(%d, '%s', '%s')", i, title, body))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	tk.MustExec("insert into t values (1000, null, null), (1001, 'The', 'a an the')")
+	// The existing rows are backfilled.
+	tk.MustExec("alter table t add fulltext index ft(title, body)")
+	tk.MustExec("create fulltext index fb on t(body)")
+	tk.MustExec("admin check table t")
+
+	queries := []string{
+		"select id from t %s where match(title, body) against('tidb') order by id",
+		"select id from t %s where match(title, body) against('distributed database' in natural language mode) order by id",
+		"select id from t %s where match(body, title) against('+tidb -mysql' in boolean mode) order by id",
+		"select id from t %s where match(title, body) against('+\"storage engine\" optim*' in boolean mode) order by id",
+		"select id from t %s where match(title, body) against('trans* que*' in boolean mode) and id mod 2 = 0 order by id",
+		"select id from t %s where match(body) against('数据库') > 0 order by id",
+		"select id from t %s where match(body) against('数据库' in boolean mode) and match(title, body) against('tidb') order by id",
+		"select id from t %s where match(title, body) against('nothing') order by id",
+	}
+	check := func() {
+		for _, q := range queries {
+			expected := tk.MustQuery(fmt.Sprintf(q, "ignore index(ft, fb)")).Rows()
+			tk.MustHavePlan(fmt.Sprintf(q, ""), "IndexMerge")
+			tk.MustQuery(fmt.Sprintf(q, "")).Check(expected)
+		}
+	}
+	check()
+	tk.MustQuery("explain format = 'brief' " + fmt.Sprintf(queries[0], "")).CheckContain("index:ft(title, body)")
+	tk.MustQuery("select count(*) from t where match(title, body) against('tidb')").Check(testkit.Rows("80"))
+
+	// The full-text index is maintained by the DML statements.
+	tk.MustExec("update t set body = 'tidb tidb tidb' where id % 7 = 0")
+	tk.MustExec("delete from t where id % 11 = 0")
+	tk.MustExec("insert into t values (2000, 'TiDB', 'TiDB is a distributed SQL database')")
+	tk.MustExec("admin check table t")
+	check()
+
+	// The rows are ordered by the relevance.
+	tk.MustExec("create table t2 (id int primary key, body text, fulltext index ft(body))")
+	tk.MustExec("insert into t2 values (1, 'tidb'), (2, 'tidb tidb database'), (3, 'mysql'), (4, 'tidb database'), (5, 'database')")
+	tk.MustQuery("select id, match(body) against('tidb') > 0 from t2 order by match(body) against('tidb') desc, id").
+		Check(testkit.Rows("2 1", "1 1", "4 1", "3 0", "5 0"))
+	tk.MustQuery("select id from t2 where match(body) against('tidb database') order by match(body) against('tidb database') desc, id").
+		Check(testkit.Rows("2", "4", "1", "5"))
+	tk.MustQuery("select id from t2 where match(body) against('+database -tidb' in boolean mode)").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from t2 where match(body) against('-tidb' in boolean mode)").Check(testkit.Rows())
+
+	// The ngram parser splits all the text into bigrams.
+	tk.MustExec("create table t3 (id int primary key, body text, fulltext index ft(body) with parser ngram)")
+	tk.MustExec("insert into t3 values (1, '分布式数据库'), (2, '数据仓库'), (3, 'TiDB')")
+	tk.MustQuery("select id from t3 where match(body) against('数据库') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t3 where match(body) against('\"数据库\"' in boolean mode)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t3 where match(body) against('db' in boolean mode)").Check(testkit.Rows("3"))
+
+	tk.MustGetErrCode("select * from t where match(title) against('tidb')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match(title, id) against('tidb')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select * from t where match(body) against(title)", errno.ErrWrongArguments)
+	tk.MustGetErrCode("select * from t where match(body) against('tidb' with query expansion)", errno.ErrNotSupportedYet)
+
+	// The full-text index can't be used as a normal index.
+	tk.MustQuery("select count(*) from t use index(ft)").Check(testkit.Rows("183"))
+	tk.MustQuery("select count(*) from t where title = 'tidb tidb'").Check(testkit.Rows("1"))
+	tk.MustExec("analyze table t")
+	tk.MustQuery("select count(*) from t where match(title, body) against('tidb')").Check(testkit.Rows("90"))
+	tk.MustExec("set @@tidb_analyze_version = 1")
+	tk.MustExec("analyze table t3 index ft")
+	tk.MustQuery("show warnings").CheckContain("analyzing full-text index is not supported in analyze version 1, skip ft")
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package includeindex
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreateIncludeIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (a int, b int, primary key (a) include (b))", errno.ErrInvalidIncludeColumn)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a) include (c))", errno.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a, b) include (b))", errno.ErrInvalidIncludeColumn)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a) include (b, b))", errno.ErrInvalidIncludeColumn)
+
+	tk.MustExec("create table t (id int primary key, a int, b varchar(10), c json, index idx_a(a) include (b, c), unique key uk_b(b) include (a) comment 'uk')")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  `c` json DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `idx_a` (`a`) INCLUDE (`b`,`c`),\n" +
+		"  UNIQUE KEY `uk_b` (`b`) INCLUDE (`a`) COMMENT 'uk'\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The included columns can't be dropped or have their data changed, but can be renamed.
+	tk.MustGetErrCode("alter table t drop column c", errno.ErrDependentByIncludeColumn)
+	tk.MustGetErrCode("alter table t modify column a varchar(10)", errno.ErrDependentByIncludeColumn)
+	tk.MustExec("alter table t modify column b varchar(20)")
+	tk.MustExec("alter table t rename column b to bb")
+	tk.MustQuery("show create table t").CheckContain("KEY `idx_a` (`a`) INCLUDE (`bb`,`c`)")
+	tk.MustExec("alter table t drop index idx_a")
+	tk.MustExec("alter table t drop column c")
+}
+
+func TestIncludeIndexDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, clustered := range []string{"clustered", "nonclustered"} {
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t (id varchar(10), a int, b varchar(10), c int, d int, primary key (id) " + clustered +
+			", key idx(a) include (b, c), unique key uk(c) include (d))")
+		tk.MustExec("insert into t values ('1', 1, 'x', 10, 100), ('2', 1, null, 20, 200), ('3', 2, 'z', null, 300)")
+		tk.MustGetErrCode("insert into t values ('4', 4, 'w', 10, 400)", errno.ErrDupEntry)
+		tk.MustExec("update t set b = 'y' where id = '2'")
+		tk.MustExec("update t set d = 201 where c = 20")
+		tk.MustExec("admin check table t")
+
+		// The index covers the included columns, so no table lookup is needed.
+		tk.MustHavePlan("select a, b, c from t use index(idx) where a = 1", "IndexReader")
+		tk.MustNotHavePlan("select a, b, c from t use index(idx) where a = 1", "IndexLookUp")
+		tk.MustQuery("select a, b, c from t use index(idx) where a = 1").Sort().Check(testkit.Rows("1 x 10", "1 y 20"))
+		tk.MustQuery("select id, b from t use index(idx) where a > 0 and c > 15").Sort().Check(testkit.Rows("2 y"))
+		tk.MustHavePlan("select c, d from t use index(uk) where c > 15", "IndexReader")
+		tk.MustQuery("select c, d from t use index(uk) where c > 15").Check(testkit.Rows("20 201"))
+		tk.MustIndexLookup("select * from t use index(idx) where a = 2").Check(testkit.Rows("3 2 z <nil> 300"))
+
+		// The uncommitted changes are read by the covering index as well.
+		tk.MustExec("begin")
+		tk.MustExec("insert into t values ('5', 1, 'v', 50, 500)")
+		tk.MustExec("update t set b = 'u' where id = '1'")
+		tk.MustExec("delete from t where id = '2'")
+		tk.MustQuery("select a, b, c from t use index(idx) where a = 1").Sort().Check(testkit.Rows("1 u 10", "1 v 50"))
+		tk.MustQuery("select c, d from t use index(uk) where c >= 50").Check(testkit.Rows("50 500"))
+		tk.MustExec("commit")
+		tk.MustQuery("select a, b, c from t use index(idx) where a = 1").Sort().Check(testkit.Rows("1 u 10", "1 v 50"))
+		tk.MustExec("admin check table t")
+		tk.MustExec("admin check index t idx")
+	}
+}
+
+func TestAddIncludeIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("set global tidb_enable_dist_task = off")
+	defer tk.MustExec("set global tidb_ddl_enable_fast_reorg = default")
+	for _, fastReorg := range []string{"on", "off"} {
+		tk.MustExec("set global tidb_ddl_enable_fast_reorg = " + fastReorg)
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t (id int primary key, a int, b int)")
+		tk.MustExec("insert into t values (1, 1, 1), (2, 1, 0), (3, 2, null)")
+		tk.MustExec("alter table t add column c varchar(10) default 'abc'")
+		tk.MustGetErrCode("alter table t add primary key (a) include (b)", errno.ErrInvalidIncludeColumn)
+		tk.MustExec("alter table t add index idx(a) include (b, c), add unique index uk(id, a) include (c)")
+		tk.MustExec("admin check table t")
+		tk.MustHavePlan("select a, b, c from t use index(idx) where a = 1", "IndexReader")
+		tk.MustQuery("select a, b, c from t use index(idx) where a = 1").Sort().Check(testkit.Rows("1 0 abc", "1 1 abc"))
+		tk.MustQuery("select id, c from t use index(uk) where id > 1").Sort().Check(testkit.Rows("2 abc", "3 abc"))
+		tk.MustExec("admin recover index t idx")
+		tk.MustExec("admin check index t idx")
+	}
+}
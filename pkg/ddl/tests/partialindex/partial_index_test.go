@@ -0,0 +1,116 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partialindex
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreatePartialIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (a int, b int, primary key (a) where b > 0)", errno.ErrInvalidPartialIndex)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a) where c > 0)", errno.ErrBadField)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a) where b > rand())", errno.ErrInvalidPartialIndex)
+	tk.MustGetErrCode("create table t (a int, b int, index idx(a) where b > (select 1))", errno.ErrInvalidPartialIndex)
+
+	tk.MustExec("create table t (id int primary key, a int, b varchar(10), index idx_a(a) where b = 'x', unique key uk_a(a) comment 'uk' where a > 10 and b is not null)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `idx_a` (`a`) WHERE `b` = _utf8mb4'x',\n" +
+		"  UNIQUE KEY `uk_a` (`a`) COMMENT 'uk' WHERE `a` > 10 and `b` is not null\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The columns referenced by the conditions can't be dropped or renamed.
+	tk.MustGetErrCode("alter table t drop column b", errno.ErrDependentByPartialIndex)
+	tk.MustGetErrCode("alter table t rename column b to c", errno.ErrDependentByPartialIndex)
+	tk.MustGetErrCode("alter table t change b c varchar(10)", errno.ErrDependentByPartialIndex)
+	tk.MustExec("alter table t drop index idx_a")
+	tk.MustExec("alter table t drop index uk_a")
+	tk.MustExec("alter table t drop column b")
+}
+
+func TestPartialIndexDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, a int, b int, unique key uk(a) where b > 0, key idx(b) where a < 100)")
+	// The uniqueness is only checked among the rows satisfying the condition.
+	tk.MustExec("insert into t values (1, 1, 1), (2, 1, 0), (3, 1, null), (4, 200, 5)")
+	tk.MustGetErrCode("insert into t values (5, 1, 2)", errno.ErrDupEntry)
+	tk.MustExec("insert into t values (5, 2, 2)")
+	tk.MustGetErrCode("update t set b = 1 where id = 2", errno.ErrDupEntry)
+	tk.MustExec("update t set b = -1 where id = 1")
+	tk.MustExec("update t set b = 1 where id = 2")
+	tk.MustExec("update t set a = 300 where id = 5")
+	tk.MustExec("delete from t where id = 3")
+	// The new row conflicts with the row 2 on uk.
+	tk.MustExec("insert into t values (3, 1, 3) on duplicate key update b = 3")
+	tk.MustQuery("select * from t").Sort().Check(testkit.Rows("1 1 -1", "2 1 3", "4 200 5", "5 300 2"))
+	tk.MustExec("admin check table t")
+	tk.MustExec("admin check index t uk")
+	tk.MustExec("admin check index t idx")
+
+	// The partial index is only used when the query implies its condition.
+	tk.MustUseIndex("select * from t use index(uk) where a = 1 and b > 0", "uk")
+	tk.MustNoIndexUsed("select * from t where a = 1")
+	tk.MustQuery("select id from t where a = 1").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where a = 1 and b > 0").Check(testkit.Rows("2"))
+	tk.MustUseIndex("select b from t use index(idx) where b > 2 and a < 100", "idx")
+	tk.MustQuery("select b from t where b > 2 and a < 100").Check(testkit.Rows("3"))
+	tk.MustQuery("select b from t where b > 2").Sort().Check(testkit.Rows("3", "5"))
+	tk.MustQuery("select id from t use index(idx) where b > 2").Sort().Check(testkit.Rows("2", "4"))
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1105 partial index idx is inapplicable because its condition is not implied by the query"))
+
+	// The partial index can't be used to enforce a foreign key.
+	tk.MustExec("create table parent (id int, key idx(id) where id > 0)")
+	tk.MustGetErrCode("create table child (pid int, foreign key (pid) references parent(id))", errno.ErrForeignKeyNoIndexInParent)
+}
+
+func TestAddPartialIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("set global tidb_enable_dist_task = off")
+	defer tk.MustExec("set global tidb_ddl_enable_fast_reorg = default")
+	for _, fastReorg := range []string{"on", "off"} {
+		tk.MustExec("set global tidb_ddl_enable_fast_reorg = " + fastReorg)
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t (id int primary key, a int, b int)")
+		tk.MustExec("insert into t values (1, 1, 1), (2, 1, 0), (3, 2, 1), (4, 2, null)")
+		tk.MustGetErrCode("alter table t add unique index uk(a)", errno.ErrDupEntry)
+		tk.MustGetErrCode("alter table t add primary key pk(a) where b > 0", errno.ErrInvalidPartialIndex)
+		tk.MustExec("alter table t add unique index uk(a) where b > 0")
+		tk.MustExec("alter table t add index idx(b) where a = 2, add index idx2(a, b) where b is not null")
+		tk.MustExec("admin check table t")
+		tk.MustQuery("select count(*) from t use index(uk) where b > 0").Check(testkit.Rows("2"))
+		tk.MustQuery("select b from t use index(idx) where a = 2").Sort().Check(testkit.Rows("1", "<nil>"))
+		tk.MustQuery("select a, b from t use index(idx2) where b is not null").Sort().Check(testkit.Rows("1 0", "1 1", "2 1"))
+		tk.MustGetErrCode("insert into t values (5, 1, 5)", errno.ErrDupEntry)
+		tk.MustExec("insert into t values (5, 1, -5)")
+		tk.MustExec("admin check table t")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatialindex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreateSpatialIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create table t (g geometry, spatial index(g))", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t (a int not null, spatial index(a))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("create table t (g geometry not null, h geometry not null, spatial index(g, h))", errno.ErrTooManyKeyParts)
+	tk.MustGetErrCode("create table t (g geometry not null, spatial index((st_centroid(g))))", errno.ErrSpatialFunctionalIndex)
+	tk.MustGetErrCode("create table t (g geometry not null, spatial index(g(10)))", errno.ErrWrongSubKey)
+
+	tk.MustExec("create table t (id int primary key, g geometry not null srid 0, spatial key sidx(g) comment 'spatial')")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry /*!80003 SRID 0 */ NOT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  SPATIAL KEY `sidx` (`g`) COMMENT 'spatial'\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select index_name, index_type from information_schema.statistics where table_name = 't' and index_name = 'sidx'").
+		Check(testkit.Rows("sidx SPATIAL"))
+
+	// The spatial index column can't be changed to a nullable or non-geometry column.
+	tk.MustGetErrCode("alter table t modify g geometry srid 0", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("alter table t modify g varchar(10) not null", errno.ErrSpatialMustHaveGeomCol)
+
+	tk.MustExec("create table t2 (g geometry not null, p point not null srid 4326)")
+	tk.MustExec("create spatial index sidx on t2(g)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3674 The spatial index on column 'g' will not be used by the query optimizer since the column does not have an SRID attribute. Consider adding an SRID attribute to the column."))
+	tk.MustExec("alter table t2 add spatial index pidx(p)")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	tk.MustQuery("show index from t2").CheckAt([]int{2, 10}, testkit.Rows("sidx SPATIAL", "pidx SPATIAL"))
+	tk.MustExec("create spatial index if not exists sidx on t2(g)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1061 Duplicate key name 'sidx'"))
+	tk.MustGetErrCode("create spatial index sidx2 on t2(g) using btree", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestSpatialIndexQuery(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t (id int primary key, g geometry not null srid 0, p point not null srid 4326)")
+	values := make([]string, 0, 400)
+	for i := range 400 {
+		x, y := i%20, i/20
+		values = append(values, fmt.Sprintf("(%d, st_geomfromtext('LINESTRING(%d %d,%d %d)'), st_geomfromtext('POINT(%d %d)', 4326))",
+			i, x, y, x+1, y+2, y-10, x*3-30))
+	}
+	tk.MustExec("insert into t values " + strings.Join(values, ","))
+	// The existing rows are backfilled.
+	tk.MustExec("alter table t add spatial index sidx(g)")
+	tk.MustExec("create spatial index pidx on t(p)")
+	tk.MustExec("admin check table t")
+
+	queries := []string{
+		"select id from t %s where st_intersects(g, st_geomfromtext('POLYGON((2 3,2 5,4 5,4 3,2 3))')) order by id",
+		"select id from t %s where st_contains(st_geomfromtext('POLYGON((2 3,2 9,9 9,9 3,2 3))'), g) order by id",
+		"select id from t %s where st_within(g, st_geomfromtext('POLYGON((0 0,0 9,9 9,9 0,0 0))')) and id %% 2 = 0 order by id",
+		"select id from t %s where st_distance(g, st_geomfromtext('POINT(10 10)')) <= 1.5 order by id",
+		"select id from t %s where 2 > st_distance(st_geomfromtext('POINT(-5 -5)'), g) order by id",
+		"select id from t %s where st_distance_sphere(p, st_geomfromtext('POINT(0 0)', 4326)) < 500000 order by id",
+		"select id from t %s where st_distance(p, st_geomfromtext('POINT(5 -20)', 4326)) <= 400000 order by id",
+		"select id from t %s where st_intersects(g, st_geomfromtext('POINT(100 100)')) order by id",
+	}
+	check := func() {
+		for _, q := range queries {
+			expected := tk.MustQuery(fmt.Sprintf(q, "ignore index(sidx, pidx)")).Rows()
+			tk.MustHavePlan(fmt.Sprintf(q, ""), "IndexMerge")
+			tk.MustQuery(fmt.Sprintf(q, "")).Check(expected)
+		}
+	}
+	check()
+	tk.MustQuery("explain format = 'brief' " + fmt.Sprintf(queries[0], "")).CheckContain("index:sidx(g)")
+
+	// The spatial index is maintained by the DML statements.
+	tk.MustExec("update t set g = st_geomfromtext('POINT(3 4)') where id % 7 = 0")
+	tk.MustExec("delete from t where id % 11 = 0")
+	tk.MustExec("insert into t values (1000, st_geomfromtext('POLYGON((-100 -100,-100 100,100 100,100 -100,-100 -100))'), st_geomfromtext('POINT(0 0)', 4326))")
+	tk.MustExec("insert into t values (1001, st_geomfromtext('GEOMETRYCOLLECTION EMPTY'), st_geomfromtext('POINT(1 1)', 4326))")
+	tk.MustExec("admin check table t")
+	check()
+
+	// The spatial index is not used for the columns without SRID or the constants of another SRID.
+	tk.MustExec("create table t2 (g geometry not null, spatial index sidx(g))")
+	tk.MustExec("insert into t2 values (st_geomfromtext('POINT(1 1)'))")
+	tk.MustNotHavePlan("select * from t2 where st_intersects(g, st_geomfromtext('POINT(1 1)'))", "IndexMerge")
+	tk.MustQuery("select count(*) from t2 where st_intersects(g, st_geomfromtext('POINT(1 1)'))").Check(testkit.Rows("1"))
+	tk.MustNotHavePlan("select * from t where st_equals(p, st_geomfromtext('POINT(1 1)'))", "IndexMerge")
+
+	// The spatial index can't be used as a normal index.
+	tk.MustQuery("select count(*) from t use index(sidx)").Check(testkit.Rows("365"))
+	tk.MustQuery("select count(*) from t where g = st_geomfromtext('POINT(3 4)')").Check(testkit.Rows("52"))
+	tk.MustExec("analyze table t index sidx")
+	tk.MustQuery("show warnings").CheckContain("analyzing spatial index is not supported, skip sidx")
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+func onCreateTrigger(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
+	args, err := model.GetCreateTriggerArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	trigger := args.Trigger
+	if tblInfo.FindTrigger(trigger.Name.L) >= 0 {
+		if args.IfNotExists {
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+	}
+	pos, err := triggerPosition(tblInfo, trigger, args.Order, args.Precedes)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo.Triggers = slices.Insert(tblInfo.Triggers, pos, trigger)
+
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// triggerPosition returns the position in tblInfo.Triggers where the new trigger
+// is inserted. The trigger referenced by FOLLOWS or PRECEDES must have the same
+// timing and event as the new one.
+func triggerPosition(tblInfo *model.TableInfo, trigger *model.TriggerInfo, order string, precedes bool) (int, error) {
+	if order == "" {
+		return len(tblInfo.Triggers), nil
+	}
+	idx := tblInfo.FindTrigger(order)
+	if idx < 0 || tblInfo.Triggers[idx].Timing != trigger.Timing || tblInfo.Triggers[idx].Event != trigger.Event {
+		return 0, dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(order)
+	}
+	if precedes {
+		return idx, nil
+	}
+	return idx + 1, nil
+}
+
+func onDropTrigger(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
+	args, err := model.GetDropTriggerArgs(job)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	idx := tblInfo.FindTrigger(args.Name.L)
+	if idx < 0 {
+		if args.IfExists {
+			job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+			return ver, nil
+		}
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+	}
+	tblInfo.Triggers = slices.Delete(tblInfo.Triggers, idx, idx+1)
+
+	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/expression"
 	infoschemactx "github.com/pingcap/tidb/pkg/infoschema/context"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
@@ -27,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ttl/cache"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/generatedexpr"
 )
 
 func onTTLInfoRemove(jobCtx *jobContext, job *model.Job) (ver int64, err error) {
@@ -52,6 +54,7 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 		return ver, errors.Trace(err)
 	}
 	ttlInfo, ttlInfoEnable, ttlInfoJobInterval := args.TTLInfo, args.TTLEnable, args.TTLCronJobSchedule
+	ttlAction, ttlArchiveTarget := args.TTLAction, args.TTLArchiveTarget
 
 	tblInfo, err := GetTableInfoAndCancelFaultJob(jobCtx.metaMut, job, job.SchemaID)
 	if err != nil {
@@ -66,6 +69,12 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 		if ttlInfoJobInterval == nil && tblInfo.TTLInfo != nil {
 			ttlInfo.JobInterval = tblInfo.TTLInfo.JobInterval
 		}
+		if ttlAction == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.Action = tblInfo.TTLInfo.Action
+		}
+		if ttlArchiveTarget == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.ArchiveTarget = tblInfo.TTLInfo.ArchiveTarget
+		}
 		tblInfo.TTLInfo = ttlInfo
 	}
 	if ttlInfoEnable != nil {
@@ -82,6 +91,20 @@ func onTTLInfoChange(jobCtx *jobContext, job *model.Job) (ver int64, err error)
 
 		tblInfo.TTLInfo.JobInterval = *ttlInfoJobInterval
 	}
+	if ttlAction != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ACTION"))
+		}
+
+		tblInfo.TTLInfo.Action = *ttlAction
+	}
+	if ttlArchiveTarget != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TARGET"))
+		}
+
+		tblInfo.TTLInfo.ArchiveTarget = *ttlArchiveTarget
+	}
 
 	ver, err = updateVersionAndTableInfo(jobCtx, job, tblInfo, true)
 	if err != nil {
@@ -114,7 +137,119 @@ func checkTTLInfoValid(schema ast.CIStr, tblInfo *model.TableInfo, foreignKeyChe
 		}
 	}
 
-	return checkTTLInfoColumnType(tblInfo)
+	if err := checkTTLArchiveTarget(schema, tblInfo, foreignKeyCheckIs); err != nil {
+		return err
+	}
+
+	if err := checkTTLInfoColumnType(tblInfo); err != nil {
+		return err
+	}
+
+	return checkTTLCondition(schema, tblInfo)
+}
+
+// checkTTLCondition checks the `WHERE` condition of the TTL config. The condition is evaluated on every scanned row, so
+// it can only reference the columns of the table and the functions which are allowed in generated columns.
+func checkTTLCondition(schema ast.CIStr, tblInfo *model.TableInfo) error {
+	ttlInfo := tblInfo.TTLInfo
+	if !ttlInfo.HasCondition() {
+		return nil
+	}
+
+	expr, err := generatedexpr.ParseExpression(ttlInfo.Condition)
+	if err != nil {
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, err.Error())
+	}
+	var c illegalFunctionChecker
+	c.disallowCastArrayFunc = true
+	expr.Accept(&c)
+	switch {
+	case c.hasIllegalFunc:
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, "subqueries, variables and non-deterministic functions are not allowed")
+	case c.hasAggFunc || c.hasWindowFunc:
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, "aggregate and window functions are not allowed")
+	case c.hasRowVal:
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, "row values are not allowed")
+	case c.otherErr != nil:
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, c.otherErr.Error())
+	}
+
+	for _, colName := range FindColumnNamesInExpr(expr) {
+		col := findColumnByName(colName.Name.L, tblInfo)
+		if col == nil || col.State != model.StatePublic {
+			return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, "unknown column '"+colName.Name.O+"'")
+		}
+	}
+	if _, err = expression.BuildSimpleExpr(newReorgExprCtx(), expr, expression.WithTableInfo(schema.O, tblInfo)); err != nil {
+		return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(ttlInfo.Condition, err.Error())
+	}
+	return nil
+}
+
+// ttlConditionColumns returns the names of the columns referenced by the TTL condition.
+func ttlConditionColumns(ttlInfo *model.TTLInfo) ([]*ast.ColumnName, error) {
+	if ttlInfo == nil || !ttlInfo.HasCondition() {
+		return nil, nil
+	}
+	expr, err := generatedexpr.ParseExpression(ttlInfo.Condition)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return FindColumnNamesInExpr(expr), nil
+}
+
+// checkRenameColumnWithTTLCondition checks whether the column is referenced by the TTL condition, which is stored as
+// a string and cannot follow the new name of the column.
+func checkRenameColumnWithTTLCondition(tblInfo *model.TableInfo, colName ast.CIStr) error {
+	colNames, err := ttlConditionColumns(tblInfo.TTLInfo)
+	if err != nil {
+		return err
+	}
+	for _, name := range colNames {
+		if name.Name.L == colName.L {
+			return dbterror.ErrInvalidTTLCondition.GenWithStackByArgs(tblInfo.TTLInfo.Condition,
+				"cannot rename column '"+colName.O+"' referenced by it")
+		}
+	}
+	return nil
+}
+
+// checkTTLArchiveTarget checks the archive target of a TTL table with the `ARCHIVE` action.
+// If `is` is not `nil`, the target table should exist and contain all the archived columns.
+func checkTTLArchiveTarget(schema ast.CIStr, tblInfo *model.TableInfo, is infoschemactx.MetaOnlyInfoSchema) error {
+	ttlInfo := tblInfo.TTLInfo
+	if !ttlInfo.IsArchive() {
+		return nil
+	}
+	if ttlInfo.ArchiveTarget == "" {
+		return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs("", "TTL_ARCHIVE_TARGET is required when TTL_ACTION is 'ARCHIVE'")
+	}
+
+	target, err := cache.ParseArchiveTarget(ttlInfo.ArchiveTarget, schema)
+	if err != nil {
+		return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs(ttlInfo.ArchiveTarget, err.Error())
+	}
+	if target.IsStorage() || is == nil {
+		return nil
+	}
+
+	if target.Schema.L == schema.L && target.Table.L == tblInfo.Name.L {
+		return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs(ttlInfo.ArchiveTarget, "the target cannot be the TTL table itself")
+	}
+	targetInfo, err := is.TableInfoByName(target.Schema, target.Table)
+	if err != nil {
+		return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs(ttlInfo.ArchiveTarget, "the target table doesn't exist")
+	}
+	if targetInfo.IsView() || targetInfo.IsSequence() || targetInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs(ttlInfo.ArchiveTarget, "the target is not a normal table")
+	}
+	for _, col := range cache.ArchiveColumns(tblInfo) {
+		if findColumnByName(col.Name.L, targetInfo) == nil {
+			return dbterror.ErrInvalidTTLArchiveTarget.GenWithStackByArgs(ttlInfo.ArchiveTarget,
+				"column '"+col.Name.O+"' doesn't exist in the target table")
+		}
+	}
+	return nil
 }
 
 func checkTTLIntervalExpr(ttlInfo *model.TTLInfo) error {
@@ -139,6 +274,15 @@ func checkDropColumnWithTTLConfig(tblInfo *model.TableInfo, colName string) erro
 		if tblInfo.TTLInfo.ColumnName.L == colName {
 			return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
 		}
+		colNames, err := ttlConditionColumns(tblInfo.TTLInfo)
+		if err != nil {
+			return err
+		}
+		for _, name := range colNames {
+			if name.Name.L == colName {
+				return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
+			}
+		}
 	}
 
 	return nil
@@ -177,21 +321,34 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 	for _, op := range options {
 		switch op.Tp {
 		case ast.TableOptionTTL:
-			var sb strings.Builder
-			restoreFlags := format.RestoreStringSingleQuotes | format.RestoreNameBackQuotes
-			restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
-			err := op.Value.Restore(restoreCtx)
-			if err != nil {
-				return nil, nil, nil, err
+			ttlInfo = &model.TTLInfo{
+				ColumnName:  op.ColumnName.Name,
+				Enable:      true,
+				JobInterval: model.DefaultTTLJobInterval,
 			}
 
-			intervalExpr := sb.String()
-			ttlInfo = &model.TTLInfo{
-				ColumnName:       op.ColumnName.Name,
-				IntervalExprStr:  intervalExpr,
-				IntervalTimeUnit: int(op.TimeUnitValue.Unit),
-				Enable:           true,
-				JobInterval:      model.DefaultTTLJobInterval,
+			// the value is nil if the column stores the expiry time of each row
+			if op.Value != nil {
+				var sb strings.Builder
+				restoreFlags := format.RestoreStringSingleQuotes | format.RestoreNameBackQuotes
+				restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+				err := op.Value.Restore(restoreCtx)
+				if err != nil {
+					return nil, nil, nil, err
+				}
+				ttlInfo.IntervalExprStr = sb.String()
+				ttlInfo.IntervalTimeUnit = int(op.TimeUnitValue.Unit)
+			}
+
+			if op.Condition != nil {
+				var sb strings.Builder
+				restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+					format.RestoreSpacesAroundBinaryOperation | format.RestoreWithoutSchemaName | format.RestoreWithoutTableName
+				restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+				if err := op.Condition.Restore(restoreCtx); err != nil {
+					return nil, nil, nil, err
+				}
+				ttlInfo.Condition = sb.String()
 			}
 		case ast.TableOptionTTLEnable:
 			ttlEnable = &op.BoolValue
@@ -200,6 +357,8 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 		}
 	}
 
+	ttlAction, ttlArchiveTarget := getTTLActionInOptions(options)
+
 	if ttlInfo != nil {
 		if ttlEnable != nil {
 			ttlInfo.Enable = *ttlEnable
@@ -207,6 +366,26 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 		if ttlCronJobSchedule != nil {
 			ttlInfo.JobInterval = *ttlCronJobSchedule
 		}
+		if ttlAction != nil {
+			ttlInfo.Action = *ttlAction
+		}
+		if ttlArchiveTarget != nil {
+			ttlInfo.ArchiveTarget = *ttlArchiveTarget
+		}
 	}
 	return ttlInfo, ttlEnable, ttlCronJobSchedule, nil
 }
+
+// getTTLActionInOptions returns the TTL_ACTION and TTL_ARCHIVE_TARGET in the options.
+// If any of them is not set in the options, the corresponding return value will be nil.
+func getTTLActionInOptions(options []*ast.TableOption) (ttlAction *string, ttlArchiveTarget *string) {
+	for _, op := range options {
+		switch op.Tp {
+		case ast.TableOptionTTLAction:
+			ttlAction = &op.StrValue
+		case ast.TableOptionTTLArchiveTarget:
+			ttlArchiveTarget = &op.StrValue
+		}
+	}
+	return ttlAction, ttlArchiveTarget
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -137,6 +138,58 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&twentyFiveHours,
 			nil,
 		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: ast.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:       ast.TableOptionTTLAction,
+					StrValue: model.TTLActionArchive,
+				},
+				{
+					Tp:       ast.TableOptionTTLArchiveTarget,
+					StrValue: "test.t_archive",
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       ast.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      "24h",
+				Action:           model.TTLActionArchive,
+				ArchiveTarget:    "test.t_archive",
+			},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:         ast.TableOptionTTL,
+					ColumnName: &ast.ColumnName{Name: ast.NewCIStr("expire_at")},
+					Condition: &ast.BinaryOperationExpr{
+						Op: opcode.EQ,
+						L:  &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: ast.NewCIStr("status")}},
+						R:  ast.NewValueExpr(1, "", ""),
+					},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:  ast.NewCIStr("expire_at"),
+				Enable:      true,
+				JobInterval: model.DefaultTTLJobInterval,
+				Condition:   "`status` = 1",
+			},
+			nil,
+			nil,
+			nil,
+		},
 	}
 
 	for _, c := range cases {
@@ -148,3 +201,19 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 		assert.Equal(t, c.err, err)
 	}
 }
+
+func Test_getTTLActionInOptions(t *testing.T) {
+	ttlAction, ttlArchiveTarget := getTTLActionInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLEnable, BoolValue: true},
+	})
+	assert.Nil(t, ttlAction)
+	assert.Nil(t, ttlArchiveTarget)
+
+	ttlAction, ttlArchiveTarget = getTTLActionInOptions([]*ast.TableOption{
+		{Tp: ast.TableOptionTTLAction, StrValue: model.TTLActionDelete},
+		{Tp: ast.TableOptionTTLArchiveTarget, StrValue: "s3://bucket/prefix"},
+		{Tp: ast.TableOptionTTLAction, StrValue: model.TTLActionArchive},
+	})
+	assert.Equal(t, model.TTLActionArchive, *ttlAction)
+	assert.Equal(t, "s3://bucket/prefix", *ttlArchiveTarget)
+}
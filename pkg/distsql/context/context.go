@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/resourcegroup"
+	"github.com/pingcap/tidb/pkg/sessiontxn/ssi"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/memory"
@@ -88,6 +89,10 @@ type DistSQLContext struct {
 
 	ExecDetails *execdetails.SyncExecDetails
 
+	// SSI records the key ranges read by the serializable transaction. It's nil if the transaction
+	// isn't serializable.
+	SSI *ssi.Txn
+
 	// Only one cop-reader can use lite worker at the same time. Using lite-worker in multiple readers will affect the concurrent execution of readers.
 	TryCopLiteWorker atomic.Uint32
 }
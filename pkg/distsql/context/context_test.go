@@ -107,6 +107,8 @@ func TestContextDetach(t *testing.T) {
 			// The following fields are on stmtctx and will be recreated before the new statement
 			"$.RunawayChecker",
 			"$.ExecDetails",
+			// The following fields are on txn context and shared by the statements of the txn
+			"$.SSI",
 		}))
 
 	staticObj := obj.Detach()
@@ -128,5 +130,6 @@ func TestContextDetach(t *testing.T) {
 			"$.RuntimeStatsColl",
 			"$.WarnHandler",
 			"$.ResourceGroupTagger",
+			"$.SSI",
 		}))
 }
@@ -90,6 +90,10 @@ func Select(ctx context.Context, dctx *distsqlctx.DistSQLContext, kvReq *kv.Requ
 		option.AppendWarning = dctx.AppendWarning
 	}
 
+	if dctx.SSI != nil && kvReq.StartTs == dctx.SSI.StartTS() {
+		dctx.SSI.TrackRanges(kvReq.KeyRanges.AppendSelfTo(nil))
+	}
+
 	resp := dctx.Client.Send(ctx, kvReq, dctx.KVVars, option)
 	if resp == nil {
 		return nil, errors.New("client returns nil response")
@@ -15,8 +15,10 @@
 package distsql
 
 import (
+	"bytes"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"sync/atomic"
 	"time"
@@ -123,18 +125,18 @@ func (builder *RequestBuilder) SetTableRanges(tid int64, tableRanges []*ranger.R
 
 // SetIndexRanges sets "KeyRanges" for "kv.Request" by converting index range
 // "ranges" to "KeyRanges" firstly.
-func (builder *RequestBuilder) SetIndexRanges(dctx *distsqlctx.DistSQLContext, tid, idxID int64, ranges []*ranger.Range) *RequestBuilder {
+func (builder *RequestBuilder) SetIndexRanges(dctx *distsqlctx.DistSQLContext, tid int64, idxInfo *model.IndexInfo, ranges []*ranger.Range) *RequestBuilder {
 	if builder.err == nil {
-		builder.Request.KeyRanges, builder.err = IndexRangesToKVRanges(dctx, tid, idxID, ranges)
+		builder.Request.KeyRanges, builder.err = IndexRangesToKVRanges(dctx, tid, idxInfo, ranges)
 	}
 	return builder
 }
 
 // SetIndexRangesForTables sets "KeyRanges" for "kv.Request" by converting multiple indexes range
 // "ranges" to "KeyRanges" firstly.
-func (builder *RequestBuilder) SetIndexRangesForTables(dctx *distsqlctx.DistSQLContext, tids []int64, idxID int64, ranges []*ranger.Range) *RequestBuilder {
+func (builder *RequestBuilder) SetIndexRangesForTables(dctx *distsqlctx.DistSQLContext, tids []int64, idxInfo *model.IndexInfo, ranges []*ranger.Range) *RequestBuilder {
 	if builder.err == nil {
-		builder.Request.KeyRanges, builder.err = IndexRangesToKVRangesForTables(dctx, tids, idxID, ranges)
+		builder.Request.KeyRanges, builder.err = IndexRangesToKVRangesForTables(dctx, tids, idxInfo, ranges)
 	}
 	return builder
 }
@@ -665,14 +667,14 @@ func PartitionHandlesToKVRanges(handles []kv.Handle) ([]kv.KeyRange, []int) {
 }
 
 // IndexRangesToKVRanges converts index ranges to "KeyRange".
-func IndexRangesToKVRanges(dctx *distsqlctx.DistSQLContext, tid, idxID int64, ranges []*ranger.Range) (*kv.KeyRanges, error) {
-	return IndexRangesToKVRangesWithInterruptSignal(dctx, tid, idxID, ranges, nil, nil)
+func IndexRangesToKVRanges(dctx *distsqlctx.DistSQLContext, tid int64, idxInfo *model.IndexInfo, ranges []*ranger.Range) (*kv.KeyRanges, error) {
+	return IndexRangesToKVRangesWithInterruptSignal(dctx, tid, idxInfo, ranges, nil, nil)
 }
 
 // IndexRangesToKVRangesWithInterruptSignal converts index ranges to "KeyRange".
 // The process can be interrupted by set `interruptSignal` to true.
-func IndexRangesToKVRangesWithInterruptSignal(dctx *distsqlctx.DistSQLContext, tid, idxID int64, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
-	keyRanges, err := indexRangesToKVRangesForTablesWithInterruptSignal(dctx, []int64{tid}, idxID, ranges, memTracker, interruptSignal)
+func IndexRangesToKVRangesWithInterruptSignal(dctx *distsqlctx.DistSQLContext, tid int64, idxInfo *model.IndexInfo, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
+	keyRanges, err := indexRangesToKVRangesForTablesWithInterruptSignal(dctx, []int64{tid}, idxInfo, ranges, memTracker, interruptSignal)
 	if err != nil {
 		return nil, err
 	}
@@ -681,14 +683,14 @@ func IndexRangesToKVRangesWithInterruptSignal(dctx *distsqlctx.DistSQLContext, t
 }
 
 // IndexRangesToKVRangesForTables converts indexes ranges to "KeyRange".
-func IndexRangesToKVRangesForTables(dctx *distsqlctx.DistSQLContext, tids []int64, idxID int64, ranges []*ranger.Range) (*kv.KeyRanges, error) {
-	return indexRangesToKVRangesForTablesWithInterruptSignal(dctx, tids, idxID, ranges, nil, nil)
+func IndexRangesToKVRangesForTables(dctx *distsqlctx.DistSQLContext, tids []int64, idxInfo *model.IndexInfo, ranges []*ranger.Range) (*kv.KeyRanges, error) {
+	return indexRangesToKVRangesForTablesWithInterruptSignal(dctx, tids, idxInfo, ranges, nil, nil)
 }
 
 // IndexRangesToKVRangesForTablesWithInterruptSignal converts indexes ranges to "KeyRange".
 // The process can be interrupted by set `interruptSignal` to true.
-func indexRangesToKVRangesForTablesWithInterruptSignal(dctx *distsqlctx.DistSQLContext, tids []int64, idxID int64, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
-	return indexRangesToKVWithoutSplit(dctx, tids, idxID, ranges, memTracker, interruptSignal)
+func indexRangesToKVRangesForTablesWithInterruptSignal(dctx *distsqlctx.DistSQLContext, tids []int64, idxInfo *model.IndexInfo, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
+	return indexRangesToKVWithoutSplit(dctx, tids, idxInfo, ranges, memTracker, interruptSignal)
 }
 
 // CommonHandleRangesToKVRanges converts common handle ranges to "KeyRange".
@@ -745,7 +747,19 @@ func VerifyTxnScope(txnScope string, physicalTableID int64, is infoschema.MetaOn
 	return true
 }
 
-func indexRangesToKVWithoutSplit(dctx *distsqlctx.DistSQLContext, tids []int64, idxID int64, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
+func indexRangesToKVWithoutSplit(dctx *distsqlctx.DistSQLContext, tids []int64, idxInfo *model.IndexInfo, ranges []*ranger.Range, memTracker *memory.Tracker, interruptSignal *atomic.Value) (*kv.KeyRanges, error) {
+	hasDescColumn := idxInfo.HasDescColumn()
+	if hasDescColumn {
+		descs := make([]bool, len(idxInfo.Columns))
+		for i, col := range idxInfo.Columns {
+			descs[i] = col.Desc
+		}
+		var err error
+		ranges, err = ranger.Ranges(ranges).SplitByDirection(types.DefaultStmtNoWarningContext, descs)
+		if err != nil {
+			return nil, err
+		}
+	}
 	krs := make([][]kv.KeyRange, len(tids))
 	for i := range krs {
 		krs[i] = make([]kv.KeyRange, 0, len(ranges))
@@ -759,7 +773,13 @@ func indexRangesToKVWithoutSplit(dctx *distsqlctx.DistSQLContext, tids []int64,
 	// encodeIndexKey and EncodeIndexSeekKey is time-consuming, thus we need to
 	// check the interrupt signal periodically.
 	for i, ran := range ranges {
-		low, high, err := EncodeIndexKey(dctx, ran)
+		var low, high []byte
+		var err error
+		if hasDescColumn {
+			low, high, err = encodeIndexKeyWithDirection(dctx, idxInfo, ran)
+		} else {
+			low, high, err = EncodeIndexKey(dctx, ran)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -767,8 +787,8 @@ func indexRangesToKVWithoutSplit(dctx *distsqlctx.DistSQLContext, tids []int64,
 			estimatedMemUsage += int64(cap(low) + cap(high))
 		}
 		for j, tid := range tids {
-			startKey := tablecodec.EncodeIndexSeekKey(tid, idxID, low)
-			endKey := tablecodec.EncodeIndexSeekKey(tid, idxID, high)
+			startKey := tablecodec.EncodeIndexSeekKey(tid, idxInfo.ID, low)
+			endKey := tablecodec.EncodeIndexSeekKey(tid, idxInfo.ID, high)
 			if i == 0 {
 				estimatedMemUsage += int64(cap(startKey)) + int64(cap(endKey))
 			}
@@ -787,9 +807,58 @@ func indexRangesToKVWithoutSplit(dctx *distsqlctx.DistSQLContext, tids []int64,
 			}
 		}
 	}
+	if hasDescColumn {
+		// The ranges are sorted by the logical order, which differs from the order of the keys.
+		for _, ranges := range krs {
+			slices.SortFunc(ranges, func(a, b kv.KeyRange) int {
+				return bytes.Compare(a.StartKey, b.StartKey)
+			})
+		}
+	}
 	return kv.NewPartitionedKeyRanges(krs), nil
 }
 
+// encodeIndexKeyWithDirection is like EncodeIndexKey, but the values are encoded according to the order
+// of the index columns. The range must be split by ranger.Ranges.SplitByDirection in advance.
+func encodeIndexKeyWithDirection(dctx *distsqlctx.DistSQLContext, idxInfo *model.IndexInfo, ran *ranger.Range) (low, high []byte, err error) {
+	tz := time.UTC
+	errCtx := errctx.StrictNoWarningContext
+	if dctx != nil {
+		tz = dctx.Location
+		errCtx = dctx.ErrCtx
+	}
+	lowVal, highVal := ran.LowVal, ran.HighVal
+	lowExclude, highExclude := ran.LowExclude, ran.HighExclude
+	eqLen, err := ran.PrefixEqualLen(types.DefaultStmtNoWarningContext)
+	if err != nil {
+		return nil, nil, err
+	}
+	isPoint := eqLen == len(lowVal) && eqLen == len(highVal)
+	if !isPoint && eqLen < len(idxInfo.Columns) && idxInfo.Columns[eqLen].Desc {
+		// The key order is reversed from the first different column.
+		lowVal, highVal = highVal, lowVal
+		lowExclude, highExclude = highExclude, lowExclude
+	}
+
+	low, err = tablecodec.EncodeIndexColumnValues(tz, nil, idxInfo, lowVal)
+	err = errCtx.HandleError(err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if lowExclude {
+		low = kv.Key(low).PrefixNext()
+	}
+	high, err = tablecodec.EncodeIndexColumnValues(tz, nil, idxInfo, highVal)
+	err = errCtx.HandleError(err)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !highExclude {
+		high = kv.Key(high).PrefixNext()
+	}
+	return low, high, nil
+}
+
 // EncodeIndexKey gets encoded keys containing low and high
 func EncodeIndexKey(dctx *distsqlctx.DistSQLContext, ran *ranger.Range) (low, high []byte, err error) {
 	tz := time.UTC
@@ -834,7 +903,7 @@ func BuildTableRanges(tbl *model.TableInfo) ([]kv.KeyRange, error) {
 		if idx.State != model.StatePublic || !idx.Global {
 			continue
 		}
-		idxRanges, err := IndexRangesToKVRanges(nil, tbl.ID, idx.ID, ranger.FullRange())
+		idxRanges, err := IndexRangesToKVRanges(nil, tbl.ID, idx, ranger.FullRange())
 		if err != nil {
 			return nil, err
 		}
@@ -871,7 +940,7 @@ func appendRanges(tbl *model.TableInfo, tblID int64) ([]kv.KeyRange, error) {
 			continue
 		}
 		ranges = ranger.FullRange()
-		idxRanges, err := IndexRangesToKVRanges(nil, tblID, index.ID, ranges)
+		idxRanges, err := IndexRangesToKVRanges(nil, tblID, index, ranges)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -0,0 +1,695 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"cmp"
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/expression/exprstatic"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/table/tables"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/tikv/client-go/v2/tikv"
+	"github.com/tikv/client-go/v2/txnkv/txnlock"
+	"go.uber.org/zap"
+)
+
+var (
+	// batchSize is the max number of changes written by a sink at once.
+	batchSize = 1024
+	// batchLogRows is the max number of log rows read by a batch.
+	batchLogRows = 64 * 1024
+	// logPurgeBatchSize is the number of log rows removed by a statement.
+	logPurgeBatchSize = 256
+	// pollInterval is the interval between the batches, and to retry a batch
+	// after a failure.
+	pollInterval = time.Second
+	// safePointTTL is the TTL in seconds of the service safe point which keeps
+	// the checkpoint from GC, it's refreshed by every batch.
+	safePointTTL int64 = 24 * 60 * 60
+)
+
+// errChangefeedDropped is returned when the changefeed doesn't exist anymore.
+var errChangefeedDropped = errors.New("changefeed is dropped")
+
+// consumer writes the changes of a changefeed to the sink batch by batch.
+type consumer struct {
+	store   kv.Storage
+	taskMgr *fstorage.TaskManager
+	meta    *TaskMeta
+	sink    sink
+	logger  *zap.Logger
+}
+
+func newConsumer(store kv.Storage, taskMgr *fstorage.TaskManager, meta *TaskMeta) *consumer {
+	return &consumer{
+		store:   store,
+		taskMgr: taskMgr,
+		meta:    meta,
+		logger:  logutil.BgLogger().With(zap.Int64("changefeedID", meta.ChangefeedID)),
+	}
+}
+
+// checkpoint is the progress of a changefeed, the changes committed in
+// (checkpointTS, resolvedTS] are written by the batch.
+type checkpoint struct {
+	batch          int64
+	checkpointTS   uint64
+	resolvedTS     uint64
+	writtenChanges int64
+}
+
+// run streams the changes until the context is cancelled or the changefeed is
+// dropped. A failed batch is retried after pollInterval, and the error is
+// recorded in the checkpoint table until the batch succeeds.
+func (c *consumer) run(ctx context.Context) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	for {
+		err := c.consumeBatch(ctx)
+		if errors.ErrorEqual(err, errChangefeedDropped) {
+			c.logger.Info("changefeed is dropped, stop streaming")
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			c.logger.Warn("changefeed batch failed", zap.Error(err))
+			c.recordError(ctx, err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// consumeBatch writes the changes committed since the checkpoint to the sink.
+func (c *consumer) consumeBatch(ctx context.Context) error {
+	cp, err := c.claim(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		cf       *model.ChangefeedInfo
+		tbl      *changeTable
+		decoders *tableDecoders
+	)
+	err = c.taskMgr.WithNewSession(func(se sessionctx.Context) error {
+		dom := domain.GetDomain(se)
+		is, err := dom.GetSnapshotInfoSchema(cp.resolvedTS)
+		if err != nil {
+			return err
+		}
+		var tblInfo *model.TableInfo
+		if cf, tblInfo, err = findChangefeed(ctx, is, c.meta); err != nil || cf == nil {
+			return err
+		}
+		c.meta.TableID = tblInfo.ID
+		tbl = newChangeTable(is, tblInfo, cf)
+		decoders = newTableDecoders(dom, tbl.columns)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if cf == nil {
+		return errChangefeedDropped
+	}
+	if err = c.keepCheckpoint(ctx, cp.checkpointTS); err != nil {
+		return err
+	}
+
+	if c.sink == nil {
+		if c.sink, err = openSink(ctx, cf); err != nil {
+			return err
+		}
+	}
+	changes, consumed, err := c.readChanges(ctx, cp, decoders)
+	if err != nil {
+		return err
+	}
+	w := &batchWriter{sink: c.sink, tbl: tbl, batch: cp.batch, offset: cp.writtenChanges}
+	for _, change := range changes {
+		if err = w.add(ctx, change); err != nil {
+			return err
+		}
+	}
+	if err = w.flush(ctx); err != nil {
+		return err
+	}
+	return c.advance(ctx, cp, w.offset-cp.writtenChanges, consumed)
+}
+
+// keepCheckpoint keeps the snapshot of the checkpoint ts from GC by the service
+// safe point of the changefeed.
+func (c *consumer) keepCheckpoint(ctx context.Context, checkpointTS uint64) error {
+	s, ok := c.store.(kv.StorageWithPD)
+	if !ok {
+		return nil
+	}
+	minSafePoint, err := s.GetPDClient().UpdateServiceGCSafePoint(ctx, serviceSafePointID(c.meta.ChangefeedID), safePointTTL, checkpointTS)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if minSafePoint > checkpointTS {
+		return errors.Errorf("the checkpoint ts %d of the changefeed is older than the GC safe point %d", checkpointTS, minSafePoint)
+	}
+	return nil
+}
+
+// logRow is a record key logged by a transaction.
+type logRow struct {
+	startTS uint64
+	seq     int64
+	key     kv.Key
+}
+
+// readLog reads the log rows of the transactions which may be committed before
+// the resolved ts. The log rows of the transactions committed before the
+// checkpoint ts are removed by the previous batches.
+func (c *consumer) readLog(ctx context.Context, resolvedTS uint64) ([]logRow, error) {
+	rows, err := c.taskMgr.ExecuteSQLWithNewSession(ctx, "SELECT start_ts, seq, record_key FROM %n.%n WHERE changefeed_id = %? AND start_ts <= %?",
+		SystemDB, LogTable, c.meta.ChangefeedID, resolvedTS)
+	if err != nil {
+		return nil, err
+	}
+	logRows := make([]logRow, 0, len(rows))
+	for _, row := range rows {
+		logRows = append(logRows, logRow{startTS: row.GetUint64(0), seq: row.GetInt64(1), key: row.GetBytes(2)})
+	}
+	return logRows, nil
+}
+
+// readChanges decodes the versions of the logged record keys which are committed
+// in (checkpoint ts, resolved ts] as the changes, the changes are sorted by the
+// commit ts, and the changes of a transaction are sorted by the keys. It also
+// returns the log rows consumed by the batch, which are the log rows of the
+// transactions committed before the resolved ts.
+func (c *consumer) readChanges(ctx context.Context, cp *checkpoint, decoders *tableDecoders) ([]*rowChange, []logRow, error) {
+	rows, err := c.readLog(ctx, cp.resolvedTS)
+	if err != nil || len(rows) == 0 {
+		return nil, nil, err
+	}
+	s, ok := c.store.(helper.Storage)
+	if !ok {
+		return nil, nil, errors.New("the storage doesn't support reading the versions of the keys")
+	}
+	h := helper.NewHelper(s)
+	byKey := make(map[string][]logRow)
+	for _, row := range rows {
+		byKey[string(row.key)] = append(byKey[string(row.key)], row)
+	}
+	var (
+		changes  []*rowChange
+		consumed []logRow
+	)
+	// The keys are read in order, so that a retried batch gets the same changes.
+	for _, key := range slices.Sorted(maps.Keys(byKey)) {
+		if err = ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+		logged := byKey[key]
+		versions, err := getVersions(ctx, h, kv.Key(key), logged, cp.resolvedTS)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, row := range logged {
+			i := slices.IndexFunc(versions, func(v recordVersion) bool { return v.startTS == row.startTS })
+			if i >= 0 && versions[i].commitTS > cp.resolvedTS {
+				// The transaction is committed after the resolved ts, its
+				// changes are written by a later batch.
+				continue
+			}
+			consumed = append(consumed, row)
+			if i < 0 || versions[i].commitTS <= cp.checkpointTS {
+				continue
+			}
+			change, err := decoders.decodeChange(kv.Key(key), versions, i)
+			if err != nil {
+				return nil, nil, err
+			}
+			if change != nil {
+				changes = append(changes, change)
+			}
+		}
+	}
+	slices.SortStableFunc(changes, func(a, b *rowChange) int {
+		return cmp.Compare(a.commitTS, b.commitTS)
+	})
+	return changes, consumed, nil
+}
+
+// recordVersion is a committed version of a record key.
+type recordVersion struct {
+	startTS  uint64
+	commitTS uint64
+	// value is nil if the record is deleted by the version.
+	value []byte
+}
+
+// getVersions returns the committed versions of the record key in the order of
+// the commit ts, the lock and rollback records are skipped. If the key is still
+// locked by a logged transaction, which is committed but not resolved on the
+// key yet, the lock is resolved before reading the versions again.
+func getVersions(ctx context.Context, h *helper.Helper, key kv.Key, logged []logRow, resolvedTS uint64) ([]recordVersion, error) {
+	resp, err := h.GetMvccByEncodedKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if lock := resp.Info.GetLock(); lock != nil && slices.ContainsFunc(logged, func(row logRow) bool { return row.startTS == lock.StartTs }) {
+		bo := tikv.NewBackofferWithVars(ctx, helper.MaxBackoffTimeoutForMvccGet, nil)
+		_, err = h.Store.GetLockResolver().ResolveLocksWithOpts(bo, txnlock.ResolveLocksOptions{
+			CallerStartTS: resolvedTS,
+			Locks: []*txnlock.Lock{{
+				Key:             key,
+				Primary:         lock.GetPrimary(),
+				TxnID:           lock.GetStartTs(),
+				TTL:             lock.GetTtl(),
+				TxnSize:         lock.GetTxnSize(),
+				LockType:        lock.GetType(),
+				UseAsyncCommit:  lock.GetUseAsyncCommit(),
+				LockForUpdateTS: lock.GetForUpdateTs(),
+			}},
+			Lite: true,
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if resp, err = h.GetMvccByEncodedKey(key); err != nil {
+			return nil, err
+		}
+		if resp.Info.GetLock().GetStartTs() == lock.StartTs {
+			return nil, errors.Errorf("the key %s is still locked by the transaction %d", key, lock.StartTs)
+		}
+	}
+
+	// The long values are stored apart from the writes.
+	values := make(map[uint64][]byte, len(resp.Info.Values))
+	for _, v := range resp.Info.Values {
+		values[v.StartTs] = v.Value
+	}
+	versions := make([]recordVersion, 0, len(resp.Info.Writes))
+	for _, w := range resp.Info.Writes {
+		switch w.Type {
+		case kvrpcpb.Op_Put:
+			value := w.ShortValue
+			if len(value) == 0 {
+				value = values[w.StartTs]
+			}
+			if len(value) == 0 {
+				return nil, errors.Errorf("the value of the key %s committed at %d is not found", key, w.CommitTs)
+			}
+			versions = append(versions, recordVersion{startTS: w.StartTs, commitTS: w.CommitTs, value: value})
+		case kvrpcpb.Op_Del:
+			versions = append(versions, recordVersion{startTS: w.StartTs, commitTS: w.CommitTs})
+		}
+	}
+	slices.SortFunc(versions, func(a, b recordVersion) int {
+		return cmp.Compare(a.commitTS, b.commitTS)
+	})
+	return versions, nil
+}
+
+func rowsEqual(a, b []types.Datum) bool {
+	for i := range a {
+		res, err := a[i].Compare(types.DefaultStmtNoWarningContext, &b[i], collate.GetBinaryCollator())
+		if err != nil || res != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// tableDecoders decodes the versions of the records by the version of the table
+// at their commit ts, like they are read at the commit ts.
+type tableDecoders struct {
+	dom     *domain.Domain
+	columns []*model.ColumnInfo
+	cache   map[tableVersion]*rowDecoder
+}
+
+// tableVersion is a physical table in a version of the info schema.
+type tableVersion struct {
+	schemaVersion int64
+	physicalID    int64
+}
+
+func newTableDecoders(dom *domain.Domain, columns []*model.ColumnInfo) *tableDecoders {
+	return &tableDecoders{dom: dom, columns: columns, cache: make(map[tableVersion]*rowDecoder)}
+}
+
+// decodeChange decodes the i-th version of the record key as a change, the
+// before image of the change is the previous version. It returns nil if the
+// version doesn't change the row.
+func (d *tableDecoders) decodeChange(key kv.Key, versions []recordVersion, i int) (*rowChange, error) {
+	v := versions[i]
+	decoder, err := d.decoderAt(v.commitTS, tablecodec.DecodeTableID(key))
+	if err != nil {
+		return nil, err
+	}
+	change := &rowChange{commitTS: v.commitTS}
+	if i > 0 && versions[i-1].value != nil {
+		if change.before, err = decoder.decode(key, versions[i-1].value); err != nil {
+			return nil, err
+		}
+	}
+	if v.value != nil {
+		if change.after, err = decoder.decode(key, v.value); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case change.before == nil && change.after == nil:
+		// The row is inserted and deleted in the transaction.
+		return nil, nil
+	case change.before == nil:
+		change.op = OpInsert
+	case change.after == nil:
+		change.op = OpDelete
+	case rowsEqual(change.before, change.after):
+		// The row is rewritten without change, e.g. by REPLACE with the same
+		// values.
+		return nil, nil
+	default:
+		change.op = OpUpdate
+	}
+	return change, nil
+}
+
+func (d *tableDecoders) decoderAt(ts uint64, physicalID int64) (*rowDecoder, error) {
+	is, err := d.dom.GetSnapshotInfoSchema(ts)
+	if err != nil {
+		return nil, err
+	}
+	key := tableVersion{schemaVersion: is.SchemaMetaVersion(), physicalID: physicalID}
+	if decoder, ok := d.cache[key]; ok {
+		return decoder, nil
+	}
+	tblInfo, ok := is.TableInfoByID(physicalID)
+	if !ok {
+		if tblInfo, _, _ = is.FindTableInfoByPartitionID(physicalID); tblInfo == nil {
+			return nil, errors.Errorf("the table of the physical table %d is not found at %d", physicalID, ts)
+		}
+	}
+	decoder, err := newRowDecoder(tblInfo, d.columns)
+	if err != nil {
+		return nil, err
+	}
+	d.cache[key] = decoder
+	return decoder, nil
+}
+
+// rowDecoder decodes the records of a table to the rows in the order of the
+// columns of the changeTable. The table can be an older version of the
+// changeTable, the columns are matched by the names, and the columns which
+// don't exist in the older version get their original default values.
+type rowDecoder struct {
+	ctx     *exprstatic.ExprContext
+	tblInfo *model.TableInfo
+	cols    []*table.Column
+	// offsets[i] is the offset in cols of the i-th column of the changeTable,
+	// or -1 if the column doesn't exist in the table.
+	offsets  []int
+	defaults []types.Datum
+}
+
+func newRowDecoder(tblInfo *model.TableInfo, columns []*model.ColumnInfo) (*rowDecoder, error) {
+	d := &rowDecoder{
+		// The values of timestamp columns are decoded in UTC.
+		ctx:      exprstatic.NewExprContext(),
+		tblInfo:  tblInfo,
+		offsets:  make([]int, len(columns)),
+		defaults: make([]types.Datum, len(columns)),
+	}
+	for _, col := range tblInfo.Cols() {
+		d.cols = append(d.cols, table.ToColumn(col))
+	}
+	for i, col := range columns {
+		d.offsets[i] = -1
+		for j, c := range d.cols {
+			if c.Name.L == col.Name.L {
+				d.offsets[i] = j
+				break
+			}
+		}
+		if d.offsets[i] < 0 {
+			val, err := table.GetColOriginDefaultValue(d.ctx, col)
+			if err != nil {
+				return nil, err
+			}
+			d.defaults[i] = val
+		}
+	}
+	return d, nil
+}
+
+func (d *rowDecoder) decode(key kv.Key, value []byte) ([]types.Datum, error) {
+	handle, err := tablecodec.DecodeRowKey(key)
+	if err != nil {
+		return nil, err
+	}
+	row, _, err := tables.DecodeRawRowData(d.ctx, d.tblInfo, handle, d.cols, value)
+	if err != nil {
+		return nil, err
+	}
+	datums := make([]types.Datum, len(d.offsets))
+	for i, offset := range d.offsets {
+		if offset < 0 {
+			datums[i] = d.defaults[i]
+		} else {
+			datums[i] = row[offset]
+		}
+	}
+	return datums, nil
+}
+
+// batchWriter writes the changes of a batch to the sink by parts of batchSize
+// changes.
+type batchWriter struct {
+	sink  sink
+	tbl   *changeTable
+	batch int64
+	part  int
+	// offset is the offset of the first pending change in all the changes
+	// written by the changefeed.
+	offset  int64
+	pending []*rowChange
+}
+
+func (w *batchWriter) add(ctx context.Context, change *rowChange) error {
+	w.pending = append(w.pending, change)
+	if len(w.pending) < batchSize {
+		return nil
+	}
+	return w.flush(ctx)
+}
+
+func (w *batchWriter) flush(ctx context.Context) error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	if err := w.sink.write(ctx, w.tbl, w.batch, w.part, w.offset, w.pending); err != nil {
+		return err
+	}
+	w.part++
+	w.offset += int64(len(w.pending))
+	w.pending = w.pending[:0]
+	return nil
+}
+
+// findChangefeed finds the changefeed and its table in the info schema, the
+// changefeed is nil if it doesn't exist.
+func findChangefeed(ctx context.Context, is infoschema.InfoSchema, meta *TaskMeta) (*model.ChangefeedInfo, *model.TableInfo, error) {
+	if tbl, ok := is.TableByID(ctx, meta.TableID); ok {
+		if cf := findChangefeedByID(tbl.Meta(), meta.ChangefeedID); cf != nil {
+			return cf, tbl.Meta(), nil
+		}
+	}
+	// The table is truncated or moved to another schema.
+	for _, schema := range is.AllSchemaNames() {
+		tblInfos, err := is.SchemaTableInfos(ctx, schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tblInfo := range tblInfos {
+			if cf := findChangefeedByID(tblInfo, meta.ChangefeedID); cf != nil {
+				return cf, tblInfo, nil
+			}
+		}
+	}
+	return nil, nil, nil
+}
+
+func findChangefeedByID(tblInfo *model.TableInfo, id int64) *model.ChangefeedInfo {
+	for _, cf := range tblInfo.Changefeeds {
+		if cf.ID == id {
+			return cf
+		}
+	}
+	return nil
+}
+
+// newChangeTable returns the changeTable of the changefeed. The virtual
+// generated columns are not stored in the records, so they are not streamed.
+func newChangeTable(is infoschema.InfoSchema, tblInfo *model.TableInfo, cf *model.ChangefeedInfo) *changeTable {
+	tbl := &changeTable{
+		changefeed: cf.Name.O,
+		table:      tblInfo.Name.O,
+	}
+	for _, col := range tblInfo.Cols() {
+		if !col.IsVirtualGenerated() {
+			tbl.columns = append(tbl.columns, col)
+		}
+	}
+	if dbInfo, ok := is.SchemaByID(tblInfo.DBID); ok {
+		tbl.schema = dbInfo.Name.O
+	}
+	if tblInfo.PKIsHandle {
+		tbl.pkNames = []string{tblInfo.GetPkColInfo().Name.O}
+	} else if pk := tblInfo.GetPrimaryKey(); pk != nil {
+		for _, col := range pk.Columns {
+			tbl.pkNames = append(tbl.pkNames, col.Name.O)
+		}
+	}
+	return tbl
+}
+
+// claim returns the current batch of the changefeed. The resolved ts of the
+// batch is the current TSO if it's not claimed yet, or it's kept if the batch
+// has been claimed by a failed attempt which must be retried.
+func (c *consumer) claim(ctx context.Context) (*checkpoint, error) {
+	id := c.meta.ChangefeedID
+	cp := &checkpoint{}
+	err := c.taskMgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		exec := se.GetSQLExecutor()
+		_, err := sqlexec.ExecSQL(ctx, exec, "INSERT IGNORE INTO %n.%n (changefeed_id, checkpoint_ts) VALUES (%?, %?)",
+			SystemDB, CheckpointTable, id, c.meta.StartTS)
+		if err != nil {
+			return err
+		}
+		rows, err := sqlexec.ExecSQL(ctx, exec, "SELECT batch, checkpoint_ts, resolved_ts, written_changes FROM %n.%n WHERE changefeed_id = %? FOR UPDATE",
+			SystemDB, CheckpointTable, id)
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return errors.Errorf("checkpoint of changefeed %d not found", id)
+		}
+		cp.batch = rows[0].GetInt64(0)
+		cp.checkpointTS = rows[0].GetUint64(1)
+		cp.writtenChanges = rows[0].GetInt64(3)
+		if !rows[0].IsNull(2) {
+			cp.resolvedTS = rows[0].GetUint64(2)
+			return nil
+		}
+		ver, err := c.store.CurrentVersion(kv.GlobalTxnScope)
+		if err != nil {
+			return err
+		}
+		cp.resolvedTS = ver.Ver
+		// A batch reads at most batchLogRows log rows. The transactions of the
+		// following log rows start after the resolved ts, so they are committed
+		// after it.
+		rows, err = sqlexec.ExecSQL(ctx, exec, "SELECT start_ts FROM %n.%n WHERE changefeed_id = %? ORDER BY start_ts, seq LIMIT %?, 1",
+			SystemDB, LogTable, id, batchLogRows)
+		if err != nil {
+			return err
+		}
+		if len(rows) > 0 && rows[0].GetUint64(0)-1 > cp.checkpointTS {
+			cp.resolvedTS = min(cp.resolvedTS, rows[0].GetUint64(0)-1)
+		}
+		_, err = sqlexec.ExecSQL(ctx, exec, "UPDATE %n.%n SET resolved_ts = %? WHERE changefeed_id = %?",
+			SystemDB, CheckpointTable, cp.resolvedTS, id)
+		return err
+	})
+	return cp, err
+}
+
+// advance moves the checkpoint to the resolved ts of the written batch, and
+// removes the log rows consumed by the batch in the same transaction.
+func (c *consumer) advance(ctx context.Context, cp *checkpoint, written int64, consumed []logRow) error {
+	return c.taskMgr.WithNewTxn(ctx, func(se sessionctx.Context) error {
+		exec := se.GetSQLExecutor()
+		_, err := sqlexec.ExecSQL(ctx, exec, "UPDATE %n.%n SET batch = batch + 1, checkpoint_ts = resolved_ts, resolved_ts = NULL, "+
+			"written_changes = written_changes + %?, last_error = NULL WHERE changefeed_id = %? AND batch = %?",
+			SystemDB, CheckpointTable, written, c.meta.ChangefeedID, cp.batch)
+		if err != nil {
+			return err
+		}
+		// The log is purged by the primary key.
+		for start := 0; start < len(consumed); start += logPurgeBatchSize {
+			end := min(start+logPurgeBatchSize, len(consumed))
+			sql := new(strings.Builder)
+			sqlescape.MustFormatSQL(sql, "DELETE FROM %n.%n WHERE changefeed_id = %? AND (start_ts, seq) IN (", SystemDB, LogTable, c.meta.ChangefeedID)
+			for i, row := range consumed[start:end] {
+				if i > 0 {
+					sql.WriteString(", ")
+				}
+				sqlescape.MustFormatSQL(sql, "(%?, %?)", row.startTS, row.seq)
+			}
+			sql.WriteString(")")
+			if _, err = sqlexec.ExecSQL(ctx, exec, sql.String()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (c *consumer) recordError(ctx context.Context, err error) {
+	_, updateErr := c.taskMgr.ExecuteSQLWithNewSession(ctx, "UPDATE %n.%n SET last_error = %? WHERE changefeed_id = %?",
+		SystemDB, CheckpointTable, err.Error(), c.meta.ChangefeedID)
+	if updateErr != nil {
+		c.logger.Warn("record changefeed error failed", zap.Error(updateErr))
+	}
+}
+
+// DeleteChangefeedData deletes the checkpoint, the log and the service safe point
+// of a dropped changefeed.
+func DeleteChangefeedData(ctx context.Context, store kv.Storage, exec sqlexec.SQLExecutor, changefeedID int64) error {
+	if s, ok := store.(kv.StorageWithPD); ok {
+		// The service safe point is removed if its TTL is 0.
+		if _, err := s.GetPDClient().UpdateServiceGCSafePoint(ctx, serviceSafePointID(changefeedID), 0, 0); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	_, err := sqlexec.ExecSQL(ctx, exec, "DELETE FROM %n.%n WHERE changefeed_id = %?", SystemDB, CheckpointTable, changefeedID)
+	if err != nil {
+		return err
+	}
+	_, err = sqlexec.ExecSQL(ctx, exec, "DELETE FROM %n.%n WHERE changefeed_id = %?", SystemDB, LogTable, changefeedID)
+	return err
+}
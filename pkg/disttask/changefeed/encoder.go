@@ -0,0 +1,413 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// rowChange is a decoded row change, the datums of the rows are in the order of
+// the columns of changeTable.
+type rowChange struct {
+	commitTS uint64
+	op       string
+	before   []types.Datum
+	after    []types.Datum
+}
+
+// changeTable is the table whose changes are encoded.
+type changeTable struct {
+	changefeed string
+	schema     string
+	table      string
+	columns    []*model.ColumnInfo
+	pkNames    []string
+}
+
+// encodeChanges encodes the changes of a batch in the format. The encoded
+// content only depends on the changes and the name of the file, so a retried
+// batch is encoded to the same content.
+func encodeChanges(buf *bytes.Buffer, format string, tbl *changeTable, changes []*rowChange, name string) error {
+	switch format {
+	case model.ChangefeedFormatDebezium:
+		return encodeDebezium(buf, tbl, changes)
+	case model.ChangefeedFormatAvro:
+		return encodeAvro(buf, tbl, changes, name)
+	default:
+		return encodeCanalJSON(buf, tbl, changes)
+	}
+}
+
+type canalJSONMessage struct {
+	ID        int64             `json:"id"`
+	Database  string            `json:"database"`
+	Table     string            `json:"table"`
+	PKNames   []string          `json:"pkNames"`
+	IsDDL     bool              `json:"isDdl"`
+	Type      string            `json:"type"`
+	ES        int64             `json:"es"`
+	TS        int64             `json:"ts"`
+	SQL       string            `json:"sql"`
+	MySQLType map[string]string `json:"mysqlType"`
+	Data      []map[string]any  `json:"data"`
+	Old       []map[string]any  `json:"old"`
+}
+
+// encodeCanalJSON encodes the changes as the canal-json messages, one message per line.
+// Like canal, all the values are strings, and the binary values are decoded by ISO-8859-1.
+func encodeCanalJSON(buf *bytes.Buffer, tbl *changeTable, changes []*rowChange) error {
+	mysqlTypes := make(map[string]string, len(tbl.columns))
+	for _, col := range tbl.columns {
+		mysqlTypes[col.Name.O] = types.TypeToStr(col.GetType(), col.GetCharset())
+	}
+	enc := newJSONEncoder(buf)
+	for _, change := range changes {
+		ts := oracle.ExtractPhysical(change.commitTS)
+		msg := &canalJSONMessage{
+			Database:  tbl.schema,
+			Table:     tbl.table,
+			PKNames:   tbl.pkNames,
+			Type:      strings.ToUpper(change.op),
+			ES:        ts,
+			TS:        ts,
+			MySQLType: mysqlTypes,
+		}
+		data, old := change.after, change.before
+		if change.op == OpDelete {
+			data, old = change.before, nil
+		}
+		row, err := canalJSONRow(tbl, data)
+		if err != nil {
+			return err
+		}
+		msg.Data = []map[string]any{row}
+		if old != nil {
+			if row, err = canalJSONRow(tbl, old); err != nil {
+				return err
+			}
+			msg.Old = []map[string]any{row}
+		}
+		if err = enc.Encode(msg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func canalJSONRow(tbl *changeTable, row []types.Datum) (map[string]any, error) {
+	m := make(map[string]any, len(tbl.columns))
+	for i, col := range tbl.columns {
+		d := row[i]
+		if d.IsNull() {
+			m[col.Name.O] = nil
+			continue
+		}
+		if isBinaryColumn(col) {
+			m[col.Name.O] = latin1String(d.GetBytes())
+			continue
+		}
+		s, err := d.ToString()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		m[col.Name.O] = s
+	}
+	return m, nil
+}
+
+func latin1String(b []byte) string {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+type debeziumMessage struct {
+	Before map[string]any `json:"before"`
+	After  map[string]any `json:"after"`
+	Source debeziumSource `json:"source"`
+	Op     string         `json:"op"`
+	TsMs   int64          `json:"ts_ms"`
+}
+
+type debeziumSource struct {
+	Connector  string `json:"connector"`
+	Name       string `json:"name"`
+	TsMs       int64  `json:"ts_ms"`
+	DB         string `json:"db"`
+	Table      string `json:"table"`
+	Changefeed string `json:"changefeed"`
+	SeqTS      uint64 `json:"seq_ts"`
+}
+
+var debeziumOps = map[string]string{OpInsert: "c", OpUpdate: "u", OpDelete: "d"}
+
+// encodeDebezium encodes the changes as the debezium change events without the
+// schema, one event per line. The numbers are kept as JSON numbers, the binary
+// values are encoded by base64, and the other values are strings.
+func encodeDebezium(buf *bytes.Buffer, tbl *changeTable, changes []*rowChange) error {
+	enc := newJSONEncoder(buf)
+	for _, change := range changes {
+		ts := oracle.ExtractPhysical(change.commitTS)
+		msg := &debeziumMessage{
+			Source: debeziumSource{
+				Connector:  "tidb",
+				Name:       tbl.changefeed,
+				TsMs:       ts,
+				DB:         tbl.schema,
+				Table:      tbl.table,
+				Changefeed: tbl.changefeed,
+				SeqTS:      change.commitTS,
+			},
+			Op:   debeziumOps[change.op],
+			TsMs: ts,
+		}
+		var err error
+		if change.before != nil {
+			if msg.Before, err = debeziumRow(tbl, change.before); err != nil {
+				return err
+			}
+		}
+		if change.after != nil {
+			if msg.After, err = debeziumRow(tbl, change.after); err != nil {
+				return err
+			}
+		}
+		if err = enc.Encode(msg); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func debeziumRow(tbl *changeTable, row []types.Datum) (map[string]any, error) {
+	m := make(map[string]any, len(tbl.columns))
+	for i, col := range tbl.columns {
+		d := row[i]
+		switch d.Kind() {
+		case types.KindNull:
+			m[col.Name.O] = nil
+		case types.KindInt64:
+			m[col.Name.O] = d.GetInt64()
+		case types.KindUint64:
+			m[col.Name.O] = d.GetUint64()
+		case types.KindFloat32, types.KindFloat64:
+			m[col.Name.O] = d.GetFloat64()
+		default:
+			if isBinaryColumn(col) {
+				// []byte is encoded by base64.
+				m[col.Name.O] = d.GetBytes()
+				continue
+			}
+			s, err := d.ToString()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			m[col.Name.O] = s
+		}
+	}
+	return m, nil
+}
+
+func newJSONEncoder(buf *bytes.Buffer) *json.Encoder {
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	return enc
+}
+
+func isBinaryColumn(col *model.ColumnInfo) bool {
+	switch col.GetType() {
+	case mysql.TypeBit:
+		return true
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeTinyBlob,
+		mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		return col.GetCharset() == charset.CharsetBin
+	}
+	return false
+}
+
+// Avro types of the columns.
+const (
+	avroLong   = "long"
+	avroDouble = "double"
+	avroBytes  = "bytes"
+	avroString = "string"
+)
+
+func avroType(col *model.ColumnInfo) string {
+	switch col.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		return avroLong
+	case mysql.TypeLonglong:
+		// An unsigned bigint may overflow the long type.
+		if mysql.HasUnsignedFlag(col.GetFlag()) {
+			return avroString
+		}
+		return avroLong
+	case mysql.TypeFloat, mysql.TypeDouble:
+		return avroDouble
+	}
+	if isBinaryColumn(col) {
+		return avroBytes
+	}
+	return avroString
+}
+
+// avroName converts the name to a valid avro name.
+func avroName(name string) string {
+	var sb strings.Builder
+	for i, c := range name {
+		switch {
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			sb.WriteRune(c)
+		case c >= '0' && c <= '9':
+			if i == 0 {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(c)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+type avroField struct {
+	Name    string `json:"name"`
+	Type    any    `json:"type"`
+	Default any    `json:"default"`
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+// avroSchema returns the schema of the change records: the operation, the seq
+// ts, and the rows before and after the change, whose columns are all nullable.
+func avroSchema(tbl *changeTable) ([]byte, error) {
+	row := avroRecord{Type: "record", Name: "Row"}
+	for _, col := range tbl.columns {
+		row.Fields = append(row.Fields, avroField{Name: avroName(col.Name.O), Type: []string{"null", avroType(col)}})
+	}
+	schema := avroRecord{
+		Type:      "record",
+		Name:      avroName(tbl.table),
+		Namespace: avroName(tbl.schema),
+		Fields: []avroField{
+			{Name: "op", Type: avroString, Default: ""},
+			{Name: "seq_ts", Type: avroLong, Default: 0},
+			{Name: "before", Type: []any{"null", row}},
+			{Name: "after", Type: []any{"null", "Row"}},
+		},
+	}
+	b, err := json.Marshal(schema)
+	return b, errors.Trace(err)
+}
+
+// encodeAvro encodes the changes as an avro object container file with a single
+// block. The sync marker is derived from the file name.
+func encodeAvro(buf *bytes.Buffer, tbl *changeTable, changes []*rowChange, name string) error {
+	schema, err := avroSchema(tbl)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(name))
+	sync := sum[:16]
+
+	var b []byte
+	b = append(b, 'O', 'b', 'j', 1)
+	b = appendAvroLong(b, 2)
+	b = appendAvroBytes(b, []byte("avro.codec"))
+	b = appendAvroBytes(b, []byte("null"))
+	b = appendAvroBytes(b, []byte("avro.schema"))
+	b = appendAvroBytes(b, schema)
+	b = appendAvroLong(b, 0)
+	b = append(b, sync...)
+
+	var data []byte
+	for _, change := range changes {
+		data = appendAvroBytes(data, []byte(change.op))
+		data = appendAvroLong(data, int64(change.commitTS))
+		for _, row := range [][]types.Datum{change.before, change.after} {
+			if row == nil {
+				data = appendAvroLong(data, 0)
+				continue
+			}
+			data = appendAvroLong(data, 1)
+			for i, col := range tbl.columns {
+				if data, err = appendAvroValue(data, row[i], avroType(col)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	b = appendAvroLong(b, int64(len(changes)))
+	b = appendAvroLong(b, int64(len(data)))
+	b = append(b, data...)
+	b = append(b, sync...)
+	buf.Write(b)
+	return nil
+}
+
+// appendAvroValue appends a value of the nullable column, which is a union of
+// null and the type of the column.
+func appendAvroValue(b []byte, d types.Datum, tp string) ([]byte, error) {
+	if d.IsNull() {
+		return appendAvroLong(b, 0), nil
+	}
+	b = appendAvroLong(b, 1)
+	switch tp {
+	case avroLong:
+		if d.Kind() == types.KindUint64 {
+			return appendAvroLong(b, int64(d.GetUint64())), nil
+		}
+		return appendAvroLong(b, d.GetInt64()), nil
+	case avroDouble:
+		return binary.LittleEndian.AppendUint64(b, math.Float64bits(d.GetFloat64())), nil
+	case avroBytes:
+		return appendAvroBytes(b, d.GetBytes()), nil
+	}
+	s, err := d.ToString()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return appendAvroBytes(b, []byte(s)), nil
+}
+
+// appendAvroLong appends a long by the zig-zag variable-length encoding.
+func appendAvroLong(b []byte, v int64) []byte {
+	return binary.AppendUvarint(b, uint64((v<<1)^(v>>63)))
+}
+
+func appendAvroBytes(b []byte, v []byte) []byte {
+	b = appendAvroLong(b, int64(len(v)))
+	return append(b, v...)
+}
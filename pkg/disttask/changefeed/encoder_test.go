@@ -0,0 +1,111 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+func newTestChangeTable() *changeTable {
+	id := &model.ColumnInfo{Name: ast.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeLong)}
+	v := &model.ColumnInfo{Name: ast.NewCIStr("v"), FieldType: *types.NewFieldType(mysql.TypeVarchar)}
+	v.SetCharset(charset.CharsetUTF8MB4)
+	b := &model.ColumnInfo{Name: ast.NewCIStr("b"), FieldType: *types.NewFieldType(mysql.TypeBlob)}
+	b.SetCharset(charset.CharsetBin)
+	return &changeTable{
+		changefeed: "cf",
+		schema:     "test",
+		table:      "t",
+		columns:    []*model.ColumnInfo{id, v, b},
+		pkNames:    []string{"id"},
+	}
+}
+
+func newTestChanges() []*rowChange {
+	ts := oracle.ComposeTS(1700000000000, 0)
+	row1 := []types.Datum{types.NewIntDatum(1), types.NewStringDatum("a"), types.NewBytesDatum([]byte{0xff})}
+	row2 := []types.Datum{types.NewIntDatum(1), types.NewStringDatum("b"), types.NewDatum(nil)}
+	return []*rowChange{
+		{commitTS: ts, op: OpInsert, after: row1},
+		{commitTS: ts + 1, op: OpUpdate, before: row1, after: row2},
+		{commitTS: ts + 2, op: OpDelete, before: row2},
+	}
+}
+
+func TestEncodeCanalJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, encodeChanges(&buf, model.ChangefeedFormatCanalJSON, newTestChangeTable(), newTestChanges(), "1/1.json"))
+	mysqlType := `"mysqlType":{"b":"blob","id":"int","v":"varchar"}`
+	require.Equal(t, []string{
+		`{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,"type":"INSERT","es":1700000000000,"ts":1700000000000,"sql":"",` +
+			mysqlType + `,"data":[{"b":"ÿ","id":"1","v":"a"}],"old":null}`,
+		`{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,"type":"UPDATE","es":1700000000000,"ts":1700000000000,"sql":"",` +
+			mysqlType + `,"data":[{"b":null,"id":"1","v":"b"}],"old":[{"b":"ÿ","id":"1","v":"a"}]}`,
+		`{"id":0,"database":"test","table":"t","pkNames":["id"],"isDdl":false,"type":"DELETE","es":1700000000000,"ts":1700000000000,"sql":"",` +
+			mysqlType + `,"data":[{"b":null,"id":"1","v":"b"}],"old":null}`,
+		"",
+	}, strings.Split(buf.String(), "\n"))
+}
+
+func TestEncodeDebezium(t *testing.T) {
+	var buf bytes.Buffer
+	changes := newTestChanges()
+	require.NoError(t, encodeChanges(&buf, model.ChangefeedFormatDebezium, newTestChangeTable(), changes[1:2], "1/1.json"))
+	require.Equal(t, `{"before":{"b":"/w==","id":1,"v":"a"},"after":{"b":null,"id":1,"v":"b"},`+
+		`"source":{"connector":"tidb","name":"cf","ts_ms":1700000000000,"db":"test","table":"t","changefeed":"cf","seq_ts":`+
+		"445644800000000001"+`},"op":"u","ts_ms":1700000000000}`+"\n", buf.String())
+}
+
+func TestEncodeAvro(t *testing.T) {
+	var buf1, buf2 bytes.Buffer
+	tbl := newTestChangeTable()
+	require.NoError(t, encodeChanges(&buf1, model.ChangefeedFormatAvro, tbl, newTestChanges(), "1/1.avro"))
+	require.True(t, bytes.HasPrefix(buf1.Bytes(), []byte("Obj\x01")))
+	require.Contains(t, buf1.String(), `{"type":"record","name":"t","namespace":"test","fields":[{"name":"op","type":"string","default":""}`)
+	// the content is the same when the batch is retried
+	require.NoError(t, encodeChanges(&buf2, model.ChangefeedFormatAvro, tbl, newTestChanges(), "1/1.avro"))
+	require.Equal(t, buf1.Bytes(), buf2.Bytes())
+
+	require.Equal(t, []byte{0}, appendAvroLong(nil, 0))
+	require.Equal(t, []byte{1}, appendAvroLong(nil, -1))
+	require.Equal(t, []byte{0x80, 0x01}, appendAvroLong(nil, 64))
+	require.Equal(t, "_1a_b", avroName("1a-b"))
+}
+
+func TestValidateSink(t *testing.T) {
+	format, err := ValidateSink("file:///tmp/cf", "")
+	require.NoError(t, err)
+	require.Equal(t, model.ChangefeedFormatCanalJSON, format)
+	format, err = ValidateSink("local:///tmp/cf", "Debezium")
+	require.NoError(t, err)
+	require.Equal(t, model.ChangefeedFormatDebezium, format)
+	_, err = ValidateSink("file:///tmp/cf", "json")
+	require.EqualError(t, err, "unsupported format 'json'")
+	_, err = ValidateSink("kafka://127.0.0.1:9092/topic", "")
+	require.EqualError(t, err, "kafka brokers are not supported, use a URI like 'segment+file:///path?topic=t' to write the log segments of a topic")
+
+	require.Equal(t, "5/00000000000000000001-000000.json", fileName(5, 1, 0, model.ChangefeedFormatCanalJSON))
+	require.Equal(t, "5/00000000000000000012-000003.avro", fileName(5, 12, 3, model.ChangefeedFormatAvro))
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// LogChanges logs the record keys written by the transaction to the tables with
+// changefeeds in LogTable, one row per key and changefeed. It's called right
+// before the transaction commits, so the log rows are committed together with
+// the keys. physicalIDs are the IDs of the physical tables changed by the
+// transaction.
+func LogChanges(ctx context.Context, mctx table.MutateContext, txn kv.Transaction, is infoschema.InfoSchema, physicalIDs []int64) error {
+	var (
+		logTable table.Table
+		seq      int64
+	)
+	for _, id := range physicalIDs {
+		tblInfo, ok := is.TableInfoByID(id)
+		if !ok {
+			if tblInfo, _, _ = is.FindTableInfoByPartitionID(id); tblInfo == nil {
+				continue
+			}
+		}
+		if len(tblInfo.Changefeeds) == 0 {
+			continue
+		}
+		if logTable == nil {
+			var err error
+			logTable, err = is.TableByName(ctx, ast.NewCIStr(SystemDB), ast.NewCIStr(LogTable))
+			if err != nil {
+				return err
+			}
+		}
+		keys, err := writtenRecordKeys(txn, id)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			for _, cf := range tblInfo.Changefeeds {
+				seq++
+				row := []types.Datum{types.NewIntDatum(cf.ID), types.NewUintDatum(txn.StartTS()), types.NewIntDatum(seq), types.NewBytesDatum(key)}
+				if _, err = logTable.AddRecord(mctx, txn, row, table.WithCtx(ctx)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// writtenRecordKeys returns the record keys of the physical table which are set
+// or deleted in the transaction.
+func writtenRecordKeys(txn kv.Transaction, physicalID int64) ([]kv.Key, error) {
+	prefix := tablecodec.GenTableRecordPrefix(physicalID)
+	iter, err := txn.GetMemBuffer().Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer iter.Close()
+	var keys []kv.Key
+	for iter.Valid() {
+		keys = append(keys, iter.Key().Clone())
+		if err = iter.Next(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return keys, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package changefeed streams the row changes of the tables to the sinks by DXF.
+//
+// The changes are decoded from the committed KV mutations of the records of the
+// table. A transaction which writes the records of a table with changefeeds logs
+// the written record keys in mysql.tidb_changefeed_log before it commits, so the
+// log rows are committed together with the records. Each changefeed runs as a
+// DXF task with a single subtask, which loops:
+//  1. claims the next batch by recording the current TSO as the resolved ts of
+//     the batch in mysql.tidb_changefeed_checkpoint.
+//  2. reads the logged record keys, and decodes the versions of the keys which
+//     are committed in (checkpoint ts, resolved ts] by rowcodec as the changes,
+//     in the order of the commit ts.
+//  3. writes the changes to the sink as the files named by the batch number.
+//  4. advances the checkpoint ts to the resolved ts and removes the consumed log
+//     rows in the same transaction.
+//
+// Every committed version of a record is streamed as a change, the before image
+// of the change is the previous version of the record. The changes of a record
+// in a transaction are streamed as a single change because they are committed as
+// a single version. The changes of a batch never change, so a batch which is
+// retried after a failure overwrites the files by the same content, that's how
+// the sinks get the exactly-once semantics. The checkpoint ts is kept from GC by
+// a service safe point of the changefeed.
+//
+// IMPORT INTO, TRUNCATE TABLE and the restore of BR don't write the records by
+// the transactions of the sessions, so their changes are not streamed.
+package changefeed
+
+import (
+	"fmt"
+
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+const (
+	// CheckpointTable is the table storing the progress of the changefeeds.
+	CheckpointTable = "tidb_changefeed_checkpoint"
+	// LogTable is the table storing the record keys written by the transactions
+	// for the changefeeds.
+	LogTable = "tidb_changefeed_log"
+	// SystemDB is the schema of CheckpointTable and LogTable.
+	SystemDB = mysql.SystemDB
+)
+
+// Operations of the row changes.
+const (
+	OpInsert = "insert"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+// TaskMeta is the meta of a changefeed task.
+type TaskMeta struct {
+	ChangefeedID int64 `json:"changefeed_id"`
+	SchemaID     int64 `json:"schema_id"`
+	// TableID is the ID of the table when the changefeed is created, the table
+	// is searched by the changefeed ID if it's truncated or moved.
+	TableID int64 `json:"table_id"`
+	// StartTS is the ts the changefeed is created at, the changes committed
+	// after it are streamed.
+	StartTS uint64 `json:"start_ts"`
+}
+
+// TaskKey returns the key of the task of the changefeed.
+func TaskKey(changefeedID int64) string {
+	return fmt.Sprintf("changefeed/%d", changefeedID)
+}
+
+// serviceSafePointID returns the ID of the service safe point which keeps the
+// checkpoint of the changefeed from GC.
+func serviceSafePointID(changefeedID int64) string {
+	return fmt.Sprintf("changefeed-%d", changefeedID)
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// Scheduler schedules a changefeed task. A changefeed has a single step with a
+// single subtask which streams the changes until the changefeed is dropped.
+type Scheduler struct {
+	*scheduler.BaseScheduler
+	logger *zap.Logger
+}
+
+var _ scheduler.Scheduler = (*Scheduler)(nil)
+
+// NewScheduler creates a new changefeed scheduler.
+func NewScheduler(ctx context.Context, task *proto.Task, param scheduler.Param) scheduler.Scheduler {
+	return &Scheduler{
+		BaseScheduler: scheduler.NewBaseScheduler(ctx, task, param),
+		logger:        logutil.BgLogger().With(zap.Int64("taskID", task.ID), zap.String("taskKey", task.Key)),
+	}
+}
+
+// Init implements the scheduler.Scheduler interface.
+func (s *Scheduler) Init() error {
+	s.BaseScheduler.Extension = s
+	return s.BaseScheduler.Init()
+}
+
+// OnTick implements the scheduler.Extension interface.
+func (*Scheduler) OnTick(context.Context, *proto.Task) {}
+
+// OnNextSubtasksBatch implements the scheduler.Extension interface.
+func (s *Scheduler) OnNextSubtasksBatch(_ context.Context, _ fstorage.TaskHandle, task *proto.Task,
+	_ []string, nextStep proto.Step) ([][]byte, error) {
+	if nextStep != proto.ChangefeedStepStream {
+		return nil, errors.Errorf("unexpected step %s", proto.Step2Str(task.Type, nextStep))
+	}
+	s.logger.Info("start streaming changes")
+	return [][]byte{task.Meta}, nil
+}
+
+// OnDone implements the scheduler.Extension interface.
+func (s *Scheduler) OnDone(_ context.Context, _ fstorage.TaskHandle, task *proto.Task) error {
+	s.logger.Info("changefeed task done", zap.Stringer("state", task.State), zap.Error(task.Error))
+	return nil
+}
+
+// GetEligibleInstances implements the scheduler.Extension interface.
+func (*Scheduler) GetEligibleInstances(context.Context, *proto.Task) ([]string, error) {
+	return nil, nil
+}
+
+// IsRetryableErr implements the scheduler.Extension interface.
+func (*Scheduler) IsRetryableErr(error) bool {
+	return true
+}
+
+// GetNextStep implements the scheduler.Extension interface.
+func (*Scheduler) GetNextStep(task *proto.TaskBase) proto.Step {
+	switch task.Step {
+	case proto.StepInit:
+		return proto.ChangefeedStepStream
+	default:
+		return proto.StepDone
+	}
+}
+
+type cleanUpChangefeed struct{}
+
+var _ scheduler.CleanUpRoutine = (*cleanUpChangefeed)(nil)
+
+func newCleanUpChangefeed() scheduler.CleanUpRoutine {
+	return &cleanUpChangefeed{}
+}
+
+// CleanUp deletes the checkpoint and the service safe point if the changefeed
+// is dropped, they are kept if the task fails.
+func (*cleanUpChangefeed) CleanUp(ctx context.Context, task *proto.Task) error {
+	meta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, meta); err != nil {
+		return err
+	}
+	taskMgr, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalDistTask)
+	return taskMgr.WithNewSession(func(se sessionctx.Context) error {
+		is := se.GetDomainInfoSchema().(infoschema.InfoSchema)
+		cf, _, err := findChangefeed(ctx, is, meta)
+		if err != nil || cf != nil {
+			return err
+		}
+		return DeleteChangefeedData(ctx, se.GetStore(), se.GetSQLExecutor(), meta.ChangefeedID)
+	})
+}
+
+func init() {
+	scheduler.RegisterSchedulerCleanUpFactory(proto.Changefeed, newCleanUpChangefeed)
+}
@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"math"
+	"slices"
+)
+
+// maxRecordBatchBytes is the max size of the values in a record batch, it's less
+// than the default message.max.bytes of the kafka brokers.
+const maxRecordBatchBytes = 512 * 1024
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// encodeSegment encodes the values as the records of a log segment in the
+// format of kafka, starting at baseOffset. timestamps are the timestamps of the
+// values in milliseconds. The records have no key and no header, and they are
+// put in the uncompressed record batches of magic v2.
+func encodeSegment(baseOffset int64, timestamps []int64, values [][]byte) []byte {
+	var buf []byte
+	for start := 0; start < len(values); {
+		end, size := start, 0
+		for end < len(values) && (end == start || size+len(values[end]) <= maxRecordBatchBytes) {
+			size += len(values[end])
+			end++
+		}
+		buf = appendRecordBatch(buf, baseOffset+int64(start), timestamps[start:end], values[start:end])
+		start = end
+	}
+	return buf
+}
+
+// appendRecordBatch appends a record batch of the values, see
+// https://kafka.apache.org/documentation/#recordbatch for the format.
+func appendRecordBatch(buf []byte, baseOffset int64, timestamps []int64, values [][]byte) []byte {
+	// The part after the crc, which is covered by the crc.
+	var body []byte
+	body = binary.BigEndian.AppendUint16(body, 0) // attributes
+	body = binary.BigEndian.AppendUint32(body, uint32(len(values)-1))
+	body = binary.BigEndian.AppendUint64(body, uint64(timestamps[0]))          // first timestamp
+	body = binary.BigEndian.AppendUint64(body, uint64(slices.Max(timestamps))) // max timestamp
+	body = binary.BigEndian.AppendUint64(body, math.MaxUint64)                 // producer ID -1
+	body = binary.BigEndian.AppendUint16(body, math.MaxUint16)                 // producer epoch -1
+	body = binary.BigEndian.AppendUint32(body, math.MaxUint32)                 // base sequence -1
+	body = binary.BigEndian.AppendUint32(body, uint32(len(values)))
+	var record []byte
+	for i, value := range values {
+		record = record[:0]
+		record = append(record, 0)                                        // attributes
+		record = binary.AppendVarint(record, timestamps[i]-timestamps[0]) // timestamp delta
+		record = binary.AppendVarint(record, int64(i))                    // offset delta
+		record = binary.AppendVarint(record, -1)                          // null key
+		record = binary.AppendVarint(record, int64(len(value)))
+		record = append(record, value...)
+		record = binary.AppendVarint(record, 0) // header count
+		body = binary.AppendVarint(body, int64(len(record)))
+		body = append(body, record...)
+	}
+
+	buf = binary.BigEndian.AppendUint64(buf, uint64(baseOffset))
+	// The batch length counts the partition leader epoch, the magic, the crc
+	// and the body.
+	buf = binary.BigEndian.AppendUint32(buf, uint32(4+1+4+len(body)))
+	buf = binary.BigEndian.AppendUint32(buf, 0) // partition leader epoch
+	buf = append(buf, 2)                        // magic
+	buf = binary.BigEndian.AppendUint32(buf, crc32.Checksum(body, castagnoliTable))
+	return append(buf, body...)
+}
@@ -0,0 +1,191 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/tikv/client-go/v2/oracle"
+)
+
+// segmentSchemePrefix is the prefix of the URI scheme of the segment sink, e.g.
+// segment+file:///path?topic=t.
+const segmentSchemePrefix = "segment+"
+
+// ValidateSink checks the sink URI and the format of a changefeed, it returns
+// the format in lower case, or canal-json if the format is empty.
+//
+// The changes are written to the files of an external storage, or to the log
+// segments of a topic in the format of kafka in an external storage if the
+// scheme of the URI is prefixed by "segment+". The changes are not produced to
+// the kafka brokers.
+func ValidateSink(sinkURI, format string) (string, error) {
+	format = strings.ToLower(format)
+	switch format {
+	case "":
+		format = model.ChangefeedFormatCanalJSON
+	case model.ChangefeedFormatCanalJSON, model.ChangefeedFormatDebezium, model.ChangefeedFormatAvro:
+	default:
+		return "", errors.Errorf("unsupported format '%s'", format)
+	}
+
+	storageURI, topic, isSegment, err := parseSinkURI(sinkURI)
+	if err != nil {
+		return "", err
+	}
+	if isSegment {
+		if format == model.ChangefeedFormatAvro {
+			return "", errors.New("avro format is not supported by the segment sink")
+		}
+		if topic != "" && !isValidTopic(topic) {
+			return "", errors.Errorf("invalid topic '%s'", topic)
+		}
+	}
+	if _, err = storage.ParseBackend(storageURI, nil); err != nil {
+		return "", err
+	}
+	return format, nil
+}
+
+// parseSinkURI returns the URI of the external storage and the topic of the
+// sink, the topic is only used by the segment sink.
+func parseSinkURI(sinkURI string) (storageURI, topic string, isSegment bool, err error) {
+	// The prefix is cut before parsing, ParseRawURL escapes the "+".
+	if len(sinkURI) < len(segmentSchemePrefix) || !strings.EqualFold(sinkURI[:len(segmentSchemePrefix)], segmentSchemePrefix) {
+		u, err := storage.ParseRawURL(sinkURI)
+		if err != nil {
+			return "", "", false, err
+		}
+		if strings.EqualFold(u.Scheme, "kafka") {
+			return "", "", false, errors.New("kafka brokers are not supported, use a URI like 'segment+file:///path?topic=t' to write the log segments of a topic")
+		}
+		return sinkURI, "", false, nil
+	}
+	u, err := storage.ParseRawURL(sinkURI[len(segmentSchemePrefix):])
+	if err != nil {
+		return "", "", false, err
+	}
+	query := u.Query()
+	topic = query.Get("topic")
+	query.Del("topic")
+	u.RawQuery = query.Encode()
+	return u.String(), topic, true, nil
+}
+
+// isValidTopic checks the topic name by the rules of kafka.
+func isValidTopic(topic string) bool {
+	if len(topic) > 249 || topic == "." || topic == ".." {
+		return false
+	}
+	for _, c := range topic {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '.' || c == '_' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// sink writes the changes of a changefeed.
+type sink interface {
+	// write writes a part of the changes of a batch, offset is the offset of the
+	// first change in all the changes written by the changefeed. A retried part
+	// must be written to the same place with the same content.
+	write(ctx context.Context, tbl *changeTable, batch int64, part int, offset int64, changes []*rowChange) error
+}
+
+func openSink(ctx context.Context, cf *model.ChangefeedInfo) (sink, error) {
+	storageURI, topic, isSegment, err := parseSinkURI(cf.SinkURI)
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.ParseBackend(storageURI, nil)
+	if err != nil {
+		return nil, err
+	}
+	store, err := storage.NewWithDefaultOpt(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	if !isSegment {
+		return &fileSink{storage: store, changefeedID: cf.ID, format: cf.Format}, nil
+	}
+	if topic == "" {
+		topic = fmt.Sprintf("tidb-changefeed-%d", cf.ID)
+	}
+	return &segmentSink{storage: store, topic: topic, format: cf.Format}, nil
+}
+
+// fileSink writes the changes as the files of an external storage.
+type fileSink struct {
+	storage      storage.ExternalStorage
+	changefeedID int64
+	format       string
+}
+
+func (s *fileSink) write(ctx context.Context, tbl *changeTable, batch int64, part int, _ int64, changes []*rowChange) error {
+	name := fileName(s.changefeedID, batch, part, s.format)
+	var buf bytes.Buffer
+	if err := encodeChanges(&buf, s.format, tbl, changes, name); err != nil {
+		return err
+	}
+	return s.storage.WriteFile(ctx, name, buf.Bytes())
+}
+
+// fileName returns the name of the file written for a part of a batch. The
+// files of a changefeed are placed in the directory named by the changefeed ID,
+// and they are sorted by the names in the order of the changes.
+func fileName(changefeedID, batch int64, part int, format string) string {
+	ext := "json"
+	if format == model.ChangefeedFormatAvro {
+		ext = "avro"
+	}
+	return fmt.Sprintf("%d/%020d-%06d.%s", changefeedID, batch, part, ext)
+}
+
+// segmentSink writes the changes as the log segments of the single partition of
+// a topic in an external storage, one message per change. The segments are in
+// the format of kafka, they can be loaded by a kafka broker by placing them in
+// its log directory, and the broker rebuilds the indexes.
+type segmentSink struct {
+	storage storage.ExternalStorage
+	topic   string
+	format  string
+}
+
+func (s *segmentSink) write(ctx context.Context, tbl *changeTable, _ int64, _ int, offset int64, changes []*rowChange) error {
+	timestamps := make([]int64, 0, len(changes))
+	values := make([][]byte, 0, len(changes))
+	for _, change := range changes {
+		var buf bytes.Buffer
+		if err := encodeChanges(&buf, s.format, tbl, []*rowChange{change}, ""); err != nil {
+			return err
+		}
+		timestamps = append(timestamps, oracle.ExtractPhysical(change.commitTS))
+		values = append(values, bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	}
+	return s.storage.WriteFile(ctx, segmentName(s.topic, offset), encodeSegment(offset, timestamps, values))
+}
+
+// segmentName returns the name of the log segment of the partition 0 of the
+// topic, which starts at the offset.
+func segmentName(topic string, offset int64) string {
+	return fmt.Sprintf("%s-0/%020d.log", topic, offset)
+}
@@ -0,0 +1,143 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSinkURI(t *testing.T) {
+	storageURI, topic, isSegment, err := parseSinkURI("s3://bucket/prefix?region=us-west-2")
+	require.NoError(t, err)
+	require.Equal(t, "s3://bucket/prefix?region=us-west-2", storageURI)
+	require.Empty(t, topic)
+	require.False(t, isSegment)
+
+	storageURI, topic, isSegment, err = parseSinkURI("SEGMENT+s3://bucket/prefix?region=us-west-2&topic=t1")
+	require.NoError(t, err)
+	require.Equal(t, "s3://bucket/prefix?region=us-west-2", storageURI)
+	require.Equal(t, "t1", topic)
+	require.True(t, isSegment)
+
+	_, _, _, err = parseSinkURI("kafka://127.0.0.1:9092/t")
+	require.ErrorContains(t, err, "kafka brokers are not supported")
+
+	_, err = ValidateSink("segment+file:///tmp/cf?topic="+strings.Repeat("a", 250), "")
+	require.ErrorContains(t, err, "invalid topic")
+	_, err = ValidateSink("segment+file:///tmp/cf", model.ChangefeedFormatAvro)
+	require.ErrorContains(t, err, "avro format is not supported by the segment sink")
+	format, err := ValidateSink("segment+file:///tmp/cf?topic=t.1_a-b", "")
+	require.NoError(t, err)
+	require.Equal(t, model.ChangefeedFormatCanalJSON, format)
+}
+
+type segmentRecord struct {
+	offset    int64
+	timestamp int64
+	value     []byte
+}
+
+// decodeSegment decodes the records of a log segment written by the segment sink.
+func decodeSegment(t *testing.T, data []byte) []segmentRecord {
+	var records []segmentRecord
+	for len(data) > 0 {
+		baseOffset := int64(binary.BigEndian.Uint64(data))
+		batchLen := int(binary.BigEndian.Uint32(data[8:]))
+		batch := data[12 : 12+batchLen]
+		data = data[12+batchLen:]
+		require.Equal(t, byte(2), batch[4])
+		body := batch[9:]
+		require.Equal(t, binary.BigEndian.Uint32(batch[5:]), crc32.Checksum(body, castagnoliTable))
+		lastOffsetDelta := int64(binary.BigEndian.Uint32(body[2:]))
+		firstTS := int64(binary.BigEndian.Uint64(body[6:]))
+		require.Equal(t, int64(-1), int64(binary.BigEndian.Uint64(body[22:])))
+		count := int(binary.BigEndian.Uint32(body[36:]))
+		require.Equal(t, lastOffsetDelta+1, int64(count))
+		rd := bytes.NewReader(body[40:])
+		for range count {
+			length, err := binary.ReadVarint(rd)
+			require.NoError(t, err)
+			rec := make([]byte, length)
+			_, err = rd.Read(rec)
+			require.NoError(t, err)
+			recRd := bytes.NewReader(rec)
+			attrs, err := recRd.ReadByte()
+			require.NoError(t, err)
+			require.Zero(t, attrs)
+			tsDelta, err := binary.ReadVarint(recRd)
+			require.NoError(t, err)
+			offsetDelta, err := binary.ReadVarint(recRd)
+			require.NoError(t, err)
+			keyLen, err := binary.ReadVarint(recRd)
+			require.NoError(t, err)
+			require.Equal(t, int64(-1), keyLen)
+			valueLen, err := binary.ReadVarint(recRd)
+			require.NoError(t, err)
+			value := make([]byte, valueLen)
+			_, err = recRd.Read(value)
+			require.NoError(t, err)
+			headers, err := binary.ReadVarint(recRd)
+			require.NoError(t, err)
+			require.Zero(t, headers)
+			require.Zero(t, recRd.Len())
+			records = append(records, segmentRecord{offset: baseOffset + offsetDelta, timestamp: firstTS + tsDelta, value: value})
+		}
+		require.Zero(t, rd.Len())
+	}
+	return records
+}
+
+func TestSegmentSink(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	s := &segmentSink{storage: store, topic: "t1", format: model.ChangefeedFormatCanalJSON}
+	changes := newTestChanges()
+	require.NoError(t, s.write(context.Background(), newTestChangeTable(), 1, 0, 10, changes))
+
+	data, err := os.ReadFile(filepath.Join(dir, "t1-0", "00000000000000000010.log"))
+	require.NoError(t, err)
+	records := decodeSegment(t, data)
+	require.Len(t, records, len(changes))
+	for i, record := range records {
+		var buf bytes.Buffer
+		require.NoError(t, encodeChanges(&buf, model.ChangefeedFormatCanalJSON, newTestChangeTable(), changes[i:i+1], ""))
+		require.Equal(t, strings.TrimSuffix(buf.String(), "\n"), string(record.value))
+		require.Equal(t, int64(10+i), record.offset)
+		require.Equal(t, int64(1700000000000), record.timestamp)
+	}
+
+	// The large values are split into several record batches.
+	values := [][]byte{bytes.Repeat([]byte("a"), maxRecordBatchBytes), []byte("b"), bytes.Repeat([]byte("c"), maxRecordBatchBytes+1)}
+	timestamps := []int64{1, 3, 2}
+	records = decodeSegment(t, encodeSegment(5, timestamps, values))
+	require.Len(t, records, 3)
+	for i, record := range records {
+		require.Equal(t, int64(5+i), record.offset)
+		require.Equal(t, timestamps[i], record.timestamp)
+		require.Equal(t, values[i], record.value)
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package changefeed
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
+	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor/execute"
+	"github.com/pingcap/tidb/pkg/kv"
+)
+
+// TaskExecutor runs the subtask of a changefeed.
+type TaskExecutor struct {
+	*taskexecutor.BaseTaskExecutor
+	store kv.Storage
+}
+
+var _ taskexecutor.TaskExecutor = (*TaskExecutor)(nil)
+
+// NewTaskExecutor creates a new changefeed task executor.
+func NewTaskExecutor(ctx context.Context, task *proto.Task, param taskexecutor.Param, store kv.Storage) taskexecutor.TaskExecutor {
+	e := &TaskExecutor{
+		BaseTaskExecutor: taskexecutor.NewBaseTaskExecutor(ctx, task, param),
+		store:            store,
+	}
+	e.BaseTaskExecutor.Extension = e
+	return e
+}
+
+// IsIdempotent implements the taskexecutor.Extension interface. A batch is
+// written to the same place when it's retried, so the subtask can be rerun.
+func (*TaskExecutor) IsIdempotent(*proto.Subtask) bool {
+	return true
+}
+
+// GetStepExecutor implements the taskexecutor.Extension interface.
+func (e *TaskExecutor) GetStepExecutor(task *proto.Task) (execute.StepExecutor, error) {
+	meta := &TaskMeta{}
+	if err := json.Unmarshal(task.Meta, meta); err != nil {
+		return nil, err
+	}
+	return &streamStepExecutor{store: e.store, meta: meta}, nil
+}
+
+// IsRetryableError implements the taskexecutor.Extension interface. The errors
+// of a batch are retried inside the subtask, so the returned error is final.
+func (*TaskExecutor) IsRetryableError(error) bool {
+	return false
+}
+
+type streamStepExecutor struct {
+	taskexecutor.BaseStepExecutor
+	store kv.Storage
+	meta  *TaskMeta
+}
+
+// RunSubtask streams the changes until the changefeed is dropped.
+func (s *streamStepExecutor) RunSubtask(ctx context.Context, _ *proto.Subtask) error {
+	taskMgr, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	return newConsumer(s.store, taskMgr, s.meta).run(ctx)
+}
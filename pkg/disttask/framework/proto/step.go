@@ -45,6 +45,8 @@ func Step2Str(t TaskType, s Step) string {
 		return importIntoStep2Str(s)
 	case TaskTypeExample:
 		return exampleStep2Str(s)
+	case Changefeed:
+		return changefeedStep2Str(s)
 	}
 	return fmt.Sprintf("unknown type %s", t)
 }
@@ -141,3 +143,19 @@ func backfillStep2Str(s Step) string {
 		return fmt.Sprintf("unknown step %d", s)
 	}
 }
+
+// Steps of changefeed, the changefeed is streamed by a single subtask which
+// keeps running until the changefeed is dropped.
+// StepInit -> ChangefeedStepStream -> StepDone
+const (
+	ChangefeedStepStream Step = 1
+)
+
+func changefeedStep2Str(s Step) string {
+	switch s {
+	case ChangefeedStepStream:
+		return "stream"
+	default:
+		return fmt.Sprintf("unknown step %d", s)
+	}
+}
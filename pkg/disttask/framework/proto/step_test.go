@@ -46,6 +46,12 @@ func TestStep(t *testing.T) {
 	require.Equal(t, "done", Step2Str(TaskTypeExample, StepDone))
 	require.Equal(t, "unknown step 333", Step2Str(TaskTypeExample, 333))
 
+	// changefeed
+	require.Equal(t, "init", Step2Str(Changefeed, StepInit))
+	require.Equal(t, "stream", Step2Str(Changefeed, ChangefeedStepStream))
+	require.Equal(t, "done", Step2Str(Changefeed, StepDone))
+	require.Equal(t, "unknown step 444", Step2Str(Changefeed, 444))
+
 	// unknown type
 	require.Equal(t, "unknown type 123", Step2Str(TaskType("123"), 123))
 }
@@ -52,6 +52,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/globalconfigsync"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschema_metrics "github.com/pingcap/tidb/pkg/infoschema/metrics"
 	"github.com/pingcap/tidb/pkg/infoschema/perfschema"
@@ -68,6 +69,7 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	metrics2 "github.com/pingcap/tidb/pkg/planner/core/metrics"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor/autoadvisor"
 	"github.com/pingcap/tidb/pkg/privilege/privileges"
 	"github.com/pingcap/tidb/pkg/resourcegroup/runaway"
 	"github.com/pingcap/tidb/pkg/session/syssession"
@@ -214,6 +216,9 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
+	indexAdvisorScheduler    atomic.Pointer[autoadvisor.Scheduler]
+	cardinalityFeedback      atomic.Pointer[workloadlearning.CardinalityFeedbackStore]
 	runawayManager           *runaway.Manager
 	resourceGroupsController *rmclient.ResourceGroupsController
 
@@ -1278,6 +1283,12 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Info("ttlJobManager exited.")
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		eventScheduler.Stop()
+	}
+	if indexAdvisorScheduler := do.indexAdvisorScheduler.Load(); indexAdvisorScheduler != nil {
+		indexAdvisorScheduler.Stop()
+	}
 	do.releaseServerID(context.Background())
 	close(do.exit)
 	if do.brOwnerMgr != nil {
@@ -3429,6 +3440,32 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler creates and starts the event scheduler, newSession is
+// used to create the sessions to execute the events.
+func (do *Domain) StartEventScheduler(newSession eventscheduler.SessionFactory) {
+	scheduler := eventscheduler.NewScheduler(do.sysSessionPool, do.etcdClient, newSession, do.ddl.OwnerManager().IsOwner)
+	do.eventScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain.
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
+// StartIndexAdvisorScheduler creates and starts the scheduler of the automatic
+// index advisor, run is called to run the index advisor once.
+func (do *Domain) StartIndexAdvisorScheduler(run autoadvisor.RunFunc) {
+	scheduler := autoadvisor.NewScheduler(do.sysSessionPool, do.etcdClient, run, do.ddl.OwnerManager().IsOwner)
+	do.indexAdvisorScheduler.Store(scheduler)
+	scheduler.Start()
+}
+
+// IndexAdvisorScheduler returns the scheduler of the automatic index advisor on this domain.
+func (do *Domain) IndexAdvisorScheduler() *autoadvisor.Scheduler {
+	return do.indexAdvisorScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
@@ -3520,9 +3557,46 @@ func (do *Domain) SetupWorkloadBasedLearningWorker() {
 		},
 		"readTableCostWorker",
 	)
+	// Start the worker to persist and load the cardinality feedback of the executed statements.
+	feedbackStore := workloadlearning.NewCardinalityFeedbackStore(do.sysSessionPool)
+	do.cardinalityFeedback.Store(feedbackStore)
+	do.wg.Run(
+		func() {
+			do.cardinalityFeedbackWorker(feedbackStore)
+		},
+		"cardinalityFeedbackWorker",
+	)
 	// TODO: Add more workers for other workload based learning tasks.
 }
 
+// CardinalityFeedback returns the store of the cardinality feedback on this domain.
+func (do *Domain) CardinalityFeedback() *workloadlearning.CardinalityFeedbackStore {
+	return do.cardinalityFeedback.Load()
+}
+
+// cardinalityFeedbackFlushInterval is the interval to save and load the cardinality feedback.
+const cardinalityFeedbackFlushInterval = time.Minute
+
+// cardinalityFeedbackWorker is a background worker that periodically saves the captured cardinality feedback and
+// loads the feedback learned by all the TiDB instances.
+func (do *Domain) cardinalityFeedbackWorker(feedbackStore *workloadlearning.CardinalityFeedbackStore) {
+	defer util.Recover(metrics.LabelDomain, "cardinalityFeedbackWorker", nil, false)
+	ticker := time.NewTicker(cardinalityFeedbackFlushInterval)
+	defer func() {
+		ticker.Stop()
+		logutil.BgLogger().Info("cardinalityFeedbackWorker exited.")
+	}()
+	feedbackStore.Load()
+	for {
+		select {
+		case <-ticker.C:
+			feedbackStore.Flush()
+		case <-do.exit:
+			return
+		}
+	}
+}
+
 // readTableCostWorker is a background worker that periodically analyze the read path table cost by statement_summary.
 func (do *Domain) readTableCostWorker(wbLearningHandle *workloadlearning.Handle, wbCacheWorker *workloadlearning.WLCacheWorker) {
 	// Recover the panic and log the error when worker exit.
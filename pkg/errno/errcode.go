@@ -828,8 +828,11 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
+	ErrReferencedTrgDoesNotExist                             = 3062
 	ErrIncorrectType                                         = 3064
 	ErrFieldInOrderNotSelect                                 = 3065
 	ErrAggregateInOrderNotSelect                             = 3066
@@ -863,9 +866,11 @@ const (
 	ErrInvalidJSONPathArrayCell                              = 3165
 	ErrInvalidEncryptionOption                               = 3184
 	ErrTooLongValueForType                                   = 3505
+	ErrGISUnsupportedArgument                                = 3516
 	ErrPKIndexCantBeInvisible                                = 3522
 	ErrGrantRole                                             = 3523
 	ErrRoleNotGranted                                        = 3530
+	ErrSRSNotFound                                           = 3548
 	ErrLockAcquireFailAndNoWaitSet                           = 3572
 	ErrCTERecursiveRequiresUnion                             = 3573
 	ErrCTERecursiveRequiresNonRecursiveFirst                 = 3574
@@ -895,10 +900,21 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
+	ErrNotImplementedForGeographicSRS                        = 3618
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrWrongSRIDForColumn                                    = 3643
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTableFunctionMustHaveAlias                            = 3667
+	ErrTableFunctionForbiddenJoinType                        = 3668
+	ErrJSONTableValueOutOfRange                              = 3669
+	ErrSpatialUselessIndex                                   = 3674
+	ErrNonPositiveRadius                                     = 3706
 	ErrInvalidDefaultUTF8MB4Collation                        = 3721
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
@@ -1149,6 +1165,24 @@ const (
 
 	ErrWarnGlobalIndexNeedManuallyAnalyze = 8265
 
+	ErrInvalidTTLArchiveTarget = 8266
+	ErrInvalidTTLCondition     = 8267
+
+	ErrChangefeedExists    = 8268
+	ErrChangefeedNotExists = 8269
+	ErrInvalidChangefeed   = 8272
+
+	ErrSerializationFailure = 8273
+
+	ErrInvalidPartialIndex     = 8274
+	ErrDependentByPartialIndex = 8275
+
+	ErrInvalidIncludeColumn     = 8276
+	ErrDependentByIncludeColumn = 8277
+
+	ErrSSIOptimisticTxn     = 8278
+	ErrWarnSSILocalInstance = 8279
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
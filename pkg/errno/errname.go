@@ -423,6 +423,15 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrSpNotVarArg:                              mysql.Message("OUT or INOUT argument %d for routine %s is not a variable or NEW pseudo-variable in BEFORE trigger", nil),
 	ErrSpNoRetset:                               mysql.Message("Not allowed to return a result set from a %s", nil),
 	ErrCantCreateGeometryObject:                 mysql.Message("Cannot get geometry object from data you send to the GEOMETRY field", nil),
+	ErrGISDifferentSRIDs:                        mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                           mysql.Message("Invalid GIS data provided to function %s.", nil),
+	ErrGISUnsupportedArgument:                   mysql.Message("Calling geometry function %s with unsupported types of arguments.", nil),
+	ErrSRSNotFound:                              mysql.Message("There's no spatial reference system with SRID %d.", nil),
+	ErrLongitudeOutOfRange:                      mysql.Message("Longitude %f is out of range in function %s. It must be within (%f, %f].", nil),
+	ErrLatitudeOutOfRange:                       mysql.Message("Latitude %f is out of range in function %s. It must be within [%f, %f].", nil),
+	ErrNotImplementedForGeographicSRS:           mysql.Message("%s(%s) has not been implemented for geographic spatial reference systems.", nil),
+	ErrWrongSRIDForColumn:                       mysql.Message("The SRID of the geometry does not match the SRID of the column '%s'. The SRID of the geometry is %d, but the SRID of the column is %d. Consider changing the SRID of the geometry or the SRID property of the column.", nil),
+	ErrNonPositiveRadius:                        mysql.Message("Invalid radius provided to function %s: Radius must be greater than zero.", nil),
 	ErrFailedRoutineBreakBinlog:                 mysql.Message("A routine failed and has neither NO SQL nor READS SQL DATA in its declaration and binary logging is enabled; if non-transactional tables were updated, the binary log will miss their changes", nil),
 	ErrBinlogUnsafeRoutine:                      mysql.Message("This function has none of DETERMINISTIC, NO SQL, or READS SQL DATA in its declaration and binary logging is enabled (you *might* want to use the less safe logBinTrustFunctionCreators variable)", nil),
 	ErrBinlogCreateRoutineNeedSuper:             mysql.Message("You do not have the SUPER privilege and binary logging is enabled (you *might* want to use the less safe logBinTrustFunctionCreators variable)", nil),
@@ -846,6 +855,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPasswordExpireAnonymousUser:                           mysql.Message("The password for anonymous user cannot be expired.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrReferencedTrgDoesNotExist:                             mysql.Message("Referenced trigger '%s' for the given action time and event type does not exist.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -895,6 +905,12 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' might not be affected by SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar JSON_TABLE column '%s'", nil),
+	ErrTableFunctionMustHaveAlias:                            mysql.Message("Every table function must have an alias", nil),
+	ErrTableFunctionForbiddenJoinType:                        mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
+	ErrJSONTableValueOutOfRange:                              mysql.Message("Value is out of range for JSON_TABLE's column '%s'", nil),
+	ErrSpatialUselessIndex:                                   mysql.Message("The spatial index on column '%s' will not be used by the query optimizer since the column does not have an SRID attribute. Consider adding an SRID attribute to the column.", nil),
 	ErrInvalidDefaultUTF8MB4Collation:                        mysql.Message("Invalid default collation %s: utf8mb4_0900_ai_ci or utf8mb4_general_ci or utf8mb4_bin expected", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
@@ -1185,4 +1201,22 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGlobalIndexNotExplicitlySet: mysql.Message("Global Index is needed for index '%-.192s', since the unique index is not including all partitioning columns, and GLOBAL is not given as IndexOption", nil),
 
 	ErrWarnGlobalIndexNeedManuallyAnalyze: mysql.Message("Auto analyze is not effective for index '%-.192s', need analyze manually", nil),
+
+	ErrInvalidTTLArchiveTarget: mysql.Message("Invalid TTL_ARCHIVE_TARGET '%-.192s': %s", nil),
+	ErrInvalidTTLCondition:     mysql.Message("Invalid TTL condition '%-.192s': %s", nil),
+
+	ErrChangefeedExists:    mysql.Message("Changefeed '%-.192s' already exists", nil),
+	ErrChangefeedNotExists: mysql.Message("Changefeed '%-.192s' doesn't exist", nil),
+	ErrInvalidChangefeed:   mysql.Message("Invalid changefeed '%-.192s': %s", nil),
+
+	ErrSerializationFailure: mysql.Message("Could not serialize access due to read/write dependencies among transactions, txnStartTS=%d", nil),
+
+	ErrInvalidPartialIndex:     mysql.Message("Invalid partial index '%-.192s': %s", nil),
+	ErrDependentByPartialIndex: mysql.Message("Column '%-.192s' has a partial index dependency and cannot be dropped or renamed", nil),
+
+	ErrInvalidIncludeColumn:     mysql.Message("Invalid included column '%-.192s' of index '%-.192s': %s", nil),
+	ErrDependentByIncludeColumn: mysql.Message("Column '%-.192s' is included by index '%-.192s' and cannot be dropped or modified", nil),
+
+	ErrSSIOptimisticTxn:     mysql.Message("SERIALIZABLE isolation level with tidb_enable_ssi is only supported in pessimistic transactions", nil),
+	ErrWarnSSILocalInstance: mysql.Message("tidb_enable_ssi only detects the read/write dependencies among the SERIALIZABLE transactions on the same TiDB instance, the transactions on different TiDB instances are not checked", nil),
 }
@@ -0,0 +1,290 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/timeutil"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event"
+	// oneTimeInterval is the interval of the timer of a one-time event. The
+	// timer fires once at the watermark plus the interval, and the event is
+	// completed after that.
+	oneTimeInterval = time.Hour
+	// MaxIntervalSeconds is the max interval of a recurring event.
+	MaxIntervalSeconds = 1_000_000_000
+	// DateTimeFormat is the format to display the times of an event.
+	DateTimeFormat = "2006-01-02 15:04:05"
+)
+
+// EventInfo is the definition of an event. It's stored as the data of the
+// timer which schedules the event.
+type EventInfo struct {
+	Schema  string `json:"schema"`
+	Name    string `json:"name"`
+	Definer string `json:"definer"`
+	Body    string `json:"body"`
+	// ExecuteAt is set for a one-time event.
+	ExecuteAt *time.Time `json:"execute_at,omitempty"`
+	// IntervalValue and IntervalField are set for a recurring event.
+	IntervalValue int64      `json:"interval_value,omitempty"`
+	IntervalField string     `json:"interval_field,omitempty"`
+	Starts        *time.Time `json:"starts,omitempty"`
+	Ends          *time.Time `json:"ends,omitempty"`
+	// Preserve indicates whether the event is kept after it's completed.
+	Preserve            bool      `json:"preserve"`
+	Comment             string    `json:"comment,omitempty"`
+	SQLMode             string    `json:"sql_mode"`
+	TimeZone            string    `json:"time_zone"`
+	CharsetClient       string    `json:"character_set_client"`
+	CollationConnection string    `json:"collation_connection"`
+	DatabaseCollation   string    `json:"database_collation"`
+	Created             time.Time `json:"created"`
+	LastAltered         time.Time `json:"last_altered"`
+}
+
+// eventTimerSummary is the summary of the last execution of an event.
+type eventTimerSummary struct {
+	LastExecuted *time.Time `json:"last_executed,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+// TimerKey returns the key of the timer for the event.
+func TimerKey(schema, name string) string {
+	return SchemaTimerKeyPrefix(schema) + strings.ToLower(name)
+}
+
+// SchemaTimerKeyPrefix returns the key prefix of the timers for the events in the schema.
+func SchemaTimerKeyPrefix(schema string) string {
+	return timerKeyPrefix + strings.ToLower(schema) + "/"
+}
+
+// UnitDuration returns the duration of the interval unit of a recurring
+// event. Only the units with a fixed length are supported.
+func UnitDuration(unit ast.TimeUnitType) (time.Duration, bool) {
+	d, ok := unitDurations[unit.String()]
+	return d, ok
+}
+
+var unitDurations = map[string]time.Duration{
+	ast.TimeUnitSecond.String(): time.Second,
+	ast.TimeUnitMinute.String(): time.Minute,
+	ast.TimeUnitHour.String():   time.Hour,
+	ast.TimeUnitDay.String():    24 * time.Hour,
+	ast.TimeUnitWeek.String():   7 * 24 * time.Hour,
+}
+
+// IsOneTime returns whether the event is executed only once.
+func (e *EventInfo) IsOneTime() bool {
+	return e.ExecuteAt != nil
+}
+
+// Interval returns the interval of a recurring event.
+func (e *EventInfo) Interval() time.Duration {
+	return time.Duration(e.IntervalValue) * unitDurations[e.IntervalField]
+}
+
+// Location returns the time zone in which the event is defined.
+func (e *EventInfo) Location() *time.Location {
+	loc, err := timeutil.ParseTimeZone(e.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// FormatTime formats the time in the time zone of the event.
+func (e *EventInfo) FormatTime(t time.Time) string {
+	return t.In(e.Location()).Format(DateTimeFormat)
+}
+
+// DefinerString returns the definer in the form of `user`@`host`.
+func (e *EventInfo) DefinerString() string {
+	user, host, ok := SplitDefiner(e.Definer)
+	if !ok {
+		return e.Definer
+	}
+	return quoteName(user) + "@" + quoteName(host)
+}
+
+// SplitDefiner splits the definer into the user name and the host, ok is false
+// if the event is not created by a user.
+func SplitDefiner(definer string) (user, host string, ok bool) {
+	idx := strings.LastIndex(definer, "@")
+	if idx < 0 {
+		return "", "", false
+	}
+	return definer[:idx], definer[idx+1:], true
+}
+
+// schedule returns the first execution time and the interval of the timer.
+func (e *EventInfo) schedule() (time.Time, time.Duration) {
+	if e.IsOneTime() {
+		return *e.ExecuteAt, oneTimeInterval
+	}
+	return *e.Starts, e.Interval()
+}
+
+// ScheduleString returns the schedule clause of the event.
+func (e *EventInfo) ScheduleString() string {
+	if e.IsOneTime() {
+		return fmt.Sprintf("AT '%s'", e.FormatTime(*e.ExecuteAt))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "EVERY %d %s STARTS '%s'", e.IntervalValue, e.IntervalField, e.FormatTime(*e.Starts))
+	if e.Ends != nil {
+		fmt.Fprintf(&sb, " ENDS '%s'", e.FormatTime(*e.Ends))
+	}
+	return sb.String()
+}
+
+// ShowCreateSQL returns the statement displayed by `SHOW CREATE EVENT`.
+func (e *EventInfo) ShowCreateSQL(enable bool) string {
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if _, _, ok := SplitDefiner(e.Definer); ok {
+		fmt.Fprintf(&sb, "DEFINER=%s ", e.DefinerString())
+	}
+	fmt.Fprintf(&sb, "EVENT %s ON SCHEDULE %s ON COMPLETION ", quoteName(e.Name), e.ScheduleString())
+	if !e.Preserve {
+		sb.WriteString("NOT ")
+	}
+	sb.WriteString("PRESERVE ")
+	sb.WriteString(StatusString(enable))
+	if e.Comment != "" {
+		fmt.Fprintf(&sb, " COMMENT '%s'", strings.ReplaceAll(e.Comment, "'", "''"))
+	}
+	sb.WriteString(" DO ")
+	sb.WriteString(e.Body)
+	return sb.String()
+}
+
+// bodySQL returns a statement to parse the body of the event.
+func (e *EventInfo) bodySQL() string {
+	return fmt.Sprintf("CREATE EVENT %s ON SCHEDULE AT CURRENT_TIMESTAMP DO %s", quoteName(e.Name), e.Body)
+}
+
+// StatusString returns the status of the event displayed by `SHOW CREATE EVENT`.
+func StatusString(enable bool) string {
+	if enable {
+		return "ENABLE"
+	}
+	return "DISABLE"
+}
+
+// TimerSpec returns the spec of the timer to schedule the event.
+func (e *EventInfo) TimerSpec(enable bool) (timerapi.TimerSpec, error) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return timerapi.TimerSpec{}, errors.Trace(err)
+	}
+	first, interval := e.schedule()
+	return timerapi.TimerSpec{
+		Key:             TimerKey(e.Schema, e.Name),
+		Data:            data,
+		SchedPolicyType: timerapi.SchedEventInterval,
+		SchedPolicyExpr: intervalExpr(interval),
+		HookClass:       timerHookClass,
+		Watermark:       first.Add(-interval),
+		Enable:          enable,
+	}, nil
+}
+
+// TimerUpdates returns the options to update the timer of the event after it's
+// altered. The watermark is reset only if the event is rescheduled.
+func (e *EventInfo) TimerUpdates(enable, rescheduled bool) ([]timerapi.UpdateTimerOption, error) {
+	spec, err := e.TimerSpec(enable)
+	if err != nil {
+		return nil, err
+	}
+	opts := []timerapi.UpdateTimerOption{
+		timerapi.WithSetData(spec.Data),
+		timerapi.WithSetSchedExpr(spec.SchedPolicyType, spec.SchedPolicyExpr),
+		timerapi.WithSetEnable(enable),
+	}
+	if rescheduled {
+		opts = append(opts, timerapi.WithSetWatermark(spec.Watermark))
+	}
+	return opts, nil
+}
+
+func intervalExpr(interval time.Duration) string {
+	return fmt.Sprintf("%ds", int64(interval/time.Second))
+}
+
+// EventFromTimer decodes the event from its timer.
+func EventFromTimer(timer *timerapi.TimerRecord) (*EventInfo, error) {
+	var e EventInfo
+	if err := json.Unmarshal(timer.Data, &e); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &e, nil
+}
+
+// LastExecuted returns the last time the event is executed, it's nil if the
+// event is never executed.
+func LastExecuted(timer *timerapi.TimerRecord) *time.Time {
+	if len(timer.SummaryData) == 0 {
+		return nil
+	}
+	var summary eventTimerSummary
+	if err := json.Unmarshal(timer.SummaryData, &summary); err != nil {
+		return nil
+	}
+	return summary.LastExecuted
+}
+
+// GetEventTimer returns the timer of the event, it returns nil if the event does not exist.
+func GetEventTimer(ctx context.Context, cli timerapi.TimerClient, schema, name string) (*timerapi.TimerRecord, error) {
+	timer, err := cli.GetTimerByKey(ctx, TimerKey(schema, name))
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		return nil, nil
+	}
+	return timer, err
+}
+
+// GetEventTimers returns the timers of all the events.
+func GetEventTimers(ctx context.Context, cli timerapi.TimerClient) ([]*timerapi.TimerRecord, error) {
+	return cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+}
+
+// DropSchemaEvents removes all events of the dropped schema.
+func DropSchemaEvents(ctx context.Context, cli timerapi.TimerClient, schema string) error {
+	timers, err := cli.GetTimers(ctx, timerapi.WithKeyPrefix(SchemaTimerKeyPrefix(schema)))
+	if err != nil {
+		return err
+	}
+	for _, timer := range timers {
+		if _, err = cli.DeleteTimer(ctx, timer.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
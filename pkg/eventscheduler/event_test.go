@@ -0,0 +1,176 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newRecurringEvent(starts time.Time) *EventInfo {
+	return &EventInfo{
+		Schema:              "test",
+		Name:                "e1",
+		Definer:             "root@%",
+		Body:                "INSERT INTO t VALUES (1)",
+		IntervalValue:       10,
+		IntervalField:       ast.TimeUnitMinute.String(),
+		Starts:              &starts,
+		SQLMode:             "STRICT_TRANS_TABLES",
+		TimeZone:            "UTC",
+		CharsetClient:       "utf8mb4",
+		CollationConnection: "utf8mb4_bin",
+		DatabaseCollation:   "utf8mb4_bin",
+	}
+}
+
+func TestEventTimerSpec(t *testing.T) {
+	starts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := newRecurringEvent(starts)
+	require.False(t, e.IsOneTime())
+	require.Equal(t, 10*time.Minute, e.Interval())
+
+	spec, err := e.TimerSpec(true)
+	require.NoError(t, err)
+	require.Equal(t, "/tidb/event/test/e1", spec.Key)
+	require.Equal(t, timerapi.SchedEventInterval, spec.SchedPolicyType)
+	require.Equal(t, "600s", spec.SchedPolicyExpr)
+	require.Equal(t, starts.Add(-10*time.Minute), spec.Watermark)
+	require.True(t, spec.Enable)
+
+	decoded, err := EventFromTimer(&timerapi.TimerRecord{TimerSpec: spec})
+	require.NoError(t, err)
+	require.Equal(t, e.Name, decoded.Name)
+	require.True(t, e.Starts.Equal(*decoded.Starts))
+
+	at := starts.Add(time.Hour)
+	e.ExecuteAt, e.Starts, e.IntervalValue, e.IntervalField = &at, nil, 0, ""
+	require.True(t, e.IsOneTime())
+	spec, err = e.TimerSpec(false)
+	require.NoError(t, err)
+	require.Equal(t, at.Add(-oneTimeInterval), spec.Watermark)
+	require.False(t, spec.Enable)
+}
+
+func TestEventShowCreateSQL(t *testing.T) {
+	starts := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := newRecurringEvent(starts)
+	e.TimeZone = "+08:00"
+	require.Equal(t, "CREATE DEFINER=`root`@`%` EVENT `e1` ON SCHEDULE EVERY 10 MINUTE STARTS '2025-01-02 11:04:05' "+
+		"ON COMPLETION NOT PRESERVE ENABLE DO INSERT INTO t VALUES (1)", e.ShowCreateSQL(true))
+
+	ends := starts.Add(24 * time.Hour)
+	e.Ends, e.Preserve, e.Comment = &ends, true, "it's"
+	require.Equal(t, "CREATE DEFINER=`root`@`%` EVENT `e1` ON SCHEDULE EVERY 10 MINUTE STARTS '2025-01-02 11:04:05' "+
+		"ENDS '2025-01-03 11:04:05' ON COMPLETION PRESERVE DISABLE COMMENT 'it''s' DO INSERT INTO t VALUES (1)", e.ShowCreateSQL(false))
+
+	user, host, ok := SplitDefiner("u@1@localhost")
+	require.True(t, ok)
+	require.Equal(t, "u@1", user)
+	require.Equal(t, "localhost", host)
+	_, _, ok = SplitDefiner("%")
+	require.False(t, ok)
+}
+
+type mockSession struct {
+	vars  *variable.SessionVars
+	stmts []ast.StmtNode
+}
+
+func (s *mockSession) ExecuteStmt(_ context.Context, stmt ast.StmtNode) (sqlexec.RecordSet, error) {
+	s.stmts = append(s.stmts, stmt)
+	return nil, nil
+}
+
+func (*mockSession) AuthWithoutVerification(context.Context, *auth.UserIdentity) bool {
+	return true
+}
+
+func (s *mockSession) GetSessionVars() *variable.SessionVars {
+	return s.vars
+}
+
+func (*mockSession) Close() {}
+
+func TestExecuteEvent(t *testing.T) {
+	ctx := context.Background()
+	store := timerapi.NewMemoryTimerStore()
+	defer store.Close()
+	cli := timerapi.NewDefaultTimerClient(store)
+	se := &mockSession{vars: variable.NewSessionVars(nil)}
+	hook := newEventTimerHook(func() (Session, error) { return se, nil }, cli)
+	defer hook.Stop()
+
+	trigger := func(e *EventInfo, preserve bool, eventStart time.Time) *timerapi.TimerRecord {
+		e.Preserve = preserve
+		spec, err := e.TimerSpec(true)
+		require.NoError(t, err)
+		timer, err := cli.CreateTimer(ctx, spec)
+		require.NoError(t, err)
+		require.NoError(t, store.Update(ctx, timer.ID, &timerapi.TimerUpdate{
+			EventStatus: timerapi.NewOptionalVal(timerapi.SchedEventTrigger),
+			EventID:     timerapi.NewOptionalVal("event1"),
+			EventStart:  timerapi.NewOptionalVal(eventStart),
+		}))
+		timer, err = cli.GetTimerByID(ctx, timer.ID)
+		require.NoError(t, err)
+		hook.wg.Add(1)
+		hook.executeEvent(zap.NewNop(), e, timer, "event1")
+		return timer
+	}
+
+	// a recurring event skips the missed executions
+	starts := time.Now().Add(-time.Hour).Truncate(time.Second)
+	e := newRecurringEvent(starts)
+	timer := trigger(e, false, starts.Add(25*time.Minute))
+	require.Len(t, se.stmts, 1)
+	block, ok := se.stmts[0].(*ast.ProcedureBlock)
+	require.True(t, ok)
+	require.Len(t, block.ProcedureProcStmts, 1)
+	require.Equal(t, "test", se.vars.CurrentDB)
+	timer, err := cli.GetTimerByID(ctx, timer.ID)
+	require.NoError(t, err)
+	require.True(t, timer.Enable)
+	require.Equal(t, starts.Add(20*time.Minute), timer.Watermark)
+	require.NotNil(t, LastExecuted(timer))
+	_, err = cli.DeleteTimer(ctx, timer.ID)
+	require.NoError(t, err)
+
+	// a one-time event is dropped after it's executed
+	at := time.Now().Truncate(time.Second)
+	e = newRecurringEvent(starts)
+	e.ExecuteAt, e.Starts, e.IntervalValue, e.IntervalField = &at, nil, 0, ""
+	timer = trigger(e, false, at)
+	require.Len(t, se.stmts, 2)
+	timer, err = GetEventTimer(ctx, cli, "test", "e1")
+	require.NoError(t, err)
+	require.Nil(t, timer)
+
+	// a preserved event is disabled after it's completed
+	timer = trigger(e, true, at)
+	require.Len(t, se.stmts, 3)
+	timer, err = cli.GetTimerByID(ctx, timer.ID)
+	require.NoError(t, err)
+	require.False(t, timer.Enable)
+}
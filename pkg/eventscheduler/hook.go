@@ -0,0 +1,208 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// Session is the session to execute the body of an event.
+type Session interface {
+	// ExecuteStmt executes a parsed statement.
+	ExecuteStmt(context.Context, ast.StmtNode) (sqlexec.RecordSet, error)
+	// AuthWithoutVerification binds the user to the session without verifying the password.
+	AuthWithoutVerification(ctx context.Context, user *auth.UserIdentity) bool
+	// GetSessionVars returns the variables of the session.
+	GetSessionVars() *variable.SessionVars
+	// Close closes the session.
+	Close()
+}
+
+// SessionFactory creates a new session to execute the body of an event.
+type SessionFactory func() (Session, error)
+
+type eventTimerHook struct {
+	newSession SessionFactory
+	cli        timerapi.TimerClient
+	ctx        context.Context
+	cancel     func()
+	wg         sync.WaitGroup
+}
+
+func newEventTimerHook(newSession SessionFactory, cli timerapi.TimerClient) *eventTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventTimerHook{
+		newSession: newSession,
+		cli:        cli,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (t *eventTimerHook) Start() {}
+
+func (t *eventTimerHook) Stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+func (t *eventTimerHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !vardef.EnableEventScheduler.Load() {
+		r.Delay = time.Minute
+	}
+	return
+}
+
+func (t *eventTimerHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", event.EventID()),
+		zap.Time("eventStart", timer.EventStart),
+	)
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+
+	info, err := EventFromTimer(timer)
+	if err != nil {
+		logger.Error("invalid event timer data", zap.ByteString("data", timer.Data))
+		return err
+	}
+
+	logger.Info("timer triggered to execute event")
+	t.wg.Add(1)
+	go t.executeEvent(logger, info, timer, event.EventID())
+	return nil
+}
+
+// executeEvent executes the body of the event and closes the timer event. The
+// event is disabled or dropped after its last execution.
+func (t *eventTimerHook) executeEvent(logger *zap.Logger, info *EventInfo, timer *timerapi.TimerRecord, eventID string) {
+	defer t.wg.Done()
+
+	// The watermark is moved to the latest scheduled time, so the executions
+	// missed when the scheduler is not running are skipped.
+	_, interval := info.schedule()
+	watermark := timer.Watermark
+	if !watermark.IsZero() && timer.EventStart.After(watermark) {
+		watermark = watermark.Add(timer.EventStart.Sub(watermark) / interval * interval)
+	}
+
+	completed := info.IsOneTime() || (info.Ends != nil && watermark.Add(interval).After(*info.Ends))
+	summary := &eventTimerSummary{}
+	if info.Ends == nil || !watermark.After(*info.Ends) {
+		now := time.Now()
+		summary.LastExecuted = &now
+		if err := t.runBody(info); err != nil {
+			logger.Warn("failed to execute event", zap.Error(err))
+			summary.LastError = err.Error()
+		}
+	}
+
+	summaryData, err := json.Marshal(summary)
+	if err != nil {
+		logger.Error("marshal summary error", zap.Error(err))
+		return
+	}
+	if err = t.cli.CloseTimerEvent(t.ctx, timer.ID, eventID,
+		timerapi.WithSetWatermark(watermark), timerapi.WithSetSummaryData(summaryData)); err != nil {
+		logger.Error("CloseTimerEvent error", zap.Error(err))
+		return
+	}
+	if !completed {
+		return
+	}
+
+	if info.Preserve {
+		err = t.cli.UpdateTimer(t.ctx, timer.ID, timerapi.WithSetEnable(false))
+	} else {
+		_, err = t.cli.DeleteTimer(t.ctx, timer.ID)
+	}
+	if err != nil {
+		logger.Error("failed to complete event", zap.Error(err))
+	}
+}
+
+// runBody executes the body of the event with the privileges of the definer
+// in the environment where the event is created.
+func (t *eventTimerHook) runBody(info *EventInfo) (err error) {
+	se, err := t.newSession()
+	if err != nil {
+		return err
+	}
+	defer se.Close()
+
+	ctx := kv.WithInternalSourceType(t.ctx, kv.InternalTxnOthers)
+	if user, host, ok := SplitDefiner(info.Definer); ok {
+		if !se.AuthWithoutVerification(ctx, &auth.UserIdentity{Username: user, Hostname: host}) {
+			return errors.Errorf("the definer %s of the event does not exist", info.DefinerString())
+		}
+	}
+
+	sessVars := se.GetSessionVars()
+	sessVars.CurrentDB = info.Schema
+	for name, val := range map[string]string{
+		vardef.SQLModeVar:          info.SQLMode,
+		vardef.TimeZone:            info.TimeZone,
+		vardef.CharacterSetClient:  info.CharsetClient,
+		vardef.CollationConnection: info.CollationConnection,
+	} {
+		if err = sessVars.SetSystemVar(name, val); err != nil {
+			return err
+		}
+	}
+
+	sqlMode, err := mysql.GetSQLMode(info.SQLMode)
+	if err != nil {
+		return err
+	}
+	p := parser.New()
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	p.SetSQLMode(sqlMode)
+	node, err := p.ParseOneStmt(info.bodySQL(), info.CharsetClient, info.CollationConnection)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The body is always executed as a compound statement, so it's
+	// interpreted in the same way as the body of a stored procedure.
+	body := node.(*ast.CreateEventStmt).Body
+	block, ok := body.(*ast.ProcedureBlock)
+	if !ok {
+		block = &ast.ProcedureBlock{ProcedureProcStmts: []ast.StmtNode{body}}
+	}
+
+	rs, err := se.ExecuteStmt(ctx, block)
+	if err != nil || rs == nil {
+		return err
+	}
+	return rs.Close()
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+var checkSchedulerInterval = 5 * time.Second
+
+func init() {
+	if intest.InTest {
+		checkSchedulerInterval = 100 * time.Millisecond
+	}
+}
+
+// Scheduler manages the timers of the events. The events are executed on the
+// owner node only when the `event_scheduler` system variable is ON.
+type Scheduler struct {
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+	newSession SessionFactory
+	leaderFunc func() bool
+	rt         *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     util.WaitGroupWrapper
+}
+
+// NewScheduler creates a new event scheduler.
+func NewScheduler(pool util.DestroyableSessionPool, etcd *clientv3.Client, newSession SessionFactory, leaderFunc func() bool) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		newSession: newSession,
+		leaderFunc: leaderFunc,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Client returns the client to manage the timers of the events.
+func (s *Scheduler) Client() timerapi.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Run(s.loop)
+}
+
+// Stop stops the scheduler.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	ticker := time.NewTicker(checkSchedulerInterval)
+	defer func() {
+		ticker.Stop()
+		s.pause()
+		logutil.BgLogger().Info("event scheduler loop exited.")
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.leaderFunc != nil && s.leaderFunc() && vardef.EnableEventScheduler.Load() {
+			s.resume()
+		} else {
+			s.pause()
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventTimerHook(s.newSession, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
@@ -1427,6 +1427,7 @@ func (a *ExecStmt) FinishExecuteStmt(txnTS uint64, err error, hasMoreResults boo
 	// `LowSlowQuery` and `SummaryStmt` must be called before recording `PrevStmt`.
 	a.LogSlowQuery(txnTS, succ, hasMoreResults)
 	a.SummaryStmt(succ)
+	a.recordCardinalityFeedback(succ)
 	a.observeStmtFinishedForTopSQL()
 	a.UpdatePlanCacheRuntimeInfo()
 	if sessVars.StmtCtx.IsTiFlash.Load() {
@@ -1928,7 +1929,7 @@ func getEncodedPlan(stmtCtx *stmtctx.StatementContext, genHint bool) (encodedPla
 			switch tableHint.HintName.L {
 			case hint.HintMemoryQuota, hint.HintUseToja, hint.HintNoIndexMerge,
 				hint.HintMaxExecutionTime, hint.HintIgnoreIndex, hint.HintReadFromStorage,
-				hint.HintMerge, hint.HintSemiJoinRewrite, hint.HintNoDecorrelate:
+				hint.HintMerge, hint.HintSemiJoinRewrite, hint.HintNoDecorrelate, hint.HintRuntimeFilter:
 				hints = append(hints, tableHint)
 			}
 		}
@@ -0,0 +1,113 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/join"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+)
+
+var (
+	_ join.LookupTarget = &TableReaderExecutor{}
+	_ join.LookupTarget = &IndexLookUpExecutor{}
+	_ exec.Executor     = &cteJoinExec{}
+)
+
+// lookupKeyColumnOffset returns the offset of the column in the schema, or -1 if the column is not output.
+func lookupKeyColumnOffset(schema *expression.Schema, colID int64) int {
+	for i, col := range schema.Columns {
+		if col.ID == colID {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildLookupRanges builds the point ranges of the sorted keys, it returns nil if keys is nil.
+func buildLookupRanges(keys []types.Datum) []*ranger.Range {
+	if keys == nil {
+		return nil
+	}
+	ranges := make([]*ranger.Range, 0, len(keys))
+	for _, key := range keys {
+		ranges = append(ranges, &ranger.Range{
+			LowVal:    []types.Datum{key},
+			HighVal:   []types.Datum{key},
+			Collators: collate.GetBinaryCollatorSlice(1),
+		})
+	}
+	return ranges
+}
+
+// joinChildCTE returns the CTE read by the child of the join, the filters on the CTE are skipped. It returns nil if
+// the child doesn't read a CTE.
+func joinChildCTE(child exec.Executor) *CTEExec {
+	for {
+		switch x := child.(type) {
+		case *CTEExec:
+			return x
+		case *SelectionExec:
+			child = x.Children(0)
+		default:
+			return nil
+		}
+	}
+}
+
+// cteJoinExec runs the hash join of a CTE with the build side chosen at the checkpoint of the CTE. The CTE is
+// materialized before the join is opened, if its rows are misestimated and the other child is now estimated to be
+// smaller, the join which builds the hash table from the other child is run instead of the planned one.
+type cteJoinExec struct {
+	exec.BaseExecutor
+
+	cte *CTEExec
+	// cteIsBuildSide is whether the planned join builds the hash table from the CTE.
+	cteIsBuildSide bool
+	// otherEstRows is the estimated rows of the other child of the join.
+	otherEstRows float64
+	planned      exec.Executor
+	swapped      exec.Executor
+}
+
+// Open implements the Executor interface.
+func (e *cteJoinExec) Open(ctx context.Context) error {
+	if err := exec.Open(ctx, e.cte); err != nil {
+		return err
+	}
+	rows, err := e.cte.materialize(ctx)
+	if err != nil {
+		return err
+	}
+	chosen, decision := e.planned, adaptive.DecisionNone
+	if e.cte.checkpoint.Misestimated(rows) && (float64(rows) > e.otherEstRows) == e.cteIsBuildSide {
+		chosen, decision = e.swapped, adaptive.DecisionSwapBuildSide
+	}
+	e.cte.registerCheckpointStats(rows, decision)
+	e.SetChildren(0, chosen)
+	return exec.Open(ctx, chosen)
+}
+
+// Next implements the Executor interface.
+func (e *cteJoinExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	return exec.Next(ctx, e.Children(0), req)
+}
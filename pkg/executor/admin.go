@@ -17,6 +17,7 @@ package executor
 import (
 	"context"
 	"math"
+	"slices"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
@@ -121,7 +122,7 @@ func (e *CheckIndexRangeExec) Open(ctx context.Context) error {
 		return nil
 	}
 	var builder distsql.RequestBuilder
-	kvReq, err := builder.SetIndexRanges(e.Ctx().GetDistSQLCtx(), e.table.ID, e.index.ID, ranger.FullRange()).
+	kvReq, err := builder.SetIndexRanges(e.Ctx().GetDistSQLCtx(), e.table.ID, e.index, ranger.FullRange()).
 		SetDAGRequest(dagPB).
 		SetStartTS(txn.StartTS()).
 		SetKeepOrder(true).
@@ -360,7 +361,7 @@ type recoverRows struct {
 
 func (e *RecoverIndexExec) fetchRecoverRows(ctx context.Context, srcResult distsql.SelectResult, result *backfillResult) ([]recoverRows, error) {
 	e.recoverRows = e.recoverRows[:0]
-	idxValLen := len(e.index.Meta().Columns)
+	idxValLen := len(e.index.Meta().Columns) + len(e.index.Meta().IncludeColumns)
 	result.scanRowCount = 0
 
 	for {
@@ -384,6 +385,17 @@ func (e *RecoverIndexExec) fetchRecoverRows(ctx context.Context, srcResult dists
 			if e.index.Meta().Global {
 				handle = kv.NewPartitionHandle(e.physicalID, handle)
 			}
+			if e.index.Meta().HasCondition() {
+				meet, err := e.index.MeetPartialCondition(e.Ctx().GetExprCtx().GetEvalCtx(), row.GetDatumRow(e.colFieldTypes))
+				if err != nil {
+					return nil, err
+				}
+				if !meet {
+					result.scanRowCount++
+					result.currentHandle = handle
+					continue
+				}
+			}
 			idxVals, err := e.buildIndexedValues(row, e.idxValsBufs[result.scanRowCount], e.colFieldTypes, idxValLen)
 			if err != nil {
 				return nil, err
@@ -419,7 +431,11 @@ func (e *RecoverIndexExec) buildIndexedValues(row chunk.Row, idxVals []types.Dat
 	}
 
 	sctx := e.Ctx()
-	for i, col := range e.index.Meta().Columns {
+	idxCols := e.index.Meta().Columns
+	if e.index.Meta().HasIncludeColumns() {
+		idxCols = append(slices.Clip(idxCols), e.index.Meta().IncludeColumns...)
+	}
+	for i, col := range idxCols {
 		if e.table.Meta().Columns[col.Offset].IsGenerated() {
 			val, err := e.cols[col.Offset].EvalVirtualColumn(sctx.GetExprCtx().GetEvalCtx(), row)
 			if err != nil {
@@ -803,7 +819,7 @@ func (e *CleanupIndexExec) buildIndexScan(ctx context.Context, txn kv.Transactio
 	}
 	var builder distsql.RequestBuilder
 	ranges := ranger.FullRange()
-	keyRanges, err := distsql.IndexRangesToKVRanges(e.Ctx().GetDistSQLCtx(), e.physicalID, e.index.Meta().ID, ranges)
+	keyRanges, err := distsql.IndexRangesToKVRanges(e.Ctx().GetDistSQLCtx(), e.physicalID, e.index.Meta(), ranges)
 	if err != nil {
 		return nil, err
 	}
@@ -198,6 +198,14 @@ func getKeysNeedCheckOneRow(ctx sessionctx.Context, t table.Table, row []types.D
 		if t.Meta().IsCommonHandle && v.Meta().Primary {
 			continue
 		}
+		// The rows not satisfying the condition of a partial index are never in it.
+		meet, err1 := v.MeetPartialCondition(ctx.GetExprCtx().GetEvalCtx(), row)
+		if err1 != nil {
+			return nil, err1
+		}
+		if !meet {
+			continue
+		}
 		colVals, err1 := v.FetchValues(row, nil)
 		if err1 != nil {
 			return nil, err1
@@ -219,7 +227,7 @@ func getKeysNeedCheckOneRow(ctx sessionctx.Context, t table.Table, row []types.D
 			if v.Meta().State != model.StatePublic && v.Meta().BackfillState != model.BackfillStateInapplicable {
 				_, key, _ = tables.GenTempIdxKeyByState(v.Meta(), key)
 			}
-			colStrVals, err1 := dataToStrings(colVals)
+			colStrVals, err1 := dataToStrings(colVals[:len(v.Meta().Columns)])
 			if err1 != nil {
 				return nil, err1
 			}
@@ -40,10 +40,12 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor/aggfuncs"
 	"github.com/pingcap/tidb/pkg/executor/aggregate"
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
 	"github.com/pingcap/tidb/pkg/executor/internal/builder"
 	"github.com/pingcap/tidb/pkg/executor/internal/calibrateresource"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/internal/pdhelper"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
 	"github.com/pingcap/tidb/pkg/executor/internal/querywatch"
 	"github.com/pingcap/tidb/pkg/executor/internal/testutil"
 	"github.com/pingcap/tidb/pkg/executor/internal/vecgroupchecker"
@@ -281,6 +283,8 @@ func (b *executorBuilder) build(p base.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -617,6 +621,9 @@ func (b *executorBuilder) buildRecoverIndex(v *plannercore.RecoverIndex) exec.Ex
 			hasGenedCol = true
 		}
 	}
+	// The condition of a partial index is evaluated on the full row as well,
+	// and so are the values of the included columns fetched.
+	hasGenedCol = hasGenedCol || index.Meta().HasCondition() || index.Meta().HasIncludeColumns()
 	cols := buildIdxColsConcatHandleCols(tblInfo, index.Meta(), hasGenedCol)
 	e := &RecoverIndexExec{
 		BaseExecutor:     exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
@@ -889,6 +896,9 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Partition:             v.Partition,
 		Column:                v.Column,
 		IndexName:             v.IndexName,
+		Procedure:             v.Procedure,
+		Trigger:               v.Trigger,
+		Event:                 v.Event,
 		ResourceGroupName:     ast.NewCIStr(v.ResourceGroupName),
 		Flag:                  v.Flag,
 		Roles:                 v.Roles,
@@ -925,6 +935,16 @@ func (b *executorBuilder) buildSimple(v *plannercore.Simple) exec.Executor {
 			WorkloadType: s.Tp,
 			OptionList:   s.DynamicCalibrateResourceOptionList,
 		}
+	case *ast.CallStmt:
+		return &procedure.Executor{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
+			Stmt:         s,
+		}
+	case *ast.ProcedureBlock:
+		return &procedure.EventExecutor{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
+			Block:        s,
+		}
 	case *ast.AddQueryWatchStmt:
 		return &querywatch.AddExecutor{
 			BaseExecutor:         exec.NewBaseExecutor(b.ctx, v.Schema(), 0),
@@ -1009,14 +1029,28 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.triggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerInsert)
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
+		ivs.deleteTriggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerDelete)
+		if b.err != nil {
+			return nil
+		}
 		return b.buildReplace(ivs)
 	}
 	insert := &InsertExec{
 		InsertValues: ivs,
 		OnDuplicate:  append(v.OnDuplicate, v.GenCols.OnDuplicates...),
 	}
+	if len(insert.OnDuplicate) > 0 {
+		insert.updateTriggers, b.err = b.buildTriggerExec(ivs.Table, ast.TriggerUpdate)
+		if b.err != nil {
+			return nil
+		}
+	}
 	return insert
 }
 
@@ -1384,6 +1418,9 @@ func (b *executorBuilder) buildUnionScanFromReader(reader exec.Executor, v *plan
 			for i, col := range x.columns {
 				if col.Name.L == ic.Name.L {
 					us.usedIndex = append(us.usedIndex, i)
+					if x.index.HasDescColumn() {
+						us.usedIndexReversed = append(us.usedIndexReversed, ic.Desc)
+					}
 					break
 				}
 			}
@@ -1400,6 +1437,9 @@ func (b *executorBuilder) buildUnionScanFromReader(reader exec.Executor, v *plan
 			for i, col := range x.columns {
 				if col.Name.L == ic.Name.L {
 					us.usedIndex = append(us.usedIndex, i)
+					if x.index.HasDescColumn() {
+						us.usedIndexReversed = append(us.usedIndexReversed, ic.Desc)
+					}
 					break
 				}
 			}
@@ -1423,6 +1463,7 @@ func (b *executorBuilder) buildUnionScanFromReader(reader exec.Executor, v *plan
 				for i, col := range x.columns {
 					if col.ID == c.ID {
 						us.usedIndex = append(us.usedIndex, i)
+						us.usedIndexReversed = append(us.usedIndexReversed, item.Desc != us.desc)
 						break
 					}
 				}
@@ -1591,6 +1632,53 @@ func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.
 		return nil
 	}
 
+	e := b.buildHashJoinV2WithChildren(v, leftExec, rightExec)
+	if b.err != nil {
+		return nil
+	}
+	return b.buildCTEJoin(v, e, leftExec, rightExec)
+}
+
+// buildCTEJoin wraps the inner hash join of a CTE, so that the build side can be swapped at the checkpoint of the
+// CTE. The hash join is returned as it is if the build side can't be swapped.
+func (b *executorBuilder) buildCTEJoin(v *plannercore.PhysicalHashJoin, planned exec.Executor, leftExec, rightExec exec.Executor) exec.Executor {
+	if v.JoinType != logicalop.InnerJoin || v.UseOuterToBuild || len(v.LeftNAJoinKeys) > 0 ||
+		len(v.LeftConditions) > 0 || len(v.RightConditions) > 0 || len(v.RootRuntimeFilters()) > 0 {
+		return planned
+	}
+	// The build side isn't swapped between 2 CTEs, they are both estimated before they are materialized.
+	var cte *CTEExec
+	cteIdx := -1
+	for i, child := range []exec.Executor{leftExec, rightExec} {
+		if childCTE := joinChildCTE(child); childCTE != nil {
+			if cte != nil {
+				return planned
+			}
+			cte, cteIdx = childCTE, i
+		}
+	}
+	if cte == nil || cte.checkpoint == nil {
+		return planned
+	}
+	swappedPlan, err := v.CloneWithOtherBuildSide()
+	if err != nil {
+		return planned
+	}
+	swapped := b.buildHashJoinV2WithChildren(swappedPlan, leftExec, rightExec)
+	if b.err != nil {
+		return nil
+	}
+	return &cteJoinExec{
+		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), 0, planned),
+		cte:            cte,
+		cteIsBuildSide: cteIdx == v.InnerChildIdx,
+		otherEstRows:   v.Children()[1-cteIdx].StatsInfo().RowCount,
+		planned:        planned,
+		swapped:        swapped,
+	}
+}
+
+func (b *executorBuilder) buildHashJoinV2WithChildren(v *plannercore.PhysicalHashJoin, leftExec, rightExec exec.Executor) *join.HashJoinV2Exec {
 	joinOtherCondition := v.OtherConditions
 	joinLeftCondition := v.LeftConditions
 	joinRightCondition := v.RightConditions
@@ -1749,9 +1837,46 @@ func (b *executorBuilder) buildHashJoinV2(v *plannercore.PhysicalHashJoin) exec.
 
 		e.BuildWorkers[i] = join.NewJoinBuildWorkerV2(e.HashJoinCtxV2, i, buildSideExec, buildKeyColIdx, exec.RetTypes(buildSideExec))
 	}
+	e.RuntimeFilters = buildJoinRuntimeFilters(v.RootRuntimeFilters(), e.ProbeSideTupleFetcher.ProbeSideExec, probeKeyColIdx)
+	buildSideIdx, probeSideIdx := 0, 1
+	if e.RightAsBuildSide {
+		buildSideIdx, probeSideIdx = 1, 0
+	}
+	checkpoint := adaptive.NewCheckpoint(b.ctx.GetSessionVars(), adaptive.CheckpointBuild, v.Children()[buildSideIdx].StatsInfo().RowCount)
+	e.SetCheckpoint(checkpoint, v.Children()[probeSideIdx].StatsInfo().RowCount, probeKeyColIdx, v.IsNullEQ, len(v.LeftNAJoinKeys) > 0)
 	return e
 }
 
+// buildJoinRuntimeFilters builds the runtime filters of the hash join, the pushed down types are only applied if the
+// probe side executor can accept them.
+func buildJoinRuntimeFilters(rfs []*plannercore.RootRuntimeFilter, probeSideExec exec.Executor, probeKeyColIdx []int) []*join.RuntimeFilter {
+	if len(rfs) == 0 {
+		return nil
+	}
+	_, canPushDown := probeSideExec.(join.RuntimeFilterTarget)
+	runtimeFilters := make([]*join.RuntimeFilter, 0, len(rfs))
+	for _, rf := range rfs {
+		runtimeFilter := &join.RuntimeFilter{
+			ID:             rf.ID,
+			KeyIdx:         rf.EQCondIdx,
+			ProbeKeyColIdx: probeKeyColIdx[rf.EQCondIdx],
+		}
+		if canPushDown && rf.TargetSelection != nil {
+			runtimeFilter.Types = slices.Clone(rf.PushDownTypes)
+			runtimeFilter.TargetColumn = rf.TargetColumn
+			runtimeFilter.TargetSelectionID = rf.TargetSelection.ID()
+			runtimeFilter.TargetScanID = rf.TargetSelection.Children()[0].ID()
+		}
+		if rf.BloomFilter {
+			runtimeFilter.Types = append(runtimeFilter.Types, variable.BloomFilter)
+		}
+		if len(runtimeFilter.Types) > 0 {
+			runtimeFilters = append(runtimeFilters, runtimeFilter)
+		}
+	}
+	return runtimeFilters
+}
+
 func (b *executorBuilder) buildHashJoin(v *plannercore.PhysicalHashJoin) exec.Executor {
 	if b.ctx.GetSessionVars().UseHashJoinV2 && joinversion.IsHashJoinV2Supported() && v.CanUseHashJoinV2() {
 		return b.buildHashJoinV2(v)
@@ -2128,6 +2253,20 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	offset := 0
+	root, err := buildJSONTableNode(v.Path, v.Columns, v.Schema(), &offset)
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		root:         root,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2312,6 +2451,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2578,6 +2720,7 @@ func (b *executorBuilder) buildApply(v *plannercore.PhysicalApply) exec.Executor
 			Sctx:         b.ctx,
 			CanUseCache:  v.CanUseCache,
 		}
+		serialExec.Checkpoint = adaptive.NewCheckpoint(b.ctx.GetSessionVars(), adaptive.CheckpointOuter, outerPlan.StatsInfo().RowCount)
 		executor_metrics.ExecutorCounterNestedLoopApplyExec.Inc()
 		return serialExec
 	}
@@ -2771,6 +2914,10 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table, ast.TriggerUpdate)
+	if b.err != nil {
+		return nil
+	}
 	return updateExec
 }
 
@@ -2824,6 +2971,10 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table, ast.TriggerDelete)
+	if b.err != nil {
+		return nil
+	}
 	return deleteExec
 }
 
@@ -3648,6 +3799,7 @@ func buildNoRangeTableReader(b *executorBuilder, v *plannercore.PhysicalTableRea
 		tablePlan:                  v.GetTablePlan(),
 		storeType:                  v.StoreType,
 		batchCop:                   v.ReadReqType == plannercore.BatchCop,
+		runtimeFilterSelections:    buildRuntimeFilterSelections(v.TablePlans),
 	}
 	e.buildVirtualColumnInfo()
 
@@ -4169,6 +4321,8 @@ func buildNoRangeIndexLookUpReader(b *executorBuilder, v *plannercore.PhysicalIn
 		PushedLimit:                v.PushedLimit,
 		idxNetDataSize:             v.GetAvgTableRowSize(),
 		avgRowSize:                 v.GetAvgTableRowSize(),
+		idxRuntimeFilterSelections: buildRuntimeFilterSelections(v.IndexPlans),
+		tblRuntimeFilterSelections: buildRuntimeFilterSelections(v.TablePlans),
 	}
 
 	if v.ExtraHandleCol != nil {
@@ -4531,7 +4685,7 @@ func (builder *dataReaderBuilder) buildTableReaderForIndexJoin(ctx context.Conte
 	tbInfo := e.table.Meta()
 	if tbInfo.GetPartitionInfo() == nil || !builder.ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
 		if v.IsCommonHandle {
-			kvRanges, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, getPhysicalTableID(e.table), -1, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
+			kvRanges, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, getPhysicalTableID(e.table), nil, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
 			if err != nil {
 				return nil, err
 			}
@@ -4581,7 +4735,7 @@ func (builder *dataReaderBuilder) buildTableReaderForIndexJoin(ctx context.Conte
 			}
 			for pid, contents := range lookUpContentsByPID {
 				// buildKvRanges for each partition.
-				tmp, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, pid, -1, contents, indexRanges, keyOff2IdxOff, cwc, nil, interruptSignal)
+				tmp, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, pid, nil, contents, indexRanges, keyOff2IdxOff, cwc, nil, interruptSignal)
 				if err != nil {
 					return nil, err
 				}
@@ -4590,7 +4744,7 @@ func (builder *dataReaderBuilder) buildTableReaderForIndexJoin(ctx context.Conte
 		} else {
 			kvRanges = make([]kv.KeyRange, 0, len(usedPartitions)*len(lookUpContents))
 			for _, p := range usedPartitionList {
-				tmp, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, p.GetPhysicalID(), -1, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
+				tmp, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, p.GetPhysicalID(), nil, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
 				if err != nil {
 					return nil, err
 				}
@@ -4789,7 +4943,7 @@ func (builder *dataReaderBuilder) buildIndexReaderForIndexJoin(ctx context.Conte
 	}
 	tbInfo := e.table.Meta()
 	if tbInfo.GetPartitionInfo() == nil || !builder.ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
-		kvRanges, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, e.physicalTableID, e.index.ID, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memoryTracker, interruptSignal)
+		kvRanges, err := buildKvRangesForIndexJoin(e.dctx, e.rctx, e.physicalTableID, e.index, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memoryTracker, interruptSignal)
 		if err != nil {
 			return nil, err
 		}
@@ -4851,7 +5005,7 @@ func (builder *dataReaderBuilder) buildIndexLookUpReaderForIndexJoin(ctx context
 
 	tbInfo := e.table.Meta()
 	if tbInfo.GetPartitionInfo() == nil || !builder.ctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
-		e.kvRanges, err = buildKvRangesForIndexJoin(e.dctx, e.rctx, getPhysicalTableID(e.table), e.index.ID, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
+		e.kvRanges, err = buildKvRangesForIndexJoin(e.dctx, e.rctx, getPhysicalTableID(e.table), e.index, lookUpContents, indexRanges, keyOff2IdxOff, cwc, memTracker, interruptSignal)
 		if err != nil {
 			return nil, err
 		}
@@ -5002,7 +5156,8 @@ func buildRangesForIndexJoin(rctx *rangerctx.RangerContext, lookUpContents []*jo
 }
 
 // buildKvRangesForIndexJoin builds kv ranges for index join when the inner plan is index scan plan.
-func buildKvRangesForIndexJoin(dctx *distsqlctx.DistSQLContext, pctx *rangerctx.RangerContext, tableID, indexID int64, lookUpContents []*join.IndexJoinLookUpContent,
+// A nil index means the inner plan scans the common handle.
+func buildKvRangesForIndexJoin(dctx *distsqlctx.DistSQLContext, pctx *rangerctx.RangerContext, tableID int64, index *model.IndexInfo, lookUpContents []*join.IndexJoinLookUpContent,
 	ranges []*ranger.Range, keyOff2IdxOff []int, cwc *plannercore.ColWithCmpFuncManager, memTracker *memory.Tracker, interruptSignal *atomic.Value) (_ []kv.KeyRange, err error) {
 	kvRanges := make([]kv.KeyRange, 0, len(ranges)*len(lookUpContents))
 	if len(ranges) == 0 {
@@ -5018,13 +5173,13 @@ func buildKvRangesForIndexJoin(dctx *distsqlctx.DistSQLContext, pctx *rangerctx.
 			}
 		}
 		if cwc == nil {
-			// Index id is -1 means it's a common handle.
+			// Index is nil means it's a common handle.
 			var tmpKvRanges *kv.KeyRanges
 			var err error
-			if indexID == -1 {
+			if index == nil {
 				tmpKvRanges, err = distsql.CommonHandleRangesToKVRanges(dctx, []int64{tableID}, ranges)
 			} else {
-				tmpKvRanges, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, tableID, indexID, ranges, memTracker, interruptSignal)
+				tmpKvRanges, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, tableID, index, ranges, memTracker, interruptSignal)
 			}
 			if err != nil {
 				return nil, err
@@ -5067,12 +5222,12 @@ func buildKvRangesForIndexJoin(dctx *distsqlctx.DistSQLContext, pctx *rangerctx.
 	if err != nil {
 		return nil, err
 	}
-	// Index id is -1 means it's a common handle.
-	if indexID == -1 {
+	// Index is nil means it's a common handle.
+	if index == nil {
 		tmpKeyRanges, err := distsql.CommonHandleRangesToKVRanges(dctx, []int64{tableID}, tmpDatumRanges)
 		return tmpKeyRanges.FirstPartitionRange(), err
 	}
-	tmpKeyRanges, err := distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, tableID, indexID, tmpDatumRanges, memTracker, interruptSignal)
+	tmpKeyRanges, err := distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, tableID, index, tmpDatumRanges, memTracker, interruptSignal)
 	return tmpKeyRanges.FirstPartitionRange(), err
 }
 
@@ -5682,6 +5837,7 @@ func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) exec.Executor {
 	return &CTEExec{
 		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 		producer:     producer,
+		checkpoint:   adaptive.NewCheckpoint(b.ctx.GetSessionVars(), adaptive.CheckpointCTE, v.StatsInfo().RowCount),
 	}
 }
 
@@ -0,0 +1,164 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// recordCardinalityFeedback captures the estimated and actual rows of the filters on the tables. Only EXPLAIN ANALYZE
+// and slow queries are sampled, because their plans are the most likely to be improved by the feedback.
+func (a *ExecStmt) recordCardinalityFeedback(succ bool) {
+	sessVars := a.Ctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	if !succ || !sessVars.EnableCardinalityFeedback || sessVars.InRestrictedSQL || stmtCtx.RuntimeStatsColl == nil {
+		return
+	}
+	if explain, ok := a.Plan.(*plannercore.Explain); ok {
+		// The rows are rendered only after the analyzed statement is executed.
+		if !explain.Analyze || len(explain.Rows) == 0 {
+			return
+		}
+	} else {
+		threshold := time.Duration(atomic.LoadUint64(&config.GetGlobalConfig().Instance.SlowThreshold)) * time.Millisecond
+		if sessVars.GetTotalCostDuration() < threshold {
+			return
+		}
+	}
+	do := domain.GetDomain(a.Ctx)
+	if do == nil || do.CardinalityFeedback() == nil {
+		return
+	}
+	flat := getFlatPlan(stmtCtx)
+	if flat == nil {
+		return
+	}
+	for _, feedback := range collectCardinalityFeedback(flat.Main, stmtCtx.RuntimeStatsColl) {
+		do.CardinalityFeedback().Record(feedback.info.TableID, feedback.info.Fingerprint, feedback.info.Predicate,
+			feedback.info.EstRows, float64(feedback.actRows))
+	}
+}
+
+type cardinalityFeedback struct {
+	info    *cardinality.FeedbackInfo
+	actRows int64
+}
+
+// collectCardinalityFeedback collects the actual rows of the readers whose output are exactly the rows of the filters
+// on the DataSource, the Selection above the reader is included since it evaluates the filters which can't be pushed
+// down. The readers under Apply are skipped because they're executed many times.
+func collectCardinalityFeedback(tree plannercore.FlatPlanTree, statsColl *execdetails.RuntimeStatsColl) []cardinalityFeedback {
+	parents := make([]int, len(tree))
+	for i := range parents {
+		parents[i] = -1
+	}
+	for i, op := range tree {
+		for _, childIdx := range op.ChildrenIdx {
+			parents[childIdx] = i
+		}
+	}
+	var feedbacks []cardinalityFeedback
+	for i, op := range tree {
+		if !op.IsRoot {
+			continue
+		}
+		info := readerCardinalityFeedback(op.Origin)
+		if info == nil {
+			continue
+		}
+		outputIdx := i
+		if parentIdx := parents[i]; parentIdx >= 0 {
+			if _, ok := tree[parentIdx].Origin.(*plannercore.PhysicalSelection); ok {
+				outputIdx = parentIdx
+			}
+		}
+		if underApply(tree, parents, outputIdx) {
+			continue
+		}
+		output := tree[outputIdx].Origin.(base.PhysicalPlan)
+		// The estimated rows are scaled down if the rows are limited by the parent, so the actual rows are too.
+		if output.StatsInfo().RowCount < info.RowCount*0.99 || !statsColl.ExistsRootStats(output.ID()) {
+			continue
+		}
+		feedbacks = append(feedbacks, cardinalityFeedback{
+			info:    info,
+			actRows: statsColl.GetPlanActRows(output.ID()),
+		})
+	}
+	return feedbacks
+}
+
+func underApply(tree plannercore.FlatPlanTree, parents []int, idx int) bool {
+	for idx = parents[idx]; idx >= 0; idx = parents[idx] {
+		if _, ok := tree[idx].Origin.(*plannercore.PhysicalApply); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// readerCardinalityFeedback returns the cardinality feedback of the reader if the pushed down plans are only the scan
+// and the Selections.
+func readerCardinalityFeedback(p base.Plan) *cardinality.FeedbackInfo {
+	switch reader := p.(type) {
+	case *plannercore.PhysicalTableReader:
+		return copPlansCardinalityFeedback(reader.TablePlans)
+	case *plannercore.PhysicalIndexReader:
+		return copPlansCardinalityFeedback(reader.IndexPlans)
+	case *plannercore.PhysicalIndexLookUpReader:
+		if reader.PushedLimit != nil || copPlansCardinalityFeedback(reader.TablePlans) != nil {
+			return nil
+		}
+		if !onlyScanAndSelections(reader.TablePlans) {
+			return nil
+		}
+		return copPlansCardinalityFeedback(reader.IndexPlans)
+	}
+	return nil
+}
+
+func copPlansCardinalityFeedback(plans []base.PhysicalPlan) *cardinality.FeedbackInfo {
+	if !onlyScanAndSelections(plans) {
+		return nil
+	}
+	for _, p := range plans {
+		switch scan := p.(type) {
+		case *plannercore.PhysicalTableScan:
+			return scan.CardinalityFeedback()
+		case *plannercore.PhysicalIndexScan:
+			return scan.CardinalityFeedback()
+		}
+	}
+	return nil
+}
+
+func onlyScanAndSelections(plans []base.PhysicalPlan) bool {
+	for _, p := range plans {
+		switch p.(type) {
+		case *plannercore.PhysicalTableScan, *plannercore.PhysicalIndexScan, *plannercore.PhysicalSelection:
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/changefeed"
+	"github.com/pingcap/tidb/pkg/disttask/framework/handle"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// The changefeeds are streamed to the sinks by the tasks of the distributed
+// execution framework, see the changefeed package.
+
+func (e *DDLExec) executeCreateChangefeed(ctx context.Context, s *ast.CreateChangefeedStmt) error {
+	format, err := changefeed.ValidateSink(s.SinkURI, s.Format)
+	if err != nil {
+		return dbterror.ErrInvalidChangefeed.GenWithStackByArgs(s.Changefeed.Name.O, err.Error())
+	}
+	cf := &model.ChangefeedInfo{
+		Name:     s.Changefeed.Name,
+		SinkURI:  s.SinkURI,
+		Format:   format,
+		CreateTS: time.Now(),
+	}
+	existed := findChangefeed(e.is, s.Table, s.Changefeed.Name) != nil
+	if err = e.ddlExecutor.CreateChangefeed(e.Ctx(), s, cf); err != nil || existed {
+		return err
+	}
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	if cf = findChangefeed(is, s.Table, s.Changefeed.Name); cf == nil {
+		return nil
+	}
+	tblInfo, err := is.TableInfoByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return err
+	}
+	// The changes committed after the changefeed is created are streamed.
+	startTS, err := e.Ctx().GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return err
+	}
+	meta, err := json.Marshal(&changefeed.TaskMeta{
+		ChangefeedID: cf.ID,
+		SchemaID:     tblInfo.DBID,
+		TableID:      tblInfo.ID,
+		StartTS:      startTS.Ver,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = handle.SubmitTask(ctx, changefeed.TaskKey(cf.ID), proto.Changefeed, 1, "", 1, meta)
+	return err
+}
+
+// findChangefeed finds the changefeed of the table, it returns nil if the table
+// or the changefeed doesn't exist.
+func findChangefeed(is infoschema.InfoSchema, tn *ast.TableName, name ast.CIStr) *model.ChangefeedInfo {
+	tblInfo, err := is.TableInfoByName(tn.Schema, tn.Name)
+	if err != nil {
+		return nil
+	}
+	if idx := tblInfo.FindChangefeed(name.L); idx >= 0 {
+		return tblInfo.Changefeeds[idx]
+	}
+	return nil
+}
+
+func (e *DDLExec) executeDropChangefeed(ctx context.Context, s *ast.DropChangefeedStmt) error {
+	var changefeedID int64
+	if tblInfos, err := e.is.SchemaTableInfos(ctx, s.Changefeed.Schema); err == nil {
+		for _, tblInfo := range tblInfos {
+			if idx := tblInfo.FindChangefeed(s.Changefeed.Name.L); idx >= 0 {
+				changefeedID = tblInfo.Changefeeds[idx].ID
+				break
+			}
+		}
+	}
+	if err := e.ddlExecutor.DropChangefeed(e.Ctx(), s); err != nil || changefeedID == 0 {
+		return err
+	}
+	// The task stops by itself when it finds the changefeed is dropped, cancel
+	// it to stop it at once.
+	if err := handle.CancelTask(ctx, changefeed.TaskKey(changefeedID)); err != nil && err != storage.ErrTaskNotFound {
+		return err
+	}
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnDDL)
+	defer e.ReleaseSysSession(ctx, sysSession)
+	return changefeed.DeleteChangefeedData(ctx, e.Ctx().GetStore(), sysSession.GetSQLExecutor(), changefeedID)
+}
+
+// changefeedStatus is the progress of a changefeed shown by SHOW CHANGEFEEDS.
+type changefeedStatus struct {
+	checkpointTS   any
+	writtenChanges int64
+	state          string
+	lastError      any
+}
+
+func getChangefeedStatus(ctx context.Context, sctx sessionctx.Context, id int64) (*changefeedStatus, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	exec := sctx.GetRestrictedSQLExecutor()
+	status := &changefeedStatus{}
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, "SELECT checkpoint_ts, written_changes, last_error FROM %n.%n WHERE changefeed_id = %?",
+		changefeed.SystemDB, changefeed.CheckpointTable, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) > 0 {
+		status.checkpointTS = rows[0].GetUint64(0)
+		status.writtenChanges = rows[0].GetInt64(1)
+		if !rows[0].IsNull(2) {
+			status.lastError = rows[0].GetString(2)
+		}
+	}
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	task, err := taskMgr.GetTaskBaseByKeyWithHistory(ctx, changefeed.TaskKey(id))
+	if err != nil && err != storage.ErrTaskNotFound {
+		return nil, err
+	}
+	if task != nil {
+		status.state = task.State.String()
+	}
+	return status, nil
+}
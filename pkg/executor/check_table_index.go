@@ -141,7 +141,8 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 
 	idxNames := make([]string, 0, len(e.indexInfos))
 	for _, idx := range e.indexInfos {
-		if idx.MVIndex || idx.IsColumnarIndex() {
+		// The count of a partial index is always less than the table.
+		if idx.MVIndex || idx.IsColumnarIndex() || idx.HasCondition() {
 			continue
 		}
 		idxNames = append(idxNames, idx.Name.O)
@@ -164,7 +165,7 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	// TODO: Make the value of concurrency adjustable. And we can consider the number of records.
 	if len(e.srcs) == 1 {
 		err = e.checkIndexHandle(ctx, e.srcs[0])
-		if err == nil && e.srcs[0].index.MVIndex {
+		if err == nil && (e.srcs[0].index.MVIndex || e.srcs[0].index.HasCondition()) {
 			err = e.checkTableRecord(ctx, 0)
 		}
 		if err != nil {
@@ -187,7 +188,7 @@ func (e *CheckTableExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 				select {
 				case src := <-taskCh:
 					err1 := e.checkIndexHandle(ctx, src)
-					if err1 == nil && src.index.MVIndex {
+					if err1 == nil && (src.index.MVIndex || src.index.HasCondition()) {
 						for offset, idx := range e.indexInfos {
 							if idx.ID == src.index.ID {
 								err1 = e.checkTableRecord(ctx, offset)
@@ -370,7 +371,7 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 	}
 	handleColumns := strings.Join(pkCols, ",")
 
-	indexColNames := make([]string, len(idxInfo.Columns))
+	indexColNames := make([]string, len(idxInfo.Columns), len(idxInfo.Columns)+len(idxInfo.IncludeColumns))
 	for i, col := range idxInfo.Columns {
 		tblCol := tblMeta.Columns[col.Offset]
 		if tblCol.IsVirtualGenerated() && tblCol.Hidden {
@@ -379,6 +380,10 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 			indexColNames[i] = ColumnName(col.Name.O)
 		}
 	}
+	// The values of the included columns are checked as well.
+	for _, col := range idxInfo.IncludeColumns {
+		indexColNames = append(indexColNames, ColumnName(col.Name.O))
+	}
 	indexColumns := strings.Join(indexColNames, ",")
 
 	// CheckSum of (handle + index columns).
@@ -387,6 +392,12 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 	// Used to group by and order.
 	md5Handle := fmt.Sprintf("crc32(md5(concat_ws(0x2, %s)))", handleColumns)
 
+	// Only the rows satisfying the condition are in a partial index.
+	var partialCond string
+	if idxInfo.HasCondition() {
+		partialCond = fmt.Sprintf(" and (%s)", idxInfo.ConditionExprString)
+	}
+
 	tableRowCntToCheck := int64(0)
 
 	offset := 0
@@ -424,11 +435,11 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 		checkOnce = true
 
 		tblQuery := fmt.Sprintf(
-			"select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0 group by %s",
-			tblName, md5HandleAndIndexCol, groupByKey, tblName, whereKey, groupByKey)
+			"select /*+ read_from_storage(tikv[%s]) */ bit_xor(%s), %s, count(*) from %s use index() where %s = 0%s group by %s",
+			tblName, md5HandleAndIndexCol, groupByKey, tblName, whereKey, partialCond, groupByKey)
 		idxQuery := fmt.Sprintf(
-			"select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0 group by %s",
-			md5HandleAndIndexCol, groupByKey, tblName, idxInfo.Name, whereKey, groupByKey)
+			"select bit_xor(%s), %s, count(*) from %s use index(`%s`) where %s = 0%s group by %s",
+			md5HandleAndIndexCol, groupByKey, tblName, idxInfo.Name, whereKey, partialCond, groupByKey)
 
 		logutil.BgLogger().Info(
 			"fast check table by group",
@@ -522,11 +533,11 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 	if meetError {
 		groupByKey := fmt.Sprintf("((cast(%s as signed) - %d) %% %d)", md5Handle, offset, mod)
 		indexSQL := fmt.Sprintf(
-			"select %s, %s, %s from %s use index(`%s`) where %s = 0 order by %s",
-			handleColumns, indexColumns, md5HandleAndIndexCol, tblName, idxInfo.Name, groupByKey, handleColumns)
+			"select %s, %s, %s from %s use index(`%s`) where %s = 0%s order by %s",
+			handleColumns, indexColumns, md5HandleAndIndexCol, tblName, idxInfo.Name, groupByKey, partialCond, handleColumns)
 		tableSQL := fmt.Sprintf(
-			"select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0 order by %s",
-			tblName, handleColumns, indexColumns, md5HandleAndIndexCol, tblName, groupByKey, handleColumns)
+			"select /*+ read_from_storage(tikv[%s]) */ %s, %s, %s from %s use index() where %s = 0%s order by %s",
+			tblName, handleColumns, indexColumns, md5HandleAndIndexCol, tblName, groupByKey, partialCond, handleColumns)
 
 		idxRow, err := queryToRow(se, indexSQL)
 		if err != nil {
@@ -594,7 +605,7 @@ func (w *checkIndexWorker) HandleTask(task checkIndexTask, _ func(workerpool.Non
 		}
 
 		getCheckSum := func(row chunk.Row) uint64 {
-			return row.GetUint64(len(pkCols) + len(idxInfo.Columns))
+			return row.GetUint64(len(pkCols) + len(indexColNames))
 		}
 
 		var handle kv.Handle
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/join"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -77,6 +78,10 @@ type CTEExec struct {
 	// limit in recursive CTE.
 	cursor         uint64
 	meetFirstBatch bool
+
+	// checkpoint compares the materialized rows with the estimated ones, it's nil if the adaptive execution is
+	// disabled.
+	checkpoint *adaptive.Checkpoint
 }
 
 // Open implements the Executor interface.
@@ -120,10 +125,37 @@ func (e *CTEExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		if err = e.producer.genCTEResult(ctx); err != nil {
 			return err
 		}
+		e.registerCheckpointStats(int64(e.producer.resTbl.NumRows()), adaptive.DecisionNone)
 	}
 	return e.producer.getChunk(e, req)
 }
 
+// materialize generates the result of the CTE if it's not generated, and returns the number of its rows. It's called
+// at the checkpoint of the CTE before the CTE is read.
+func (e *CTEExec) materialize(ctx context.Context) (int64, error) {
+	e.producer.resTbl.Lock()
+	defer e.producer.resTbl.Unlock()
+	if !e.producer.hasCTEResult() {
+		if !e.producer.executorOpened {
+			if err := e.producer.openProducerExecutor(ctx, e); err != nil {
+				return 0, err
+			}
+		}
+		if err := e.producer.genCTEResult(ctx); err != nil {
+			return 0, err
+		}
+	}
+	return int64(e.producer.resTbl.NumRows()), nil
+}
+
+// registerCheckpointStats reports the materialized rows of the CTE and the decision made at the checkpoint.
+func (e *CTEExec) registerCheckpointStats(rows int64, decision adaptive.Decision) {
+	if e.checkpoint == nil || e.RuntimeStats() == nil {
+		return
+	}
+	e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.checkpoint.NewRuntimeStats(rows, decision))
+}
+
 func setFirstErr(firstErr error, newErr error, msg string) error {
 	if newErr != nil {
 		logutil.BgLogger().Error("cte got error", zap.Any("err", newErr), zap.String("extra msg", msg))
@@ -23,7 +23,9 @@ import (
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
@@ -170,6 +172,12 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateTable(x)
 	case *ast.CreateViewStmt:
 		err = e.executeCreateView(ctx, x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
+	case *ast.DropMaterializedViewStmt:
+		err = e.executeDropMaterializedView(x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(x)
 	case *ast.DropIndexStmt:
 		err = e.executeDropIndex(x)
 	case *ast.DropDatabaseStmt:
@@ -213,6 +221,18 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeDropSequence(x)
 	case *ast.AlterSequenceStmt:
 		err = e.executeAlterSequence(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(ctx, x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
+	case *ast.CreateChangefeedStmt:
+		err = e.executeCreateChangefeed(ctx, x)
+	case *ast.DropChangefeedStmt:
+		err = e.executeDropChangefeed(ctx, x)
+	case *ast.CreateStatisticsStmt:
+		err = e.ddlExecutor.CreateStatistics(e.Ctx(), x)
+	case *ast.DropStatisticsStmt:
+		err = e.ddlExecutor.DropStatistics(e.Ctx(), x)
 	case *ast.CreatePlacementPolicyStmt:
 		err = e.executeCreatePlacementPolicy(x)
 	case *ast.DropPlacementPolicyStmt:
@@ -256,7 +276,10 @@ func (e *DDLExec) executeTruncateTable(s *ast.TruncateTableStmt) error {
 		return e.tempTableDDL.TruncateLocalTemporaryTable(s.Table.Schema, s.Table.Name)
 	}
 	err = e.ddlExecutor.TruncateTable(e.Ctx(), ident)
-	return err
+	if err != nil {
+		return err
+	}
+	return e.resetMaterializedViews(s.Table.Schema, s.Table.Name)
 }
 
 func (e *DDLExec) executeRenameTable(s *ast.RenameTableStmt) error {
@@ -358,6 +381,12 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	}
 
 	err := e.ddlExecutor.DropSchema(e.Ctx(), s)
+	if err == nil {
+		err = e.dropSchemaRoutines(dbName.O)
+	}
+	if err == nil {
+		err = e.dropSchemaEvents(dbName.O)
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
@@ -373,6 +402,27 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	return err
 }
 
+// dropSchemaRoutines removes the stored routines of the dropped schema.
+func (e *DDLExec) dropSchemaRoutines(dbName string) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	defer e.ReleaseSysSession(ctx, sysSession)
+	return procedure.DropSchemaRoutines(ctx, sysSession.GetSQLExecutor(), dbName)
+}
+
+// dropSchemaEvents removes the events of the dropped schema.
+func (e *DDLExec) dropSchemaEvents(dbName string) error {
+	scheduler := domain.GetDomain(e.Ctx()).EventScheduler()
+	if scheduler == nil {
+		return nil
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	return eventscheduler.DropSchemaEvents(ctx, scheduler.Client(), dbName)
+}
+
 func (e *DDLExec) executeDropTable(s *ast.DropTableStmt) error {
 	return e.ddlExecutor.DropTable(e.Ctx(), s)
 }
@@ -421,7 +471,25 @@ func (e *DDLExec) executeAlterTable(ctx context.Context, s *ast.AlterTableStmt)
 		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
 	}
 
-	return e.ddlExecutor.AlterTable(ctx, e.Ctx(), s)
+	if err = e.ddlExecutor.AlterTable(ctx, e.Ctx(), s); err != nil {
+		return err
+	}
+	// The materialized views need a complete refresh if the rows are changed.
+	for _, spec := range s.Specs {
+		switch spec.Tp {
+		case ast.AlterTableTruncatePartition, ast.AlterTableDropPartition, ast.AlterTableModifyColumn, ast.AlterTableChangeColumn:
+			err = e.resetMaterializedViews(s.Table.Schema, s.Table.Name)
+		case ast.AlterTableExchangePartition:
+			err = e.resetMaterializedViews(s.Table.Schema, s.Table.Name)
+			if err == nil {
+				err = e.resetMaterializedViews(spec.NewTable.Schema, spec.NewTable.Name)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // executeRecoverTable represents a recover table executor.
@@ -769,6 +837,26 @@ func (e *DDLExec) executeAlterSequence(s *ast.AlterSequenceStmt) error {
 	return e.ddlExecutor.AlterSequence(e.Ctx(), s)
 }
 
+func (e *DDLExec) executeCreateTrigger(ctx context.Context, s *ast.CreateTriggerStmt) error {
+	dbInfo, ok := e.is.SchemaByName(s.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.Table.Schema.O)
+	}
+	tbl, err := e.is.TableByName(ctx, s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return err
+	}
+	trigger, err := procedure.NewTriggerInfo(ctx, e.Ctx(), s, dbInfo, tbl.Meta())
+	if err != nil {
+		return err
+	}
+	return e.ddlExecutor.CreateTrigger(e.Ctx(), s, trigger)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return e.ddlExecutor.DropTrigger(e.Ctx(), s)
+}
+
 func (e *DDLExec) executeCreatePlacementPolicy(s *ast.CreatePlacementPolicyStmt) error {
 	return e.ddlExecutor.CreatePlacementPolicy(e.Ctx(), s)
 }
@@ -290,7 +290,7 @@ func (e *IndexReaderExecutor) buildKeyRanges(dctx *distsqlctx.DistSQLContext, ra
 	if e.index.ID == -1 {
 		rRanges, err = distsql.CommonHandleRangesToKVRanges(dctx, []int64{physicalID}, ranges)
 	} else {
-		rRanges, err = distsql.IndexRangesToKVRanges(dctx, physicalID, e.index.ID, ranges)
+		rRanges, err = distsql.IndexRangesToKVRanges(dctx, physicalID, e.index, ranges)
 	}
 	return rRanges.FirstPartitionRange(), err
 }
@@ -511,6 +511,14 @@ type IndexLookUpExecutor struct {
 	// If dummy flag is set, this is not a real IndexLookUpReader, it just provides the KV ranges for UnionScan.
 	// Used by the temporary table, cached table.
 	dummy bool
+
+	// idxRuntimeFilterSelections and tblRuntimeFilterSelections are the Selections whose conditions are set by the
+	// runtime filters of the hash join.
+	idxRuntimeFilterSelections []*runtimeFilterSelection
+	tblRuntimeFilterSelections []*runtimeFilterSelection
+	// lookupRanges are the point ranges of the keys set by the hash join at the adaptive checkpoint, which replace the
+	// ranges of the plan if not nil.
+	lookupRanges []*ranger.Range
 }
 
 type getHandleType int8
@@ -535,6 +543,30 @@ func (e *IndexLookUpExecutor) setDummy() {
 	e.dummy = true
 }
 
+// SetRuntimeFilterConditions implements the join.RuntimeFilterTarget interface.
+func (e *IndexLookUpExecutor) SetRuntimeFilterConditions(selectionID int, conds []expression.Expression) {
+	setRuntimeFilterConditions(e.idxRuntimeFilterSelections, selectionID, conds)
+	setRuntimeFilterConditions(e.tblRuntimeFilterSelections, selectionID, conds)
+}
+
+// LookupKeyColumn implements the join.LookupTarget interface. Only the full scan of the index can look up the keys,
+// by the first column of the index.
+func (e *IndexLookUpExecutor) LookupKeyColumn() int {
+	if e.dummy || e.partitionTableMode || e.corColInAccess || e.PushedLimit != nil || e.checkIndexValue != nil ||
+		e.index.ID == -1 || e.index.Global || e.index.MVIndex ||
+		e.index.Columns[0].Length != types.UnspecifiedLength || e.index.Columns[0].Desc ||
+		len(e.ranges) != 1 || !e.ranges[0].IsFullRange(false) {
+		return -1
+	}
+	colInfo := e.table.Meta().Columns[e.index.Columns[0].Offset]
+	return lookupKeyColumnOffset(e.Schema(), colInfo.ID)
+}
+
+// SetLookupKeys implements the join.LookupTarget interface.
+func (e *IndexLookUpExecutor) SetLookupKeys(keys []types.Datum) {
+	e.lookupRanges = buildLookupRanges(keys)
+}
+
 // Open implements the Executor Open interface.
 func (e *IndexLookUpExecutor) Open(ctx context.Context) error {
 	var err error
@@ -582,7 +614,7 @@ func (e *IndexLookUpExecutor) buildTableKeyRanges() (err error) {
 			if e.index.ID == -1 {
 				kvRange, err = distsql.CommonHandleRangesToKVRanges(dctx, []int64{physicalID}, ranges)
 			} else {
-				kvRange, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, physicalID, e.index.ID, ranges, e.memTracker, nil)
+				kvRange, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, physicalID, e.index, ranges, e.memTracker, nil)
 			}
 			if err != nil {
 				return err
@@ -591,11 +623,15 @@ func (e *IndexLookUpExecutor) buildTableKeyRanges() (err error) {
 		}
 	} else {
 		physicalID := getPhysicalTableID(e.table)
+		ranges := e.ranges
+		if e.lookupRanges != nil {
+			ranges = e.lookupRanges
+		}
 		var kvRanges *kv.KeyRanges
 		if e.index.ID == -1 {
-			kvRanges, err = distsql.CommonHandleRangesToKVRanges(dctx, []int64{physicalID}, e.ranges)
+			kvRanges, err = distsql.CommonHandleRangesToKVRanges(dctx, []int64{physicalID}, ranges)
 		} else {
-			kvRanges, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, physicalID, e.index.ID, e.ranges, e.memTracker, nil)
+			kvRanges, err = distsql.IndexRangesToKVRangesWithInterruptSignal(dctx, physicalID, e.index, ranges, e.memTracker, nil)
 		}
 		e.kvRanges = kvRanges.FirstPartitionRange()
 	}
@@ -633,6 +669,13 @@ func (e *IndexLookUpExecutor) open(_ context.Context) error {
 		}
 	}
 
+	if err = fillRuntimeFilterConditions(e.buildPBCtx, e.dagPB, e.idxRuntimeFilterSelections); err != nil {
+		return err
+	}
+	if err = fillRuntimeFilterConditions(e.buildPBCtx, e.tableRequest, e.tblRuntimeFilterSelections); err != nil {
+		return err
+	}
+
 	e.idxWorkerWg = &sync.WaitGroup{}
 	e.tblWorkerWg = &sync.WaitGroup{}
 	return nil
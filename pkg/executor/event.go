@@ -0,0 +1,405 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+// eventTimerClient returns the client to manage the timers of the events.
+func eventTimerClient(sctx sessionctx.Context) (timerapi.TimerClient, error) {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil, errors.New("event scheduler is not initialized")
+	}
+	return scheduler.Client(), nil
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	sctx := e.Ctx()
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return err
+	}
+	schema, err := procedure.ResolveSchema(sctx, s.Event.Schema)
+	if err != nil {
+		return err
+	}
+	dbInfo, ok := e.is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	name := s.Event.Name.O
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	existed, err := eventscheduler.GetEventTimer(ctx, cli, dbInfo.Name.O, name)
+	if err != nil {
+		return err
+	}
+	if existed != nil {
+		err = exeerrors.ErrEventAlreadyExists.FastGenByArgs(name)
+		if s.IfNotExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	info := &eventscheduler.EventInfo{
+		Schema:            dbInfo.Name.O,
+		Name:              name,
+		Preserve:          s.Completion == ast.EventCompletionPreserve,
+		DatabaseCollation: dbInfo.Collate,
+		Created:           now,
+		LastAltered:       now,
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if err = setEventBody(ctx, sctx, info, s.Body); err != nil {
+		return err
+	}
+	if err = setEventSchedule(sctx, info, s.Schedule, now); err != nil {
+		return err
+	}
+	enable := s.Status == ast.EventStatusUnspecified || s.Status == ast.EventStatusEnable
+	if eventExpired(info, now) {
+		if !info.Preserve {
+			sctx.GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast.FastGenByArgs())
+			return nil
+		}
+		sctx.GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.FastGenByArgs())
+		enable = false
+	}
+
+	spec, err := info.TimerSpec(enable)
+	if err != nil {
+		return err
+	}
+	_, err = cli.CreateTimer(ctx, spec)
+	return err
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	sctx := e.Ctx()
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return err
+	}
+	schema, err := procedure.ResolveSchema(sctx, s.Event.Schema)
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	timer, err := eventscheduler.GetEventTimer(ctx, cli, schema.O, s.Event.Name.O)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		return exeerrors.ErrEventDoesNotExist.FastGenByArgs(s.Event.Name.O)
+	}
+	info, err := eventscheduler.EventFromTimer(timer)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	info.LastAltered = now
+	enable := timer.Enable
+	switch s.Status {
+	case ast.EventStatusEnable:
+		enable = true
+	case ast.EventStatusDisable, ast.EventStatusSlavesideDisable:
+		enable = false
+	}
+	if s.Completion != ast.EventCompletionUnspecified {
+		info.Preserve = s.Completion == ast.EventCompletionPreserve
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		if err = setEventBody(ctx, sctx, info, s.Body); err != nil {
+			return err
+		}
+	}
+	if s.Schedule != nil {
+		if err = setEventSchedule(sctx, info, s.Schedule, now); err != nil {
+			return err
+		}
+		if eventExpired(info, now) {
+			if !info.Preserve {
+				sctx.GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotAlterInThePast.FastGenByArgs())
+				return nil
+			}
+			sctx.GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast.FastGenByArgs())
+			enable = false
+		}
+	}
+
+	if s.NewName == nil {
+		opts, err := info.TimerUpdates(enable, s.Schedule != nil)
+		if err != nil {
+			return err
+		}
+		return cli.UpdateTimer(ctx, timer.ID, opts...)
+	}
+
+	// A renamed event is scheduled by a new timer with the key of the new name.
+	newSchema, err := procedure.ResolveSchema(sctx, s.NewName.Schema)
+	if err != nil {
+		return err
+	}
+	dbInfo, ok := e.is.SchemaByName(newSchema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(newSchema.O)
+	}
+	if eventscheduler.TimerKey(dbInfo.Name.O, s.NewName.Name.O) == timer.Key {
+		return exeerrors.ErrEventSameName.FastGenByArgs()
+	}
+	existed, err := eventscheduler.GetEventTimer(ctx, cli, dbInfo.Name.O, s.NewName.Name.O)
+	if err != nil {
+		return err
+	}
+	if existed != nil {
+		return exeerrors.ErrEventAlreadyExists.FastGenByArgs(s.NewName.Name.O)
+	}
+	info.Schema, info.Name, info.DatabaseCollation = dbInfo.Name.O, s.NewName.Name.O, dbInfo.Collate
+	spec, err := info.TimerSpec(enable)
+	if err != nil {
+		return err
+	}
+	if s.Schedule == nil {
+		spec.Watermark = timer.Watermark
+	}
+	if _, err = cli.CreateTimer(ctx, spec); err != nil {
+		return err
+	}
+	_, err = cli.DeleteTimer(ctx, timer.ID)
+	return err
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	sctx := e.Ctx()
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return err
+	}
+	schema, err := procedure.ResolveSchema(sctx, s.Event.Schema)
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	timer, err := eventscheduler.GetEventTimer(ctx, cli, schema.O, s.Event.Name.O)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		err = exeerrors.ErrEventDoesNotExist.FastGenByArgs(s.Event.Name.O)
+		if s.IfExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	_, err = cli.DeleteTimer(ctx, timer.ID)
+	return err
+}
+
+// setEventBody sets the body of the event, the event is executed by the
+// current user in the current session environment.
+func setEventBody(ctx context.Context, sctx sessionctx.Context, info *eventscheduler.EventInfo, body ast.StmtNode) error {
+	sessVars := sctx.GetSessionVars()
+	info.Definer = "%"
+	if user := sessVars.User; user != nil {
+		info.Definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	charsetClient, err := sessVars.GetSessionOrGlobalSystemVar(ctx, vardef.CharacterSetClient)
+	if err != nil {
+		return err
+	}
+	timeZone, err := sessVars.GetSessionOrGlobalSystemVar(ctx, vardef.TimeZone)
+	if err != nil {
+		return err
+	}
+	_, info.CollationConnection = sessVars.GetCharsetInfo()
+	info.SQLMode, _ = sessVars.GetSystemVar(vardef.SQLModeVar)
+	info.CharsetClient, info.TimeZone = charsetClient, timeZone
+	info.Body = body.Text()
+	return nil
+}
+
+// setEventSchedule evaluates the schedule of the event. The times are
+// evaluated in the time zone of the session.
+func setEventSchedule(sctx sessionctx.Context, info *eventscheduler.EventInfo, schedule *ast.EventSchedule, now time.Time) error {
+	info.ExecuteAt, info.Starts, info.Ends = nil, nil, nil
+	info.IntervalValue, info.IntervalField = 0, ""
+	if schedule.At != nil {
+		at, err := evalEventTime(sctx, schedule.At, "AT")
+		if err != nil {
+			return err
+		}
+		info.ExecuteAt = &at
+		return nil
+	}
+
+	unit, ok := eventscheduler.UnitDuration(schedule.Unit)
+	if !ok {
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("EVERY with the interval unit " + schedule.Unit.String())
+	}
+	d, err := plannerutil.EvalAstExprWithPlanCtx(sctx.GetPlanCtx(), schedule.Every)
+	if err != nil {
+		return err
+	}
+	value, err := d.ToInt64(sctx.GetSessionVars().StmtCtx.TypeCtx())
+	if err != nil || d.IsNull() || value <= 0 || value > eventscheduler.MaxIntervalSeconds/int64(unit/time.Second) {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig.FastGenByArgs()
+	}
+	info.IntervalValue, info.IntervalField = value, schedule.Unit.String()
+
+	starts := now.Truncate(time.Second)
+	if schedule.Starts != nil {
+		if starts, err = evalEventTime(sctx, schedule.Starts, "STARTS"); err != nil {
+			return err
+		}
+	}
+	info.Starts = &starts
+	if schedule.Ends != nil {
+		ends, err := evalEventTime(sctx, schedule.Ends, "ENDS")
+		if err != nil {
+			return err
+		}
+		if ends.Before(starts) {
+			return exeerrors.ErrEventEndsBeforeStarts.FastGenByArgs()
+		}
+		info.Ends = &ends
+	}
+	return nil
+}
+
+func evalEventTime(sctx sessionctx.Context, expr ast.ExprNode, clause string) (time.Time, error) {
+	d, err := plannerutil.EvalAstExprWithPlanCtx(sctx.GetPlanCtx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	sessVars := sctx.GetSessionVars()
+	str, _ := d.ToString()
+	t, err := d.ConvertTo(sessVars.StmtCtx.TypeCtx(), types.NewFieldType(mysql.TypeDatetime))
+	if err != nil || t.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, str)
+	}
+	return t.GetMysqlTime().GoTime(sessVars.Location())
+}
+
+// eventExpired checks whether the event will never be executed.
+func eventExpired(info *eventscheduler.EventInfo, now time.Time) bool {
+	if info.IsOneTime() {
+		return info.ExecuteAt.Before(now.Truncate(time.Second))
+	}
+	return info.Ends != nil && info.Ends.Before(now)
+}
+
+// listEvents returns the events visible to the current user. The events of
+// all schemas are returned if schema is empty.
+func listEvents(ctx context.Context, sctx sessionctx.Context, schema string) ([]*eventscheduler.EventInfo, []*timerapi.TimerRecord, error) {
+	cli, err := eventTimerClient(sctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	timers, err := eventscheduler.GetEventTimers(ctx, cli)
+	if err != nil {
+		return nil, nil, err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	activeRoles := sctx.GetSessionVars().ActiveRoles
+	infos := make([]*eventscheduler.EventInfo, 0, len(timers))
+	records := make([]*timerapi.TimerRecord, 0, len(timers))
+	for _, timer := range timers {
+		info, err := eventscheduler.EventFromTimer(timer)
+		if err != nil {
+			return nil, nil, err
+		}
+		if schema != "" && !strings.EqualFold(info.Schema, schema) {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, info.Schema, "", "", mysql.EventPriv) {
+			continue
+		}
+		infos = append(infos, info)
+		records = append(records, timer)
+	}
+	return infos, records, nil
+}
+
+// eventTypeString returns the type of the event displayed by `SHOW EVENTS`.
+func eventTypeString(info *eventscheduler.EventInfo) string {
+	if info.IsOneTime() {
+		return "ONE TIME"
+	}
+	return "RECURRING"
+}
+
+// eventStatusString returns the status of the event displayed by `SHOW EVENTS`.
+func eventStatusString(enable bool) string {
+	if enable {
+		return "ENABLED"
+	}
+	return "DISABLED"
+}
+
+// eventIntervalValue returns the interval value of a recurring event, it's nil
+// for a one-time event.
+func eventIntervalValue(info *eventscheduler.EventInfo) any {
+	if info.IsOneTime() {
+		return nil
+	}
+	return strconv.FormatInt(info.IntervalValue, 10)
+}
+
+func eventIntervalField(info *eventscheduler.EventInfo) any {
+	if info.IsOneTime() {
+		return nil
+	}
+	return info.IntervalField
+}
+
+// eventTime converts the time of the event to a datetime in the given time zone.
+func eventTime(t *time.Time, loc *time.Location) any {
+	if t == nil {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+}
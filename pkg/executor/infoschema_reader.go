@@ -36,8 +36,10 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/internal/pdhelper"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	infoschemacontext "github.com/pingcap/tidb/pkg/infoschema/context"
@@ -211,6 +213,12 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForVariablesInfo(sctx)
 		case infoschema.TableUserAttributes:
 			err = e.setDataForUserAttributes(ctx, sctx)
+		case infoschema.TableRoutines:
+			err = e.setDataForRoutines(ctx, sctx)
+		case infoschema.TableTriggers:
+			err = e.setDataForTriggers(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataForEvents(ctx, sctx)
 		case infoschema.TableMemoryUsage:
 			err = e.setDataForMemoryUsage()
 		case infoschema.ClusterTableMemoryUsage:
@@ -368,6 +376,154 @@ func (e *memtableRetriever) setDataForVariablesInfo(ctx sessionctx.Context) erro
 	return nil
 }
 
+func (e *memtableRetriever) setDataForTriggers(ctx context.Context, sctx sessionctx.Context) error {
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range e.is.AllSchemaNames() {
+		tables, err := e.is.SchemaTableInfos(ctx, schema)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, table := range tables {
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the position of the trigger among the triggers with the same timing and event.
+			orders := make(map[[2]int]int)
+			for _, t := range table.Triggers {
+				key := [2]int{int(t.Timing), int(t.Event)}
+				orders[key]++
+				created := types.NewTime(types.FromGoTime(t.CreateTS.In(loc)), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal, // TRIGGER_CATALOG
+					schema.O,              // TRIGGER_SCHEMA
+					t.Name.O,              // TRIGGER_NAME
+					t.Event.String(),      // EVENT_MANIPULATION
+					infoschema.CatalogVal, // EVENT_OBJECT_CATALOG
+					schema.O,              // EVENT_OBJECT_SCHEMA
+					table.Name.O,          // EVENT_OBJECT_TABLE
+					orders[key],           // ACTION_ORDER
+					nil,                   // ACTION_CONDITION
+					t.Statement,           // ACTION_STATEMENT
+					"ROW",                 // ACTION_ORIENTATION
+					t.Timing.String(),     // ACTION_TIMING
+					nil,                   // ACTION_REFERENCE_OLD_TABLE
+					nil,                   // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                 // ACTION_REFERENCE_OLD_ROW
+					"NEW",                 // ACTION_REFERENCE_NEW_ROW
+					created,               // CREATED
+					t.SQLMode,             // SQL_MODE
+					t.Definer,             // DEFINER
+					t.CharsetClient,       // CHARACTER_SET_CLIENT
+					t.CollationConnection, // COLLATION_CONNECTION
+					t.DatabaseCollation,   // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+				e.recordMemoryConsume(record)
+			}
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	routines, err := procedure.ListRoutines(ctx, sctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(routines))
+	for _, r := range routines {
+		row := types.MakeDatums(
+			r.Name,                // SPECIFIC_NAME
+			infoschema.CatalogVal, // ROUTINE_CATALOG
+			r.Schema,              // ROUTINE_SCHEMA
+			r.Name,                // ROUTINE_NAME
+			r.Type,                // ROUTINE_TYPE
+			"",                    // DATA_TYPE
+			nil,                   // CHARACTER_MAXIMUM_LENGTH
+			nil,                   // CHARACTER_OCTET_LENGTH
+			nil,                   // NUMERIC_PRECISION
+			nil,                   // NUMERIC_SCALE
+			nil,                   // DATETIME_PRECISION
+			nil,                   // CHARACTER_SET_NAME
+			nil,                   // COLLATION_NAME
+			nil,                   // DTD_IDENTIFIER
+			"SQL",                 // ROUTINE_BODY
+			r.Definition,          // ROUTINE_DEFINITION
+			nil,                   // EXTERNAL_NAME
+			"SQL",                 // EXTERNAL_LANGUAGE
+			"SQL",                 // PARAMETER_STYLE
+			"NO",                  // IS_DETERMINISTIC
+			"CONTAINS SQL",        // SQL_DATA_ACCESS
+			nil,                   // SQL_PATH
+			r.SecurityType,        // SECURITY_TYPE
+			r.Created,             // CREATED
+			r.LastAltered,         // LAST_ALTERED
+			r.SQLMode,             // SQL_MODE
+			r.Comment,             // ROUTINE_COMMENT
+			r.Definer,             // DEFINER
+			r.CharsetClient,       // CHARACTER_SET_CLIENT
+			r.CollationConnection, // COLLATION_CONNECTION
+			r.DatabaseCollation,   // DATABASE_COLLATION
+		)
+		rows = append(rows, row)
+		e.recordMemoryConsume(row)
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForEvents(ctx context.Context, sctx sessionctx.Context) error {
+	infos, timers, err := listEvents(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+	sessLoc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(infos))
+	for i, info := range infos {
+		loc := info.Location()
+		onCompletion := "NOT PRESERVE"
+		if info.Preserve {
+			onCompletion = "PRESERVE"
+		}
+		row := types.MakeDatums(
+			infoschema.CatalogVal,                 // EVENT_CATALOG
+			info.Schema,                           // EVENT_SCHEMA
+			info.Name,                             // EVENT_NAME
+			info.Definer,                          // DEFINER
+			info.TimeZone,                         // TIME_ZONE
+			"SQL",                                 // EVENT_BODY
+			info.Body,                             // EVENT_DEFINITION
+			eventTypeString(info),                 // EVENT_TYPE
+			eventTime(info.ExecuteAt, loc),        // EXECUTE_AT
+			eventIntervalValue(info),              // INTERVAL_VALUE
+			eventIntervalField(info),              // INTERVAL_FIELD
+			info.SQLMode,                          // SQL_MODE
+			eventTime(info.Starts, loc),           // STARTS
+			eventTime(info.Ends, loc),             // ENDS
+			eventStatusString(timers[i].Enable),   // STATUS
+			onCompletion,                          // ON_COMPLETION
+			eventTime(&info.Created, sessLoc),     // CREATED
+			eventTime(&info.LastAltered, sessLoc), // LAST_ALTERED
+			eventTime(eventscheduler.LastExecuted(timers[i]), sessLoc), // LAST_EXECUTED
+			info.Comment,             // EVENT_COMMENT
+			0,                        // ORIGINATOR
+			info.CharsetClient,       // CHARACTER_SET_CLIENT
+			info.CollationConnection, // COLLATION_CONNECTION
+			info.DatabaseCollation,   // DATABASE_COLLATION
+		)
+		rows = append(rows, row)
+		e.recordMemoryConsume(row)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataForUserAttributes(ctx context.Context, sctx sessionctx.Context) error {
 	exec := sctx.GetRestrictedSQLExecutor()
 	wrappedCtx := kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
@@ -515,6 +671,12 @@ func (e *memtableRetriever) setDataForStatisticsInTable(
 		if index.Unique {
 			nonUnique = "0"
 		}
+		indexType := "BTREE"
+		if index.Spatial {
+			indexType = "SPATIAL"
+		} else if index.FullTextInfo != nil {
+			indexType = "FULLTEXT"
+		}
 		for i, key := range index.Columns {
 			col := nameToCol[key.Name.L]
 			nullable := "YES"
@@ -541,6 +703,11 @@ func (e *memtableRetriever) setDataForStatisticsInTable(
 				subPart = key.Length
 			}
 
+			collation := "A"
+			if key.Desc {
+				collation = "D"
+			}
+
 			record := types.MakeDatums(
 				infoschema.CatalogVal, // TABLE_CATALOG
 				schema.O,              // TABLE_SCHEMA
@@ -550,12 +717,12 @@ func (e *memtableRetriever) setDataForStatisticsInTable(
 				index.Name.O,          // INDEX_NAME
 				i+1,                   // SEQ_IN_INDEX
 				colName,               // COLUMN_NAME
-				"A",                   // COLLATION
+				collation,             // COLLATION
 				0,                     // CARDINALITY
 				subPart,               // SUB_PART
 				nil,                   // PACKED
 				nullable,              // NULLABLE
-				"BTREE",               // INDEX_TYPE
+				indexType,             // INDEX_TYPE
 				"",                    // COMMENT
 				index.Comment,         // INDEX_COMMENT
 				visible,               // IS_VISIBLE
@@ -1228,6 +1395,10 @@ func (e *hugeMemTableRetriever) dataForColumnsInTable(
 		if colType == mysql.TypeVarString {
 			colType = mysql.TypeVarchar
 		}
+		dataType := types.TypeToStr(colType, ft.GetCharset())
+		if colType == mysql.TypeGeometry {
+			dataType = ft.GetGeometryType().String()
+		}
 		record := types.MakeDatums(
 			infoschema.CatalogVal, // TABLE_CATALOG
 			schema.O,              // TABLE_SCHEMA
@@ -1236,17 +1407,17 @@ func (e *hugeMemTableRetriever) dataForColumnsInTable(
 			ordinalPos[i],         // ORDINAL_POSITION
 			columnDefault,         // COLUMN_DEFAULT
 			columnDesc.Null,       // IS_NULLABLE
-			types.TypeToStr(colType, ft.GetCharset()), // DATA_TYPE
-			charMaxLen,           // CHARACTER_MAXIMUM_LENGTH
-			charOctLen,           // CHARACTER_OCTET_LENGTH
-			numericPrecision,     // NUMERIC_PRECISION
-			numericScale,         // NUMERIC_SCALE
-			datetimePrecision,    // DATETIME_PRECISION
-			columnDesc.Charset,   // CHARACTER_SET_NAME
-			columnDesc.Collation, // COLLATION_NAME
-			columnType,           // COLUMN_TYPE
-			columnDesc.Key,       // COLUMN_KEY
-			columnDesc.Extra,     // EXTRA
+			dataType,              // DATA_TYPE
+			charMaxLen,            // CHARACTER_MAXIMUM_LENGTH
+			charOctLen,            // CHARACTER_OCTET_LENGTH
+			numericPrecision,      // NUMERIC_PRECISION
+			numericScale,          // NUMERIC_SCALE
+			datetimePrecision,     // DATETIME_PRECISION
+			columnDesc.Charset,    // CHARACTER_SET_NAME
+			columnDesc.Collation,  // COLLATION_NAME
+			columnType,            // COLUMN_TYPE
+			columnDesc.Key,        // COLUMN_KEY
+			columnDesc.Extra,      // EXTRA
 			strings.ToLower(privileges.PrivToString(priv, mysql.AllColumnPrivs, mysql.Priv2Str)), // PRIVILEGES
 			columnDesc.Comment,      // COLUMN_COMMENT
 			col.GeneratedExprString, // GENERATION_EXPRESSION
@@ -51,6 +51,8 @@ type InsertExec struct {
 	evalBuffer4Dup chunk.MutRow
 	curInsertVals  chunk.MutRow
 	row4Update     []types.Datum
+	// updateTriggers fire the UPDATE triggers when the duplicate rows are updated.
+	updateTriggers *TriggerExec
 
 	Priority mysql.PriorityEnum
 }
@@ -525,7 +527,7 @@ func (e *InsertExec) doDupRowUpdate(
 		handle, oldRow, newData,
 		0, generated, e.evalBuffer4Dup, errorHandler,
 		assignFlag, e.Table,
-		true, e.memTracker, e.fkChecks, e.fkCascades, dupKeyMode, e.ignoreErr)
+		true, e.memTracker, e.fkChecks, e.fkCascades, e.updateTriggers, dupKeyMode, e.ignoreErr)
 
 	if ignored {
 		return nil
@@ -95,6 +95,10 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers fire the INSERT triggers, deleteTriggers fire the DELETE triggers
+	// when the conflicting rows are removed by REPLACE.
+	triggers       *TriggerExec
+	deleteTriggers *TriggerExec
 
 	ignoreErr bool
 }
@@ -675,6 +679,8 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 		rowCntInLoadData = e.rowCount
 	}
 
+	// The BEFORE INSERT triggers may change the values, so the null values are checked after them.
+	hasBeforeTriggers := e.triggers.has(ast.TriggerBefore)
 	for i, c := range tCols {
 		var err error
 		// Evaluate the generated columns later after real columns set
@@ -685,13 +691,26 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			if row[i], err = e.fillColValue(ctx, row[i], i, c, hasValue[i]); err != nil {
 				return nil, err
 			}
-			if !e.lazyFillAutoID || (e.lazyFillAutoID && !mysql.HasAutoIncrementFlag(c.GetFlag())) {
+			if !hasBeforeTriggers && (!e.lazyFillAutoID || (e.lazyFillAutoID && !mysql.HasAutoIncrementFlag(c.GetFlag()))) {
 				if err = c.HandleBadNull(e.Ctx().GetSessionVars().StmtCtx.ErrCtx(), &row[i], rowCntInLoadData); err != nil {
 					return nil, err
 				}
 			}
 		}
 	}
+	if hasBeforeTriggers {
+		if err := e.triggers.fire(ctx, ast.TriggerBefore, nil, row); err != nil {
+			return nil, err
+		}
+		for i, c := range tCols {
+			if c.IsGenerated() || (e.lazyFillAutoID && mysql.HasAutoIncrementFlag(c.GetFlag())) {
+				continue
+			}
+			if err := c.HandleBadNull(e.Ctx().GetSessionVars().StmtCtx.ErrCtx(), &row[i], rowCntInLoadData); err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	// Handle exchange partition
 	tbl := e.Table.Meta()
@@ -1364,6 +1383,9 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	if err = e.deleteTriggers.fire(ctx, ast.TriggerBefore, oldRow, nil); err != nil {
+		return false, err
+	}
 	if ph, ok := handle.(kv.PartitionHandle); ok {
 		err = e.Table.(table.PartitionedTable).GetPartition(ph.PartitionID).RemoveRecord(e.Ctx().GetTableCtx(), txn, ph.Handle, oldRow)
 	} else {
@@ -1382,7 +1404,7 @@ func (e *InsertValues) removeRow(
 		e.Ctx().GetSessionVars().StmtCtx.AddDeletedRows(1)
 	}
 
-	return false, nil
+	return false, e.deleteTriggers.fire(ctx, ast.TriggerAfter, oldRow, nil)
 }
 
 // equalDatumsAsBinary compare if a and b contains the same datum values in binary collation.
@@ -1441,7 +1463,7 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 		vars.TxnCtx.InsertTTLRowsCount++
 	}
 
-	return nil
+	return e.triggers.fire(ctx, ast.TriggerAfter, nil, row)
 }
 
 // CreateSession will be assigned by session package.
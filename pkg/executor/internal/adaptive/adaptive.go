@@ -0,0 +1,145 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package adaptive implements the checkpoints of the adaptive query execution. At a checkpoint, the executor compares
+// the row count it has observed with the estimation of the optimizer, and adjusts the rest of its plan if the
+// estimation is far from the real one.
+package adaptive
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/execdetails"
+)
+
+// Decision is the adjustment of the plan made at a checkpoint.
+type Decision string
+
+const (
+	// DecisionNone means the plan is kept.
+	DecisionNone Decision = "none"
+	// DecisionIndexLookup means the probe side reader of the hash join looks up the build keys by point ranges like
+	// an index join, instead of reading the ranges of the plan.
+	DecisionIndexLookup Decision = "index_lookup"
+	// DecisionEnableApplyCache means the apply starts to cache the inner results of the correlated values.
+	DecisionEnableApplyCache Decision = "enable_apply_cache"
+	// DecisionSwapBuildSide means the hash join builds the hash table from the other child, because the child which
+	// is the build side of the plan has more rows than the other one.
+	DecisionSwapBuildSide Decision = "swap_build_side"
+	// DecisionReduceConcurrency means the hash join probes with fewer workers, because the concurrency of the plan
+	// is for the much larger build side that was estimated.
+	DecisionReduceConcurrency Decision = "reduce_concurrency"
+)
+
+const (
+	// CheckpointBuild is the checkpoint after the build side of the hash join is finished.
+	CheckpointBuild = "build"
+	// CheckpointOuter is the checkpoint on the outer side of the apply, which executes the subquery for every outer row.
+	CheckpointOuter = "outer"
+	// CheckpointCTE is the checkpoint after the CTE is materialized.
+	CheckpointCTE = "cte"
+)
+
+// Checkpoint is a point in the executor tree where the observed row count is compared with the estimated one.
+type Checkpoint struct {
+	// Name describes where the checkpoint is, such as CheckpointBuild.
+	Name    string
+	EstRows float64
+	// Ratio is the threshold of the misestimation, see vardef.TiDBAdaptiveExecutionMisestimateRatio.
+	Ratio float64
+}
+
+// NewCheckpoint creates a checkpoint if the adaptive execution is enabled, otherwise it returns nil.
+func NewCheckpoint(vars *variable.SessionVars, name string, estRows float64) *Checkpoint {
+	if !vars.EnableAdaptiveExecution {
+		return nil
+	}
+	return &Checkpoint{Name: name, EstRows: estRows, Ratio: vars.AdaptiveExecutionMisestimateRatio}
+}
+
+// Misestimated returns whether the observed rows are far from the estimated rows in either direction.
+func (c *Checkpoint) Misestimated(actRows int64) bool {
+	return c.Underestimated(actRows) || c.Overestimated(actRows)
+}
+
+// Underestimated returns whether the observed rows are more than Ratio times of the estimated rows.
+func (c *Checkpoint) Underestimated(actRows int64) bool {
+	return float64(actRows) > math.Max(c.EstRows, 1)*c.Ratio
+}
+
+// Overestimated returns whether the estimated rows are more than Ratio times of the observed rows.
+func (c *Checkpoint) Overestimated(actRows int64) bool {
+	return c.EstRows > math.Max(float64(actRows), 1)*c.Ratio
+}
+
+// NewRuntimeStats returns the runtime stats of the checkpoint with the observed rows and the decision made.
+func (c *Checkpoint) NewRuntimeStats(actRows int64, decision Decision) *RuntimeStats {
+	return &RuntimeStats{
+		checkpoint: c.Name,
+		estRows:    c.EstRows,
+		actRows:    actRows,
+		decision:   decision,
+	}
+}
+
+// RuntimeStats is the runtime stats of a checkpoint, which is shown in the execution info of EXPLAIN ANALYZE.
+type RuntimeStats struct {
+	checkpoint string
+	estRows    float64
+	actRows    int64
+	decision   Decision
+}
+
+// String implements the RuntimeStats interface.
+func (e *RuntimeStats) String() string {
+	var builder strings.Builder
+	builder.WriteString("adaptive:{checkpoint:")
+	builder.WriteString(e.checkpoint)
+	builder.WriteString(", est_rows:")
+	builder.WriteString(strconv.FormatFloat(e.estRows, 'f', 2, 64))
+	builder.WriteString(", act_rows:")
+	builder.WriteString(strconv.FormatInt(e.actRows, 10))
+	builder.WriteString(", decision:")
+	builder.WriteString(string(e.decision))
+	builder.WriteString("}")
+	return builder.String()
+}
+
+// Clone implements the RuntimeStats interface.
+func (e *RuntimeStats) Clone() execdetails.RuntimeStats {
+	newRs := *e
+	return &newRs
+}
+
+// Merge implements the RuntimeStats interface. The executor may be executed many times, e.g. in the inner side of
+// an apply, the rows are accumulated and the last decision which changes the plan is kept.
+func (e *RuntimeStats) Merge(other execdetails.RuntimeStats) {
+	tmp, ok := other.(*RuntimeStats)
+	if !ok {
+		return
+	}
+	e.estRows += tmp.estRows
+	e.actRows += tmp.actRows
+	if tmp.decision != DecisionNone {
+		e.decision = tmp.decision
+	}
+}
+
+// Tp implements the RuntimeStats interface.
+func (*RuntimeStats) Tp() int {
+	return execdetails.TpAdaptiveRuntimeStats
+}
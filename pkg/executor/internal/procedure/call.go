@@ -0,0 +1,195 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+// Executor is used to execute the `CALL` statement.
+type Executor struct {
+	Stmt *ast.CallStmt
+	exec.BaseExecutor
+	done bool
+}
+
+// Next implements the interface of Executor.
+func (e *Executor) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	sctx := e.Ctx()
+	outerSC := sctx.GetSessionVars().StmtCtx
+	return call(ctx, sctx, outerSC, e.Stmt, nil, nil)
+}
+
+// call executes the procedure. caller and callerScope are set if the procedure
+// is called by another procedure.
+func call(ctx context.Context, sctx sessionctx.Context, outerSC *stmtctx.StatementContext, s *ast.CallStmt,
+	caller *interpreter, callerScope *scope) (err error) {
+	schema, err := ResolveSchema(sctx, s.Procedure.Schema)
+	if err != nil {
+		return err
+	}
+	name := s.Procedure.FnName.O
+	r, err := GetProcedure(ctx, sctx, schema.O, name)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(RoutineTypeProcedure, schema.O+"."+name)
+	}
+	for c := caller; c != nil; c = c.caller {
+		if c.routine.Type == r.Type && strings.EqualFold(c.routine.Schema, r.Schema) && strings.EqualFold(c.routine.Name, r.Name) {
+			return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(0, r.Name)
+		}
+	}
+
+	sessVars := sctx.GetSessionVars()
+	sqlMode, err := mysql.GetSQLMode(r.SQLMode)
+	if err != nil {
+		return err
+	}
+	p := parser.New()
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	p.SetSQLMode(sqlMode)
+	node, err := p.ParseOneStmt(r.CreateSQL(), r.CharsetClient, r.CollationConnection)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info := node.(*ast.ProcedureInfo)
+	if len(info.ProcedureParam) != len(s.Procedure.Args) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs(RoutineTypeProcedure, r.Schema+"."+r.Name,
+			len(info.ProcedureParam), len(s.Procedure.Args))
+	}
+
+	it := &interpreter{
+		sctx:        sctx,
+		routine:     r,
+		caller:      caller,
+		outerSC:     outerSC,
+		parser:      p,
+		texts:       make(map[ast.Node]string),
+		dbCharset:   mysql.DefaultCharset,
+		dbCollation: r.DatabaseCollation,
+	}
+	if caller != nil {
+		it.runner = caller.runner
+	}
+	if coll, err := charset.GetCollationByName(r.DatabaseCollation); err == nil {
+		it.dbCharset = coll.CharsetName
+	}
+	root := newScope(nil)
+	params := make([]*variable, 0, len(info.ProcedureParam))
+	for i, param := range info.ProcedureParam {
+		arg := s.Procedure.Args[i]
+		if param.Paramstatus != ast.MODE_IN && !isVarArg(arg, callerScope) {
+			return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, r.Schema+"."+r.Name)
+		}
+		v := &variable{tp: it.fieldType(param.ParamType)}
+		if param.Paramstatus != ast.MODE_OUT {
+			var d types.Datum
+			if caller != nil {
+				d, err = caller.evalExpr(ctx, callerScope, arg)
+			} else {
+				d, err = evalArg(sctx, arg)
+			}
+			if err != nil {
+				return err
+			}
+			if err = it.assign(v, d); err != nil {
+				return err
+			}
+		}
+		root.vars[strings.ToLower(param.ParamName)] = v
+		params = append(params, v)
+	}
+
+	// The routine runs in the schema it belongs to with the sql_mode when it was created.
+	originDB := sessVars.CurrentDB
+	sessVars.CurrentDB = r.Schema
+	originMode, err := sessVars.SetSystemVarWithOldValAsRet(vardef.SQLModeVar, r.SQLMode)
+	if err != nil {
+		sessVars.CurrentDB = originDB
+		return err
+	}
+	defer func() {
+		sessVars.StmtCtx = outerSC
+		sessVars.CurrentDB = originDB
+		if setErr := sessVars.SetSystemVar(vardef.SQLModeVar, originMode); err == nil {
+			err = setErr
+		}
+	}()
+
+	if err = it.execStmt(ctx, root, info.ProcedureBody); err != nil {
+		return err
+	}
+	for i, param := range info.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		switch x := s.Procedure.Args[i].(type) {
+		case *ast.VariableExpr:
+			setUserVar(sctx, x.Name, params[i].value, params[i].tp)
+		case *ast.ColumnNameExpr:
+			if err = caller.assign(callerScope.lookupVar(x.Name.Name.L), params[i].value); err != nil {
+				return err
+			}
+		}
+	}
+	if caller != nil {
+		caller.affectedRows = it.affectedRows
+	} else {
+		outerSC.AddAffectedRows(it.affectedRows)
+	}
+	return nil
+}
+
+// isVarArg checks whether the argument of an OUT or INOUT parameter is a
+// user variable or a local variable of the caller.
+func isVarArg(arg ast.ExprNode, callerScope *scope) bool {
+	switch x := arg.(type) {
+	case *ast.VariableExpr:
+		return !x.IsSystem
+	case *ast.ColumnNameExpr:
+		return x.Name.Table.L == "" && callerScope.lookupVar(x.Name.Name.L) != nil
+	}
+	return false
+}
+
+func evalArg(sctx sessionctx.Context, arg ast.ExprNode) (types.Datum, error) {
+	expr, err := plannerutil.RewriteAstExprWithPlanCtx(sctx.GetPlanCtx(), arg, nil, nil, false)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return expr.Eval(sctx.GetExprCtx().GetEvalCtx(), chunk.Row{})
+}
@@ -0,0 +1,256 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+type label struct {
+	name   string
+	isLoop bool
+}
+
+// checker validates the structure of a procedure body when it is created,
+// so the errors which MySQL reports at creation time are not delayed to CALL.
+type checker struct {
+	labels []label
+	// cursors is the stack of cursor names declared in the enclosing blocks.
+	cursors []map[string]struct{}
+	// visit is called for the SQL statements and the expressions in the body if it's set.
+	visit func(node ast.Node, isStmt bool) error
+}
+
+func checkProcedure(s *ast.ProcedureInfo) error {
+	params := make(map[string]struct{}, len(s.ProcedureParam))
+	for _, param := range s.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params[name] = struct{}{}
+	}
+	c := &checker{}
+	return c.checkStmt(s.ProcedureBody)
+}
+
+func (c *checker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *checker) checkNode(node ast.Node, isStmt bool) error {
+	if c.visit == nil || node == nil {
+		return nil
+	}
+	return c.visit(node, isStmt)
+}
+
+func (c *checker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		return c.checkLabeled(x.LabelName, x.LabelError, x.LabelEnd, false, x.Block)
+	case *ast.ProcedureLabelLoop:
+		return c.checkLabeled(x.LabelName, x.LabelError, x.LabelEnd, true, x.Block)
+	case *ast.ProcedureIfInfo:
+		return c.checkIf(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		if err := c.checkNode(x.Condition, false); err != nil {
+			return err
+		}
+		for _, when := range x.WhenCases {
+			if err := c.checkNode(when.Expr, false); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkNode(when.Expr, false); err != nil {
+				return err
+			}
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		if err := c.checkNode(x.Condition, false); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		if err := c.checkNode(x.Condition, false); err != nil {
+			return err
+		}
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureJump:
+		return c.checkJump(x)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		return c.checkCursor(x.CurName)
+	}
+	return c.checkNode(stmt, true)
+}
+
+func (c *checker) checkIf(block *ast.ProcedureIfBlock) error {
+	if err := c.checkNode(block.IfExpr, false); err != nil {
+		return err
+	}
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIf(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *checker) checkLabeled(name string, mismatch bool, end string, isLoop bool, body ast.StmtNode) error {
+	if mismatch {
+		return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+	}
+	for _, l := range c.labels {
+		if strings.EqualFold(l.name, name) {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, label{name: name, isLoop: isLoop})
+	err := c.checkStmt(body)
+	c.labels = c.labels[:len(c.labels)-1]
+	return err
+}
+
+func (c *checker) checkJump(jump *ast.ProcedureJump) error {
+	kind := "ITERATE"
+	if jump.IsLeave {
+		kind = "LEAVE"
+	}
+	for i := len(c.labels) - 1; i >= 0; i-- {
+		if !strings.EqualFold(c.labels[i].name, jump.Name) {
+			continue
+		}
+		// ITERATE can only appear within loops.
+		if !jump.IsLeave && !c.labels[i].isLoop {
+			break
+		}
+		return nil
+	}
+	return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs(kind, jump.Name)
+}
+
+func (c *checker) checkCursor(name string) error {
+	name = strings.ToLower(name)
+	for i := len(c.cursors) - 1; i >= 0; i-- {
+		if _, ok := c.cursors[i][name]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+// declaration kinds in the order MySQL requires them to appear in a block.
+const (
+	declVar = iota
+	declCursor
+	declHandler
+)
+
+func (c *checker) checkBlock(block *ast.ProcedureBlock) error {
+	vars := make(map[string]struct{})
+	cursors := make(map[string]struct{})
+	handlers := make(map[string]struct{})
+	c.cursors = append(c.cursors, cursors)
+	defer func() {
+		c.cursors = c.cursors[:len(c.cursors)-1]
+	}()
+
+	last := declVar
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			if last != declVar {
+				return exeerrors.ErrSpVarcondAfterCurshndlr.GenWithStackByArgs()
+			}
+			for _, name := range x.DeclNames {
+				name = strings.ToLower(name)
+				if _, ok := vars[name]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				vars[name] = struct{}{}
+			}
+			if err := c.checkNode(x.DeclDefault, false); err != nil {
+				return err
+			}
+		case *ast.ProcedureCursor:
+			if last == declHandler {
+				return exeerrors.ErrSpCursorAfterHandler.GenWithStackByArgs()
+			}
+			last = declCursor
+			name := strings.ToLower(x.CurName)
+			if _, ok := cursors[name]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(name)
+			}
+			cursors[name] = struct{}{}
+			if err := c.checkNode(x.Selectstring, false); err != nil {
+				return err
+			}
+		case *ast.ProcedureErrorControl:
+			last = declHandler
+			for _, cond := range x.ErrorCon {
+				key := conditionKey(cond)
+				if _, ok := handlers[key]; ok {
+					return exeerrors.ErrSpDupHandler.GenWithStackByArgs()
+				}
+				handlers[key] = struct{}{}
+			}
+			if err := c.checkStmt(x.Operate); err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func conditionKey(cond ast.ErrNode) string {
+	switch x := cond.(type) {
+	case *ast.ProcedureErrorVal:
+		return fmt.Sprintf("errno:%d", x.ErrorNum)
+	case *ast.ProcedureErrorState:
+		return "state:" + x.CodeStatus
+	case *ast.ProcedureErrorCon:
+		return fmt.Sprintf("class:%d", x.ErrorCon)
+	}
+	return fmt.Sprintf("%T", cond)
+}
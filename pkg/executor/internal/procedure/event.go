@@ -0,0 +1,89 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// RoutineTypeEvent is the routine type of the event bodies run by the interpreter.
+const RoutineTypeEvent = "EVENT"
+
+// EventExecutor executes the body of an event. The event scheduler prepares
+// the session with the environment of the event, and executes the body as a
+// compound statement.
+type EventExecutor struct {
+	Block *ast.ProcedureBlock
+	exec.BaseExecutor
+	done bool
+}
+
+// Next implements the interface of Executor.
+func (e *EventExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	sctx := e.Ctx()
+	sessVars := sctx.GetSessionVars()
+	outerSC := sessVars.StmtCtx
+	sqlMode, _ := sessVars.GetSystemVar(vardef.SQLModeVar)
+	charsetClient, _ := sessVars.GetSystemVar(vardef.CharacterSetClient)
+	_, collationConnection := sessVars.GetCharsetInfo()
+	r := &Routine{
+		Schema:              sessVars.CurrentDB,
+		Type:                RoutineTypeEvent,
+		SQLMode:             sqlMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+		DatabaseCollation:   mysql.DefaultCollationName,
+	}
+	if dbInfo, ok := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema().SchemaByName(ast.NewCIStr(r.Schema)); ok {
+		r.DatabaseCollation = dbInfo.Collate
+	}
+	p := parser.New()
+	p.SetParserConfig(sessVars.BuildParserConfig())
+	p.SetSQLMode(sessVars.SQLMode)
+	it := &interpreter{
+		sctx:        sctx,
+		routine:     r,
+		outerSC:     outerSC,
+		parser:      p,
+		texts:       make(map[ast.Node]string),
+		dbCharset:   mysql.DefaultCharset,
+		dbCollation: r.DatabaseCollation,
+	}
+	if coll, err := charset.GetCollationByName(r.DatabaseCollation); err == nil {
+		it.dbCharset = coll.CharsetName
+	}
+	defer func() {
+		sessVars.StmtCtx = outerSC
+	}()
+	if err := it.execStmt(ctx, newScope(nil), e.Block); err != nil {
+		return err
+	}
+	outerSC.AddAffectedRows(it.affectedRows)
+	return nil
+}
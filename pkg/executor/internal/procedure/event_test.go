@@ -0,0 +1,122 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure_test
+
+import (
+	"testing"
+	"time"
+
+	mysql "github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser/auth"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndDropEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2035-01-01 00:00:00' do insert into t values (1)")
+	tk.MustGetErrCode("create event e1 on schedule every 1 day do insert into t values (2)", mysql.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 day do insert into t values (2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustGetErrCode("create event e2 on schedule every 0 second do select 1", mysql.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e2 on schedule every 1 hour starts '2035-01-02' ends '2035-01-01' do select 1", mysql.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e2 on schedule every 1 month do select 1", mysql.ErrNotSupportedYet)
+	tk.MustGetErrCode("create event not_exist.e2 on schedule every 1 hour do select 1", mysql.ErrBadDB)
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e2 on schedule at '2035-01-01 00:00:00' on completion preserve disable comment 'once' do begin insert into t values (3); end")
+
+	tk.MustQuery("show events").CheckAt([]int{0, 1, 4, 5, 6, 7, 8, 10}, testkit.RowsWithSep("|",
+		"test|e1|RECURRING|<nil>|1|HOUR|2035-01-01 00:00:00|ENABLED",
+		"test|e2|ONE TIME|2035-01-01 00:00:00|<nil>|<nil>|<nil>|DISABLED"))
+	tk.MustQuery("show events like 'e2'").CheckAt([]int{1}, testkit.Rows("e2"))
+	tk.MustQuery("show create event e1").CheckAt([]int{0, 3}, testkit.RowsWithSep("|",
+		"e1|CREATE EVENT `e1` ON SCHEDULE EVERY 1 HOUR STARTS '2035-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE DO insert into t values (1)"))
+	tk.MustQuery("show create event e2").CheckAt([]int{3}, testkit.RowsWithSep("|",
+		"CREATE EVENT `e2` ON SCHEDULE AT '2035-01-01 00:00:00' ON COMPLETION PRESERVE DISABLE COMMENT 'once' DO begin insert into t values (3); end"))
+	err := tk.QueryToErr("show create event e_none")
+	require.True(t, exeerrors.ErrEventDoesNotExist.Equal(err))
+	tk.MustQuery("select event_name, event_type, interval_value, interval_field, status, on_completion, event_comment from information_schema.events order by event_name").
+		Check(testkit.Rows("e1 RECURRING 1 HOUR ENABLED NOT PRESERVE ", "e2 ONE TIME <nil> <nil> DISABLED PRESERVE once"))
+
+	tk.MustExec("alter event e1 on schedule every 2 minute starts '2035-01-01 00:00:00' disable")
+	tk.MustQuery("show events where name = 'e1'").CheckAt([]int{6, 7, 10}, testkit.Rows("2 MINUTE DISABLED"))
+	tk.MustExec("alter event e1 enable comment 'c'")
+	tk.MustQuery("show events where name = 'e1'").CheckAt([]int{6, 7, 10}, testkit.Rows("2 MINUTE ENABLED"))
+	tk.MustGetErrCode("alter event e1 rename to e2", mysql.ErrEventAlreadyExists)
+	tk.MustGetErrCode("alter event e1 rename to e1", mysql.ErrEventSameName)
+	tk.MustGetErrCode("alter event e_none enable", mysql.ErrEventDoesNotExist)
+	tk.MustExec("alter event e1 rename to e3")
+	tk.MustQuery("select event_name, event_comment from information_schema.events order by event_name").
+		Check(testkit.Rows("e2 once", "e3 c"))
+
+	tk.MustExec("drop event e2")
+	tk.MustGetErrCode("drop event e2", mysql.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+
+	tk.MustExec("create database db1")
+	tk.MustExec("create event db1.e1 on schedule every 1 hour do select 1")
+	tk.MustQuery("show events from db1").CheckAt([]int{0, 1}, testkit.Rows("db1 e1"))
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select event_schema, event_name from information_schema.events").Check(testkit.Rows("test e3"))
+}
+
+func TestEventPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database db1")
+	tk.MustExec("create user u1")
+	tk.MustExec("create event db1.e1 on schedule every 1 hour do select 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("create event db1.e2 on schedule every 1 hour do select 1", mysql.ErrDBaccessDenied)
+	tk1.MustGetErrCode("drop event db1.e1", mysql.ErrDBaccessDenied)
+	tk1.MustQuery("select event_name from information_schema.events").Check(testkit.Rows())
+
+	tk.MustExec("grant event on db1.* to u1")
+	tk1.MustExec("create event db1.e2 on schedule every 1 hour do select 1")
+	tk1.MustQuery("show events from db1").CheckAt([]int{1, 3}, testkit.Rows("e1 %", "e2 u1@%"))
+}
+
+func TestEventScheduler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustQuery("select @@global.event_scheduler").Check(testkit.Rows("0"))
+	defer tk.MustExec("set @@global.event_scheduler = default")
+
+	tk.MustExec("create event e1 on schedule every 1 second do insert into t values (1)")
+	tk.MustExec("create event e2 on schedule at current_timestamp + interval 1 second do " +
+		"begin declare x int default 2; insert into t values (x); end")
+	tk.MustExec("set @@global.event_scheduler = on")
+	require.Eventually(t, func() bool {
+		rows := tk.MustQuery("select count(*) from t where a = 1").Rows()
+		return rows[0][0].(string) != "0"
+	}, 30*time.Second, 100*time.Millisecond)
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t where a = 2").Rows()) == 1 &&
+			len(tk.MustQuery("show events like 'e2'").Rows()) == 0
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select last_executed is not null from information_schema.events").Check(testkit.Rows("1"))
+}
@@ -0,0 +1,830 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	parsertypes "github.com/pingcap/tidb/pkg/parser/types"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/types"
+	driver "github.com/pingcap/tidb/pkg/types/parser_driver"
+	contextutil "github.com/pingcap/tidb/pkg/util/context"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// variable is a parameter or a local variable of a stored procedure.
+type variable struct {
+	tp    *types.FieldType
+	value types.Datum
+}
+
+// valueExpr returns a constant expression holding the current value of the variable.
+func (v *variable) valueExpr() ast.ExprNode {
+	expr := ast.NewValueExpr(nil, "", "").(*driver.ValueExpr)
+	v.value.Copy(&expr.Datum)
+	expr.Type = *v.tp.Clone()
+	return expr
+}
+
+type cursor struct {
+	query ast.StmtNode
+	rows  [][]types.Datum
+	fts   []*types.FieldType
+	pos   int
+	open  bool
+}
+
+type handler struct {
+	*ast.ProcedureErrorControl
+	// scope is the block declaring the handler.
+	scope *scope
+}
+
+// scope is the runtime context of a BEGIN ... END block.
+type scope struct {
+	parent   *scope
+	vars     map[string]*variable
+	cursors  map[string]*cursor
+	handlers []*handler
+	// fence is set for the scope of a handler body. The handlers declared in the
+	// fenced scope are invisible while the handler is running.
+	fence *scope
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		parent:  parent,
+		vars:    make(map[string]*variable),
+		cursors: make(map[string]*cursor),
+	}
+}
+
+func (s *scope) lookupVar(name string) *variable {
+	name = strings.ToLower(name)
+	for ; s != nil; s = s.parent {
+		if v, ok := s.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *scope) lookupCursor(name string) *cursor {
+	name = strings.ToLower(name)
+	for ; s != nil; s = s.parent {
+		if c, ok := s.cursors[name]; ok {
+			return c
+		}
+	}
+	return nil
+}
+
+// findHandler finds the most specific handler for the condition in the
+// innermost scope which declares one.
+func (s *scope) findHandler(code uint16, state string) *handler {
+	fenced := make(map[*scope]struct{})
+	for ; s != nil; s = s.parent {
+		if s.fence != nil {
+			fenced[s.fence] = struct{}{}
+		}
+		if _, ok := fenced[s]; ok {
+			continue
+		}
+		var (
+			best     *handler
+			bestRank int
+		)
+		for _, h := range s.handlers {
+			if rank := h.match(code, state); rank > bestRank {
+				best, bestRank = h, rank
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+// match returns how specific the handler matches the condition, 0 means not matched.
+func (h *handler) match(code uint16, state string) int {
+	rank := 0
+	for _, cond := range h.ErrorCon {
+		switch x := cond.(type) {
+		case *ast.ProcedureErrorVal:
+			if x.ErrorNum == uint64(code) {
+				rank = max(rank, 3)
+			}
+		case *ast.ProcedureErrorState:
+			if x.CodeStatus == state {
+				rank = max(rank, 2)
+			}
+		case *ast.ProcedureErrorCon:
+			if conditionClass(state) == x.ErrorCon {
+				rank = max(rank, 1)
+			}
+		}
+	}
+	return rank
+}
+
+func conditionClass(state string) int {
+	switch {
+	case strings.HasPrefix(state, "01"):
+		return ast.PROCEDUR_SQLWARNING
+	case strings.HasPrefix(state, "02"):
+		return ast.PROCEDUR_NOT_FOUND
+	}
+	return ast.PROCEDUR_SQLEXCEPTION
+}
+
+func sqlErrorOf(err error) (uint16, string) {
+	if te, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(te)
+		return sqlErr.Code, sqlErr.State
+	}
+	return errno.ErrUnknown, mysql.DefaultMySQLState
+}
+
+// jumpSignal implements LEAVE and ITERATE, it unwinds the statements until the labeled one.
+type jumpSignal struct {
+	label string
+	leave bool
+}
+
+func (s *jumpSignal) Error() string {
+	return "jump to label " + s.label
+}
+
+// exitSignal is raised by an EXIT handler, it terminates the block declaring the handler.
+type exitSignal struct {
+	scope *scope
+}
+
+func (*exitSignal) Error() string {
+	return "exit handler"
+}
+
+// interpreter executes the body of a stored procedure. Every SQL statement in
+// the body is executed as a nested statement of the session with the local
+// variables replaced by their values.
+type interpreter struct {
+	sctx    sessionctx.Context
+	routine *Routine
+	// caller is the interpreter of the calling procedure.
+	caller *interpreter
+	// outerSC is the statement context of the CALL statement.
+	outerSC *stmtctx.StatementContext
+	parser  *parser.Parser
+	// texts caches the restored SQL of the statements and expressions.
+	texts map[ast.Node]string
+	// warnings are the warnings of the last executed statement.
+	warnings     []stmtctx.SQLWarn
+	affectedRows uint64
+	dbCharset    string
+	dbCollation  string
+	// runner executes the statements if it's set, see StmtRunner.
+	runner StmtRunner
+}
+
+// StmtRunner executes a statement in the body of a trigger as a part of the
+// triggering statement. At most limit rows are returned, fts is nil if the
+// statement doesn't return a result set.
+type StmtRunner func(ctx context.Context, stmt ast.StmtNode, limit int) (rows [][]types.Datum, fts []*types.FieldType, err error)
+
+func (it *interpreter) restore(key, node ast.Node) (string, error) {
+	if text, ok := it.texts[key]; ok {
+		return text, nil
+	}
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	text := sb.String()
+	it.texts[key] = text
+	return text, nil
+}
+
+// prepare parses a fresh copy of the statement and replaces the references to
+// the local variables with their current values.
+func (it *interpreter) prepare(sc *scope, sql string) (ast.StmtNode, error) {
+	stmt, err := it.parser.ParseOneStmt(sql, it.routine.CharsetClient, it.routine.CollationConnection)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	stmt.Accept(&varSubstitutor{scope: sc})
+	return stmt, nil
+}
+
+// varSubstitutor replaces the unqualified column names which refer to local
+// variables, and the NEW.col and OLD.col references in a trigger body.
+type varSubstitutor struct {
+	scope *scope
+}
+
+func (v *varSubstitutor) Enter(n ast.Node) (ast.Node, bool) {
+	switch x := n.(type) {
+	case *ast.ValuesExpr, *ast.SelectIntoOption:
+		return n, true
+	case *ast.ColumnNameExpr:
+		if x.Name.Schema.L != "" {
+			break
+		}
+		name := x.Name.Name.L
+		if x.Name.Table.L != "" {
+			name = x.Name.Table.L + "." + name
+		}
+		if vr := v.scope.lookupVar(name); vr != nil {
+			return vr.valueExpr(), true
+		}
+	}
+	return n, false
+}
+
+func (*varSubstitutor) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// execSQL executes the statement in the session. The result rows are returned
+// if the statement is a query, at most limit rows are read.
+func (it *interpreter) execSQL(ctx context.Context, stmt ast.StmtNode, limit int) (rows [][]types.Datum, fts []*types.FieldType, err error) {
+	if it.runner != nil {
+		return it.runSQL(ctx, stmt, limit)
+	}
+	sessVars := it.sctx.GetSessionVars()
+	// The statement context of the CALL statement must be current when a nested
+	// statement starts, so the nested one never reuses and resets it.
+	sessVars.StmtCtx = it.outerSC
+	defer func() {
+		if nested := sessVars.StmtCtx; nested != it.outerSC {
+			it.warnings = nested.GetWarnings()
+			it.affectedRows = nested.AffectedRows()
+		}
+		sessVars.StmtCtx = it.outerSC
+	}()
+
+	rs, err := it.sctx.GetSQLExecutor().ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, nil, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	if limit == 0 {
+		return nil, nil, exeerrors.ErrSpBadselect.GenWithStackByArgs(it.routine.Schema + "." + it.routine.Name)
+	}
+	for _, field := range rs.Fields() {
+		fts = append(fts, &field.Column.FieldType)
+	}
+	for len(rows) < limit {
+		req := rs.NewChunk(nil)
+		if err = rs.Next(ctx, req); err != nil {
+			return nil, nil, err
+		}
+		if req.NumRows() == 0 {
+			break
+		}
+		for i := range req.NumRows() {
+			rows = append(rows, req.GetRow(i).GetDatumRow(fts))
+		}
+	}
+	return rows, fts, nil
+}
+
+// runSQL executes the statement by the runner. The statement shares the
+// statement context with the triggering statement, so the affected rows and
+// the insert id of it are restored and its warnings are taken out.
+func (it *interpreter) runSQL(ctx context.Context, stmt ast.StmtNode, limit int) (rows [][]types.Datum, fts []*types.FieldType, err error) {
+	sc := it.sctx.GetSessionVars().StmtCtx
+	affectedRows, warnCnt := sc.AffectedRows(), int(sc.WarningCount())
+	lastInsertID, lastInsertIDSet, insertID := sc.LastInsertID, sc.LastInsertIDSet, sc.InsertID
+	defer func() {
+		it.warnings = sc.TruncateWarnings(warnCnt)
+		it.affectedRows = sc.AffectedRows() - affectedRows
+		sc.SetAffectedRows(affectedRows)
+		sc.LastInsertID, sc.LastInsertIDSet, sc.InsertID = lastInsertID, lastInsertIDSet, insertID
+	}()
+
+	rows, fts, err = it.runner(ctx, stmt, limit)
+	if err == nil && limit == 0 && fts != nil {
+		return nil, nil, exeerrors.ErrSpBadselect.GenWithStackByArgs(it.routine.Schema + "." + it.routine.Name)
+	}
+	return rows, fts, err
+}
+
+// evalExpr evaluates the expression by a nested `SELECT expr` statement.
+func (it *interpreter) evalExpr(ctx context.Context, sc *scope, expr ast.ExprNode) (types.Datum, error) {
+	text, err := it.restore(expr, expr)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	stmt, err := it.prepare(sc, "SELECT "+text)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return it.evalSelect(ctx, stmt)
+}
+
+func (it *interpreter) evalSelect(ctx context.Context, stmt ast.StmtNode) (types.Datum, error) {
+	rows, _, err := it.execSQL(ctx, stmt, 1)
+	if err != nil || len(rows) == 0 {
+		return types.Datum{}, err
+	}
+	return rows[0][0], nil
+}
+
+func (it *interpreter) evalCond(ctx context.Context, sc *scope, expr ast.ExprNode) (bool, error) {
+	d, err := it.evalExpr(ctx, sc, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	b, err := d.ToBool(it.outerSC.TypeCtx())
+	return b != 0, err
+}
+
+func (it *interpreter) assign(v *variable, d types.Datum) error {
+	val, err := d.ConvertTo(it.outerSC.TypeCtx(), v.tp)
+	if err != nil {
+		return err
+	}
+	v.value = val
+	return nil
+}
+
+// fieldType fills the unspecified charset, collation and length of the type
+// of a parameter or a local variable.
+func (it *interpreter) fieldType(tp *types.FieldType) *types.FieldType {
+	ft := tp.Clone()
+	if parsertypes.HasCharset(ft) {
+		if ft.GetCharset() == "" {
+			ft.SetCharset(it.dbCharset)
+			if ft.GetCollate() == "" {
+				ft.SetCollate(it.dbCollation)
+			}
+		}
+		if ft.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(ft.GetCharset())
+			if err == nil {
+				ft.SetCollate(coll)
+			}
+		}
+	}
+	flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(flen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(decimal)
+	}
+	return ft
+}
+
+func (it *interpreter) checkKilled() error {
+	return it.sctx.GetSessionVars().SQLKiller.HandleSignal()
+}
+
+func (it *interpreter) execStmts(ctx context.Context, sc *scope, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := it.execStmt(ctx, sc, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (it *interpreter) execStmt(ctx context.Context, sc *scope, stmt ast.StmtNode) error {
+	if err := it.checkKilled(); err != nil {
+		return err
+	}
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return it.execBlock(ctx, sc, x)
+	case *ast.ProcedureLabelBlock:
+		err := it.execBlock(ctx, sc, x.Block)
+		if jump, ok := err.(*jumpSignal); ok && jump.leave && strings.EqualFold(jump.label, x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return it.execLoop(ctx, sc, x.Block, x.LabelName)
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt:
+		return it.execLoop(ctx, sc, x, "")
+	case *ast.ProcedureIfInfo:
+		return it.execIf(ctx, sc, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return it.execSimpleCase(ctx, sc, x)
+	case *ast.SearchCaseStmt:
+		return it.execSearchCase(ctx, sc, x)
+	case *ast.ProcedureJump:
+		return &jumpSignal{label: x.Name, leave: x.IsLeave}
+	}
+	it.warnings = nil
+	if err := it.execLeaf(ctx, sc, stmt); err != nil {
+		return it.handleError(ctx, sc, err)
+	}
+	return it.raiseWarnings(ctx, sc)
+}
+
+func (it *interpreter) execBlock(ctx context.Context, parent *scope, block *ast.ProcedureBlock) error {
+	sc := newScope(parent)
+	err := it.declare(ctx, sc, block.ProcedureVars)
+	if err == nil {
+		err = it.execStmts(ctx, sc, block.ProcedureProcStmts)
+	}
+	if exit, ok := err.(*exitSignal); ok && exit.scope == sc {
+		return nil
+	}
+	return err
+}
+
+func (it *interpreter) declare(ctx context.Context, sc *scope, decls []ast.DeclNode) error {
+	for _, decl := range decls {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			tp := it.fieldType(x.DeclType)
+			var value types.Datum
+			if x.DeclDefault != nil {
+				d, err := it.evalExpr(ctx, sc, x.DeclDefault)
+				if err != nil {
+					if err = it.handleError(ctx, sc, err); err != nil {
+						return err
+					}
+				}
+				if value, err = d.ConvertTo(it.outerSC.TypeCtx(), tp); err != nil {
+					return err
+				}
+			}
+			for _, name := range x.DeclNames {
+				v := &variable{tp: tp}
+				value.Copy(&v.value)
+				sc.vars[strings.ToLower(name)] = v
+			}
+		case *ast.ProcedureCursor:
+			sc.cursors[strings.ToLower(x.CurName)] = &cursor{query: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			sc.handlers = append(sc.handlers, &handler{ProcedureErrorControl: x, scope: sc})
+		}
+	}
+	return nil
+}
+
+func (it *interpreter) execLoop(ctx context.Context, sc *scope, loop ast.StmtNode, label string) error {
+	for {
+		if err := it.checkKilled(); err != nil {
+			return err
+		}
+		var (
+			done bool
+			err  error
+		)
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			var ok bool
+			if ok, err = it.evalCond(ctx, sc, x.Condition); err != nil {
+				return it.handleError(ctx, sc, err)
+			}
+			if !ok {
+				return nil
+			}
+			err = it.execStmts(ctx, sc, x.Body)
+		case *ast.ProcedureRepeatStmt:
+			if err = it.execStmts(ctx, sc, x.Body); err == nil {
+				if done, err = it.evalCond(ctx, sc, x.Condition); err != nil {
+					return it.handleError(ctx, sc, err)
+				}
+			}
+		default:
+			return errors.Errorf("unsupported loop statement %T", loop)
+		}
+		if jump, ok := err.(*jumpSignal); ok && label != "" && strings.EqualFold(jump.label, label) {
+			if jump.leave {
+				return nil
+			}
+			continue
+		}
+		if err != nil || done {
+			return err
+		}
+	}
+}
+
+func (it *interpreter) execIf(ctx context.Context, sc *scope, block *ast.ProcedureIfBlock) error {
+	ok, err := it.evalCond(ctx, sc, block.IfExpr)
+	if err != nil {
+		return it.handleError(ctx, sc, err)
+	}
+	if ok {
+		return it.execStmts(ctx, sc, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return it.execIf(ctx, sc, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return it.execStmts(ctx, sc, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (it *interpreter) execSimpleCase(ctx context.Context, sc *scope, s *ast.SimpleCaseStmt) error {
+	value, err := it.evalExpr(ctx, sc, s.Condition)
+	if err != nil {
+		return it.handleError(ctx, sc, err)
+	}
+	caseVar := &variable{tp: types.NewFieldType(mysql.TypeNull), value: value}
+	if !value.IsNull() {
+		caseVar.tp = types.NewFieldType(mysql.TypeVarString)
+		types.DefaultTypeForValue(value.GetValue(), caseVar.tp, it.dbCharset, it.dbCollation)
+	}
+	for _, when := range s.WhenCases {
+		// Evaluate `case_value = when_value` with the case value evaluated only once.
+		text, err := it.restore(when.Expr, when.Expr)
+		if err != nil {
+			return err
+		}
+		stmt, err := it.prepare(sc, "SELECT "+text)
+		if err != nil {
+			return err
+		}
+		field := stmt.(*ast.SelectStmt).Fields.Fields[0]
+		field.Expr = &ast.BinaryOperationExpr{Op: opcode.EQ, L: caseVar.valueExpr(), R: field.Expr}
+		d, err := it.evalSelect(ctx, stmt)
+		if err != nil {
+			return it.handleError(ctx, sc, err)
+		}
+		if !d.IsNull() && d.GetInt64() != 0 {
+			return it.execStmts(ctx, sc, when.ProcedureStmts)
+		}
+	}
+	return it.execElse(ctx, sc, s.ElseCases)
+}
+
+func (it *interpreter) execSearchCase(ctx context.Context, sc *scope, s *ast.SearchCaseStmt) error {
+	for _, when := range s.WhenCases {
+		ok, err := it.evalCond(ctx, sc, when.Expr)
+		if err != nil {
+			return it.handleError(ctx, sc, err)
+		}
+		if ok {
+			return it.execStmts(ctx, sc, when.ProcedureStmts)
+		}
+	}
+	return it.execElse(ctx, sc, s.ElseCases)
+}
+
+func (it *interpreter) execElse(ctx context.Context, sc *scope, stmts []ast.StmtNode) error {
+	if stmts == nil {
+		return it.handleError(ctx, sc, exeerrors.ErrSpCaseNotFound.GenWithStackByArgs())
+	}
+	return it.execStmts(ctx, sc, stmts)
+}
+
+// handleError runs the handler of the error. It returns nil if the error is
+// handled by a CONTINUE handler.
+func (it *interpreter) handleError(ctx context.Context, sc *scope, err error) error {
+	switch err.(type) {
+	case *jumpSignal, *exitSignal:
+		return err
+	}
+	if exeerrors.ErrQueryInterrupted.Equal(err) || exeerrors.ErrMaxExecTimeExceeded.Equal(err) {
+		return err
+	}
+	h := sc.findHandler(sqlErrorOf(err))
+	if h == nil {
+		return err
+	}
+	return it.runHandler(ctx, h)
+}
+
+// raiseWarnings passes the warnings of the last statement to the CALL statement,
+// or runs the handler of them.
+func (it *interpreter) raiseWarnings(ctx context.Context, sc *scope) error {
+	warnings := it.warnings
+	it.warnings = nil
+	for _, warn := range warnings {
+		if warn.Level == contextutil.WarnLevelNote {
+			continue
+		}
+		if h := sc.findHandler(sqlErrorOf(warn.Err)); h != nil {
+			return it.runHandler(ctx, h)
+		}
+	}
+	it.outerSC.AppendWarnings(warnings)
+	return nil
+}
+
+// raiseCondition raises a completion condition such as NOT FOUND, it becomes
+// a warning if no handler handles it.
+func (it *interpreter) raiseCondition(ctx context.Context, sc *scope, err error) error {
+	if h := sc.findHandler(sqlErrorOf(err)); h != nil {
+		return it.runHandler(ctx, h)
+	}
+	it.outerSC.AppendWarning(err)
+	return nil
+}
+
+func (it *interpreter) runHandler(ctx context.Context, h *handler) error {
+	sc := newScope(h.scope)
+	sc.fence = h.scope
+	if err := it.execStmt(ctx, sc, h.Operate); err != nil {
+		return err
+	}
+	if h.ControlHandle == ast.PROCEDUR_EXIT {
+		return &exitSignal{scope: h.scope}
+	}
+	return nil
+}
+
+func (it *interpreter) execLeaf(ctx context.Context, sc *scope, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.SetStmt:
+		return it.execSet(ctx, sc, x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return it.execSelectInto(ctx, sc, x)
+		}
+	case *ast.ProcedureOpenCur:
+		return it.openCursor(ctx, sc, x)
+	case *ast.ProcedureFetchInto:
+		return it.fetchCursor(sc, x)
+	case *ast.ProcedureCloseCur:
+		c := sc.lookupCursor(x.CurName)
+		if !c.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		c.open, c.rows, c.pos = false, nil, 0
+		return nil
+	case *ast.CallStmt:
+		return call(ctx, it.sctx, it.outerSC, x, it, sc)
+	}
+	text, err := it.restore(stmt, stmt)
+	if err != nil {
+		return err
+	}
+	prepared, err := it.prepare(sc, text)
+	if err != nil {
+		return err
+	}
+	_, _, err = it.execSQL(ctx, prepared, 0)
+	return err
+}
+
+func (it *interpreter) execSet(ctx context.Context, sc *scope, s *ast.SetStmt) error {
+	for _, assign := range s.Variables {
+		if assign.IsSystem && !assign.IsGlobal {
+			if v := sc.lookupVar(assign.Name); v != nil {
+				d, err := it.evalExpr(ctx, sc, assign.Value)
+				if err != nil {
+					return err
+				}
+				if err = it.assign(v, d); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		// Execute the other assignments one by one to keep the order of them.
+		text, err := it.restore(assign, &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}})
+		if err != nil {
+			return err
+		}
+		prepared, err := it.prepare(sc, text)
+		if err != nil {
+			return err
+		}
+		if _, _, err = it.execSQL(ctx, prepared, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (it *interpreter) execSelectInto(ctx context.Context, sc *scope, s *ast.SelectStmt) error {
+	text, err := it.restore(s, s)
+	if err != nil {
+		return err
+	}
+	prepared, err := it.prepare(sc, text)
+	if err != nil {
+		return err
+	}
+	sel := prepared.(*ast.SelectStmt)
+	targets := sel.SelectIntoOpt.Vars
+	sel.SelectIntoOpt = nil
+	rows, fts, err := it.execSQL(ctx, sel, 2)
+	if err != nil {
+		return err
+	}
+	if len(fts) != len(targets) {
+		return plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	switch len(rows) {
+	case 0:
+		return it.raiseCondition(ctx, sc, exeerrors.ErrSpFetchNoData.FastGenByArgs())
+	case 1:
+		return it.assignTargets(sc, targets, rows[0], fts)
+	}
+	return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+}
+
+// assignTargets assigns the row to the local variables or user variables.
+func (it *interpreter) assignTargets(sc *scope, targets []ast.ExprNode, row []types.Datum, fts []*types.FieldType) error {
+	for i, target := range targets {
+		switch x := target.(type) {
+		case *ast.VariableExpr:
+			setUserVar(it.sctx, x.Name, row[i], fts[i])
+		case *ast.ColumnNameExpr:
+			v := sc.lookupVar(x.Name.Name.L)
+			if v == nil {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+			}
+			if err := it.assign(v, row[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (it *interpreter) openCursor(ctx context.Context, sc *scope, s *ast.ProcedureOpenCur) error {
+	c := sc.lookupCursor(s.CurName)
+	if c.open {
+		return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+	}
+	text, err := it.restore(c.query, c.query)
+	if err != nil {
+		return err
+	}
+	prepared, err := it.prepare(sc, text)
+	if err != nil {
+		return err
+	}
+	rows, fts, err := it.execSQL(ctx, prepared, int(^uint(0)>>1))
+	if err != nil {
+		return err
+	}
+	c.rows, c.fts, c.pos, c.open = rows, fts, 0, true
+	return nil
+}
+
+func (it *interpreter) fetchCursor(sc *scope, s *ast.ProcedureFetchInto) error {
+	c := sc.lookupCursor(s.CurName)
+	if !c.open {
+		return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+	}
+	if c.pos >= len(c.rows) {
+		return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+	}
+	row := c.rows[c.pos]
+	c.pos++
+	if len(row) != len(s.Variables) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+	}
+	for i, name := range s.Variables {
+		v := sc.lookupVar(name)
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := it.assign(v, row[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setUserVar(sctx sessionctx.Context, name string, d types.Datum, ft *types.FieldType) {
+	sessVars := sctx.GetSessionVars()
+	name = strings.ToLower(name)
+	if d.IsNull() {
+		sessVars.UnsetUserVar(name)
+		return
+	}
+	sessVars.SetUserVarVal(name, d)
+	sessVars.SetUserVarType(name, ft)
+}
@@ -0,0 +1,212 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure_test
+
+import (
+	"testing"
+
+	mysql "github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndDropProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create procedure p1(in a int, out b varchar(10)) select a into b")
+	tk.MustGetErrCode("create procedure p1() select 1", mysql.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustGetErrCode("create procedure not_exist_db.p1() select 1", mysql.ErrBadDB)
+	// The referenced tables are not required to exist.
+	tk.MustExec("create procedure p_later() insert into t_later values (1)")
+	tk.MustGetErrCode("call p_later()", mysql.ErrNoSuchTable)
+	tk.MustExec("drop procedure p_later")
+
+	tk.MustQuery("show create procedure p1").CheckAt([]int{0, 2}, testkit.RowsWithSep("|",
+		"p1|CREATE PROCEDURE `p1`(in a int, out b varchar(10))\nselect a into b"))
+	tk.MustQuery("show create procedure test.P1").CheckAt([]int{0}, testkit.Rows("p1"))
+	err := tk.QueryToErr("show create procedure p2")
+	require.True(t, exeerrors.ErrSpDoesNotExist.Equal(err))
+	tk.MustQuery("show procedure status").CheckAt([]int{0, 1, 2, 6}, testkit.Rows("test p1 PROCEDURE INVOKER"))
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition from information_schema.routines").
+		Check(testkit.Rows("test p1 PROCEDURE select a into b"))
+
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", mysql.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+
+	tk.MustExec("create database db1")
+	tk.MustExec("create procedure db1.p1() select 1")
+	tk.MustQuery("select count(*) from mysql.routines where route_schema = 'db1'").Check(testkit.Rows("1"))
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from mysql.routines where route_schema = 'db1'").Check(testkit.Rows("0"))
+}
+
+func TestCheckProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode("create procedure p(a int, A int) select 1", mysql.ErrSpDupParam)
+	tk.MustGetErrCode("create procedure p() begin declare a int; declare a int; end", mysql.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare c cursor for select 2; end", mysql.ErrSpDupCurs)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare a int; end", mysql.ErrSpVarcondAfterCurshndlr)
+	tk.MustGetErrCode("create procedure p() begin declare continue handler for sqlexception begin end; "+
+		"declare c cursor for select 1; end", mysql.ErrSpCursorAfterHandler)
+	tk.MustGetErrCode("create procedure p() begin declare continue handler for 1062 begin end; "+
+		"declare exit handler for 1062 begin end; end", mysql.ErrSpDupHandler)
+	tk.MustGetErrCode("create procedure p() begin open c; end", mysql.ErrSpCursorMismatch)
+	tk.MustGetErrCode("create procedure p() begin leave l; end", mysql.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create procedure p() l: begin iterate l; end", mysql.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create procedure p() l: begin select 1; end l2", mysql.ErrSpLabelMismatch)
+	tk.MustGetErrCode("create procedure p() l: begin l: while 1 do leave l; end while; end", mysql.ErrSpLabelRedefine)
+	tk.MustQuery("select count(*) from mysql.routines").Check(testkit.Rows("0"))
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+
+	tk.MustExec(`create procedure p_sum(in n int, out s int)
+begin
+	declare i int default 0;
+	set s = 0;
+	while i < n do
+		set i = i + 1;
+		set s = s + i;
+	end while;
+end`)
+	tk.MustExec("call p_sum(10, @s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("55"))
+
+	// INOUT parameters, REPEAT, labels and CASE.
+	tk.MustExec(`create procedure p_loop(inout x int)
+begin
+	l: repeat
+		set x = x + 1;
+		if x % 2 = 0 then
+			iterate l;
+		end if;
+		case
+			when x > 6 then leave l;
+			else insert into t values (x, concat('v', x));
+		end case;
+	until x > 100 end repeat l;
+end`)
+	tk.MustExec("set @x = 0")
+	tk.MustExec("call p_loop(@x)")
+	tk.MustQuery("select @x").Check(testkit.Rows("7"))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 v1", "3 v3", "5 v5"))
+
+	// SELECT INTO local variables and user variables.
+	tk.MustExec(`create procedure p_into(in k int)
+begin
+	declare val varchar(10);
+	select v into val from t where id = k;
+	select upper(val), k into @upper, @k;
+end`)
+	tk.MustExec("call p_into(3)")
+	tk.MustQuery("select @upper, @k").Check(testkit.Rows("V3 3"))
+	tk.MustExec("call p_into(2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1329 No data - zero rows fetched, selected, or processed"))
+	tk.MustQuery("select @upper, @k").Check(testkit.Rows("<nil> 2"))
+
+	// Cursors with a NOT FOUND handler.
+	tk.MustExec(`create procedure p_cursor(out total varchar(100))
+begin
+	declare done int default 0;
+	declare val varchar(10);
+	declare c cursor for select v from t order by id;
+	declare continue handler for not found set done = 1;
+	set total = '';
+	open c;
+	read_loop: while 1 do
+		fetch c into val;
+		if done then
+			leave read_loop;
+		end if;
+		set total = concat(total, val);
+	end while;
+	close c;
+end`)
+	tk.MustExec("call p_cursor(@total)")
+	tk.MustQuery("select @total").Check(testkit.Rows("v1v3v5"))
+
+	// EXIT handlers terminate the block declaring them.
+	tk.MustExec(`create procedure p_exit(out res varchar(20))
+begin
+	begin
+		declare exit handler for 1062 set res = 'duplicated';
+		insert into t values (1, 'dup');
+		set res = 'not reached';
+	end;
+	set res = concat(res, '!');
+end`)
+	tk.MustExec("call p_exit(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("duplicated!"))
+
+	// Nested calls pass local variables as OUT arguments.
+	tk.MustExec(`create procedure p_outer(out res int)
+begin
+	declare s int;
+	call p_sum(4, s);
+	set res = s * 2;
+end`)
+	tk.MustExec("call p_outer(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("20"))
+
+	tk.MustExec("create procedure p_select() select * from t")
+	tk.MustGetErrCode("call p_select()", mysql.ErrSpBadselect)
+	tk.MustGetErrCode("call p_sum(1)", mysql.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call p_sum(1, 2)", mysql.ErrSpNotVarArg)
+	tk.MustGetErrCode("call p_not_exist()", mysql.ErrSpDoesNotExist)
+	tk.MustExec("create procedure p_fetch() begin declare a int; declare c cursor for select 1; open c; fetch c into a; fetch c into a; end")
+	tk.MustGetErrCode("call p_fetch()", mysql.ErrSpFetchNoData)
+	tk.MustExec("create procedure p_recursive() call p_recursive()")
+	tk.MustGetErrCode("call p_recursive()", mysql.ErrSpRecursionLimit)
+	tk.MustExec("create procedure p_case(a int) begin case a when 1 then select 1 into @a; end case; end")
+	tk.MustGetErrCode("call p_case(2)", mysql.ErrSpCaseNotFound)
+
+	// The procedure runs in its own schema.
+	tk.MustExec("create database db1")
+	tk.MustExec("use db1")
+	tk.MustExec("call test.p_sum(3, @s)")
+	tk.MustQuery("select @s, database()").Check(testkit.Rows("6 db1"))
+	tk.MustGetErrCode("call p_sum(3, @s)", mysql.ErrSpDoesNotExist)
+}
+
+func TestSelectIntoUserVars(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'x'), (2, 'y')")
+
+	tk.MustExec("select a, b into @a, @b from t where a = 2")
+	tk.MustQuery("select @a, @b").Check(testkit.Rows("2 y"))
+	tk.MustExec("select a into @a from t where a = 3")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1329 No data - zero rows fetched, selected, or processed"))
+	tk.MustQuery("select @a").Check(testkit.Rows("2"))
+	tk.MustGetErrCode("select a into @a from t", mysql.ErrTooManyRows)
+	tk.MustGetErrCode("select a into @a, @b from t", mysql.ErrWrongNumberOfColumnsInSelect)
+	tk.MustGetErrCode("select a into x from t", mysql.ErrSpUndeclaredVar)
+}
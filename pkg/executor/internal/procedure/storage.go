@@ -0,0 +1,241 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const (
+	// RoutineTypeProcedure is the routine type of stored procedures in mysql.routines.
+	RoutineTypeProcedure = "PROCEDURE"
+	// SecurityTypeInvoker means the routine is executed with the privileges of the invoker.
+	// It is the only security type supported now.
+	SecurityTypeInvoker = "INVOKER"
+)
+
+const selectRoutineSQL = `SELECT route_schema, name, type, definition, parameter_str, definer, sql_mode, security_type,
+	comment, character_set_client, collation_connection, database_collation, created, last_altered FROM mysql.routines`
+
+// Routine is a stored routine persisted in mysql.routines.
+type Routine struct {
+	Schema              string
+	Name                string
+	Type                string
+	Definition          string
+	ParameterStr        string
+	Definer             string
+	SQLMode             string
+	SecurityType        string
+	Comment             string
+	CharsetClient       string
+	CollationConnection string
+	DatabaseCollation   string
+	Created             types.Time
+	LastAltered         types.Time
+}
+
+// CreateSQL returns the statement which creates the routine.
+func (r *Routine) CreateSQL() string {
+	return fmt.Sprintf("CREATE PROCEDURE %s(%s)\n%s", quoteName(r.Name), r.ParameterStr, r.Definition)
+}
+
+func quoteName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func routineFromRow(row chunk.Row) *Routine {
+	return &Routine{
+		Schema:              row.GetString(0),
+		Name:                row.GetString(1),
+		Type:                row.GetEnum(2).String(),
+		Definition:          row.GetString(3),
+		ParameterStr:        row.GetString(4),
+		Definer:             row.GetString(5),
+		SQLMode:             row.GetString(6),
+		SecurityType:        row.GetEnum(7).String(),
+		Comment:             row.GetString(8),
+		CharsetClient:       row.GetString(9),
+		CollationConnection: row.GetString(10),
+		DatabaseCollation:   row.GetString(11),
+		Created:             row.GetTime(12),
+		LastAltered:         row.GetTime(13),
+	}
+}
+
+func queryRoutines(ctx context.Context, sctx sessionctx.Context, sql string, args ...any) ([]*Routine, error) {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := sctx.GetRestrictedSQLExecutor().ExecRestrictedSQL(ctx, nil, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	routines := make([]*Routine, 0, len(rows))
+	for _, row := range rows {
+		routines = append(routines, routineFromRow(row))
+	}
+	return routines, nil
+}
+
+// GetProcedure loads a stored procedure, it returns nil if the procedure does not exist.
+func GetProcedure(ctx context.Context, sctx sessionctx.Context, schema, name string) (*Routine, error) {
+	routines, err := queryRoutines(ctx, sctx, selectRoutineSQL+" WHERE route_schema = %? AND name = %? AND type = %?",
+		schema, name, RoutineTypeProcedure)
+	if err != nil || len(routines) == 0 {
+		return nil, err
+	}
+	return routines[0], nil
+}
+
+// ListRoutines lists all stored routines which the current user has privilege to see.
+func ListRoutines(ctx context.Context, sctx sessionctx.Context) ([]*Routine, error) {
+	routines, err := queryRoutines(ctx, sctx, selectRoutineSQL+" ORDER BY route_schema, name")
+	if err != nil {
+		return nil, err
+	}
+	visible := routines[:0]
+	for _, r := range routines {
+		if HasRoutinePrivilege(sctx, r.Schema) {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
+
+// HasRoutinePrivilege checks whether the current user can see the routines in the schema.
+// Any of CREATE ROUTINE, ALTER ROUTINE or EXECUTE privilege is enough.
+func HasRoutinePrivilege(sctx sessionctx.Context, schema string) bool {
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil {
+		return true
+	}
+	return checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(schema), "", "",
+		mysql.CreateRoutinePriv|mysql.AlterRoutinePriv|mysql.ExecutePriv)
+}
+
+// ResolveSchema returns the schema name of a routine, it falls back to the current database.
+func ResolveSchema(sctx sessionctx.Context, schema ast.CIStr) (ast.CIStr, error) {
+	if schema.O == "" {
+		currentDB := sctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			return schema, errors.Trace(plannererrors.ErrNoDB)
+		}
+		schema = ast.NewCIStr(currentDB)
+	}
+	return schema, nil
+}
+
+// CreateProcedure persists the procedure defined by `CREATE PROCEDURE` statement.
+// sysSctx is a system session which is used to write mysql.routines.
+func CreateProcedure(ctx context.Context, sctx, sysSctx sessionctx.Context, is infoschema.InfoSchema, s *ast.ProcedureInfo) error {
+	schema, err := ResolveSchema(sctx, s.ProcedureName.Schema)
+	if err != nil {
+		return err
+	}
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+	if err := checkProcedure(s); err != nil {
+		return err
+	}
+	name := s.ProcedureName.Name.O
+	existed, err := GetProcedure(ctx, sctx, dbInfo.Name.O, name)
+	if err != nil {
+		return err
+	}
+	if existed != nil {
+		err = exeerrors.ErrSpAlreadyExists.FastGenByArgs(RoutineTypeProcedure, name)
+		if s.IfNotExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	sessVars := sctx.GetSessionVars()
+	definer := "%"
+	if user := sessVars.User; user != nil {
+		definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	charsetClient, err := sessVars.GetSessionOrGlobalSystemVar(ctx, vardef.CharacterSetClient)
+	if err != nil {
+		return err
+	}
+	_, collationConnection := sessVars.GetCharsetInfo()
+	sqlMode, _ := sessVars.GetSystemVar(vardef.SQLModeVar)
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, `INSERT INTO mysql.routines (route_schema, name, type, definition, parameter_str, definer,
+		sql_mode, security_type, comment, character_set_client, collation_connection, database_collation)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, %?, '', %?, %?, %?)`,
+		dbInfo.Name.O, name, RoutineTypeProcedure, s.ProcedureBody.Text(), s.ProcedureParamStr, definer,
+		sqlMode, SecurityTypeInvoker, charsetClient, collationConnection, dbInfo.Collate)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, err = sysSctx.GetSQLExecutor().ExecuteInternal(ctx, sql.String())
+	if kv.ErrKeyExists.Equal(err) {
+		// Another session created the procedure concurrently.
+		return exeerrors.ErrSpAlreadyExists.FastGenByArgs(RoutineTypeProcedure, name)
+	}
+	return err
+}
+
+// DropProcedure removes the procedure specified by `DROP PROCEDURE` statement.
+func DropProcedure(ctx context.Context, sctx, sysSctx sessionctx.Context, s *ast.DropProcedureStmt) error {
+	schema, err := ResolveSchema(sctx, s.ProcedureName.Schema)
+	if err != nil {
+		return err
+	}
+	name := s.ProcedureName.Name.O
+	existed, err := GetProcedure(ctx, sctx, schema.O, name)
+	if err != nil {
+		return err
+	}
+	if existed == nil {
+		err = exeerrors.ErrSpDoesNotExist.FastGenByArgs(RoutineTypeProcedure, schema.O+"."+name)
+		if s.IfExists {
+			sctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, err = sysSctx.GetSQLExecutor().ExecuteInternal(ctx,
+		"DELETE FROM mysql.routines WHERE route_schema = %? AND name = %? AND type = %?",
+		existed.Schema, existed.Name, RoutineTypeProcedure)
+	return err
+}
+
+// DropSchemaRoutines removes all routines of the dropped schema.
+func DropSchemaRoutines(ctx context.Context, sqlExec sqlexec.SQLExecutor, schema string) error {
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, err := sqlExec.ExecuteInternal(ctx, "DELETE FROM mysql.routines WHERE route_schema = %?", schema)
+	return err
+}
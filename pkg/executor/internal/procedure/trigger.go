@@ -0,0 +1,310 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// RoutineTypeTrigger is the routine type of the trigger bodies run by the interpreter.
+const RoutineTypeTrigger = "TRIGGER"
+
+const (
+	rowNew = "new"
+	rowOld = "old"
+)
+
+// TriggerCreateSQL returns the statement which creates the trigger on the table.
+func TriggerCreateSQL(trigger *model.TriggerInfo, table string) string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW %s", quoteName(trigger.Name.O),
+		trigger.Timing, trigger.Event, quoteName(table), trigger.Statement)
+}
+
+// NewTriggerInfo checks the body of the `CREATE TRIGGER` statement and builds
+// the trigger with the current session environment.
+func NewTriggerInfo(ctx context.Context, sctx sessionctx.Context, s *ast.CreateTriggerStmt,
+	dbInfo *model.DBInfo, tblInfo *model.TableInfo) (*model.TriggerInfo, error) {
+	tc := &triggerChecker{stmt: s, tblInfo: tblInfo}
+	c := &checker{visit: tc.check}
+	if err := c.checkStmt(s.Body); err != nil {
+		return nil, err
+	}
+
+	sessVars := sctx.GetSessionVars()
+	definer := "%"
+	if user := sessVars.User; user != nil {
+		definer = user.AuthUsername + "@" + user.AuthHostname
+	}
+	charsetClient, err := sessVars.GetSessionOrGlobalSystemVar(ctx, vardef.CharacterSetClient)
+	if err != nil {
+		return nil, err
+	}
+	_, collationConnection := sessVars.GetCharsetInfo()
+	sqlMode, _ := sessVars.GetSystemVar(vardef.SQLModeVar)
+	return &model.TriggerInfo{
+		Name:                s.Trigger.Name,
+		Timing:              s.Timing,
+		Event:               s.Event,
+		Statement:           s.Body.Text(),
+		Definer:             definer,
+		SQLMode:             sqlMode,
+		CharsetClient:       charsetClient,
+		CollationConnection: collationConnection,
+		DatabaseCollation:   dbInfo.Collate,
+		CreateTS:            time.Now(),
+	}, nil
+}
+
+// triggerChecker checks the statements and the NEW and OLD references in a trigger body.
+type triggerChecker struct {
+	stmt    *ast.CreateTriggerStmt
+	tblInfo *model.TableInfo
+	err     error
+}
+
+func (c *triggerChecker) check(node ast.Node, isStmt bool) error {
+	if isStmt {
+		switch x := node.(type) {
+		case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt:
+			return exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+		case *ast.SelectStmt:
+			if x.SelectIntoOpt == nil {
+				return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+			}
+		case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+			return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		case *ast.SetStmt:
+			for _, assign := range x.Variables {
+				if !assign.IsSystem || assign.IsGlobal {
+					continue
+				}
+				if row, col, ok := strings.Cut(strings.ToLower(assign.Name), "."); ok {
+					if err := c.checkRow(row, col, true); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	node.Accept(c)
+	return c.err
+}
+
+func (c *triggerChecker) Enter(n ast.Node) (ast.Node, bool) {
+	if x, ok := n.(*ast.ColumnNameExpr); ok && x.Name.Schema.L == "" {
+		if err := c.checkRow(x.Name.Table.L, x.Name.Name.L, false); err != nil {
+			c.err = err
+			return n, true
+		}
+	}
+	return n, c.err != nil
+}
+
+func (c *triggerChecker) Leave(n ast.Node) (ast.Node, bool) {
+	return n, c.err == nil
+}
+
+// checkRow checks the reference of NEW.col or OLD.col, set means the column is assigned.
+func (c *triggerChecker) checkRow(row, col string, set bool) error {
+	if row != rowNew && row != rowOld {
+		return nil
+	}
+	upper := strings.ToUpper(row)
+	if (row == rowNew && c.stmt.Event == ast.TriggerDelete) || (row == rowOld && c.stmt.Event == ast.TriggerInsert) {
+		return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(upper, "on "+c.stmt.Event.String())
+	}
+	if set {
+		if row == rowOld {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs(upper, "")
+		}
+		if c.stmt.Timing == ast.TriggerAfter {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs(upper, "after ")
+		}
+	}
+	if model.FindColumnInfo(c.tblInfo.Columns, col) == nil {
+		return plannererrors.ErrUnknownColumn.GenWithStackByArgs(col, upper)
+	}
+	return nil
+}
+
+// preparedTrigger is a trigger parsed for the triggering statement.
+type preparedTrigger struct {
+	routine *Routine
+	parser  *parser.Parser
+	body    ast.StmtNode
+	texts   map[ast.Node]string
+}
+
+// Triggers are the triggers of a table activated by an event. They are parsed
+// once and fired for every row changed by the triggering statement.
+type Triggers struct {
+	sctx   sessionctx.Context
+	schema string
+	cols   []*model.ColumnInfo
+	before []*preparedTrigger
+	after  []*preparedTrigger
+	runner StmtRunner
+}
+
+// NewTriggers prepares the triggers of the table for the event, it returns nil
+// if the table has no such trigger. The statements in the trigger bodies are
+// executed by the runner.
+func NewTriggers(sctx sessionctx.Context, schema string, tblInfo *model.TableInfo, event ast.TriggerEvent,
+	runner StmtRunner) (*Triggers, error) {
+	var t *Triggers
+	for _, info := range tblInfo.Triggers {
+		if info.Event != event {
+			continue
+		}
+		if t == nil {
+			t = &Triggers{sctx: sctx, schema: schema, runner: runner}
+			for _, col := range tblInfo.Columns {
+				if col.State == model.StatePublic {
+					t.cols = append(t.cols, col)
+				}
+			}
+		}
+		tr, err := prepareTrigger(sctx, schema, tblInfo.Name.O, info)
+		if err != nil {
+			return nil, err
+		}
+		if info.Timing == ast.TriggerBefore {
+			t.before = append(t.before, tr)
+		} else {
+			t.after = append(t.after, tr)
+		}
+	}
+	return t, nil
+}
+
+func prepareTrigger(sctx sessionctx.Context, schema, table string, info *model.TriggerInfo) (*preparedTrigger, error) {
+	sqlMode, err := mysql.GetSQLMode(info.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	p := parser.New()
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	p.SetSQLMode(sqlMode)
+	node, err := p.ParseOneStmt(TriggerCreateSQL(info, table), info.CharsetClient, info.CollationConnection)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &preparedTrigger{
+		routine: &Routine{
+			Schema:              schema,
+			Name:                info.Name.O,
+			Type:                RoutineTypeTrigger,
+			Definer:             info.Definer,
+			SQLMode:             info.SQLMode,
+			CharsetClient:       info.CharsetClient,
+			CollationConnection: info.CollationConnection,
+			DatabaseCollation:   info.DatabaseCollation,
+		},
+		parser: p,
+		body:   node.(*ast.CreateTriggerStmt).Body,
+		texts:  make(map[ast.Node]string),
+	}, nil
+}
+
+// Has checks whether there are triggers of the action time.
+func (t *Triggers) Has(timing ast.TriggerTiming) bool {
+	if t == nil {
+		return false
+	}
+	if timing == ast.TriggerBefore {
+		return len(t.before) > 0
+	}
+	return len(t.after) > 0
+}
+
+// Fire fires the triggers of the action time for a row. oldRow is nil for
+// INSERT and newRow is nil for DELETE. The values assigned to the NEW row by
+// the BEFORE triggers are written back to newRow.
+func (t *Triggers) Fire(ctx context.Context, timing ast.TriggerTiming, oldRow, newRow []types.Datum) error {
+	triggers := t.after
+	if timing == ast.TriggerBefore {
+		triggers = t.before
+	}
+	if len(triggers) == 0 {
+		return nil
+	}
+	// The trigger runs in the schema of its table.
+	sessVars := t.sctx.GetSessionVars()
+	originDB := sessVars.CurrentDB
+	sessVars.CurrentDB = t.schema
+	defer func() {
+		sessVars.CurrentDB = originDB
+	}()
+	for _, tr := range triggers {
+		if err := t.fire(ctx, tr, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Triggers) fire(ctx context.Context, tr *preparedTrigger, oldRow, newRow []types.Datum) error {
+	it := &interpreter{
+		sctx:        t.sctx,
+		routine:     tr.routine,
+		outerSC:     t.sctx.GetSessionVars().StmtCtx,
+		parser:      tr.parser,
+		texts:       tr.texts,
+		dbCharset:   mysql.DefaultCharset,
+		dbCollation: tr.routine.DatabaseCollation,
+		runner:      t.runner,
+	}
+	if coll, err := charset.GetCollationByName(tr.routine.DatabaseCollation); err == nil {
+		it.dbCharset = coll.CharsetName
+	}
+	root := newScope(nil)
+	bind := func(row string, data []types.Datum) {
+		for _, col := range t.cols {
+			v := &variable{tp: &col.FieldType}
+			data[col.Offset].Copy(&v.value)
+			root.vars[row+"."+col.Name.L] = v
+		}
+	}
+	if oldRow != nil {
+		bind(rowOld, oldRow)
+	}
+	if newRow != nil {
+		bind(rowNew, newRow)
+	}
+	if err := it.execStmt(ctx, root, tr.body); err != nil {
+		return err
+	}
+	if newRow != nil {
+		for _, col := range t.cols {
+			root.vars[rowNew+"."+col.Name.L].value.Copy(&newRow[col.Offset])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package procedure_test
+
+import (
+	"testing"
+
+	mysql "github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndDropTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table log (a int)")
+
+	tk.MustExec("create trigger tr1 after insert on t for each row insert into log values (new.a)")
+	tk.MustGetErrCode("create trigger tr1 after delete on t for each row insert into log values (old.a)", mysql.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 after insert on t for each row begin end")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger tr2 after insert on not_exist for each row begin end", mysql.ErrNoSuchTable)
+	tk.MustGetErrCode("create trigger mysql.tr2 after insert on test.t for each row begin end", mysql.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger tr2 after insert on t for each row follows tr_none begin end", mysql.ErrReferencedTrgDoesNotExist)
+	tk.MustGetErrCode("create trigger tr2 after update on t for each row follows tr1 begin end", mysql.ErrReferencedTrgDoesNotExist)
+	tk.MustExec("create trigger tr0 after insert on t for each row precedes tr1 set @x = 1")
+	tk.MustExec("create view v as select * from t")
+	tk.MustGetErrCode("create trigger tr2 after insert on v for each row begin end", mysql.ErrTrgOnViewOrTempTable)
+
+	tk.MustQuery("show triggers").CheckAt([]int{0, 1, 2, 3, 4}, testkit.RowsWithSep("|",
+		"tr0|INSERT|t|set @x = 1|AFTER",
+		"tr1|INSERT|t|insert into log values (new.a)|AFTER"))
+	tk.MustQuery("show triggers like 'log'").Check(testkit.Rows())
+	tk.MustQuery("show create trigger tr1").CheckAt([]int{0, 2}, testkit.RowsWithSep("|",
+		"tr1|CREATE TRIGGER `tr1` AFTER INSERT ON `t` FOR EACH ROW insert into log values (new.a)"))
+	err := tk.QueryToErr("show create trigger tr_none")
+	require.True(t, dbterror.ErrTrgDoesNotExist.Equal(err))
+	tk.MustQuery("select trigger_name, event_object_table, action_order, action_timing from information_schema.triggers").
+		Check(testkit.Rows("tr0 t 1 AFTER", "tr1 t 2 AFTER"))
+
+	tk.MustExec("drop trigger tr0")
+	tk.MustExec("drop trigger test.tr1")
+	tk.MustGetErrCode("drop trigger tr1", mysql.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustQuery("show triggers").Check(testkit.Rows())
+}
+
+func TestCheckTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set old.a = 1", mysql.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr before delete on t for each row set @x = new.a", mysql.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr before update on t for each row set old.a = 1", mysql.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr after update on t for each row set new.a = 1", mysql.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr before update on t for each row set new.c = 1", mysql.ErrBadField)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row select 1", mysql.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row commit", mysql.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row rollback", mysql.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustQuery("show triggers").Check(testkit.Rows())
+}
+
+func TestFireTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int not null, b int)")
+	tk.MustExec("create table log (op varchar(10), old_a int, new_a int)")
+
+	tk.MustExec("create trigger t_bi before insert on t for each row begin " +
+		"if new.a is null then set new.a = 0; end if; set new.b = new.a * 10; end")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log values ('insert', null, new.a)")
+	tk.MustExec("create trigger t_bu before update on t for each row set new.b = new.a * 100")
+	tk.MustExec("create trigger t_au after update on t for each row insert into log values ('update', old.a, new.a)")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into log values ('delete', old.a, null)")
+
+	// The BEFORE INSERT trigger fixes the null value before it's checked.
+	tk.MustExec("insert into t (id, a) values (1, 1), (2, null)")
+	tk.RequireEqual(uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 10", "2 0 0"))
+	tk.MustExec("update t set a = a + 1 where id = 1")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 2 200", "2 0 0"))
+	tk.MustExec("insert into t (id, a) values (2, 5) on duplicate key update a = 7")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 2 200", "2 7 700"))
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select * from log").Check(testkit.Rows(
+		"insert <nil> 1", "insert <nil> 0", "update 1 2", "update 0 7", "delete 2 <nil>"))
+
+	// The triggers run in the transaction of the triggering statement.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t (id, a) values (3, 3)")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("5"))
+
+	// An error in the trigger fails the triggering statement.
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create trigger t2_bi before insert on t2 for each row insert into t2 values (1)")
+	tk.MustGetErrCode("insert into t2 values (1)", mysql.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+	tk.MustExec("drop trigger t2_bi")
+	tk.MustExec("create trigger t2_bi before insert on t2 for each row insert into not_exist values (1)")
+	tk.MustGetErrCode("insert into t2 values (1)", mysql.ErrNoSuchTable)
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+
+	// The REPLACE statement fires the DELETE triggers for the removed rows.
+	tk.MustExec("truncate table log")
+	tk.MustExec("replace into t (id, a) values (2, 8)")
+	tk.MustQuery("select * from log").Check(testkit.Rows("delete 7 <nil>", "insert <nil> 8"))
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"math"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// LookupTarget is the probe side reader of the hash join which can read the rows of some keys by point ranges, so
+// that the probe side can be switched to look up the build keys like an index join.
+type LookupTarget interface {
+	exec.Executor
+	// LookupKeyColumn returns the offset of the output column which the reader can look up by, or -1 if the reader
+	// can't look up by any column.
+	LookupKeyColumn() int
+	// SetLookupKeys sets the sorted values of the key column to look up, and the reader reads the ranges of the plan
+	// if keys is nil. It must be called before the reader is opened.
+	SetLookupKeys(keys []types.Datum)
+}
+
+// CanLookUpBuildKeys checks whether the build keys can be looked up by the probe side reader. Only the integers with
+// the same signedness are supported, which are encoded to the same key for the same value.
+func CanLookUpBuildKeys(buildKeyType, probeKeyType *types.FieldType) bool {
+	if buildKeyType.EvalType() != types.ETInt || probeKeyType.EvalType() != types.ETInt {
+		return false
+	}
+	if buildKeyType.GetType() == mysql.TypeBit || probeKeyType.GetType() == mysql.TypeBit {
+		return false
+	}
+	return mysql.HasUnsignedFlag(buildKeyType.GetFlag()) == mysql.HasUnsignedFlag(probeKeyType.GetFlag())
+}
+
+// SetCheckpoint sets the checkpoint after the build side, and finds the join key whose build keys can be looked up by
+// the probe side reader. The probe rows which don't match any build key must be skippable to look up the build keys,
+// so the null-aware and null-safe keys are not supported.
+func (e *HashJoinV2Exec) SetCheckpoint(checkpoint *adaptive.Checkpoint, probeEstRows float64, probeKeyColIdx []int, isNullEQ []bool, hasNAKeys bool) {
+	e.Checkpoint = checkpoint
+	e.ProbeEstRows = probeEstRows
+	e.LookupKeyIdx = -1
+	if checkpoint == nil || hasNAKeys || !e.canSkipProbeIfHashTableIsEmpty() {
+		return
+	}
+	target, ok := e.ProbeSideTupleFetcher.ProbeSideExec.(LookupTarget)
+	if !ok {
+		return
+	}
+	keyCol := target.LookupKeyColumn()
+	if keyCol < 0 {
+		return
+	}
+	for i, colIdx := range probeKeyColIdx {
+		if colIdx == keyCol && (i >= len(isNullEQ) || !isNullEQ[i]) && CanLookUpBuildKeys(e.BuildKeyTypes[i], e.ProbeKeyTypes[i]) {
+			e.LookupKeyIdx = i
+			return
+		}
+	}
+}
+
+// canLookUpAtCheckpoint returns whether the probe side may be switched to look up the build keys at the checkpoint.
+func (e *HashJoinV2Exec) canLookUpAtCheckpoint() bool {
+	return e.Checkpoint != nil && e.LookupKeyIdx >= 0
+}
+
+// canReduceConcurrencyAtCheckpoint returns whether the probe workers may be reduced at the checkpoint.
+func (e *HashJoinV2Exec) canReduceConcurrencyAtCheckpoint() bool {
+	return e.Checkpoint != nil && e.Concurrency > 1
+}
+
+// openProbeSideAfterBuild returns whether the probe side is opened after the build side is finished, so that it can
+// be adjusted by the build side.
+func (e *HashJoinV2Exec) openProbeSideAfterBuild() bool {
+	return len(e.RuntimeFilters) > 0 || e.canLookUpAtCheckpoint() || e.canReduceConcurrencyAtCheckpoint()
+}
+
+func (e *HashJoinV2Exec) initCheckpoint() {
+	if e.Checkpoint == nil {
+		return
+	}
+	e.checkpointReached = false
+	e.checkpointDecision = adaptive.DecisionNone
+	for _, worker := range e.BuildWorkers {
+		worker.buildRows = 0
+		worker.lookupKeyCollector = nil
+		if e.canLookUpAtCheckpoint() {
+			rf := &RuntimeFilter{Types: []variable.RuntimeFilterType{variable.In}, KeyIdx: e.LookupKeyIdx}
+			worker.lookupKeyCollector = newRuntimeFilterCollector(rf, worker.BuildKeyColIdx[e.LookupKeyIdx],
+				e.BuildKeyTypes[e.LookupKeyIdx], e.ProbeKeyTypes[e.LookupKeyIdx])
+		}
+	}
+}
+
+// reachCheckpoint records the build rows after the build side of the first round is finished.
+func (e *HashJoinV2Exec) reachCheckpoint() {
+	if e.Checkpoint == nil {
+		return
+	}
+	e.checkpointBuildRows = 0
+	for _, worker := range e.BuildWorkers {
+		e.checkpointBuildRows += worker.buildRows
+	}
+	e.checkpointReached = true
+}
+
+// lookUpBuildKeysAtCheckpoint switches the probe side reader to look up the build keys if there are much less build
+// rows than estimated, because the plan would have been an index join with the right estimation.
+func (e *HashJoinV2Exec) lookUpBuildKeysAtCheckpoint() {
+	target := e.ProbeSideTupleFetcher.ProbeSideExec.(LookupTarget)
+	typeCtx := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+	collector := e.BuildWorkers[0].lookupKeyCollector
+	for _, worker := range e.BuildWorkers[1:] {
+		collector.merge(typeCtx, worker.lookupKeyCollector)
+		worker.lookupKeyCollector = nil
+	}
+	e.BuildWorkers[0].lookupKeyCollector = nil
+	if !e.checkpointReached || !e.Checkpoint.Overestimated(e.checkpointBuildRows) ||
+		len(collector.values) == 0 || float64(len(collector.values)) >= e.ProbeEstRows {
+		target.SetLookupKeys(nil)
+		return
+	}
+	keys := make([]types.Datum, 0, len(collector.values))
+	for _, value := range collector.values {
+		keys = append(keys, value)
+	}
+	binCollator := collate.GetBinaryCollator()
+	slices.SortFunc(keys, func(a, b types.Datum) int {
+		cmp, _ := a.Compare(typeCtx, &b, binCollator)
+		return cmp
+	})
+	target.SetLookupKeys(keys)
+	e.checkpointDecision = adaptive.DecisionIndexLookup
+}
+
+// reduceConcurrencyAtCheckpoint retires some probe workers if there are much less build rows than estimated and the
+// probe side is not switched to look up the build keys. The concurrency of the plan is for the estimated build rows,
+// with the right estimation the join produces less rows, and the workers mostly wait for the probe side reader while
+// each of them holds the probe and join result chunks.
+func (e *HashJoinV2Exec) reduceConcurrencyAtCheckpoint() {
+	if !e.checkpointReached || e.checkpointDecision != adaptive.DecisionNone || !e.Checkpoint.Overestimated(e.checkpointBuildRows) {
+		return
+	}
+	ratio := float64(max(e.checkpointBuildRows, 1)) / e.Checkpoint.EstRows
+	workers := max(uint(math.Ceil(float64(e.Concurrency)*ratio)), 1)
+	if workers >= e.Concurrency {
+		return
+	}
+	e.ProbeSideTupleFetcher.retireProbeWorkers(int(workers))
+	e.checkpointDecision = adaptive.DecisionReduceConcurrency
+}
+
+func (e *HashJoinV2Exec) registerCheckpointStats() {
+	if e.Checkpoint == nil || !e.checkpointReached || e.RuntimeStats() == nil {
+		return
+	}
+	e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(),
+		e.Checkpoint.NewRuntimeStats(e.checkpointBuildRows, e.checkpointDecision))
+}
@@ -16,6 +16,7 @@ package join
 
 import (
 	"context"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -64,6 +65,9 @@ type probeSideTupleFetcherBase struct {
 	probeResultChs     []chan *chunk.Chunk
 	requiredRows       int64
 	joinResultChannel  chan *hashjoinWorkerResult
+	// runtimeBloomFilters filter the probe side chunks before probing, they are only used by hash join v2.
+	runtimeBloomFilters []*runtimeBloomFilter
+	runtimeFilterStats  *runtimeFilterStats
 }
 
 func (fetcher *probeSideTupleFetcherBase) initializeForProbeBase(concurrency uint, joinResultChannel chan *hashjoinWorkerResult) {
@@ -95,6 +99,27 @@ func (fetcher *probeSideTupleFetcherBase) handleProbeSideFetcherPanic(r any) {
 	}
 }
 
+// retireProbeWorkers closes the channels of the probe workers from the workers-th one, so that the probe chunks are
+// only sent to the first workers, and the others finish without probing. It must be called before the probe side is
+// fetched, when all the probe chunk resources are in probeChkResourceCh.
+func (fetcher *probeSideTupleFetcherBase) retireProbeWorkers(workers int) {
+	retired := fetcher.probeResultChs[workers:]
+	kept := make([]*probeChkResource, 0, workers)
+	for range fetcher.probeResultChs {
+		resource := <-fetcher.probeChkResourceCh
+		if !slices.ContainsFunc(retired, func(ch chan *chunk.Chunk) bool { return ch == resource.dest }) {
+			kept = append(kept, resource)
+		}
+	}
+	for _, resource := range kept {
+		fetcher.probeChkResourceCh <- resource
+	}
+	for _, ch := range retired {
+		close(ch)
+	}
+	fetcher.probeResultChs = fetcher.probeResultChs[:workers]
+}
+
 type isBuildSideEmpty func() bool
 type isSpillTriggered func() bool
 
@@ -165,7 +190,7 @@ func (fetcher *probeSideTupleFetcherBase) fetchProbeSideChunks(ctx context.Conte
 			return
 		}
 		probeSideResult := probeSideResource.chk
-		err := exec.Next(ctx, fetcher.ProbeSideExec, probeSideResult)
+		err := fetcher.nextProbeSideChunk(ctx, hashJoinCtx.SessCtx.GetSessionVars().StmtCtx.TypeCtx(), probeSideResult)
 		failpoint.Inject("ConsumeRandomPanic", nil)
 		if err != nil {
 			hashJoinCtx.joinResultCh <- &hashjoinWorkerResult{
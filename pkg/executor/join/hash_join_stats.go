@@ -163,6 +163,8 @@ type hashJoinRuntimeStatsV2 struct {
 
 	spill spillStats
 
+	runtimeFilter runtimeFilterStats
+
 	isHashJoinGA bool
 }
 
@@ -194,6 +196,7 @@ func (e *hashJoinRuntimeStatsV2) reset() {
 	e.maxBuildHashTableForCurrentRound = 0
 	e.maxProbeForCurrentRound = 0
 	e.maxWorkerFetchAndProbeForCurrentRound = 0
+	e.runtimeFilter = runtimeFilterStats{}
 }
 
 func (e *hashJoinRuntimeStatsV2) resetCurrentRound() {
@@ -288,6 +291,10 @@ func (e *hashJoinRuntimeStatsV2) String() string {
 		writeBytesStatsToString(buf, e.spill.spillBuildHashTableBytesPerRound)
 		buf.WriteString("}")
 	}
+	if len(e.runtimeFilter.filters) > 0 {
+		buf.WriteString(", ")
+		buf.WriteString(e.runtimeFilter.String())
+	}
 	return buf.String()
 }
 
@@ -308,6 +315,11 @@ func (e *hashJoinRuntimeStatsV2) Clone() execdetails.RuntimeStats {
 		maxBuildHashTableForCurrentRound:      e.maxBuildHashTableForCurrentRound,
 		maxProbeForCurrentRound:               e.maxProbeForCurrentRound,
 		maxWorkerFetchAndProbeForCurrentRound: e.maxWorkerFetchAndProbeForCurrentRound,
+		runtimeFilter: runtimeFilterStats{
+			filters:            e.runtimeFilter.filters,
+			pushedFilteredRows: e.runtimeFilter.pushedFilteredRows,
+			bloomFilteredRows:  atomic.LoadInt64(&e.runtimeFilter.bloomFilteredRows),
+		},
 	}
 }
 
@@ -331,4 +343,9 @@ func (e *hashJoinRuntimeStatsV2) Merge(rs execdetails.RuntimeStats) {
 	if e.maxWorkerFetchAndProbe < tmp.maxWorkerFetchAndProbe {
 		e.maxWorkerFetchAndProbe = tmp.maxWorkerFetchAndProbe
 	}
+	if len(e.runtimeFilter.filters) == 0 {
+		e.runtimeFilter.filters = tmp.runtimeFilter.filters
+	}
+	e.runtimeFilter.pushedFilteredRows += tmp.runtimeFilter.pushedFilteredRows
+	e.runtimeFilter.bloomFilteredRows += tmp.runtimeFilter.bloomFilteredRows
 }
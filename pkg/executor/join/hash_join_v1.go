@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/executor/aggregate"
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
 	"github.com/pingcap/tidb/pkg/executor/internal/applycache"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/unionexec"
@@ -1150,6 +1151,13 @@ type NestedLoopApplyExec struct {
 
 	Outer bool
 
+	// Checkpoint compares the outer rows with the estimated ones, and enables the cache if there are much more outer
+	// rows than estimated. The cache is kept in the later executions of the statement once it's enabled. It's nil if
+	// the adaptive execution is disabled.
+	Checkpoint         *adaptive.Checkpoint
+	outerRows          int64
+	checkpointDecision adaptive.Decision
+
 	memTracker *memory.Tracker // track memory usage.
 }
 
@@ -1170,6 +1178,9 @@ func (e *NestedLoopApplyExec) Close() error {
 		}
 		runtimeStats.SetConcurrencyInfo(execdetails.NewConcurrencyInfo("concurrency", 0))
 		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), runtimeStats)
+		if e.Checkpoint != nil {
+			e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.Checkpoint.NewRuntimeStats(e.outerRows, e.checkpointDecision))
+		}
 	}
 	return exec.Close(e.OuterExec)
 }
@@ -1182,6 +1193,8 @@ func (e *NestedLoopApplyExec) Open(ctx context.Context) error {
 	}
 	e.cursor = 0
 	e.innerRows = e.innerRows[:0]
+	e.outerRows = 0
+	e.checkpointDecision = adaptive.DecisionNone
 	e.OuterChunk = exec.TryNewCacheChunk(e.OuterExec)
 	e.InnerChunk = exec.TryNewCacheChunk(e.InnerExec)
 	e.InnerList = chunk.NewList(exec.RetTypes(e.InnerExec), e.InitCap(), e.MaxChunkSize())
@@ -1248,6 +1261,10 @@ func (e *NestedLoopApplyExec) fetchSelectedOuterRow(ctx context.Context, chk *ch
 			if e.OuterChunk.NumRows() == 0 {
 				return nil, nil
 			}
+			e.outerRows += int64(e.OuterChunk.NumRows())
+			if err = e.checkOuterRows(); err != nil {
+				return nil, err
+			}
 			e.outerSelected, err = expression.VectorizedFilter(e.Sctx.GetExprCtx().GetEvalCtx(), e.Sctx.GetSessionVars().EnableVectorizedExpression, e.OuterFilter, outerIter, e.outerSelected)
 			if err != nil {
 				return nil, err
@@ -1275,6 +1292,25 @@ func (e *NestedLoopApplyExec) fetchSelectedOuterRow(ctx context.Context, chk *ch
 	}
 }
 
+// checkOuterRows enables the cache once there are much more outer rows than estimated, because the optimizer disables
+// the cache when it expects few outer rows to share the same correlated values.
+func (e *NestedLoopApplyExec) checkOuterRows() (err error) {
+	if e.Checkpoint == nil || e.CanUseCache || !e.Checkpoint.Underestimated(e.outerRows) ||
+		e.Sctx.GetSessionVars().MemQuotaApplyCache <= 0 {
+		return nil
+	}
+	e.cache, err = applycache.NewApplyCache(e.Sctx)
+	if err != nil {
+		return err
+	}
+	e.cacheHitCounter = 0
+	e.cacheAccessCounter = 0
+	e.cache.GetMemTracker().AttachTo(e.memTracker)
+	e.CanUseCache = true
+	e.checkpointDecision = adaptive.DecisionEnableApplyCache
+	return nil
+}
+
 // fetchAllInners reads all data from the inner table and stores them in a List.
 func (e *NestedLoopApplyExec) fetchAllInners(ctx context.Context) error {
 	err := exec.Open(ctx, e.InnerExec)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/executor/internal/adaptive"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/executor/join/joinversion"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -426,6 +427,11 @@ type BuildWorkerV2 struct {
 	WorkerID       uint
 	builder        *rowTableBuilder
 	restoredChkBuf *chunk.Chunk
+
+	runtimeFilterCollectors []*runtimeFilterCollector
+	// buildRows and lookupKeyCollector are used by the adaptive execution checkpoint after the build side.
+	buildRows          int64
+	lookupKeyCollector *runtimeFilterCollector
 }
 
 func (b *BuildWorkerV2) getSegmentsInRowTable(partID int) []*rowTableSegment {
@@ -548,6 +554,17 @@ func (b *BuildWorkerV2) splitPartitionAndAppendToRowTable(typeCtx types.Context,
 
 func (b *BuildWorkerV2) processOneChunk(typeCtx types.Context, chk *chunk.Chunk, cost *int64) error {
 	start := time.Now()
+	for _, collector := range b.runtimeFilterCollectors {
+		if err := collector.collect(typeCtx, chk, b.HashJoinCtx.memTracker); err != nil {
+			return err
+		}
+	}
+	b.buildRows += int64(chk.NumRows())
+	if b.lookupKeyCollector != nil {
+		if err := b.lookupKeyCollector.collect(typeCtx, chk, b.HashJoinCtx.memTracker); err != nil {
+			return err
+		}
+	}
 	err := b.builder.processOneChunk(chk, typeCtx, b.HashJoinCtx, int(b.WorkerID))
 	failpoint.Inject("splitPartitionPanic", nil)
 	*cost += int64(time.Since(start))
@@ -637,6 +654,23 @@ type HashJoinV2Exec struct {
 
 	IsGA bool
 
+	// RuntimeFilters are built from the build side and pushed down to the probe side reader, which is opened after
+	// the build side is finished.
+	RuntimeFilters []*RuntimeFilter
+
+	// Checkpoint compares the build rows with the estimated ones after the build side is finished, it's nil if the
+	// adaptive execution is disabled.
+	Checkpoint *adaptive.Checkpoint
+	// LookupKeyIdx is the offset of the join key whose build keys can be looked up by the probe side reader at the
+	// checkpoint, it's -1 if the probe side can't be switched to look up the build keys.
+	LookupKeyIdx int
+	// ProbeEstRows is the estimated row count of the probe side.
+	ProbeEstRows float64
+
+	checkpointReached   bool
+	checkpointBuildRows int64
+	checkpointDecision  adaptive.Decision
+
 	isMemoryClearedForTest bool
 }
 
@@ -685,6 +719,7 @@ func (e *HashJoinV2Exec) Close() error {
 	for _, w := range e.ProbeWorkers {
 		w.joinChkResourceCh = nil
 	}
+	e.ProbeSideTupleFetcher.runtimeBloomFilters = nil
 
 	if e.stats != nil {
 		defer e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(e.ID(), e.stats)
@@ -694,12 +729,22 @@ func (e *HashJoinV2Exec) Close() error {
 		e.spillHelper.close()
 	}
 	err := e.BaseExecutor.Close()
+	// The runtime stats of the probe side reader are collected after it's closed.
+	e.collectRuntimeFilterStats()
+	e.registerCheckpointStats()
 	return err
 }
 
 // Open implements the Executor Open interface.
 func (e *HashJoinV2Exec) Open(ctx context.Context) error {
-	if err := e.BaseExecutor.Open(ctx); err != nil {
+	var err error
+	if e.openProbeSideAfterBuild() {
+		// The probe side is opened after it's adjusted by the build side.
+		err = exec.Open(ctx, e.BuildWorkers[0].BuildSideExec)
+	} else {
+		err = e.BaseExecutor.Open(ctx)
+	}
+	if err != nil {
 		e.closeCh = nil
 		e.prepared = false
 		return err
@@ -798,6 +843,28 @@ func (e *HashJoinV2Exec) startProbeFetcher(ctx context.Context) {
 	if !e.inRestore {
 		fetchProbeSideChunksFunc := func() {
 			defer trace.StartRegion(ctx, "HashJoinProbeSideFetcher").End()
+			if e.openProbeSideAfterBuild() {
+				// The runtime filters and the checkpoint can only be applied after the build side is finished.
+				skipProbe := wait4BuildSide(
+					func() bool { return e.ProbeSideTupleFetcher.hashTableContext.hashTable.isHashTableEmpty() },
+					func() bool { return e.spillHelper.isSpillTriggered() },
+					e.ProbeSideTupleFetcher.canSkipProbeIfHashTableIsEmpty,
+					e.ProbeSideTupleFetcher.needScanRowTableAfterProbeDone,
+					&e.ProbeSideTupleFetcher.hashJoinCtxBase)
+				if skipProbe {
+					return
+				}
+				if e.canLookUpAtCheckpoint() {
+					e.lookUpBuildKeysAtCheckpoint()
+				}
+				if e.canReduceConcurrencyAtCheckpoint() {
+					e.reduceConcurrencyAtCheckpoint()
+				}
+				if err := e.openProbeSideWithRuntimeFilters(ctx); err != nil {
+					e.joinResultCh <- &hashjoinWorkerResult{err: err}
+					return
+				}
+			}
 			e.ProbeSideTupleFetcher.fetchProbeSideChunks(
 				ctx,
 				e.MaxChunkSize(),
@@ -1294,6 +1361,10 @@ func (e *HashJoinV2Exec) fetchAndBuildHashTableImpl(ctx context.Context) {
 	doneCh := make(chan struct{}, e.Concurrency)
 	// init builder, todo maybe the builder can be reused during the whole life cycle of the executor
 	hashJoinCtx := e.HashJoinCtxV2
+	if !e.inRestore {
+		e.initRuntimeFilterCollectors()
+		e.initCheckpoint()
+	}
 	for _, worker := range e.BuildWorkers {
 		worker.builder = createRowTableBuilder(worker.BuildKeyColIdx, hashJoinCtx.BuildKeyTypes, hashJoinCtx.partitionNumber, worker.HasNullableKey, hashJoinCtx.BuildFilter != nil, hashJoinCtx.needScanRowTableAfterProbeDone)
 	}
@@ -1303,6 +1374,9 @@ func (e *HashJoinV2Exec) fetchAndBuildHashTableImpl(ctx context.Context) {
 	if !success {
 		return
 	}
+	if !e.inRestore {
+		e.reachCheckpoint()
+	}
 
 	if e.spillHelper.spillTriggered {
 		e.spillHelper.spillTriggedInBuildingStageForTest = true
@@ -0,0 +1,157 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
+	"go.uber.org/zap"
+)
+
+// indexJoinSpillAction implements memory.ActionOnExceed for IndexLookUpJoin
+// and IndexNestedLoopHashJoin.
+//
+// The inner results of the running tasks are buffered in chunk.RowContainer.
+// When the memory quota of the query is exceeded, the action spills these
+// containers to disk, and halves the batch size of the outer worker so that
+// the following tasks buffer fewer outer rows. The fallback action is only
+// triggered when there is nothing left to spill or to shrink.
+type indexJoinSpillAction struct {
+	memory.BaseOOMAction
+
+	// batchSizeLimit is the upper bound of the batch size of the outer worker.
+	batchSizeLimit atomic.Int64
+	minBatchSize   int64
+
+	mu struct {
+		sync.Mutex
+		// containers records the inner results which haven't been released,
+		// and whether they have been asked to spill.
+		containers map[*chunk.RowContainer]bool
+	}
+}
+
+func newIndexJoinSpillAction(maxBatchSize, minBatchSize int) *indexJoinSpillAction {
+	a := &indexJoinSpillAction{minBatchSize: int64(min(minBatchSize, maxBatchSize))}
+	a.batchSizeLimit.Store(int64(maxBatchSize))
+	a.mu.containers = make(map[*chunk.RowContainer]bool)
+	return a
+}
+
+// GetPriority get the priority of the Action.
+func (*indexJoinSpillAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *indexJoinSpillAction) Action(t *memory.Tracker) {
+	spilled := false
+	a.mu.Lock()
+	for rc, triggered := range a.mu.containers {
+		if triggered {
+			continue
+		}
+		// The container spills itself asynchronously, so it's safe to trigger
+		// it when the memory is consumed by the worker which is filling it.
+		rc.ActionSpill().Action(t)
+		a.mu.containers[rc] = true
+		spilled = true
+	}
+	a.mu.Unlock()
+	shrunk := a.shrinkBatchSize()
+	if shrunk {
+		logutil.BgLogger().Info("memory exceeds quota, shrink the batch size of index join.",
+			zap.Int64("consumed", t.BytesConsumed()), zap.Int64("quota", t.GetBytesLimit()),
+			zap.Int64("batchSize", a.batchSizeLimit.Load()))
+	}
+	if !spilled && !shrunk && t.CheckExceed() {
+		a.TriggerFallBackAction(t)
+	}
+}
+
+// shrinkBatchSize halves the batch size limit of the outer worker, it returns
+// false if the limit has already reached the minimum.
+func (a *indexJoinSpillAction) shrinkBatchSize() bool {
+	for {
+		limit := a.batchSizeLimit.Load()
+		if limit <= a.minBatchSize {
+			return false
+		}
+		if a.batchSizeLimit.CompareAndSwap(limit, max(limit/2, a.minBatchSize)) {
+			return true
+		}
+	}
+}
+
+// maxBatchSize returns the current upper bound of the batch size of the outer worker.
+func (a *indexJoinSpillAction) maxBatchSize() int {
+	return int(a.batchSizeLimit.Load())
+}
+
+// newInnerResult creates a RowContainer to buffer the inner rows of a task.
+// It must be released by releaseInnerResult after the task is processed.
+func (a *indexJoinSpillAction) newInnerResult(fieldTypes []*types.FieldType, chunkSize int,
+	memTracker *memory.Tracker, diskTracker *disk.Tracker) *chunk.RowContainer {
+	rc := chunk.NewRowContainer(fieldTypes, chunkSize)
+	rc.GetMemTracker().AttachTo(memTracker)
+	rc.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
+	rc.GetDiskTracker().AttachTo(diskTracker)
+	rc.GetDiskTracker().SetLabel(memory.LabelForBuildSideResult)
+	// Initialize the spill action of the container, which prevents it from
+	// spilling after it's closed.
+	rc.ActionSpill()
+	a.mu.Lock()
+	a.mu.containers[rc] = false
+	a.mu.Unlock()
+	return rc
+}
+
+// releaseInnerResult closes the RowContainer created by newInnerResult.
+func (a *indexJoinSpillAction) releaseInnerResult(rc *chunk.RowContainer) error {
+	if rc == nil {
+		return nil
+	}
+	a.mu.Lock()
+	_, ok := a.mu.containers[rc]
+	delete(a.mu.containers, rc)
+	a.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return rc.Close()
+}
+
+// close releases all the inner results which haven't been released, e.g.
+// the ones of the tasks abandoned when the executor is closed early.
+func (a *indexJoinSpillAction) close() error {
+	a.SetFinished()
+	a.mu.Lock()
+	containers := a.mu.containers
+	a.mu.containers = make(map[*chunk.RowContainer]bool)
+	a.mu.Unlock()
+	var firstErr error
+	for rc := range containers {
+		if err := rc.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
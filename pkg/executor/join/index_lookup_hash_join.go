@@ -142,6 +142,7 @@ func (e *IndexNestedLoopHashJoin) Open(ctx context.Context) error {
 		e.memTracker = memory.NewTracker(e.ID(), -1)
 	}
 	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	e.openSpill()
 	e.cancelFunc = nil
 	e.innerPtrBytes = make([][]byte, 0, 8)
 	if e.RuntimeStats() != nil {
@@ -355,6 +356,11 @@ func (e *IndexNestedLoopHashJoin) Close() error {
 		close(e.joinChkResourceCh[i])
 	}
 	e.joinChkResourceCh = nil
+	if e.spillAction != nil {
+		// The inner workers may be still running, each of them releases the
+		// inner results of its own tasks.
+		e.spillAction.SetFinished()
+	}
 	e.Finished.Store(false)
 	e.prepared = false
 	e.ctxWithCancel = nil
@@ -668,6 +674,10 @@ func (iw *indexHashJoinInnerWorker) handleHashJoinInnerWorkerPanic(resultCh chan
 func (iw *indexHashJoinInnerWorker) handleTask(ctx context.Context, task *indexHashJoinTask, joinResult *indexHashJoinResult, h hash.Hash64, resultCh chan *indexHashJoinResult) (err error) {
 	defer func() {
 		iw.memTracker.Consume(-iw.memTracker.BytesConsumed())
+		if releaseErr := iw.lookup.spillAction.releaseInnerResult(task.innerResult); releaseErr != nil && err == nil {
+			err = releaseErr
+		}
+		task.innerResult = nil
 		if task.keepOuterOrder {
 			if err != nil {
 				joinResult.err = err
@@ -735,13 +745,16 @@ func (iw *indexHashJoinInnerWorker) handleTask(ctx context.Context, task *indexH
 
 func (iw *indexHashJoinInnerWorker) doJoinUnordered(ctx context.Context, task *indexHashJoinTask, joinResult *indexHashJoinResult, h hash.Hash64, resultCh chan *indexHashJoinResult) error {
 	var ok bool
-	iter := chunk.NewIterator4List(task.innerResult)
+	iter := chunk.NewIterator4RowContainer(task.innerResult)
 	for row := iter.Begin(); row != iter.End(); row = iter.Next() {
 		ok, joinResult = iw.joinMatchedInnerRow2Chunk(ctx, row, task, joinResult, h, iw.joinKeyBuf)
 		if !ok {
 			return joinResult.err
 		}
 	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
 	for chkIdx, outerRowStatus := range task.outerRowStatus {
 		chk := task.outerResult.GetChunk(chkIdx)
 		for rowIdx, val := range outerRowStatus {
@@ -871,7 +884,12 @@ func (iw *indexHashJoinInnerWorker) doJoinInOrder(ctx context.Context, task *ind
 		}
 	}()
 	for i, numChunks := 0, task.innerResult.NumChunks(); i < numChunks; i++ {
-		for j, chk := 0, task.innerResult.GetChunk(i); j < chk.NumRows(); j++ {
+		var chk *chunk.Chunk
+		chk, err = task.innerResult.GetChunk(i)
+		if err != nil {
+			return err
+		}
+		for j := 0; j < chk.NumRows(); j++ {
 			row := chk.GetRow(j)
 			ptr := chunk.RowPtr{ChkIdx: uint32(i), RowIdx: uint32(j)}
 			err = iw.collectMatchedInnerPtrs4OuterRows(row, ptr, task, h, iw.joinKeyBuf)
@@ -891,7 +909,12 @@ func (iw *indexHashJoinInnerWorker) doJoinInOrder(ctx context.Context, task *ind
 			matchedInnerRows, hasMatched, hasNull = matchedInnerRows[:0], false, false
 			outerRow := task.outerResult.GetChunk(chkIdx).GetRow(outerRowIdx)
 			for _, ptr := range innerRowPtrs {
-				matchedInnerRows = append(matchedInnerRows, task.innerResult.GetRow(ptr))
+				var innerRow chunk.Row
+				innerRow, err = task.innerResult.GetRow(ptr)
+				if err != nil {
+					return err
+				}
+				matchedInnerRows = append(matchedInnerRows, innerRow)
 			}
 			iw.rowIter.Reset(matchedInnerRows)
 			iter := iw.rowIter
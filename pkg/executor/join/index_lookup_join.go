@@ -33,11 +33,13 @@ import (
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
@@ -82,7 +84,11 @@ type IndexLookUpJoin struct {
 	// LastColHelper store the information for last col if there's complicated filter like col > x_col and col < x_col + 100.
 	LastColHelper *plannercore.ColWithCmpFuncManager
 
-	memTracker *memory.Tracker // track memory usage.
+	memTracker  *memory.Tracker // track memory usage.
+	diskTracker *disk.Tracker   // track disk usage.
+	// spillAction buffers the inner results of the tasks, it spills them to disk
+	// and shrinks the outer batches when the memory quota is exceeded.
+	spillAction *indexJoinSpillAction
 
 	stats    *indexLookUpJoinRuntimeStats
 	Finished *atomic.Value
@@ -123,7 +129,7 @@ type lookUpJoinTask struct {
 	outerResult *chunk.List
 	outerMatch  [][]bool
 
-	innerResult       *chunk.List
+	innerResult       *chunk.RowContainer
 	encodedLookUpKeys []*chunk.Chunk
 	lookupMap         *mvmap.MVMap
 	matchedInners     []chunk.Row
@@ -177,6 +183,7 @@ func (e *IndexLookUpJoin) Open(ctx context.Context) error {
 	}
 	e.memTracker = memory.NewTracker(e.ID(), -1)
 	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	e.openSpill()
 	e.innerPtrBytes = make([][]byte, 0, 8)
 	e.Finished.Store(false)
 	if e.RuntimeStats() != nil {
@@ -186,6 +193,20 @@ func (e *IndexLookUpJoin) Open(ctx context.Context) error {
 	return nil
 }
 
+// openSpill creates the disk tracker and the spill action of the executor.
+func (e *IndexLookUpJoin) openSpill() {
+	if e.diskTracker != nil {
+		e.diskTracker.Reset()
+	} else {
+		e.diskTracker = disk.NewTracker(e.ID(), -1)
+	}
+	e.diskTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.DiskTracker)
+	e.spillAction = newIndexJoinSpillAction(e.Ctx().GetSessionVars().IndexJoinBatchSize, e.MaxChunkSize())
+	if vardef.EnableTmpStorageOnOOM.Load() {
+		e.Ctx().GetSessionVars().MemTracker.FallbackOldAndSetNewAction(e.spillAction)
+	}
+}
+
 func (e *IndexLookUpJoin) startWorkers(ctx context.Context, initBatchSize int) {
 	concurrency := e.Ctx().GetSessionVars().IndexLookupJoinConcurrency()
 	if e.stats != nil {
@@ -293,7 +314,9 @@ func (e *IndexLookUpJoin) Next(ctx context.Context, req *chunk.Chunk) error {
 		}
 		startTime := time.Now()
 		if e.innerIter == nil || e.innerIter.Current() == e.innerIter.End() {
-			e.lookUpMatchedInners(task, task.cursor)
+			if err = e.lookUpMatchedInners(task, task.cursor); err != nil {
+				return err
+			}
 			if e.innerIter == nil {
 				e.innerIter = chunk.NewIterator4Slice(task.matchedInners)
 			}
@@ -340,6 +363,9 @@ func (e *IndexLookUpJoin) getFinishedTask(ctx context.Context) (*lookUpJoinTask,
 	// The previous task has been processed, so release the occupied memory
 	if task != nil {
 		task.memTracker.Detach()
+		if err := e.spillAction.releaseInnerResult(task.innerResult); err != nil {
+			return nil, err
+		}
 	}
 	select {
 	case task = <-e.resultCh:
@@ -363,16 +389,20 @@ func (e *IndexLookUpJoin) getFinishedTask(ctx context.Context) (*lookUpJoinTask,
 	return task, nil
 }
 
-func (e *IndexLookUpJoin) lookUpMatchedInners(task *lookUpJoinTask, rowPtr chunk.RowPtr) {
+func (e *IndexLookUpJoin) lookUpMatchedInners(task *lookUpJoinTask, rowPtr chunk.RowPtr) error {
 	outerKey := task.encodedLookUpKeys[rowPtr.ChkIdx].GetRow(int(rowPtr.RowIdx)).GetBytes(0)
 	e.innerPtrBytes = task.lookupMap.Get(outerKey, e.innerPtrBytes[:0])
 	task.matchedInners = task.matchedInners[:0]
 
 	for _, b := range e.innerPtrBytes {
 		ptr := *(*chunk.RowPtr)(unsafe.Pointer(&b[0]))
-		matchedInner := task.innerResult.GetRow(ptr)
+		matchedInner, err := task.innerResult.GetRow(ptr)
+		if err != nil {
+			return err
+		}
 		task.matchedInners = append(task.matchedInners, matchedInner)
 	}
+	return nil
 }
 
 func (ow *outerWorker) run(ctx context.Context, wg *sync.WaitGroup) {
@@ -495,11 +525,13 @@ func (ow *outerWorker) buildTask(ctx context.Context) (*lookUpJoinTask, error) {
 }
 
 func (ow *outerWorker) increaseBatchSize() {
-	if ow.batchSize < ow.maxBatchSize {
+	// The spill action shrinks the max batch size when the memory quota is exceeded.
+	maxBatchSize := min(ow.maxBatchSize, ow.lookup.spillAction.maxBatchSize())
+	if ow.batchSize < maxBatchSize {
 		ow.batchSize *= 2
 	}
-	if ow.batchSize > ow.maxBatchSize {
-		ow.batchSize = ow.maxBatchSize
+	if ow.batchSize > maxBatchSize {
+		ow.batchSize = maxBatchSize
 	}
 }
 
@@ -725,9 +757,8 @@ func (iw *innerWorker) fetchInnerResults(ctx context.Context, task *lookUpJoinTa
 		return err
 	}
 
-	innerResult := chunk.NewList(exec.RetTypes(innerExec), iw.ctx.GetSessionVars().MaxChunkSize, iw.ctx.GetSessionVars().MaxChunkSize)
-	innerResult.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
-	innerResult.GetMemTracker().AttachTo(task.memTracker)
+	innerResult := iw.lookup.spillAction.newInnerResult(exec.RetTypes(innerExec), iw.ctx.GetSessionVars().MaxChunkSize, task.memTracker, iw.lookup.diskTracker)
+	task.innerResult = innerResult
 	for {
 		select {
 		case <-ctx.Done():
@@ -742,10 +773,11 @@ func (iw *innerWorker) fetchInnerResults(ctx context.Context, task *lookUpJoinTa
 		if iw.executorChk.NumRows() == 0 {
 			break
 		}
-		innerResult.Add(iw.executorChk)
+		if err = innerResult.Add(iw.executorChk); err != nil {
+			return err
+		}
 		iw.executorChk = exec.TryNewCacheChunk(innerExec)
 	}
-	task.innerResult = innerResult
 	return nil
 }
 
@@ -759,7 +791,10 @@ func (iw *innerWorker) buildLookUpMap(task *lookUpJoinTask) error {
 	keyBuf := make([]byte, 0, 64)
 	valBuf := make([]byte, 8)
 	for i := 0; i < task.innerResult.NumChunks(); i++ {
-		chk := task.innerResult.GetChunk(i)
+		chk, err := task.innerResult.GetChunk(i)
+		if err != nil {
+			return err
+		}
 		for j := 0; j < chk.NumRows(); j++ {
 			innerRow := chk.GetRow(j)
 			if iw.hasNullInJoinKey(innerRow) {
@@ -802,11 +837,18 @@ func (e *IndexLookUpJoin) Close() error {
 		e.cancelFunc()
 	}
 	e.WorkerWg.Wait()
+	var spillErr error
+	if e.spillAction != nil {
+		spillErr = e.spillAction.close()
+	}
 	e.memTracker = nil
 	e.task = nil
 	e.Finished.Store(false)
 	e.prepared = false
-	return e.BaseExecutor.Close()
+	if err := e.BaseExecutor.Close(); err != nil {
+		return err
+	}
+	return spillErr
 }
 
 type indexLookUpJoinRuntimeStats struct {
@@ -201,3 +201,35 @@ func TestIssue54055(t *testing.T) {
 	require.NotNil(t, err)
 	rs.Close()
 }
+
+func TestIndexLookupJoinSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1 (a int, b varchar(255))")
+	tk.MustExec("create table t2 (a int, b varchar(255), index(a))")
+	tk.MustExec("set @@cte_max_recursion_depth = 3000")
+	tk.MustExec("insert into t1 with recursive cte(a) as (select 1 union select a + 1 from cte where a < 300) " +
+		"select a mod 10, lpad(a, 64, 'x') from cte")
+	// Every outer row matches 300 inner rows, so the inner results are much larger than the outer batches.
+	tk.MustExec("insert into t2 with recursive cte(a) as (select 1 union select a + 1 from cte where a < 3000) " +
+		"select a mod 10, lpad(a, 128, 'y') from cte")
+	tk.MustExec("set @@tidb_index_lookup_join_concurrency = 1")
+
+	sqls := []string{
+		"select /*+ INL_JOIN(t2) */ count(*), sum(length(t1.b)), sum(length(t2.b)) from t1 join t2 on t1.a = t2.a",
+		"select /*+ INL_JOIN(t2) */ count(*), sum(length(t2.b)) from t1 left join t2 on t1.a = t2.a and t2.b > 'y'",
+		"select /*+ INL_HASH_JOIN(t2) */ count(*), sum(length(t1.b)), sum(length(t2.b)) from t1 join t2 on t1.a = t2.a",
+		"select /*+ INL_HASH_JOIN(t2) */ count(*), sum(length(t2.b)) from t1 left join t2 on t1.a = t2.a and t2.b > 'y'",
+	}
+	for _, sql := range sqls {
+		tk.MustExec("set @@tidb_mem_quota_query = default")
+		expected := tk.MustQuery(sql).Rows()
+
+		tk.MustExec("set @@tidb_mem_quota_query = 262144")
+		tk.MustQuery(sql).Check(expected)
+		// The inner results of the index join should have been spilled to disk, and removed after the execution.
+		require.Equal(t, int64(0), tk.Session().GetSessionVars().StmtCtx.DiskTracker.BytesConsumed(), sql)
+		require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0), sql)
+	}
+}
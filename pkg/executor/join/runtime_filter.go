@@ -0,0 +1,504 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"context"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/collate"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+const (
+	// maxRuntimeFilterInValues is the max number of the distinct values in the IN runtime filter. The MIN_MAX
+	// runtime filter is used instead if the build side has more distinct values.
+	maxRuntimeFilterInValues = 1024
+	// maxRuntimeFilterBloomKeys is the max number of the build rows to build the bloom filter.
+	maxRuntimeFilterBloomKeys    = 1 << 22
+	runtimeFilterBloomBitsPerKey = 10
+	runtimeFilterBloomMinBits    = 1 << 10
+	runtimeFilterBloomHashNum    = 3
+)
+
+// RuntimeFilterTarget is the probe side reader of the hash join, which the runtime filters are pushed down to.
+type RuntimeFilterTarget interface {
+	exec.Executor
+	// SetRuntimeFilterConditions sets the conditions of the Selection with selectionID in the pushed down plans of
+	// the reader. It must be called before the reader is opened.
+	SetRuntimeFilterConditions(selectionID int, conds []expression.Expression)
+}
+
+// RuntimeFilter is a runtime filter built from the build side of the hash join, and applied to the probe side reader
+// before it's opened.
+type RuntimeFilter struct {
+	ID    int
+	Types []variable.RuntimeFilterType
+	// KeyIdx is the offset of the join key the runtime filter is built from.
+	KeyIdx int
+	// ProbeKeyColIdx is the offset of the join key in the chunks of the probe side.
+	ProbeKeyColIdx int
+	// TargetColumn is the probe key column resolved against the scan in the pushed down plans of the reader. It's nil
+	// if only the bloom filter is used, which is probed in TiDB.
+	TargetColumn *expression.Column
+	// TargetSelectionID is the plan ID of the Selection the conditions are pushed down to.
+	TargetSelectionID int
+	// TargetScanID is the plan ID of the scan below the Selection.
+	TargetScanID int
+}
+
+func (rf *RuntimeFilter) hasType(tp variable.RuntimeFilterType) bool {
+	return slices.Contains(rf.Types, tp)
+}
+
+// runtimeFilterCollector collects the build keys of a runtime filter in one build worker.
+type runtimeFilterCollector struct {
+	rf         *RuntimeFilter
+	buildColID int
+	tp         *types.FieldType
+	collator   collate.Collator
+	// values are the distinct build keys, it's nil after the number of them exceeds maxRuntimeFilterInValues.
+	values   map[string]types.Datum
+	min, max types.Datum
+	// hashes are the hash values of the build keys for the bloom filter.
+	hashes    []uint64
+	useBloom  bool
+	hasher    hash.Hash64
+	keyBuf    []byte
+	collected bool
+}
+
+func newRuntimeFilterCollector(rf *RuntimeFilter, buildColID int, buildKeyType, probeKeyType *types.FieldType) *runtimeFilterCollector {
+	c := &runtimeFilterCollector{
+		rf:         rf,
+		buildColID: buildColID,
+		tp:         buildKeyType,
+		collator:   collate.GetCollator(buildKeyType.GetCollate()),
+		hasher:     fnv.New64a(),
+	}
+	if rf.hasType(variable.In) {
+		c.values = make(map[string]types.Datum)
+	}
+	c.useBloom = rf.hasType(variable.BloomFilter) && canUseRuntimeBloomFilter(buildKeyType, probeKeyType)
+	return c
+}
+
+// canUseRuntimeBloomFilter checks whether the build keys and the probe keys are encoded to the same bytes for the
+// same value, so that the bloom filter built from the build keys can be probed by the probe keys.
+func canUseRuntimeBloomFilter(buildKeyType, probeKeyType *types.FieldType) bool {
+	buildEvalType, probeEvalType := buildKeyType.EvalType(), probeKeyType.EvalType()
+	if buildEvalType != probeEvalType {
+		return false
+	}
+	switch buildEvalType {
+	case types.ETInt:
+		return mysql.HasUnsignedFlag(buildKeyType.GetFlag()) == mysql.HasUnsignedFlag(probeKeyType.GetFlag())
+	case types.ETString:
+		return buildKeyType.GetCollate() == probeKeyType.GetCollate()
+	}
+	return false
+}
+
+// runtimeFilterKey encodes the value for the distinct check of IN and the bloom filter.
+func runtimeFilterKey(typeCtx types.Context, collator collate.Collator, d types.Datum, buf []byte) ([]byte, error) {
+	if d.Kind() == types.KindString || d.Kind() == types.KindBytes {
+		return append(buf[:0], collator.Key(d.GetString())...), nil
+	}
+	return codec.EncodeKey(typeCtx.Location(), buf[:0], d)
+}
+
+func (c *runtimeFilterCollector) hashKey(key []byte) uint64 {
+	c.hasher.Reset()
+	_, _ = c.hasher.Write(key)
+	return c.hasher.Sum64()
+}
+
+// collect collects the build keys in the chunk.
+func (c *runtimeFilterCollector) collect(typeCtx types.Context, chk *chunk.Chunk, memTracker *memory.Tracker) (err error) {
+	needKey := c.values != nil || c.useBloom
+	oldHashLen := len(c.hashes)
+	for i := range chk.NumRows() {
+		d := chk.GetRow(i).GetDatum(c.buildColID, c.tp)
+		// NULL never matches the probe keys.
+		if d.IsNull() {
+			continue
+		}
+		if !c.collected || c.compare(typeCtx, &d, &c.min) < 0 {
+			d.Copy(&c.min)
+		}
+		if !c.collected || c.compare(typeCtx, &d, &c.max) > 0 {
+			d.Copy(&c.max)
+		}
+		c.collected = true
+		if !needKey {
+			continue
+		}
+		c.keyBuf, err = runtimeFilterKey(typeCtx, c.collator, d, c.keyBuf)
+		if err != nil {
+			return err
+		}
+		if c.values != nil {
+			if _, ok := c.values[string(c.keyBuf)]; !ok {
+				if len(c.values) >= maxRuntimeFilterInValues {
+					c.values = nil
+				} else {
+					var value types.Datum
+					d.Copy(&value)
+					c.values[string(c.keyBuf)] = value
+				}
+			}
+		}
+		if c.useBloom {
+			if len(c.hashes) >= maxRuntimeFilterBloomKeys {
+				c.useBloom, c.hashes = false, nil
+			} else {
+				c.hashes = append(c.hashes, c.hashKey(c.keyBuf))
+			}
+		}
+		needKey = c.values != nil || c.useBloom
+	}
+	if memTracker != nil {
+		memTracker.Consume(int64(len(c.hashes)-oldHashLen) * 8)
+	}
+	return nil
+}
+
+func (c *runtimeFilterCollector) compare(typeCtx types.Context, a, b *types.Datum) int {
+	cmp, err := a.Compare(typeCtx, b, c.collator)
+	if err != nil {
+		// The values are from the same column, so it never happens.
+		return 0
+	}
+	return cmp
+}
+
+// merge merges the build keys collected by other build worker.
+func (c *runtimeFilterCollector) merge(typeCtx types.Context, other *runtimeFilterCollector) {
+	if other.collected {
+		if !c.collected || c.compare(typeCtx, &other.min, &c.min) < 0 {
+			c.min = other.min
+		}
+		if !c.collected || c.compare(typeCtx, &other.max, &c.max) > 0 {
+			c.max = other.max
+		}
+		c.collected = true
+	}
+	if c.values != nil && other.values != nil {
+		for key, value := range other.values {
+			c.values[key] = value
+		}
+		if len(c.values) > maxRuntimeFilterInValues {
+			c.values = nil
+		}
+	} else {
+		c.values = nil
+	}
+	if c.useBloom && other.useBloom && len(c.hashes)+len(other.hashes) <= maxRuntimeFilterBloomKeys {
+		c.hashes = append(c.hashes, other.hashes...)
+	} else {
+		c.useBloom, c.hashes = false, nil
+	}
+}
+
+// runtimeBloomFilter is the bloom filter of the build keys, which is probed in TiDB because the coprocessor can't
+// evaluate it.
+type runtimeBloomFilter struct {
+	rf       *RuntimeFilter
+	tp       *types.FieldType
+	collator collate.Collator
+	bits     []uint64
+	mask     uint64
+	hasher   hash.Hash64
+	keyBuf   []byte
+}
+
+func newRuntimeBloomFilter(rf *RuntimeFilter, probeKeyType *types.FieldType, hashes []uint64) *runtimeBloomFilter {
+	numBits := uint64(runtimeFilterBloomMinBits)
+	for numBits < uint64(len(hashes))*runtimeFilterBloomBitsPerKey {
+		numBits <<= 1
+	}
+	f := &runtimeBloomFilter{
+		rf:       rf,
+		tp:       probeKeyType,
+		collator: collate.GetCollator(probeKeyType.GetCollate()),
+		bits:     make([]uint64, numBits/64),
+		mask:     numBits - 1,
+		hasher:   fnv.New64a(),
+	}
+	for _, h := range hashes {
+		f.insert(h)
+	}
+	return f
+}
+
+func (f *runtimeBloomFilter) insert(h uint64) {
+	delta := h>>32 | h<<32
+	for range runtimeFilterBloomHashNum {
+		pos := h & f.mask
+		f.bits[pos>>6] |= 1 << (pos & 63)
+		h += delta
+	}
+}
+
+func (f *runtimeBloomFilter) mayContain(h uint64) bool {
+	delta := h>>32 | h<<32
+	for range runtimeFilterBloomHashNum {
+		pos := h & f.mask
+		if f.bits[pos>>6]&(1<<(pos&63)) == 0 {
+			return false
+		}
+		h += delta
+	}
+	return true
+}
+
+func (f *runtimeBloomFilter) memUsage() int64 {
+	return int64(len(f.bits)) * 8
+}
+
+// filter filters out the rows in the chunk whose keys are not in the bloom filter, and returns the number of them.
+func (f *runtimeBloomFilter) filter(typeCtx types.Context, chk *chunk.Chunk) (int, error) {
+	numRows := chk.NumRows()
+	sel := make([]int, 0, numRows)
+	var err error
+	for i := range numRows {
+		row := chk.GetRow(i)
+		d := row.GetDatum(f.rf.ProbeKeyColIdx, f.tp)
+		if d.IsNull() {
+			continue
+		}
+		f.keyBuf, err = runtimeFilterKey(typeCtx, f.collator, d, f.keyBuf)
+		if err != nil {
+			return 0, err
+		}
+		f.hasher.Reset()
+		_, _ = f.hasher.Write(f.keyBuf)
+		if f.mayContain(f.hasher.Sum64()) {
+			sel = append(sel, row.Idx())
+		}
+	}
+	if len(sel) == numRows {
+		return 0, nil
+	}
+	chk.SetSel(sel)
+	chk.Reconstruct()
+	return numRows - len(sel), nil
+}
+
+// runtimeFilterStats is the runtime stats of the runtime filters.
+type runtimeFilterStats struct {
+	// filters describe the runtime filters applied in the last execution.
+	filters []string
+	// pushedFilteredRows is the number of rows filtered out by the conditions pushed down to the probe side reader.
+	pushedFilteredRows int64
+	// bloomFilteredRows is the number of rows filtered out by the bloom filters in TiDB.
+	bloomFilteredRows int64
+}
+
+func (s *runtimeFilterStats) String() string {
+	var builder strings.Builder
+	builder.WriteString("runtime_filter:{")
+	builder.WriteString(strings.Join(s.filters, ", "))
+	builder.WriteString(", pushed_filtered_rows:")
+	builder.WriteString(strconv.FormatInt(s.pushedFilteredRows, 10))
+	builder.WriteString(", bloom_filtered_rows:")
+	builder.WriteString(strconv.FormatInt(atomic.LoadInt64(&s.bloomFilteredRows), 10))
+	builder.WriteString("}")
+	return builder.String()
+}
+
+func (e *HashJoinV2Exec) initRuntimeFilterCollectors() {
+	if len(e.RuntimeFilters) == 0 {
+		return
+	}
+	for _, worker := range e.BuildWorkers {
+		worker.runtimeFilterCollectors = worker.runtimeFilterCollectors[:0]
+		for _, rf := range e.RuntimeFilters {
+			worker.runtimeFilterCollectors = append(worker.runtimeFilterCollectors,
+				newRuntimeFilterCollector(rf, worker.BuildKeyColIdx[rf.KeyIdx], e.BuildKeyTypes[rf.KeyIdx], e.ProbeKeyTypes[rf.KeyIdx]))
+		}
+	}
+}
+
+// openProbeSideWithRuntimeFilters builds the runtime filters from the build keys, pushes them down to the probe side
+// reader and opens it. It's called after the build side is finished.
+func (e *HashJoinV2Exec) openProbeSideWithRuntimeFilters(ctx context.Context) error {
+	typeCtx := e.Ctx().GetSessionVars().StmtCtx.TypeCtx()
+	exprCtx := e.Ctx().GetExprCtx()
+	pushDownCtx := expression.NewPushDownContextFromSessionVars(exprCtx.GetEvalCtx(), e.Ctx().GetSessionVars(), e.Ctx().GetClient())
+	condsBySelection := make(map[int][]expression.Expression)
+	selectionIDs := make([]int, 0, len(e.RuntimeFilters))
+	var bloomFilters []*runtimeBloomFilter
+	descs := make([]string, 0, len(e.RuntimeFilters))
+	for i, rf := range e.RuntimeFilters {
+		collector := e.BuildWorkers[0].runtimeFilterCollectors[i]
+		var hashMemUsage int64
+		for _, worker := range e.BuildWorkers {
+			hashMemUsage += int64(len(worker.runtimeFilterCollectors[i].hashes)) * 8
+			if worker != e.BuildWorkers[0] {
+				collector.merge(typeCtx, worker.runtimeFilterCollectors[i])
+			}
+		}
+		var conds []expression.Expression
+		var appliedTypes []string
+		if rf.TargetColumn != nil {
+			var err error
+			conds, appliedTypes, err = e.buildRuntimeFilterConditions(rf, collector)
+			if err != nil {
+				return err
+			}
+		}
+		pushed := len(conds) > 0 && expression.CanExprsPushDown(pushDownCtx, conds, kv.TiKV)
+		if pushed {
+			if _, ok := condsBySelection[rf.TargetSelectionID]; !ok {
+				selectionIDs = append(selectionIDs, rf.TargetSelectionID)
+			}
+			condsBySelection[rf.TargetSelectionID] = append(condsBySelection[rf.TargetSelectionID], conds...)
+		} else {
+			appliedTypes = appliedTypes[:0]
+		}
+		// The bloom filter built from no keys filters out all the probe rows, it's only needed if the EMPTY condition
+		// is not pushed down.
+		if collector.useBloom && (collector.collected || !pushed) {
+			bloomFilter := newRuntimeBloomFilter(rf, e.ProbeKeyTypes[rf.KeyIdx], collector.hashes)
+			e.memTracker.Consume(bloomFilter.memUsage())
+			bloomFilters = append(bloomFilters, bloomFilter)
+			appliedTypes = append(appliedTypes, variable.BloomFilter.String())
+		}
+		e.memTracker.Consume(-hashMemUsage)
+		for _, worker := range e.BuildWorkers {
+			worker.runtimeFilterCollectors[i] = nil
+		}
+		descs = append(descs, fmt.Sprintf("%d[%s]", rf.ID, strings.Join(appliedTypes, ",")))
+	}
+	for _, id := range selectionIDs {
+		e.ProbeSideTupleFetcher.ProbeSideExec.(RuntimeFilterTarget).SetRuntimeFilterConditions(id, condsBySelection[id])
+	}
+	e.ProbeSideTupleFetcher.runtimeBloomFilters = bloomFilters
+	if e.stats != nil {
+		e.stats.runtimeFilter.filters = descs
+		e.ProbeSideTupleFetcher.runtimeFilterStats = &e.stats.runtimeFilter
+	}
+	return exec.Open(ctx, e.ProbeSideTupleFetcher.ProbeSideExec)
+}
+
+// buildRuntimeFilterConditions builds the conditions of the runtime filter on the target column. It also returns the
+// types of the conditions for the runtime stats.
+func (e *HashJoinV2Exec) buildRuntimeFilterConditions(rf *RuntimeFilter, collector *runtimeFilterCollector) ([]expression.Expression, []string, error) {
+	if !collector.collected {
+		// The build side has no non-null keys, so no probe row can match.
+		return []expression.Expression{expression.NewZero()}, []string{"EMPTY"}, nil
+	}
+	exprCtx := e.Ctx().GetExprCtx()
+	newConstant := func(d types.Datum) *expression.Constant {
+		return &expression.Constant{Value: d, RetType: collector.tp.Clone()}
+	}
+	if rf.hasType(variable.In) && collector.values != nil {
+		args := make([]expression.Expression, 0, len(collector.values)+1)
+		args = append(args, rf.TargetColumn)
+		for _, value := range collector.values {
+			args = append(args, newConstant(value))
+		}
+		cond, err := expression.NewFunction(exprCtx, ast.In, types.NewFieldType(mysql.TypeLonglong), args...)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return []expression.Expression{cond}, []string{fmt.Sprintf("%s(%d)", variable.In.String(), len(collector.values))}, nil
+	}
+	// The MIN_MAX runtime filter is also used when there are too many distinct values for the IN runtime filter.
+	if !rf.hasType(variable.MinMax) && !rf.hasType(variable.In) {
+		return nil, nil, nil
+	}
+	geCond, err := expression.NewFunction(exprCtx, ast.GE, types.NewFieldType(mysql.TypeLonglong), rf.TargetColumn, newConstant(collector.min))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	leCond, err := expression.NewFunction(exprCtx, ast.LE, types.NewFieldType(mysql.TypeLonglong), rf.TargetColumn, newConstant(collector.max))
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return []expression.Expression{geCond, leCond}, []string{variable.MinMax.String()}, nil
+}
+
+// collectRuntimeFilterStats counts the rows filtered out by the conditions pushed down to the probe side reader.
+func (e *HashJoinV2Exec) collectRuntimeFilterStats() {
+	if e.stats == nil || len(e.RuntimeFilters) == 0 {
+		return
+	}
+	statsColl := e.Ctx().GetSessionVars().StmtCtx.RuntimeStatsColl
+	if statsColl == nil {
+		return
+	}
+	var filteredRows int64
+	counted := make(map[int]struct{}, len(e.RuntimeFilters))
+	for _, rf := range e.RuntimeFilters {
+		if rf.TargetColumn == nil {
+			continue
+		}
+		if _, ok := counted[rf.TargetSelectionID]; ok {
+			continue
+		}
+		counted[rf.TargetSelectionID] = struct{}{}
+		if !statsColl.ExistsCopStats(rf.TargetSelectionID) {
+			continue
+		}
+		_, scanRows := statsColl.GetCopCountAndRows(rf.TargetScanID)
+		_, selRows := statsColl.GetCopCountAndRows(rf.TargetSelectionID)
+		filteredRows += scanRows - selRows
+	}
+	e.stats.runtimeFilter.pushedFilteredRows = filteredRows
+}
+
+// nextProbeSideChunk fetches the next chunk of the probe side, and filters it by the bloom filters.
+func (fetcher *probeSideTupleFetcherBase) nextProbeSideChunk(ctx context.Context, typeCtx types.Context, chk *chunk.Chunk) error {
+	for {
+		err := exec.Next(ctx, fetcher.ProbeSideExec, chk)
+		if err != nil || len(fetcher.runtimeBloomFilters) == 0 || chk.NumRows() == 0 {
+			return err
+		}
+		for _, bloomFilter := range fetcher.runtimeBloomFilters {
+			filtered, err := bloomFilter.filter(typeCtx, chk)
+			if err != nil {
+				return err
+			}
+			if fetcher.runtimeFilterStats != nil {
+				atomic.AddInt64(&fetcher.runtimeFilterStats.bloomFilteredRows, int64(filtered))
+			}
+			if chk.NumRows() == 0 {
+				break
+			}
+		}
+		// An empty chunk means the end of the probe side, so fetch the next chunk if all the rows are filtered out.
+		if chk.NumRows() > 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+var _ exec.Executor = &JSONTableExec{}
+
+// JSONTableExec is the executor of the JSON_TABLE table function. It evaluates
+// the JSON document when it's opened and produces all the rows at once, the
+// inner side of the Apply reopens it for every outer row.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	expr expression.Expression
+	root *jsonTableNode
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// jsonTableNode is the row path and the columns of the JSON_TABLE or one of its
+// NESTED PATH.
+type jsonTableNode struct {
+	path    types.JSONPathExpression
+	columns []*jsonTableColumn
+	nested  []*jsonTableNode
+	// offsets are the schema offsets of all the columns of this node,
+	// including the columns of the nested nodes.
+	offsets []int
+}
+
+type jsonTableColumn struct {
+	*ast.JSONTableColumn
+	offset int
+	ft     *types.FieldType
+	path   types.JSONPathExpression
+}
+
+// buildJSONTableNode builds the node tree from the COLUMNS clause, the schema
+// columns are in the pre-order of the COLUMNS clause.
+func buildJSONTableNode(path string, cols []*ast.JSONTableColumn, schema *expression.Schema, offset *int) (*jsonTableNode, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	node := &jsonTableNode{path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			child, err := buildJSONTableNode(col.Path, col.Columns, schema, offset)
+			if err != nil {
+				return nil, err
+			}
+			node.nested = append(node.nested, child)
+			node.offsets = append(node.offsets, child.offsets...)
+			continue
+		}
+		if *offset >= schema.Len() {
+			return nil, errors.Errorf("the schema of JSON_TABLE doesn't match the columns, schema length %d", schema.Len())
+		}
+		c := &jsonTableColumn{JSONTableColumn: col, offset: *offset, ft: schema.Columns[*offset].RetType}
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if c.path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+		}
+		node.columns = append(node.columns, c)
+		node.offsets = append(node.offsets, *offset)
+		*offset++
+	}
+	return node, nil
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	doc, isNull, err := e.expr.EvalJSON(e.Ctx().GetExprCtx().GetEvalCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	row := make([]types.Datum, e.Schema().Len())
+	_, err = e.produce(e.root, doc, row)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// produce produces the rows for every value matched by the row path of the
+// node, and returns the number of the produced rows.
+func (e *JSONTableExec) produce(node *jsonTableNode, doc types.BinaryJSON, row []types.Datum) (int, error) {
+	matched, found := doc.Extract([]types.JSONPathExpression{node.path})
+	if !found {
+		return 0, nil
+	}
+	values := []types.BinaryJSON{matched}
+	if node.path.CouldMatchMultipleValues() {
+		values = values[:0]
+		for i := range matched.GetElemCount() {
+			values = append(values, matched.ArrayGetElem(i))
+		}
+	}
+	produced := 0
+	for i, value := range values {
+		for _, col := range node.columns {
+			d, err := e.evalColumn(col, value, i+1)
+			if err != nil {
+				return 0, err
+			}
+			row[col.offset] = d
+		}
+		n, err := e.produceNested(node, value, row)
+		if err != nil {
+			return 0, err
+		}
+		produced += n
+	}
+	return produced, nil
+}
+
+// produceNested produces the rows of the sibling NESTED PATH one after another,
+// the columns of the other siblings are NULL. If none of them matches, one row
+// is produced with all the nested columns as NULL.
+func (e *JSONTableExec) produceNested(node *jsonTableNode, value types.BinaryJSON, row []types.Datum) (int, error) {
+	produced := 0
+	for _, child := range node.nested {
+		n, err := e.produce(child, value, row)
+		if err != nil {
+			return 0, err
+		}
+		for _, offset := range child.offsets {
+			row[offset].SetNull()
+		}
+		produced += n
+	}
+	if produced == 0 {
+		e.rows = append(e.rows, append([]types.Datum(nil), row...))
+		produced = 1
+	}
+	return produced, nil
+}
+
+func (e *JSONTableExec) evalColumn(col *jsonTableColumn, value types.BinaryJSON, ordinality int) (types.Datum, error) {
+	typeCtx := types.StrictContext.WithLocation(e.Ctx().GetSessionVars().Location())
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExistsPath:
+		_, found := value.Extract([]types.JSONPathExpression{col.path})
+		d := types.NewIntDatum(0)
+		if found {
+			d = types.NewIntDatum(1)
+		}
+		return d.ConvertTo(typeCtx, col.ft)
+	}
+
+	matched, found := value.Extract([]types.JSONPathExpression{col.path})
+	if !found {
+		return e.evalResponse(col, col.OnEmpty, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	if col.path.CouldMatchMultipleValues() {
+		if matched.GetElemCount() != 1 {
+			return e.evalResponse(col, col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O))
+		}
+		matched = matched.ArrayGetElem(0)
+	}
+	if col.ft.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(matched), nil
+	}
+
+	var d types.Datum
+	switch matched.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return e.evalResponse(col, col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O))
+	case types.JSONTypeCodeLiteral:
+		switch matched.Value[0] {
+		case types.JSONLiteralNil:
+			return d, nil
+		case types.JSONLiteralTrue:
+			d.SetInt64(1)
+		default:
+			d.SetInt64(0)
+		}
+	case types.JSONTypeCodeInt64:
+		d.SetInt64(matched.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d.SetUint64(matched.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d.SetFloat64(matched.GetFloat64())
+	default:
+		s, err := matched.Unquote()
+		if err != nil {
+			return e.evalResponse(col, col.OnError, err)
+		}
+		d.SetString(s, mysql.DefaultCollationName)
+	}
+	converted, err := d.ConvertTo(typeCtx, col.ft)
+	if err != nil {
+		if types.ErrOverflow.Equal(err) || types.ErrWarnDataOutOfRange.Equal(err) {
+			err = exeerrors.ErrJSONTableValueOutOfRange.GenWithStackByArgs(col.Name.O)
+		}
+		return e.evalResponse(col, col.OnError, err)
+	}
+	return converted, nil
+}
+
+// evalResponse evaluates the ON EMPTY or ON ERROR clause, the default behavior
+// is NULL.
+func (e *JSONTableExec) evalResponse(col *jsonTableColumn, resp *ast.JSONTableResponse, err error) (types.Datum, error) {
+	var d types.Datum
+	if resp == nil {
+		return d, nil
+	}
+	switch resp.Tp {
+	case ast.JSONTableResponseError:
+		return d, err
+	case ast.JSONTableResponseDefault:
+		if col.ft.GetType() == mysql.TypeJSON {
+			j, err := types.ParseBinaryJSONFromString(resp.Default)
+			if err != nil {
+				return d, err
+			}
+			return types.NewJSONDatum(j), nil
+		}
+		d.SetString(resp.Default, mysql.DefaultCollationName)
+		typeCtx := types.StrictContext.WithLocation(e.Ctx().GetSessionVars().Location())
+		return d.ConvertTo(typeCtx, col.ft)
+	}
+	return d, nil
+}
@@ -50,9 +50,11 @@ var (
 )
 
 type memIndexReader struct {
-	ctx            sessionctx.Context
-	index          *model.IndexInfo
-	table          *model.TableInfo
+	ctx   sessionctx.Context
+	index *model.IndexInfo
+	table *model.TableInfo
+	// includeCols are the included columns read from the index value.
+	includeCols    []*model.ColumnInfo
 	kvRanges       []kv.KeyRange
 	conditions     []expression.Expression
 	addedRows      [][]types.Datum
@@ -84,6 +86,7 @@ func buildMemIndexReader(ctx context.Context, us *UnionScanExec, idxReader *Inde
 		ctx:            us.Ctx(),
 		index:          idxReader.index,
 		table:          idxReader.table.Meta(),
+		includeCols:    buildMemIndexIncludeCols(idxReader),
 		kvRanges:       kvRanges,
 		conditions:     us.conditions,
 		retFieldTypes:  exec.RetTypes(us),
@@ -97,6 +100,26 @@ func buildMemIndexReader(ctx context.Context, us *UnionScanExec, idxReader *Inde
 	}
 }
 
+// buildMemIndexIncludeCols returns the included columns read by the index scan, they
+// follow the index columns in the schema of the index scan.
+func buildMemIndexIncludeCols(idxReader *IndexReaderExecutor) []*model.ColumnInfo {
+	idxInfo, tblInfo := idxReader.index, idxReader.table.Meta()
+	if !idxInfo.HasIncludeColumns() || len(idxReader.plans) == 0 {
+		return nil
+	}
+	var includeCols []*model.ColumnInfo
+	for _, col := range idxReader.plans[0].Schema().Columns[len(idxInfo.Columns):] {
+		for _, ic := range idxInfo.IncludeColumns {
+			tblCol := tblInfo.Columns[ic.Offset]
+			// The columns of the clustered index are read from the handle.
+			if tblCol.ID == col.ID && !(tblInfo.HasClusteredIndex() && mysql.HasPriKeyFlag(tblCol.GetFlag())) {
+				includeCols = append(includeCols, tblCol)
+			}
+		}
+	}
+	return includeCols
+}
+
 func (m *memIndexReader) getMemRowsIter(ctx context.Context) (memRowsIter, error) {
 	if m.keepOrder && m.table.GetPartitionInfo() != nil {
 		data, err := m.getMemRows(ctx)
@@ -111,8 +134,7 @@ func (m *memIndexReader) getMemRowsIter(ctx context.Context) (memRowsIter, error
 		return nil, errors.Trace(err)
 	}
 	tps := m.getTypes()
-	colInfos := tables.BuildRowcodecColInfoForIndexColumns(m.index, m.table)
-	colInfos = tables.TryAppendCommonHandleRowcodecColInfos(colInfos, m.table)
+	colInfos := m.buildRowcodecColInfos()
 	return &memRowsIterForIndex{
 		kvIter:         kvIter,
 		tps:            tps,
@@ -122,12 +144,26 @@ func (m *memIndexReader) getMemRowsIter(ctx context.Context) (memRowsIter, error
 	}, nil
 }
 
+func (m *memIndexReader) buildRowcodecColInfos() []rowcodec.ColInfo {
+	colInfos := tables.BuildRowcodecColInfoForIndexColumns(m.index, m.table)
+	for _, col := range m.includeCols {
+		colInfos = append(colInfos, rowcodec.ColInfo{
+			ID: col.ID,
+			Ft: rowcodec.FieldTypeFromModelColumn(col),
+		})
+	}
+	return tables.TryAppendCommonHandleRowcodecColInfos(colInfos, m.table)
+}
+
 func (m *memIndexReader) getTypes() []*types.FieldType {
-	tps := make([]*types.FieldType, 0, len(m.index.Columns)+1)
+	tps := make([]*types.FieldType, 0, len(m.index.Columns)+len(m.includeCols)+1)
 	cols := m.table.Columns
 	for _, col := range m.index.Columns {
 		tps = append(tps, &cols[col.Offset].FieldType)
 	}
+	for _, col := range m.includeCols {
+		tps = append(tps, &col.FieldType)
+	}
 	switch {
 	case m.table.PKIsHandle:
 		for _, col := range m.table.Columns {
@@ -151,8 +187,7 @@ func (m *memIndexReader) getTypes() []*types.FieldType {
 func (m *memIndexReader) getMemRows(ctx context.Context) ([][]types.Datum, error) {
 	defer tracing.StartRegion(ctx, "memIndexReader.getMemRows").End()
 	tps := m.getTypes()
-	colInfos := tables.BuildRowcodecColInfoForIndexColumns(m.index, m.table)
-	colInfos = tables.TryAppendCommonHandleRowcodecColInfos(colInfos, m.table)
+	colInfos := m.buildRowcodecColInfos()
 
 	mutableRow := chunk.MutRowFromTypes(m.retFieldTypes)
 	err := iterTxnMemBuffer(m.ctx, m.cacheTable, m.kvRanges, m.desc, func(key, value []byte) error {
@@ -189,13 +224,13 @@ func (m *memIndexReader) getMemRows(ctx context.Context) ([][]types.Datum, error
 }
 
 func (m *memIndexReader) decodeIndexKeyValue(key, value []byte, tps []*types.FieldType, colInfos []rowcodec.ColInfo) ([]types.Datum, error) {
+	colsLen := len(m.index.Columns) + len(m.includeCols)
 	hdStatus := tablecodec.HandleDefault
 	// `HandleIsUnsigned` only affects IntHandle which always has one column.
-	if mysql.HasUnsignedFlag(tps[len(m.index.Columns)].GetFlag()) {
+	if mysql.HasUnsignedFlag(tps[colsLen].GetFlag()) {
 		hdStatus = tablecodec.HandleIsUnsigned
 	}
 
-	colsLen := len(m.index.Columns)
 	if m.decodeBuff == nil {
 		m.decodeBuff = make([][]byte, colsLen, colsLen+len(colInfos))
 	} else {
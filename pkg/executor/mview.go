@@ -0,0 +1,545 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/charset"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/opcode"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+// The changes of the base table of a materialized view are logged in
+// mysql.tidb_mview_log by the DML statements, in the same transaction as the
+// changes. A log row records the group key of a changed row, and a NULL key
+// means the whole view needs to be recomputed. Refreshing a view incrementally
+// recomputes the logged groups and removes the consumed log rows.
+//
+// The log is clustered by (mview_id, commit_ts, seq). The commit ts isn't known
+// before the transaction commits, so commit_ts is the start ts of the transaction
+// which logs the change, and seq orders the changes in the transaction.
+//
+// IMPORT INTO and LOAD DATA don't log the changes, the views of the table need
+// a complete refresh after them.
+
+var (
+	mviewLogSchema = ast.NewCIStr(mysql.SystemDB)
+	mviewLogTable  = ast.NewCIStr("tidb_mview_log")
+)
+
+// mviewRefreshBatchSize is the number of groups recomputed by a statement.
+const mviewRefreshBatchSize = 256
+
+// mviewLogTarget is a materialized view logged by mviewLogger.
+type mviewLogTarget struct {
+	id int64
+	// keyOffsets are the offsets of the group keys in the rows of the base
+	// table, nil means the changes are logged by a NULL key.
+	keyOffsets []int
+}
+
+// mviewLogger logs the changed groups of the base table for its incrementally
+// refreshable materialized views.
+type mviewLogger struct {
+	sctx     sessionctx.Context
+	logTable table.Table
+	targets  []mviewLogTarget
+	// logged is used to log a group only once in a statement.
+	logged map[string]struct{}
+}
+
+func (b *executorBuilder) buildMViewLogger(tbl table.Table) (*mviewLogger, error) {
+	tblInfo := tbl.Meta()
+	if tblInfo.TempTableType != model.TempTableNone {
+		return nil, nil
+	}
+	dbInfo, ok := b.is.SchemaByID(tblInfo.DBID)
+	if !ok {
+		return nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(tblInfo.DBID)
+	}
+	mvIDs := b.is.GetTableMaterializedViews(dbInfo.Name.L, tblInfo.Name.L)
+	if len(mvIDs) == 0 {
+		return nil, nil
+	}
+	var targets []mviewLogTarget
+	for _, id := range mvIDs {
+		mv, ok := b.is.TableByID(context.Background(), id)
+		if !ok || !mv.Meta().IsMaterializedView() || !mv.Meta().MaterializedView.Incremental {
+			continue
+		}
+		targets = append(targets, mviewLogTarget{id: id, keyOffsets: mviewKeyOffsets(tbl, mv.Meta().MaterializedView)})
+	}
+	if len(targets) == 0 {
+		return nil, nil
+	}
+	logTable, err := b.is.TableByName(context.Background(), mviewLogSchema, mviewLogTable)
+	if err != nil {
+		return nil, err
+	}
+	return &mviewLogger{sctx: b.ctx, logTable: logTable, targets: targets, logged: make(map[string]struct{})}, nil
+}
+
+// mviewKeyOffsets returns the offsets of the group keys in the rows of the base
+// table, it returns nil if the table doesn't have the keys anymore.
+func mviewKeyOffsets(tbl table.Table, mvInfo *model.MaterializedViewInfo) []int {
+	offsets := make([]int, 0, len(mvInfo.BaseKeys))
+	for _, key := range mvInfo.BaseKeys {
+		col := table.FindColLowerCase(tbl.Cols(), key.L)
+		if col == nil {
+			return nil
+		}
+		offsets = append(offsets, col.Offset)
+	}
+	return offsets
+}
+
+// log logs the groups of the old row and the new row.
+func (l *mviewLogger) log(ctx context.Context, oldRow, newRow []types.Datum) error {
+	for _, row := range [][]types.Datum{oldRow, newRow} {
+		if row == nil {
+			continue
+		}
+		for _, target := range l.targets {
+			key := types.NewDatum(nil)
+			if target.keyOffsets != nil {
+				keyDatums := make([]types.Datum, len(target.keyOffsets))
+				colIDs := make([]int64, len(target.keyOffsets))
+				for i, offset := range target.keyOffsets {
+					keyDatums[i] = row[offset]
+					colIDs[i] = int64(i + 1)
+				}
+				encoded, err := tablecodec.EncodeOldRow(l.sctx.GetSessionVars().Location(), keyDatums, colIDs, nil, nil)
+				if err != nil {
+					return err
+				}
+				key = types.NewBytesDatum(encoded)
+			}
+			loggedKey := strconv.FormatInt(target.id, 10) + "/" + string(key.GetBytes())
+			if key.IsNull() {
+				loggedKey = strconv.FormatInt(target.id, 10)
+			}
+			if _, ok := l.logged[loggedKey]; ok {
+				continue
+			}
+			l.logged[loggedKey] = struct{}{}
+			txn, err := l.sctx.Txn(true)
+			if err != nil {
+				return err
+			}
+			commitTS, seq := nextMViewLogKey(l.sctx, txn)
+			logRow := []types.Datum{types.NewIntDatum(target.id), types.NewUintDatum(commitTS), types.NewIntDatum(seq), key}
+			_, err = l.logTable.AddRecord(l.sctx.GetTableCtx(), txn, logRow, table.WithCtx(ctx))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nextMViewLogKey returns the commit_ts and seq of the next change logged in the
+// transaction.
+func nextMViewLogKey(sctx sessionctx.Context, txn kv.Transaction) (uint64, int64) {
+	txnCtx := sctx.GetSessionVars().TxnCtx
+	txnCtx.MViewLogSeq++
+	return txn.StartTS(), txnCtx.MViewLogSeq
+}
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	ret := &plannercore.PreprocessorReturn{}
+	nodeW := resolve.NewNodeW(s.Select)
+	err := plannercore.Preprocess(ctx, e.Ctx(), nodeW, plannercore.WithPreprocessorReturn(ret))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ret.IsStaleness {
+		return exeerrors.ErrViewInvalid.GenWithStackByArgs(s.ViewName.Schema.L, s.ViewName.Name.L)
+	}
+
+	e.Ctx().GetSessionVars().ClearRelatedTableForMDL()
+	existed := e.is.TableExists(s.ViewName.Schema, s.ViewName.Name)
+	if err = e.ddlExecutor.CreateMaterializedView(e.Ctx(), s); err != nil || existed {
+		return err
+	}
+	// Fill the rows of the new view.
+	return e.refreshMaterializedView(s.ViewName, ast.RefreshComplete)
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropMaterializedViewStmt) error {
+	var mvIDs []int64
+	for _, tn := range s.Views {
+		if tbl, err := e.is.TableByName(context.Background(), tn.Schema, tn.Name); err == nil && tbl.Meta().IsMaterializedView() {
+			mvIDs = append(mvIDs, tbl.Meta().ID)
+		}
+	}
+	if err := e.ddlExecutor.DropMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	if len(mvIDs) == 0 {
+		return nil
+	}
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	defer e.ReleaseSysSession(ctx, sysSession)
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "DELETE FROM %n.%n WHERE mview_id IN (", mviewLogSchema.O, mviewLogTable.O)
+	writeInt64List(sql, mvIDs)
+	sql.WriteString(")")
+	_, err = sysSession.GetSQLExecutor().ExecuteInternal(ctx, sql.String())
+	return err
+}
+
+func (e *DDLExec) executeRefreshMaterializedView(s *ast.RefreshMaterializedViewStmt) error {
+	return e.refreshMaterializedView(s.ViewName, s.Type)
+}
+
+// resetMaterializedViews logs a NULL key for the materialized views of the
+// table, so that the views are completely refreshed next time. It's used after
+// the DDL which changes the rows without logging them.
+func (e *DDLExec) resetMaterializedViews(schema, tblName ast.CIStr) error {
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	var mvIDs []int64
+	for _, id := range is.GetTableMaterializedViews(schema.L, tblName.L) {
+		if mv, ok := is.TableByID(context.Background(), id); ok && mv.Meta().IsMaterializedView() && mv.Meta().MaterializedView.Incremental {
+			mvIDs = append(mvIDs, id)
+		}
+	}
+	if len(mvIDs) == 0 {
+		return nil
+	}
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	defer e.ReleaseSysSession(ctx, sysSession)
+	exec := sysSession.GetSQLExecutor()
+	if _, err = exec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, rollbackErr := exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(rollbackErr)
+		}
+	}()
+	txn, err := sysSession.Txn(true)
+	if err != nil {
+		return err
+	}
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "INSERT INTO %n.%n (mview_id, commit_ts, seq, group_key) VALUES ", mviewLogSchema.O, mviewLogTable.O)
+	for i, id := range mvIDs {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		commitTS, seq := nextMViewLogKey(sysSession, txn)
+		sqlescape.MustFormatSQL(sql, "(%?, %?, %?, NULL)", id, commitTS, seq)
+	}
+	if _, err = exec.ExecuteInternal(ctx, sql.String()); err != nil {
+		return err
+	}
+	_, err = exec.ExecuteInternal(ctx, "COMMIT")
+	return err
+}
+
+// refreshMaterializedView refreshes the view in an internal transaction. The
+// view is refreshed incrementally by default if it's supported.
+func (e *DDLExec) refreshMaterializedView(viewName *ast.TableName, tp ast.RefreshMaterializedViewType) (err error) {
+	// The view may be created by the statement, so read it from the latest schema.
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	tbl, err := is.TableByName(context.Background(), viewName.Schema, viewName.Name)
+	if err != nil {
+		return err
+	}
+	mvInfo := tbl.Meta()
+	if !mvInfo.IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(viewName.Schema, viewName.Name, "MATERIALIZED VIEW")
+	}
+	if tp == ast.RefreshIncremental && !mvInfo.MaterializedView.Incremental {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("incremental refresh of materialized view " + mvInfo.Name.O)
+	}
+
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, sysSession)
+	r := &mviewRefresher{
+		sctx:    sysSession,
+		exec:    sysSession.GetSQLExecutor(),
+		is:      is,
+		schema:  viewName.Schema,
+		mvInfo:  mvInfo,
+		refresh: tp,
+	}
+	if _, err = r.exec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, rollbackErr := r.exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(rollbackErr)
+		}
+	}()
+	if err = r.run(ctx); err != nil {
+		return err
+	}
+	_, err = r.exec.ExecuteInternal(ctx, "COMMIT")
+	return err
+}
+
+// mviewRefresher refreshes a materialized view by the internal SQL.
+type mviewRefresher struct {
+	sctx    sessionctx.Context
+	exec    sqlexec.SQLExecutor
+	is      infoschema.InfoSchema
+	schema  ast.CIStr
+	mvInfo  *model.TableInfo
+	refresh ast.RefreshMaterializedViewType
+}
+
+func (r *mviewRefresher) run(ctx context.Context) error {
+	// The log of the view is read and purged by the primary key.
+	rows, err := sqlexec.ExecSQL(ctx, r.exec, "SELECT commit_ts, seq, group_key FROM %n.%n WHERE mview_id = %?",
+		mviewLogSchema.O, mviewLogTable.O, r.mvInfo.ID)
+	if err != nil {
+		return err
+	}
+
+	incremental := r.refresh != ast.RefreshComplete && r.mvInfo.MaterializedView.Incremental
+	var keys [][]types.Datum
+	var keyTypes []*types.FieldType
+	if incremental {
+		keys, keyTypes, err = r.decodeKeys(rows)
+		if err != nil {
+			return err
+		}
+		incremental = keys != nil || len(rows) == 0
+	}
+	if incremental {
+		for start := 0; start < len(keys); start += mviewRefreshBatchSize {
+			end := min(start+mviewRefreshBatchSize, len(keys))
+			if err = r.refreshGroups(ctx, keys[start:end], keyTypes); err != nil {
+				return err
+			}
+		}
+	} else {
+		if err = r.refreshAll(ctx); err != nil {
+			return err
+		}
+	}
+
+	for start := 0; start < len(rows); start += mviewRefreshBatchSize {
+		end := min(start+mviewRefreshBatchSize, len(rows))
+		sql := new(strings.Builder)
+		sqlescape.MustFormatSQL(sql, "DELETE FROM %n.%n WHERE mview_id = %? AND (commit_ts, seq) IN (", mviewLogSchema.O, mviewLogTable.O, r.mvInfo.ID)
+		for i, row := range rows[start:end] {
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sqlescape.MustFormatSQL(sql, "(%?, %?)", row.GetUint64(0), row.GetInt64(1))
+		}
+		sql.WriteString(")")
+		if _, err = r.exec.ExecuteInternal(ctx, sql.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeKeys decodes the distinct group keys in the log rows. It returns nil
+// keys if the view needs to be refreshed completely.
+func (r *mviewRefresher) decodeKeys(rows []chunk.Row) ([][]types.Datum, []*types.FieldType, error) {
+	mv := r.mvInfo.MaterializedView
+	baseTbl, err := r.is.TableByName(context.Background(), mv.BaseSchema, mv.BaseTable)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyTypes := make([]*types.FieldType, len(mv.BaseKeys))
+	colTypes := make(map[int64]*types.FieldType, len(mv.BaseKeys))
+	for i, key := range mv.BaseKeys {
+		col := model.FindColumnInfo(baseTbl.Meta().Columns, key.L)
+		if col == nil {
+			return nil, nil, nil
+		}
+		keyTypes[i] = &col.FieldType
+		colTypes[int64(i+1)] = &col.FieldType
+	}
+	keys := make([][]types.Datum, 0, len(rows))
+	decoded := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		if row.IsNull(2) {
+			return nil, nil, nil
+		}
+		b := row.GetBytes(2)
+		if _, ok := decoded[string(b)]; ok {
+			continue
+		}
+		decoded[string(b)] = struct{}{}
+		// The keys are compared in the time zone of the internal session.
+		datumMap, err := tablecodec.DecodeRowToDatumMap(b, colTypes, r.sctx.GetSessionVars().Location())
+		if err != nil {
+			return nil, nil, err
+		}
+		key := make([]types.Datum, len(keyTypes))
+		for i := range key {
+			key[i] = datumMap[int64(i+1)]
+		}
+		keys = append(keys, key)
+	}
+	return keys, keyTypes, nil
+}
+
+// refreshAll recomputes all the rows of the view.
+func (r *mviewRefresher) refreshAll(ctx context.Context) error {
+	_, err := sqlexec.ExecSQL(ctx, r.exec, "DELETE FROM %n.%n", r.schema.O, r.mvInfo.Name.O)
+	if err != nil {
+		return err
+	}
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "INSERT INTO %n.%n ", r.schema.O, r.mvInfo.Name.O)
+	sql.WriteString(r.mvInfo.MaterializedView.Definition)
+	_, err = r.exec.ExecuteInternal(ctx, sql.String())
+	return err
+}
+
+// refreshGroups recomputes the rows of the view for the groups.
+func (r *mviewRefresher) refreshGroups(ctx context.Context, keys [][]types.Datum, keyTypes []*types.FieldType) error {
+	mv := r.mvInfo.MaterializedView
+	viewCols := make([]string, len(mv.KeyOffsets))
+	baseCols := make([]string, len(mv.BaseKeys))
+	for i, offset := range mv.KeyOffsets {
+		viewCols[i] = r.mvInfo.Columns[offset].Name.O
+		baseCols[i] = mv.BaseKeys[i].O
+	}
+
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "DELETE FROM %n.%n WHERE ", r.schema.O, r.mvInfo.Name.O)
+	writeMViewKeysCond(sql, viewCols, keys, keyTypes)
+	if _, err := r.exec.ExecuteInternal(ctx, sql.String()); err != nil {
+		return err
+	}
+
+	// Recompute the groups by the definition filtered by the group keys.
+	stmt, err := parser.New().ParseOneStmt(mv.Definition, "", "")
+	if err != nil {
+		return err
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok {
+		return errors.Errorf("unexpected definition of materialized view %s", r.mvInfo.Name.O)
+	}
+	sql.Reset()
+	sql.WriteString("SELECT 1 FROM DUAL WHERE ")
+	writeMViewKeysCond(sql, baseCols, keys, keyTypes)
+	condStmt, err := parser.New().ParseOneStmt(sql.String(), "", "")
+	if err != nil {
+		return err
+	}
+	cond := condStmt.(*ast.SelectStmt).Where
+	if sel.Where != nil {
+		cond = &ast.BinaryOperationExpr{Op: opcode.LogicAnd, L: &ast.ParenthesesExpr{Expr: sel.Where}, R: &ast.ParenthesesExpr{Expr: cond}}
+	}
+	sel.Where = cond
+
+	sql.Reset()
+	sqlescape.MustFormatSQL(sql, "INSERT INTO %n.%n ", r.schema.O, r.mvInfo.Name.O)
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	if err = sel.Restore(format.NewRestoreCtx(restoreFlags, sql)); err != nil {
+		return err
+	}
+	_, err = r.exec.ExecuteInternal(ctx, sql.String())
+	return err
+}
+
+// writeMViewKeysCond writes the condition matching the group keys.
+func writeMViewKeysCond(sql *strings.Builder, cols []string, keys [][]types.Datum, keyTypes []*types.FieldType) {
+	if len(cols) == 0 {
+		sql.WriteString("TRUE")
+		return
+	}
+	for i, key := range keys {
+		if i > 0 {
+			sql.WriteString(" OR ")
+		}
+		sql.WriteString("(")
+		for j, d := range key {
+			if j > 0 {
+				sql.WriteString(" AND ")
+			}
+			sqlescape.MustFormatSQL(sql, "%n <=> %?", cols[j], mviewKeyArg(d, keyTypes[j]))
+		}
+		sql.WriteString(")")
+	}
+}
+
+// mviewKeyArg converts the group key to the argument of the internal SQL.
+func mviewKeyArg(d types.Datum, tp *types.FieldType) any {
+	switch d.Kind() {
+	case types.KindNull:
+		return nil
+	case types.KindInt64:
+		return d.GetInt64()
+	case types.KindUint64:
+		return d.GetUint64()
+	case types.KindFloat64:
+		return d.GetFloat64()
+	case types.KindString, types.KindBytes:
+		if tp.GetCharset() == charset.CharsetBin {
+			return d.GetBytes()
+		}
+		return d.GetString()
+	}
+	s, err := d.ToString()
+	terror.Log(err)
+	return s
+}
+
+func writeInt64List(sql *strings.Builder, ids []int64) {
+	for i, id := range ids {
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sql.WriteString(strconv.FormatInt(id, 10))
+	}
+}
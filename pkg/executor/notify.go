@@ -0,0 +1,70 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/notification"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+)
+
+// executeNotify inserts the notification in the current transaction, so it's
+// published only if the transaction commits.
+func (e *SimpleExec) executeNotify(ctx context.Context, s *ast.NotifyStmt) error {
+	if len(s.Channel) > notification.MaxChannelLen {
+		return dbterror.ErrTooLongIdent.GenWithStackByArgs(s.Channel)
+	}
+	sctx := e.Ctx()
+	payload := types.NewDatum(nil)
+	if s.Payload != nil {
+		d, err := plannerutil.EvalAstExprWithPlanCtx(sctx.GetPlanCtx(), s.Payload)
+		if err != nil {
+			return err
+		}
+		if !d.IsNull() {
+			str, err := d.ToString()
+			if err != nil {
+				return err
+			}
+			payload.SetString(str, mysql.DefaultCollationName)
+		}
+	}
+
+	is := e.is
+	if is == nil {
+		is = domain.GetDomain(sctx).InfoSchema()
+	}
+	tbl, err := is.TableByName(ctx, ast.NewCIStr(notification.SystemDB), ast.NewCIStr(notification.Table))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	now := types.NewTime(types.FromGoTime(time.Now().In(sctx.GetSessionVars().Location())), mysql.TypeTimestamp, types.MaxFsp)
+	datums := []types.Datum{types.NewStringDatum(s.Channel), payload, types.NewDatum(nil), types.NewTimeDatum(now)}
+	_, err = tbl.AddRecord(sctx.GetTableCtx(), txn, datums, table.WithCtx(ctx))
+	return err
+}
@@ -26,14 +26,10 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
-type dataInfo struct {
-	chk         *chunk.Chunk
-	remaining   uint64
-	accumulated uint64
-}
-
 // PipelinedWindowExec is the executor for window functions.
 type PipelinedWindowExec struct {
 	exec.BaseExecutor
@@ -45,15 +41,13 @@ type PipelinedWindowExec struct {
 	end                *logicalop.FrameBound
 	groupChecker       *vecgroupchecker.VecGroupChecker
 
-	// childResult stores the child chunk. Note that even if remaining is 0, e.rows might still references rows in data[0].chk after returned it to upper executor, since there is no guarantee what the upper executor will do to the returned chunk, it might destroy the data (as in the benchmark test, it reused the chunk to pull data, and it will be chk.Reset(), causing panicking). So dataIdx, accumulated and dropped are added to ensure that chunk will only be returned if there is no row reference.
+	// childResult stores the child chunk. The rows in it are copied into e.rows, so it can be reused to fetch data.
 	childResult *chunk.Chunk
-	data        []dataInfo
-	dataIdx     int
+	// childColIdxs are the indexes of the child columns returned by the executor.
+	childColIdxs []int
 
 	// done indicates the child executor is drained or something unexpected happened.
 	done         bool
-	accumulated  uint64
-	dropped      uint64
 	rowToConsume uint64
 	newPartition bool
 
@@ -63,47 +57,56 @@ type PipelinedWindowExec struct {
 	lastEndRow     uint64
 	stagedStartRow uint64
 	stagedEndRow   uint64
-	rowStart       uint64
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
 
-	// rows keeps rows starting from curStartRow
-	rows                     []chunk.Row
+	// rows keeps the rows of the current partition starting from partitionStart, followed by the rows fetched for
+	// the next partition. The rows before the current frame are dropped, and the rows can be spilled to disk.
+	rows                     *windowRowContainer
+	partitionStart           uint64
 	rowCnt                   uint64
 	whole                    bool
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
 }
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
+	if e.rows != nil {
+		if err := e.rows.close(); err != nil {
+			return err
+		}
+		e.rows = nil
+	}
+	e.childResult = nil
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
 // Open implements the Executor Open interface
 func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
-	e.done, e.newPartition, e.whole, e.initializedSlidingWindow = false, false, false, false
-	e.dataIdx, e.curRowIdx, e.dropped, e.rowToConsume, e.accumulated = 0, 0, 0, 0, 0
-	e.lastStartRow, e.lastEndRow, e.stagedStartRow, e.stagedEndRow, e.rowStart, e.rowCnt = 0, 0, 0, 0, 0, 0
-	e.rows, e.data = make([]chunk.Row, 0), make([]dataInfo, 0)
-	return e.BaseExecutor.Open(ctx)
-}
-
-func (e *PipelinedWindowExec) firstResultChunkNotReady() bool {
-	if !e.done && len(e.data) == 0 {
-		return true
+	if err = e.BaseExecutor.Open(ctx); err != nil {
+		return err
 	}
-	// chunk can't be ready unless, 1. all of the rows in the chunk is filled, 2. e.rows doesn't contain rows in the chunk
-	return len(e.data) > 0 && (e.data[0].remaining != 0 || e.data[0].accumulated > e.dropped)
+	e.done, e.newPartition, e.whole, e.initializedSlidingWindow = false, false, false, false
+	e.curRowIdx, e.rowToConsume = 0, 0
+	e.lastStartRow, e.lastEndRow, e.stagedStartRow, e.stagedEndRow, e.partitionStart, e.rowCnt = 0, 0, 0, 0, 0, 0
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	e.childColIdxs = windowChildColIdxs(e.Schema(), e.numWindowFuncs)
+	e.memTracker, e.diskTracker = openWindowTrackers(e.Ctx(), e.ID(), e.memTracker, e.diskTracker)
+	e.rows = newWindowRowContainer(e.Ctx(), exec.RetTypes(e.Children(0)), e.MaxChunkSize(), e.memTracker, e.diskTracker)
+	return nil
 }
 
 // Next implements the Executor Next interface.
 func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err error) {
 	chk.Reset()
 
-	for e.firstResultChunkNotReady() {
+	for !chk.IsFull() {
 		// we firstly gathering enough rows and consume them, until we are able to produce.
 		// for unbounded frame, it needs consume the whole partition before being able to produce, in this case
 		// e.p.enoughToProduce will be false until so.
@@ -130,7 +133,10 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 					continue
 				}
 				e.newPartition = false
-				e.reset()
+				err = e.reset()
+				if err != nil {
+					return err
+				}
 				if e.rowToConsume == 0 {
 					// no more data
 					break
@@ -141,28 +147,17 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 		}
 
 		// e.p is ready to produce data
-		if len(e.data) > e.dataIdx && e.data[e.dataIdx].remaining != 0 {
-			produced, err := e.produce(e.Ctx(), e.data[e.dataIdx].chk, e.data[e.dataIdx].remaining)
-			if err != nil {
-				return err
-			}
-			e.data[e.dataIdx].remaining -= produced
-			if e.data[e.dataIdx].remaining == 0 {
-				e.dataIdx++
-			}
+		_, err = e.produce(e.Ctx(), chk, uint64(chk.RequiredRows()-chk.NumRows()))
+		if err != nil {
+			return err
 		}
 	}
-	if len(e.data) > 0 {
-		chk.SwapColumns(e.data[0].chk)
-		e.data = e.data[1:]
-		e.dataIdx--
-	}
 	return nil
 }
 
 func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
 	e.newPartition = true
-	if len(e.rows) == 0 {
+	if e.rows.numRows() == e.partitionStart {
 		// if getRowsInPartition is called for the first time, we ignore it as a new partition
 		e.newPartition = false
 	}
@@ -189,54 +184,24 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 	}
 	begin, end := e.groupChecker.GetNextGroup()
 	e.rowToConsume += uint64(end - begin)
-	for i := begin; i < end; i++ {
-		e.rows = append(e.rows, e.childResult.GetRow(i))
-	}
-	return
+	return e.rows.appendRows(e.childResult, begin, end)
 }
 
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
-	// TODO: reuse chunks
-	childResult := exec.TryNewCacheChunk(e.Children(0))
-	err = exec.Next(ctx, e.Children(0), childResult)
+	err = exec.Next(ctx, e.Children(0), e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
-		return true, nil
-	}
-
-	// TODO: reuse chunks
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
-	}
-	e.accumulated += uint64(numRows)
-	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
-
-	e.childResult = childResult
-	return false, nil
-}
-
-func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
-			return err
-		}
-	}
-	return nil
+	return e.childResult.NumRows() == 0, nil
 }
 
-func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
-	return e.rows[i-e.rowStart]
+func (e *PipelinedWindowExec) getRow(i uint64) (chunk.Row, error) {
+	return e.rows.getRow(e.partitionStart + i)
 }
 
-func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
-	return e.rows[start-e.rowStart : end-e.rowStart]
+func (e *PipelinedWindowExec) getRows(start, end uint64) ([]chunk.Row, error) {
+	return e.rows.getRows(e.partitionStart+start, e.partitionStart+end)
 }
 
 // finish is called upon a whole partition is consumed
@@ -250,11 +215,18 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	}
 	if e.isRangeFrame {
 		var start uint64
+		curRow, err := e.getRow(e.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
 		for start = max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
 			var res int64
-			var err error
+			startRow, err := e.getRow(start)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.start.CompareCols[i], e.start.CalcFuncs[i], e.getRow(start), e.getRow(e.curRowIdx))
+				res, _, err = e.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.start.CompareCols[i], e.start.CalcFuncs[i], startRow, curRow)
 				if err != nil {
 					return 0, err
 				}
@@ -290,11 +262,18 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	}
 	if e.isRangeFrame {
 		var end uint64
+		curRow, err := e.getRow(e.curRowIdx)
+		if err != nil {
+			return 0, err
+		}
 		for end = max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
 			var res int64
-			var err error
+			endRow, err := e.getRow(end)
+			if err != nil {
+				return 0, err
+			}
 			for i := range e.orderByCols {
-				res, _, err = e.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.end.CalcFuncs[i], e.end.CompareCols[i], e.getRow(e.curRowIdx), e.getRow(end))
+				res, _, err = e.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), e.end.CalcFuncs[i], e.end.CompareCols[i], curRow, endRow)
 				if err != nil {
 					return 0, err
 				}
@@ -354,6 +333,12 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		if start >= e.rowCnt {
 			start = e.rowCnt
 		}
+		var row chunk.Row
+		row, err = e.getRow(e.curRowIdx)
+		if err != nil {
+			return
+		}
+		chk.AppendPartialRowByColIdxs(0, row, e.childColIdxs)
 		// if start >= end, we should return a default value, and we reset the frame to empty.
 		if start >= end {
 			for i, wf := range e.windowFuncs {
@@ -375,7 +360,7 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 				slidingWindowAggFunc := e.slidingWindowFuncs[i]
 				if e.lastStartRow != start || e.lastEndRow != end {
 					if slidingWindowAggFunc != nil && e.initializedSlidingWindow {
-						err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), e.getRow, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
+						err = slideWindowFunc(ctx, slidingWindowAggFunc, e.rows, e.partitionStart, e.lastStartRow, e.lastEndRow, start-e.lastStartRow, end-e.lastEndRow, e.partialResults[i])
 					} else {
 						// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 						// whether elements inside deque are out of current window.
@@ -385,7 +370,11 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 						}
 						// TODO(zhifeng): track memory usage here
 						wf.ResetPartialResult(e.partialResults[i])
-						_, err = wf.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), e.getRows(start, end), e.partialResults[i])
+						var frameRows []chunk.Row
+						frameRows, err = e.getRows(start, end)
+						if err == nil {
+							_, err = wf.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), frameRows, e.partialResults[i])
+						}
 					}
 				}
 				if err != nil {
@@ -405,12 +394,7 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		remained--
 	}
 	extend := min(e.curRowIdx, e.lastEndRow, e.lastStartRow)
-	if extend > e.rowStart {
-		numDrop := extend - e.rowStart
-		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
-		e.rowStart = extend
-	}
+	err = e.rows.drop(e.partitionStart + extend)
 	return
 }
 
@@ -433,7 +417,7 @@ func (e *PipelinedWindowExec) enoughToProduce(ctx sessionctx.Context) (enough bo
 }
 
 // reset resets the processor
-func (e *PipelinedWindowExec) reset() {
+func (e *PipelinedWindowExec) reset() error {
 	e.lastStartRow = 0
 	e.lastEndRow = 0
 	e.stagedStartRow = 0
@@ -441,13 +425,11 @@ func (e *PipelinedWindowExec) reset() {
 	e.emptyFrame = false
 	e.curRowIdx = 0
 	e.whole = false
-	numDrop := e.rowCnt - e.rowStart
-	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
-	e.rowStart = 0
+	e.partitionStart += e.rowCnt
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
+	return e.rows.drop(e.partitionStart)
 }
@@ -0,0 +1,72 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"github.com/pingcap/tidb/pkg/executor/join"
+	"github.com/pingcap/tidb/pkg/expression"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/planctx"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ join.RuntimeFilterTarget = &TableReaderExecutor{}
+	_ join.RuntimeFilterTarget = &IndexLookUpExecutor{}
+)
+
+// runtimeFilterSelection is a Selection in the pushed down plans of a reader, whose conditions are set by the runtime
+// filters of the hash join before the reader is opened.
+type runtimeFilterSelection struct {
+	planID int
+	// offset is the offset of the Selection in the executors of the DAG request.
+	offset int
+	conds  []expression.Expression
+}
+
+func buildRuntimeFilterSelections(plans []base.PhysicalPlan) []*runtimeFilterSelection {
+	var sels []*runtimeFilterSelection
+	for i, p := range plans {
+		if sel, ok := p.(*plannercore.PhysicalSelection); ok && len(sel.RootRuntimeFilters()) > 0 {
+			sels = append(sels, &runtimeFilterSelection{planID: p.ID(), offset: i})
+		}
+	}
+	return sels
+}
+
+func setRuntimeFilterConditions(sels []*runtimeFilterSelection, selectionID int, conds []expression.Expression) {
+	for _, sel := range sels {
+		if sel.planID == selectionID {
+			sel.conds = conds
+		}
+	}
+}
+
+// fillRuntimeFilterConditions replaces the conditions of the Selections in the DAG request with the conditions of the
+// runtime filters.
+func fillRuntimeFilterConditions(buildPBCtx *planctx.BuildPBContext, dagPB *tipb.DAGRequest, sels []*runtimeFilterSelection) error {
+	for _, sel := range sels {
+		if len(sel.conds) == 0 {
+			continue
+		}
+		conds, err := expression.ExpressionsToPBList(buildPBCtx.GetExprCtx().GetEvalCtx(), sel.conds, buildPBCtx.GetClient())
+		if err != nil {
+			return err
+		}
+		dagPB.Executors[sel.offset].Selection.Conditions = conds
+	}
+	return nil
+}
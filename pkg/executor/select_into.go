@@ -21,6 +21,7 @@ import (
 	"math"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
@@ -29,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 )
 
 // SelectIntoExec represents a SelectInto executor.
@@ -50,7 +52,17 @@ type SelectIntoExec struct {
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		for _, v := range s.intoOpt.Vars {
+			// Local variables are only available in stored procedures.
+			if col, ok := v.(*ast.ColumnNameExpr); ok {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(col.Name.Name.O)
+			}
+		}
+		s.chk = exec.TryNewCacheChunk(s.Children(0))
+		return s.BaseExecutor.Open(ctx)
+	}
+	// only 'select ... into outfile' and 'select ... into var_list' are supported now
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
@@ -72,6 +84,9 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.assignVars(ctx)
+	}
 	for {
 		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
@@ -86,6 +101,42 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 	return nil
 }
 
+// assignVars assigns the only row of the result to the user variables.
+func (s *SelectIntoExec) assignVars(ctx context.Context) error {
+	fts := exec.RetTypes(s.Children(0))
+	var row []types.Datum
+	for {
+		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
+			return err
+		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+		}
+		row = s.chk.GetRow(0).GetDatumRow(fts)
+		for i := range row {
+			row[i] = *row[i].Clone()
+		}
+	}
+	sessVars := s.Ctx().GetSessionVars()
+	if row == nil {
+		sessVars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.FastGenByArgs())
+		return nil
+	}
+	for i, v := range s.intoOpt.Vars {
+		name := strings.ToLower(v.(*ast.VariableExpr).Name)
+		if row[i].IsNull() {
+			sessVars.UnsetUserVar(name)
+			continue
+		}
+		sessVars.SetUserVarVal(name, row[i])
+		sessVars.SetUserVarType(name, fts[i])
+	}
+	return nil
+}
+
 func (*SelectIntoExec) considerEncloseOpt(et types.EvalType) bool {
 	return et == types.ETString || et == types.ETDuration ||
 		et == types.ETTimestamp || et == types.ETDatetime ||
@@ -215,6 +266,9 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.BaseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
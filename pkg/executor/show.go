@@ -35,8 +35,10 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/importinto"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor/importer"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -72,6 +74,7 @@ import (
 	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/collate"
 	contextutil "github.com/pingcap/tidb/pkg/util/context"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
 	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
 	"github.com/pingcap/tidb/pkg/util/filter"
@@ -98,6 +101,9 @@ type ShowExec struct {
 	Partition         ast.CIStr            // Used for showing partition
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         ast.CIStr            // Used for show table regions.
+	Procedure         *ast.TableName       // Used for show create procedure.
+	Trigger           *ast.TableName       // Used for show create trigger.
+	Event             *ast.TableName       // Used for show create event.
 	ResourceGroupName ast.CIStr            // Used for showing resource group
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	Roles             []*auth.RoleIdentity // Used for show grants.
@@ -193,6 +199,12 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateUser(ctx)
 	case ast.ShowCreateView:
 		return e.fetchShowCreateView()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure(ctx)
+	case ast.ShowCreateTrigger:
+		return e.fetchShowCreateTrigger(ctx)
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent(ctx)
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
 	case ast.ShowCreatePlacementPolicy:
@@ -208,7 +220,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(ctx)
 	case ast.ShowStatus:
 		return e.fetchShowStatus()
 	case ast.ShowTables:
@@ -218,7 +230,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowTableStatus:
 		return e.fetchShowTableStatus(ctx)
 	case ast.ShowTriggers:
-		return e.fetchShowTriggers()
+		return e.fetchShowTriggers(ctx)
 	case ast.ShowVariables:
 		return e.fetchShowVariables(ctx)
 	case ast.ShowWarnings:
@@ -228,7 +240,9 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
+	case ast.ShowChangefeeds:
+		return e.fetchShowChangefeeds(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended(ctx)
 	case ast.ShowStatsMeta:
@@ -883,6 +897,11 @@ func (e *ShowExec) fetchShowIndex() error {
 				subPart = col.Length
 			}
 
+			collation := "A"
+			if col.Desc {
+				collation = "D"
+			}
+
 			tblCol := tb.Meta().Columns[col.Offset]
 			nullVal := "YES"
 			if mysql.HasNotNullFlag(tblCol.GetFlag()) {
@@ -907,24 +926,31 @@ func (e *ShowExec) fetchShowIndex() error {
 				ndv = colStats.NDV
 			}
 
+			indexType := idx.Meta().Tp.String()
+			if idx.Meta().Spatial {
+				indexType = "SPATIAL"
+			} else if idx.Meta().FullTextInfo != nil {
+				indexType = "FULLTEXT"
+			}
+
 			e.appendRow([]any{
-				tb.Meta().Name.O,       // Table
-				nonUniq,                // Non_unique
-				idx.Meta().Name.O,      // Key_name
-				i + 1,                  // Seq_in_index
-				colName,                // Column_name
-				"A",                    // Collation
-				ndv,                    // Cardinality
-				subPart,                // Sub_part
-				nil,                    // Packed
-				nullVal,                // Null
-				idx.Meta().Tp.String(), // Index_type
-				"",                     // Comment
-				idx.Meta().Comment,     // Index_comment
-				visible,                // Index_visible
-				expression,             // Expression
-				isClustered,            // Clustered
-				isGlobalIndex,          // Global_index
+				tb.Meta().Name.O,   // Table
+				nonUniq,            // Non_unique
+				idx.Meta().Name.O,  // Key_name
+				i + 1,              // Seq_in_index
+				colName,            // Column_name
+				collation,          // Collation
+				ndv,                // Cardinality
+				subPart,            // Sub_part
+				nil,                // Packed
+				nullVal,            // Null
+				indexType,          // Index_type
+				"",                 // Comment
+				idx.Meta().Comment, // Index_comment
+				visible,            // Index_visible
+				expression,         // Expression
+				isClustered,        // Clustered
+				isGlobalIndex,      // Global_index
 			})
 		}
 	}
@@ -1125,6 +1151,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 				}
 			}
 		}
+		if srid, ok := col.GetSRID(); ok {
+			fmt.Fprintf(buf, " /*!80003 SRID %d */", srid)
+		}
 		if col.IsGenerated() {
 			// It's a generated column.
 			fmt.Fprintf(buf, " GENERATED ALWAYS AS (%s)", col.GeneratedExprString)
@@ -1253,6 +1282,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else if idxInfo.VectorInfo != nil {
 			fmt.Fprintf(buf, "  VECTOR INDEX %s", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.Spatial {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.FullTextInfo != nil {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1268,6 +1301,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 					colInfo = fmt.Sprintf("%s(%s)", colInfo, strconv.Itoa(c.Length))
 				}
 			}
+			if c.Desc {
+				colInfo += " DESC"
+			}
 			cols = append(cols, colInfo)
 		}
 		if idxInfo.VectorInfo != nil {
@@ -1276,6 +1312,16 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 		} else {
 			fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
 		}
+		if idxInfo.HasIncludeColumns() {
+			includeCols := make([]string, 0, len(idxInfo.IncludeColumns))
+			for _, c := range idxInfo.IncludeColumns {
+				includeCols = append(includeCols, stringutil.Escape(c.Name.O, sqlMode))
+			}
+			fmt.Fprintf(buf, " INCLUDE (%s)", strings.Join(includeCols, ","))
+		}
+		if idxInfo.FullTextInfo != nil && idxInfo.FullTextInfo.ParserType == model.FullTextParserTypeNgram {
+			fmt.Fprintf(buf, " /*!50100 WITH PARSER %s */", stringutil.Escape(string(model.FullTextParserTypeNgram), sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1295,6 +1341,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 		if idxInfo.Global {
 			buf.WriteString(" /*T![global_index] GLOBAL */")
 		}
+		if idxInfo.HasCondition() {
+			fmt.Fprintf(buf, " WHERE %s", idxInfo.ConditionExprString)
+		}
 		if i != len(publicIndices)-1 {
 			buf.WriteString(",\n")
 		}
@@ -1441,8 +1490,16 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 			restoreCtx.WriteKeyWord("TTL")
 			restoreCtx.WritePlain("=")
 			restoreCtx.WriteName(columnName.String())
-			restoreCtx.WritePlainf(" + INTERVAL %s ", tableInfo.TTLInfo.IntervalExprStr)
-			return timeUnit.Restore(restoreCtx)
+			if !tableInfo.TTLInfo.IsPerRowExpiry() {
+				restoreCtx.WritePlainf(" + INTERVAL %s ", tableInfo.TTLInfo.IntervalExprStr)
+				if err := timeUnit.Restore(restoreCtx); err != nil {
+					return err
+				}
+			}
+			if tableInfo.TTLInfo.HasCondition() {
+				restoreCtx.WritePlainf(" WHERE (%s)", tableInfo.TTLInfo.Condition)
+			}
+			return nil
 		})
 
 		if err != nil {
@@ -1483,6 +1540,34 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *ast.CIStr,
 		if err != nil {
 			return err
 		}
+
+		if tableInfo.TTLInfo.IsArchive() {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ACTION")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.Action)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(tableInfo.TTLInfo.ArchiveTarget) > 0 {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ARCHIVE_TARGET")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.ArchiveTarget)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -1992,11 +2077,148 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
+func (e *ShowExec) fetchShowTriggers(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	tblInfos, err := e.is.SchemaTableInfos(ctx, e.DBName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tblInfo := range tblInfos {
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, tblInfo.Name.O, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, t := range tblInfo.Triggers {
+			created := types.NewTime(types.FromGoTime(t.CreateTS.In(e.Ctx().GetSessionVars().Location())), mysql.TypeDatetime, 2)
+			e.appendRow([]any{t.Name.O, t.Event.String(), tblInfo.Name.O, t.Statement, t.Timing.String(), created,
+				t.SQLMode, t.Definer, t.CharsetClient, t.CollationConnection, t.DatabaseCollation})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowChangefeeds(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	tblInfos, err := e.is.SchemaTableInfos(ctx, e.DBName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tblInfo := range tblInfos {
+		if len(tblInfo.Changefeeds) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, tblInfo.Name.O, "", mysql.SelectPriv) {
+			continue
+		}
+		for _, cf := range tblInfo.Changefeeds {
+			status, err := getChangefeedStatus(ctx, e.Ctx(), cf.ID)
+			if err != nil {
+				return err
+			}
+			created := types.NewTime(types.FromGoTime(cf.CreateTS.In(e.Ctx().GetSessionVars().Location())), mysql.TypeDatetime, 0)
+			e.appendRow([]any{cf.Name.O, tblInfo.Name.O, cf.ID, cf.SinkURI, cf.Format, status.checkpointTS,
+				status.writtenChanges, status.state, status.lastError, created})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateTrigger(ctx context.Context) error {
+	schema, err := procedure.ResolveSchema(e.Ctx(), e.Trigger.Schema)
+	if err != nil {
+		return err
+	}
+	tblInfos, err := e.is.SchemaTableInfos(ctx, schema)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, tblInfo := range tblInfos {
+		idx := tblInfo.FindTrigger(e.Trigger.Name.L)
+		if idx < 0 {
+			continue
+		}
+		t := tblInfo.Triggers[idx]
+		created := types.NewTime(types.FromGoTime(t.CreateTS.In(e.Ctx().GetSessionVars().Location())), mysql.TypeTimestamp, 2)
+		e.appendRow([]any{t.Name.O, t.SQLMode, procedure.TriggerCreateSQL(t, tblInfo.Name.O), t.CharsetClient,
+			t.CollationConnection, t.DatabaseCollation, created})
+		return nil
+	}
+	return dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+}
+
+func (e *ShowExec) fetchShowProcedureStatus(ctx context.Context) error {
+	routines, err := procedure.ListRoutines(ctx, e.Ctx())
+	if err != nil {
+		return err
+	}
+	for _, r := range routines {
+		if r.Type != procedure.RoutineTypeProcedure {
+			continue
+		}
+		e.appendRow([]any{r.Schema, r.Name, r.Type, r.Definer, r.LastAltered, r.Created, r.SecurityType, r.Comment,
+			r.CharsetClient, r.CollationConnection, r.DatabaseCollation})
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowCreateProcedure(ctx context.Context) error {
+	schema, err := procedure.ResolveSchema(e.Ctx(), e.Procedure.Schema)
+	if err != nil {
+		return err
+	}
+	r, err := procedure.GetProcedure(ctx, e.Ctx(), schema.O, e.Procedure.Name.O)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(procedure.RoutineTypeProcedure, schema.O+"."+e.Procedure.Name.O)
+	}
+	e.appendRow([]any{r.Name, r.SQLMode, r.CreateSQL(), r.CharsetClient, r.CollationConnection, r.DatabaseCollation})
+	return nil
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	infos, timers, err := listEvents(ctx, e.Ctx(), e.DBName.O)
+	if err != nil {
+		return err
+	}
+	for i, info := range infos {
+		loc := info.Location()
+		e.appendRow([]any{info.Schema, info.Name, info.TimeZone, info.Definer, eventTypeString(info),
+			eventTime(info.ExecuteAt, loc), eventIntervalValue(info), eventIntervalField(info),
+			eventTime(info.Starts, loc), eventTime(info.Ends, loc), eventStatusString(timers[i].Enable), 0,
+			info.CharsetClient, info.CollationConnection, info.DatabaseCollation})
+	}
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+func (e *ShowExec) fetchShowCreateEvent(ctx context.Context) error {
+	cli, err := eventTimerClient(e.Ctx())
+	if err != nil {
+		return err
+	}
+	schema, err := procedure.ResolveSchema(e.Ctx(), e.Event.Schema)
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	timer, err := eventscheduler.GetEventTimer(ctx, cli, schema.O, e.Event.Name.O)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(e.Event.Name.O)
+	}
+	info, err := eventscheduler.EventFromTimer(timer)
+	if err != nil {
+		return err
+	}
+	e.appendRow([]any{info.Name, info.SQLMode, info.TimeZone, info.ShowCreateSQL(timer.Enable), info.CharsetClient,
+		info.CollationConnection, info.DatabaseCollation})
 	return nil
 }
 
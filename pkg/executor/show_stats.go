@@ -96,6 +96,13 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
 			statsVal = item.StringVals
+		case ast.StatsTypeMultiColumn:
+			kinds := make([]string, 0, len(item.MultiColumn.Kinds))
+			for _, kind := range item.MultiColumn.Kinds {
+				kinds = append(kinds, ast.StatsTypeName(kind))
+			}
+			statsType = strings.Join(kinds, ",")
+			statsVal = item.StringVals
 		}
 		e.appendRow([]any{
 			dbName,
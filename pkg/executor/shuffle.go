@@ -26,11 +26,15 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/vecgroupchecker"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/channel"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/twmb/murmur3"
 	"go.uber.org/zap"
 )
@@ -67,9 +71,9 @@ import (
 //     |                 |                 |
 //     |                +-+  +-+  ......  +-+
 //     |                | |  | |          | |
-//     |                ...  ...          ...  inputCh (Concurrency x 1)
+//     |                ...  ...          ...  input queues (Concurrency x M)
 //     v                | |  | |          | |
-//     inputHolderCh          +++  +++          +++
+//     recycled chunks        +++  +++          +++
 //     v                 ^    ^            ^
 //     |                 |    |            |
 //     |          +------o----+            |
@@ -97,6 +101,9 @@ type ShuffleExec struct {
 
 	finishCh chan struct{}
 	outputCh chan *shuffleOutput
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
 }
 
 type shuffleOutput struct {
@@ -116,6 +123,19 @@ func (e *ShuffleExec) Open(ctx context.Context) error {
 		return err
 	}
 
+	if e.memTracker != nil {
+		e.memTracker.Reset()
+	} else {
+		e.memTracker = memory.NewTracker(e.ID(), -1)
+	}
+	e.memTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.MemTracker)
+	if e.diskTracker != nil {
+		e.diskTracker.Reset()
+	} else {
+		e.diskTracker = disk.NewTracker(e.ID(), -1)
+	}
+	e.diskTracker.AttachTo(e.Ctx().GetSessionVars().StmtCtx.DiskTracker)
+
 	e.prepared = false
 	e.finishCh = make(chan struct{}, 1)
 	e.outputCh = make(chan *shuffleOutput, e.concurrency+len(e.dataSources))
@@ -123,9 +143,8 @@ func (e *ShuffleExec) Open(ctx context.Context) error {
 	for _, w := range e.workers {
 		w.finishCh = e.finishCh
 
-		for _, r := range w.receivers {
-			r.inputCh = make(chan *chunk.Chunk, 1)
-			r.inputHolderCh = make(chan *chunk.Chunk, 1)
+		for i, r := range w.receivers {
+			r.input = newShuffleInputQueue(e.Ctx(), exec.RetTypes(e.dataSources[i]), e.dataSources[i].MaxChunkSize(), e.memTracker, e.diskTracker)
 		}
 
 		w.outputCh = e.outputCh
@@ -135,9 +154,6 @@ func (e *ShuffleExec) Open(ctx context.Context) error {
 			return err
 		}
 
-		for i, r := range w.receivers {
-			r.inputHolderCh <- exec.NewFirstChunk(e.dataSources[i])
-		}
 		w.outputHolderCh <- exec.NewFirstChunk(e)
 	}
 
@@ -149,14 +165,6 @@ func (e *ShuffleExec) Close() error {
 	var firstErr error
 	if !e.prepared {
 		for _, w := range e.workers {
-			for _, r := range w.receivers {
-				if r.inputHolderCh != nil {
-					close(r.inputHolderCh)
-				}
-				if r.inputCh != nil {
-					close(r.inputCh)
-				}
-			}
 			if w.outputHolderCh != nil {
 				close(w.outputHolderCh)
 			}
@@ -168,10 +176,18 @@ func (e *ShuffleExec) Close() error {
 	if e.finishCh != nil {
 		close(e.finishCh)
 	}
+	// outputCh is closed after all the workers and splitters exit, so the
+	// input queues can be closed safely after it's cleared.
+	if e.outputCh != nil {
+		channel.Clear(e.outputCh)
+	}
 	for _, w := range e.workers {
 		for _, r := range w.receivers {
-			if r.inputCh != nil {
-				channel.Clear(r.inputCh)
+			if r.input != nil {
+				if err := r.input.close(); err != nil && firstErr == nil {
+					firstErr = err
+				}
+				r.input = nil
 			}
 		}
 		// close child executor of each worker
@@ -179,9 +195,6 @@ func (e *ShuffleExec) Close() error {
 			firstErr = err
 		}
 	}
-	if e.outputCh != nil {
-		channel.Clear(e.outputCh)
-	}
 	e.executed = false
 
 	if e.RuntimeStats() != nil {
@@ -274,7 +287,7 @@ func (e *ShuffleExec) fetchDataAndSplit(ctx context.Context, dataSourceIndex int
 			recoveryShuffleExec(e.outputCh, r)
 		}
 		for _, w := range e.workers {
-			close(w.receivers[dataSourceIndex].inputCh)
+			w.receivers[dataSourceIndex].input.finish()
 		}
 		waitGroup.Done()
 	}()
@@ -304,43 +317,51 @@ func (e *ShuffleExec) fetchDataAndSplit(ctx context.Context, dataSourceIndex int
 		numRows := chk.NumRows()
 		for i := 0; i < numRows; i++ {
 			workerIdx := workerIndices[i]
-			w := e.workers[workerIdx]
+			input := e.workers[workerIdx].receivers[dataSourceIndex].input
 
 			if results[workerIdx] == nil {
-				select {
-				case <-e.finishCh:
-					return
-				case results[workerIdx] = <-w.receivers[dataSourceIndex].inputHolderCh:
-					//nolint: revive
-					break
-				}
+				results[workerIdx] = input.allocChunk()
 			}
 			results[workerIdx].AppendRow(chk.GetRow(i))
 			if results[workerIdx].IsFull() {
-				w.receivers[dataSourceIndex].inputCh <- results[workerIdx]
+				if !e.pushToWorker(input, results[workerIdx]) {
+					return
+				}
 				results[workerIdx] = nil
 			}
 		}
 	}
 	for i, w := range e.workers {
 		if results[i] != nil {
-			w.receivers[dataSourceIndex].inputCh <- results[i]
+			if !e.pushToWorker(w.receivers[dataSourceIndex].input, results[i]) {
+				return
+			}
 			results[i] = nil
 		}
 	}
 }
 
+// pushToWorker pushes chk to the input queue of a worker, it returns false if
+// the splitter should exit.
+func (e *ShuffleExec) pushToWorker(input *shuffleInputQueue, chk *chunk.Chunk) bool {
+	ok, err := input.push(chk, e.finishCh)
+	if err != nil {
+		e.outputCh <- &shuffleOutput{err: err}
+		return false
+	}
+	return ok
+}
+
 var _ exec.Executor = &shuffleReceiver{}
 
-// shuffleReceiver receives chunk from dataSource through inputCh
+// shuffleReceiver receives chunk from dataSource through input
 type shuffleReceiver struct {
 	exec.BaseExecutor
 
 	finishCh <-chan struct{}
 	executed bool
 
-	inputCh       chan *chunk.Chunk
-	inputHolderCh chan *chunk.Chunk
+	input *shuffleInputQueue
 }
 
 // Open implements the Executor Open interface.
@@ -358,27 +379,152 @@ func (e *shuffleReceiver) Close() error {
 }
 
 // Next implements the Executor Next interface.
-// It is called by `Tail` executor within "shuffle", to fetch data from `DataSource` by `inputCh`.
+// It is called by `Tail` executor within "shuffle", to fetch data from `DataSource` by `input`.
 func (e *shuffleReceiver) Next(_ context.Context, req *chunk.Chunk) error {
 	req.Reset()
 	if e.executed {
 		return nil
 	}
-	select {
-	case <-e.finishCh:
+	ok, err := e.input.pop(req, e.finishCh)
+	if err != nil {
+		return err
+	}
+	if !ok {
 		e.executed = true
-		return nil
-	case result, ok := <-e.inputCh:
-		if !ok || result.NumRows() == 0 {
-			e.executed = true
-			return nil
+	}
+	return nil
+}
+
+// shuffleQueueMaxChunks is the max number of chunks buffered by a shuffleInputQueue.
+const shuffleQueueMaxChunks = 32
+
+// shuffleInputQueue buffers the chunks sent from the splitter of a dataSource
+// to a worker. The chunks are kept in a chunk.RowContainer which is tracked by
+// the memory tracker of ShuffleExec and spills them to disk when the memory
+// quota of the query is exceeded, so that a worker which receives most of the
+// rows of a skewed dataSource doesn't make the query get killed.
+type shuffleInputQueue struct {
+	mu sync.Mutex
+	rc *chunk.RowContainer
+	// readIdx is the index of the next chunk to pop in rc.
+	readIdx int
+	// finished indicates no more chunks will be pushed.
+	finished bool
+
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+func newShuffleInputQueue(sctx sessionctx.Context, fieldTypes []*types.FieldType, chunkSize int,
+	memTracker *memory.Tracker, diskTracker *disk.Tracker) *shuffleInputQueue {
+	q := &shuffleInputQueue{
+		rc:       chunk.NewRowContainer(fieldTypes, chunkSize),
+		notEmpty: make(chan struct{}, 1),
+		notFull:  make(chan struct{}, 1),
+	}
+	q.rc.GetMemTracker().AttachTo(memTracker)
+	q.rc.GetMemTracker().SetLabel(memory.LabelForRowContainer)
+	q.rc.GetDiskTracker().AttachTo(diskTracker)
+	q.rc.GetDiskTracker().SetLabel(memory.LabelForRowContainer)
+	// The spill action is always initialized because resetting a spilled
+	// RowContainer resets its spill action.
+	actionSpill := q.rc.ActionSpill()
+	if vardef.EnableTmpStorageOnOOM.Load() {
+		failpoint.Inject("testShuffleInputQueueSpill", func(val failpoint.Value) {
+			if val.(bool) {
+				actionSpill = q.rc.ActionSpillForTest()
+			}
+		})
+		sctx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(actionSpill)
+	}
+	return q
+}
+
+func notifyShuffleQueue(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// allocChunk allocates a chunk to collect the rows which will be pushed.
+func (q *shuffleInputQueue) allocChunk() *chunk.Chunk {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.rc.AllocChunk()
+}
+
+// push appends chk to the queue, chk can't be used by the caller any more.
+// It blocks when the queue is full, and returns false if finishCh is closed
+// before chk is pushed.
+func (q *shuffleInputQueue) push(chk *chunk.Chunk, finishCh <-chan struct{}) (bool, error) {
+	for {
+		q.mu.Lock()
+		if q.rc.NumChunks() < shuffleQueueMaxChunks {
+			err := q.rc.Add(chk)
+			q.mu.Unlock()
+			notifyShuffleQueue(q.notEmpty)
+			return err == nil, err
+		}
+		q.mu.Unlock()
+		select {
+		case <-q.notFull:
+		case <-finishCh:
+			return false, nil
 		}
-		req.SwapColumns(result)
-		e.inputHolderCh <- result
-		return nil
 	}
 }
 
+// finish indicates no more chunks will be pushed.
+func (q *shuffleInputQueue) finish() {
+	q.mu.Lock()
+	q.finished = true
+	q.mu.Unlock()
+	notifyShuffleQueue(q.notEmpty)
+}
+
+// pop copies the rows of the next chunk in the queue to req. It returns false
+// if there are no more chunks or finishCh is closed.
+func (q *shuffleInputQueue) pop(req *chunk.Chunk, finishCh <-chan struct{}) (bool, error) {
+	for {
+		q.mu.Lock()
+		if numChunks := q.rc.NumChunks(); q.readIdx < numChunks {
+			chk, err := q.rc.GetChunk(q.readIdx)
+			if err != nil {
+				q.mu.Unlock()
+				return false, err
+			}
+			// The rows are copied because the chunks in rc are reused after
+			// rc is reset.
+			req.Append(chk, 0, chk.NumRows())
+			q.readIdx++
+			if q.readIdx == numChunks {
+				q.readIdx = 0
+				err = q.rc.Reset()
+			}
+			q.mu.Unlock()
+			notifyShuffleQueue(q.notFull)
+			return err == nil, err
+		}
+		finished := q.finished
+		q.mu.Unlock()
+		if finished {
+			return false, nil
+		}
+		select {
+		case <-q.notEmpty:
+		case <-finishCh:
+			return false, nil
+		}
+	}
+}
+
+func (q *shuffleInputQueue) close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.rc.Close()
+}
+
 // shuffleWorker is the multi-thread worker executing child executors within "partition".
 type shuffleWorker struct {
 	childExec exec.Executor
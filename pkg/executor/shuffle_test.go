@@ -21,6 +21,8 @@ import (
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/pingcap/tidb/pkg/util/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -63,3 +65,46 @@ func TestPartitionRangeSplitter(t *testing.T) {
 		require.Equal(t, expected[i], obtained[i])
 	}
 }
+
+func TestShuffleInputQueueSpill(t *testing.T) {
+	ctx := mock.NewContext()
+	fieldTypes := []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}
+	memTracker := memory.NewTracker(-1, -1)
+	diskTracker := disk.NewTracker(-1, -1)
+	q := newShuffleInputQueue(ctx, fieldTypes, 4, memTracker, diskTracker)
+	actionSpill := q.rc.ActionSpillForTest()
+	memTracker.SetBytesLimit(1)
+	memTracker.FallbackOldAndSetNewAction(actionSpill)
+
+	finishCh := make(chan struct{})
+	for i := range 8 {
+		chk := q.allocChunk()
+		for j := range 4 {
+			chk.AppendInt64(0, int64(i*4+j))
+		}
+		ok, err := q.push(chk, finishCh)
+		require.NoError(t, err)
+		require.True(t, ok)
+		actionSpill.WaitForTest()
+	}
+	require.True(t, q.rc.AlreadySpilledSafeForTest())
+	require.Greater(t, diskTracker.BytesConsumed(), int64(0))
+	q.finish()
+
+	req := chunk.NewChunkWithCapacity(fieldTypes, 4)
+	expected := int64(0)
+	for {
+		req.Reset()
+		ok, err := q.pop(req, finishCh)
+		require.NoError(t, err)
+		if !ok {
+			break
+		}
+		for i := range req.NumRows() {
+			require.Equal(t, expected, req.GetRow(i).GetInt64(0))
+			expected++
+		}
+	}
+	require.Equal(t, int64(32), expected)
+	require.NoError(t, q.close())
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
 	"github.com/pingcap/tidb/pkg/executor/internal/querywatch"
 	executor_metrics "github.com/pingcap/tidb/pkg/executor/metrics"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -202,6 +203,18 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAlterRange(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
+	case *ast.NotifyStmt:
+		err = e.executeNotify(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2311,6 +2324,24 @@ func (e *SimpleExec) executeDropQueryWatch(s *ast.DropQueryWatchStmt) error {
 	return querywatch.ExecDropQueryWatch(e.Ctx(), s.IntValue)
 }
 
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	return procedure.CreateProcedure(ctx, e.Ctx(), sysSession, e.is, s)
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	sysSession, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(ctx, sysSession)
+	return procedure.DropProcedure(ctx, e.Ctx(), sysSession, s)
+}
+
 func (e *SimpleExec) executeDropUser(ctx context.Context, s *ast.DropUserStmt) error {
 	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnPrivilege)
 	// Check privileges.
@@ -2866,6 +2897,12 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Statements that implicitly use or modify tables in the mysql database.
 	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
 		return true
+	// Stored procedures are persisted in mysql.routines.
+	case *ast.ProcedureInfo, *ast.DropProcedureStmt:
+		return true
+	// Events are persisted in mysql.tidb_timers.
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
 	// Transaction-control and locking statements.  BEGIN, LOCK TABLES, SET autocommit = 1 (if the value is not already 1), START TRANSACTION, UNLOCK TABLES.
 	// (handled in other place)
 	// Data loading statements. LOAD DATA
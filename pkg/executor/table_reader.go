@@ -37,6 +37,7 @@ import (
 	isctx "github.com/pingcap/tidb/pkg/infoschema/context"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/planctx"
@@ -192,6 +193,12 @@ type TableReaderExecutor struct {
 	// If dummy flag is set, this is not a real TableReader, it just provides the KV ranges for UnionScan.
 	// Used by the temporary table, cached table.
 	dummy bool
+
+	// runtimeFilterSelections are the Selections whose conditions are set by the runtime filters of the hash join.
+	runtimeFilterSelections []*runtimeFilterSelection
+	// lookupRanges are the point ranges of the keys set by the hash join at the adaptive checkpoint, which replace the
+	// ranges of the plan if not nil.
+	lookupRanges []*ranger.Range
 }
 
 // Table implements the dataSourceExecutor interface.
@@ -203,6 +210,28 @@ func (e *TableReaderExecutor) setDummy() {
 	e.dummy = true
 }
 
+// SetRuntimeFilterConditions implements the join.RuntimeFilterTarget interface.
+func (e *TableReaderExecutor) SetRuntimeFilterConditions(selectionID int, conds []expression.Expression) {
+	setRuntimeFilterConditions(e.runtimeFilterSelections, selectionID, conds)
+}
+
+// LookupKeyColumn implements the join.LookupTarget interface. Only the full scan of the table whose handle is the
+// integer primary key can look up the keys.
+func (e *TableReaderExecutor) LookupKeyColumn() int {
+	tblInfo := e.table.Meta()
+	if e.dummy || e.kvRangeBuilder != nil || e.corColInAccess || e.storeType != kv.TiKV ||
+		tblInfo == nil || !tblInfo.PKIsHandle || tblInfo.GetPartitionInfo() != nil ||
+		len(e.ranges) != 1 || !e.ranges[0].IsFullRange(mysql.HasUnsignedFlag(tblInfo.GetPkColInfo().GetFlag())) {
+		return -1
+	}
+	return lookupKeyColumnOffset(e.Schema(), tblInfo.GetPkColInfo().ID)
+}
+
+// SetLookupKeys implements the join.LookupTarget interface.
+func (e *TableReaderExecutor) SetLookupKeys(keys []types.Datum) {
+	e.lookupRanges = buildLookupRanges(keys)
+}
+
 func (e *TableReaderExecutor) memUsage() int64 {
 	const sizeofTableReaderExecutor = int64(unsafe.Sizeof(*(*TableReaderExecutor)(nil)))
 
@@ -249,6 +278,9 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 			}
 		}
 	}
+	if err = fillRuntimeFilterConditions(e.buildPBCtx, e.dagPB, e.runtimeFilterSelections); err != nil {
+		return err
+	}
 	if e.dctx.RuntimeStatsColl != nil {
 		collExec := true
 		e.dagPB.CollectExecutionSummaries = &collExec
@@ -263,7 +295,11 @@ func (e *TableReaderExecutor) Open(ctx context.Context) error {
 
 	e.resultHandler = &tableResultHandler{}
 
-	firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(e.ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
+	ranges := e.ranges
+	if e.lookupRanges != nil {
+		ranges = e.lookupRanges
+	}
+	firstPartRanges, secondPartRanges := distsql.SplitRangesAcrossInt64Boundary(ranges, e.keepOrder, e.desc, e.table.Meta() != nil && e.table.Meta().IsCommonHandle)
 
 	// Treat temporary table as dummy table, avoid sending distsql request to TiKV.
 	// Calculate the kv ranges here, UnionScan rely on this kv ranges.
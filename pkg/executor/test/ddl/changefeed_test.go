@@ -0,0 +1,171 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangefeed(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10), b blob)")
+	tk.MustExec("create view v as select * from t")
+	dir := t.TempDir()
+	sink := "'file://" + dir + "'"
+
+	tk.MustGetErrMsg("create changefeed cf for table t into "+sink+" format 'json'",
+		"[ddl:8272]Invalid changefeed 'cf': unsupported format 'json'")
+	tk.MustGetErrMsg("create changefeed cf for table t into 'kafka://127.0.0.1:9092/t'",
+		"[ddl:8272]Invalid changefeed 'cf': kafka brokers are not supported, use a URI like 'segment+file:///path?topic=t' to write the log segments of a topic")
+	tk.MustGetErrMsg("create changefeed cf for table t into 'segment+file://"+dir+"' format avro",
+		"[ddl:8272]Invalid changefeed 'cf': avro format is not supported by the segment sink")
+	tk.MustGetErrMsg("create changefeed cf for table t into 'segment+file://"+dir+"?topic=a/b'",
+		"[ddl:8272]Invalid changefeed 'cf': invalid topic 'a/b'")
+	tk.MustGetErrMsg("create changefeed cf for table v into "+sink,
+		"[ddl:8272]Invalid changefeed 'cf': the table is a view, a sequence or a temporary table")
+	tk.MustGetErrMsg("create changefeed cf for table mysql.user into "+sink,
+		"[ddl:8272]Invalid changefeed 'cf': the table is a system table")
+	tk.MustGetErrCode("create changefeed cf for table t_not_exist into "+sink, errno.ErrNoSuchTable)
+
+	tk.MustExec("create changefeed cf for table t into " + sink + " format canal-json")
+	tk.MustGetErrCode("create changefeed cf for table t into "+sink, errno.ErrChangefeedExists)
+	tk.MustExec("create changefeed if not exists cf for table t into " + sink)
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 8268 Changefeed 'cf' already exists"))
+	rows := tk.MustQuery("show changefeeds").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []any{"cf", "t", rows[0][2], "file://" + dir, "canal-json"}, rows[0][:5])
+	id := rows[0][2].(string)
+
+	readMessages := func(pattern string, n int) []map[string]any {
+		var messages []map[string]any
+		require.Eventually(t, func() bool {
+			// The files are sorted by the names in the order of the changes.
+			files, err := filepath.Glob(pattern)
+			require.NoError(t, err)
+			messages = messages[:0]
+			for _, file := range files {
+				content, err := os.ReadFile(file)
+				require.NoError(t, err)
+				for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+					msg := make(map[string]any)
+					require.NoError(t, json.Unmarshal([]byte(line), &msg))
+					messages = append(messages, msg)
+				}
+			}
+			return len(messages) >= n
+		}, 30*time.Second, 100*time.Millisecond)
+		require.Len(t, messages, n)
+		return messages
+	}
+	readChanges := func(n int) []string {
+		var changes []string
+		for _, msg := range readMessages(filepath.Join(dir, id, "*.json"), n) {
+			data := msg["data"].([]any)[0].(map[string]any)
+			changes = append(changes, msg["type"].(string)+" "+data["id"].(string)+" "+data["v"].(string))
+		}
+		return changes
+	}
+	waitWritten := func(n int) {
+		require.Eventually(t, func() bool {
+			for _, row := range tk.MustQuery("show changefeeds").Rows() {
+				if row[0] == "cf" {
+					return row[6] == strconv.Itoa(n) && row[5] != "0"
+				}
+			}
+			return false
+		}, 30*time.Second, 100*time.Millisecond)
+	}
+
+	// The changes are read from the committed versions of the rows, the changes
+	// of a row in a transaction are streamed as a single change.
+	tk.MustExec("begin optimistic")
+	tk.MustExec("insert into t values (1, 'a', null), (2, 'b', 0x01)")
+	tk.MustExec("update t set v = 'c' where id = 1")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustExec("commit")
+	messages := readMessages(filepath.Join(dir, id, "*.json"), 1)
+	require.Equal(t, "test", messages[0]["database"])
+	require.Equal(t, []any{"id"}, messages[0]["pkNames"])
+	require.Equal(t, []string{"INSERT 1 c"}, readChanges(1))
+
+	tk.MustExec("update t set v = 'd' where id = 1")
+	tk.MustExec("insert into t values (2, 'b', 0x01)")
+	require.Equal(t, []string{"INSERT 1 c", "UPDATE 1 d", "INSERT 2 b"}, readChanges(3))
+
+	// Every committed change is streamed in the commit order, even if the row
+	// is changed again before the changes are read.
+	tk.MustExec("set @@tidb_dml_type = bulk")
+	tk.MustExec("insert into t values (5, 'x', null)")
+	tk.MustQuery("show warnings").CheckContain("Pipelined DML can not be used on tables with changefeeds. Fallback to standard mode")
+	tk.MustExec("set @@tidb_dml_type = standard")
+	tk.MustExec("delete from t where id = 5")
+	tk.MustExec("update t set v = 'e' where id = 1")
+	tk.MustExec("update t set v = 'f' where id = 1")
+	expected := []string{"INSERT 1 c", "UPDATE 1 d", "INSERT 2 b", "INSERT 5 x", "DELETE 5 x", "UPDATE 1 e", "UPDATE 1 f"}
+	require.Equal(t, expected, readChanges(7))
+	waitWritten(7)
+	// The consumed changes are removed from the log.
+	tk.MustQuery("select count(*) from mysql.tidb_changefeed_log").Check(testkit.Rows("0"))
+
+	// TRUNCATE TABLE isn't streamed, the changes after it are streamed.
+	tk.MustExec("truncate table t")
+	tk.MustExec("insert into t values (3, 'e', null)")
+	require.Equal(t, append(expected, "INSERT 3 e"), readChanges(8))
+	waitWritten(8)
+
+	// The changefeeds are not copied by CREATE TABLE LIKE.
+	tk.MustExec("create table t2 like t")
+	require.Len(t, tk.MustQuery("show changefeeds").Rows(), 1)
+
+	// The changefeed is dropped with its checkpoint.
+	tk.MustExec("drop changefeed cf")
+	tk.MustQuery("show changefeeds").Check(testkit.Rows())
+	tk.MustExec("insert into t values (4, 'f', null)")
+	// The checkpoint written by a running batch is removed when the task is
+	// cleaned up.
+	require.Eventually(t, func() bool {
+		return tk.MustQuery("select count(*) from mysql.tidb_changefeed_checkpoint").Rows()[0][0] == "0"
+	}, 30*time.Second, 100*time.Millisecond)
+
+	// The segment sink writes the changes to the log segments of the topic, the
+	// first segment starts at offset 0.
+	segmentDir := t.TempDir()
+	tk.MustExec("create changefeed cf_segment for table t into 'segment+file://" + segmentDir + "?topic=t_changes'")
+	tk.MustExec("update t set v = 'g' where id = 3")
+	require.Eventually(t, func() bool {
+		content, err := os.ReadFile(filepath.Join(segmentDir, "t_changes-0", "00000000000000000000.log"))
+		return err == nil && strings.Contains(string(content), `"data":[{"b":null,"id":"3","v":"g"}],"old":[{"b":null,"id":"3","v":"e"}]`)
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustExec("drop changefeed cf_segment")
+	require.Eventually(t, func() bool {
+		return tk.MustQuery("select count(*) from mysql.tidb_changefeed_checkpoint").Rows()[0][0] == "0" &&
+			tk.MustQuery("select count(*) from mysql.tidb_changefeed_log").Rows()[0][0] == "0"
+	}, 30*time.Second, 100*time.Millisecond)
+	tk.MustGetErrCode("drop changefeed cf", errno.ErrChangefeedNotExists)
+	tk.MustExec("drop changefeed if exists cf")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 8269 Changefeed 'cf' doesn't exist"))
+}
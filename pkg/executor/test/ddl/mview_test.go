@@ -0,0 +1,127 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/testkit"
+)
+
+func TestCreateAndDropMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table sales (region varchar(10), product int, amount decimal(10, 2))")
+	tk.MustExec("insert into sales values ('east', 1, 10), ('east', 2, 20), ('west', 1, 5), (null, 1, 1)")
+
+	tk.MustExec("create materialized view mv (region, total, cnt) as select region, sum(amount), count(*) from sales group by region")
+	tk.MustQuery("select * from mv order by region").Check(testkit.Rows("<nil> 1.00 1", "east 30.00 2", "west 5.00 1"))
+	tk.MustGetErrCode("create materialized view mv as select 1", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+	tk.MustGetErrCode("create materialized view mv2 (a) as select region, product from sales", errno.ErrViewWrongList)
+
+	// The rows of the view can only be changed by refreshing it.
+	tk.MustGetErrCode("insert into mv values ('north', 1, 1)", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("update mv set cnt = 0", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("delete from mv", errno.ErrNonUpdatableTable)
+	tk.MustGetErrCode("create trigger tr after insert on mv for each row begin end", errno.ErrTrgOnViewOrTempTable)
+
+	tk.MustGetErrCode("drop table mv", errno.ErrBadTable)
+	tk.MustGetErrCode("drop materialized view sales", errno.ErrWrongObject)
+	tk.MustExec("drop materialized view mv")
+	tk.MustGetErrCode("select * from mv", errno.ErrNoSuchTable)
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1051 Unknown table 'test.mv'"))
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+}
+
+func TestRefreshMaterializedView(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table sales (id int primary key, region varchar(10), day date, amount int)")
+	tk.MustExec("insert into sales values (1, 'east', '2025-01-01', 10), (2, 'west', '2025-01-01', 20), (3, 'east', '2025-01-02', 30)")
+	tk.MustExec("create materialized view mv as select region, day, sum(amount) as total, count(*) as cnt from sales where amount > 0 group by region, day")
+	tk.MustExec("create materialized view mv_all as select sum(amount) as total from sales")
+	tk.MustQuery("select * from mv order by region, day").Check(testkit.Rows(
+		"east 2025-01-01 10 1", "east 2025-01-02 30 1", "west 2025-01-01 20 1"))
+
+	// The changes are logged for the incrementally refreshable view only.
+	tk.MustExec("insert into sales values (4, 'east', '2025-01-01', 5)")
+	tk.MustExec("update sales set region = 'north' where id = 2")
+	tk.MustExec("delete from sales where id = 3")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("4"))
+	tk.MustQuery("select count(distinct commit_ts), max(seq) from mysql.tidb_mview_log").Check(testkit.Rows("3 2"))
+	// The log is read and purged by the primary key.
+	tk.MustHavePlan("select commit_ts, seq, group_key from mysql.tidb_mview_log where mview_id = 1", "TableRangeScan")
+	tk.MustHavePlan("delete from mysql.tidb_mview_log where mview_id = 1 and (commit_ts, seq) in ((1, 1), (2, 1))", "Batch_Point_Get")
+	tk.MustQuery("select * from mv order by region, day").Check(testkit.Rows(
+		"east 2025-01-01 10 1", "east 2025-01-02 30 1", "west 2025-01-01 20 1"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by region, day").Check(testkit.Rows(
+		"east 2025-01-01 15 2", "north 2025-01-01 20 1"))
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	// The rolled back changes don't matter.
+	tk.MustExec("begin")
+	tk.MustExec("insert into sales values (5, 'south', '2025-01-03', 1)")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	// Truncating the base table requires a complete refresh.
+	tk.MustExec("truncate table sales")
+	tk.MustExec("insert into sales values (1, 'east', '2025-01-01', 7)")
+	tk.MustExec("refresh materialized view mv incremental")
+	tk.MustQuery("select * from mv").Check(testkit.Rows("east 2025-01-01 7 1"))
+
+	tk.MustGetErrCode("refresh materialized view mv_all incremental", errno.ErrUnsupportedDDLOperation)
+	tk.MustQuery("select * from mv_all").Check(testkit.Rows("60"))
+	tk.MustExec("refresh materialized view mv_all")
+	tk.MustQuery("select * from mv_all").Check(testkit.Rows("7"))
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select * from mv").Check(testkit.Rows("east 2025-01-01 7 1"))
+	tk.MustGetErrCode("refresh materialized view sales", errno.ErrWrongObject)
+}
+
+func TestMaterializedViewRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table sales (region varchar(10), product int, amount int)")
+	tk.MustExec("insert into sales values ('east', 1, 10), ('east', 2, 20), ('west', 1, 5)")
+	tk.MustExec("create materialized view mv as select region, product, sum(amount) as total, count(*) as cnt, max(amount) as top from sales group by region, product")
+	// Change the base table to tell the results read from the stale view.
+	tk.MustExec("insert into sales values ('west', 1, 100)")
+
+	tk.MustQuery("select region, sum(amount) from sales group by region order by region").Check(testkit.Rows("east 30", "west 105"))
+	tk.MustExec("set @@tidb_opt_enable_materialized_view_rewrite = on")
+	tk.MustQuery("select region, sum(amount) from sales group by region order by region").Check(testkit.Rows("east 30", "west 5"))
+	tk.MustQuery("select region, product, count(*) c from sales group by region, product order by c desc, region, product limit 1").Check(testkit.Rows("east 1 1"))
+	tk.MustQuery("select product, count(*), max(amount) from sales group by 1 order by 1").Check(testkit.Rows("1 2 10", "2 1 20"))
+	tk.MustQuery("select sum(amount) from sales").Check(testkit.Rows("35"))
+	tk.MustQuery("explain select region, sum(amount) from sales group by region").CheckContain("table:mv")
+
+	// The queries which can't be answered by the view read the base table.
+	tk.MustQuery("select region, min(amount) from sales group by region order by region").Check(testkit.Rows("east 10", "west 5"))
+	tk.MustQuery("select region, sum(amount) from sales where product = 1 group by region order by region").Check(testkit.Rows("east 10", "west 105"))
+	tk.MustQuery("select region, avg(amount) from sales group by region order by region").Check(testkit.Rows("east 15.0000", "west 52.5000"))
+	tk.MustQuery("explain select region, sum(amount) from sales group by region having sum(amount) > 0").CheckNotContain("table:mv")
+
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select region, sum(amount) from sales group by region order by region").Check(testkit.Rows("east 30", "west 105"))
+}
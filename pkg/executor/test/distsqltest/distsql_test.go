@@ -20,6 +20,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/testkit"
@@ -75,3 +77,88 @@ func TestDistsqlPartitionTableConcurrency(t *testing.T) {
 		tk.MustQueryWithContext(ctx, fmt.Sprintf("select * from %s limit 5", tbl))
 	}
 }
+
+func TestDescIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int, b int, c varchar(10), key idx(a, b desc))")
+	tk.MustQuery("show create table t").CheckContain("KEY `idx` (`a`,`b` DESC)")
+	tk.MustQuery("show index from t where Key_name = 'idx'").CheckAt([]int{4, 5}, testkit.Rows("a A", "b D"))
+	id := 0
+	for _, a := range []string{"null", "1", "2", "3"} {
+		for _, b := range []string{"null", "-1", "0", "1", "2"} {
+			id++
+			tk.MustExec(fmt.Sprintf("insert into t values(%d, %s, %s, '%d')", id, a, b, id))
+		}
+	}
+
+	// The index matches the orders which are the same as or reversed from the index columns.
+	tk.MustNotHavePlan("select a, b from t use index(idx) order by a, b desc", "Sort")
+	tk.MustNotHavePlan("select a, b from t use index(idx) order by a desc, b", "Sort")
+	tk.MustNotHavePlan("select * from t use index(idx) where a = 1 order by b limit 2", "Sort")
+	tk.MustHavePlan("select a, b from t use index(idx) order by a, b", "Sort")
+	tk.MustHavePlan("select a, b from t use index(idx) order by a desc, b desc", "Sort")
+
+	queries := []string{
+		"select %s a, b from t %s order by a, b desc",
+		"select %s a, b from t %s order by a desc, b",
+		"select %s * from t %s order by a, b desc limit 7",
+		"select %s * from t %s where a = 2 order by b limit 3",
+		"select %s * from t %s where a = 2 order by b desc limit 3",
+		"select %s a, b from t %s where a > 1 order by a, b desc",
+		"select %s a, b from t %s where a >= 1 and a < 3 order by a desc, b",
+		"select %s a, b from t %s where a = 1 and b > 0 order by b desc",
+		"select %s a, b from t %s where a = 1 and b <= 0 order by b",
+		"select %s a, b from t %s where a in (1, 3) and b between -1 and 1 order by a, b desc",
+		"select %s a, b from t %s where a = 2 and b is null",
+		"select %s a, b from t %s where a = 2 and b is not null order by b desc",
+		"select %s a, b from t %s where a is null order by a, b desc",
+		"select %s a, b from t %s where (a, b) > (1, 1) and (a, b) < (3, 0) order by a, b desc",
+		"select %s count(*) from t %s where b > 0",
+	}
+	check := func() {
+		for _, q := range queries {
+			expected := tk.MustQuery(fmt.Sprintf(q, "", "ignore index(idx)")).Rows()
+			tk.MustQuery(fmt.Sprintf(q, "/*+ use_index(t, idx) */", "")).Check(expected)
+		}
+	}
+	check()
+	tk.MustExec("admin check table t")
+
+	// The rows written in the transaction are merged with the index in the right order.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values(100, 1, 3, '100'), (101, 2, -2, '101'), (102, null, 5, '102')")
+	tk.MustExec("update t set b = b + 10 where a = 3 and b > 0")
+	tk.MustExec("delete from t where a = 2 and b = 0")
+	check()
+	tk.MustExec("commit")
+	check()
+	tk.MustExec("admin check table t")
+
+	// Index join probes the index with descending columns.
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("insert into t2 values(1, 0), (2, 1), (3, 12), (4, 1)")
+	tk.MustQuery("select /*+ inl_join(t) */ t.id from t2 join t on t.a = t2.a and t.b > t2.b order by t.id").Check(
+		tk.MustQuery("select /*+ hash_join(t) */ t.id from t2 join t ignore index(idx) on t.a = t2.a and t.b > t2.b order by t.id").Rows())
+
+	// Point get and batch point get on the unique index with descending columns.
+	tk.MustExec("create table t3(a int, b varchar(10), c int, unique key uk(a desc, b))")
+	tk.MustExec("insert into t3 values(1, 'x', 1), (1, 'y', 2), (2, 'x', 3), (3, 'z', 4)")
+	tk.MustPointGet("select c from t3 where a = 1 and b = 'y'").Check(testkit.Rows("2"))
+	tk.MustQuery("select c from t3 where (a, b) in ((1, 'x'), (2, 'x'), (3, 'z')) order by a desc").Check(testkit.Rows("4", "3", "1"))
+	tk.MustQuery("select c from t3 where (a, b) in ((1, 'x'), (2, 'x'), (3, 'z')) order by a").Check(testkit.Rows("1", "3", "4"))
+	tk.MustGetErrCode("insert into t3 values(1, 'x', 5)", errno.ErrDupEntry)
+	tk.MustQuery("select a, b from t3 use index(uk) where a < 3 order by a desc, b").Check(testkit.Rows("2 x", "1 x", "1 y"))
+	tk.MustExec("admin check table t3")
+
+	// The descending key parts are rejected on TiKV, which can't decode them yet.
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Store = config.StoreTypeTiKV
+	})
+	tk.MustGetErrCode("create table t4(a int, b int, key idx(a, b desc))", errno.ErrNotSupportedYet)
+	tk.MustExec("create table t4(a int, b int)")
+	tk.MustGetErrCode("alter table t4 add index idx(a, b desc)", errno.ErrNotSupportedYet)
+	tk.MustExec("alter table t4 add index idx(a, b)")
+}
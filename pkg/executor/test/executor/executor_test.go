@@ -1359,6 +1359,71 @@ func TestApplyCache(t *testing.T) {
 	require.Equal(t, "cache:OFF", value[ind:])
 }
 
+func TestAdaptiveExecutionCheckpoints(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, index idx(a))")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("analyze table t")
+	// The stats are not updated, so the rows are underestimated.
+	tk.MustExec("insert into t values (1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1),(1)")
+	applySQL := "SELECT count(a) FROM (SELECT (SELECT min(a) FROM t as t2 WHERE t2.a > t1.a) AS a from t as t1) t"
+	cteSQL := "with cte as (select a from t where a > 0) select count(*) from cte c1 join cte c2 on c1.a = c2.a"
+	expectedApply := tk.MustQuery(applySQL).Rows()
+	expectedCTE := tk.MustQuery(cteSQL).Rows()
+
+	result := tk.MustQuery("explain analyze " + applySQL)
+	require.Contains(t, result.Rows()[1][0], "Apply")
+	require.NotContains(t, result.Rows()[1][5], "adaptive")
+	require.Contains(t, result.Rows()[1][5], "cache:OFF")
+
+	tk.MustExec("set tidb_enable_adaptive_execution = on")
+	tk.MustQuery(applySQL).Check(expectedApply)
+	tk.MustQuery(cteSQL).Check(expectedCTE)
+	result = tk.MustQuery("explain analyze " + applySQL)
+	require.Contains(t, result.Rows()[1][0], "Apply")
+	require.Regexp(t, "adaptive:{checkpoint:outer, est_rows:1.00, act_rows:21, decision:enable_apply_cache}.*cache:ON", result.Rows()[1][5])
+
+	result = tk.MustQuery("explain analyze " + cteSQL)
+	require.Regexp(t, "adaptive:{checkpoint:cte, est_rows:.*, act_rows:21, decision:none}", fmt.Sprintf("%v", result.Rows()))
+
+	// The CTE is underestimated, so the hash join builds the hash table from the other child instead.
+	tk.MustExec("create table s(a int)")
+	tk.MustExec("insert into s values (1),(2),(3),(4),(5)")
+	tk.MustExec("analyze table s")
+	swapSQL := "with cte as (select a from t where a > 0) select /*+ hash_join(cte, s) */ count(*) from cte join s on cte.a = s.a union all select count(*) from cte"
+	tk.MustExec("set tidb_enable_adaptive_execution = off")
+	expectedSwap := tk.MustQuery(swapSQL).Rows()
+	tk.MustExec("set tidb_enable_adaptive_execution = on")
+	tk.MustQuery(swapSQL).Check(expectedSwap)
+	result = tk.MustQuery("explain analyze " + swapSQL)
+	require.Regexp(t, "adaptive:{checkpoint:cte, est_rows:.*, act_rows:21, decision:swap_build_side}", fmt.Sprintf("%v", result.Rows()))
+
+	// The build side is overestimated, so the hash join probes with fewer workers.
+	tk.MustExec("create table u(a int)")
+	tk.MustExec("insert into u select a from s")
+	for range 5 {
+		tk.MustExec("insert into u select a from u")
+	}
+	tk.MustExec("analyze table u")
+	tk.MustExec("delete from u where a > 1")
+	tk.MustExec("delete from u limit 30")
+	concurrencySQL := "select /*+ hash_join_build(u) */ count(*) from u join s on u.a = s.a"
+	tk.MustExec("set tidb_enable_adaptive_execution = off")
+	expectedConcurrency := tk.MustQuery(concurrencySQL).Rows()
+	tk.MustExec("set tidb_enable_adaptive_execution = on")
+	tk.MustQuery(concurrencySQL).Check(expectedConcurrency)
+	result = tk.MustQuery("explain analyze " + concurrencySQL)
+	require.Regexp(t, "adaptive:{checkpoint:build, est_rows:159.84, act_rows:2, decision:reduce_concurrency}", fmt.Sprintf("%v", result.Rows()))
+
+	// The cache isn't enabled if the outer rows are estimated well.
+	tk.MustExec("set tidb_adaptive_execution_misestimate_ratio = 100")
+	result = tk.MustQuery("explain analyze " + applySQL)
+	require.Regexp(t, "decision:none}.*cache:OFF", result.Rows()[1][5])
+}
+
 func TestCollectDMLRuntimeStats(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
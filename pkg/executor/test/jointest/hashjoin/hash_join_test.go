@@ -716,3 +716,130 @@ func TestIssue56825(t *testing.T) {
 		tk.MustQuery("select * from t1 right join t2 on t1.id = t2.id and t1.col1 <= t2.col1 order by t2.id").Check(testkit.Rows("1 2 1 2 3 4 5 6", "<nil> <nil> 3 4 5 6 7 8", "<nil> <nil> 4 5 6 7 8 9"))
 	}
 }
+
+func TestHashJoinRuntimeFilter(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(join.EnableHashJoinV2)
+	tk.MustExec("create table t1 (a int, b varchar(10), c int, index idx_a(a))")
+	tk.MustExec("create table t2 (a int, b varchar(10))")
+	tk.MustExec("create table t3 (a int, b varchar(10))")
+	sql := "insert into t1 values"
+	for i := range 100 {
+		if i != 0 {
+			sql += ","
+		}
+		sql += fmt.Sprintf("(%d, 'v%d', %d)", i, i, i%10)
+	}
+	tk.MustExec(sql)
+	tk.MustExec("insert into t2 values (1, 'v1'), (5, 'v5'), (5, 'v5'), (50, 'v50'), (null, null), (200, 'v200')")
+	tk.MustExec("analyze table t1, t2, t3")
+
+	// The runtime filters are only generated with the hint.
+	rows := tk.MustQuery("explain format='brief' select /*+ hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.NotContains(t, fmt.Sprintf("%v", rows), "runtime filter")
+	rows = tk.MustQuery("explain format='brief' select /*+ runtime_filter(), hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a and t1.b = t2.b").Rows()
+	require.Regexp(t, "HashJoin", rows[1][0])
+	require.Regexp(t, "runtime filter:0\\[IN\\] <- test.t2.a, 1\\[IN\\] <- test.t2.b$", rows[1][4])
+	require.Regexp(t, "Selection", rows[7][0])
+	require.Equal(t, "runtime filter:0[IN] -> test.t1.a, 1[IN] -> test.t1.b", rows[7][4])
+	// The probe side can't be filtered if it's the outer side.
+	rows = tk.MustQuery("explain format='brief' select /*+ runtime_filter(), hash_join_build(t2) */ * from t1 left join t2 on t1.a = t2.a").Rows()
+	require.NotContains(t, fmt.Sprintf("%v", rows), "runtime filter")
+	// The bloom filter is probed in TiDB, so it's not shown on the Selection.
+	tk.MustExec("set tidb_runtime_filter_type='IN,BLOOM_FILTER'")
+	rows = tk.MustQuery("explain format='brief' select /*+ runtime_filter(), hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Contains(t, fmt.Sprintf("%v", rows), "runtime filter:0[IN,BLOOM_FILTER] <- test.t2.a")
+	require.Contains(t, fmt.Sprintf("%v", rows), "runtime filter:0[IN] -> test.t1.a")
+	tk.MustExec("set tidb_runtime_filter_type='BLOOM_FILTER'")
+	rows = tk.MustQuery("explain format='brief' select /*+ runtime_filter(), hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Contains(t, fmt.Sprintf("%v", rows), "runtime filter:0[BLOOM_FILTER] <- test.t2.a")
+	require.NotContains(t, fmt.Sprintf("%v", rows), "->")
+	tk.MustExec("set tidb_runtime_filter_type=default")
+
+	queries := []string{
+		"select %s t1.a, t1.b, t2.a from t1 join t2 on t1.a = t2.a order by t1.a",
+		"select %s t1.a, t1.b, t2.a from t1 join t2 on t1.b = t2.b order by t1.a",
+		"select %s t1.a, t1.b, t2.a from t1 join t2 on t1.a = t2.a and t1.b = t2.b where t1.c > 0 order by t1.a",
+		"select %s t2.a, t1.b from t2 left join t1 on t1.a = t2.a order by t2.a",
+		"select %s t1.a, t1.b from t1 where exists (select 1 from t2 where t1.a = t2.a) order by t1.a",
+		"select %s t1.a, t3.a from t1 join t3 on t1.a = t3.a",
+	}
+	for _, rfType := range []string{"IN", "MIN_MAX", "BLOOM_FILTER", "IN,MIN_MAX,BLOOM_FILTER"} {
+		tk.MustExec(fmt.Sprintf("set tidb_runtime_filter_type='%s'", rfType))
+		for _, query := range queries {
+			expected := tk.MustQuery(fmt.Sprintf(query, "")).Rows()
+			for _, hint := range []string{"/*+ runtime_filter(), hash_join_build(t2, t3) */", "/*+ runtime_filter(), hash_join_build(t2, t3), use_index(t1, idx_a) */"} {
+				tk.MustQuery(fmt.Sprintf(query, hint)).Check(expected)
+			}
+		}
+	}
+
+	tk.MustExec("set tidb_runtime_filter_type='IN'")
+	rows = tk.MustQuery("explain analyze select /*+ runtime_filter(), hash_join_build(t2), use_index(t1, idx_a) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "runtime_filter:{0\\[IN\\(4\\)\\], pushed_filtered_rows:97, bloom_filtered_rows:0}", rows[1][5])
+	tk.MustExec("set tidb_runtime_filter_type='MIN_MAX'")
+	rows = tk.MustQuery("explain analyze select /*+ runtime_filter(), hash_join_build(t2), use_index(t1, idx_a) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "runtime_filter:{0\\[MIN_MAX\\], pushed_filtered_rows:1, bloom_filtered_rows:0}", rows[1][5])
+	tk.MustExec("set tidb_runtime_filter_type='BLOOM_FILTER'")
+	rows = tk.MustQuery("explain analyze select /*+ runtime_filter(), hash_join_build(t2), use_index(t1, idx_a) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "runtime_filter:{0\\[BLOOM_FILTER\\], pushed_filtered_rows:0, bloom_filtered_rows:97}", rows[1][5])
+	tk.MustExec("set tidb_runtime_filter_type='MIN_MAX,BLOOM_FILTER'")
+	rows = tk.MustQuery("explain analyze select /*+ runtime_filter(), hash_join_build(t2), use_index(t1, idx_a) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "runtime_filter:{0\\[MIN_MAX,BLOOM_FILTER\\], pushed_filtered_rows:1, bloom_filtered_rows:96}", rows[1][5])
+}
+
+func TestHashJoinAdaptiveLookup(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(join.EnableHashJoinV2)
+	tk.MustExec("create table t1 (a int primary key, b varchar(10), c int, index idx_c(c))")
+	tk.MustExec("create table t2 (a int, c int)")
+	sql := "insert into t1 values"
+	for i := range 100 {
+		if i != 0 {
+			sql += ","
+		}
+		sql += fmt.Sprintf("(%d, 'v%d', %d)", i, i, i)
+	}
+	tk.MustExec(sql)
+	tk.MustExec("insert into t2 values (1, 1), (5, 5), (5, 5), (50, null), (null, null), (200, 200)")
+	// t2 uses the pseudo stats, so the build side is overestimated.
+	tk.MustExec("analyze table t1")
+
+	queries := []string{
+		"select %s t1.a, t1.b, t2.a from t1 join t2 on t1.a = t2.a order by t1.a",
+		"select %s t1.a, t1.b, t2.c from t1 join t2 on t1.c = t2.c order by t1.a",
+		"select %s t2.a, t1.b from t2 left join t1 on t1.a = t2.a order by t2.a",
+		"select %s t1.a, t1.b, t2.a from t1 left join t2 on t1.a = t2.a order by t1.a, t2.a",
+		"select %s t1.a, t1.b from t1 where exists (select 1 from t2 where t1.a = t2.a) order by t1.a",
+	}
+	for _, query := range queries {
+		expected := tk.MustQuery(fmt.Sprintf(query, "")).Rows()
+		tk.MustExec("set tidb_enable_adaptive_execution = on")
+		for _, hint := range []string{"/*+ hash_join_build(t2) */", "/*+ hash_join_build(t2), use_index(t1, idx_c) */"} {
+			tk.MustQuery(fmt.Sprintf(query, hint)).Check(expected)
+		}
+		tk.MustExec("set tidb_enable_adaptive_execution = off")
+	}
+
+	tk.MustExec("set tidb_enable_adaptive_execution = on")
+	rows := tk.MustQuery("explain analyze select /*+ hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "adaptive:{checkpoint:build, est_rows:9990.00, act_rows:5, decision:index_lookup}", rows[0][5])
+	require.Equal(t, "3", rows[len(rows)-1][2])
+	rows = tk.MustQuery("explain analyze select /*+ hash_join_build(t2), use_index(t1, idx_c) */ * from t1 join t2 on t1.c = t2.c").Rows()
+	require.Regexp(t, "decision:index_lookup", rows[0][5])
+	// The probe side is the outer side, so the rows without matches can't be skipped.
+	rows = tk.MustQuery("explain analyze select /*+ hash_join_build(t2) */ * from t1 left join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "adaptive:{checkpoint:build, est_rows:9990.00, act_rows:5, decision:none}", rows[0][5])
+	// The build side is estimated well.
+	tk.MustExec("analyze table t2")
+	rows = tk.MustQuery("explain analyze select /*+ hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.Regexp(t, "HashJoin", rows[1][0])
+	require.Regexp(t, "act_rows:5, decision:none}", rows[1][5])
+	tk.MustExec("set tidb_enable_adaptive_execution = off")
+	rows = tk.MustQuery("explain analyze select /*+ hash_join_build(t2) */ * from t1 join t2 on t1.a = t2.a").Rows()
+	require.NotContains(t, fmt.Sprintf("%v", rows), "adaptive")
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jointest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"b": "z"}]', '$[*]' columns (
+		id for ordinality, a int path '$.a', b varchar(10) path '$.b', has_b int exists path '$.b')) as jt`).
+		Check(testkit.Rows("1 1 x 1", "2 2 <nil> 0", "3 <nil> z 1"))
+	tk.MustQuery(`select * from json_table('{"a": [1, 2]}', '$' columns (a json path '$.a')) as jt`).
+		Check(testkit.Rows("[1, 2]"))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table('[]', '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+
+	// NESTED PATH, the rows of the sibling nested paths don't combine with each other.
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [1, 2], "c": [3]}, {"a": 2}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b_id for ordinality, b int path '$'),
+		nested path '$.c[*]' columns (c int path '$'))) as jt`).
+		Check(testkit.Rows("1 1 1 <nil>", "1 2 2 <nil>", "1 <nil> <nil> 3", "2 <nil> <nil> <nil>"))
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a": "x"}, {"a": [1]}, {}]', '$[*]' columns (
+		a varchar(10) path '$.a' default '"e"' on empty default 'err' on error,
+		b int path '$.b' default '7' on empty)) as jt`).
+		Check(testkit.Rows("x 7", "err 7", "\"e\" 7"))
+	tk.MustQuery(`select * from json_table('[{"a": "abc"}]', '$[*]' columns (a int path '$.a')) as jt`).Check(testkit.Rows("<nil>"))
+	tk.MustGetErrMsg(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`,
+		exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs("a").Error())
+	tk.MustGetErrCode(`select * from json_table('[{"a": {}}]', '$[*]' columns (a int path '$.a' error on error)) as jt`, 3666)
+	tk.MustGetErrCode(`select * from json_table('[{"a": 300}]', '$[*]' columns (a tinyint path '$.a' error on error)) as jt`, 3669)
+	tk.MustGetErrCode(`select * from json_table('[{"a": 3}]', '$[*]' columns (a tinyint path '$.a' error on error, a int path '$')) as jt`, 1060)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$')) as jt, json_table('[1]', '$[*]' columns (a int path '$')) as jt`, 1066)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*' columns (a int path '$')) as jt`, 3143)
+	tk.MustGetErrMsg(`select * from json_table('[1]', '$[*]' columns (a int path '$'))`, plannererrors.ErrTableFunctionMustHaveAlias.GenWithStackByArgs().Error())
+}
+
+func TestJSONTableLateral(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table orders (id int primary key, items json)")
+	tk.MustExec(`insert into orders values
+		(1, '[{"sku": "A", "qty": 2}, {"sku": "B", "qty": 1}]'),
+		(2, '[]'),
+		(3, '[{"sku": "C", "qty": 5}]')`)
+
+	tk.MustQuery(`select o.id, l.sku, l.qty from orders o, json_table(o.items, '$[*]' columns (
+		sku varchar(10) path '$.sku', qty int path '$.qty')) as l order by o.id, l.sku`).
+		Check(testkit.Rows("1 A 2", "1 B 1", "3 C 5"))
+	tk.MustQuery(`select o.id, l.sku from orders o join json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l
+		on l.sku <> 'B' order by o.id`).
+		Check(testkit.Rows("1 A", "3 C"))
+	tk.MustQuery(`select o.id, l.sku from orders o left join json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l
+		on true order by o.id, l.sku`).
+		Check(testkit.Rows("1 A", "1 B", "2 <nil>", "3 C"))
+	tk.MustQuery(`select o.id, sum(l.qty) from orders o, json_table(o.items, '$[*]' columns (qty int path '$.qty')) as l
+		group by o.id order by o.id`).
+		Check(testkit.Rows("1 3", "3 5"))
+	tk.MustExec("set @@tidb_enable_parallel_apply = 1")
+	tk.MustQuery(`select o.id, l.sku from orders o, json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l
+		order by o.id, l.sku`).
+		Check(testkit.Rows("1 A", "1 B", "3 C"))
+	tk.MustExec("set @@tidb_enable_parallel_apply = default")
+	tk.MustExec(`prepare stmt from "select l.sku from orders o, json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l where o.id = ? order by l.sku"`)
+	tk.MustExec("set @id = 1")
+	tk.MustQuery("execute stmt using @id").Check(testkit.Rows("A", "B"))
+	tk.MustExec("set @id = 3")
+	tk.MustQuery("execute stmt using @id").Check(testkit.Rows("C"))
+	tk.MustQuery(`explain format = 'brief' select * from orders o, json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l`).
+		CheckContain("Apply")
+	tk.MustQuery(`explain format = 'brief' select * from orders o, json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l`).
+		CheckContain("JSONTable")
+
+	tk.MustGetErrMsg(`select * from orders o right join json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l on true`,
+		plannererrors.ErrTableFunctionForbiddenJoinType.GenWithStackByArgs("l").Error())
+	tk.MustGetErrCode(`select * from json_table(o.items, '$[*]' columns (sku varchar(10) path '$.sku')) as l, orders o`, 1054)
+}
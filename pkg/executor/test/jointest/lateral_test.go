@@ -0,0 +1,66 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jointest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table g (id int primary key, name varchar(10))")
+	tk.MustExec("create table s (id int primary key, g int, score int, key(g))")
+	tk.MustExec("insert into g values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec("insert into s values (1, 1, 10), (2, 1, 30), (3, 1, 20), (4, 2, 5), (5, 2, 7)")
+
+	// Top-N per group.
+	topN := `select g.name, dt.score from g, lateral (select score from s where s.g = g.id order by score desc limit 2) as dt
+		order by g.name, dt.score desc`
+	tk.MustQuery(topN).Check(testkit.Rows("a 30", "a 20", "b 7", "b 5"))
+	tk.MustQuery("explain format = 'brief' " + topN).CheckContain("Apply")
+	tk.MustQuery("explain analyze " + topN).CheckContain("cache:ON")
+	tk.MustExec("set @@tidb_enable_parallel_apply = 1")
+	tk.MustQuery(topN).Check(testkit.Rows("a 30", "a 20", "b 7", "b 5"))
+	tk.MustExec("set @@tidb_enable_parallel_apply = default")
+	tk.MustQuery(`select g.name, dt.score from g left join lateral (select score from s where s.g = g.id order by score desc limit 1) as dt
+		on true order by g.name`).Check(testkit.Rows("a 30", "b 7", "c <nil>"))
+
+	// The lateral derived table is decorrelated when possible.
+	tk.MustQuery(`select g.name, dt.cnt from g, lateral (select count(*) cnt from s where s.g = g.id) as dt order by g.name`).
+		Check(testkit.Rows("a 3", "b 2", "c 0"))
+	agg := `select g.name, dt.total from g, lateral (select sum(score) total from s where s.g = g.id) as dt order by g.name`
+	tk.MustQuery(agg).Check(testkit.Rows("a 60", "b 12", "c <nil>"))
+	tk.MustQuery("explain format = 'brief' " + agg).CheckNotContain("Apply")
+	sel := `select g.name, dt.score from g join lateral (select score from s where s.g = g.id and s.score > 6) as dt
+		order by g.name, dt.score`
+	tk.MustQuery(sel).Check(testkit.Rows("a 10", "a 20", "a 30", "b 7"))
+	tk.MustQuery("explain format = 'brief' " + sel).CheckNotContain("Apply")
+
+	// The lateral derived table without outer references is a normal derived table.
+	tk.MustQuery("select count(*) from g, lateral (select * from s) as dt").Check(testkit.Rows("15"))
+	tk.MustQuery("select * from lateral (select 1) as dt").Check(testkit.Rows("1"))
+
+	tk.MustGetErrMsg("select * from g right join lateral (select * from s where s.g = g.id) as dt on true",
+		plannererrors.ErrTableFunctionForbiddenJoinType.GenWithStackByArgs("dt").Error())
+	// Only LATERAL derived tables can refer to the preceding tables.
+	tk.MustGetErrCode("select * from g, (select * from s where s.g = g.id) as dt", 1054)
+	tk.MustGetErrCode("select * from lateral (select * from s where s.g = g.id) as dt, g", 1054)
+	tk.MustGetErrCode("select * from g, lateral (select * from s where s.g = g.id)", 1248)
+}
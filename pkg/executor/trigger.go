@@ -0,0 +1,198 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/executor/internal/procedure"
+	"github.com/pingcap/tidb/pkg/infoschema"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/planner"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror/exeerrors"
+)
+
+type triggerStackKeyType int
+
+func (triggerStackKeyType) String() string {
+	return "trigger_stack"
+}
+
+// triggerStackKey is the session value key of the IDs of the tables whose
+// triggers are running.
+const triggerStackKey triggerStackKeyType = 0
+
+// TriggerExec fires the triggers of a table for the DML executor, and logs the
+// changed rows for the materialized views of the table. A nil TriggerExec means
+// the table has neither trigger for the event nor materialized view.
+type TriggerExec struct {
+	b        *executorBuilder
+	tbl      table.Table
+	triggers *procedure.Triggers
+	mviewLog *mviewLogger
+}
+
+func (b *executorBuilder) buildTriggerExec(tbl table.Table, event ast.TriggerEvent) (*TriggerExec, error) {
+	mviewLog, err := b.buildMViewLogger(tbl)
+	if err != nil {
+		return nil, err
+	}
+	e := &TriggerExec{b: b, tbl: tbl, mviewLog: mviewLog}
+	tblInfo := tbl.Meta()
+	if len(tblInfo.Triggers) > 0 {
+		dbInfo, ok := b.is.SchemaByID(tblInfo.DBID)
+		if !ok {
+			return nil, infoschema.ErrDatabaseNotExists.GenWithStackByArgs(tblInfo.DBID)
+		}
+		e.triggers, err = procedure.NewTriggers(b.ctx, dbInfo.Name.O, tblInfo, event, e.runStmt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if e.triggers == nil && e.mviewLog == nil {
+		return nil, nil
+	}
+	return e, nil
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2Table map[int64]table.Table, event ast.TriggerEvent) (map[int64]*TriggerExec, error) {
+	triggersMap := make(map[int64]*TriggerExec)
+	for tid, tbl := range tblID2Table {
+		triggers, err := b.buildTriggerExec(tbl, event)
+		if err != nil {
+			return nil, err
+		}
+		if triggers != nil {
+			triggersMap[tid] = triggers
+		}
+	}
+	return triggersMap, nil
+}
+
+func (e *TriggerExec) has(timing ast.TriggerTiming) bool {
+	return e != nil && e.triggers != nil && e.triggers.Has(timing)
+}
+
+// fire fires the triggers of the action time for a row, see procedure.Triggers.Fire.
+// The changed row is logged for the materialized views after it's written.
+func (e *TriggerExec) fire(ctx context.Context, timing ast.TriggerTiming, oldRow, newRow []types.Datum) error {
+	if e != nil && e.mviewLog != nil && timing == ast.TriggerAfter {
+		if err := e.mviewLog.log(ctx, oldRow, newRow); err != nil {
+			return err
+		}
+	}
+	if !e.has(timing) {
+		return nil
+	}
+	sctx := e.b.ctx
+	stack, _ := sctx.Value(triggerStackKey).([]int64)
+	sctx.SetValue(triggerStackKey, append(stack, e.tbl.Meta().ID))
+	defer sctx.SetValue(triggerStackKey, stack)
+	return e.triggers.Fire(ctx, timing, oldRow, newRow)
+}
+
+// runStmt executes a statement of the trigger body in the transaction and the
+// statement context of the triggering statement.
+func (e *TriggerExec) runStmt(ctx context.Context, stmt ast.StmtNode, limit int) (rows [][]types.Datum, fts []*types.FieldType, err error) {
+	switch stmt.(type) {
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt:
+		return nil, nil, exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	}
+	sctx, is := e.b.ctx, e.b.is
+	nodeW := resolve.NewNodeW(stmt)
+	if err = plannercore.Preprocess(ctx, sctx, nodeW); err != nil {
+		return nil, nil, err
+	}
+	p, err := planner.OptimizeForTrigger(ctx, sctx.GetPlanCtx(), nodeW, is)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = e.checkUsedTables(p); err != nil {
+		return nil, nil, err
+	}
+	b := newExecutorBuilder(sctx, is)
+	executor := b.build(p)
+	if b.err != nil {
+		return nil, nil, b.err
+	}
+	if err = exec.Open(ctx, executor); err != nil {
+		terror.Log(exec.Close(executor))
+		return nil, nil, err
+	}
+	defer func() {
+		if closeErr := exec.Close(executor); err == nil {
+			err = closeErr
+		}
+	}()
+
+	if executor.Schema().Len() == 0 {
+		if err = exec.Next(ctx, executor, exec.NewFirstChunk(executor)); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, (&ExecStmt{Ctx: sctx}).handleForeignKeyTrigger(ctx, executor, 1)
+	}
+	fts = exec.RetTypes(executor)
+	for len(rows) < limit {
+		req := exec.NewFirstChunk(executor)
+		if err = exec.Next(ctx, executor, req); err != nil {
+			return nil, nil, err
+		}
+		if req.NumRows() == 0 {
+			break
+		}
+		for i := range req.NumRows() {
+			rows = append(rows, req.GetRow(i).GetDatumRow(fts))
+		}
+	}
+	return rows, fts, nil
+}
+
+// checkUsedTables checks the statement doesn't modify a table whose triggers
+// are running, which is not allowed in MySQL either.
+func (e *TriggerExec) checkUsedTables(p base.Plan) error {
+	var tblIDs []int64
+	switch x := p.(type) {
+	case *plannercore.Insert:
+		tblIDs = append(tblIDs, x.Table.Meta().ID)
+	case *plannercore.Update:
+		for _, info := range x.TblColPosInfos {
+			tblIDs = append(tblIDs, info.TblID)
+		}
+	case *plannercore.Delete:
+		for _, info := range x.TblColPosInfos {
+			tblIDs = append(tblIDs, info.TblID)
+		}
+	}
+	stack, _ := e.b.ctx.Value(triggerStackKey).([]int64)
+	for _, id := range tblIDs {
+		if slices.Contains(stack, id) {
+			tbl, ok := e.b.is.TableByID(context.Background(), id)
+			if !ok {
+				return errors.Errorf("table %d not found", id)
+			}
+			return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tbl.Meta().Name.O)
+		}
+	}
+	return nil
+}
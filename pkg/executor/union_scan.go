@@ -295,14 +295,17 @@ type compareExec struct {
 	collators []collate.Collator
 	// usedIndex is the column offsets of the index which Src executor has used.
 	usedIndex []int
-	desc      bool
+	// usedIndexReversed tells whether the order of each used index column is reversed from desc,
+	// it's set when the index has columns stored in descending order.
+	usedIndexReversed []bool
+	desc              bool
 	// handleCols is the handle's position of the below scan plan.
 	handleCols plannerutil.HandleCols
 }
 
 func (ce compareExec) compare(sctx *stmtctx.StatementContext, a, b []types.Datum) (ret int, err error) {
 	var cmp int
-	for _, colOff := range ce.usedIndex {
+	for i, colOff := range ce.usedIndex {
 		aColumn := a[colOff]
 		bColumn := b[colOff]
 		cmp, err = aColumn.Compare(sctx.TypeCtx(), &bColumn, ce.collators[colOff])
@@ -312,7 +315,7 @@ func (ce compareExec) compare(sctx *stmtctx.StatementContext, a, b []types.Datum
 		if cmp == 0 {
 			continue
 		}
-		if ce.desc {
+		if ce.desc != (i < len(ce.usedIndexReversed) && ce.usedIndexReversed[i]) {
 			return -cmp, nil
 		}
 		return cmp, nil
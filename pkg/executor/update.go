@@ -75,6 +75,8 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers contains the UPDATE triggers. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
 
 	IgnoreError bool
 }
@@ -299,6 +301,7 @@ func (e *UpdateExec) exec(
 			flags, tbl, false, e.memTracker,
 			e.fkChecks[content.TblID],
 			e.fkCascades[content.TblID],
+			e.triggers[content.TblID],
 			dupKeyCheck, e.IgnoreError)
 
 		// Copy data from new row to merge row
@@ -26,6 +26,8 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
 // WindowExec is the executor for window functions.
@@ -37,45 +39,75 @@ type WindowExec struct {
 	childResult *chunk.Chunk
 	// executed indicates the child executor is drained or something unexpected happened.
 	executed bool
-	// resultChunks stores the chunks to return
-	resultChunks []*chunk.Chunk
-	// remainingRowsInChunk indicates how many rows the resultChunks[i] is not prepared.
-	remainingRowsInChunk []int
+	// partition buffers the rows of the current partition, it can be spilled to disk.
+	partition *windowRowContainer
+	// partitionReady indicates all the rows of the current partition are buffered.
+	partitionReady bool
+	// nextRowIdx is the index of the next row to return in the current partition.
+	nextRowIdx uint64
+	// childColIdxs are the indexes of the child columns returned by the executor.
+	childColIdxs []int
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
 
 	numWindowFuncs int
 	processor      windowProcessor
 }
 
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.executed, e.partitionReady, e.nextRowIdx = false, false, 0
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	e.childColIdxs = windowChildColIdxs(e.Schema(), e.numWindowFuncs)
+	e.memTracker, e.diskTracker = openWindowTrackers(e.Ctx(), e.ID(), e.memTracker, e.diskTracker)
+	e.partition = newWindowRowContainer(e.Ctx(), exec.RetTypes(e.Children(0)), e.MaxChunkSize(), e.memTracker, e.diskTracker)
+	return nil
+}
+
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
+	if e.partition != nil {
+		if err := e.partition.close(); err != nil {
+			return err
+		}
+		e.partition = nil
+	}
+	e.childResult = nil
 	return errors.Trace(e.BaseExecutor.Close())
 }
 
 // Next implements the Executor Next interface.
 func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 	chk.Reset()
-	for !e.executed && !e.preparedChunkAvailable() {
-		err := e.consumeOneGroup(ctx)
-		if err != nil {
+	for !chk.IsFull() {
+		if !e.partitionReady {
+			if e.executed {
+				return nil
+			}
+			if err := e.consumeOneGroup(ctx); err != nil {
+				e.executed = true
+				return err
+			}
+			continue
+		}
+		if err := e.appendResult2Chunk(chk); err != nil {
 			e.executed = true
 			return err
 		}
 	}
-	if len(e.resultChunks) > 0 {
-		chk.SwapColumns(e.resultChunks[0])
-		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
-		e.resultChunks = e.resultChunks[1:]
-		e.remainingRowsInChunk = e.remainingRowsInChunk[1:]
-	}
 	return nil
 }
 
-func (e *WindowExec) preparedChunkAvailable() bool {
-	return len(e.resultChunks) > 0 && e.remainingRowsInChunk[0] == 0
-}
-
+// consumeOneGroup buffers the rows of the next partition and consumes them.
 func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
+	if err := e.partition.reset(); err != nil {
+		return err
+	}
+	e.nextRowIdx = 0
 	if e.groupChecker.IsExhausted() {
 		eof, err := e.fetchChild(ctx)
 		if err != nil {
@@ -83,7 +115,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return nil
 		}
 		_, err = e.groupChecker.SplitIntoGroups(e.childResult)
 		if err != nil {
@@ -91,8 +123,8 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 	}
 	begin, end := e.groupChecker.GetNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
+	if err := e.partition.appendRows(e.childResult, begin, end); err != nil {
+		return err
 	}
 
 	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
@@ -103,7 +135,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			break
 		}
 
 		isFirstGroupSameAsPrev, err := e.groupChecker.SplitIntoGroups(e.childResult)
@@ -113,86 +145,85 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 
 		if isFirstGroupSameAsPrev {
 			begin, end = e.groupChecker.GetNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
+			if err = e.partition.appendRows(e.childResult, begin, end); err != nil {
+				return err
 			}
 			meetLastGroup = end == e.childResult.NumRows()
 		}
 	}
-	return e.consumeGroupRows(groupRows)
-}
-
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
-	if remainingRowsInGroup == 0 {
+	if e.partition.numRows() == 0 {
 		return nil
 	}
-	for i := 0; i < len(e.resultChunks); i++ {
-		remained := min(e.remainingRowsInChunk[i], remainingRowsInGroup)
-		e.remainingRowsInChunk[i] -= remained
-		remainingRowsInGroup -= remained
+	e.partitionReady = true
+	return e.processor.consumeGroupRows(e.Ctx(), e.partition)
+}
 
-		// TODO: Combine these three methods.
-		// The old implementation needs the processor has these three methods
-		// but now it does not have to.
-		groupRows, err = e.processor.consumeGroupRows(e.Ctx(), groupRows)
-		if err != nil {
-			return errors.Trace(err)
-		}
-		_, err = e.processor.appendResult2Chunk(e.Ctx(), groupRows, e.resultChunks[i], remained)
+// appendResult2Chunk appends the rows of the current partition and their window function results to chk.
+func (e *WindowExec) appendResult2Chunk(chk *chunk.Chunk) error {
+	numRows := e.partition.numRows()
+	remained := min(uint64(chk.RequiredRows()-chk.NumRows()), numRows-e.nextRowIdx)
+	for i := range remained {
+		row, err := e.partition.getRow(e.nextRowIdx + i)
 		if err != nil {
-			return errors.Trace(err)
-		}
-		if remainingRowsInGroup == 0 {
-			e.processor.resetPartialResult()
-			break
+			return err
 		}
+		chk.AppendPartialRowByColIdxs(0, row, e.childColIdxs)
+	}
+	if err := e.processor.appendResult2Chunk(e.Ctx(), e.partition, chk, int(remained)); err != nil {
+		return errors.Trace(err)
+	}
+	e.nextRowIdx += remained
+	if e.nextRowIdx == numRows {
+		e.processor.resetPartialResult()
+		e.partitionReady = false
 	}
 	return nil
 }
 
 func (e *WindowExec) fetchChild(ctx context.Context) (eof bool, err error) {
-	childResult := exec.TryNewCacheChunk(e.Children(0))
-	err = exec.Next(ctx, e.Children(0), childResult)
+	err = exec.Next(ctx, e.Children(0), e.childResult)
 	if err != nil {
 		return false, errors.Trace(err)
 	}
 	// No more data.
-	numRows := childResult.NumRows()
-	if numRows == 0 {
-		return true, nil
-	}
+	return e.childResult.NumRows() == 0, nil
+}
 
-	resultChk := e.AllocPool.Alloc(e.RetFieldTypes(), 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
-		return false, err
+// windowChildColIdxs returns the indexes of the child columns in the output of window executors.
+func windowChildColIdxs(schema *expression.Schema, numWindowFuncs int) []int {
+	columns := schema.Columns[:len(schema.Columns)-numWindowFuncs]
+	colIdxs := make([]int, 0, len(columns))
+	for _, col := range columns {
+		colIdxs = append(colIdxs, col.Index)
 	}
-	e.resultChunks = append(e.resultChunks, resultChk)
-	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
-
-	e.childResult = childResult
-	return false, nil
+	return colIdxs
 }
 
-func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
-	columns := e.Schema().Columns[:len(e.Schema().Columns)-e.numWindowFuncs]
-	for i, col := range columns {
-		if err := dst.MakeRefTo(i, src, col.Index); err != nil {
-			return err
-		}
+// openWindowTrackers resets or creates the memory and disk trackers of window executors.
+func openWindowTrackers(sctx sessionctx.Context, id int, memTracker *memory.Tracker, diskTracker *disk.Tracker) (*memory.Tracker, *disk.Tracker) {
+	if memTracker != nil {
+		memTracker.Reset()
+	} else {
+		memTracker = memory.NewTracker(id, -1)
 	}
-	return nil
+	memTracker.AttachTo(sctx.GetSessionVars().StmtCtx.MemTracker)
+	if diskTracker != nil {
+		diskTracker.Reset()
+	} else {
+		diskTracker = disk.NewTracker(id, -1)
+	}
+	diskTracker.AttachTo(sctx.GetSessionVars().StmtCtx.DiskTracker)
+	return memTracker, diskTracker
 }
 
 // windowProcessor is the interface for processing different kinds of windows.
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
-	// appendResult2Chunk appends the final results to chunk.
+	consumeGroupRows(ctx sessionctx.Context, rows *windowRowContainer) error
+	// appendResult2Chunk appends the final results of the next `remained` rows to chunk.
 	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	appendResult2Chunk(ctx sessionctx.Context, rows *windowRowContainer, chk *chunk.Chunk, remained int) error
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -202,30 +233,37 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	for i, windowFunc := range p.windowFuncs {
-		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows, p.partialResults[i])
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows *windowRowContainer) error {
+	// Consume the rows chunk by chunk, so the rows spilled to disk are not loaded at once.
+	numRows := rows.numRows()
+	for start := uint64(0); start < numRows; start += uint64(rows.chunkSize) {
+		batch, err := rows.getRows(start, min(start+uint64(rows.chunkSize), numRows))
 		if err != nil {
-			return nil, err
+			return err
+		}
+		for i, windowFunc := range p.windowFuncs {
+			// @todo Add memory trace
+			_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), batch, p.partialResults[i])
+			if err != nil {
+				return err
+			}
 		}
 	}
-	rows = rows[:0]
-	return rows, nil
+	return nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, _ *windowRowContainer, chk *chunk.Chunk, remained int) error {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
 			err := windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 		}
 		remained--
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *aggWindowProcessor) resetPartialResult() {
@@ -288,12 +326,12 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (*rowFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rowFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowRowContainer) error {
+	return nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowRowContainer, chk *chunk.Chunk, remained int) error {
+	numRows := rows.numRows()
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -321,16 +359,14 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slideWindowFunc(ctx, slidingWindowAggFunc, rows, 0, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -339,9 +375,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slideWindowFunc(ctx, slidingWindowAggFunc, rows, 0, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 				// whether elements inside deque are out of current window.
@@ -349,14 +383,18 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
+				var frameRows []chunk.Row
+				frameRows, err = rows.getRows(start, end)
+				if err == nil {
+					_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), frameRows, p.partialResults[i])
+				}
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -369,7 +407,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
@@ -389,16 +427,23 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows *windowRowContainer) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
+	numRows := rows.numRows()
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
 	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
 		var res int64
-		var err error
+		startRow, err := rows.getRow(p.lastStartOffset)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.start.CompareCols[i], p.start.CalcFuncs[i], startRow, curRow)
 			if err != nil {
 				return 0, err
 			}
@@ -415,16 +460,23 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows *windowRowContainer) (uint64, error) {
+	numRows := rows.numRows()
 	if p.end.UnBounded {
 		return numRows, nil
 	}
+	curRow, err := rows.getRow(p.curRowIdx)
+	if err != nil {
+		return 0, err
+	}
 	for ; p.lastEndOffset < numRows; p.lastEndOffset++ {
 		var res int64
-		var err error
+		endRow, err := rows.getRow(p.lastEndOffset)
+		if err != nil {
+			return 0, err
+		}
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx.GetExprCtx().GetEvalCtx(), p.end.CalcFuncs[i], p.end.CompareCols[i], curRow, endRow)
 			if err != nil {
 				return 0, err
 			}
@@ -441,7 +493,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows *windowRowContainer, chk *chunk.Chunk, remained int) error {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -461,11 +513,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start, err = p.getStartOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		end, err = p.getEndOffset(ctx, rows)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		p.curRowIdx++
 		remained--
@@ -475,16 +527,14 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slideWindowFunc(ctx, slidingWindowAggFunc, rows, 0, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
-						return nil, err
+						return err
 					}
 				}
 				err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 				if err != nil {
-					return nil, err
+					return err
 				}
 			}
 			continue
@@ -493,21 +543,23 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slideWindowFunc(ctx, slidingWindowAggFunc, rows, 0, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), rows[start:end], p.partialResults[i])
+				var frameRows []chunk.Row
+				frameRows, err = rows.getRows(start, end)
+				if err == nil {
+					_, err = windowFunc.UpdatePartialResult(ctx.GetExprCtx().GetEvalCtx(), frameRows, p.partialResults[i])
+				}
 			}
 			if err != nil {
-				return nil, err
+				return err
 			}
 			err = windowFunc.AppendFinalResult2Chunk(ctx.GetExprCtx().GetEvalCtx(), p.partialResults[i], chk)
 			if err != nil {
-				return nil, err
+				return err
 			}
 			if slidingWindowAggFunc == nil {
 				windowFunc.ResetPartialResult(p.partialResults[i])
@@ -520,11 +572,11 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	for i, windowFunc := range p.windowFuncs {
 		windowFunc.ResetPartialResult(p.partialResults[i])
 	}
-	return rows, nil
+	return nil
 }
 
-func (*rangeFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	return rows, nil
+func (*rangeFrameWindowProcessor) consumeGroupRows(sessionctx.Context, *windowRowContainer) error {
+	return nil
 }
 
 func (p *rangeFrameWindowProcessor) resetPartialResult() {
@@ -532,3 +584,15 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
 }
+
+// slideWindowFunc slides the frame of a sliding window function, the frame offsets are relative to the base-th
+// row in the container.
+func slideWindowFunc(ctx sessionctx.Context, windowFunc aggfuncs.SlidingWindowAggFunc, rows *windowRowContainer,
+	base, lastStart, lastEnd, shiftStart, shiftEnd uint64, pr aggfuncs.PartialResult) error {
+	var getRowErr error
+	err := windowFunc.Slide(ctx.GetExprCtx().GetEvalCtx(), rows.rowGetter(base, &getRowErr), lastStart, lastEnd, shiftStart, shiftEnd, pr)
+	if err != nil {
+		return err
+	}
+	return getRowErr
+}
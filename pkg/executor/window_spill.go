@@ -0,0 +1,221 @@
+// Copyright 2026 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/disk"
+	"github.com/pingcap/tidb/pkg/util/memory"
+)
+
+// windowRowCacheSize is the number of chunks read back from the row container
+// that are cached by windowRowContainer.
+const windowRowCacheSize = 4
+
+type windowCachedChunk struct {
+	idx int
+	chk *chunk.Chunk
+}
+
+// windowRowContainer buffers the input rows of window executors. Rows are
+// addressed by a monotonically increasing index. Full chunks are kept in a
+// chunk.RowContainer, which spills them to disk when the memory quota of the
+// query is exceeded, so a huge partition doesn't make the query get killed.
+//
+// Rows returned by the container stay valid until they are dropped, because
+// the window functions may keep references to them in their partial results.
+type windowRowContainer struct {
+	fieldTypes   []*types.FieldType
+	chunkSize    int
+	memTracker   *memory.Tracker
+	rowContainer *chunk.RowContainer
+
+	// curChk collects the rows that haven't been added to rowContainer. It is
+	// added to rowContainer once it's full, so every chunk in rowContainer has
+	// exactly chunkSize rows and a row can be located by its index directly.
+	curChk         *chunk.Chunk
+	curChkMemUsage int64
+	// offset is the index of the first row in rowContainer.
+	offset uint64
+	// numRowsInContainer is the number of rows in rowContainer.
+	numRowsInContainer uint64
+
+	// cache keeps the chunks recently read from rowContainer, which avoids
+	// reading a chunk back from disk for every row after spilling.
+	cache    []windowCachedChunk
+	cacheIdx int
+	// nullRow is returned by the getter of sliding window functions when
+	// reading a row fails.
+	nullRow chunk.Row
+	rowBuf  []chunk.Row
+}
+
+func newWindowRowContainer(sctx sessionctx.Context, fieldTypes []*types.FieldType, chunkSize int,
+	memTracker *memory.Tracker, diskTracker *disk.Tracker) *windowRowContainer {
+	c := &windowRowContainer{
+		fieldTypes:   fieldTypes,
+		chunkSize:    chunkSize,
+		memTracker:   memTracker,
+		rowContainer: chunk.NewRowContainer(fieldTypes, chunkSize),
+		cache:        make([]windowCachedChunk, 0, windowRowCacheSize),
+	}
+	c.rowContainer.GetMemTracker().AttachTo(memTracker)
+	c.rowContainer.GetMemTracker().SetLabel(memory.LabelForRowContainer)
+	c.rowContainer.GetDiskTracker().AttachTo(diskTracker)
+	c.rowContainer.GetDiskTracker().SetLabel(memory.LabelForRowContainer)
+	if vardef.EnableTmpStorageOnOOM.Load() {
+		actionSpill := c.rowContainer.ActionSpill()
+		failpoint.Inject("testWindowRowContainerSpill", func(val failpoint.Value) {
+			if val.(bool) {
+				actionSpill = c.rowContainer.ActionSpillForTest()
+			}
+		})
+		sctx.GetSessionVars().MemTracker.FallbackOldAndSetNewAction(actionSpill)
+	}
+	c.curChk = c.rowContainer.AllocChunk()
+	return c
+}
+
+// numRows returns the number of rows appended to the container, including
+// the dropped ones.
+func (c *windowRowContainer) numRows() uint64 {
+	return c.offset + c.numRowsInContainer + uint64(c.curChk.NumRows())
+}
+
+// appendRows copies the rows in [begin, end) of chk into the container.
+func (c *windowRowContainer) appendRows(chk *chunk.Chunk, begin, end int) error {
+	for begin < end {
+		n := min(end-begin, c.chunkSize-c.curChk.NumRows())
+		if chk.Sel() != nil {
+			for i := begin; i < begin+n; i++ {
+				c.curChk.AppendRow(chk.GetRow(i))
+			}
+		} else {
+			c.curChk.Append(chk, begin, begin+n)
+		}
+		begin += n
+		if c.curChk.NumRows() < c.chunkSize {
+			break
+		}
+		c.memTracker.Consume(-c.curChkMemUsage)
+		c.curChkMemUsage = 0
+		if err := c.rowContainer.Add(c.curChk); err != nil {
+			return err
+		}
+		c.numRowsInContainer += uint64(c.chunkSize)
+		c.curChk = c.rowContainer.AllocChunk()
+	}
+	memUsage := c.curChk.MemoryUsage()
+	c.memTracker.Consume(memUsage - c.curChkMemUsage)
+	c.curChkMemUsage = memUsage
+	return nil
+}
+
+// getRow returns the idx-th row in the container.
+func (c *windowRowContainer) getRow(idx uint64) (chunk.Row, error) {
+	idx -= c.offset
+	if idx >= c.numRowsInContainer {
+		return c.curChk.GetRow(int(idx - c.numRowsInContainer)), nil
+	}
+	chkIdx := int(idx) / c.chunkSize
+	for _, cached := range c.cache {
+		if cached.idx == chkIdx {
+			return cached.chk.GetRow(int(idx) % c.chunkSize), nil
+		}
+	}
+	chk, err := c.rowContainer.GetChunk(chkIdx)
+	if err != nil {
+		return chunk.Row{}, err
+	}
+	if len(c.cache) < windowRowCacheSize {
+		c.cache = append(c.cache, windowCachedChunk{idx: chkIdx, chk: chk})
+	} else {
+		c.cache[c.cacheIdx] = windowCachedChunk{idx: chkIdx, chk: chk}
+		c.cacheIdx = (c.cacheIdx + 1) % windowRowCacheSize
+	}
+	return chk.GetRow(int(idx) % c.chunkSize), nil
+}
+
+// getRows returns the rows in [start, end). The returned slice is reused by
+// the next call.
+func (c *windowRowContainer) getRows(start, end uint64) ([]chunk.Row, error) {
+	c.rowBuf = c.rowBuf[:0]
+	for i := start; i < end; i++ {
+		row, err := c.getRow(i)
+		if err != nil {
+			return nil, err
+		}
+		c.rowBuf = append(c.rowBuf, row)
+	}
+	return c.rowBuf, nil
+}
+
+// rowGetter returns the function used by aggfuncs.SlidingWindowAggFunc to get
+// the rows relative to base. The function can't return an error, so the error
+// is recorded in err and a row of NULLs is returned instead.
+func (c *windowRowContainer) rowGetter(base uint64, err *error) func(uint64) chunk.Row {
+	return func(idx uint64) chunk.Row {
+		row, getErr := c.getRow(base + idx)
+		if getErr == nil {
+			return row
+		}
+		if *err == nil {
+			*err = getErr
+		}
+		if c.nullRow.IsEmpty() {
+			nullChk := chunk.NewChunkWithCapacity(c.fieldTypes, 1)
+			for i := range c.fieldTypes {
+				nullChk.AppendNull(i)
+			}
+			c.nullRow = nullChk.GetRow(0)
+		}
+		return c.nullRow
+	}
+}
+
+// drop releases the rows before idx. The rows in rowContainer can only be
+// released together, so they are kept until all of them are dropped.
+func (c *windowRowContainer) drop(idx uint64) error {
+	if c.numRowsInContainer == 0 || idx < c.offset+c.numRowsInContainer {
+		return nil
+	}
+	c.offset += c.numRowsInContainer
+	c.numRowsInContainer = 0
+	c.cache = c.cache[:0]
+	c.cacheIdx = 0
+	return c.rowContainer.Reset()
+}
+
+// reset drops all the rows in the container, the index of rows appended
+// later starts from 0 again.
+func (c *windowRowContainer) reset() error {
+	c.offset, c.numRowsInContainer = 0, 0
+	c.cache = c.cache[:0]
+	c.cacheIdx = 0
+	c.curChk.Reset()
+	return c.rowContainer.Reset()
+}
+
+func (c *windowRowContainer) close() error {
+	c.memTracker.Consume(-c.curChkMemUsage)
+	c.curChkMemUsage = 0
+	c.cache = nil
+	c.rowBuf = nil
+	return c.rowContainer.Close()
+}
@@ -20,7 +20,10 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWindowFunctions(t *testing.T) {
@@ -51,8 +54,10 @@ func doTestWindowFunctions(tk *testkit.TestKit) {
 	tk.MustExec("drop table if exists t")
 	tk.MustExec("create table t (a int, b int, c int)")
 	tk.MustExec("set @@tidb_enable_window_function = 1")
+	maxChunkSize := tk.Session().GetSessionVars().MaxChunkSize
 	defer func() {
 		tk.MustExec("set @@tidb_enable_window_function = 0")
+		tk.Session().GetSessionVars().MaxChunkSize = maxChunkSize
 	}()
 	tk.MustExec("insert into t values (1,2,3),(4,3,2),(2,3,4)")
 	tk.MustQuery("select count(a) over () from t").
@@ -479,3 +484,75 @@ func TestVarSampAsAWindowFunction(t *testing.T) {
 	tk.MustExec("select var_samp(c1) from t1")
 	tk.MustExec("select c1, var_samp(c1) over (partition by c1) from t1")
 }
+
+func TestWindowFunctionsSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (p int, a int, c varchar(255), primary key(p, a) clustered)")
+	tk.MustExec("set @@cte_max_recursion_depth = 3000")
+	tk.MustExec("insert into t with recursive cte(a) as (select 1 union select a + 1 from cte where a < 3000) " +
+		"select a mod 3, a, lpad(a, 128 + a mod 128, 'x') from cte")
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+
+	sqls := []string{
+		"select p, a, c, row_number() over (partition by p order by a) from t",
+		"select p, a, c, rank() over (partition by p order by a), lead(c, 2) over (partition by p order by a) from t",
+		"select p, a, c, sum(a) over (partition by p order by a rows between 3 preceding and 2 following) from t",
+		"select p, a, max(c) over (partition by p order by a range between 50 preceding and current row) from t",
+		"select p, a, first_value(c) over (partition by p order by a rows between 5 preceding and 1 preceding) from t",
+		"select p, a, c, count(*) over (partition by p) from t",
+	}
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %s", pipelined))
+		for _, sql := range sqls {
+			tk.MustExec("set @@tidb_mem_quota_query = default")
+			expected := tk.MustQuery(sql).Sort().Rows()
+
+			tk.MustExec("set @@tidb_mem_quota_query = 65536")
+			tk.MustQuery(sql).Sort().Check(expected)
+			// The window executor should have spilled its rows to disk, and removed them after the execution.
+			require.Equal(t, int64(0), tk.Session().GetSessionVars().StmtCtx.DiskTracker.BytesConsumed(), sql)
+			require.Greater(t, tk.Session().GetSessionVars().StmtCtx.DiskTracker.MaxConsumed(), int64(0), sql)
+		}
+	}
+}
+
+func TestShuffleInputsSpill(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (p int, a int, c varchar(255))")
+	tk.MustExec("set @@cte_max_recursion_depth = 3000")
+	// Most of the rows are in one partition, so one worker receives most of them.
+	tk.MustExec("insert into t with recursive cte(a) as (select 1 union select a + 1 from cte where a < 3000) " +
+		"select if(a mod 100 = 0, a, 0), a, lpad(a, 128 + a mod 128, 'x') from cte")
+	tk.MustExec("set @@tidb_window_concurrency = 4")
+	tk.MustExec("set @@tidb_max_chunk_size = 32")
+
+	sql := "select p, a, c, row_number() over (partition by p order by a) from t"
+	tk.MustExec("set @@tidb_mem_quota_query = default")
+	expected := tk.MustQuery(sql).Sort().Rows()
+
+	tk.MustExec("set @@tidb_mem_quota_query = 65536")
+	tk.MustQuery(sql).Sort().Check(expected)
+	var shuffle *plannercore.PhysicalShuffle
+	var findShuffle func(p base.PhysicalPlan)
+	findShuffle = func(p base.PhysicalPlan) {
+		if s, ok := p.(*plannercore.PhysicalShuffle); ok {
+			shuffle = s
+			return
+		}
+		for _, child := range p.Children() {
+			findShuffle(child)
+		}
+	}
+	stmtCtx := tk.Session().GetSessionVars().StmtCtx
+	findShuffle(stmtCtx.GetPlan().(base.PhysicalPlan))
+	require.NotNil(t, shuffle)
+	// The inputs of the shuffle workers should have been spilled to disk by the shuffle executor itself.
+	diskTracker := stmtCtx.DiskTracker.SearchTrackerWithoutLock(shuffle.ID())
+	require.NotNil(t, diskTracker)
+	require.Greater(t, diskTracker.MaxConsumed(), int64(0))
+}
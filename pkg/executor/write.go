@@ -89,6 +89,7 @@ func updateRecord(
 	_ *memory.Tracker,
 	fkChecks []*FKCheckExec,
 	fkCascades []*FKCascadeExec,
+	triggers *TriggerExec,
 	dupKeyMode table.DupKeyCheckMode,
 	ignoreErr bool,
 ) (changed bool, ignored bool, retErr error) {
@@ -154,6 +155,19 @@ func updateRecord(
 		return nil
 	}
 
+	// The BEFORE UPDATE triggers may change the new values of the non-generated columns.
+	if triggers.has(ast.TriggerBefore) {
+		if err := triggers.fire(ctx, ast.TriggerBefore, oldData, newData); err != nil {
+			return false, false, err
+		}
+		// For update statement, evalBuffer is initialized on demand.
+		if chunk.Row(evalBuffer).Chunk() != nil {
+			for i := range cols {
+				evalBuffer.SetDatum(i+offset, newData[i])
+			}
+		}
+	}
+
 	// Before do actual update, We need to ensure that all columns are evaluated in the following order:
 	// Step 1: non-generated columns (These columns should be evaluated outside this function).
 	// Step 2: check whether there are some columns changed.
@@ -180,8 +194,10 @@ func updateRecord(
 		if sessVars.LockUnchangedKeys {
 			keySet |= lockUniqueKeys
 		}
-		_, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet)
-		return false, false, err
+		if _, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet); err != nil {
+			return false, false, err
+		}
+		return false, false, triggers.fire(ctx, ast.TriggerAfter, oldData, newData)
 	}
 
 	// Step 3: fill values into on-update-now fields.
@@ -336,6 +352,9 @@ func updateRecord(
 			return false, false, err
 		}
 	}
+	if err := triggers.fire(ctx, ast.TriggerAfter, oldData, newData); err != nil {
+		return false, false, err
+	}
 	if onDup {
 		sc.AddAffectedRows(2)
 	} else {
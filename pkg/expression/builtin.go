@@ -402,7 +402,7 @@ func (b *baseBuiltinFunc) isChildrenVectorized() bool {
 }
 
 func (b *baseBuiltinFunc) getRetTp() *types.FieldType {
-	if b.tp.EvalType() == types.ETString {
+	if b.tp.EvalType() == types.ETString && b.tp.GetType() != mysql.TypeGeometry {
 		if b.tp.GetFlen() >= mysql.MaxBlobWidth {
 			b.tp.SetType(mysql.TypeLongBlob)
 		} else if b.tp.GetFlen() >= 65536 {
@@ -978,6 +978,50 @@ var funcs = map[string]functionClass{
 	ast.VecFromText:             &vecFromTextFunctionClass{baseFunctionClass{ast.VecFromText, 1, 1}},
 	ast.VecAsText:               &vecAsTextFunctionClass{baseFunctionClass{ast.VecAsText, 1, 1}},
 
+	// spatial functions
+	ast.STGeomFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 3}, types.GeometryTypeGeometry},
+	ast.STGeometryFromText:   &geomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 3}, types.GeometryTypeGeometry},
+	ast.STPointFromText:      &geomFromTextFunctionClass{baseFunctionClass{ast.STPointFromText, 1, 3}, types.GeometryTypePoint},
+	ast.STLineFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STLineFromText, 1, 3}, types.GeometryTypeLineString},
+	ast.STLineStringFromText: &geomFromTextFunctionClass{baseFunctionClass{ast.STLineStringFromText, 1, 3}, types.GeometryTypeLineString},
+	ast.STPolyFromText:       &geomFromTextFunctionClass{baseFunctionClass{ast.STPolyFromText, 1, 3}, types.GeometryTypePolygon},
+	ast.STPolygonFromText:    &geomFromTextFunctionClass{baseFunctionClass{ast.STPolygonFromText, 1, 3}, types.GeometryTypePolygon},
+	ast.STGeomFromWKB:        &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 3}},
+	ast.STGeometryFromWKB:    &geomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 3}},
+	ast.STGeomFromGeoJSON:    &geomFromGeoJSONFunctionClass{baseFunctionClass{ast.STGeomFromGeoJSON, 1, 3}},
+	ast.Point:                &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.LineString:           &geomConstructorFunctionClass{baseFunctionClass{ast.LineString, 2, -1}, types.GeometryTypeLineString},
+	ast.Polygon:              &geomConstructorFunctionClass{baseFunctionClass{ast.Polygon, 1, -1}, types.GeometryTypePolygon},
+	ast.MultiPoint:           &geomConstructorFunctionClass{baseFunctionClass{ast.MultiPoint, 1, -1}, types.GeometryTypeMultiPoint},
+	ast.MultiLineString:      &geomConstructorFunctionClass{baseFunctionClass{ast.MultiLineString, 1, -1}, types.GeometryTypeMultiLineString},
+	ast.MultiPolygon:         &geomConstructorFunctionClass{baseFunctionClass{ast.MultiPolygon, 1, -1}, types.GeometryTypeMultiPolygon},
+	ast.GeometryCollection:   &geomConstructorFunctionClass{baseFunctionClass{ast.GeometryCollection, 0, -1}, types.GeometryTypeGeometryCollection},
+	ast.GeomCollection:       &geomConstructorFunctionClass{baseFunctionClass{ast.GeomCollection, 0, -1}, types.GeometryTypeGeometryCollection},
+	ast.STAsText:             &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 2}},
+	ast.STAsWKT:              &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 2}},
+	ast.STAsBinary:           &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 2}},
+	ast.STAsWKB:              &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 2}},
+	ast.STAsGeoJSON:          &stAsGeoJSONFunctionClass{baseFunctionClass{ast.STAsGeoJSON, 1, 3}},
+	ast.STSRID:               &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 2}},
+	ast.STX:                  &stCoordinateFunctionClass{baseFunctionClass{ast.STX, 1, 1}, coordinateX},
+	ast.STY:                  &stCoordinateFunctionClass{baseFunctionClass{ast.STY, 1, 1}, coordinateY},
+	ast.STLatitude:           &stCoordinateFunctionClass{baseFunctionClass{ast.STLatitude, 1, 1}, coordinateLatitude},
+	ast.STLongitude:          &stCoordinateFunctionClass{baseFunctionClass{ast.STLongitude, 1, 1}, coordinateLongitude},
+	ast.STGeometryType:       &stGeometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STIsEmpty:            &stIsEmptyFunctionClass{baseFunctionClass{ast.STIsEmpty, 1, 1}},
+	ast.STDistance:           &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STDistanceSphere:     &stDistanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
+	ast.STArea:               &stMeasureFunctionClass{baseFunctionClass{ast.STArea, 1, 1}},
+	ast.STLength:             &stMeasureFunctionClass{baseFunctionClass{ast.STLength, 1, 1}},
+	ast.STContains:           &stRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STWithin:             &stRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STIntersects:         &stRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+	ast.STDisjoint:           &stRelationFunctionClass{baseFunctionClass{ast.STDisjoint, 2, 2}},
+	ast.STEquals:             &stRelationFunctionClass{baseFunctionClass{ast.STEquals, 2, 2}},
+
+	// full-text search function
+	ast.Match: &matchAgainstFunctionClass{baseFunctionClass{ast.Match, 4, -1}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey:       &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	ast.TiDBMVCCInfo:        &tidbMVCCInfoFunctionClass{baseFunctionClass: baseFunctionClass{ast.TiDBMVCCInfo, 1, 1}},
@@ -0,0 +1,272 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+// MATCH (col1, col2, ...) AGAINST (expr [modifier]) is built as the function
+// match(col1, col2, ..., expr, mode, parser), the last 3 arguments are the
+// string constants of the search string, the search mode and the parser of
+// the full-text index.
+
+const (
+	// FullTextNaturalLanguageMode is the mode argument of the natural language search.
+	FullTextNaturalLanguageMode = "NATURAL LANGUAGE MODE"
+	// FullTextBooleanMode is the mode argument of the boolean search.
+	FullTextBooleanMode = "BOOLEAN MODE"
+
+	// The parameters of the BM25 relevance.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+var (
+	_ functionClass = &matchAgainstFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinMatchAgainstSig{}
+)
+
+// FullTextTerm is a term of the full-text search.
+type FullTextTerm struct {
+	// Tokens are the tokens of the term, a term with multiple tokens is a
+	// phrase which matches the consecutive tokens.
+	Tokens []string
+	// Prefix indicates the term matches the tokens with Tokens[0] as prefix.
+	Prefix bool
+	// Required and Excluded are the '+' and '-' operators of the boolean mode.
+	Required bool
+	Excluded bool
+}
+
+// FullTextStats is the statistics used by the relevance of the full-text search.
+type FullTextStats struct {
+	// RowCount is the number of rows of the table.
+	RowCount float64
+	// AvgDocLen is the average number of the distinct tokens of a row, the
+	// length of the rows is not normalized if it's 0.
+	AvgDocLen float64
+	// DocFreqs are the number of rows matching each term.
+	DocFreqs []float64
+}
+
+// FullTextSearch is the parsed search of a MATCH ... AGAINST function.
+type FullTextSearch struct {
+	Parser      model.FullTextParserType
+	BooleanMode bool
+	Terms       []FullTextTerm
+	// Stats is set by the planner, the idf of all the terms are the same if
+	// it's nil.
+	Stats *FullTextStats
+}
+
+// NewFullTextSearch parses the search string. In the natural language mode,
+// every distinct token is an optional term. In the boolean mode, the '+' and
+// '-' operators, the double quoted phrases and the truncation operator '*'
+// are supported, and the other operators are ignored.
+func NewFullTextSearch(parser model.FullTextParserType, query string, booleanMode bool) *FullTextSearch {
+	s := &FullTextSearch{Parser: parser, BooleanMode: booleanMode}
+	if !booleanMode {
+		tokens := tablecodec.FullTextTokenize(parser, query)
+		slices.Sort(tokens)
+		for _, token := range slices.Compact(tokens) {
+			s.Terms = append(s.Terms, FullTextTerm{Tokens: []string{token}})
+		}
+		return s
+	}
+	runes := []rune(query)
+	var required, excluded bool
+	for i := 0; i < len(runes); {
+		var term FullTextTerm
+		switch r := runes[i]; {
+		case r == '+' || r == '-':
+			required, excluded = r == '+', r == '-'
+			i++
+			continue
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			term.Tokens = tablecodec.FullTextTokenize(parser, string(runes[i+1:end]))
+			i = end + 1
+		case tablecodec.IsFullTextWordChar(r):
+			end := i
+			for end < len(runes) && tablecodec.IsFullTextWordChar(runes[end]) {
+				end++
+			}
+			if end < len(runes) && runes[end] == '*' {
+				term.Tokens = tablecodec.FullTextTokenizePrefix(parser, string(runes[i:end]))
+				term.Prefix = len(term.Tokens) == 1
+				end++
+			} else {
+				term.Tokens = tablecodec.FullTextTokenize(parser, string(runes[i:end]))
+			}
+			i = end
+		default:
+			// The grouping and the relevance operators are not supported.
+			i++
+		}
+		if len(term.Tokens) > 0 {
+			term.Required, term.Excluded = required, excluded
+			s.Terms = append(s.Terms, term)
+		}
+		required, excluded = false, false
+	}
+	return s
+}
+
+// frequency returns the number of the occurrences of the term in the tokens
+// of the columns.
+func (t *FullTextTerm) frequency(docs [][]string, counts map[string]int) int {
+	n := 0
+	switch {
+	case len(t.Tokens) > 1:
+		for _, doc := range docs {
+			for i := 0; i+len(t.Tokens) <= len(doc); i++ {
+				if slices.Equal(doc[i:i+len(t.Tokens)], t.Tokens) {
+					n++
+				}
+			}
+		}
+	case t.Prefix:
+		for token, c := range counts {
+			if strings.HasPrefix(token, t.Tokens[0]) {
+				n += c
+			}
+		}
+	default:
+		n = counts[t.Tokens[0]]
+	}
+	return n
+}
+
+// Relevance returns the BM25 relevance of a row, docs are the tokens of the
+// columns of the row. It's 0 if the row doesn't match the search.
+func (s *FullTextSearch) Relevance(docs [][]string) float64 {
+	var stats FullTextStats
+	if s.Stats != nil {
+		stats = *s.Stats
+	}
+	counts := make(map[string]int)
+	for _, doc := range docs {
+		for _, token := range doc {
+			counts[token]++
+		}
+	}
+	// The length of a row is the number of the distinct tokens, which is the
+	// number of its index entries.
+	norm := 1.0
+	if stats.AvgDocLen > 0 {
+		norm = 1 - bm25B + bm25B*float64(len(counts))/stats.AvgDocLen
+	}
+	score := 0.0
+	for i, term := range s.Terms {
+		tf := float64(term.frequency(docs, counts))
+		if term.Excluded {
+			if tf > 0 {
+				return 0
+			}
+			continue
+		}
+		if tf == 0 {
+			if term.Required {
+				return 0
+			}
+			continue
+		}
+		df := 0.0
+		if i < len(stats.DocFreqs) {
+			df = min(stats.DocFreqs[i], stats.RowCount)
+		}
+		idf := math.Log(1 + (stats.RowCount-df+0.5)/(df+0.5))
+		score += idf * tf * (bm25K1 + 1) / (tf + bm25K1*norm)
+	}
+	return score
+}
+
+// GetFullTextSearch returns the full-text search of the MATCH ... AGAINST function.
+func GetFullTextSearch(sf *ScalarFunction) (*FullTextSearch, bool) {
+	sig, ok := sf.Function.(*builtinMatchAgainstSig)
+	if !ok {
+		return nil, false
+	}
+	return sig.search, true
+}
+
+type matchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *matchAgainstFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	for range args {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	var consts [3]string
+	for i, arg := range bf.args[len(args)-3:] {
+		str, _, err := GetStringFromConstant(ctx.GetEvalCtx(), arg)
+		if err != nil {
+			return nil, err
+		}
+		consts[i] = str
+	}
+	search := NewFullTextSearch(model.FullTextParserType(consts[2]), consts[0], consts[1] == FullTextBooleanMode)
+	return &builtinMatchAgainstSig{bf, search}, nil
+}
+
+type builtinMatchAgainstSig struct {
+	baseBuiltinFunc
+	search *FullTextSearch
+}
+
+func (b *builtinMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinMatchAgainstSig{search: b.search}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinMatchAgainstSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	cols := b.args[:len(b.args)-3]
+	docs := make([][]string, 0, len(cols))
+	for _, col := range cols {
+		str, isNull, err := col.EvalString(ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if isNull {
+			continue
+		}
+		docs = append(docs, tablecodec.FullTextTokenize(b.search.Parser, str))
+	}
+	return b.search.Relevance(docs), false, nil
+}
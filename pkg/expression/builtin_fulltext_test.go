@@ -0,0 +1,98 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFullTextSearch(t *testing.T) {
+	s := NewFullTextSearch(model.FullTextParserTypeStandard, "Database, the DATABASE systems", false)
+	require.False(t, s.BooleanMode)
+	require.Equal(t, []FullTextTerm{{Tokens: []string{"database"}}, {Tokens: []string{"systems"}}}, s.Terms)
+
+	s = NewFullTextSearch(model.FullTextParserTypeStandard, `+tidb -mysql "distributed sql" dat* ~(the) 数据库`, true)
+	require.True(t, s.BooleanMode)
+	require.Equal(t, []FullTextTerm{
+		{Tokens: []string{"tidb"}, Required: true},
+		{Tokens: []string{"mysql"}, Excluded: true},
+		{Tokens: []string{"distributed", "sql"}},
+		{Tokens: []string{"dat"}, Prefix: true},
+		{Tokens: []string{"数据", "据库"}},
+	}, s.Terms)
+
+	s = NewFullTextSearch(model.FullTextParserTypeNgram, "+数* -\"ab\"", true)
+	require.Equal(t, []FullTextTerm{
+		{Tokens: []string{"数"}, Prefix: true, Required: true},
+		{Tokens: []string{"ab"}, Excluded: true},
+	}, s.Terms)
+	require.Empty(t, NewFullTextSearch(model.FullTextParserTypeStandard, "+the -a", true).Terms)
+}
+
+func TestFullTextRelevance(t *testing.T) {
+	tokenize := func(texts ...string) [][]string {
+		docs := make([][]string, 0, len(texts))
+		for _, text := range texts {
+			docs = append(docs, tablecodec.FullTextTokenize(model.FullTextParserTypeStandard, text))
+		}
+		return docs
+	}
+	s := NewFullTextSearch(model.FullTextParserTypeStandard, "database tidb", false)
+	require.Zero(t, s.Relevance(tokenize("mysql server")))
+	one := s.Relevance(tokenize("tidb server"))
+	two := s.Relevance(tokenize("tidb database"))
+	require.Greater(t, one, 0.0)
+	require.Greater(t, two, one)
+	require.Greater(t, s.Relevance(tokenize("tidb tidb")), one)
+
+	// The rarer term has the higher relevance.
+	s.Stats = &FullTextStats{RowCount: 100, AvgDocLen: 2, DocFreqs: []float64{50, 1}}
+	require.Greater(t, s.Relevance(tokenize("tidb server")), s.Relevance(tokenize("database server")))
+	// The shorter row has the higher relevance.
+	require.Greater(t, s.Relevance(tokenize("tidb")), s.Relevance(tokenize("tidb server", "distributed sql")))
+
+	s = NewFullTextSearch(model.FullTextParserTypeStandard, `+tidb -mysql "distributed sql" serv*`, true)
+	require.Zero(t, s.Relevance(tokenize("distributed sql")))
+	require.Zero(t, s.Relevance(tokenize("tidb", "mysql")))
+	plain := s.Relevance(tokenize("tidb"))
+	require.Greater(t, plain, 0.0)
+	require.Greater(t, s.Relevance(tokenize("tidb", "distributed sql")), plain)
+	require.Equal(t, plain, s.Relevance(tokenize("tidb", "sql distributed")))
+	require.Greater(t, s.Relevance(tokenize("tidb servers")), plain)
+}
+
+func TestMatchAgainst(t *testing.T) {
+	ctx := createContext(t)
+	args := datumsToConstants(types.MakeDatums("TiDB is a distributed SQL database", nil, "+distributed database", FullTextBooleanMode, string(model.FullTextParserTypeStandard)))
+	f, err := funcs[ast.Match].getFunction(ctx, args)
+	require.NoError(t, err)
+	d, err := evalBuiltinFunc(f, ctx.GetEvalCtx(), chunk.Row{})
+	require.NoError(t, err)
+	require.Greater(t, d.GetFloat64(), 0.0)
+
+	args = datumsToConstants(types.MakeDatums("TiDB is a distributed SQL database", "+mysql", FullTextBooleanMode, string(model.FullTextParserTypeStandard)))
+	f, err = funcs[ast.Match].getFunction(ctx, args)
+	require.NoError(t, err)
+	d, err = evalBuiltinFunc(f, ctx.GetEvalCtx(), chunk.Row{})
+	require.NoError(t, err)
+	require.Zero(t, d.GetFloat64())
+}
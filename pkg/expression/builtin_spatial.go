@@ -0,0 +1,1056 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/hack"
+)
+
+// The geometries are evaluated as the binary strings in the storage format,
+// which is the 4 bytes SRID followed by the WKB.
+
+var (
+	_ functionClass = &geomFromTextFunctionClass{}
+	_ functionClass = &geomFromWKBFunctionClass{}
+	_ functionClass = &geomFromGeoJSONFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &geomConstructorFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stAsGeoJSONFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stIsEmptyFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+	_ functionClass = &stMeasureFunctionClass{}
+	_ functionClass = &stRelationFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinGeomFromTextSig{}
+	_ builtinFunc = &builtinGeomFromWKBSig{}
+	_ builtinFunc = &builtinGeomFromGeoJSONSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinGeomConstructorSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTAsGeoJSONSig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSetSRIDSig{}
+	_ builtinFunc = &builtinSTCoordinateSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTIsEmptySig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+	_ builtinFunc = &builtinSTMeasureSig{}
+	_ builtinFunc = &builtinSTRelationSig{}
+)
+
+// setGeometryRetType sets the return type of the functions which return the
+// geometries.
+func setGeometryRetType(tp *types.FieldType, geomType types.GeometryType) {
+	tp.SetType(mysql.TypeGeometry)
+	tp.SetGeometryType(geomType)
+	tp.SetFlen(mysql.MaxBlobWidth)
+	tp.SetDecimal(types.UnspecifiedLength)
+	types.SetBinChsClnFlag(tp)
+}
+
+// geometryArgTps returns the eval types of the arguments, the first n
+// arguments are geometries and the others are the given types.
+func geometryArgTps(n int, others ...types.EvalType) []types.EvalType {
+	argTps := make([]types.EvalType, 0, n+len(others))
+	for range n {
+		argTps = append(argTps, types.ETString)
+	}
+	return append(argTps, others...)
+}
+
+func parseGeometryArg(fn string, s string) (types.Geometry, error) {
+	return types.ParseGeometry(fn, hack.Slice(s))
+}
+
+// evalSRIDArg evaluates the SRID argument, it must be a valid uint32 of a
+// known spatial reference system.
+func evalSRIDArg(ctx EvalContext, arg Expression, row chunk.Row) (uint32, bool, error) {
+	srid, isNull, err := arg.EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return checkSRID(srid)
+}
+
+func checkSRID(srid int64) (uint32, bool, error) {
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, false, types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	if _, ok := types.GetSpatialReferenceSystem(uint32(srid)); !ok {
+		return 0, false, types.ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return uint32(srid), false, nil
+}
+
+// evalAxisOrderArg evaluates the options argument of the functions which
+// parse or output the WKT and WKB.
+func evalAxisOrderArg(ctx EvalContext, fn string, arg Expression, row chunk.Row) (types.AxisOrder, bool, error) {
+	opts, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.AxisOrderSRIDDefined, isNull, err
+	}
+	order, err := types.ParseAxisOrder(fn, opts)
+	return order, false, err
+}
+
+// geometryFromArgs parses the geometry from the WKT or WKB, with the optional
+// SRID and options arguments.
+type geometryFromArgs struct {
+	baseBuiltinFunc
+	name     string
+	geomType types.GeometryType
+}
+
+func (b *geometryFromArgs) cloneFrom(from *geometryFromArgs) {
+	b.baseBuiltinFunc.cloneFrom(&from.baseBuiltinFunc)
+	b.name = from.name
+	b.geomType = from.geomType
+}
+
+// evalSRIDAndOrder evaluates the optional SRID and options arguments.
+func (b *geometryFromArgs) evalSRIDAndOrder(ctx EvalContext, row chunk.Row) (srid uint32, order types.AxisOrder, isNull bool, err error) {
+	if len(b.args) > 1 {
+		if srid, isNull, err = evalSRIDArg(ctx, b.args[1], row); isNull || err != nil {
+			return
+		}
+	}
+	if len(b.args) > 2 {
+		order, isNull, err = evalAxisOrderArg(ctx, b.name, b.args[2], row)
+	}
+	return
+}
+
+func (b *geometryFromArgs) checkType(g types.Geometry) error {
+	if b.geomType != types.GeometryTypeGeometry && g.Type != b.geomType {
+		return types.ErrGISInvalidData.GenWithStackByArgs(b.name)
+	}
+	return nil
+}
+
+type geomFromTextFunctionClass struct {
+	baseFunctionClass
+	geomType types.GeometryType
+}
+
+func (c *geomFromTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, c.geomType)
+	return &builtinGeomFromTextSig{geometryFromArgs{bf, c.funcName, c.geomType}}, nil
+}
+
+type builtinGeomFromTextSig struct {
+	geometryFromArgs
+}
+
+func (b *builtinGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromTextSig{}
+	newSig.cloneFrom(&b.geometryFromArgs)
+	return newSig
+}
+
+func (b *builtinGeomFromTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, order, isNull, err := b.evalSRIDAndOrder(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return b.fromText(wkt, srid, order)
+}
+
+func (b *builtinGeomFromTextSig) fromText(wkt string, srid uint32, order types.AxisOrder) (string, bool, error) {
+	g, err := types.ParseGeometryFromWKT(b.name, wkt, srid, order)
+	if err != nil {
+		return "", false, err
+	}
+	if err = b.checkType(g); err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+type geomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromWKBFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETString}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, types.GeometryTypeGeometry)
+	return &builtinGeomFromWKBSig{geometryFromArgs{bf, c.funcName, types.GeometryTypeGeometry}}, nil
+}
+
+type builtinGeomFromWKBSig struct {
+	geometryFromArgs
+}
+
+func (b *builtinGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromWKBSig{}
+	newSig.cloneFrom(&b.geometryFromArgs)
+	return newSig
+}
+
+func (b *builtinGeomFromWKBSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, order, isNull, err := b.evalSRIDAndOrder(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return b.fromWKB(wkb, srid, order)
+}
+
+func (b *builtinGeomFromWKBSig) fromWKB(wkb string, srid uint32, order types.AxisOrder) (string, bool, error) {
+	g, err := types.ParseGeometryFromWKB(b.name, hack.Slice(wkb), srid, order)
+	if err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+// geoJSONDefaultSRID is the default SRID of ST_GeomFromGeoJSON.
+const geoJSONDefaultSRID = 4326
+
+type geomFromGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *geomFromGeoJSONFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETJson, types.ETInt, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, types.GeometryTypeGeometry)
+	return &builtinGeomFromGeoJSONSig{bf}, nil
+}
+
+type builtinGeomFromGeoJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinGeomFromGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinGeomFromGeoJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinGeomFromGeoJSONSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	j, isNull, err := b.args[0].EvalJSON(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	options := int64(1)
+	if len(b.args) > 1 {
+		if options, isNull, err = b.args[1].EvalInt(ctx, row); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	srid := int64(geoJSONDefaultSRID)
+	if len(b.args) > 2 {
+		if srid, isNull, err = b.args[2].EvalInt(ctx, row); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	return b.fromGeoJSON(j, options, srid)
+}
+
+// fromGeoJSON parses the GeoJSON, the options 1 rejects the coordinates with
+// more than 2 dimensions, and 2, 3, 4 ignore the higher dimensions.
+func (*builtinGeomFromGeoJSONSig) fromGeoJSON(j types.BinaryJSON, options int64, srid int64) (string, bool, error) {
+	if options < 1 || options > 4 {
+		return "", false, types.ErrGISInvalidData.GenWithStackByArgs(ast.STGeomFromGeoJSON)
+	}
+	validSRID, _, err := checkSRID(srid)
+	if err != nil {
+		return "", false, err
+	}
+	g, err := types.ParseGeometryFromGeoJSON(ast.STGeomFromGeoJSON, j, validSRID, options == 1)
+	if err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, types.GeometryTypePoint)
+	return &builtinPointSig{bf}, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinPointSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(types.NewPointGeometry(x, y).Encode()), false, nil
+}
+
+// geomConstructorFunctionClass is the function class of LineString(),
+// Polygon(), MultiPoint(), MultiLineString(), MultiPolygon() and
+// GeomCollection().
+type geomConstructorFunctionClass struct {
+	baseFunctionClass
+	geomType types.GeometryType
+}
+
+func (c *geomConstructorFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, geometryArgTps(len(args))...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, c.geomType)
+	return &builtinGeomConstructorSig{geometryFromArgs{bf, c.funcName, c.geomType}}, nil
+}
+
+type builtinGeomConstructorSig struct {
+	geometryFromArgs
+}
+
+func (b *builtinGeomConstructorSig) Clone() builtinFunc {
+	newSig := &builtinGeomConstructorSig{}
+	newSig.cloneFrom(&b.geometryFromArgs)
+	return newSig
+}
+
+func (b *builtinGeomConstructorSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	parts := make([]string, 0, len(b.args))
+	for _, arg := range b.args {
+		s, isNull, err := arg.EvalString(ctx, row)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		parts = append(parts, s)
+	}
+	return b.construct(parts)
+}
+
+func (b *builtinGeomConstructorSig) construct(args []string) (string, bool, error) {
+	parts := make([]types.Geometry, 0, len(args))
+	for _, arg := range args {
+		g, err := parseGeometryArg(b.name, arg)
+		if err != nil {
+			return "", false, err
+		}
+		parts = append(parts, g)
+	}
+	g, err := types.NewGeometryFromParts(b.name, b.geomType, parts)
+	if err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+// geometryOutput outputs the geometry with the optional options argument.
+type geometryOutput struct {
+	baseBuiltinFunc
+	name string
+}
+
+func (b *geometryOutput) cloneFrom(from *geometryOutput) {
+	b.baseBuiltinFunc.cloneFrom(&from.baseBuiltinFunc)
+	b.name = from.name
+}
+
+func (b *geometryOutput) evalGeometryAndOrder(ctx EvalContext, row chunk.Row) (g types.Geometry, order types.AxisOrder, isNull bool, err error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return g, order, isNull, err
+	}
+	if len(b.args) > 1 {
+		if order, isNull, err = evalAxisOrderArg(ctx, b.name, b.args[1], row); isNull || err != nil {
+			return g, order, isNull, err
+		}
+	}
+	g, err = parseGeometryArg(b.name, s)
+	return g, order, false, err
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, geometryArgTps(1, types.ETString)[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.DelFlag(mysql.BinaryFlag)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsTextSig{geometryOutput{bf, c.funcName}}, nil
+}
+
+type builtinSTAsTextSig struct {
+	geometryOutput
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{}
+	newSig.cloneFrom(&b.geometryOutput)
+	return newSig
+}
+
+func (b *builtinSTAsTextSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, order, isNull, err := b.evalGeometryAndOrder(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(order), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, geometryArgTps(1, types.ETString)[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	types.SetBinChsClnFlag(bf.tp)
+	bf.tp.SetType(mysql.TypeLongBlob)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsBinarySig{geometryOutput{bf, c.funcName}}, nil
+}
+
+type builtinSTAsBinarySig struct {
+	geometryOutput
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{}
+	newSig.cloneFrom(&b.geometryOutput)
+	return newSig
+}
+
+func (b *builtinSTAsBinarySig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	g, order, isNull, err := b.evalGeometryAndOrder(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.WKB(order)), false, nil
+}
+
+// geoJSONDefaultMaxDecimals is the default max decimal digits of ST_AsGeoJSON.
+const geoJSONDefaultMaxDecimals = math.MaxInt32
+
+type stAsGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsGeoJSONFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, geometryArgTps(1, types.ETInt, types.ETInt)[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTAsGeoJSONSig{bf}, nil
+}
+
+type builtinSTAsGeoJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTAsGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinSTAsGeoJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTAsGeoJSONSig) evalJSON(ctx EvalContext, row chunk.Row) (types.BinaryJSON, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, isNull, err
+	}
+	maxDecimals, options := int64(geoJSONDefaultMaxDecimals), int64(0)
+	if len(b.args) > 1 {
+		if maxDecimals, isNull, err = b.args[1].EvalInt(ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, isNull, err
+		}
+	}
+	if len(b.args) > 2 {
+		if options, isNull, err = b.args[2].EvalInt(ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, isNull, err
+		}
+	}
+	return b.asGeoJSON(s, maxDecimals, options)
+}
+
+func (*builtinSTAsGeoJSONSig) asGeoJSON(s string, maxDecimals, options int64) (types.BinaryJSON, bool, error) {
+	if maxDecimals < 0 || options < 0 || options > 7 {
+		return types.BinaryJSON{}, false, types.ErrGISInvalidData.GenWithStackByArgs(ast.STAsGeoJSON)
+	}
+	g, err := parseGeometryArg(ast.STAsGeoJSON, s)
+	if err != nil {
+		return types.BinaryJSON{}, false, err
+	}
+	return g.GeoJSON(int(min(maxDecimals, math.MaxInt32)), int(options)), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+		if err != nil {
+			return nil, err
+		}
+		bf.tp.AddFlag(mysql.UnsignedFlag)
+		return &builtinSTSRIDSig{bf}, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp, args[0].GetType(ctx.GetEvalCtx()).GetGeometryType())
+	return &builtinSTSetSRIDSig{bf}, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSRIDSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.evalSRID(s)
+}
+
+func (*builtinSTSRIDSig) evalSRID(s string) (int64, bool, error) {
+	g, err := parseGeometryArg(ast.STSRID, s)
+	if err != nil {
+		return 0, false, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+// builtinSTSetSRIDSig is ST_SRID(g, srid), it returns the geometry with the
+// coordinates unchanged and the SRID replaced.
+type builtinSTSetSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSetSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSetSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTSetSRIDSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := b.args[1].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return b.setSRID(s, srid)
+}
+
+func (*builtinSTSetSRIDSig) setSRID(s string, srid int64) (string, bool, error) {
+	g, err := parseGeometryArg(ast.STSRID, s)
+	if err != nil {
+		return "", false, err
+	}
+	validSRID, _, err := checkSRID(srid)
+	if err != nil {
+		return "", false, err
+	}
+	g.SetSRID(validSRID)
+	// Validates the coordinates in the new spatial reference system.
+	if g, err = types.ParseGeometry(ast.STSRID, g.Encode()); err != nil {
+		return "", false, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+// Coordinates of ST_X, ST_Y, ST_Latitude and ST_Longitude.
+const (
+	coordinateX = iota
+	coordinateY
+	coordinateLatitude
+	coordinateLongitude
+)
+
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+	coordinate int
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTCoordinateSig{bf, c.funcName, c.coordinate}, nil
+}
+
+type builtinSTCoordinateSig struct {
+	baseBuiltinFunc
+	name       string
+	coordinate int
+}
+
+func (b *builtinSTCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordinateSig{name: b.name, coordinate: b.coordinate}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTCoordinateSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.getCoordinate(s)
+}
+
+// getCoordinate returns the coordinate of the point. ST_X and ST_Y return
+// the first and second coordinates in the axis order of the spatial reference
+// system.
+func (b *builtinSTCoordinateSig) getCoordinate(s string) (float64, bool, error) {
+	g, err := parseGeometryArg(b.name, s)
+	if err != nil {
+		return 0, false, err
+	}
+	if g.Type != types.GeometryTypePoint {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(b.name)
+	}
+	srs, _ := types.GetSpatialReferenceSystem(g.SRID)
+	p := g.Points[0]
+	switch b.coordinate {
+	case coordinateX:
+		if srs.LatLong {
+			return p.Y, false, nil
+		}
+		return p.X, false, nil
+	case coordinateY:
+		if srs.LatLong {
+			return p.X, false, nil
+		}
+		return p.Y, false, nil
+	}
+	if !srs.Geographic {
+		return 0, false, types.ErrGISUnsupportedArgument.GenWithStackByArgs(b.name)
+	}
+	if b.coordinate == coordinateLatitude {
+		return p.Y, false, nil
+	}
+	return p.X, false, nil
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.DelFlag(mysql.BinaryFlag)
+	bf.tp.SetFlen(len("GEOMCOLLECTION"))
+	return &builtinSTGeometryTypeSig{bf}, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTGeometryTypeSig) evalString(ctx EvalContext, row chunk.Row) (string, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := parseGeometryArg(ast.STGeometryType, s)
+	if err != nil {
+		return "", false, err
+	}
+	return g.TypeName(), false, nil
+}
+
+type stIsEmptyFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stIsEmptyFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinSTIsEmptySig{bf}, nil
+}
+
+type builtinSTIsEmptySig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTIsEmptySig) Clone() builtinFunc {
+	newSig := &builtinSTIsEmptySig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTIsEmptySig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.isEmpty(s)
+}
+
+func (*builtinSTIsEmptySig) isEmpty(s string) (int64, bool, error) {
+	g, err := parseGeometryArg(ast.STIsEmpty, s)
+	if err != nil {
+		return 0, false, err
+	}
+	if g.IsEmpty() {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, geometryArgTps(2)...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSig{bf}, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTDistanceSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	s1, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	s2, isNull, err := b.args[1].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.distance(s1, s2)
+}
+
+// distance returns NULL if any of the geometries is empty.
+func (*builtinSTDistanceSig) distance(s1, s2 string) (float64, bool, error) {
+	g1, err := parseGeometryArg(ast.STDistance, s1)
+	if err != nil {
+		return 0, false, err
+	}
+	g2, err := parseGeometryArg(ast.STDistance, s2)
+	if err != nil {
+		return 0, false, err
+	}
+	d, ok, err := g1.Distance(ast.STDistance, g2)
+	return d, !ok, err
+}
+
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, geometryArgTps(2, types.ETReal)[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSphereSig{bf}, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTDistanceSphereSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	s1, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	s2, isNull, err := b.args[1].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	radius := float64(types.DefaultSphereRadius)
+	if len(b.args) > 2 {
+		if radius, isNull, err = b.args[2].EvalReal(ctx, row); isNull || err != nil {
+			return 0, isNull, err
+		}
+	}
+	return b.distanceSphere(s1, s2, radius)
+}
+
+func (*builtinSTDistanceSphereSig) distanceSphere(s1, s2 string, radius float64) (float64, bool, error) {
+	g1, err := parseGeometryArg(ast.STDistanceSphere, s1)
+	if err != nil {
+		return 0, false, err
+	}
+	g2, err := parseGeometryArg(ast.STDistanceSphere, s2)
+	if err != nil {
+		return 0, false, err
+	}
+	d, err := g1.DistanceSphere(ast.STDistanceSphere, g2, radius)
+	return d, false, err
+}
+
+// stMeasureFunctionClass is the function class of ST_Area and ST_Length.
+type stMeasureFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stMeasureFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTMeasureSig{bf, c.funcName}, nil
+}
+
+type builtinSTMeasureSig struct {
+	baseBuiltinFunc
+	name string
+}
+
+func (b *builtinSTMeasureSig) Clone() builtinFunc {
+	newSig := &builtinSTMeasureSig{name: b.name}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTMeasureSig) evalReal(ctx EvalContext, row chunk.Row) (float64, bool, error) {
+	s, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.measure(s)
+}
+
+func (b *builtinSTMeasureSig) measure(s string) (float64, bool, error) {
+	g, err := parseGeometryArg(b.name, s)
+	if err != nil {
+		return 0, false, err
+	}
+	var res float64
+	if b.name == ast.STArea {
+		res, err = g.Area(b.name)
+	} else {
+		res, err = g.Length(b.name)
+	}
+	return res, false, err
+}
+
+// stRelationFunctionClass is the function class of ST_Contains, ST_Within,
+// ST_Intersects, ST_Disjoint and ST_Equals.
+type stRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stRelationFunctionClass) getFunction(ctx BuildContext, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, geometryArgTps(2)...)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinSTRelationSig{bf, c.funcName}, nil
+}
+
+type builtinSTRelationSig struct {
+	baseBuiltinFunc
+	name string
+}
+
+func (b *builtinSTRelationSig) Clone() builtinFunc {
+	newSig := &builtinSTRelationSig{name: b.name}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinSTRelationSig) evalInt(ctx EvalContext, row chunk.Row) (int64, bool, error) {
+	s1, isNull, err := b.args[0].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	s2, isNull, err := b.args[1].EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return b.relate(s1, s2)
+}
+
+func (b *builtinSTRelationSig) relate(s1, s2 string) (int64, bool, error) {
+	g1, err := parseGeometryArg(b.name, s1)
+	if err != nil {
+		return 0, false, err
+	}
+	g2, err := parseGeometryArg(b.name, s2)
+	if err != nil {
+		return 0, false, err
+	}
+	var res bool
+	switch b.name {
+	case ast.STContains:
+		res, err = g1.Contains(b.name, g2)
+	case ast.STWithin:
+		res, err = g2.Contains(b.name, g1)
+	case ast.STIntersects:
+		res, err = g1.Intersects(b.name, g2)
+	case ast.STDisjoint:
+		res, err = g1.Intersects(b.name, g2)
+		res = !res
+	case ast.STEquals:
+		res, err = g1.Equals(b.name, g2)
+	}
+	if err != nil || !res {
+		return 0, false, err
+	}
+	return 1, false, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func evalSpatialFunc(ctx BuildContext, name string, args ...any) (types.Datum, error) {
+	f, err := funcs[name].getFunction(ctx, datumsToConstants(types.MakeDatums(args...)))
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return evalBuiltinFunc(f, ctx.GetEvalCtx(), chunk.Row{})
+}
+
+func TestSpatialFromAndAsText(t *testing.T) {
+	ctx := createContext(t)
+	geomFromText := func(args ...any) string {
+		d, err := evalSpatialFunc(ctx, ast.STGeomFromText, args...)
+		require.NoError(t, err)
+		return d.GetString()
+	}
+
+	tests := []struct {
+		args     []any
+		expected string
+	}{
+		{[]any{"POINT(1 2)"}, "POINT(1 2)"},
+		{[]any{"LINESTRING(0 0,1 1)", 0}, "LINESTRING(0 0,1 1)"},
+		{[]any{"POINT(30 120)", 4326}, "POINT(30 120)"},
+		{[]any{"POINT(120 30)", 4326, "axis-order=long-lat"}, "POINT(30 120)"},
+	}
+	for _, tt := range tests {
+		d, err := evalSpatialFunc(ctx, ast.STAsText, geomFromText(tt.args...))
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, d.GetString())
+	}
+	d, err := evalSpatialFunc(ctx, ast.STAsText, geomFromText("POINT(30 120)", 4326), "axis-order=long-lat")
+	require.NoError(t, err)
+	require.Equal(t, "POINT(120 30)", d.GetString())
+
+	d, err = evalSpatialFunc(ctx, ast.STGeomFromText, nil)
+	require.NoError(t, err)
+	require.True(t, d.IsNull())
+	_, err = evalSpatialFunc(ctx, ast.STGeomFromText, "POINT(1)")
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STPointFromText, "LINESTRING(0 0,1 1)")
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STGeomFromText, "POINT(1 1)", 1234)
+	require.True(t, types.ErrSRSNotFound.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STAsText, "\x00")
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+
+	// The result of the constructors is the geometry type.
+	f, err := funcs[ast.STPointFromText].getFunction(ctx, datumsToConstants(types.MakeDatums("POINT(1 1)")))
+	require.NoError(t, err)
+	require.Equal(t, mysql.TypeGeometry, f.getRetTp().GetType())
+	require.Equal(t, types.GeometryTypePoint, f.getRetTp().GetGeometryType())
+}
+
+func TestSpatialConstructors(t *testing.T) {
+	ctx := createContext(t)
+	point := func(x, y float64) string {
+		d, err := evalSpatialFunc(ctx, ast.Point, x, y)
+		require.NoError(t, err)
+		return d.GetString()
+	}
+	asText := func(name string, args ...any) string {
+		d, err := evalSpatialFunc(ctx, name, args...)
+		require.NoError(t, err)
+		d, err = evalSpatialFunc(ctx, ast.STAsText, d.GetString())
+		require.NoError(t, err)
+		return d.GetString()
+	}
+	line := asText(ast.LineString, point(0, 0), point(1, 1))
+	require.Equal(t, "LINESTRING(0 0,1 1)", line)
+	require.Equal(t, "MULTIPOINT((0 0),(1 1))", asText(ast.MultiPoint, point(0, 0), point(1, 1)))
+
+	ringDatum, err := evalSpatialFunc(ctx, ast.LineString, point(0, 0), point(1, 0), point(1, 1), point(0, 0))
+	require.NoError(t, err)
+	require.Equal(t, "POLYGON((0 0,1 0,1 1,0 0))", asText(ast.Polygon, ringDatum.GetString()))
+	require.Equal(t, "GEOMETRYCOLLECTION(POINT(1 2))", asText(ast.GeomCollection, point(1, 2)))
+
+	// The ring of the polygon must be closed.
+	ringDatum, err = evalSpatialFunc(ctx, ast.LineString, point(0, 0), point(1, 0), point(1, 1), point(0, 1))
+	require.NoError(t, err)
+	_, err = evalSpatialFunc(ctx, ast.Polygon, ringDatum.GetString())
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.MultiPoint, ringDatum.GetString())
+	require.Error(t, err)
+}
+
+func TestSpatialAccessors(t *testing.T) {
+	ctx := createContext(t)
+	geomFromText := func(args ...any) string {
+		d, err := evalSpatialFunc(ctx, ast.STGeomFromText, args...)
+		require.NoError(t, err)
+		return d.GetString()
+	}
+	p := geomFromText("POINT(30 120)", 4326)
+
+	tests := []struct {
+		name     string
+		args     []any
+		expected any
+	}{
+		{ast.STSRID, []any{p}, uint64(4326)},
+		{ast.STX, []any{p}, float64(30)},
+		{ast.STY, []any{p}, float64(120)},
+		{ast.STLatitude, []any{p}, float64(30)},
+		{ast.STLongitude, []any{p}, float64(120)},
+		{ast.STX, []any{geomFromText("POINT(1 2)")}, float64(1)},
+		{ast.STGeometryType, []any{p}, "POINT"},
+		{ast.STGeometryType, []any{geomFromText("GEOMETRYCOLLECTION EMPTY")}, "GEOMCOLLECTION"},
+		{ast.STIsEmpty, []any{p}, int64(0)},
+		{ast.STIsEmpty, []any{geomFromText("GEOMETRYCOLLECTION EMPTY")}, int64(1)},
+		{ast.STSRID, []any{nil}, nil},
+	}
+	for _, tt := range tests {
+		d, err := evalSpatialFunc(ctx, tt.name, tt.args...)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.expected, d.GetValue(), tt.name)
+	}
+
+	_, err := evalSpatialFunc(ctx, ast.STLatitude, geomFromText("POINT(1 2)"))
+	require.True(t, types.ErrGISUnsupportedArgument.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STX, geomFromText("LINESTRING(0 0,1 1)"))
+	require.True(t, types.ErrGISUnsupportedArgument.Equal(err))
+
+	// ST_SRID(g, srid) changes the SRID without transforming the coordinates.
+	d, err := evalSpatialFunc(ctx, ast.STSRID, geomFromText("POINT(1 2)"), 4326)
+	require.NoError(t, err)
+	d, err = evalSpatialFunc(ctx, ast.STSRID, d.GetString())
+	require.NoError(t, err)
+	require.Equal(t, int64(4326), d.GetInt64())
+	_, err = evalSpatialFunc(ctx, ast.STSRID, geomFromText("POINT(1 100)"), 4326)
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STSRID, geomFromText("POINT(1 2)"), 1234)
+	require.True(t, types.ErrSRSNotFound.Equal(err))
+}
+
+func TestSpatialGeoJSON(t *testing.T) {
+	ctx := createContext(t)
+	j, err := types.ParseBinaryJSONFromString(`{"type": "Point", "coordinates": [120, 30]}`)
+	require.NoError(t, err)
+	g, err := evalSpatialFunc(ctx, ast.STGeomFromGeoJSON, j)
+	require.NoError(t, err)
+	d, err := evalSpatialFunc(ctx, ast.STSRID, g.GetString())
+	require.NoError(t, err)
+	require.Equal(t, int64(4326), d.GetInt64())
+	d, err = evalSpatialFunc(ctx, ast.STAsText, g.GetString())
+	require.NoError(t, err)
+	require.Equal(t, "POINT(30 120)", d.GetString())
+
+	d, err = evalSpatialFunc(ctx, ast.STAsGeoJSON, g.GetString())
+	require.NoError(t, err)
+	require.Equal(t, `{"coordinates": [120.0, 30.0], "type": "Point"}`, d.GetMysqlJSON().String())
+	d, err = evalSpatialFunc(ctx, ast.STAsGeoJSON, g.GetString(), 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, `{"coordinates": [120.0, 30.0], "crs": {"properties": {"name": "EPSG:4326"}, "type": "name"}, "type": "Point"}`, d.GetMysqlJSON().String())
+
+	_, err = evalSpatialFunc(ctx, ast.STGeomFromGeoJSON, j, 5)
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STAsGeoJSON, g.GetString(), -1)
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+}
+
+func TestSpatialMeasuresAndRelations(t *testing.T) {
+	ctx := createContext(t)
+	geomFromText := func(args ...any) string {
+		d, err := evalSpatialFunc(ctx, ast.STGeomFromText, args...)
+		require.NoError(t, err)
+		return d.GetString()
+	}
+	square := geomFromText("POLYGON((0 0,4 0,4 4,0 4,0 0))")
+	inside, outside := geomFromText("POINT(1 1)"), geomFromText("POINT(5 5)")
+
+	tests := []struct {
+		name     string
+		args     []any
+		expected any
+	}{
+		{ast.STContains, []any{square, inside}, int64(1)},
+		{ast.STContains, []any{square, outside}, int64(0)},
+		{ast.STWithin, []any{inside, square}, int64(1)},
+		{ast.STWithin, []any{square, inside}, int64(0)},
+		{ast.STIntersects, []any{square, outside}, int64(0)},
+		{ast.STDisjoint, []any{square, outside}, int64(1)},
+		{ast.STEquals, []any{square, geomFromText("POLYGON((4 4,0 4,0 0,4 0,4 4))")}, int64(1)},
+		{ast.STContains, []any{square, nil}, nil},
+		{ast.STDistance, []any{inside, outside}, float64(5.656854249492381)},
+		{ast.STDistance, []any{square, outside}, float64(1.4142135623730951)},
+		{ast.STDistance, []any{square, geomFromText("GEOMETRYCOLLECTION EMPTY")}, nil},
+		{ast.STArea, []any{square}, float64(16)},
+		{ast.STLength, []any{geomFromText("LINESTRING(0 0,3 4)")}, float64(5)},
+	}
+	for _, tt := range tests {
+		d, err := evalSpatialFunc(ctx, tt.name, tt.args...)
+		require.NoError(t, err, tt.name)
+		require.Equal(t, tt.expected, d.GetValue(), tt.name)
+	}
+
+	d, err := evalSpatialFunc(ctx, ast.STDistanceSphere, geomFromText("POINT(0 0)"), geomFromText("POINT(0 90)"), 2)
+	require.NoError(t, err)
+	require.InDelta(t, math.Pi, d.GetFloat64(), 1e-9)
+	d, err = evalSpatialFunc(ctx, ast.STDistanceSphere, geomFromText("POINT(30 120)", 4326), geomFromText("POINT(31 121)", 4326))
+	require.NoError(t, err)
+	require.InDelta(t, 146000, d.GetFloat64(), 1000)
+
+	_, err = evalSpatialFunc(ctx, ast.STContains, square, geomFromText("POINT(1 1)", 4326))
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STDistanceSphere, inside, outside, -1)
+	require.True(t, types.ErrNonPositiveRadius.Equal(err))
+	_, err = evalSpatialFunc(ctx, ast.STArea, geomFromText("POLYGON((0 0,1 0,1 1,0 0))", 4326))
+	require.True(t, types.ErrNotImplementedForGeographicSRS.Equal(err))
+}
@@ -0,0 +1,391 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+)
+
+//revive:disable:defer
+
+// vecEvalStringArgs evaluates the string arguments into the buffers, the
+// caller must put the buffers back by the returned function.
+func vecEvalStringArgs(ctx EvalContext, bufAllocator columnBufferAllocator, args []Expression, input *chunk.Chunk) ([]*chunk.Column, func(), error) {
+	bufs := make([]*chunk.Column, 0, len(args))
+	release := func() {
+		for _, buf := range bufs {
+			bufAllocator.put(buf)
+		}
+	}
+	for _, arg := range args {
+		buf, err := bufAllocator.get()
+		if err != nil {
+			release()
+			return nil, nil, err
+		}
+		bufs = append(bufs, buf)
+		if err := arg.VecEvalString(ctx, input, buf); err != nil {
+			release()
+			return nil, nil, err
+		}
+	}
+	return bufs, release, nil
+}
+
+func (b *builtinGeomFromTextSig) vectorized() bool {
+	return len(b.args) == 1
+}
+
+func (b *builtinGeomFromTextSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := range n {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		res, _, err := b.fromText(buf.GetString(i), 0, types.AxisOrderSRIDDefined)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinPointSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinPointSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	xBuf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(xBuf)
+	if err := b.args[0].VecEvalReal(ctx, input, xBuf); err != nil {
+		return err
+	}
+	yBuf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(yBuf)
+	if err := b.args[1].VecEvalReal(ctx, input, yBuf); err != nil {
+		return err
+	}
+
+	xs, ys := xBuf.Float64s(), yBuf.Float64s()
+	result.ReserveString(n)
+	for i := range n {
+		if xBuf.IsNull(i) || yBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		result.AppendBytes(types.NewPointGeometry(xs[i], ys[i]).Encode())
+	}
+	return nil
+}
+
+func (b *builtinSTAsTextSig) vectorized() bool {
+	return len(b.args) == 1
+}
+
+func (b *builtinSTAsTextSig) vecEvalString(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ReserveString(n)
+	for i := range n {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		g, err := parseGeometryArg(b.name, buf.GetString(i))
+		if err != nil {
+			return err
+		}
+		result.AppendString(g.WKT(types.AxisOrderSRIDDefined))
+	}
+	return nil
+}
+
+func (b *builtinSTAsGeoJSONSig) vectorized() bool {
+	return len(b.args) == 1
+}
+
+func (b *builtinSTAsGeoJSONSig) vecEvalJSON(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ReserveJSON(n)
+	for i := range n {
+		if buf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		res, _, err := b.asGeoJSON(buf.GetString(i), geoJSONDefaultMaxDecimals, 0)
+		if err != nil {
+			return err
+		}
+		result.AppendJSON(res)
+	}
+	return nil
+}
+
+func (b *builtinSTSRIDSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTSRIDSig) vecEvalInt(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	res := result.Int64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		srid, _, err := b.evalSRID(buf.GetString(i))
+		if err != nil {
+			return err
+		}
+		res[i] = srid
+	}
+	return nil
+}
+
+func (b *builtinSTCoordinateSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTCoordinateSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(buf)
+	res := result.Float64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		if res[i], _, err = b.getCoordinate(buf.GetString(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSTIsEmptySig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTIsEmptySig) vecEvalInt(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	res := result.Int64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		if res[i], _, err = b.isEmpty(buf.GetString(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSTDistanceSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTDistanceSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, release, err := vecEvalStringArgs(ctx, b.bufAllocator, b.args, input)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(bufs...)
+	res := result.Float64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		d, isNull, err := b.distance(bufs[0].GetString(i), bufs[1].GetString(i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.SetNull(i, true)
+			continue
+		}
+		res[i] = d
+	}
+	return nil
+}
+
+func (b *builtinSTDistanceSphereSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTDistanceSphereSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, release, err := vecEvalStringArgs(ctx, b.bufAllocator, b.args[:2], input)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	var radiuses []float64
+	if len(b.args) > 2 {
+		radiusBuf, err := b.bufAllocator.get()
+		if err != nil {
+			return err
+		}
+		defer b.bufAllocator.put(radiusBuf)
+		if err := b.args[2].VecEvalReal(ctx, input, radiusBuf); err != nil {
+			return err
+		}
+		bufs = append(bufs, radiusBuf)
+		radiuses = radiusBuf.Float64s()
+	}
+
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(bufs...)
+	res := result.Float64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		radius := float64(types.DefaultSphereRadius)
+		if radiuses != nil {
+			radius = radiuses[i]
+		}
+		if res[i], _, err = b.distanceSphere(bufs[0].GetString(i), bufs[1].GetString(i), radius); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSTMeasureSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTMeasureSig) vecEvalReal(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalString(ctx, input, buf); err != nil {
+		return err
+	}
+
+	result.ResizeFloat64(n, false)
+	result.MergeNulls(buf)
+	res := result.Float64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		if res[i], _, err = b.measure(buf.GetString(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinSTRelationSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinSTRelationSig) vecEvalInt(ctx EvalContext, input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	bufs, release, err := vecEvalStringArgs(ctx, b.bufAllocator, b.args, input)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	res := result.Int64s()
+	for i := range n {
+		if result.IsNull(i) {
+			continue
+		}
+		if res[i], _, err = b.relate(bufs[0].GetString(i), bufs[1].GetString(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
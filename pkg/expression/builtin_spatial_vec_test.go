@@ -0,0 +1,189 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// geometryGener generates the points, the linestrings or the square polygons
+// in the storage format, the coordinates are valid longitudes and latitudes.
+type geometryGener struct {
+	geomType types.GeometryType
+	randGen  *defaultRandGen
+}
+
+func newGeometryGener(geomType types.GeometryType) *geometryGener {
+	return &geometryGener{geomType, newDefaultRandGen()}
+}
+
+func (g *geometryGener) gen() any {
+	x, y := g.randGen.Float64()*300-150, g.randGen.Float64()*160-80
+	size := g.randGen.Float64() * 20
+	var wkt string
+	switch g.geomType {
+	case types.GeometryTypeLineString:
+		wkt = fmt.Sprintf("LINESTRING(%g %g,%g %g)", x, y, x+size, y+size)
+	case types.GeometryTypePolygon:
+		wkt = fmt.Sprintf("POLYGON((%[1]g %[2]g,%[3]g %[2]g,%[3]g %[4]g,%[1]g %[4]g,%[1]g %[2]g))", x, y, x+size, y+size)
+	default:
+		return string(types.NewPointGeometry(x, y).Encode())
+	}
+	geom, err := types.ParseGeometryFromWKT("", wkt, 0, types.AxisOrderSRIDDefined)
+	if err != nil {
+		panic(err)
+	}
+	return string(geom.Encode())
+}
+
+// pointWKTGener generates the points in WKT.
+type pointWKTGener struct {
+	randGen *defaultRandGen
+}
+
+func (g *pointWKTGener) gen() any {
+	return fmt.Sprintf("POINT(%g %g)", g.randGen.Float64()*100, g.randGen.Float64()*100)
+}
+
+var vecBuiltinSpatialCases = map[string][]vecExprBenchCase{
+	ast.STGeomFromText: {
+		{
+			retEvalType:   types.ETString,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, &pointWKTGener{newDefaultRandGen()})},
+		},
+	},
+	ast.Point: {
+		{
+			retEvalType:   types.ETString,
+			childrenTypes: []types.EvalType{types.ETReal, types.ETReal},
+		},
+	},
+	ast.STAsText: {
+		{
+			retEvalType:   types.ETString,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon))},
+		},
+	},
+	ast.STAsGeoJSON: {
+		{
+			retEvalType:   types.ETJson,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePoint))},
+		},
+	},
+	ast.STSRID: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePoint))},
+		},
+	},
+	ast.STX: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePoint))},
+		},
+	},
+	ast.STY: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePoint))},
+		},
+	},
+	ast.STIsEmpty: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon))},
+		},
+	},
+	ast.STDistance: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon)), newGeometryGener(types.GeometryTypePoint)},
+		},
+	},
+	ast.STDistanceSphere: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePoint)), newGeometryGener(types.GeometryTypePoint)},
+		},
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString, types.ETReal},
+			geners:        []dataGenerator{newGeometryGener(types.GeometryTypePoint), newGeometryGener(types.GeometryTypePoint), newRangeRealGener(1, 1e7, 0.1)},
+		},
+	},
+	ast.STArea: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon))},
+		},
+	},
+	ast.STLength: {
+		{
+			retEvalType:   types.ETReal,
+			childrenTypes: []types.EvalType{types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypeLineString))},
+		},
+	},
+	ast.STContains: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon)), newGeometryGener(types.GeometryTypePoint)},
+		},
+	},
+	ast.STWithin: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newGeometryGener(types.GeometryTypePoint), newNullWrappedGener(0.1, newGeometryGener(types.GeometryTypePolygon))},
+		},
+	},
+	ast.STIntersects: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newGeometryGener(types.GeometryTypePolygon), newGeometryGener(types.GeometryTypePolygon)},
+		},
+	},
+	ast.STDisjoint: {
+		{
+			retEvalType:   types.ETInt,
+			childrenTypes: []types.EvalType{types.ETString, types.ETString},
+			geners:        []dataGenerator{newGeometryGener(types.GeometryTypePolygon), newGeometryGener(types.GeometryTypePolygon)},
+		},
+	},
+}
+
+func TestVectorizedBuiltinSpatialFunc(t *testing.T) {
+	testVectorizedBuiltinFunc(t, vecBuiltinSpatialCases)
+}
+
+func BenchmarkVectorizedBuiltinSpatialFunc(b *testing.B) {
+	benchmarkVectorizedBuiltinFunc(b, vecBuiltinSpatialCases)
+}
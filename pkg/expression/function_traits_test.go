@@ -110,6 +110,8 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"from_days",
 		"from_unixtime",
 		"ge",
+		"geomcollection",
+		"geometrycollection",
 		"get_format",
 		"getparam",
 		"greatest",
@@ -173,6 +175,7 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"leftshift",
 		"length",
 		"like",
+		"linestring",
 		"ln",
 		"locate",
 		"log",
@@ -185,6 +188,7 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"make_set",
 		"makedate",
 		"maketime",
+		"match",
 		"md5",
 		"microsecond",
 		"mid",
@@ -194,6 +198,9 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"month",
 		"monthname",
 		"mul",
+		"multilinestring",
+		"multipoint",
+		"multipolygon",
 		"ne",
 		"nextval",
 		"not",
@@ -207,6 +214,8 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"period_diff",
 		"pi",
 		"plus",
+		"point",
+		"polygon",
 		"position",
 		"pow",
 		"power",
@@ -237,6 +246,37 @@ func TestIllegalFunctions4GeneratedColumns(t *testing.T) {
 		"sm3", // TiDB specific?
 		"space",
 		"sqrt",
+		"st_area",
+		"st_asbinary",
+		"st_asgeojson",
+		"st_astext",
+		"st_aswkb",
+		"st_aswkt",
+		"st_contains",
+		"st_disjoint",
+		"st_distance",
+		"st_distance_sphere",
+		"st_equals",
+		"st_geometryfromtext",
+		"st_geometryfromwkb",
+		"st_geometrytype",
+		"st_geomfromgeojson",
+		"st_geomfromtext",
+		"st_geomfromwkb",
+		"st_intersects",
+		"st_isempty",
+		"st_latitude",
+		"st_length",
+		"st_linefromtext",
+		"st_linestringfromtext",
+		"st_longitude",
+		"st_pointfromtext",
+		"st_polyfromtext",
+		"st_polygonfromtext",
+		"st_srid",
+		"st_within",
+		"st_x",
+		"st_y",
 		"str_to_date",
 		"strcmp",
 		"subdate",
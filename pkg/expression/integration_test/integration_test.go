@@ -1217,6 +1217,89 @@ func TestVectorSearchPreparedStatement(t *testing.T) {
 	tk.MustQuery("EXECUTE stmt USING @pvec, @plimit;").Check(testkit.Rows("3", "2", "1"))
 }
 
+func TestSpatialColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table t(id int primary key, g geometry, p point srid 4326 not null)")
+	tk.MustQuery("show create table t").Check(testkit.Rows(
+		"t CREATE TABLE `t` (\n" +
+			"  `id` int(11) NOT NULL,\n" +
+			"  `g` geometry DEFAULT NULL,\n" +
+			"  `p` point /*!80003 SRID 4326 */ NOT NULL,\n" +
+			"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin",
+	))
+	tk.MustQuery("SELECT column_name, data_type FROM INFORMATION_SCHEMA.COLUMNS WHERE TABLE_NAME = 't' and column_name != 'id'").Sort().Check(testkit.Rows(
+		"g geometry",
+		"p point",
+	))
+
+	tk.MustExec("insert into t values (1, st_geomfromtext('LINESTRING(0 0,1 1)'), st_geomfromtext('POINT(30 120)', 4326))")
+	tk.MustExec("insert into t values (2, null, st_srid(point(121, 31), 4326))")
+	tk.MustQuery("select id, st_astext(g), st_astext(p), st_srid(p) from t order by id").Check(testkit.Rows(
+		"1 LINESTRING(0 0,1 1) POINT(30 120) 4326",
+		"2 <nil> POINT(31 121) 4326",
+	))
+
+	// The geometries must match the subtype and SRID of the column.
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('POINT(1 1)'))", errno.ErrWrongSRIDForColumn)
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('LINESTRING(0 0,1 1)', 4326))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (3, 'abc', st_geomfromtext('POINT(1 1)', 4326))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("update t set p = point(1, 1) where id = 1", errno.ErrWrongSRIDForColumn)
+
+	// The column attributes are validated.
+	tk.MustGetErrCode("create table t1(g geometry srid 1234)", errno.ErrSRSNotFound)
+	tk.MustGetErrCode("create table t1(a int srid 4326)", errno.ErrWrongUsage)
+	tk.MustGetErrCode("create table t1(g geometry default 'abc')", errno.ErrBlobCantHaveDefault)
+	tk.MustGetErrCode("create table t1(g geometry, key(g))", errno.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("alter table t add index idx(p)", errno.ErrBlobKeyWithoutLength)
+}
+
+func TestSpatialFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("create table warehouse(id int primary key, location point srid 4326 not null)")
+	tk.MustExec("create table zone(id int primary key, area polygon not null)")
+	tk.MustExec("insert into warehouse values (1, st_geomfromtext('POINT(39.9042 116.4074)', 4326)), (2, st_geomfromtext('POINT(121.4737 31.2304)', 4326, 'axis-order=long-lat'))")
+	tk.MustExec("insert into zone values (1, st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')), (2, st_geomfromtext('POLYGON((20 20,30 20,30 30,20 20))'))")
+
+	tk.MustQuery("select round(st_distance_sphere(a.location, b.location)) from warehouse a, warehouse b where a.id = 1 and b.id = 2").Check(testkit.Rows("1067308"))
+	tk.MustQuery("select round(st_distance(a.location, b.location)) from warehouse a, warehouse b where a.id = 1 and b.id = 2").Check(testkit.Rows("1065851"))
+	tk.MustQuery("select st_latitude(location), st_longitude(location), st_x(location), st_y(location) from warehouse where id = 2").Check(testkit.Rows("31.2304 121.4737 31.2304 121.4737"))
+	tk.MustQuery("select st_asgeojson(location) from warehouse where id = 1").Check(testkit.Rows(`{"coordinates": [116.4074, 39.9042], "type": "Point"}`))
+	tk.MustQuery("select st_astext(st_geomfromgeojson('{\"type\": \"Point\", \"coordinates\": [116.4074, 39.9042]}'))").Check(testkit.Rows("POINT(39.9042 116.4074)"))
+
+	tk.MustQuery("select id from zone where st_contains(area, point(5, 5))").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from zone where st_within(point(25, 22), area)").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from zone where st_intersects(area, st_geomfromtext('LINESTRING(5 5,25 21)')) order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id, st_area(area), st_geometrytype(area), st_isempty(area) from zone order by id").Check(testkit.Rows(
+		"1 100 POLYGON 0",
+		"2 50 POLYGON 0",
+	))
+	tk.MustQuery("select hex(st_asbinary(point(1, 2)))").Check(testkit.Rows("0101000000000000000000F03F0000000000000040"))
+	tk.MustQuery("select st_astext(st_geomfromwkb(st_asbinary(point(1, 2))))").Check(testkit.Rows("POINT(1 2)"))
+	tk.MustQuery("select st_astext(multipoint(point(1, 1), point(2, 2))), st_length(linestring(point(0, 0), point(3, 4)))").Check(testkit.Rows("MULTIPOINT((1 1),(2 2)) 5"))
+	tk.MustQuery("select st_astext(null), st_distance(point(1, 1), null)").Check(testkit.Rows("<nil> <nil>"))
+
+	for _, tt := range []struct {
+		sql string
+		err *terror.Error
+	}{
+		{"select st_contains(location, point(1, 1)) from warehouse", types.ErrGISDifferentSRIDs},
+		{"select st_area(st_geomfromtext('POLYGON((0 0,1 0,1 1,0 0))', 4326))", types.ErrNotImplementedForGeographicSRS},
+		{"select st_geomfromtext('POINT(100 0)', 4326)", types.ErrLatitudeOutOfRange},
+		{"select st_distance_sphere(point(0, 0), point(1, 1), 0)", types.ErrNonPositiveRadius},
+		{"select st_geomfromtext('POINT(1 1)', 1)", types.ErrSRSNotFound},
+	} {
+		err := tk.QueryToErr(tt.sql)
+		require.True(t, tt.err.Equal(err), "sql: %s, err: %v", tt.sql, err)
+	}
+}
+
 func TestGetLock(t *testing.T) {
 	ctx := context.Background()
 	store := testkit.CreateMockStore(t, mockstore.WithStoreType(mockstore.EmbedUnistore))
@@ -1752,18 +1835,18 @@ func TestTiDBEncodeKey(t *testing.T) {
 	err := tk.QueryToErr("select tidb_encode_record_key('test', 't1', 0);")
 	require.ErrorContains(t, err, "doesn't exist")
 	tk.MustQuery("select tidb_encode_record_key('test', 't', 1);").
-		Check(testkit.Rows("7480000000000000705f728000000000000001"))
+		Check(testkit.Rows("74800000000000007a5f728000000000000001"))
 
 	tk.MustExec("alter table t add index i(b);")
 	err = tk.QueryToErr("select tidb_encode_index_key('test', 't', 'i1', 1);")
 	require.ErrorContains(t, err, "index not found")
 	tk.MustQuery("select tidb_encode_index_key('test', 't', 'i', 1, 1);").
-		Check(testkit.Rows("7480000000000000705f698000000000000001038000000000000001038000000000000001"))
+		Check(testkit.Rows("74800000000000007a5f698000000000000001038000000000000001038000000000000001"))
 
 	tk.MustExec("create table t1 (a int primary key, b int) partition by hash(a) partitions 4;")
 	tk.MustExec("insert into t1 values (1, 1);")
-	tk.MustQuery("select tidb_encode_record_key('test', 't1(p1)', 1);").Check(testkit.Rows("7480000000000000755f728000000000000001"))
-	rs := tk.MustQuery("select tidb_mvcc_info('74800000000000007f5f728000000000000001');")
+	tk.MustQuery("select tidb_encode_record_key('test', 't1(p1)', 1);").Check(testkit.Rows("74800000000000007f5f728000000000000001"))
+	rs := tk.MustQuery("select tidb_mvcc_info('7480000000000000895f728000000000000001');")
 	mvccInfo := rs.Rows()[0][0].(string)
 	require.NotEqual(t, mvccInfo, `{"info":{}}`)
 
@@ -1772,14 +1855,14 @@ func TestTiDBEncodeKey(t *testing.T) {
 	tk2 := testkit.NewTestKit(t, store)
 	err = tk2.Session().Auth(&auth.UserIdentity{Username: "alice", Hostname: "localhost"}, nil, nil, nil)
 	require.NoError(t, err)
-	err = tk2.QueryToErr("select tidb_mvcc_info('74800000000000007f5f728000000000000001');")
+	err = tk2.QueryToErr("select tidb_mvcc_info('7480000000000000895f728000000000000001');")
 	require.ErrorContains(t, err, "Access denied")
 	err = tk2.QueryToErr("select tidb_encode_record_key('test', 't1(p1)', 1);")
 	require.ErrorContains(t, err, "SELECT command denied")
 	err = tk2.QueryToErr("select tidb_encode_index_key('test', 't', 'i1', 1);")
 	require.ErrorContains(t, err, "SELECT command denied")
 	tk.MustExec("grant select on test.t1 to 'alice'@'%';")
-	tk2.MustQuery("select tidb_encode_record_key('test', 't1(p1)', 1);").Check(testkit.Rows("7480000000000000755f728000000000000001"))
+	tk2.MustQuery("select tidb_encode_record_key('test', 't1(p1)', 1);").Check(testkit.Rows("74800000000000007f5f728000000000000001"))
 }
 
 func TestIssue9710(t *testing.T) {
@@ -114,7 +114,7 @@ func (b *Builder) initMisc(dbInfos []*model.DBInfo, policies []*model.PolicyInfo
 		info.setResourceGroup(group)
 	}
 
-	// Maintain foreign key reference information.
+	// Maintain foreign key reference and materialized view information.
 	if b.enableV2 {
 		rs := b.ListTablesWithSpecialAttribute(infoschemacontext.ForeignKeysAttribute)
 		for _, db := range rs {
@@ -122,11 +122,18 @@ func (b *Builder) initMisc(dbInfos []*model.DBInfo, policies []*model.PolicyInfo
 				info.addReferredForeignKeys(db.DBName, tbl)
 			}
 		}
+		rs = b.ListTablesWithSpecialAttribute(infoschemacontext.MaterializedViewAttribute)
+		for _, db := range rs {
+			for _, tbl := range db.TableInfos {
+				info.addMaterializedView(tbl)
+			}
+		}
 		return
 	}
 	for _, di := range dbInfos {
 		for _, t := range di.Deprecated.Tables {
 			b.infoSchema.addReferredForeignKeys(di.Name, t)
+			b.infoSchema.addMaterializedView(t)
 		}
 	}
 }
@@ -81,9 +81,15 @@ var PartitionAttribute SpecialAttributeFilter = func(t *model.TableInfo) bool {
 	return t.GetPartitionInfo() != nil
 }
 
+// MaterializedViewAttribute is the MaterializedView attribute filter used by ListTablesWithSpecialAttribute.
+var MaterializedViewAttribute SpecialAttributeFilter = func(t *model.TableInfo) bool {
+	return t.IsMaterializedView()
+}
+
 // HasSpecialAttributes checks if a table has any special attributes.
 func HasSpecialAttributes(t *model.TableInfo) bool {
-	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t) || TableLockAttribute(t) || ForeignKeysAttribute(t)
+	return TTLAttribute(t) || TiFlashAttribute(t) || PlacementPolicyAttribute(t) || PartitionAttribute(t) || TableLockAttribute(t) || ForeignKeysAttribute(t) ||
+		MaterializedViewAttribute(t)
 }
 
 // AllSpecialAttribute marks a model.TableInfo with any special attributes.
@@ -140,6 +146,8 @@ type Misc interface {
 	HasTemporaryTable() bool
 	// GetTableReferredForeignKeys gets the table's ReferredFKInfo by lowercase schema and table name.
 	GetTableReferredForeignKeys(schema, table string) []*model.ReferredFKInfo
+	// GetTableMaterializedViews gets the IDs of the materialized views reading the table by lowercase schema and table name.
+	GetTableMaterializedViews(schema, table string) []int64
 }
 
 // DBInfoAsInfoSchema is used mainly in test.
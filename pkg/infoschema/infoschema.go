@@ -90,6 +90,10 @@ type infoSchemaMisc struct {
 	// referredForeignKeyMap records all table's ReferredFKInfo.
 	// referredSchemaAndTableName => child SchemaAndTableAndForeignKeyName => *model.ReferredFKInfo
 	referredForeignKeyMap map[SchemaAndTableName][]*model.ReferredFKInfo
+
+	// materializedViewMap records the materialized views reading a table.
+	// baseSchemaAndTableName => the IDs of the materialized views
+	materializedViewMap map[SchemaAndTableName][]int64
 }
 
 // SchemaAndTableName contains the lower-case schema name and table name.
@@ -203,6 +207,7 @@ func newInfoSchema() *infoSchema {
 			resourceGroupMap:      map[string]*model.ResourceGroupInfo{},
 			ruleBundleMap:         map[int64]*placement.Bundle{},
 			referredForeignKeyMap: make(map[SchemaAndTableName][]*model.ReferredFKInfo),
+			materializedViewMap:   make(map[SchemaAndTableName][]int64),
 		},
 		schemaMap:           map[string]*schemaTables{},
 		schemaID2Name:       map[int64]string{},
@@ -692,6 +697,53 @@ func (is *infoSchemaMisc) GetTableReferredForeignKeys(schema, table string) []*m
 	return is.referredForeignKeyMap[name]
 }
 
+func (is *infoSchemaMisc) addMaterializedView(tbInfo *model.TableInfo) {
+	mv := tbInfo.MaterializedView
+	if mv == nil || mv.BaseTable.L == "" {
+		return
+	}
+	base := SchemaAndTableName{schema: mv.BaseSchema.L, table: mv.BaseTable.L}
+	ids := is.materializedViewMap[base]
+	if slices.Contains(ids, tbInfo.ID) {
+		return
+	}
+	newIDs := make([]int64, 0, len(ids)+1)
+	newIDs = append(newIDs, ids...)
+	newIDs = append(newIDs, tbInfo.ID)
+	slices.Sort(newIDs)
+	is.materializedViewMap[base] = newIDs
+}
+
+func (is *infoSchemaMisc) deleteMaterializedView(tbInfo *model.TableInfo) {
+	mv := tbInfo.MaterializedView
+	if mv == nil || mv.BaseTable.L == "" {
+		return
+	}
+	base := SchemaAndTableName{schema: mv.BaseSchema.L, table: mv.BaseTable.L}
+	ids := is.materializedViewMap[base]
+	if !slices.Contains(ids, tbInfo.ID) {
+		return
+	}
+	newIDs := make([]int64, 0, len(ids)-1)
+	for _, id := range ids {
+		if id != tbInfo.ID {
+			newIDs = append(newIDs, id)
+		}
+	}
+	if len(newIDs) == 0 {
+		delete(is.materializedViewMap, base)
+		return
+	}
+	is.materializedViewMap[base] = newIDs
+}
+
+// GetTableMaterializedViews gets the IDs of the materialized views reading the
+// table by lowercase schema and table name.
+func (is *infoSchemaMisc) GetTableMaterializedViews(schema, table string) []int64 {
+	name := SchemaAndTableName{schema: schema, table: table}
+	return is.materializedViewMap[name]
+}
+
 // SessionTables store local temporary tables
 type SessionTables struct {
 	// Session tables can be accessed after the db is dropped, so there needs a way to retain the DBInfo.
@@ -95,7 +95,8 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
-	tableTriggers    = "TRIGGERS"
+	// TableTriggers is the string constant of infoschema table.
+	TableTriggers = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
 	tableSchemaPrivileges = "SCHEMA_PRIVILEGES"
@@ -104,10 +105,12 @@ const (
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews          = "VIEWS"
-	tableRoutines       = "ROUTINES"
-	tableParameters     = "PARAMETERS"
-	tableEvents         = "EVENTS"
+	TableViews = "VIEWS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents         = "EVENTS"
 	tableOptimizerTrace = "OPTIMIZER_TRACE"
 	tableTableSpaces    = "TABLESPACES"
 	// TableCollationCharacterSetApplicability is the string constant of infoschema memory table.
@@ -261,16 +264,16 @@ var tableIDMap = map[string]int64{
 	// TableSessionVar:    autoid.InformationSchemaDBID + 14,
 	tablePlugins:          autoid.InformationSchemaDBID + 15,
 	TableConstraints:      autoid.InformationSchemaDBID + 16,
-	tableTriggers:         autoid.InformationSchemaDBID + 17,
+	TableTriggers:         autoid.InformationSchemaDBID + 17,
 	TableUserPrivileges:   autoid.InformationSchemaDBID + 18,
 	tableSchemaPrivileges: autoid.InformationSchemaDBID + 19,
 	tableTablePrivileges:  autoid.InformationSchemaDBID + 20,
 	tableColumnPrivileges: autoid.InformationSchemaDBID + 21,
 	TableEngines:          autoid.InformationSchemaDBID + 22,
 	TableViews:            autoid.InformationSchemaDBID + 23,
-	tableRoutines:         autoid.InformationSchemaDBID + 24,
+	TableRoutines:         autoid.InformationSchemaDBID + 24,
 	tableParameters:       autoid.InformationSchemaDBID + 25,
-	tableEvents:           autoid.InformationSchemaDBID + 26,
+	TableEvents:           autoid.InformationSchemaDBID + 26,
 	// Removed, see https://github.com/pingcap/tidb/issues/9154
 	// tableGlobalStatus:                    autoid.InformationSchemaDBID + 27,
 	// tableGlobalVariables:                 autoid.InformationSchemaDBID + 28,
@@ -2430,16 +2433,16 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableReferConst:                         referConstCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
-	tableTriggers:                           tableTriggersCols,
+	TableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
 	tableTablePrivileges:                    tableTablePrivilegesCols,
 	tableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableOptimizerTrace:                     tableOptimizerTraceCols,
 	tableTableSpaces:                        tableTableSpacesCols,
 	TableCollationCharacterSetApplicability: tableCollationCharacterSetApplicabilityCols,
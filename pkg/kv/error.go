@@ -70,6 +70,15 @@ var (
 			mysql.MySQLErrName[mysql.ErrWriteConflictInTiDB].RedactArgPos,
 		),
 	)
+	// ErrSerializationFailure is the error when a serializable transaction is aborted to avoid a
+	// dangerous structure of read/write dependencies.
+	ErrSerializationFailure = dbterror.ClassKV.NewStdErr(
+		mysql.ErrSerializationFailure,
+		pmysql.Message(
+			mysql.MySQLErrName[mysql.ErrSerializationFailure].Raw+" "+TxnRetryableMark,
+			mysql.MySQLErrName[mysql.ErrSerializationFailure].RedactArgPos,
+		),
+	)
 	// ErrLockExpire is the error when the lock is expired.
 	ErrLockExpire = dbterror.ClassTiKV.NewStd(mysql.ErrLockExpire)
 	// ErrAssertionFailed is the error when an assertion fails.
@@ -82,7 +91,8 @@ func IsTxnRetryableError(err error) bool {
 		return false
 	}
 
-	if ErrTxnRetryable.Equal(err) || ErrWriteConflict.Equal(err) || ErrWriteConflictInTiDB.Equal(err) {
+	if ErrTxnRetryable.Equal(err) || ErrWriteConflict.Equal(err) || ErrWriteConflictInTiDB.Equal(err) ||
+		ErrSerializationFailure.Equal(err) {
 		return true
 	}
 
@@ -33,6 +33,7 @@ func TestError(t *testing.T) {
 		ErrNotImplemented,
 		ErrWriteConflict,
 		ErrWriteConflictInTiDB,
+		ErrSerializationFailure,
 	}
 
 	for _, err := range kvErrs {
@@ -234,7 +234,7 @@ func tableHandleKeyRanges(tableInfo *model.TableInfo) (*tidbkv.KeyRanges, error)
 // tableIndexKeyRanges returns all key ranges associated with the tableInfo and indexInfo.
 func tableIndexKeyRanges(tableInfo *model.TableInfo, indexInfo *model.IndexInfo) (*tidbkv.KeyRanges, error) {
 	tableIDs := physicalTableIDs(tableInfo)
-	return distsql.IndexRangesToKVRangesForTables(nil, tableIDs, indexInfo.ID, ranger.FullRange())
+	return distsql.IndexRangesToKVRangesForTables(nil, tableIDs, indexInfo, ranger.FullRange())
 }
 
 // DupKVStream is a streaming interface for collecting duplicate key-value pairs.
@@ -104,6 +104,10 @@ var BDRActionMap = map[DDLBDRType][]ActionType{
 		ActionAddColumnarIndex,
 		ActionModifyEngineAttribute,
 		ActionAlterTableMode,
+		ActionCreateTrigger,
+		ActionDropTrigger,
+		ActionCreateChangefeed,
+		ActionDropChangefeed,
 	},
 	UnmanagementDDL: {
 		ActionCreatePlacementPolicy,
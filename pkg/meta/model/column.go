@@ -123,6 +123,17 @@ func (c *ColumnInfo) GetElems() []string {
 	return c.FieldType.GetElems()
 }
 
+// GetGeometryType returns the geometry subtype of ColumnInfo.
+func (c *ColumnInfo) GetGeometryType() types.GeometryType {
+	return c.FieldType.GetGeometryType()
+}
+
+// GetSRID returns the SRID of ColumnInfo, the second return value is false if
+// the SRID isn't specified.
+func (c *ColumnInfo) GetSRID() (uint32, bool) {
+	return c.FieldType.GetSRID()
+}
+
 // SetType set the type of ColumnInfo.
 func (c *ColumnInfo) SetType(tp byte) {
 	c.FieldType.SetType(tp)
@@ -173,6 +184,11 @@ func (c *ColumnInfo) SetCollate(collate string) {
 	c.FieldType.SetCollate(collate)
 }
 
+// SetSRID sets the SRID of the spatial column.
+func (c *ColumnInfo) SetSRID(srid uint32) {
+	c.FieldType.SetSRID(srid)
+}
+
 // SetElems set the elements of enum column.
 func (c *ColumnInfo) SetElems(elems []string) {
 	c.FieldType.SetElems(elems)
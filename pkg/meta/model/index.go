@@ -58,6 +58,22 @@ type VectorIndexInfo struct {
 	DistanceMetric DistanceMetric `json:"distance_metric"`
 }
 
+// FullTextParserType is the parser used by a full-text index to split the text into tokens.
+type FullTextParserType string
+
+const (
+	// FullTextParserTypeStandard splits the text into words, and the CJK characters into bigrams.
+	FullTextParserTypeStandard FullTextParserType = "standard"
+	// FullTextParserTypeNgram splits the text into bigrams.
+	FullTextParserTypeNgram FullTextParserType = "ngram"
+)
+
+// FullTextIndexInfo is the information of full-text index.
+type FullTextIndexInfo struct {
+	// ParserType is the parser used to split the text into tokens.
+	ParserType FullTextParserType `json:"parser_type"`
+}
+
 // ColumnarIndexType is the type of columnar index.
 type ColumnarIndexType uint8
 
@@ -74,20 +90,30 @@ const (
 // It corresponds to the statement `CREATE INDEX Name ON Table (Column);`
 // See https://dev.mysql.com/doc/refman/5.7/en/create-index.html
 type IndexInfo struct {
-	ID            int64            `json:"id"`
-	Name          ast.CIStr        `json:"idx_name"` // Index name.
-	Table         ast.CIStr        `json:"tbl_name"` // Table name.
-	Columns       []*IndexColumn   `json:"idx_cols"` // Index columns.
-	State         SchemaState      `json:"state"`
-	BackfillState BackfillState    `json:"backfill_state"`
-	Comment       string           `json:"comment"`      // Comment
-	Tp            ast.IndexType    `json:"index_type"`   // Index type: Btree, Hash, Rtree or HNSW
-	Unique        bool             `json:"is_unique"`    // Whether the index is unique.
-	Primary       bool             `json:"is_primary"`   // Whether the index is primary key.
-	Invisible     bool             `json:"is_invisible"` // Whether the index is invisible.
-	Global        bool             `json:"is_global"`    // Whether the index is global.
-	MVIndex       bool             `json:"mv_index"`     // Whether the index is multivalued index.
-	VectorInfo    *VectorIndexInfo `json:"vector_index"` // VectorInfo is the vector index information.
+	ID            int64              `json:"id"`
+	Name          ast.CIStr          `json:"idx_name"` // Index name.
+	Table         ast.CIStr          `json:"tbl_name"` // Table name.
+	Columns       []*IndexColumn     `json:"idx_cols"` // Index columns.
+	State         SchemaState        `json:"state"`
+	BackfillState BackfillState      `json:"backfill_state"`
+	Comment       string             `json:"comment"`                   // Comment
+	Tp            ast.IndexType      `json:"index_type"`                // Index type: Btree, Hash, Rtree or HNSW
+	Unique        bool               `json:"is_unique"`                 // Whether the index is unique.
+	Primary       bool               `json:"is_primary"`                // Whether the index is primary key.
+	Invisible     bool               `json:"is_invisible"`              // Whether the index is invisible.
+	Global        bool               `json:"is_global"`                 // Whether the index is global.
+	MVIndex       bool               `json:"mv_index"`                  // Whether the index is multivalued index.
+	VectorInfo    *VectorIndexInfo   `json:"vector_index"`              // VectorInfo is the vector index information.
+	Spatial       bool               `json:"is_spatial"`                // Whether the index is spatial index.
+	FullTextInfo  *FullTextIndexInfo `json:"full_text_index,omitempty"` // FullTextInfo is the full-text index information.
+	// ConditionExprString is the predicate of a partial index, only the rows
+	// satisfying it are indexed. It is empty for a normal index.
+	ConditionExprString string `json:"condition_expr_string,omitempty"`
+	// ConditionColumns are the columns referenced by ConditionExprString.
+	ConditionColumns []ast.CIStr `json:"condition_cols,omitempty"`
+	// IncludeColumns are the non-key columns whose values are stored in the
+	// index value, so that the index can cover them without a table lookup.
+	IncludeColumns []*IndexColumn `json:"include_cols,omitempty"`
 }
 
 // Hash64 implement HashEquals interface.
@@ -121,9 +147,21 @@ func (index *IndexInfo) Clone() *IndexInfo {
 	for i := range index.Columns {
 		ni.Columns[i] = index.Columns[i].Clone()
 	}
+	if len(index.IncludeColumns) > 0 {
+		ni.IncludeColumns = make([]*IndexColumn, len(index.IncludeColumns))
+		for i := range index.IncludeColumns {
+			ni.IncludeColumns[i] = index.IncludeColumns[i].Clone()
+		}
+	}
 	return &ni
 }
 
+// IsSearchIndex returns whether the index is a spatial or full-text index. The keys of such an index are derived from
+// the column values instead of the values themselves, so it can't be used to read the values like a normal index.
+func (index *IndexInfo) IsSearchIndex() bool {
+	return index.Spatial || index.FullTextInfo != nil
+}
+
 // HasPrefixIndex returns whether any columns of this index uses prefix length.
 func (index *IndexInfo) HasPrefixIndex() bool {
 	for _, ic := range index.Columns {
@@ -134,6 +172,32 @@ func (index *IndexInfo) HasPrefixIndex() bool {
 	return false
 }
 
+// HasDescColumn returns whether any columns of this index is stored in descending order.
+func (index *IndexInfo) HasDescColumn() bool {
+	for _, ic := range index.Columns {
+		if ic.Desc {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCondition returns whether the index is a partial index.
+func (index *IndexInfo) HasCondition() bool {
+	return index.ConditionExprString != ""
+}
+
+// HasIncludeColumns returns whether the index stores the values of some non-key columns.
+func (index *IndexInfo) HasIncludeColumns() bool {
+	return len(index.IncludeColumns) > 0
+}
+
+// FindIncludeColumnByName finds the included column with the specified name.
+func (index *IndexInfo) FindIncludeColumnByName(nameL string) *IndexColumn {
+	_, ret := FindIndexColumnByName(index.IncludeColumns, nameL)
+	return ret
+}
+
 // HasColumnInIndexColumns checks whether the index contains the column with the specified ID.
 func (index *IndexInfo) HasColumnInIndexColumns(tblInfo *TableInfo, colID int64) bool {
 	for _, ic := range index.Columns {
@@ -170,9 +234,10 @@ func (index *IndexInfo) GetColumnarIndexType() ColumnarIndexType {
 }
 
 // FindIndexByColumns find IndexInfo in indices which is cover the specified columns.
+// Partial indexes are ignored since they don't cover all the rows.
 func FindIndexByColumns(tbInfo *TableInfo, indices []*IndexInfo, cols ...ast.CIStr) *IndexInfo {
 	for _, index := range indices {
-		if IsIndexPrefixCovered(tbInfo, index, cols...) {
+		if !index.HasCondition() && IsIndexPrefixCovered(tbInfo, index, cols...) {
 			return index
 		}
 	}
@@ -215,6 +280,8 @@ type IndexColumn struct {
 	// for indexing;
 	// UnspecifedLength if not using prefix indexing
 	Length int `json:"length"`
+	// Desc means the column is stored in descending order in the index key.
+	Desc bool `json:"desc,omitempty"`
 }
 
 // Clone clones IndexColumn.
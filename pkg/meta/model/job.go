@@ -114,6 +114,10 @@ const (
 	ActionAddColumnarIndex       ActionType = 73
 	ActionModifyEngineAttribute  ActionType = 74
 	ActionAlterTableMode         ActionType = 75
+	ActionCreateTrigger          ActionType = 76
+	ActionDropTrigger            ActionType = 77
+	ActionCreateChangefeed       ActionType = 78
+	ActionDropChangefeed         ActionType = 79
 )
 
 // ActionMap is the map of DDL ActionType to string.
@@ -188,6 +192,10 @@ var ActionMap = map[ActionType]string{
 	ActionAddColumnarIndex:              "add columnar index",
 	ActionModifyEngineAttribute:         "modify engine attribute",
 	ActionAlterTableMode:                "alter table mode",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionCreateChangefeed:              "create changefeed",
+	ActionDropChangefeed:                "drop changefeed",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -951,14 +951,16 @@ type AlterTTLInfoArgs struct {
 	TTLInfo            *TTLInfo `json:"ttl_info,omitempty"`
 	TTLEnable          *bool    `json:"ttl_enable,omitempty"`
 	TTLCronJobSchedule *string  `json:"ttl_cron_job_schedule,omitempty"`
+	TTLAction          *string  `json:"ttl_action,omitempty"`
+	TTLArchiveTarget   *string  `json:"ttl_archive_target,omitempty"`
 }
 
 func (a *AlterTTLInfoArgs) getArgsV1(*Job) []any {
-	return []any{a.TTLInfo, a.TTLEnable, a.TTLCronJobSchedule}
+	return []any{a.TTLInfo, a.TTLEnable, a.TTLCronJobSchedule, a.TTLAction, a.TTLArchiveTarget}
 }
 
 func (a *AlterTTLInfoArgs) decodeV1(job *Job) error {
-	return errors.Trace(job.decodeArgs(&a.TTLInfo, &a.TTLEnable, &a.TTLCronJobSchedule))
+	return errors.Trace(job.decodeArgs(&a.TTLInfo, &a.TTLEnable, &a.TTLCronJobSchedule, &a.TTLAction, &a.TTLArchiveTarget))
 }
 
 // GetAlterTTLInfoArgs gets the args for alter ttl info job.
@@ -1103,6 +1105,86 @@ func GetAlterTableModeArgs(job *Job) (*AlterTableModeArgs, error) {
 	return getOrDecodeArgs[*AlterTableModeArgs](&AlterTableModeArgs{}, job)
 }
 
+// CreateTriggerArgs is the argument for create trigger.
+type CreateTriggerArgs struct {
+	Trigger     *TriggerInfo `json:"trigger,omitempty"`
+	IfNotExists bool         `json:"if_not_exists,omitempty"`
+	// Order is the name of the trigger which the new trigger follows or precedes,
+	// empty means the new trigger is fired after the existing ones.
+	Order    string `json:"order,omitempty"`
+	Precedes bool   `json:"precedes,omitempty"`
+}
+
+func (a *CreateTriggerArgs) getArgsV1(*Job) []any {
+	return []any{a}
+}
+
+func (a *CreateTriggerArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(a))
+}
+
+// GetCreateTriggerArgs get the CreateTriggerArgs argument.
+func GetCreateTriggerArgs(job *Job) (*CreateTriggerArgs, error) {
+	return getOrDecodeArgs[*CreateTriggerArgs](&CreateTriggerArgs{}, job)
+}
+
+// DropTriggerArgs is the argument for drop trigger.
+type DropTriggerArgs struct {
+	Name     ast.CIStr `json:"name,omitempty"`
+	IfExists bool      `json:"if_exists,omitempty"`
+}
+
+func (a *DropTriggerArgs) getArgsV1(*Job) []any {
+	return []any{a}
+}
+
+func (a *DropTriggerArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(a))
+}
+
+// GetDropTriggerArgs get the DropTriggerArgs argument.
+func GetDropTriggerArgs(job *Job) (*DropTriggerArgs, error) {
+	return getOrDecodeArgs[*DropTriggerArgs](&DropTriggerArgs{}, job)
+}
+
+// CreateChangefeedArgs is the argument for create changefeed.
+type CreateChangefeedArgs struct {
+	Changefeed  *ChangefeedInfo `json:"changefeed,omitempty"`
+	IfNotExists bool            `json:"if_not_exists,omitempty"`
+}
+
+func (a *CreateChangefeedArgs) getArgsV1(*Job) []any {
+	return []any{a}
+}
+
+func (a *CreateChangefeedArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(a))
+}
+
+// GetCreateChangefeedArgs get the CreateChangefeedArgs argument.
+func GetCreateChangefeedArgs(job *Job) (*CreateChangefeedArgs, error) {
+	return getOrDecodeArgs[*CreateChangefeedArgs](&CreateChangefeedArgs{}, job)
+}
+
+// DropChangefeedArgs is the argument for drop changefeed.
+type DropChangefeedArgs struct {
+	Name     ast.CIStr `json:"name,omitempty"`
+	IfExists bool      `json:"if_exists,omitempty"`
+}
+
+func (a *DropChangefeedArgs) getArgsV1(*Job) []any {
+	return []any{a}
+}
+
+func (a *DropChangefeedArgs) decodeV1(job *Job) error {
+	return errors.Trace(job.decodeArgs(a))
+}
+
+// GetDropChangefeedArgs get the DropChangefeedArgs argument.
+func GetDropChangefeedArgs(job *Job) (*DropChangefeedArgs, error) {
+	return getOrDecodeArgs[*DropChangefeedArgs](&DropChangefeedArgs{}, job)
+}
+
 // RepairTableArgs is the argument for repair table
 type RepairTableArgs struct {
 	TableInfo *TableInfo `json:"table_info"`
@@ -1357,6 +1439,13 @@ type IndexArg struct {
 	//       2. when you set it, make sure IsColumnar = ColumnarIndexType != ColumnarIndexTypeNA.
 	ColumnarIndexType ColumnarIndexType `json:"columnar_index_type,omitempty"`
 
+	// Spatial is used to create a spatial index.
+	Spatial bool `json:"spatial,omitempty"`
+	// FullText is used to create a full-text index.
+	FullText bool `json:"full_text,omitempty"`
+	// ConditionExpr is the restored predicate of a partial index.
+	ConditionExpr string `json:"condition_expr,omitempty"`
+
 	// For PK
 	IsPK    bool          `json:"is_pk,omitempty"`
 	SQLMode mysql.SQLMode `json:"sql_mode,omitempty"`
@@ -1452,6 +1541,9 @@ func (a *ModifyIndexArgs) getArgsV1(job *Job) []any {
 	indexOption := make([]*ast.IndexOption, n)
 	hiddenCols := make([][]*ColumnInfo, n)
 	global := make([]bool, n)
+	spatial := make([]bool, n)
+	fullText := make([]bool, n)
+	conditionExpr := make([]string, n)
 
 	for i, arg := range a.IndexArgs {
 		unique[i] = arg.Unique
@@ -1460,14 +1552,17 @@ func (a *ModifyIndexArgs) getArgsV1(job *Job) []any {
 		indexOption[i] = arg.IndexOption
 		hiddenCols[i] = arg.HiddenCols
 		global[i] = arg.Global
+		spatial[i] = arg.Spatial
+		fullText[i] = arg.FullText
+		conditionExpr[i] = arg.ConditionExpr
 	}
 
 	// This is to make the args compatible with old logic
 	if n == 1 {
-		return []any{unique[0], indexName[0], indexPartSpecification[0], indexOption[0], hiddenCols[0], global[0]}
+		return []any{unique[0], indexName[0], indexPartSpecification[0], indexOption[0], hiddenCols[0], global[0], spatial[0], fullText[0], conditionExpr[0]}
 	}
 
-	return []any{unique, indexName, indexPartSpecification, indexOption, hiddenCols, global}
+	return []any{unique, indexName, indexPartSpecification, indexOption, hiddenCols, global, spatial, fullText, conditionExpr}
 }
 
 func (a *ModifyIndexArgs) decodeV1(job *Job) error {
@@ -1525,16 +1620,29 @@ func (a *ModifyIndexArgs) decodeAddIndexV1(job *Job) error {
 	indexOptions := make([]*ast.IndexOption, 1)
 	hiddenCols := make([][]*ColumnInfo, 1)
 	globals := make([]bool, 1)
+	spatials := make([]bool, 1)
+	fullTexts := make([]bool, 1)
+	conditionExprs := make([]string, 1)
 
 	if err := job.decodeArgs(
 		&uniques, &indexNames, &indexPartSpecifications,
-		&indexOptions, &hiddenCols, &globals); err != nil {
+		&indexOptions, &hiddenCols, &globals, &spatials, &fullTexts, &conditionExprs); err != nil {
 		if err = job.decodeArgs(
 			&uniques[0], &indexNames[0], &indexPartSpecifications[0],
-			&indexOptions[0], &hiddenCols[0], &globals[0]); err != nil {
+			&indexOptions[0], &hiddenCols[0], &globals[0], &spatials[0], &fullTexts[0], &conditionExprs[0]); err != nil {
 			return errors.Trace(err)
 		}
 	}
+	// Jobs created before spatial or full-text index was introduced don't carry these arguments.
+	if len(spatials) < len(uniques) {
+		spatials = make([]bool, len(uniques))
+	}
+	if len(fullTexts) < len(uniques) {
+		fullTexts = make([]bool, len(uniques))
+	}
+	if len(conditionExprs) < len(uniques) {
+		conditionExprs = make([]string, len(uniques))
+	}
 
 	for i, unique := range uniques {
 		a.IndexArgs = append(a.IndexArgs, &IndexArg{
@@ -1544,6 +1652,9 @@ func (a *ModifyIndexArgs) decodeAddIndexV1(job *Job) error {
 			IndexOption:             indexOptions[i],
 			HiddenCols:              hiddenCols[i],
 			Global:                  globals[i],
+			Spatial:                 spatials[i],
+			FullText:                fullTexts[i],
+			ConditionExpr:           conditionExprs[i],
 		})
 	}
 	return nil
@@ -637,6 +637,8 @@ func TestGetDropForeignKeyArgs(t *testing.T) {
 func TestGetAlterTTLInfoArgs(t *testing.T) {
 	ttlEanble := true
 	ttlCronJobSchedule := "ttl-schedule"
+	ttlAction := TTLActionArchive
+	ttlArchiveTarget := "db.tbl"
 	inArgs := &AlterTTLInfoArgs{
 		TTLInfo: &TTLInfo{
 			ColumnName:       ast.NewCIStr("column_name"),
@@ -645,6 +647,8 @@ func TestGetAlterTTLInfoArgs(t *testing.T) {
 		},
 		TTLEnable:          &ttlEanble,
 		TTLCronJobSchedule: &ttlCronJobSchedule,
+		TTLAction:          &ttlAction,
+		TTLArchiveTarget:   &ttlArchiveTarget,
 	}
 	for _, v := range []JobVersion{JobVersion1, JobVersion2} {
 		j2 := &Job{}
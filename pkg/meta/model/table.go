@@ -17,6 +17,7 @@ package model
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -192,6 +193,15 @@ type TableInfo struct {
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
 
+	// Triggers are the row-level triggers of the table in the order they are fired.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the data of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+
+	// Changefeeds are the changefeeds which stream the row changes of the table.
+	Changefeeds []*ChangefeedInfo `json:"changefeeds,omitempty"`
+
 	// Revision is per table schema's version, it will be increased when the schema changed.
 	Revision uint64 `json:"revision"`
 
@@ -267,6 +277,21 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if len(t.Triggers) > 0 {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if len(t.Changefeeds) > 0 {
+		nt.Changefeeds = make([]*ChangefeedInfo, len(t.Changefeeds))
+		for i := range t.Changefeeds {
+			nt.Changefeeds[i] = t.Changefeeds[i].Clone()
+		}
+	}
 
 	return &nt
 }
@@ -415,6 +440,12 @@ func (t *TableInfo) MoveColumnInfo(from, to int) {
 				idxCol.Offset = newOffset
 			}
 		}
+		for _, idxCol := range idx.IncludeColumns {
+			newOffset, ok := updatedOffsets[idxCol.Offset]
+			if ok {
+				idxCol.Offset = newOffset
+			}
+		}
 	}
 }
 
@@ -504,6 +535,11 @@ func (t *TableInfo) IsSequence() bool {
 	return t.Sequence != nil
 }
 
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
 // IsBaseTable checks to see the table is neither a view nor a sequence.
 func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
@@ -1365,16 +1401,31 @@ const DefaultTTLJobInterval = "24h"
 // It is used by some codes to keep compatible with the previous versions.
 const OldDefaultTTLJobInterval = "1h"
 
+const (
+	// TTLActionDelete deletes the expired rows directly. It's the default action of TTL.
+	TTLActionDelete = "DELETE"
+	// TTLActionArchive copies the expired rows to the archive target before deleting them.
+	TTLActionArchive = "ARCHIVE"
+)
+
 // TTLInfo records the TTL config
 type TTLInfo struct {
-	ColumnName      ast.CIStr `json:"column"`
-	IntervalExprStr string    `json:"interval_expr"`
+	ColumnName ast.CIStr `json:"column"`
+	// IntervalExprStr is empty if the column stores the expiry time of each row, e.g. `TTL = expire_at`.
+	IntervalExprStr string `json:"interval_expr"`
 	// `IntervalTimeUnit` is actually ast.TimeUnitType. Use `int` to avoid cycle dependency
 	IntervalTimeUnit int  `json:"interval_time_unit"`
 	Enable           bool `json:"enable"`
 	// JobInterval is the interval between two TTL scan jobs.
 	// It's suggested to get a duration with `(*TTLInfo).GetJobInterval`
 	JobInterval string `json:"job_interval"`
+	// Action is the action on the expired rows, it's `TTLActionDelete` if empty.
+	Action string `json:"action,omitempty"`
+	// ArchiveTarget is where the expired rows are archived when the action is `TTLActionArchive`.
+	// It's either a table name such as `db.tbl`, or the URL of an external storage.
+	ArchiveTarget string `json:"archive_target,omitempty"`
+	// Condition is the `WHERE` condition of the TTL option, only the expired rows matching it are deleted.
+	Condition string `json:"condition,omitempty"`
 }
 
 // Clone clones TTLInfo
@@ -1383,6 +1434,21 @@ func (t *TTLInfo) Clone() *TTLInfo {
 	return &cloned
 }
 
+// IsArchive returns whether the expired rows are archived before deletion.
+func (t *TTLInfo) IsArchive() bool {
+	return t.Action == TTLActionArchive
+}
+
+// IsPerRowExpiry returns whether the TTL column stores the expiry time of each row.
+func (t *TTLInfo) IsPerRowExpiry() bool {
+	return t.IntervalExprStr == ""
+}
+
+// HasCondition returns whether the TTL only applies to the rows matching a condition.
+func (t *TTLInfo) HasCondition() bool {
+	return t.Condition != ""
+}
+
 // GetJobInterval parses the job interval and return
 // if the job interval is an empty string, the "1h" will be returned, to keep compatible with 6.5 (in which
 // TTL_JOB_INTERVAL attribute doesn't exist)
@@ -1402,3 +1468,97 @@ func (t *TTLInfo) GetJobInterval() (time.Duration, error) {
 
 	return duration.ParseDuration(t.JobInterval)
 }
+
+// TriggerInfo records a row-level trigger of the table.
+type TriggerInfo struct {
+	Name   ast.CIStr         `json:"name"`
+	Timing ast.TriggerTiming `json:"timing"`
+	Event  ast.TriggerEvent  `json:"event"`
+	// Statement is the original SQL of the trigger body.
+	Statement           string `json:"statement"`
+	Definer             string `json:"definer"`
+	SQLMode             string `json:"sql_mode"`
+	CharsetClient       string `json:"charset_client"`
+	CollationConnection string `json:"collation_connection"`
+	DatabaseCollation   string `json:"database_collation"`
+	// CreateTS is the physical time when the trigger is created.
+	CreateTS time.Time `json:"create_ts"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	cloned := *t
+	return &cloned
+}
+
+// FindTrigger finds the trigger by name, it returns the index of the trigger in
+// Triggers or -1 if it's not found.
+func (t *TableInfo) FindTrigger(name string) int {
+	for i, trigger := range t.Triggers {
+		if trigger.Name.L == strings.ToLower(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Changefeed formats.
+const (
+	ChangefeedFormatCanalJSON = "canal-json"
+	ChangefeedFormatDebezium  = "debezium"
+	ChangefeedFormatAvro      = "avro"
+)
+
+// ChangefeedInfo records a changefeed of the table. The changefeed task captures
+// the row changes from the committed data of the table, and writes them to the
+// sink.
+type ChangefeedInfo struct {
+	// ID is a global ID, which identifies the changefeed in the checkpoint and the task.
+	ID      int64     `json:"id"`
+	Name    ast.CIStr `json:"name"`
+	SinkURI string    `json:"sink_uri"`
+	Format  string    `json:"format"`
+	// CreateTS is the physical time when the changefeed is created.
+	CreateTS time.Time `json:"create_ts"`
+}
+
+// Clone clones ChangefeedInfo.
+func (c *ChangefeedInfo) Clone() *ChangefeedInfo {
+	cloned := *c
+	return &cloned
+}
+
+// FindChangefeed finds the changefeed by name, it returns the index of the
+// changefeed in Changefeeds or -1 if it's not found.
+func (t *TableInfo) FindChangefeed(name string) int {
+	for i, cf := range t.Changefeeds {
+		if cf.Name.L == strings.ToLower(name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// MaterializedViewInfo records the definition of a materialized view, the rows
+// of the view are stored in the table.
+type MaterializedViewInfo struct {
+	// Definition is the SELECT statement of the view with qualified table names.
+	Definition string `json:"definition"`
+	// BaseSchema and BaseTable are set if the view reads a single table.
+	BaseSchema ast.CIStr `json:"base_schema"`
+	BaseTable  ast.CIStr `json:"base_table"`
+	// Incremental means the view can be refreshed incrementally by the change
+	// log of the base table. Such a view groups the base table by the BaseKeys
+	// columns, and the keys are stored in the view columns at KeyOffsets.
+	Incremental bool        `json:"incremental"`
+	BaseKeys    []ast.CIStr `json:"base_keys"`
+	KeyOffsets  []int       `json:"key_offsets"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	cloned := *m
+	cloned.BaseKeys = slices.Clone(m.BaseKeys)
+	cloned.KeyOffsets = slices.Clone(m.KeyOffsets)
+	return &cloned
+}
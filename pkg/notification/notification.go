@@ -0,0 +1,175 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notification implements the pub-sub channels of NOTIFY.
+//
+// NOTIFY inserts a notification into mysql.tidb_notification in the transaction
+// of the statement, so the notification is delivered only if the transaction
+// commits. The committed notifications are published in batches: a publisher
+// locks mysql.tidb_notification_batch and assigns the next batch to the
+// unpublished notifications. A batch becomes visible at once when it's committed,
+// so the position (batch, _tidb_rowid) of the published notifications only
+// grows, and a subscriber resuming from the position of the last notification it
+// has processed receives every later notification at least once.
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const (
+	// Table is the table storing the notifications.
+	Table = "tidb_notification"
+	// BatchTable is the table storing the last published batch.
+	BatchTable = "tidb_notification_batch"
+	// SystemDB is the schema of Table and BatchTable.
+	SystemDB = mysql.SystemDB
+	// MaxChannelLen is the max length of a channel name.
+	MaxChannelLen = 64
+)
+
+var (
+	// Retention is how long the published notifications are kept.
+	Retention = 24 * time.Hour
+	// publishBatchSize is the max number of notifications published in a batch.
+	publishBatchSize = 1024
+)
+
+// Position is the position of a published notification.
+type Position struct {
+	Batch int64
+	ID    int64
+}
+
+// String implements the fmt.Stringer interface.
+func (p Position) String() string {
+	return fmt.Sprintf("%d-%d", p.Batch, p.ID)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (p Position) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (p *Position) UnmarshalText(text []byte) (err error) {
+	*p, err = ParsePosition(string(text))
+	return err
+}
+
+// ParsePosition parses a position returned by Position.String.
+func ParsePosition(s string) (Position, error) {
+	batch, id, ok := strings.Cut(s, "-")
+	if ok {
+		b, err1 := strconv.ParseInt(batch, 10, 64)
+		i, err2 := strconv.ParseInt(id, 10, 64)
+		if err1 == nil && err2 == nil && b >= 0 && i >= 0 {
+			return Position{Batch: b, ID: i}, nil
+		}
+	}
+	return Position{}, errors.Errorf("invalid position '%s'", s)
+}
+
+// Notification is a published notification.
+type Notification struct {
+	Channel  string    `json:"channel"`
+	Payload  *string   `json:"payload"`
+	Position Position  `json:"position"`
+	Time     time.Time `json:"time"`
+}
+
+// Publish publishes the committed notifications in a new batch, and removes
+// the published notifications older than Retention.
+func Publish(ctx context.Context, sctx sessionctx.Context) error {
+	exec := sctx.GetSQLExecutor()
+	rows, err := sqlexec.ExecSQL(ctx, exec, "SELECT 1 FROM %n.%n WHERE batch IS NULL LIMIT 1", SystemDB, Table)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	if err = publishBatch(ctx, exec); err != nil {
+		return err
+	}
+	expire := time.Now().Add(-Retention).Unix()
+	_, err = sqlexec.ExecSQL(ctx, exec, "DELETE FROM %n.%n WHERE create_time < FROM_UNIXTIME(%?) AND batch IS NOT NULL LIMIT %?",
+		SystemDB, Table, expire, publishBatchSize)
+	return err
+}
+
+func publishBatch(ctx context.Context, exec sqlexec.SQLExecutor) (err error) {
+	if _, err = exec.ExecuteInternal(ctx, "BEGIN PESSIMISTIC"); err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_, err1 := exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(err1)
+			return
+		}
+		_, err = exec.ExecuteInternal(ctx, "COMMIT")
+	}()
+	if _, err = sqlexec.ExecSQL(ctx, exec, "INSERT IGNORE INTO %n.%n VALUES (1, 0)", SystemDB, BatchTable); err != nil {
+		return err
+	}
+	rows, err := sqlexec.ExecSQL(ctx, exec, "SELECT batch FROM %n.%n WHERE id = 1 FOR UPDATE", SystemDB, BatchTable)
+	if err != nil {
+		return err
+	}
+	batch := rows[0].GetInt64(0) + 1
+	_, err = sqlexec.ExecSQL(ctx, exec, "UPDATE %n.%n SET batch = %? WHERE batch IS NULL ORDER BY _tidb_rowid LIMIT %?",
+		SystemDB, Table, batch, publishBatchSize)
+	if err != nil {
+		return err
+	}
+	_, err = sqlexec.ExecSQL(ctx, exec, "UPDATE %n.%n SET batch = %? WHERE id = 1", SystemDB, BatchTable, batch)
+	return err
+}
+
+// Fetch returns at most limit published notifications of the channel after the
+// position, in the order of their positions.
+func Fetch(ctx context.Context, sctx sessionctx.Context, channel string, after Position, limit int) ([]*Notification, error) {
+	rows, err := sqlexec.ExecSQL(ctx, sctx.GetSQLExecutor(), "SELECT payload, batch, _tidb_rowid, UNIX_TIMESTAMP(create_time) FROM %n.%n "+
+		"WHERE channel = %? AND (batch > %? OR (batch = %? AND _tidb_rowid > %?)) ORDER BY batch, _tidb_rowid LIMIT %?",
+		SystemDB, Table, channel, after.Batch, after.Batch, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	notifications := make([]*Notification, 0, len(rows))
+	for _, row := range rows {
+		n := &Notification{
+			Channel:  channel,
+			Position: Position{Batch: row.GetInt64(1), ID: row.GetInt64(2)},
+		}
+		if !row.IsNull(0) {
+			payload := row.GetString(0)
+			n.Payload = &payload
+		}
+		ts, err := row.GetMyDecimal(3).ToFloat64()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		n.Time = time.UnixMicro(int64(ts * 1e6)).UTC()
+		notifications = append(notifications, n)
+	}
+	return notifications, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/notification"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePosition(t *testing.T) {
+	pos, err := notification.ParsePosition("12-345")
+	require.NoError(t, err)
+	require.Equal(t, notification.Position{Batch: 12, ID: 345}, pos)
+	require.Equal(t, "12-345", pos.String())
+
+	for _, s := range []string{"", "12", "a-1", "1-b", "-1-2", "1-2-3"} {
+		_, err = notification.ParsePosition(s)
+		require.ErrorContains(t, err, "invalid position")
+	}
+}
+
+func TestNotify(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+
+	tk.MustExec("notify ch1, 'a'")
+	tk.MustExec("notify ch2")
+	tk.MustExec("begin")
+	tk.MustExec("notify ch1, concat('b', 1)")
+	tk.MustExec("rollback")
+	tk.MustExec("begin")
+	tk.MustExec("notify ch1, 1 + 1")
+	tk.MustQuery("select count(*) from mysql.tidb_notification where batch is null").Check(testkit.Rows("3"))
+	tk.MustExec("commit")
+	tk.MustGetErrCode("notify "+strings.Repeat("a", 65), 1059)
+
+	// the notifications are invisible to the subscribers until they are published
+	ns, err := notification.Fetch(ctx, tk.Session(), "ch1", notification.Position{}, 10)
+	require.NoError(t, err)
+	require.Empty(t, ns)
+
+	require.NoError(t, notification.Publish(ctx, tk.Session()))
+	tk.MustQuery("select batch from mysql.tidb_notification_batch").Check(testkit.Rows("1"))
+	ns, err = notification.Fetch(ctx, tk.Session(), "ch1", notification.Position{}, 10)
+	require.NoError(t, err)
+	require.Len(t, ns, 2)
+	require.Equal(t, "a", *ns[0].Payload)
+	require.Equal(t, "2", *ns[1].Payload)
+	require.Equal(t, int64(1), ns[0].Position.Batch)
+	require.Less(t, ns[0].Position.ID, ns[1].Position.ID)
+	require.WithinDuration(t, time.Now(), ns[0].Time, time.Minute)
+	ns2, err := notification.Fetch(ctx, tk.Session(), "ch2", notification.Position{}, 10)
+	require.NoError(t, err)
+	require.Len(t, ns2, 1)
+	require.Nil(t, ns2[0].Payload)
+
+	// resume from the position of the last received notification
+	last := ns[0].Position
+	ns, err = notification.Fetch(ctx, tk.Session(), "ch1", last, 10)
+	require.NoError(t, err)
+	require.Len(t, ns, 1)
+	require.Equal(t, "2", *ns[0].Payload)
+	last = ns[0].Position
+	tk.MustExec("notify ch1, 'c'")
+	require.NoError(t, notification.Publish(ctx, tk.Session()))
+	ns, err = notification.Fetch(ctx, tk.Session(), "ch1", last, 10)
+	require.NoError(t, err)
+	require.Len(t, ns, 1)
+	require.Equal(t, "c", *ns[0].Payload)
+	require.Equal(t, int64(2), ns[0].Position.Batch)
+
+	// nothing to publish
+	require.NoError(t, notification.Publish(ctx, tk.Session()))
+	tk.MustQuery("select batch from mysql.tidb_notification_batch").Check(testkit.Rows("2"))
+
+	// the expired notifications are removed
+	tk.MustExec("update mysql.tidb_notification set create_time = '2000-01-01 00:00:00' where channel = 'ch2'")
+	tk.MustExec("notify ch1, 'd'")
+	require.NoError(t, notification.Publish(ctx, tk.Session()))
+	tk.MustQuery("select count(*) from mysql.tidb_notification where channel = 'ch2'").Check(testkit.Rows("0"))
+	tk.MustQuery("select payload from mysql.tidb_notification order by batch, _tidb_rowid").Check(testkit.Rows("a", "2", "c", "d"))
+}
+
+func TestNotifyInProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p(v int) begin notify ch, v * 2; end")
+	tk.MustExec("call p(21)")
+	tk.MustQuery("select channel, payload from mysql.tidb_notification").Check(testkit.Rows("ch 42"))
+}
@@ -32,6 +32,8 @@ var (
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
 	_ DDLNode = &CreateViewStmt{}
+	_ DDLNode = &CreateMaterializedViewStmt{}
+	_ DDLNode = &CreateChangefeedStmt{}
 	_ DDLNode = &CreateSequenceStmt{}
 	_ DDLNode = &CreatePlacementPolicyStmt{}
 	_ DDLNode = &CreateResourceGroupStmt{}
@@ -39,10 +41,13 @@ var (
 	_ DDLNode = &FlashBackDatabaseStmt{}
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
+	_ DDLNode = &DropMaterializedViewStmt{}
+	_ DDLNode = &DropChangefeedStmt{}
 	_ DDLNode = &DropSequenceStmt{}
 	_ DDLNode = &DropPlacementPolicyStmt{}
 	_ DDLNode = &DropResourceGroupStmt{}
 	_ DDLNode = &OptimizeTableStmt{}
+	_ DDLNode = &RefreshMaterializedViewStmt{}
 	_ DDLNode = &RenameTableStmt{}
 	_ DDLNode = &TruncateTableStmt{}
 	_ DDLNode = &RepairTableStmt{}
@@ -292,7 +297,7 @@ type IndexPartSpecification struct {
 
 	Column *ColumnName
 	Length int
-	// Order is parsed but should be ignored because MySQL v5.7 doesn't support it.
+	// Desc means the key part is stored in descending order.
 	Desc bool
 	Expr ExprNode
 }
@@ -522,6 +527,7 @@ const (
 	ColumnOptionColumnFormat
 	ColumnOptionStorage
 	ColumnOptionAutoRandom
+	ColumnOptionSRID // For spatial types only.
 )
 
 var (
@@ -676,6 +682,11 @@ func (n *ColumnOption) Restore(ctx *format.RestoreCtx) error {
 			}
 			return nil
 		})
+	case ColumnOptionSRID:
+		ctx.WriteKeyWord("SRID ")
+		if err := n.Expr.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while splicing ColumnOption SRID Expr")
+		}
 	default:
 		return errors.New("An error occurred while splicing ColumnOption")
 	}
@@ -738,6 +749,12 @@ type IndexOption struct {
 	PrimaryKeyTp PrimaryKeyType
 	Global       bool
 	SplitOpt     *SplitOption `json:"-"` // SplitOption contains expr nodes, which cannot marshal for DDL job arguments.
+	// IncludeColumns are the non-key columns whose values are stored in the
+	// index value, so that the index can cover them without a table lookup.
+	IncludeColumns []*ColumnName
+	// Condition is the `WHERE` predicate of a partial index. Only the rows
+	// satisfying it are indexed.
+	Condition ExprNode `json:"-"`
 }
 
 // IsEmpty is true if only default options are given
@@ -750,7 +767,9 @@ func (n *IndexOption) IsEmpty() bool {
 		n.Comment != "" ||
 		n.Global ||
 		n.Visibility != IndexVisibilityDefault ||
-		n.SplitOpt != nil {
+		n.SplitOpt != nil ||
+		len(n.IncludeColumns) > 0 ||
+		n.Condition != nil {
 		return false
 	}
 	return true
@@ -847,6 +866,35 @@ func (n *IndexOption) Restore(ctx *format.RestoreCtx) error {
 		if err != nil {
 			return err
 		}
+		hasPrevOption = true
+	}
+
+	if len(n.IncludeColumns) > 0 {
+		if hasPrevOption {
+			ctx.WritePlain(" ")
+		}
+		ctx.WriteKeyWord("INCLUDE ")
+		ctx.WritePlain("(")
+		for i, col := range n.IncludeColumns {
+			if i > 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := col.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while splicing IndexOption IncludeColumns: [%v]", i)
+			}
+		}
+		ctx.WritePlain(")")
+		hasPrevOption = true
+	}
+
+	if n.Condition != nil {
+		if hasPrevOption {
+			ctx.WritePlain(" ")
+		}
+		ctx.WriteKeyWord("WHERE ")
+		if err := n.Condition.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while splicing IndexOption Condition")
+		}
 	}
 	return nil
 }
@@ -865,6 +913,20 @@ func (n *IndexOption) Accept(v Visitor) (Node, bool) {
 		}
 		n.SplitOpt = node.(*SplitOption)
 	}
+	for i, col := range n.IncludeColumns {
+		node, ok := col.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.IncludeColumns[i] = node.(*ColumnName)
+	}
+	if n.Condition != nil {
+		node, ok := n.Condition.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Condition = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -885,6 +947,7 @@ const (
 	ConstraintCheck
 	ConstraintVector
 	ConstraintColumnar
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -939,6 +1002,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1667,6 +1732,247 @@ func (n *CreateViewStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+// The view is stored as a table which is filled by the SELECT statement.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	ViewName    *TableName
+	Cols        []CIStr
+	Select      StmtNode
+	// ColTypes are the types of the view columns, they are filled by the planner.
+	ColTypes []*types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropMaterializedViewStmt is a statement to drop materialized views.
+type DropMaterializedViewStmt struct {
+	ddlNode
+
+	IfExists bool
+	Views    []*TableName
+}
+
+// Restore implements Node interface.
+func (n *DropMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	for i, view := range n.Views {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := view.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore DropMaterializedViewStmt.Views[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaterializedViewStmt)
+	for i, val := range n.Views {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Views[i] = node.(*TableName)
+	}
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewType is the way to refresh a materialized view.
+type RefreshMaterializedViewType int
+
+// Refresh types of materialized views.
+const (
+	// RefreshDefault refreshes the view incrementally if possible.
+	RefreshDefault RefreshMaterializedViewType = iota
+	RefreshComplete
+	RefreshIncremental
+)
+
+// String implements fmt.Stringer interface.
+func (t RefreshMaterializedViewType) String() string {
+	switch t {
+	case RefreshComplete:
+		return "COMPLETE"
+	case RefreshIncremental:
+		return "INCREMENTAL"
+	}
+	return ""
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh the data of a materialized view.
+type RefreshMaterializedViewStmt struct {
+	ddlNode
+
+	ViewName *TableName
+	Type     RefreshMaterializedViewType
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Type != RefreshDefault {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Type.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// CreateChangefeedStmt is a statement to create a changefeed, which streams the
+// row changes of a table to the sink.
+type CreateChangefeedStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Changefeed  *TableName
+	Table       *TableName
+	SinkURI     string
+	// Format is the format of the changes written to the sink, empty means the default format.
+	Format string
+}
+
+// Restore implements Node interface.
+func (n *CreateChangefeedStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE CHANGEFEED ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Changefeed.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateChangefeedStmt.Changefeed")
+	}
+	ctx.WriteKeyWord(" FOR TABLE ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateChangefeedStmt.Table")
+	}
+	ctx.WriteKeyWord(" INTO ")
+	ctx.WriteString(n.SinkURI)
+	if n.Format != "" {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(n.Format)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateChangefeedStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateChangefeedStmt)
+	// The changefeed name is not a table, so only the subject table is traversed.
+	node, ok := n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	return v.Leave(n)
+}
+
+// DropChangefeedStmt is a statement to drop a changefeed.
+type DropChangefeedStmt struct {
+	ddlNode
+
+	IfExists   bool
+	Changefeed *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropChangefeedStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP CHANGEFEED ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.Changefeed.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropChangefeedStmt.Changefeed")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropChangefeedStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropChangefeedStmt)
+	return v.Leave(n)
+}
+
 // CreatePlacementPolicyStmt is a statement to create a policy.
 type CreatePlacementPolicyStmt struct {
 	ddlNode
@@ -2571,6 +2877,8 @@ const (
 	TableOptionTTLEnable
 	TableOptionTTLJobInterval
 	TableOptionEngineAttribute
+	TableOptionTTLAction
+	TableOptionTTLArchiveTarget
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2627,6 +2935,8 @@ type TableOption struct {
 	Value         ValueExpr
 	TableNames    []*TableName
 	ColumnName    *ColumnName
+	// Condition is the `WHERE` condition of the TTL option.
+	Condition ExprNode
 }
 
 func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
@@ -2910,13 +3220,26 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord("TTL ")
 			ctx.WritePlain("= ")
 			ctx.WriteName(n.ColumnName.Name.String())
-			ctx.WritePlain(" + INTERVAL ")
-			err := n.Value.Restore(ctx)
-			ctx.WritePlain(" ")
-			if err != nil {
-				return err
+			if n.Value != nil {
+				ctx.WritePlain(" + INTERVAL ")
+				err := n.Value.Restore(ctx)
+				ctx.WritePlain(" ")
+				if err != nil {
+					return err
+				}
+				if err = n.TimeUnitValue.Restore(ctx); err != nil {
+					return err
+				}
 			}
-			return n.TimeUnitValue.Restore(ctx)
+			if n.Condition != nil {
+				ctx.WriteKeyWord(" WHERE ")
+				ctx.WritePlain("(")
+				if err := n.Condition.Restore(ctx); err != nil {
+					return err
+				}
+				ctx.WritePlain(")")
+			}
+			return nil
 		})
 	case TableOptionTTLEnable:
 		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
@@ -2936,6 +3259,20 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionTTLAction:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ACTION ")
+			ctx.WritePlain("= ")
+			ctx.WriteString(n.StrValue)
+			return nil
+		})
+	case TableOptionTTLArchiveTarget:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ARCHIVE_TARGET ")
+			ctx.WritePlain("= ")
+			ctx.WriteString(n.StrValue)
+			return nil
+		})
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
@@ -2963,6 +3300,13 @@ func (n *TableOption) Accept(v Visitor) (Node, bool) {
 		}
 		n.TimeUnitValue = node.(*TimeUnitExpr)
 	}
+	if n.Condition != nil {
+		node, ok := n.Condition.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Condition = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -3327,6 +3671,8 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteKeyWord(" DEPENDENCY(")
 		case StatsTypeCorrelation:
 			ctx.WriteKeyWord(" CORRELATION(")
+		case StatsTypeMCV:
+			ctx.WriteKeyWord(" MCV(")
 		}
 		for i, col := range n.Statistics.Columns {
 			if i != 0 {
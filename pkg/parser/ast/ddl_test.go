@@ -45,6 +45,11 @@ func TestDDLVisitorCover(t *testing.T) {
 		{&CreateIndexStmt{Table: &TableName{}}, 0, 0},
 		{&CreateTableStmt{Table: &TableName{}, ReferTable: &TableName{}}, 0, 0},
 		{&CreateViewStmt{ViewName: &TableName{}, Select: &SelectStmt{}}, 0, 0},
+		{&CreateMaterializedViewStmt{ViewName: &TableName{}, Select: &SelectStmt{}}, 0, 0},
+		{&DropMaterializedViewStmt{Views: []*TableName{{}, {}}}, 0, 0},
+		{&RefreshMaterializedViewStmt{ViewName: &TableName{}}, 0, 0},
+		{&CreateChangefeedStmt{Changefeed: &TableName{}, Table: &TableName{}}, 0, 0},
+		{&DropChangefeedStmt{Changefeed: &TableName{}}, 0, 0},
 		{&AlterTableSpec{}, 0, 0},
 		{&ColumnDef{Name: &ColumnName{}, Options: []*ColumnOption{{Expr: ce}}}, 1, 1},
 		{&ColumnOption{Expr: ce}, 1, 1},
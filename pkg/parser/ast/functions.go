@@ -361,6 +361,50 @@ const (
 	VecFromText             = "vec_from_text"
 	VecAsText               = "vec_as_text"
 
+	// spatial functions
+	STGeomFromText       = "st_geomfromtext"
+	STGeometryFromText   = "st_geometryfromtext"
+	STPointFromText      = "st_pointfromtext"
+	STLineFromText       = "st_linefromtext"
+	STLineStringFromText = "st_linestringfromtext"
+	STPolyFromText       = "st_polyfromtext"
+	STPolygonFromText    = "st_polygonfromtext"
+	STGeomFromWKB        = "st_geomfromwkb"
+	STGeometryFromWKB    = "st_geometryfromwkb"
+	STGeomFromGeoJSON    = "st_geomfromgeojson"
+	Point                = "point"
+	LineString           = "linestring"
+	Polygon              = "polygon"
+	MultiPoint           = "multipoint"
+	MultiLineString      = "multilinestring"
+	MultiPolygon         = "multipolygon"
+	GeometryCollection   = "geometrycollection"
+	GeomCollection       = "geomcollection"
+	STAsText             = "st_astext"
+	STAsWKT              = "st_aswkt"
+	STAsBinary           = "st_asbinary"
+	STAsWKB              = "st_aswkb"
+	STAsGeoJSON          = "st_asgeojson"
+	STSRID               = "st_srid"
+	STX                  = "st_x"
+	STY                  = "st_y"
+	STLatitude           = "st_latitude"
+	STLongitude          = "st_longitude"
+	STGeometryType       = "st_geometrytype"
+	STIsEmpty            = "st_isempty"
+	STDistance           = "st_distance"
+	STDistanceSphere     = "st_distance_sphere"
+	STArea               = "st_area"
+	STLength             = "st_length"
+	STContains           = "st_contains"
+	STWithin             = "st_within"
+	STIntersects         = "st_intersects"
+	STDisjoint           = "st_disjoint"
+	STEquals             = "st_equals"
+
+	// full-text search function, which is built from MatchAgainst.
+	Match = "match"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBMVCCInfo        = "tidb_mvcc_info"
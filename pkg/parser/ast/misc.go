@@ -28,6 +28,8 @@ import (
 )
 
 var (
+	_ DDLNode  = &CreateStatisticsStmt{}
+	_ DDLNode  = &DropStatisticsStmt{}
 	_ StmtNode = &AdminStmt{}
 	_ StmtNode = &AlterUserStmt{}
 	_ StmtNode = &AlterRangeStmt{}
@@ -2372,8 +2374,28 @@ const (
 	StatsTypeCardinality uint8 = iota
 	StatsTypeDependency
 	StatsTypeCorrelation
+	// StatsTypeMCV is the most common values of a column group.
+	StatsTypeMCV
+	// StatsTypeMultiColumn is not written in SQL. It's the type of the extended stats
+	// created by CREATE STATISTICS, which may collect several kinds of stats at once.
+	StatsTypeMultiColumn
 )
 
+// StatsTypeName returns the name of the extended statistics type.
+func StatsTypeName(tp uint8) string {
+	switch tp {
+	case StatsTypeCardinality:
+		return "cardinality"
+	case StatsTypeDependency:
+		return "dependency"
+	case StatsTypeCorrelation:
+		return "correlation"
+	case StatsTypeMCV:
+		return "mcv"
+	}
+	return ""
+}
+
 // StatisticsSpec is the specification for ADD /DROP STATISTICS.
 type StatisticsSpec struct {
 	StatsName string
@@ -2387,12 +2409,13 @@ type StatisticsSpec struct {
 //	CREATE STATISTICS stats1 (cardinality) ON t(a, b, c);
 //	CREATE STATISTICS stats2 (dependency) ON t(a, b);
 //	CREATE STATISTICS stats3 (correlation) ON t(a, b);
+//	CREATE STATISTICS stats4 (dependencies, ndistinct, mcv) ON t(a, b, c);
 type CreateStatisticsStmt struct {
-	stmtNode
+	ddlNode
 
 	IfNotExists bool
 	StatsName   string
-	StatsType   uint8
+	StatsTypes  []uint8
 	Table       *TableName
 	Columns     []*ColumnName
 }
@@ -2404,14 +2427,14 @@ func (n *CreateStatisticsStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
 	ctx.WriteName(n.StatsName)
-	switch n.StatsType {
-	case StatsTypeCardinality:
-		ctx.WriteKeyWord(" (cardinality) ")
-	case StatsTypeDependency:
-		ctx.WriteKeyWord(" (dependency) ")
-	case StatsTypeCorrelation:
-		ctx.WriteKeyWord(" (correlation) ")
+	ctx.WritePlain(" (")
+	for i, tp := range n.StatsTypes {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteKeyWord(StatsTypeName(tp))
 	}
+	ctx.WritePlain(") ")
 	ctx.WriteKeyWord("ON ")
 	if err := n.Table.Restore(ctx); err != nil {
 		return errors.Annotate(err, "An error occurred while restore CreateStatisticsStmt.Table")
@@ -2457,7 +2480,7 @@ func (n *CreateStatisticsStmt) Accept(v Visitor) (Node, bool) {
 //
 //	DROP STATISTICS stats1;
 type DropStatisticsStmt struct {
-	stmtNode
+	ddlNode
 
 	StatsName string
 }
@@ -2517,6 +2540,45 @@ func (n *DoStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// NotifyStmt is the struct for NOTIFY statement, which sends a notification
+// with an optional payload to the channel when the transaction commits.
+type NotifyStmt struct {
+	stmtNode
+
+	Channel string
+	Payload ExprNode
+}
+
+// Restore implements Node interface.
+func (n *NotifyStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("NOTIFY ")
+	ctx.WriteName(n.Channel)
+	if n.Payload != nil {
+		ctx.WritePlain(", ")
+		if err := n.Payload.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore NotifyStmt.Payload")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *NotifyStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*NotifyStmt)
+	if n.Payload != nil {
+		node, ok := n.Payload.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Payload = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
 // AdminStmtType is the type for admin statement.
 type AdminStmtType int
 
@@ -3978,7 +4040,7 @@ func (n *TableOptimizerHint) Restore(ctx *format.RestoreCtx) error {
 	}
 	// Hints without args except query block.
 	switch n.HintName.L {
-	case "mpp_1phase_agg", "mpp_2phase_agg", "hash_agg", "stream_agg", "agg_to_cop", "read_consistent_replica", "no_index_merge", "ignore_plan_cache", "limit_to_cop", "straight_join", "merge", "no_decorrelate":
+	case "mpp_1phase_agg", "mpp_2phase_agg", "hash_agg", "stream_agg", "agg_to_cop", "read_consistent_replica", "no_index_merge", "ignore_plan_cache", "limit_to_cop", "straight_join", "merge", "no_decorrelate", "runtime_filter":
 		ctx.WritePlain(")")
 		return nil
 	}
@@ -53,6 +53,10 @@ func TestParseDuration(t *testing.T) {
 			"1d3.555h",
 			24*time.Hour + time.Duration(3.555*float64(time.Hour)),
 		},
+		{
+			"1m30s",
+			time.Minute + 30*time.Second,
+		},
 	}
 
 	for _, c := range cases {
@@ -123,6 +123,7 @@ import (
 	hintLeading               "LEADING"
 	hintSemiJoinRewrite       "SEMI_JOIN_REWRITE"
 	hintNoDecorrelate         "NO_DECORRELATE"
+	hintRuntimeFilter         "RUNTIME_FILTER"
 
 	/* Other keywords */
 	hintOLAP            "OLAP"
@@ -694,6 +695,7 @@ NullaryHintName:
 |	"STRAIGHT_JOIN"
 |	"SEMI_JOIN_REWRITE"
 |	"NO_DECORRELATE"
+|	"RUNTIME_FILTER"
 
 HintQueryType:
 	"OLAP"
@@ -777,6 +779,7 @@ Identifier:
 |	"LEADING"
 |	"SEMI_JOIN_REWRITE"
 |	"NO_DECORRELATE"
+|	"RUNTIME_FILTER"
 /* other keywords */
 |	"OLAP"
 |	"OLTP"
@@ -129,11 +129,13 @@ var Keywords = []KeywordsType{
 	{"IS", true, "reserved"},
 	{"ITERATE", true, "reserved"},
 	{"JOIN", true, "reserved"},
+	{"JSON_TABLE", true, "reserved"},
 	{"KEY", true, "reserved"},
 	{"KEYS", true, "reserved"},
 	{"KILL", true, "reserved"},
 	{"LAG", true, "reserved"},
 	{"LAST_VALUE", true, "reserved"},
+	{"LATERAL", true, "reserved"},
 	{"LEAD", true, "reserved"},
 	{"LEADING", true, "reserved"},
 	{"LEAVE", true, "reserved"},
@@ -268,6 +270,7 @@ var Keywords = []KeywordsType{
 	{"ANY", false, "unreserved"},
 	{"APPLY", false, "unreserved"},
 	{"ASCII", false, "unreserved"},
+	{"AT", false, "unreserved"},
 	{"ATTRIBUTE", false, "unreserved"},
 	{"ATTRIBUTES", false, "unreserved"},
 	{"AUTO_ID_CACHE", false, "unreserved"},
@@ -280,6 +283,7 @@ var Keywords = []KeywordsType{
 	{"BACKUP", false, "unreserved"},
 	{"BACKUPS", false, "unreserved"},
 	{"BDR", false, "unreserved"},
+	{"BEFORE", false, "unreserved"},
 	{"BEGIN", false, "unreserved"},
 	{"BERNOULLI", false, "unreserved"},
 	{"BINDING", false, "unreserved"},
@@ -298,6 +302,8 @@ var Keywords = []KeywordsType{
 	{"CASCADED", false, "unreserved"},
 	{"CAUSAL", false, "unreserved"},
 	{"CHAIN", false, "unreserved"},
+	{"CHANGEFEED", false, "unreserved"},
+	{"CHANGEFEEDS", false, "unreserved"},
 	{"CHARSET", false, "unreserved"},
 	{"CHECKPOINT", false, "unreserved"},
 	{"CHECKSUM", false, "unreserved"},
@@ -318,6 +324,8 @@ var Keywords = []KeywordsType{
 	{"COMMIT", false, "unreserved"},
 	{"COMMITTED", false, "unreserved"},
 	{"COMPACT", false, "unreserved"},
+	{"COMPLETE", false, "unreserved"},
+	{"COMPLETION", false, "unreserved"},
 	{"COMPRESSED", false, "unreserved"},
 	{"COMPRESSION", false, "unreserved"},
 	{"COMPRESSION_LEVEL", false, "unreserved"},
@@ -355,12 +363,15 @@ var Keywords = []KeywordsType{
 	{"DO", false, "unreserved"},
 	{"DUPLICATE", false, "unreserved"},
 	{"DYNAMIC", false, "unreserved"},
+	{"EACH", false, "unreserved"},
+	{"EMPTY", false, "unreserved"},
 	{"ENABLE", false, "unreserved"},
 	{"ENABLED", false, "unreserved"},
 	{"ENCRYPTION", false, "unreserved"},
 	{"ENCRYPTION_KEYFILE", false, "unreserved"},
 	{"ENCRYPTION_METHOD", false, "unreserved"},
 	{"END", false, "unreserved"},
+	{"ENDS", false, "unreserved"},
 	{"ENFORCED", false, "unreserved"},
 	{"ENGINE", false, "unreserved"},
 	{"ENGINES", false, "unreserved"},
@@ -371,6 +382,7 @@ var Keywords = []KeywordsType{
 	{"ESCAPE", false, "unreserved"},
 	{"EVENT", false, "unreserved"},
 	{"EVENTS", false, "unreserved"},
+	{"EVERY", false, "unreserved"},
 	{"EVOLVE", false, "unreserved"},
 	{"EXCHANGE", false, "unreserved"},
 	{"EXCLUSIVE", false, "unreserved"},
@@ -386,11 +398,15 @@ var Keywords = []KeywordsType{
 	{"FIXED", false, "unreserved"},
 	{"FLUSH", false, "unreserved"},
 	{"FOLLOWING", false, "unreserved"},
+	{"FOLLOWS", false, "unreserved"},
 	{"FORMAT", false, "unreserved"},
 	{"FOUND", false, "unreserved"},
 	{"FULL", false, "unreserved"},
 	{"FUNCTION", false, "unreserved"},
 	{"GENERAL", false, "unreserved"},
+	{"GEOMCOLLECTION", false, "unreserved"},
+	{"GEOMETRY", false, "unreserved"},
+	{"GEOMETRYCOLLECTION", false, "unreserved"},
 	{"GLOBAL", false, "unreserved"},
 	{"GRANTS", false, "unreserved"},
 	{"HANDLER", false, "unreserved"},
@@ -405,6 +421,7 @@ var Keywords = []KeywordsType{
 	{"IGNORE_STATS", false, "unreserved"},
 	{"IMPORT", false, "unreserved"},
 	{"IMPORTS", false, "unreserved"},
+	{"INCLUDE", false, "unreserved"},
 	{"INCREMENT", false, "unreserved"},
 	{"INCREMENTAL", false, "unreserved"},
 	{"INDEXES", false, "unreserved"},
@@ -425,6 +442,7 @@ var Keywords = []KeywordsType{
 	{"LAST_BACKUP", false, "unreserved"},
 	{"LESS", false, "unreserved"},
 	{"LEVEL", false, "unreserved"},
+	{"LINESTRING", false, "unreserved"},
 	{"LIST", false, "unreserved"},
 	{"LOAD_STATS", false, "unreserved"},
 	{"LOCAL", false, "unreserved"},
@@ -432,6 +450,7 @@ var Keywords = []KeywordsType{
 	{"LOCKED", false, "unreserved"},
 	{"LOGS", false, "unreserved"},
 	{"MASTER", false, "unreserved"},
+	{"MATERIALIZED", false, "unreserved"},
 	{"MAX_CONNECTIONS_PER_HOUR", false, "unreserved"},
 	{"MAX_IDXNUM", false, "unreserved"},
 	{"MAX_MINUTES", false, "unreserved"},
@@ -450,9 +469,13 @@ var Keywords = []KeywordsType{
 	{"MODE", false, "unreserved"},
 	{"MODIFY", false, "unreserved"},
 	{"MONTH", false, "unreserved"},
+	{"MULTILINESTRING", false, "unreserved"},
+	{"MULTIPOINT", false, "unreserved"},
+	{"MULTIPOLYGON", false, "unreserved"},
 	{"NAMES", false, "unreserved"},
 	{"NATIONAL", false, "unreserved"},
 	{"NCHAR", false, "unreserved"},
+	{"NESTED", false, "unreserved"},
 	{"NEVER", false, "unreserved"},
 	{"NEXT", false, "unreserved"},
 	{"NEXTVAL", false, "unreserved"},
@@ -464,6 +487,7 @@ var Keywords = []KeywordsType{
 	{"NOMINVALUE", false, "unreserved"},
 	{"NONCLUSTERED", false, "unreserved"},
 	{"NONE", false, "unreserved"},
+	{"NOTIFY", false, "unreserved"},
 	{"NOWAIT", false, "unreserved"},
 	{"NULLS", false, "unreserved"},
 	{"NVARCHAR", false, "unreserved"},
@@ -477,6 +501,7 @@ var Keywords = []KeywordsType{
 	{"ON_DUPLICATE", false, "unreserved"},
 	{"OPEN", false, "unreserved"},
 	{"OPTIONAL", false, "unreserved"},
+	{"ORDINALITY", false, "unreserved"},
 	{"PACK_KEYS", false, "unreserved"},
 	{"PAGE", false, "unreserved"},
 	{"PARSER", false, "unreserved"},
@@ -485,6 +510,7 @@ var Keywords = []KeywordsType{
 	{"PARTITIONS", false, "unreserved"},
 	{"PASSWORD", false, "unreserved"},
 	{"PASSWORD_LOCK_TIME", false, "unreserved"},
+	{"PATH", false, "unreserved"},
 	{"PAUSE", false, "unreserved"},
 	{"PERCENT", false, "unreserved"},
 	{"PER_DB", false, "unreserved"},
@@ -492,6 +518,8 @@ var Keywords = []KeywordsType{
 	{"PLUGINS", false, "unreserved"},
 	{"POINT", false, "unreserved"},
 	{"POLICY", false, "unreserved"},
+	{"POLYGON", false, "unreserved"},
+	{"PRECEDES", false, "unreserved"},
 	{"PRECEDING", false, "unreserved"},
 	{"PREPARE", false, "unreserved"},
 	{"PRESERVE", false, "unreserved"},
@@ -512,6 +540,7 @@ var Keywords = []KeywordsType{
 	{"RECOMMEND", false, "unreserved"},
 	{"RECOVER", false, "unreserved"},
 	{"REDUNDANT", false, "unreserved"},
+	{"REFRESH", false, "unreserved"},
 	{"RELOAD", false, "unreserved"},
 	{"REMOVE", false, "unreserved"},
 	{"REORGANIZE", false, "unreserved"},
@@ -576,7 +605,9 @@ var Keywords = []KeywordsType{
 	{"SQL_TSI_SECOND", false, "unreserved"},
 	{"SQL_TSI_WEEK", false, "unreserved"},
 	{"SQL_TSI_YEAR", false, "unreserved"},
+	{"SRID", false, "unreserved"},
 	{"START", false, "unreserved"},
+	{"STARTS", false, "unreserved"},
 	{"STATS_AUTO_RECALC", false, "unreserved"},
 	{"STATS_COL_CHOICE", false, "unreserved"},
 	{"STATS_COL_LIST", false, "unreserved"},
@@ -615,6 +646,8 @@ var Keywords = []KeywordsType{
 	{"TRUNCATE", false, "unreserved"},
 	{"TSO", false, "unreserved"},
 	{"TTL", false, "unreserved"},
+	{"TTL_ACTION", false, "unreserved"},
+	{"TTL_ARCHIVE_TARGET", false, "unreserved"},
 	{"TTL_ENABLE", false, "unreserved"},
 	{"TTL_JOB_INTERVAL", false, "unreserved"},
 	{"TYPE", false, "unreserved"},
@@ -651,6 +684,7 @@ var Keywords = []KeywordsType{
 	{"COLUMN_STATS_USAGE", false, "tidb"},
 	{"CORRELATION", false, "tidb"},
 	{"DDL", false, "tidb"},
+	{"DEPENDENCIES", false, "tidb"},
 	{"DEPENDENCY", false, "tidb"},
 	{"DEPTH", false, "tidb"},
 	{"DISTRIBUTE", false, "tidb"},
@@ -660,6 +694,8 @@ var Keywords = []KeywordsType{
 	{"HISTOGRAMS_IN_FLIGHT", false, "tidb"},
 	{"JOB", false, "tidb"},
 	{"JOBS", false, "tidb"},
+	{"MCV", false, "tidb"},
+	{"NDISTINCT", false, "tidb"},
 	{"NODE_ID", false, "tidb"},
 	{"NODE_STATE", false, "tidb"},
 	{"OPTIMISTIC", false, "tidb"},
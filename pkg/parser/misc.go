@@ -179,10 +179,20 @@ var tokenMap = map[string]int{
 	"ASC":                      asc,
 	"ASCII":                    ascii,
 	"APPLY":                    apply,
+	"AT":                       at,
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
 	"BACKGROUND":               background,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometry,
+	"GEOMETRYCOLLECTION":       geometryCollection,
+	"LINESTRING":               lineString,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
+	"POLYGON":                  polygon,
+	"SRID":                     srid,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
 	"STATS_COL_CHOICE":         statsColChoice,
@@ -197,6 +207,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
 	"BDR":                      bdr,
+	"BEFORE":                   before,
 	"BEGIN":                    begin,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
@@ -236,6 +247,8 @@ var tokenMap = map[string]int{
 	"CAST":                     cast,
 	"CAUSAL":                   causal,
 	"CHAIN":                    chain,
+	"CHANGEFEED":               changefeed,
+	"CHANGEFEEDS":              changefeeds,
 	"CHANGE":                   change,
 	"CHAR":                     charType,
 	"CHARACTER":                character,
@@ -263,6 +276,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETE":                 complete,
+	"COMPLETION":               completion,
 	"COMPRESS":                 compress,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
@@ -331,6 +346,7 @@ var tokenMap = map[string]int{
 	"DELAY_KEY_WRITE":          delayKeyWrite,
 	"DELAYED":                  delayed,
 	"DELETE":                   deleteKwd,
+	"DEPENDENCIES":             dependencies,
 	"DEPENDENCY":               dependency,
 	"DEPTH":                    depth,
 	"DESC":                     desc,
@@ -358,6 +374,8 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                duplicate,
 	"DURATION":                 timeDuration,
 	"DYNAMIC":                  dynamic,
+	"EACH":                     each,
+	"EMPTY":                    empty,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
 	"ENABLE":                   enable,
@@ -365,6 +383,7 @@ var tokenMap = map[string]int{
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
@@ -377,6 +396,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -408,6 +428,7 @@ var tokenMap = map[string]int{
 	"FOLLOWERS":                followers,
 	"FOLLOWER_CONSTRAINTS":     followerConstraints,
 	"FOLLOWING":                following,
+	"FOLLOWS":                  follows,
 	"FOR":                      forKwd,
 	"FORCE":                    force,
 	"FOREIGN":                  foreign,
@@ -447,6 +468,7 @@ var tokenMap = map[string]int{
 	"IMPORT":                   importKwd,
 	"IMPORTS":                  imports,
 	"IN":                       in,
+	"INCLUDE":                  include,
 	"INCREMENT":                increment,
 	"INCREMENTAL":              incremental,
 	"INDEX":                    index,
@@ -491,6 +513,7 @@ var tokenMap = map[string]int{
 	"JOIN":                     join,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
+	"JSON_TABLE":               jsonTable,
 	"JSON":                     jsonType,
 	"KEY_BLOCK_SIZE":           keyBlockSize,
 	"KEY":                      key,
@@ -501,6 +524,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -530,6 +554,7 @@ var tokenMap = map[string]int{
 	"LONGTEXT":                 longtextType,
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
+	"MATERIALIZED":             materialized,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
@@ -541,6 +566,7 @@ var tokenMap = map[string]int{
 	"MAX":                      max,
 	"MAXVALUE":                 maxValue,
 	"MB":                       mb,
+	"MCV":                      mcv,
 	"MEDIUMBLOB":               mediumblobType,
 	"MEDIUMINT":                mediumIntType,
 	"MEDIUMTEXT":               mediumtextType,
@@ -564,6 +590,8 @@ var tokenMap = map[string]int{
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NDISTINCT":                ndistinct,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -580,6 +608,7 @@ var tokenMap = map[string]int{
 	"NONCLUSTERED":             nonclustered,
 	"NONE":                     none,
 	"NOT":                      not,
+	"NOTIFY":                   notify,
 	"NOW":                      now,
 	"NOWAIT":                   nowait,
 	"NULL":                     null,
@@ -606,6 +635,7 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
+	"ORDINALITY":               ordinality,
 	"OUT":                      out,
 	"OUTER":                    outer,
 	"OUTFILE":                  outfile,
@@ -617,6 +647,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     path,
 	"PAUSE":                    pause,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
@@ -630,6 +661,7 @@ var tokenMap = map[string]int{
 	"POLICY":                   policy,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
+	"PRECEDES":                 precedes,
 	"PRECEDING":                preceding,
 	"PREDICATE":                predicate,
 	"PRECISION":                precisionType,
@@ -662,6 +694,7 @@ var tokenMap = map[string]int{
 	"RECOVER":                  recover,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
+	"REFRESH":                  refresh,
 	"REFERENCES":               references,
 	"REGEXP":                   regexpKwd,
 	"REGION":                   region,
@@ -774,6 +807,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -869,6 +903,8 @@ var tokenMap = map[string]int{
 	"TRUE_CARD_COST":           trueCardCost,
 	"TSO":                      tsoType,
 	"TTL":                      ttl,
+	"TTL_ACTION":               ttlAction,
+	"TTL_ARCHIVE_TARGET":       ttlArchiveTarget,
 	"TTL_ENABLE":               ttlEnable,
 	"TTL_JOB_INTERVAL":         ttlJobInterval,
 	"TYPE":                     tp,
@@ -1087,6 +1123,7 @@ var hintTokenMap = map[string]int{
 	"LEADING":                 hintLeading,
 	"SEMI_JOIN_REWRITE":       hintSemiJoinRewrite,
 	"NO_DECORRELATE":          hintNoDecorrelate,
+	"RUNTIME_FILTER":          hintRuntimeFilter,
 
 	// TiDB hint aliases
 	"TIDB_HJ":   hintHashJoin,
@@ -180,11 +180,13 @@ import (
 	is                "IS"
 	iterate           "ITERATE"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -321,6 +323,7 @@ import (
 	any                   "ANY"
 	apply                 "APPLY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	autoIdCache           "AUTO_ID_CACHE"
@@ -333,6 +336,7 @@ import (
 	backup                "BACKUP"
 	backups               "BACKUPS"
 	bdr                   "BDR"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -351,6 +355,8 @@ import (
 	cascaded              "CASCADED"
 	causal                "CAUSAL"
 	chain                 "CHAIN"
+	changefeed            "CHANGEFEED"
+	changefeeds           "CHANGEFEEDS"
 	charsetKwd            "CHARSET"
 	checkpoint            "CHECKPOINT"
 	checksum              "CHECKSUM"
@@ -371,6 +377,8 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	compressionLevel      "COMPRESSION_LEVEL"
@@ -408,12 +416,15 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
+	empty                 "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	encryptionKeyFile     "ENCRYPTION_KEYFILE"
 	encryptionMethod      "ENCRYPTION_METHOD"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
@@ -424,6 +435,7 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -439,11 +451,15 @@ import (
 	fixed                 "FIXED"
 	flush                 "FLUSH"
 	following             "FOLLOWING"
+	follows               "FOLLOWS"
 	format                "FORMAT"
 	found                 "FOUND"
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometry              "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -458,6 +474,7 @@ import (
 	ignoreStats           "IGNORE_STATS"
 	importKwd             "IMPORT"
 	imports               "IMPORTS"
+	include               "INCLUDE"
 	increment             "INCREMENT"
 	incremental           "INCREMENTAL"
 	indexes               "INDEXES"
@@ -478,6 +495,7 @@ import (
 	lastBackup            "LAST_BACKUP"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	loadStats             "LOAD_STATS"
 	local                 "LOCAL"
@@ -485,6 +503,7 @@ import (
 	locked                "LOCKED"
 	logs                  "LOGS"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
@@ -503,9 +522,13 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -517,6 +540,7 @@ import (
 	nominvalue            "NOMINVALUE"
 	nonclustered          "NONCLUSTERED"
 	none                  "NONE"
+	notify                "NOTIFY"
 	nowait                "NOWAIT"
 	nulls                 "NULLS"
 	nvarcharType          "NVARCHAR"
@@ -530,6 +554,7 @@ import (
 	onDuplicate           "ON_DUPLICATE"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -538,6 +563,7 @@ import (
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
 	passwordLockTime      "PASSWORD_LOCK_TIME"
+	path                  "PATH"
 	pause                 "PAUSE"
 	percent               "PERCENT"
 	per_db                "PER_DB"
@@ -546,6 +572,8 @@ import (
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
+	precedes              "PRECEDES"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
@@ -566,6 +594,7 @@ import (
 	recommend             "RECOMMEND"
 	recover               "RECOVER"
 	redundant             "REDUNDANT"
+	refresh               "REFRESH"
 	reload                "RELOAD"
 	remove                "REMOVE"
 	reorganize            "REORGANIZE"
@@ -630,7 +659,9 @@ import (
 	sqlTsiSecond          "SQL_TSI_SECOND"
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
+	srid                  "SRID"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsColChoice        "STATS_COL_CHOICE"
 	statsColList          "STATS_COL_LIST"
@@ -669,6 +700,8 @@ import (
 	truncate              "TRUNCATE"
 	tsoType               "TSO"
 	ttl                   "TTL"
+	ttlAction             "TTL_ACTION"
+	ttlArchiveTarget      "TTL_ARCHIVE_TARGET"
 	ttlEnable             "TTL_ENABLE"
 	ttlJobInterval        "TTL_JOB_INTERVAL"
 	tp                    "TYPE"
@@ -865,6 +898,7 @@ import (
 	columnStatsUsage           "COLUMN_STATS_USAGE"
 	correlation                "CORRELATION"
 	ddl                        "DDL"
+	dependencies               "DEPENDENCIES"
 	dependency                 "DEPENDENCY"
 	depth                      "DEPTH"
 	distribute                 "DISTRIBUTE"
@@ -874,6 +908,8 @@ import (
 	histogramsInFlight         "HISTOGRAMS_IN_FLIGHT"
 	job                        "JOB"
 	jobs                       "JOBS"
+	mcv                        "MCV"
+	ndistinct                  "NDISTINCT"
 	nodeID                     "NODE_ID"
 	nodeState                  "NODE_STATE"
 	optimistic                 "OPTIMISTIC"
@@ -965,6 +1001,7 @@ import (
 	WindowFuncCall                  "WINDOW function call"
 	RepeatableOpt                   "Repeatable optional in sample clause"
 	ProcedureCall                   "Procedure call with Identifier or identifier"
+	SelectIntoVar                   "SELECT statement into variable"
 
 %type	<statement>
 	AdminStmt                  "Check table statement or show ddl statement"
@@ -975,6 +1012,7 @@ import (
 	AlterRangeStmt             "Alter data range configuration statement"
 	AlterPolicyStmt            "Alter Placement Policy statement"
 	AlterResourceGroupStmt     "Alter Resource Group statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AlterSequenceStmt          "Alter sequence statement"
 	AnalyzeTableStmt           "Analyze table statement"
 	BeginTransactionStmt       "BEGIN TRANSACTION statement"
@@ -984,6 +1022,7 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
@@ -991,6 +1030,9 @@ import (
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	CreateChangefeedStmt       "CREATE CHANGEFEED statement"
+	CreateEventStmt            "CREATE EVENT statement"
 	AddQueryWatchStmt          "ADD QUERY WATCH statement"
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
@@ -999,6 +1041,9 @@ import (
 	DropDatabaseStmt           "DROP DATABASE statement"
 	DropIndexStmt              "DROP INDEX statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	DropChangefeedStmt         "DROP CHANGEFEED statement"
+	DropEventStmt              "DROP EVENT statement"
 	DropQueryWatchStmt         "DROP QUERY WATCH statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
@@ -1008,6 +1053,7 @@ import (
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
 	DropViewStmt               "DROP VIEW statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
 	DropBindingStmt            "DROP BINDING  statement"
 	DropPolicyStmt             "DROP PLACEMENT POLICY statement"
 	DeallocateStmt             "Deallocate prepared statement"
@@ -1034,6 +1080,7 @@ import (
 	LoadDataStmt               "Load data statement"
 	LoadStatsStmt              "Load statistic statement"
 	LockStatsStmt              "Lock statistic statement"
+	NotifyStmt                 "NOTIFY statement"
 	UnlockStatsStmt            "Unlock statistic statement"
 	LockTablesStmt             "Lock tables statement"
 	NonTransactionalDMLStmt    "Non-transactional DML statement"
@@ -1048,6 +1095,7 @@ import (
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
 	RecoverTableStmt           "recover table statement"
+	RefreshMatViewStmt         "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1228,6 +1276,11 @@ import (
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
 	JoinType                               "join type"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnsClause                 "JSON_TABLE COLUMNS clause"
+	JSONTableOnEmptyOnErrorOpt             "optional JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableResponse                      "JSON_TABLE ON EMPTY or ON ERROR response"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
 	LikeTableWithOrWithoutParen            "LIKE table_name or ( LIKE table_name )"
@@ -1302,6 +1355,7 @@ import (
 	RecommendIndexOptionList               "Recommend index option list"
 	RecommendIndexOption                   "Recommend index option"
 	ReferOpt                               "reference option"
+	RefreshTypeOpt                         "Optional materialized view refresh type"
 	ReorganizePartitionRuleOpt             "optional reorganize partition partition list and definitions"
 	RequireList                            "require list for tls options"
 	RequireListElement                     "require list element for tls option"
@@ -1330,6 +1384,8 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectStmtIntoClause                   "SELECT statement non-empty into clause"
+	SelectIntoVarList                      "SELECT statement into variable list"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1351,6 +1407,7 @@ import (
 	StatementList                          "statement list"
 	StatsPersistentVal                     "stats_persistent value"
 	StatsType                              "stats type value"
+	StatsTypeList                          "stats type value list"
 	StringLitOrUserVariable                "stringLit or user variable"
 	StringLitOrUserVariableList            "stringLit or user variable list"
 	BindingStatusType                      "binding status type value"
@@ -1376,6 +1433,7 @@ import (
 	TableNameListOpt                       "Table name list opt"
 	TableOption                            "create table option"
 	TableOptionList                        "create table option list"
+	TTLConditionOpt                        "TTL condition option"
 	TableRef                               "table reference"
 	TableRefs                              "table references"
 	TableSampleOpt                         "table sample clause optional"
@@ -1433,6 +1491,18 @@ import (
 	Writeable                              "Table writeable status"
 	ElseOpt                                "Optional else clause"
 	Type                                   "Types"
+	TriggerEvent                           "Trigger event"
+	TriggerOrderOpt                        "Optional trigger order"
+	TriggerTiming                          "Trigger action time"
+	EventBodyOpt                           "Optional event body"
+	EventCommentOpt                        "Optional event comment"
+	EventCompletionOpt                     "Optional event completion"
+	EventEndsOpt                           "Optional event end time"
+	EventRenameOpt                         "Optional new event name"
+	EventSchedule                          "Event schedule"
+	EventScheduleOpt                       "Optional event schedule"
+	EventStartsOpt                         "Optional event start time"
+	EventStatusOpt                         "Optional event status"
 	OptExistingWindowName                  "Optional existing WINDOW name"
 	OptFromFirstLast                       "Optional FROM FIRST/LAST"
 	OptLLDefault                           "Optional LEAD/LAG default"
@@ -1482,6 +1552,7 @@ import (
 	OptBinary                              "Optional BINARY"
 	OptBinMod                              "Optional BINARY mode"
 	OptCharsetWithOptBinary                "Optional BINARY or ASCII or UNICODE or BYTE"
+	SpatialType                            "Spatial types"
 	OptVectorElementType                   "Optional vector element type setting"
 	IgnoreLines                            "Ignore num(int) lines"
 	Int64Num                               "a number that can be safely converted to int64"
@@ -1548,46 +1619,47 @@ import (
 	ProcedureHcondList                     "Procedure handler condition value list"
 
 %type	<ident>
-	AsOpt             "AS or EmptyString"
-	KeyOrIndex        "{KEY|INDEX}"
-	ColumnKeywordOpt  "Column keyword or empty"
-	PrimaryOpt        "Optional primary keyword"
-	NowSym            "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP"
-	NowSymFunc        "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP/NOW"
-	CurdateSym        "CURDATE or CURRENT_DATE"
-	DefaultKwdOpt     "optional DEFAULT keyword"
-	DatabaseSym       "DATABASE or SCHEMA"
-	ExplainSym        "EXPLAIN or DESCRIBE or DESC"
-	RegexpSym         "REGEXP or RLIKE"
-	IntoOpt           "INTO or EmptyString"
-	ValueSym          "Value or Values"
-	NotSym            "Not token"
-	Char              "{CHAR|CHARACTER}"
-	NChar             "{NCHAR|NATIONAL CHARACTER|NATIONAL CHAR}"
-	Varchar           "{VARCHAR|VARCHARACTER|CHARACTER VARYING|CHAR VARYING}"
-	NVarchar          "{NATIONAL VARCHAR|NATIONAL VARCHARACTER|NVARCHAR|NCHAR VARCHAR|NATIONAL CHARACTER VARYING|NATIONAL CHAR VARYING|NCHAR VARYING}"
-	Year              "{YEAR|SQL_TSI_YEAR}"
-	DeallocateSym     "Deallocate or drop"
-	OuterOpt          "optional OUTER clause"
-	CrossOpt          "Cross join option"
-	TablesTerminalSym "{TABLE|TABLES}"
-	IsolationLevel    "Isolation level"
-	ShowIndexKwd      "Show index/indexs/key keyword"
-	DistinctKwd       "DISTINCT/DISTINCTROW keyword"
-	FromOrIn          "From or In"
-	OptTable          "Optional table keyword"
-	OptInteger        "Optional Integer keyword"
-	CharsetKw         "charset or charater set"
-	logAnd            "logical and operator"
-	logOr             "logical or operator"
-	LinearOpt         "linear or empty"
-	FieldsOrColumns   "Fields or columns"
-	StorageMedia      "{DISK|MEMORY|DEFAULT}"
-	EncryptionOpt     "Encryption option 'Y' or 'N'"
-	FirstOrNext       "FIRST or NEXT"
-	RowOrRows         "ROW or ROWS"
-	Replica           "{REPLICA | SLAVE}"
-	GlobalOrLocalOpt  "GLOBAL, LOCAL or empty"
+	AsOpt               "AS or EmptyString"
+	ChangefeedFormatOpt "Optional changefeed format"
+	KeyOrIndex          "{KEY|INDEX}"
+	ColumnKeywordOpt    "Column keyword or empty"
+	PrimaryOpt          "Optional primary keyword"
+	NowSym              "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP"
+	NowSymFunc          "CURRENT_TIMESTAMP/LOCALTIME/LOCALTIMESTAMP/NOW"
+	CurdateSym          "CURDATE or CURRENT_DATE"
+	DefaultKwdOpt       "optional DEFAULT keyword"
+	DatabaseSym         "DATABASE or SCHEMA"
+	ExplainSym          "EXPLAIN or DESCRIBE or DESC"
+	RegexpSym           "REGEXP or RLIKE"
+	IntoOpt             "INTO or EmptyString"
+	ValueSym            "Value or Values"
+	NotSym              "Not token"
+	Char                "{CHAR|CHARACTER}"
+	NChar               "{NCHAR|NATIONAL CHARACTER|NATIONAL CHAR}"
+	Varchar             "{VARCHAR|VARCHARACTER|CHARACTER VARYING|CHAR VARYING}"
+	NVarchar            "{NATIONAL VARCHAR|NATIONAL VARCHARACTER|NVARCHAR|NCHAR VARCHAR|NATIONAL CHARACTER VARYING|NATIONAL CHAR VARYING|NCHAR VARYING}"
+	Year                "{YEAR|SQL_TSI_YEAR}"
+	DeallocateSym       "Deallocate or drop"
+	OuterOpt            "optional OUTER clause"
+	CrossOpt            "Cross join option"
+	TablesTerminalSym   "{TABLE|TABLES}"
+	IsolationLevel      "Isolation level"
+	ShowIndexKwd        "Show index/indexs/key keyword"
+	DistinctKwd         "DISTINCT/DISTINCTROW keyword"
+	FromOrIn            "From or In"
+	OptTable            "Optional table keyword"
+	OptInteger          "Optional Integer keyword"
+	CharsetKw           "charset or charater set"
+	logAnd              "logical and operator"
+	logOr               "logical or operator"
+	LinearOpt           "linear or empty"
+	FieldsOrColumns     "Fields or columns"
+	StorageMedia        "{DISK|MEMORY|DEFAULT}"
+	EncryptionOpt       "Encryption option 'Y' or 'N'"
+	FirstOrNext         "FIRST or NEXT"
+	RowOrRows           "ROW or ROWS"
+	Replica             "{REPLICA | SLAVE}"
+	GlobalOrLocalOpt    "GLOBAL, LOCAL or empty"
 
 %type	<ident>
 	Identifier                      "identifier or unreserved keyword"
@@ -1646,6 +1718,8 @@ import (
 %precedence set
 %precedence selectKwd
 %precedence lowerThanSelectStmt
+%precedence lowerThanInto
+%precedence into
 %precedence lowerThanInsertValues
 %precedence insertValues
 %precedence lowerThanCreateTableSelect
@@ -3658,6 +3732,10 @@ ColumnOption:
 	{
 		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionAutoIncrement}
 	}
+|	"SRID" LengthNum
+	{
+		$$ = &ast.ColumnOption{Tp: ast.ColumnOptionSRID, Expr: ast.NewValueExpr($2, "", "")}
+	}
 |	PrimaryOpt "KEY" GlobalOrLocalOpt
 	{
 		// KEY is normally a synonym for INDEX. The key attribute PRIMARY KEY
@@ -3897,6 +3975,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4209,6 +4300,28 @@ StatsType:
 	{
 		$$ = ast.StatsTypeCorrelation
 	}
+|	"NDISTINCT"
+	{
+		$$ = ast.StatsTypeCardinality
+	}
+|	"DEPENDENCIES"
+	{
+		$$ = ast.StatsTypeDependency
+	}
+|	"MCV"
+	{
+		$$ = ast.StatsTypeMCV
+	}
+
+StatsTypeList:
+	StatsType
+	{
+		$$ = []uint8{$1.(uint8)}
+	}
+|	StatsTypeList ',' StatsType
+	{
+		$$ = append($1.([]uint8), $3.(uint8))
+	}
 
 BindingStatusType:
 	"ENABLED"
@@ -4221,12 +4334,12 @@ BindingStatusType:
 	}
 
 CreateStatisticsStmt:
-	"CREATE" "STATISTICS" IfNotExists Identifier '(' StatsType ')' "ON" TableName '(' ColumnNameList ')'
+	"CREATE" "STATISTICS" IfNotExists Identifier '(' StatsTypeList ')' "ON" TableName '(' ColumnNameList ')'
 	{
 		$$ = &ast.CreateStatisticsStmt{
 			IfNotExists: $3.(bool),
 			StatsName:   $4,
-			StatsType:   $6.(uint8),
+			StatsTypes:  $6.([]uint8),
 			Table:       $9.(*ast.TableName),
 			Columns:     $11.([]*ast.ColumnName),
 		}
@@ -5158,6 +5271,61 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW IF NOT EXISTS mv (c, total)
+ *          AS SELECT c, SUM(v) FROM t GROUP BY c
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $8.(ast.StmtNode)
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists: $4.(bool),
+			ViewName:    $5.(*ast.TableName),
+			Select:      selStmt,
+		}
+		if $6 != nil {
+			x.Cols = $6.([]ast.CIStr)
+		}
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+/*******************************************************************
+ *
+ *  Refresh Materialized View Statement
+ *
+ *  Example:
+ *      REFRESH MATERIALIZED VIEW mv [COMPLETE | INCREMENTAL]
+ *******************************************************************/
+RefreshMatViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" ViewName RefreshTypeOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Type:     $5.(ast.RefreshMaterializedViewType),
+		}
+	}
+
+RefreshTypeOpt:
+	/* EMPTY */
+	{
+		$$ = ast.RefreshDefault
+	}
+|	"COMPLETE"
+	{
+		$$ = ast.RefreshComplete
+	}
+|	"INCREMENTAL"
+	{
+		$$ = ast.RefreshIncremental
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -5259,6 +5427,20 @@ DoStmt:
 		}
 	}
 
+/******************************************************************
+ * NOTIFY channel [, payload]
+ * Sends a notification to the channel when the transaction commits.
+ ******************************************************************/
+NotifyStmt:
+	"NOTIFY" Identifier
+	{
+		$$ = &ast.NotifyStmt{Channel: $2}
+	}
+|	"NOTIFY" Identifier ',' Expression
+	{
+		$$ = &ast.NotifyStmt{Channel: $2, Payload: $4}
+	}
+
 /*******************************************************************
  *
  *  Delete Statement
@@ -5420,6 +5602,12 @@ DropViewStmt:
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
 
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableNameList
+	{
+		$$ = &ast.DropMaterializedViewStmt{IfExists: $4.(bool), Views: $5.([]*ast.TableName)}
+	}
+
 DropUserStmt:
 	"DROP" "USER" UsernameList
 	{
@@ -6165,14 +6353,6 @@ Expression:
 			$$ = &ast.UnaryOperationExpr{Op: opcode.Not, V: $2}
 		}
 	}
-|	"MATCH" '(' ColumnNameList ')' "AGAINST" '(' BitExpr FulltextSearchModifierOpt ')'
-	{
-		$$ = &ast.MatchAgainst{
-			ColumnNames: $3.([]*ast.ColumnName),
-			Against:     $7,
-			Modifier:    ast.FulltextSearchModifier($8.(int)),
-		}
-	}
 |	BoolPri IsOrNotOp trueKwd %prec is
 	{
 		$$ = &ast.IsTruthExpr{Expr: $1, Not: !$2.(bool), True: int64(1)}
@@ -6670,6 +6850,10 @@ IndexOptionList:
 				opt1.Global = true
 			} else if opt2.SplitOpt != nil {
 				opt1.SplitOpt = opt2.SplitOpt
+			} else if len(opt2.IncludeColumns) > 0 {
+				opt1.IncludeColumns = opt2.IncludeColumns
+			} else if opt2.Condition != nil {
+				opt1.Condition = opt2.Condition
 			}
 			$$ = opt1
 		}
@@ -6740,6 +6924,18 @@ IndexOption:
 			},
 		}
 	}
+|	"INCLUDE" '(' ColumnNameList ')'
+	{
+		$$ = &ast.IndexOption{
+			IncludeColumns: $3.([]*ast.ColumnName),
+		}
+	}
+|	"WHERE" Expression
+	{
+		$$ = &ast.IndexOption{
+			Condition: $2.(ast.ExprNode),
+		}
+	}
 
 /*
   See: https://github.com/mysql/mysql-server/blob/8.0/sql/sql_yacc.yy#L7179
@@ -6838,6 +7034,7 @@ UnReservedKeyword:
 |	"ADVISE"
 |	"ASCII"
 |	"APPLY"
+|	"AT"
 |	"ATTRIBUTE"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
@@ -6851,6 +7048,7 @@ UnReservedKeyword:
 |	"ALWAYS"
 |	"AVG"
 |	"BDR"
+|	"BEFORE"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6862,12 +7060,16 @@ UnReservedKeyword:
 |	"CLEANUP"
 |	"CLOSE"
 |	"CHAIN"
+|	"CHANGEFEED"
+|	"CHANGEFEEDS"
 |	"CHARSET"
 |	"COLUMNS"
 |	"CONFIG"
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETE"
+|	"COMPLETION"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6880,8 +7082,11 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EACH"
+|	"EMPTY"
 |	"ENCRYPTION"
 |	"END"
+|	"ENDS"
 |	"ENFORCED"
 |	"ENGINE"
 |	"ENGINES"
@@ -6890,6 +7095,7 @@ UnReservedKeyword:
 |	"ERROR"
 |	"ERRORS"
 |	"ESCAPE"
+|	"EVERY"
 |	"EVOLVE"
 |	"EXECUTE"
 |	"EXTENDED"
@@ -6899,6 +7105,7 @@ UnReservedKeyword:
 |	"FIXED"
 |	"FLUSH"
 |	"FOLLOWING"
+|	"FOLLOWS"
 |	"FORMAT"
 |	"FULL"
 |	"GENERAL"
@@ -6923,6 +7130,7 @@ UnReservedKeyword:
 |	"REBUILD"
 |	"RECOMMEND"
 |	"REDUNDANT"
+|	"REFRESH"
 |	"REORGANIZE"
 |	"RESOURCE"
 |	"RESTART"
@@ -6936,9 +7144,19 @@ UnReservedKeyword:
 |	"SHUTDOWN"
 |	"SNAPSHOT"
 |	"START"
+|	"STARTS"
 |	"STATUS"
 |	"OPEN"
 |	"POINT"
+|	"GEOMETRY"
+|	"GEOMCOLLECTION"
+|	"GEOMETRYCOLLECTION"
+|	"LINESTRING"
+|	"MULTILINESTRING"
+|	"MULTIPOINT"
+|	"MULTIPOLYGON"
+|	"POLYGON"
+|	"SRID"
 |	"SUBPARTITIONS"
 |	"SUBPARTITION"
 |	"TABLES"
@@ -6972,6 +7190,7 @@ UnReservedKeyword:
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
 |	"MASTER"
+|	"MATERIALIZED"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
 |	"NATIONAL"
@@ -7026,6 +7245,7 @@ UnReservedKeyword:
 |	"MICROSECOND"
 |	"MINUTE"
 |	"PLUGINS"
+|	"PRECEDES"
 |	"PRECEDING"
 |	"QUERY"
 |	"QUERIES"
@@ -7060,8 +7280,10 @@ UnReservedKeyword:
 |	"ISSUER"
 |	"X509"
 |	"NEVER"
+|	"NESTED"
 |	"EXPIRE"
 |	"ACCOUNT"
+|	"INCLUDE"
 |	"INCREMENTAL"
 |	"CPU"
 |	"MEMBER"
@@ -7084,6 +7306,8 @@ UnReservedKeyword:
 |	"NODEGROUP"
 |	"SYSTEM_TIME"
 |	"PARTIAL"
+|	"ORDINALITY"
+|	"PATH"
 |	"SIMPLE"
 |	"REMOVE"
 |	"PARTITIONING"
@@ -7120,6 +7344,7 @@ UnReservedKeyword:
 |	"INVISIBLE"
 |	"VISIBLE"
 |	"TYPE"
+|	"NOTIFY"
 |	"NOWAIT"
 |	"INSTANCE"
 |	"REPLICA"
@@ -7191,6 +7416,8 @@ UnReservedKeyword:
 |	"PRESERVE"
 |	"TOKEN_ISSUER"
 |	"TTL"
+|	"TTL_ACTION"
+|	"TTL_ARCHIVE_TARGET"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
 |	"FAILED_LOGIN_ATTEMPTS"
@@ -7230,6 +7457,7 @@ TiDBKeyword:
 |	"COLUMN_STATS_USAGE"
 |	"CORRELATION"
 |	"DDL"
+|	"DEPENDENCIES"
 |	"DEPENDENCY"
 |	"DEPTH"
 |	"DISTRIBUTE"
@@ -7237,6 +7465,8 @@ TiDBKeyword:
 |	"DISTRIBUTIONS"
 |	"JOBS"
 |	"JOB"
+|	"MCV"
+|	"NDISTINCT"
 |	"NODE_ID"
 |	"NODE_STATE"
 |	"SAMPLES"
@@ -7945,6 +8175,14 @@ SimpleExpr:
 		$$ = &ast.SetCollationExpr{Expr: $1, Collate: $3}
 	}
 |	WindowFuncCall
+|	"MATCH" '(' ColumnNameList ')' "AGAINST" '(' BitExpr FulltextSearchModifierOpt ')'
+	{
+		$$ = &ast.MatchAgainst{
+			ColumnNames: $3.([]*ast.ColumnName),
+			Against:     $7,
+			Modifier:    ast.FulltextSearchModifier($8.(int)),
+		}
+	}
 |	Literal
 |	paramMarker
 	{
@@ -8185,6 +8423,13 @@ FunctionNameConflict:
 |	"MONTH"
 |	builtinNow
 |	"POINT"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
+|	"GEOMCOLLECTION"
 |	"QUARTER"
 |	"REPEAT"
 |	"REPLACE"
@@ -9395,6 +9640,30 @@ SelectStmtFromTable:
 		}
 		$$ = st
 	}
+|	SelectStmtBasic SelectStmtIntoClause "FROM" TableRefsClause WhereClauseOptional SelectStmtGroup HavingClause WindowClauseOptional
+	{
+		st := $1.(*ast.SelectStmt)
+		st.SelectIntoOpt = $2.(*ast.SelectIntoOption)
+		st.From = $4.(*ast.TableRefsClause)
+		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+		if lastField.Expr != nil && lastField.AsName.O == "" {
+			lastEnd := parser.endOffset(&yyS[yypt-6])
+			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
+		}
+		if $5 != nil {
+			st.Where = $5.(ast.ExprNode)
+		}
+		if $6 != nil {
+			st.GroupBy = $6.(*ast.GroupByClause)
+		}
+		if $7 != nil {
+			st.Having = $7.(*ast.HavingClause)
+		}
+		if $8 != nil {
+			st.WindowSpecs = ($8.([]ast.WindowSpec))
+		}
+		$$ = st
+	}
 
 TableSampleOpt:
 	%prec empty
@@ -9469,7 +9738,13 @@ RepeatableOpt:
 	}
 
 SelectStmt:
-	SelectStmtBasic WhereClauseOptional SelectStmtGroup OrderByOptional SelectStmtLimitOpt SelectLockOpt SelectStmtIntoOption
+	SelectStmtBasic SelectStmtIntoClause
+	{
+		st := $1.(*ast.SelectStmt)
+		st.SelectIntoOpt = $2.(*ast.SelectIntoOption)
+		$$ = st
+	}
+|	SelectStmtBasic WhereClauseOptional SelectStmtGroup OrderByOptional SelectStmtLimitOpt SelectLockOpt SelectStmtIntoOption
 	{
 		st := $1.(*ast.SelectStmt)
 		if $6 != nil {
@@ -9991,12 +10266,105 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(ast.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(ast.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit JSONTableColumnsClause ')' TableAsNameOpt
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $6.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $8.(ast.CIStr)}
+	}
+
+JSONTableColumnsClause:
+	"COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = $3
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: ast.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      ast.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExistsPath,
+			Name:      ast.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, Columns: $3.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" "PATH" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, Columns: $4.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	/* empty */
+	{
+		$$ = []*ast.JSONTableResponse{nil, nil}
+	}
+|	JSONTableResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableResponse{$1.(*ast.JSONTableResponse), nil}
+	}
+|	JSONTableResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableResponse{nil, $1.(*ast.JSONTableResponse)}
+	}
+|	JSONTableResponse "ON" "EMPTY" JSONTableResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableResponse{$1.(*ast.JSONTableResponse), $4.(*ast.JSONTableResponse)}
+	}
+
+JSONTableResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableResponse{Tp: ast.JSONTableResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10397,7 +10765,10 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectStmtIntoClause
+
+SelectStmtIntoClause:
+	"INTO" "OUTFILE" stringLit Fields Lines
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -10412,6 +10783,30 @@ SelectStmtIntoOption:
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:   ast.SelectIntoVars,
+			Vars: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1.(ast.ExprNode)}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3.(ast.ExprNode))
+	}
+
+SelectIntoVar:
+	UserVariable
+|	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: ast.NewCIStr($1)}}
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -11788,6 +12183,20 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "TRIGGER" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:      ast.ShowCreateTrigger,
+			Trigger: $4.(*ast.TableName),
+		}
+	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateEvent,
+			Event: $4.(*ast.TableName),
+		}
+	}
 |	"SHOW" "TABLE" TableName PartitionNameListOpt "DISTRIBUTIONS" WhereClauseOptional
 	{
 		stmt := &ast.ShowStmt{
@@ -12032,6 +12441,13 @@ ShowTargetFilterable:
 			DBName: $2,
 		}
 	}
+|	"CHANGEFEEDS" ShowDatabaseNameOpt
+	{
+		$$ = &ast.ShowStmt{
+			Tp:     ast.ShowChangefeeds,
+			DBName: $2,
+		}
+	}
 |	"BINDING_CACHE" "STATUS"
 	{
 		$$ = &ast.ShowStmt{
@@ -12346,6 +12762,7 @@ Statement:
 |	AlterInstanceStmt
 |	AlterRangeStmt
 |	AlterSequenceStmt
+|	AlterEventStmt
 |	AlterPolicyStmt
 |	AlterResourceGroupStmt
 |	AnalyzeTableStmt
@@ -12362,11 +12779,15 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
 |	CreatePolicyStmt
 |	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateChangefeedStmt
+|	CreateEventStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -12377,9 +12798,13 @@ Statement:
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropTriggerStmt
+|	DropChangefeedStmt
+|	DropEventStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropMaterializedViewStmt
 |	DropUserStmt
 |	DropResourceGroupStmt
 |	DropQueryWatchStmt
@@ -12402,6 +12827,7 @@ Statement:
 |	LoadStatsStmt
 |	LockStatsStmt
 |	UnlockStatsStmt
+|	NotifyStmt
 |	PlanReplayerStmt
 |	PreparedStmt
 |	RollbackStmt
@@ -12409,6 +12835,7 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RefreshMatViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12852,14 +13279,29 @@ TableOption:
 		// Parse it but will ignore it
 		$$ = &ast.TableOption{Tp: ast.TableOptionEncryption, StrValue: $3}
 	}
-|	"TTL" EqOpt Identifier '+' "INTERVAL" Literal TimeUnit
+|	"TTL" EqOpt Identifier '+' "INTERVAL" Literal TimeUnit TTLConditionOpt
 	{
-		$$ = &ast.TableOption{
+		opt := &ast.TableOption{
 			Tp:            ast.TableOptionTTL,
 			ColumnName:    &ast.ColumnName{Name: ast.NewCIStr($3)},
 			Value:         ast.NewValueExpr($6, parser.charset, parser.collation),
 			TimeUnitValue: &ast.TimeUnitExpr{Unit: $7.(ast.TimeUnitType)},
 		}
+		if $8 != nil {
+			opt.Condition = $8.(ast.ExprNode)
+		}
+		$$ = opt
+	}
+|	"TTL" EqOpt Identifier TTLConditionOpt
+	{
+		opt := &ast.TableOption{
+			Tp:         ast.TableOptionTTL,
+			ColumnName: &ast.ColumnName{Name: ast.NewCIStr($3)},
+		}
+		if $4 != nil {
+			opt.Condition = $4.(ast.ExprNode)
+		}
+		$$ = opt
 	}
 |	"TTL_ENABLE" EqOpt stringLit
 	{
@@ -12882,6 +13324,29 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"TTL_ACTION" EqOpt stringLit
+	{
+		action := strings.ToUpper($3)
+		if action != "DELETE" && action != "ARCHIVE" {
+			yylex.AppendError(yylex.Errorf("The TTL_ACTION option has to be set 'DELETE' or 'ARCHIVE'"))
+			return 1
+		}
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLAction, StrValue: action}
+	}
+|	"TTL_ARCHIVE_TARGET" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveTarget, StrValue: $3}
+	}
+
+TTLConditionOpt:
+	/* empty */
+	{
+		$$ = nil
+	}
+|	"WHERE" '(' Expression ')'
+	{
+		$$ = $3
+	}
 
 ForceOpt:
 	/* empty */
@@ -12997,6 +13462,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -13385,6 +13851,44 @@ StringType:
 		$$ = tp
 	}
 
+SpatialType:
+	"GEOMETRY"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeGeometry)
+	}
+|	"POINT"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypePoint)
+	}
+|	"LINESTRING"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeLineString)
+	}
+|	"POLYGON"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypePolygon)
+	}
+|	"MULTIPOINT"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeMultiPoint)
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeMultiLineString)
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeMultiPolygon)
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeGeometryCollection)
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = newSpatialFieldType(types.GeometryTypeGeometryCollection)
+	}
+
 Char:
 	"CHARACTER"
 |	"CHAR"
@@ -13780,6 +14284,7 @@ WhereClause:
 	}
 
 WhereClauseOptional:
+	%prec lowerThanInto
 	{
 		$$ = nil
 	}
@@ -16136,6 +16641,8 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
+|	NotifyStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -16648,6 +17155,310 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *  CREATE TRIGGER [IF NOT EXISTS] trigger_name trigger_time trigger_event
+ *      ON tbl_name FOR EACH ROW [trigger_order] trigger_body
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrderOpt ProcedureProcStmt
+	{
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			Trigger:     $4.(*ast.TableName),
+			Timing:      $5.(ast.TriggerTiming),
+			Event:       $6.(ast.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        $13,
+		}
+		if $12 != nil {
+			x.Order = $12.(*ast.TriggerOrder)
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		x.Body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = ast.TriggerBefore
+	}
+|	"AFTER"
+	{
+		$$ = ast.TriggerAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = ast.TriggerInsert
+	}
+|	"UPDATE"
+	{
+		$$ = ast.TriggerUpdate
+	}
+|	"DELETE"
+	{
+		$$ = ast.TriggerDelete
+	}
+
+TriggerOrderOpt:
+	{
+		$$ = nil
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.TriggerOrder{OtherTrigger: $2}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.TriggerOrder{Precedes: true, OtherTrigger: $2}
+	}
+
+/********************************************************************************************
+ *  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+ ********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists: $3.(bool),
+			Trigger:  $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *  CREATE CHANGEFEED [IF NOT EXISTS] [schema_name.]changefeed_name
+ *      FOR TABLE tbl_name INTO 'sink_uri' [FORMAT [=] format_name]
+ ********************************************************************************************/
+CreateChangefeedStmt:
+	"CREATE" "CHANGEFEED" IfNotExists TableName "FOR" "TABLE" TableName "INTO" stringLit ChangefeedFormatOpt
+	{
+		$$ = &ast.CreateChangefeedStmt{
+			IfNotExists: $3.(bool),
+			Changefeed:  $4.(*ast.TableName),
+			Table:       $7.(*ast.TableName),
+			SinkURI:     $9,
+			Format:      $10,
+		}
+	}
+
+ChangefeedFormatOpt:
+	{
+		$$ = ""
+	}
+|	"FORMAT" EqOpt stringLit
+	{
+		$$ = $3
+	}
+|	"FORMAT" EqOpt Identifier
+	{
+		$$ = $3
+	}
+|	"FORMAT" EqOpt Identifier '-' Identifier
+	{
+		$$ = $3 + "-" + $5
+	}
+
+/********************************************************************************************
+ *  DROP CHANGEFEED [IF EXISTS] [schema_name.]changefeed_name
+ ********************************************************************************************/
+DropChangefeedStmt:
+	"DROP" "CHANGEFEED" IfExists TableName
+	{
+		$$ = &ast.DropChangefeedStmt{
+			IfExists:   $3.(bool),
+			Changefeed: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *  CREATE EVENT [IF NOT EXISTS] event_name
+ *      ON SCHEDULE schedule
+ *      [ON COMPLETION [NOT] PRESERVE]
+ *      [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *      [COMMENT 'string']
+ *      DO event_body
+ *
+ *  schedule: {
+ *      AT timestamp
+ *    | EVERY interval [STARTS timestamp] [ENDS timestamp]
+ *  }
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		x := &ast.CreateEventStmt{
+			IfNotExists: $3.(bool),
+			Event:       $4.(*ast.TableName),
+			Schedule:    $7.(*ast.EventSchedule),
+			Completion:  $8.(ast.EventCompletion),
+			Status:      $9.(ast.EventStatus),
+			Body:        $12,
+		}
+		if $10 != nil {
+			comment := $10.(string)
+			x.Comment = &comment
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		x.Body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionUnspecified
+	}
+|	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusSlavesideDisable
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+ *  ALTER EVENT event_name
+ *      [ON SCHEDULE schedule]
+ *      [ON COMPLETION [NOT] PRESERVE]
+ *      [RENAME TO new_event_name]
+ *      [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *      [COMMENT 'string']
+ *      [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	"ALTER" "EVENT" TableName EventScheduleOpt EventRenameOpt EventStatusOpt EventCommentOpt EventBodyOpt
+	{
+		x := &ast.AlterEventStmt{
+			Event:  $3.(*ast.TableName),
+			Status: $6.(ast.EventStatus),
+		}
+		sched := $4.([]interface{})
+		if sched[0] != nil {
+			x.Schedule = sched[0].(*ast.EventSchedule)
+		}
+		x.Completion = sched[1].(ast.EventCompletion)
+		if $5 != nil {
+			x.NewName = $5.(*ast.TableName)
+		}
+		if $7 != nil {
+			comment := $7.(string)
+			x.Comment = &comment
+		}
+		if $8 != nil {
+			x.Body = $8.(ast.StmtNode)
+		}
+		$$ = x
+	}
+
+/* Both the schedule and the completion clauses start with "ON", so they are parsed together. */
+EventScheduleOpt:
+	EventCompletionOpt
+	{
+		$$ = []interface{}{nil, $1}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = []interface{}{$3, $4}
+	}
+
+EventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+EventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		$2.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = $2
+	}
+
+/********************************************************************************************
+ *  DROP EVENT [IF EXISTS] [schema_name.]event_name
+ ********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists: $3.(bool),
+			Event:    $4.(*ast.TableName),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -98,7 +98,7 @@ func TestSimple(t *testing.T) {
 		"following", "preceding", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
 		"chain", "error", "general", "nvarchar", "pack_keys", "p", "shard_row_id_bits", "pre_split_regions",
 		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement", "attributes", "attribute", "resource",
-		"burstable", "calibrate", "rollup",
+		"burstable", "calibrate", "rollup", "before", "each", "follows", "precedes", "at", "every", "starts", "ends", "completion", "complete", "materialized", "refresh",
 	}
 	for _, kw := range unreservedKws {
 		src := fmt.Sprintf("SELECT %s FROM tbl;", kw)
@@ -823,6 +823,17 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 
+		// select into variables
+		{"select a, b from t into @x, @y", true, "SELECT `a`,`b` FROM `t` INTO @`x`,@`y`"},
+		{"select a from t where b = 1 limit 1 into v", true, "SELECT `a` FROM `t` WHERE `b`=1 LIMIT 1 INTO `v`"},
+		{"select count(*) from t into @cnt", true, "SELECT COUNT(1) FROM `t` INTO @`cnt`"},
+		{"select 1, 2 into v1, @v2", true, "SELECT 1,2 INTO `v1`,@`v2`"},
+		{"select a from t into", false, ""},
+		{"select a, b into @x, @y from t where c = 1", true, "SELECT `a`,`b` FROM `t` WHERE `c`=1 INTO @`x`,@`y`"},
+		{"select count(*) into cnt from t", true, "SELECT COUNT(1) FROM `t` INTO `cnt`"},
+		{"select 1 into @x", true, "SELECT 1 INTO @`x`"},
+		{"select a into outfile '/tmp/a' from t", true, "SELECT `a` FROM `t` INTO OUTFILE '/tmp/a'"},
+
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
 		{"select * from t1 join t2 left join t3 on t2.id = t3.id", true, "SELECT * FROM (`t1` JOIN `t2`) LEFT JOIN `t3` ON `t2`.`id`=`t3`.`id`"},
@@ -3203,6 +3214,9 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD SPATIAL KEY `sp` (`g`)", true, "ALTER TABLE `t` ADD SPATIAL `sp`(`g`)"},
+		{"ALTER TABLE t ADD SPATIAL INDEX (`g`) COMMENT 'a'", true, "ALTER TABLE `t` ADD SPATIAL(`g`) COMMENT 'a'"},
+		{"ALTER TABLE t ADD SPATIAL `sp` (`g`) INVISIBLE", true, "ALTER TABLE `t` ADD SPATIAL `sp`(`g`) INVISIBLE"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3216,6 +3230,16 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD INDEX (a) comment 'a' PRE_SPLIT_REGIONS = (between (1, 'a') and (2, 'b') regions 4);", true, "ALTER TABLE `t` ADD INDEX(`a`) COMMENT 'a' PRE_SPLIT_REGIONS = (BETWEEN (1,_UTF8MB4'a') AND (2,_UTF8MB4'b') REGIONS 4)"},
 		{"CREATE INDEX idx ON t (a, b) pre_split_regions = 100", true, "CREATE INDEX `idx` ON `t` (`a`, `b`) PRE_SPLIT_REGIONS = 100"},
 		{"CREATE INDEX idx ON t (a, b) PRE_SPLIT_REGIONS = (between (1, 'a') and (2, 'b') regions 4);", true, "CREATE INDEX `idx` ON `t` (`a`, `b`) PRE_SPLIT_REGIONS = (BETWEEN (1,_UTF8MB4'a') AND (2,_UTF8MB4'b') REGIONS 4)"},
+		{"CREATE INDEX idx ON t (c) WHERE status = 'open'", true, "CREATE INDEX `idx` ON `t` (`c`) WHERE `status`=_UTF8MB4'open'"},
+		{"CREATE UNIQUE INDEX idx ON t (c) COMMENT 'x' WHERE a > 1 AND b IS NULL ALGORITHM = INPLACE", true, "CREATE UNIQUE INDEX `idx` ON `t` (`c`) COMMENT 'x' WHERE `a`>1 AND `b` IS NULL ALGORITHM = INPLACE"},
+		{"ALTER TABLE t ADD INDEX idx(a) WHERE b = 1, ADD INDEX idx2(b)", true, "ALTER TABLE `t` ADD INDEX `idx`(`a`) WHERE `b`=1, ADD INDEX `idx2`(`b`)"},
+		{"CREATE TABLE t (a INT, b INT, INDEX idx(a) WHERE b IN (1, 2), KEY (b))", true, "CREATE TABLE `t` (`a` INT,`b` INT,INDEX `idx`(`a`) WHERE `b` IN (1,2),INDEX(`b`))"},
+		{"CREATE INDEX idx ON t (c) WHERE", false, ""},
+		{"CREATE INDEX idx ON t (a) INCLUDE (b, c)", true, "CREATE INDEX `idx` ON `t` (`a`) INCLUDE (`b`, `c`)"},
+		{"CREATE UNIQUE INDEX idx ON t (a) INCLUDE (b) COMMENT 'x' WHERE b > 0", true, "CREATE UNIQUE INDEX `idx` ON `t` (`a`) COMMENT 'x' INCLUDE (`b`) WHERE `b`>0"},
+		{"ALTER TABLE t ADD INDEX idx(a) INCLUDE (b), ADD KEY (include)", true, "ALTER TABLE `t` ADD INDEX `idx`(`a`) INCLUDE (`b`), ADD INDEX(`include`)"},
+		{"CREATE TABLE t (a INT, b INT, INDEX idx(a) INCLUDE (b))", true, "CREATE TABLE `t` (`a` INT,`b` INT,INDEX `idx`(`a`) INCLUDE (`b`))"},
+		{"CREATE INDEX idx ON t (a) INCLUDE ()", false, ""},
 		{"ALTER TABLE t ADD INDEX idx(a) pre_split_regions = 100, ADD INDEX idx2(b) pre_split_regions = (by(1),(2),(3))", true, "ALTER TABLE `t` ADD INDEX `idx`(`a`) PRE_SPLIT_REGIONS = 100, ADD INDEX `idx2`(`b`) PRE_SPLIT_REGIONS = (BY (1),(2),(3))"},
 		{"ALTER TABLE t ADD KEY (a) USING HASH COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING HASH COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE /*T![global_index] GLOBAL */ COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a' GLOBAL"},
@@ -3404,6 +3428,8 @@ func TestDDL(t *testing.T) {
 		{"CREATE UNIQUE INDEX ident USING BTREE ON d_n.t_n ( ident , ident ASC )", true, "CREATE UNIQUE INDEX `ident` ON `d_n`.`t_n` (`ident`, `ident`) USING BTREE"},
 		{"CREATE SPATIAL INDEX idx ON t (a)", true, "CREATE SPATIAL INDEX `idx` ON `t` (`a`)"},
 		{"CREATE SPATIAL INDEX IF NOT EXISTS idx ON t (a)", true, "CREATE SPATIAL INDEX IF NOT EXISTS `idx` ON `t` (`a`)"},
+		{"CREATE TABLE t (g GEOMETRY NOT NULL, SPATIAL KEY sp (g))", true, "CREATE TABLE `t` (`g` GEOMETRY NOT NULL,SPATIAL `sp`(`g`))"},
+		{"CREATE TABLE t (g POINT NOT NULL SRID 4326, SPATIAL INDEX (g) COMMENT 'a')", true, "CREATE TABLE `t` (`g` POINT NOT NULL SRID 4326,SPATIAL(`g`) COMMENT 'a')"},
 		{"CREATE FULLTEXT INDEX idx ON t (a)", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`)"},
 		{"CREATE FULLTEXT INDEX IF NOT EXISTS idx ON t (a)", true, "CREATE FULLTEXT INDEX IF NOT EXISTS `idx` ON `t` (`a`)"},
 		{"CREATE FULLTEXT INDEX idx ON t (a) WITH PARSER ident", true, "CREATE FULLTEXT INDEX `idx` ON `t` (`a`) WITH PARSER `ident`"},
@@ -4878,6 +4904,16 @@ func TestOptimizerHints(t *testing.T) {
 	require.Equal(t, "straight_join", hints[0].HintName.L)
 	require.Equal(t, "straight_join", hints[1].HintName.L)
 
+	// Test RUNTIME_FILTER
+	stmt, _, err = p.Parse("select /*+ RUNTIME_FILTER(), runtime_filter() */ c1, c2 from t1, t2 where t1.c1 = t2.c1", "", "")
+	require.NoError(t, err)
+	selectStmt = stmt[0].(*ast.SelectStmt)
+
+	hints = selectStmt.TableHints
+	require.Len(t, hints, 2)
+	require.Equal(t, "runtime_filter", hints[0].HintName.L)
+	require.Equal(t, "runtime_filter", hints[1].HintName.L)
+
 	// Test LEADING
 	stmt, _, err = p.Parse("select /*+ LEADING(T1), LEADING(t2, t3), LEADING(T4, t5, t6) */ c1, c2 from t1, t2 where t1.c1 = t2.c1", "", "")
 	require.NoError(t, err)
@@ -5955,6 +5991,123 @@ CREATE VIEW v2 AS SELECT 123123123123123;
 	require.Equal(t, nodes[1].(*ast.CreateViewStmt).Select.Text(), "SELECT 123123123123123")
 }
 
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger tr before insert on t for each row set new.a = new.a + 1", true, "CREATE TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=`new`.`a`+1"},
+		{"create trigger if not exists db.tr after update on db.t for each row insert into log values (old.a, new.a)", true, "CREATE TRIGGER IF NOT EXISTS `db`.`tr` AFTER UPDATE ON `db`.`t` FOR EACH ROW INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`)"},
+		{"create trigger tr after delete on t for each row follows tr0 delete from t2 where id = old.id", true, "CREATE TRIGGER `tr` AFTER DELETE ON `t` FOR EACH ROW FOLLOWS `tr0` DELETE FROM `t2` WHERE `id`=`old`.`id`"},
+		{"create trigger tr before update on t for each row precedes tr0 set new.b = 1", true, "CREATE TRIGGER `tr` BEFORE UPDATE ON `t` FOR EACH ROW PRECEDES `tr0` SET @@SESSION.`new.b`=1"},
+		{"create trigger tr before select on t for each row set new.a = 1", false, ""},
+		{"create trigger tr before insert on t set new.a = 1", false, ""},
+		{"drop trigger tr", true, "DROP TRIGGER `tr`"},
+		{"drop trigger if exists db.tr", true, "DROP TRIGGER IF EXISTS `db`.`tr`"},
+		{"show create trigger db.tr", true, "SHOW CREATE TRIGGER `db`.`tr`"},
+		{"show triggers from db like 't%'", true, "SHOW TRIGGERS IN `db` LIKE _UTF8MB4't%'"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("CREATE TRIGGER tr BEFORE INSERT ON t FOR EACH ROW BEGIN SET NEW.a = 1; END", "", "")
+	require.NoError(t, err)
+	trigger := stmt.(*ast.CreateTriggerStmt)
+	require.Equal(t, ast.TriggerBefore, trigger.Timing)
+	require.Equal(t, ast.TriggerInsert, trigger.Event)
+	require.Equal(t, "t", trigger.Table.Name.O)
+	require.Equal(t, "BEGIN SET NEW.a = 1; END", trigger.Body.Text())
+	_, ok := trigger.Body.(*ast.ProcedureBlock)
+	require.True(t, ok)
+}
+
+func TestChangefeed(t *testing.T) {
+	table := []testCase{
+		{"create changefeed cf for table t into 'file:///tmp/cf'", true, "CREATE CHANGEFEED `cf` FOR TABLE `t` INTO 'file:///tmp/cf'"},
+		{"create changefeed if not exists db.cf for table db.t into 's3://bucket/cf' format 'canal-json'", true, "CREATE CHANGEFEED IF NOT EXISTS `db`.`cf` FOR TABLE `db`.`t` INTO 's3://bucket/cf' FORMAT 'canal-json'"},
+		{"create changefeed cf for table t into 'file:///tmp/cf' format = avro", true, "CREATE CHANGEFEED `cf` FOR TABLE `t` INTO 'file:///tmp/cf' FORMAT 'avro'"},
+		{"create changefeed cf for table t into 'file:///tmp/cf' format canal-json", true, "CREATE CHANGEFEED `cf` FOR TABLE `t` INTO 'file:///tmp/cf' FORMAT 'canal-json'"},
+		{"create changefeed cf for table t", false, ""},
+		{"create changefeed cf for table t into 'file:///tmp/cf' format", false, ""},
+		{"drop changefeed cf", true, "DROP CHANGEFEED `cf`"},
+		{"drop changefeed if exists db.cf", true, "DROP CHANGEFEED IF EXISTS `db`.`cf`"},
+		{"show changefeeds", true, "SHOW CHANGEFEEDS"},
+		{"show changefeeds from db like 'c%'", true, "SHOW CHANGEFEEDS IN `db` LIKE _UTF8MB4'c%'"},
+		{"create table changefeed (changefeeds int)", true, "CREATE TABLE `changefeed` (`changefeeds` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestNotify(t *testing.T) {
+	table := []testCase{
+		{"notify low_stock", true, "NOTIFY `low_stock`"},
+		{"notify `low stock`, 'p1'", true, "NOTIFY `low stock`, _UTF8MB4'p1'"},
+		{"notify ch, concat('id=', @id)", true, "NOTIFY `ch`, CONCAT(_UTF8MB4'id=', @`id`)"},
+		{"notify notify", true, "NOTIFY `notify`"},
+		{"CREATE PROCEDURE `p`() BEGIN NOTIFY `ch`, 1; END", true, "CREATE PROCEDURE `p`() BEGIN NOTIFY `ch`, 1; END"},
+		{"notify", false, ""},
+		{"notify 'ch'", false, ""},
+		{"notify ch,", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists db.mv (a, cnt) as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `db`.`mv` (`a`,`cnt`) AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view mv as with cte as (select 1) select * from cte", true, "CREATE MATERIALIZED VIEW `mv` AS WITH `cte` AS (SELECT 1) SELECT * FROM `cte`"},
+		{"create or replace materialized view mv as select 1", false, ""},
+		{"create materialized view mv", false, ""},
+		{"drop materialized view mv", true, "DROP MATERIALIZED VIEW `mv`"},
+		{"drop materialized view if exists db.mv1, mv2", true, "DROP MATERIALIZED VIEW IF EXISTS `db`.`mv1`, `mv2`"},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view db.mv complete", true, "REFRESH MATERIALIZED VIEW `db`.`mv` COMPLETE"},
+		{"refresh materialized view mv incremental", true, "REFRESH MATERIALIZED VIEW `mv` INCREMENTAL"},
+		{"refresh materialized view mv fast", false, ""},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("CREATE MATERIALIZED VIEW mv AS SELECT a, SUM(b) FROM t GROUP BY a", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "SELECT a, SUM(b) FROM t GROUP BY a", stmt.(*ast.CreateMaterializedViewStmt).Select.Text())
+}
+
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event ev on schedule every 1 hour do delete from t where a < now()", true, "CREATE EVENT `ev` ON SCHEDULE EVERY 1 HOUR DO DELETE FROM `t` WHERE `a`<NOW()"},
+		{"create event if not exists db.ev on schedule at '2026-01-01 00:00:00' + interval 1 day on completion preserve disable comment 'once' do insert into t values (1)", true, "CREATE EVENT IF NOT EXISTS `db`.`ev` ON SCHEDULE AT DATE_ADD(_UTF8MB4'2026-01-01 00:00:00', INTERVAL 1 DAY) ON COMPLETION PRESERVE DISABLE COMMENT 'once' DO INSERT INTO `t` VALUES (1)"},
+		{"create event ev on schedule every 10 minute starts now() ends now() + interval 1 week on completion not preserve enable do call p()", true, "CREATE EVENT `ev` ON SCHEDULE EVERY 10 MINUTE STARTS NOW() ENDS DATE_ADD(NOW(), INTERVAL 1 WEEK) ON COMPLETION NOT PRESERVE ENABLE DO CALL `p`()"},
+		{"create event ev on schedule every 1 day disable on slave do select 1", true, "CREATE EVENT `ev` ON SCHEDULE EVERY 1 DAY DISABLE ON SLAVE DO SELECT 1"},
+		{"create event ev on schedule every 1 day", false, ""},
+		{"create event ev on schedule at now() starts now() do select 1", false, ""},
+		{"alter event ev disable", true, "ALTER EVENT `ev` DISABLE"},
+		{"alter event db.ev on schedule every 2 hour on completion preserve rename to db.ev2 enable comment 'x' do delete from t", true, "ALTER EVENT `db`.`ev` ON SCHEDULE EVERY 2 HOUR ON COMPLETION PRESERVE RENAME TO `db`.`ev2` ENABLE COMMENT 'x' DO DELETE FROM `t`"},
+		{"alter event ev on completion not preserve", true, "ALTER EVENT `ev` ON COMPLETION NOT PRESERVE"},
+		{"drop event ev", true, "DROP EVENT `ev`"},
+		{"drop event if exists db.ev", true, "DROP EVENT IF EXISTS `db`.`ev`"},
+		{"show create event db.ev", true, "SHOW CREATE EVENT `db`.`ev`"},
+		{"show events from db like 'e%'", true, "SHOW EVENTS IN `db` LIKE _UTF8MB4'e%'"},
+		// AT is an unreserved keyword, it's still valid as an identifier.
+		{"CREATE TABLE t (at INT)", true, "CREATE TABLE `t` (`at` INT)"},
+		{"create table at (a int)", true, "CREATE TABLE `at` (`a` INT)"},
+		{"select at from at where at > 1", true, "SELECT `at` FROM `at` WHERE `at`>1"},
+		{"create event at on schedule at now() do update at set at = at + 1", true, "CREATE EVENT `at` ON SCHEDULE AT NOW() DO UPDATE `at` SET `at`=`at`+1"},
+	}
+	RunTest(t, table, false)
+
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("CREATE EVENT ev ON SCHEDULE EVERY 1 DAY DO BEGIN DELETE FROM t; END", "", "")
+	require.NoError(t, err)
+	event := stmt.(*ast.CreateEventStmt)
+	require.Equal(t, ast.TimeUnitDay, event.Schedule.Unit)
+	require.Equal(t, "BEGIN DELETE FROM t; END", event.Body.Text())
+	_, ok := event.Body.(*ast.ProcedureBlock)
+	require.True(t, ok)
+
+	stmt, err = p.ParseOneStmt("ALTER EVENT ev DO INSERT INTO t VALUES (1)", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO t VALUES (1)", stmt.(*ast.AlterEventStmt).Body.Text())
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	// Test case for timestampdiff unit.
 	// TimeUnit should be unified to upper case.
@@ -6197,6 +6350,52 @@ func TestAnalyze(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		{`select * from json_table('[{"a": 1}]', '$[*]' columns (a int path '$.a')) as jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[{\"a\": 1}]', '$[*]' COLUMNS (`a` INT PATH '$.a')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' default '0' on empty error on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '0' ON EMPTY ERROR ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' null on empty, b json path '$.b' null on error)) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY, `b` JSON PATH '$.b' NULL ON ERROR)) AS `jt`"},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'), nested '$.c' columns (c int path '$'))) jt`, true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'), NESTED PATH '$.c' COLUMNS (`c` INT PATH '$'))) AS `jt`"},
+		{`select t.id, jt.* from t, json_table(t.doc, '$.items[*]' columns (sku varchar(20) path '$.sku')) as jt`, true, "SELECT `t`.`id`,`jt`.* FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$.items[*]' COLUMNS (`sku` VARCHAR(20) PATH '$.sku')) AS `jt`"},
+		{`select * from t left join json_table(t.doc, '$[*]' columns (nested int path '$.path', path int path '$.nested')) jt on true`, true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`nested` INT PATH '$.path', `path` INT PATH '$.nested')) AS `jt` ON TRUE"},
+		{"create table nested (path int, ordinality int, empty int)", true, "CREATE TABLE `nested` (`path` INT,`ordinality` INT,`empty` INT)"},
+
+		{`select * from json_table('[]', '$[*]') jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns ()) jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error null on empty)) jt`, false, ""},
+		{`select * from json_table('[]', '$[*]' columns (a int exists path '$.a' null on empty)) jt`, false, ""},
+		{"create table json_table (a int)", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateral(t *testing.T) {
+	table := []testCase{
+		{"select * from t, lateral (select * from t1 where t1.a = t.a) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt`"},
+		{"select * from t left join lateral (select max(b) m from t1 where t1.a = t.a) dt on true", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT MAX(`b`) AS `m` FROM `t1` WHERE `t1`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{"select * from t join lateral (select 1 union select t.a) dt", true, "SELECT * FROM `t` JOIN LATERAL (SELECT 1 UNION SELECT `t`.`a`) AS `dt`"},
+
+		{"select * from t, lateral t1", false, ""},
+		{"create table lateral (a int)", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestSpatialType(t *testing.T) {
+	table := []testCase{
+		{"create table t (g geometry, p point srid 4326 not null, l linestring, pg polygon)", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT SRID 4326 NOT NULL,`l` LINESTRING,`pg` POLYGON)"},
+		{"create table t (a multipoint, b multilinestring, c multipolygon, d geometrycollection, e geomcollection)", true, "CREATE TABLE `t` (`a` MULTIPOINT,`b` MULTILINESTRING,`c` MULTIPOLYGON,`d` GEOMCOLLECTION,`e` GEOMCOLLECTION)"},
+		{"alter table t add column g geometry not null srid 0", true, "ALTER TABLE `t` ADD COLUMN `g` GEOMETRY NOT NULL SRID 0"},
+		{"select st_astext(point(1, 2)), polygon(linestring(point(0, 0), point(1, 1), point(0, 0)))", true, "SELECT ST_ASTEXT(POINT(1, 2)),POLYGON(LINESTRING(POINT(0, 0), POINT(1, 1), POINT(0, 0)))"},
+		{"create table geometry (point int, srid int)", true, "CREATE TABLE `geometry` (`point` INT,`srid` INT)"},
+
+		{"create table t (p point srid)", false, ""},
+		{"create table t (p point srid -1)", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTableSample(t *testing.T) {
 	table := []testCase{
 		// positive test cases
@@ -6917,6 +7116,13 @@ func TestFulltextSearch(t *testing.T) {
 	writer.Reset()
 	st.(*ast.SelectStmt).Where.Format(writer)
 	require.Equal(t, "MATCH(title,content) AGAINST(\"search\" WITH QUERY EXPANSION)", writer.String())
+
+	st, err = p.ParseOneStmt("SELECT MATCH(content) AGAINST('search') + 1 FROM fulltext_test WHERE MATCH(content) AGAINST('search') > 0.5 ORDER BY MATCH(content) AGAINST('search') DESC", "", "")
+	require.NoError(t, err)
+	require.NotNil(t, st.(*ast.SelectStmt))
+	writer.Reset()
+	st.(*ast.SelectStmt).Where.Format(writer)
+	require.Equal(t, "MATCH(content) AGAINST(\"search\") > 0.5", writer.String())
 }
 
 func TestStartTransaction(t *testing.T) {
@@ -7014,6 +7220,14 @@ func (checker *nodeTextCleaner) Enter(in ast.Node) (out ast.Node, skipChildren b
 				}
 			}
 		}
+	case *ast.CreateTriggerStmt:
+		node.Body.Accept(checker)
+	case *ast.CreateEventStmt:
+		node.Body.Accept(checker)
+	case *ast.AlterEventStmt:
+		if node.Body != nil {
+			node.Body.Accept(checker)
+		}
 	case *ast.DeleteStmt:
 		for _, tableHint := range node.TableHints {
 			tableHint.HintName.O = ""
@@ -7146,6 +7360,9 @@ func TestStatisticsOps(t *testing.T) {
 		{"create statistics if not exists stats3 (correlation) on t(a,b)", true, "CREATE STATISTICS IF NOT EXISTS `stats3` (CORRELATION) ON `t`(`a`, `b`)"},
 		{"create statistics if not exists stats3 on t(a,b)", false, ""},
 		{"create statistics stats1(cardinality) on t(a,b,c)", true, "CREATE STATISTICS `stats1` (CARDINALITY) ON `t`(`a`, `b`, `c`)"},
+		{"create statistics stats4 (dependencies, ndistinct, mcv) on t(a,b,c)", true, "CREATE STATISTICS `stats4` (DEPENDENCY, CARDINALITY, MCV) ON `t`(`a`, `b`, `c`)"},
+		{"create statistics stats4 (mcv) on t(a,b)", true, "CREATE STATISTICS `stats4` (MCV) ON `t`(`a`, `b`)"},
+		{"create statistics stats4 () on t(a,b)", false, ""},
 		{"drop statistics stats1", true, "DROP STATISTICS `stats1`"},
 	}
 	RunTest(t, table, false)
@@ -7157,7 +7374,7 @@ func TestStatisticsOps(t *testing.T) {
 	require.True(t, ok)
 	require.True(t, v.IfNotExists)
 	require.Equal(t, "stats1", v.StatsName)
-	require.Equal(t, ast.StatsTypeCardinality, v.StatsType)
+	require.Equal(t, []uint8{ast.StatsTypeCardinality}, v.StatsTypes)
 	require.Equal(t, ast.CIStr{O: "t", L: "t"}, v.Table.Name)
 	require.Len(t, v.Columns, 3)
 	require.Equal(t, ast.CIStr{O: "a", L: "a"}, v.Columns[0].Name)
@@ -7770,6 +7987,15 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL created_at + INTERVAL 1 YEAR TTL_ENABLE 'OFF' TTL_JOB_INTERVAL='8h'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'OFF' TTL_JOB_INTERVAL = '8h'"},
 		{"create table t (created_at datetime) /*T![ttl] ttl=created_at + INTERVAL 1 YEAR ttl_enable='ON'*/", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ENABLE = 'ON'"},
 
+		// per-row expiry column and condition
+		{"create table t (expire_at datetime) TTL = expire_at", true, "CREATE TABLE `t` (`expire_at` DATETIME) TTL = `expire_at`"},
+		{"create table t (expire_at datetime, status varchar(10)) TTL = expire_at WHERE (status = 'closed')", true, "CREATE TABLE `t` (`expire_at` DATETIME,`status` VARCHAR(10)) TTL = `expire_at` WHERE (`status`=_UTF8MB4'closed')"},
+		{"create table t (created_at datetime, status int) TTL = created_at + INTERVAL 1 DAY WHERE (status in (1, 2)) TTL_ENABLE = 'OFF'", true, "CREATE TABLE `t` (`created_at` DATETIME,`status` INT) TTL = `created_at` + INTERVAL 1 DAY WHERE (`status` IN (1,2)) TTL_ENABLE = 'OFF'"},
+		{"create table t (created_at datetime, status int) TTL = created_at + INTERVAL 1 DAY WHERE status = 1", false, ""},
+		{"alter table t TTL = expire_at", true, "ALTER TABLE `t` TTL = `expire_at`"},
+		{"alter table t TTL = created_at + INTERVAL 1 MONTH WHERE (status = 'closed' and a > 1)", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 MONTH WHERE (`status`=_UTF8MB4'closed' AND `a`>1)"},
+		{"alter table t /*T![ttl] ttl=expire_at WHERE (status = 1) ttl_enable='ON'*/", true, "ALTER TABLE `t` TTL = `expire_at` WHERE (`status`=1) TTL_ENABLE = 'ON'"},
+
 		// alter table with various temporal interval
 		{"alter table t TTL = created_at + INTERVAL 1 MONTH", true, "ALTER TABLE `t` TTL = `created_at` + INTERVAL 1 MONTH"},
 		{"alter table t TTL_ENABLE = 'ON'", true, "ALTER TABLE `t` TTL_ENABLE = 'ON'"},
@@ -7792,6 +8018,12 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '@monthly'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10hourxx'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10.10.255h'", false, ""},
+
+		// TTL_ACTION and TTL_ARCHIVE_TARGET settings
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 7 YEAR TTL_ACTION = 'archive' TTL_ARCHIVE_TARGET = 'db.t_history'", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 7 YEAR TTL_ACTION = 'ARCHIVE' TTL_ARCHIVE_TARGET = 'db.t_history'"},
+		{"alter table t /*T![ttl] TTL_ACTION='DELETE' */", true, "ALTER TABLE `t` TTL_ACTION = 'DELETE'"},
+		{"alter table t TTL_ARCHIVE_TARGET 's3://bucket/prefix?format=parquet'", true, "ALTER TABLE `t` TTL_ARCHIVE_TARGET = 's3://bucket/prefix?format=parquet'"},
+		{"create table t (created_at datetime) TTL_ACTION = 'move'", false, ""},
 	}
 
 	RunTest(t, table, false)
@@ -45,6 +45,41 @@ func IsTypeVector(tp byte) bool {
 	return tp == mysql.TypeTiDBVectorFloat32
 }
 
+// GeometryType is the subtype of the GEOMETRY type, the values are the same as
+// the geometry types of WKB.
+type GeometryType byte
+
+// Geometry subtypes.
+const (
+	GeometryTypeGeometry GeometryType = iota
+	GeometryTypePoint
+	GeometryTypeLineString
+	GeometryTypePolygon
+	GeometryTypeMultiPoint
+	GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection
+)
+
+var geometryType2Str = [...]string{
+	GeometryTypeGeometry:           "geometry",
+	GeometryTypePoint:              "point",
+	GeometryTypeLineString:         "linestring",
+	GeometryTypePolygon:            "polygon",
+	GeometryTypeMultiPoint:         "multipoint",
+	GeometryTypeMultiLineString:    "multilinestring",
+	GeometryTypeMultiPolygon:       "multipolygon",
+	GeometryTypeGeometryCollection: "geomcollection",
+}
+
+// String implements the fmt.Stringer interface.
+func (tp GeometryType) String() string {
+	if int(tp) < len(geometryType2Str) {
+		return geometryType2Str[tp]
+	}
+	return geometryType2Str[GeometryTypeGeometry]
+}
+
 var type2Str = map[byte]string{
 	mysql.TypeBit:               "bit",
 	mysql.TypeBlob:              "text",
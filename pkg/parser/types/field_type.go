@@ -72,6 +72,12 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of the GEOMETRY type, e.g. POINT.
+	geometryType GeometryType
+	// srid is the spatial reference system identifier of the GEOMETRY type,
+	// it's only meaningful when hasSRID is true.
+	srid    uint32
+	hasSRID bool
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -92,6 +98,9 @@ func (ft *FieldType) Hash64(h IHasher) {
 		h.HashBool(elem)
 	}
 	h.HashBool(ft.array)
+	h.HashByte(byte(ft.geometryType))
+	h.HashUint64(uint64(ft.srid))
+	h.HashBool(ft.hasSRID)
 }
 
 // Equals implements the cascades/base.Hasher.<1th> interface.
@@ -112,7 +121,10 @@ func (ft *FieldType) Equals(other any) bool {
 		ft.decimal == ft2.decimal &&
 		ft.charset == ft2.charset &&
 		ft.collate == ft2.collate &&
-		ft.array == ft2.array
+		ft.array == ft2.array &&
+		ft.geometryType == ft2.geometryType &&
+		ft.srid == ft2.srid &&
+		ft.hasSRID == ft2.hasSRID
 	if !ok {
 		return false
 	}
@@ -298,6 +310,34 @@ func (ft *FieldType) SetElem(idx int, element string) {
 	ft.elems[idx] = element
 }
 
+// GetGeometryType returns the subtype of the GEOMETRY type.
+func (ft *FieldType) GetGeometryType() GeometryType {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the subtype of the GEOMETRY type.
+func (ft *FieldType) SetGeometryType(tp GeometryType) {
+	ft.geometryType = tp
+}
+
+// GetSRID returns the spatial reference system identifier of the GEOMETRY
+// type, the second return value is false if the SRID isn't specified.
+func (ft *FieldType) GetSRID() (uint32, bool) {
+	return ft.srid, ft.hasSRID
+}
+
+// SetSRID sets the spatial reference system identifier of the GEOMETRY type.
+func (ft *FieldType) SetSRID(srid uint32) {
+	ft.srid = srid
+	ft.hasSRID = true
+}
+
+// ClearSRID removes the spatial reference system identifier of the GEOMETRY type.
+func (ft *FieldType) ClearSRID() {
+	ft.srid = 0
+	ft.hasSRID = false
+}
+
 // SetArray sets the array field of the FieldType.
 func (ft *FieldType) SetArray(array bool) {
 	ft.array = array
@@ -371,7 +411,8 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.geometryType == other.geometryType
 	if !partialEqual {
 		return false
 	}
@@ -441,7 +482,7 @@ func (ft *FieldType) Init(tp byte) {
 // CompactStr only considers tp/CharsetBin/flen/Deimal.
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
-	ts := TypeToStr(ft.GetType(), ft.charset)
+	ts := ft.typeStr()
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -509,6 +550,14 @@ func (ft *FieldType) CompactStr() string {
 	return ts + suffix
 }
 
+// typeStr returns the type name, the GEOMETRY type is named by its subtype.
+func (ft *FieldType) typeStr() string {
+	if ft.GetType() == mysql.TypeGeometry {
+		return ft.geometryType.String()
+	}
+	return TypeToStr(ft.GetType(), ft.charset)
+}
+
 // InfoSchemaStr joins the CompactStr with unsigned flag and
 // returns a string.
 func (ft *FieldType) InfoSchemaStr() string {
@@ -549,7 +598,7 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
+	ctx.WriteKeyWord(ft.typeStr())
 
 	precision := UnspecifiedLength
 	scale := UnspecifiedLength
@@ -722,6 +771,8 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     GeometryType `json:",omitempty"`
+	SRID             *uint32      `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -738,6 +789,11 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
+		ft.srid, ft.hasSRID = 0, r.SRID != nil
+		if r.SRID != nil {
+			ft.srid = *r.SRID
+		}
 	}
 	return err
 }
@@ -754,6 +810,11 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
+	if ft.hasSRID {
+		srid := ft.srid
+		r.SRID = &srid
+	}
 	return json.Marshal(r)
 }
 
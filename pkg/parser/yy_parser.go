@@ -407,6 +407,17 @@ func convertToPriv(roleOrPrivList []*ast.RoleOrPriv) ([]*ast.PrivElem, error) {
 	return privileges, nil
 }
 
+// newSpatialFieldType returns the field type of the spatial type, the values
+// are stored in binary.
+func newSpatialFieldType(tp types.GeometryType) *types.FieldType {
+	ft := types.NewFieldType(mysql.TypeGeometry)
+	ft.SetGeometryType(tp)
+	ft.SetCharset(charset.CharsetBin)
+	ft.SetCollate(charset.CollationBin)
+	ft.AddFlag(mysql.BinaryFlag)
+	return ft
+}
+
 var (
 	_ ParseParam = CharsetConnection("")
 	_ ParseParam = CollationConnection("")
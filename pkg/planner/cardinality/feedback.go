@@ -0,0 +1,86 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/planctx"
+)
+
+// FeedbackStore provides the cardinality feedback learned from the executed statements.
+type FeedbackStore interface {
+	// GetCorrection returns the ratio of the actual rows to the estimated rows learned for the predicate of the
+	// fingerprint on the table.
+	GetCorrection(tableID int64, fingerprint string) (float64, bool)
+}
+
+// FeedbackInfo is the cardinality feedback of the filters on a DataSource. It's used to apply the learned correction
+// to the estimation, and to capture the actual rows of the filters after the statement is executed.
+type FeedbackInfo struct {
+	TableID     int64
+	Fingerprint string
+	// Predicate is the text of the filters, which is kept for the readability of the learned feedback.
+	Predicate string
+	// EstRows is the estimated rows of the filters before the correction is applied.
+	EstRows float64
+	// Correction is the ratio of the actual rows to the estimated rows applied to EstRows, it's 0 if no learned
+	// feedback matches the filters.
+	Correction float64
+	// RowCount is the estimated rows of the filters used by the plan, after the correction is applied.
+	RowCount float64
+}
+
+// Applied returns whether a learned correction is applied to the estimation.
+func (f *FeedbackInfo) Applied() bool {
+	return f != nil && f.Correction > 0
+}
+
+// PredicateFingerprint returns the fingerprint and the text of the CNF filters. The filters are sorted so that the
+// fingerprint doesn't depend on their order, and the constants are kept because the correction learned for one value
+// rarely fits another.
+func PredicateFingerprint(sctx planctx.PlanContext, conds []expression.Expression) (fingerprint, predicate string) {
+	predicate = string(expression.SortedExplainExpressionList(sctx.GetExprCtx().GetEvalCtx(), conds))
+	hash := sha256.Sum256([]byte(predicate))
+	return hex.EncodeToString(hash[:]), predicate
+}
+
+// ApplyFeedback looks up the learned correction of the filters on the table, and returns the corrected rows of the
+// filters, which are no more than the rows of the table. The returned FeedbackInfo is always non-nil so that the
+// actual rows can be captured for the filters, even if nothing is learned yet.
+func ApplyFeedback(sctx planctx.PlanContext, store FeedbackStore, tableID int64, conds []expression.Expression,
+	estRows, tableRows float64) (float64, *FeedbackInfo) {
+	fingerprint, predicate := PredicateFingerprint(sctx, conds)
+	info := &FeedbackInfo{
+		TableID:     tableID,
+		Fingerprint: fingerprint,
+		Predicate:   predicate,
+		EstRows:     estRows,
+		RowCount:    estRows,
+	}
+	if store == nil {
+		return estRows, info
+	}
+	correction, ok := store.GetCorrection(tableID, fingerprint)
+	if !ok || correction <= 0 {
+		return estRows, info
+	}
+	info.Correction = correction
+	info.RowCount = math.Min(estRows*correction, tableRows)
+	return info.RowCount, info
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cardinality
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/planctx"
+	"github.com/pingcap/tidb/pkg/statistics"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// multiColumnEqCond is an equal condition on a single column, which can be corrected by the multi-column stats.
+type multiColumnEqCond struct {
+	uniqueID    int64
+	selectivity float64
+	value       types.Datum
+}
+
+// multiColumnStatsFactor returns the factor to correct the selectivity of the used stats nodes, which
+// is calculated under the independence assumption, by the multi-column stats created by CREATE STATISTICS.
+// Only the equal conditions on the single columns are corrected, and every column is corrected at most once.
+func multiColumnStatsFactor(ctx planctx.PlanContext, coll *statistics.HistColl, usedSets []*StatsNode) float64 {
+	if len(coll.MultiColumnStats) == 0 || !ctx.GetSessionVars().EnableExtendedStats {
+		return 1
+	}
+	tc := ctx.GetSessionVars().StmtCtx.TypeCtx()
+	eqConds := make(map[int64]*multiColumnEqCond)
+	for _, set := range usedSets {
+		if set.Tp != ColType || len(set.Ranges) != 1 || !set.Ranges[0].IsPointNonNullable(tc) {
+			continue
+		}
+		colID, ok := coll.UniqueID2colInfoID[set.ID]
+		if !ok {
+			continue
+		}
+		eqConds[colID] = &multiColumnEqCond{
+			uniqueID:    set.ID,
+			selectivity: set.Selectivity,
+			value:       set.Ranges[0].LowVal[0],
+		}
+	}
+	factor := 1.0
+	for len(eqConds) >= 2 {
+		// Greedily pick the stats covering the most columns.
+		var (
+			best    *statistics.MultiColumnStats
+			covered []int64
+		)
+		for _, stats := range coll.MultiColumnStats {
+			cols := make([]int64, 0, len(stats.ColIDs))
+			for _, colID := range stats.ColIDs {
+				if _, ok := eqConds[colID]; ok {
+					cols = append(cols, colID)
+				}
+			}
+			if len(cols) >= 2 && len(cols) > len(covered) {
+				best, covered = stats, cols
+			}
+		}
+		if best == nil {
+			break
+		}
+		indepSel := 1.0
+		for _, colID := range covered {
+			indepSel *= eqConds[colID].selectivity
+		}
+		if indepSel > 0 {
+			factor *= multiColumnEqualSelectivity(ctx, coll, best, covered, eqConds) / indepSel
+		}
+		for _, colID := range covered {
+			delete(eqConds, colID)
+		}
+	}
+	return factor
+}
+
+// multiColumnEqualSelectivity estimates the selectivity of the equal conditions on the covered columns
+// of the multi-column stats. The MCV list is used if all the columns are covered, otherwise the functional
+// dependencies are applied in the descending order of degree.
+func multiColumnEqualSelectivity(
+	ctx planctx.PlanContext,
+	coll *statistics.HistColl,
+	stats *statistics.MultiColumnStats,
+	covered []int64,
+	eqConds map[int64]*multiColumnEqCond,
+) float64 {
+	upperBound := 1.0
+	for _, colID := range covered {
+		upperBound = min(upperBound, eqConds[colID].selectivity)
+	}
+	if stats.HasKind(ast.StatsTypeMCV) && len(covered) == len(stats.ColIDs) {
+		if values, ok := encodeMultiColumnValues(ctx, coll, covered, eqConds); ok {
+			freq, found := stats.MCVFrequency(values)
+			if found {
+				return min(freq, upperBound)
+			}
+			upperBound = min(upperBound, freq)
+		}
+	}
+	// implied records the degree of the dependency by which the column is implied, the determinants
+	// can't be implied by other columns in turn.
+	implied := make(map[int64]float64)
+	determinants := make(map[int64]struct{})
+	if stats.HasKind(ast.StatsTypeDependency) {
+		for {
+			var best *statistics.FuncDependency
+			for _, dep := range stats.Dependencies {
+				if dep.Degree <= 0 || !slices.Contains(covered, dep.To) {
+					continue
+				}
+				if _, ok := implied[dep.To]; ok {
+					continue
+				}
+				if _, ok := determinants[dep.To]; ok {
+					continue
+				}
+				if slices.ContainsFunc(dep.From, func(colID int64) bool {
+					_, ok := implied[colID]
+					return ok || !slices.Contains(covered, colID)
+				}) {
+					continue
+				}
+				if best == nil || dep.Degree > best.Degree || (dep.Degree == best.Degree && len(dep.From) < len(best.From)) {
+					best = dep
+				}
+			}
+			if best == nil {
+				break
+			}
+			implied[best.To] = best.Degree
+			for _, colID := range best.From {
+				determinants[colID] = struct{}{}
+			}
+		}
+	}
+	sel := 1.0
+	for _, colID := range covered {
+		colSel := eqConds[colID].selectivity
+		if degree, ok := implied[colID]; ok {
+			colSel = degree + (1-degree)*colSel
+		}
+		sel *= colSel
+	}
+	return min(sel, upperBound)
+}
+
+func encodeMultiColumnValues(
+	ctx planctx.PlanContext,
+	coll *statistics.HistColl,
+	colIDs []int64,
+	eqConds map[int64]*multiColumnEqCond,
+) ([][]byte, bool) {
+	loc := ctx.GetSessionVars().StmtCtx.TimeZone()
+	values := make([][]byte, 0, len(colIDs))
+	for _, colID := range colIDs {
+		cond := eqConds[colID]
+		col := coll.GetCol(cond.uniqueID)
+		if col == nil || col.Info == nil {
+			return nil, false
+		}
+		val, err := statistics.EncodeMultiColumnValue(loc, &col.Info.FieldType, cond.value)
+		if err != nil {
+			return nil, false
+		}
+		values = append(values, val)
+	}
+	return values, true
+}
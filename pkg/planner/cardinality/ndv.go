@@ -16,6 +16,7 @@ package cardinality
 
 import (
 	"math"
+	"slices"
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/planner/property"
@@ -94,6 +95,29 @@ func EstimateColsNDVWithMatchedLen(cols []*expression.Column, schema *expression
 	return ndv, 1
 }
 
+// EstimateGroupNDVByMultiColumnStats returns the joint NDV of the columns identified by their UniqueIDs,
+// if it's collected by the multi-column stats created by CREATE STATISTICS.
+func EstimateGroupNDVByMultiColumnStats(coll *statistics.HistColl, uniqueIDs []int64) (float64, bool) {
+	if coll == nil || len(coll.MultiColumnStats) == 0 {
+		return 0, false
+	}
+	colIDs := make([]int64, 0, len(uniqueIDs))
+	for _, uniqueID := range uniqueIDs {
+		colID, ok := coll.UniqueID2colInfoID[uniqueID]
+		if !ok {
+			return 0, false
+		}
+		colIDs = append(colIDs, colID)
+	}
+	slices.Sort(colIDs)
+	for _, stats := range coll.MultiColumnStats {
+		if ndv, ok := stats.GroupNDV(colIDs); ok {
+			return ndv, true
+		}
+	}
+	return 0, false
+}
+
 // EstimateColsDNVWithMatchedLenFromUniqueIDs is similar to EstimateColsDNVWithMatchedLen, but it receives UniqueIDs instead of Columns.
 func EstimateColsDNVWithMatchedLenFromUniqueIDs(ids []int64, schema *expression.Schema, profile *property.StatsInfo) (float64, int) {
 	cols := make([]*expression.Column, 0, len(ids))
@@ -453,6 +453,10 @@ func expBackoffEstimation(sctx planctx.PlanContext, idx *statistics.Index, coll
 		},
 	}
 	colsIDs := coll.Idx2ColUniqueIDs[idx.Histogram.ID]
+	if len(colsIDs) < len(indexRange.LowVal) {
+		// The columns of the index are not collected, e.g. the HistColl is not generated for a DataSource.
+		return 0, 0, false, nil
+	}
 	singleColumnEstResults := make([]float64, 0, len(indexRange.LowVal))
 	// The following codes uses Exponential Backoff to reduce the impact of independent assumption. It works like:
 	//   1. Calc the selectivity of each column.
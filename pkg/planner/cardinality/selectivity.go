@@ -260,6 +260,12 @@ func Selectivity(
 			)
 		}
 	}
+	if factor := multiColumnStatsFactor(ctx, coll, usedSets); factor != 1 {
+		ret *= factor
+		if sc.EnableOptimizerDebugTrace {
+			debugtrace.RecordAnyValuesWithNames(ctx, "Multi-column stats factor", factor)
+		}
+	}
 
 	notCoveredConstants := make(map[int]*expression.Constant)
 	notCoveredDNF := make(map[int]*expression.ScalarFunction)
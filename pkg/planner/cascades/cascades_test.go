@@ -15,8 +15,15 @@
 package cascades_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/planner/cascades"
+	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/planner/core/rule"
+	"github.com/pingcap/tidb/pkg/session"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/stretchr/testify/require"
 )
@@ -78,3 +85,108 @@ func TestXFormedOperatorShouldDeriveTheirStatsOwn(t *testing.T) {
 	res2 = tk.MustQuery("explain format=\"brief\" SELECT /*+ hash_join(tab, t2@sel_2) */ 1 FROM t1 AS tab WHERE  (EXISTS(SELECT  1 FROM t2 WHERE a2 = a1 ))").String()
 	require.Equal(t, res1, res2)
 }
+
+func TestXFJoinAssociateExploresJoinOrders(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	for _, tbl := range []string{"t1", "t2", "t3", "t4"} {
+		tk.MustExec("create table " + tbl + " (a int, b int)")
+		tk.MustExec("insert into " + tbl + " values (1, 1), (1, 2), (2, 2), (3, 1), (null, 3)")
+	}
+
+	countPlans := func(sql string) int {
+		return countMemoPlans(t, tk, sql)
+	}
+	// (t1 ⋈ t2) ⋈ t3 and t1 ⋈ (t2 ⋈ t3).
+	require.Equal(t, 2, countPlans("select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b"))
+	// t2 ⋈ t3 is a cartesian product, which is not explored.
+	require.Equal(t, 1, countPlans("select * from t1 join t2 on t1.a = t2.a join t3 on t1.b = t3.b"))
+	// the outer join can't be re-associated.
+	require.Equal(t, 1, countPlans("select * from t1 left join t2 on t1.a = t2.a join t3 on t2.b = t3.b"))
+	// all the 5 bracketings of 4 tables are explored without duplication.
+	require.Equal(t, 5, countPlans("select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b join t4 on t3.a = t4.a"))
+
+	queries := []string{
+		"select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b",
+		"select t1.a, t4.b from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b join t4 on t3.a = t4.a where t1.b > 1",
+		"select count(*), t3.a from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b and t1.b < t3.a group by t3.a",
+	}
+	for _, sql := range queries {
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+		res1 := tk.MustQuery(sql).Sort().Rows()
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(true)
+		tk.MustQuery(sql).Sort().Check(res1)
+		tk.MustQuery("explain format = 'brief' " + sql)
+	}
+	tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+	// the cascades planner can be selected by the hint.
+	tk.MustQuery("select /*+ use_cascades(true) */ * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b").Sort().
+		Check(tk.MustQuery("select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b").Sort().Rows())
+}
+
+// countMemoPlans counts the logical plans in memo after the exploration.
+func countMemoPlans(t *testing.T, tk *testkit.TestKit, sql string) int {
+	sctx := tk.Session()
+	stmts, err := session.Parse(sctx, sql)
+	require.NoError(t, err)
+	ret := &plannercore.PreprocessorReturn{}
+	nodeW := resolve.NewNodeW(stmts[0])
+	require.NoError(t, plannercore.Preprocess(context.Background(), sctx, nodeW, plannercore.WithPreprocessorReturn(ret)))
+	p, err := plannercore.BuildLogicalPlanForTest(context.Background(), sctx, nodeW, ret.InfoSchema)
+	require.NoError(t, err)
+	// push the join conditions down into the joins, just like the normalization before the memo exploration.
+	p, err = plannercore.LogicalOptimizeTest(context.Background(), rule.FlagPredicatePushDown|rule.FlagPruneColumns, p.(base.LogicalPlan))
+	require.NoError(t, err)
+	cas, err := cascades.NewOptimizer(p.(base.LogicalPlan))
+	require.NoError(t, err)
+	defer cas.Destroy()
+	require.NoError(t, cas.Execute())
+	cnt := 0
+	cas.GetMemo().NewIterator().Each(func(base.LogicalPlan) bool {
+		cnt++
+		return true
+	})
+	return cnt
+}
+
+func TestXFRulesKeepQueryResults(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	for _, tbl := range []string{"t1", "t2"} {
+		tk.MustExec("create table " + tbl + " (a int, b int)")
+		tk.MustExec("insert into " + tbl + " values (1, 1), (1, 2), (2, 2), (3, 1), (null, 3)")
+	}
+	tk.MustExec("create table tp (a int, b int) partition by range (a) " +
+		"(partition p0 values less than (2), partition p1 values less than (3), partition p2 values less than maxvalue)")
+	tk.MustExec("insert into tp values (1, 1), (2, 2), (3, 3), (4, 1)")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+
+	// the aggregation isn't pushed down through the join unless tidb_opt_agg_push_down is on.
+	require.Equal(t, 1, countMemoPlans(t, tk, "select t1.a, sum(t2.b) from t1 join t2 on t1.a = t2.a group by t1.a"))
+	tk.MustExec("set @@tidb_opt_agg_push_down = 1")
+	// the sum is pushed down to t2 as the partial aggregation, the one on t1 is all firstrow which isn't pushed.
+	require.Equal(t, 2, countMemoPlans(t, tk, "select t1.a, sum(t2.b) from t1 join t2 on t1.a = t2.a group by t1.a"))
+	// count(t1.b) keeps the rows of t1, and t2 can't be reduced because of the count on the other side.
+	require.Equal(t, 1, countMemoPlans(t, tk, "select count(t1.b), sum(t2.b) from t1 join t2 on t1.a = t2.a"))
+
+	queries := []string{
+		"select t1.a, sum(t2.b) from t1 join t2 on t1.a = t2.a group by t1.a",
+		"select t1.a, count(t2.b), max(t2.a) from t1 left join t2 on t1.a = t2.a group by t1.a",
+		"select t2.a, min(t1.b), count(t2.b) from t1 right join t2 on t1.a = t2.a group by t2.a",
+		"select t1.b, sum(t2.b) from t1 join t2 on t1.a = t2.a where t1.b > 1 group by t1.b",
+		"select * from (select a, sum(b) s from t1 group by a) x join t2 on x.a = t2.a where x.a > 1 and x.s > 1",
+		"select * from (select a + 1 as c, b from t1) x left join t2 on x.c = t2.a where x.b > 1 and t2.b is null",
+		"select * from t1 join tp on t1.a = tp.a where t1.a > 2",
+		"select t1.a, sum(tp.b) from t1 join tp on t1.a = tp.a where tp.a < 3 group by t1.a",
+	}
+	for _, sql := range queries {
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+		res1 := tk.MustQuery(sql).Sort().Rows()
+		tk.Session().GetSessionVars().SetEnableCascadesPlanner(true)
+		tk.MustQuery(sql).Sort().Check(res1)
+		tk.MustQuery("explain format = 'brief' " + sql)
+	}
+	tk.Session().GetSessionVars().SetEnableCascadesPlanner(false)
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/cascades/util"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/funcdep"
 	"github.com/pingcap/tidb/pkg/planner/property"
 	"github.com/pingcap/tidb/pkg/util/intest"
 )
@@ -171,6 +172,18 @@ func (e *GroupExpression) GetWrappedLogicalPlan() base.LogicalPlan {
 	return e.LogicalPlan
 }
 
+// ExtractFD overrides the logical plan interface implemented by the wrapped logical plan.
+// The children of the wrapped logical plan are bound by the binder or iterator temporarily, they may be stale
+// when a new parent GE is derived from this GE, so use the FD derived for the group instead. The FD is copied
+// since the parent may modify it in place.
+func (e *GroupExpression) ExtractFD() *funcdep.FDSet {
+	fds := &funcdep.FDSet{HashCodeToUniqueID: make(map[string]int)}
+	if e.group != nil && e.group.HasLogicalProperty() && e.group.GetLogicalProperty().FD != nil {
+		fds.AddFrom(e.group.GetLogicalProperty().FD)
+	}
+	return fds
+}
+
 // DeriveLogicalProp derive the new group's logical property from a specific GE.
 // DeriveLogicalProp is not called with recursive, because we only examine and
 // init new group from bottom-up, so we can sure that this new group's children
@@ -223,9 +223,7 @@ func (*ImplIndexScan) OnImplement(expr *memo.GroupExpr, reqProp *property.Physic
 	is := plannercore.GetPhysicalIndexScan4LogicalIndexScan(logicalScan, expr.Group.Prop.Schema, expr.Group.Prop.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt))
 	if !reqProp.IsSortItemEmpty() {
 		is.KeepOrder = true
-		if reqProp.SortItems[0].Desc {
-			is.Desc = true
-		}
+		is.Desc = logicalScan.IsDescScan(reqProp)
 	}
 	return []memo.Implementation{impl.NewIndexScanImpl(is, logicalScan.Source.TblColHists)}, nil
 }
@@ -69,6 +69,8 @@ const (
 	OperandShow
 	// OperandWindow is the operand for window function.
 	OperandWindow
+	// OperandPartitionUnionAll is the operand for LogicalPartitionUnionAll.
+	OperandPartitionUnionAll
 	// OperandUnsupported is the operand for unsupported operators.
 	OperandUnsupported
 )
@@ -118,6 +120,8 @@ func (o Operand) String() string {
 		return "OperandShow"
 	case OperandWindow:
 		return "OperandWindow"
+	case OperandPartitionUnionAll:
+		return "OperandPartitionUnionAll"
 	default:
 		return "OperandUnsupported"
 	}
@@ -146,6 +150,8 @@ func GetOperand(p base.LogicalPlan) Operand {
 		return OperandUnionScan
 	case *logicalop.LogicalUnionAll:
 		return OperandUnionAll
+	case *logicalop.LogicalPartitionUnionAll:
+		return OperandPartitionUnionAll
 	case *logicalop.LogicalSort:
 		return OperandSort
 	case *logicalop.LogicalTopN:
@@ -0,0 +1,352 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agg
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/expression/aggregation"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+var _ rule.Rule = &XFPushAggDownJoin{}
+
+// XFPushAggDownJoin is the rule to push the Aggregation through the Join, which is the eager aggregation like:
+//
+//	select sum(b.x) from a join b on a.id = b.id group by a.k
+//	->
+//	select sum(b.s) from a join (select sum(x) as s, id from b group by id) b on a.id = b.id group by a.k
+//
+// The aggregate functions on one side of the join are pushed down as a new Aggregation grouped by the columns used
+// in the group by items and the join conditions, the top Aggregation is kept in the final mode. Whether the pushed
+// Aggregation is cheaper depends on how many rows it reduces, so it's left to the cost of the memo.
+type XFPushAggDownJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFPushAggDownJoin creates a new XFPushAggDownJoin rule.
+func NewXFPushAggDownJoin() *XFPushAggDownJoin {
+	pa := pattern.NewPattern(pattern.OperandAggregation, pattern.EngineTiDBOnly)
+	join := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	join.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll), pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(join)
+	return &XFPushAggDownJoin{
+		BaseRule: rule.NewBaseRule(rule.XFPushAggDownJoin, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPushAggDownJoin) ID() uint {
+	return uint(rule.XFPushAggDownJoin)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFPushAggDownJoin) PreCheck(aggGE base.LogicalPlan) bool {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	// it's controlled by tidb_opt_agg_push_down like the aggregation push down rule of the normalization.
+	if !agg.SCtx().GetSessionVars().AllowAggPushDown {
+		return false
+	}
+	// the Aggregation which has been split can't be split again.
+	for _, aggFunc := range agg.AggFuncs {
+		if aggFunc.Mode != aggregation.CompleteMode {
+			return false
+		}
+	}
+	return len(agg.AggFuncs) > 0
+}
+
+// XForm implements the Rule interface.
+func (*XFPushAggDownJoin) XForm(aggGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	joinGE := aggGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	switch join.JoinType {
+	case logicalop.InnerJoin, logicalop.LeftOuterJoin, logicalop.RightOuterJoin:
+	default:
+		return nil, false, nil
+	}
+	if len(join.NAEQConditions) > 0 {
+		return nil, false, nil
+	}
+	left, right := joinGE.Children()[0], joinGE.Children()[1]
+	// the aggregate functions are decomposed in place, clone them to keep the original Aggregation unchanged.
+	aggFuncs := make([]*aggregation.AggFuncDesc, 0, len(agg.AggFuncs))
+	for _, aggFunc := range agg.AggFuncs {
+		aggFuncs = append(aggFuncs, aggFunc.Clone())
+	}
+	leftAggFuncs, rightAggFuncs, valid := splitAggFuncs(aggFuncs, join, left.Schema(), right.Schema())
+	if !valid {
+		return nil, false, nil
+	}
+	leftGbyCols, rightGbyCols := collectGbyCols(agg, join, left.Schema())
+	newJoin := join.Shallow()
+	lChild, rChild := left, right
+	var pushed bool
+	var err error
+	// if there are count or sum functions on one side, the rows of the other side can't be reduced.
+	if !hasCountOrSum(leftAggFuncs) {
+		if rChild, err = tryToPushDownAgg(agg, rightAggFuncs, rightGbyCols, newJoin, right, 1); err != nil {
+			return nil, false, err
+		}
+		pushed = pushed || rChild != right
+	}
+	if !hasCountOrSum(rightAggFuncs) {
+		if lChild, err = tryToPushDownAgg(agg, leftAggFuncs, leftGbyCols, newJoin, left, 0); err != nil {
+			return nil, false, err
+		}
+		pushed = pushed || lChild != left
+	}
+	if !pushed {
+		return nil, false, nil
+	}
+	newJoin.SetChildren(lChild, rChild)
+	newJoin.SetSchema(expression.MergeSchema(lChild.Schema(), rChild.Schema()))
+	newJoin.SetOutputNames(append(slices.Clone(lChild.OutputNames()), rChild.OutputNames()...))
+	if join.JoinType == logicalop.LeftOuterJoin {
+		util.ResetNotNullFlag(newJoin.Schema(), lChild.Schema().Len(), newJoin.Schema().Len())
+	} else if join.JoinType == logicalop.RightOuterJoin {
+		util.ResetNotNullFlag(newJoin.Schema(), 0, lChild.Schema().Len())
+	}
+	newAgg := (*agg).Init(agg.SCtx(), agg.QueryBlockOffset())
+	newAgg.AggFuncs = aggFuncs
+	newAgg.SetChildren(newJoin)
+	return []base.LogicalPlan{newAgg}, false, nil
+}
+
+// splitAggFuncs splits the aggregate functions by the side of the join where their arguments come from.
+func splitAggFuncs(aggFuncs []*aggregation.AggFuncDesc, join *logicalop.LogicalJoin,
+	lSchema, rSchema *expression.Schema) (leftAggFuncs, rightAggFuncs []*aggregation.AggFuncDesc, valid bool) {
+	for _, aggFunc := range aggFuncs {
+		if !isDecomposableWithJoin(aggFunc) {
+			return nil, nil, false
+		}
+		fromLeft, fromRight := false, false
+		for _, col := range expression.ExtractColumnsFromExpressions(nil, aggFunc.Args, nil) {
+			fromLeft = fromLeft || lSchema.Contains(col)
+			fromRight = fromRight || rSchema.Contains(col)
+		}
+		switch {
+		case fromLeft && fromRight:
+			return nil, nil, false
+		case fromLeft:
+			if join.JoinType == logicalop.RightOuterJoin && !allArgsColumn(aggFunc) {
+				return nil, nil, false
+			}
+			leftAggFuncs = append(leftAggFuncs, aggFunc)
+		case fromRight:
+			if join.JoinType == logicalop.LeftOuterJoin && !allArgsColumn(aggFunc) {
+				return nil, nil, false
+			}
+			rightAggFuncs = append(rightAggFuncs, aggFunc)
+		case join.JoinType == logicalop.LeftOuterJoin:
+			// the arguments are constant, put them on the outer side.
+			leftAggFuncs = append(leftAggFuncs, aggFunc)
+		default:
+			rightAggFuncs = append(rightAggFuncs, aggFunc)
+		}
+	}
+	return leftAggFuncs, rightAggFuncs, true
+}
+
+// collectGbyCols collects the columns used in the group by items and the join conditions, they are the group by
+// columns of the pushed down Aggregation of each side.
+func collectGbyCols(agg *logicalop.LogicalAggregation, join *logicalop.LogicalJoin,
+	lSchema *expression.Schema) (leftGbyCols, rightGbyCols []*expression.Column) {
+	evalCtx := agg.SCtx().GetExprCtx().GetEvalCtx()
+	addByside := func(cols []*expression.Column) {
+		for _, col := range cols {
+			if lSchema.Contains(col) {
+				leftGbyCols = addGbyCol(evalCtx, leftGbyCols, col)
+			} else {
+				rightGbyCols = addGbyCol(evalCtx, rightGbyCols, col)
+			}
+		}
+	}
+	for _, gbyItem := range agg.GroupByItems {
+		addByside(expression.ExtractColumns(gbyItem))
+	}
+	for _, eqCond := range join.EqualConditions {
+		leftGbyCols = addGbyCol(evalCtx, leftGbyCols, eqCond.GetArgs()[0].(*expression.Column))
+		rightGbyCols = addGbyCol(evalCtx, rightGbyCols, eqCond.GetArgs()[1].(*expression.Column))
+	}
+	for _, cond := range join.LeftConditions {
+		leftGbyCols = addGbyCol(evalCtx, leftGbyCols, expression.ExtractColumns(cond)...)
+	}
+	for _, cond := range join.RightConditions {
+		rightGbyCols = addGbyCol(evalCtx, rightGbyCols, expression.ExtractColumns(cond)...)
+	}
+	for _, cond := range join.OtherConditions {
+		addByside(expression.ExtractColumns(cond))
+	}
+	return leftGbyCols, rightGbyCols
+}
+
+func addGbyCol(evalCtx expression.EvalContext, gbyCols []*expression.Column, cols ...*expression.Column) []*expression.Column {
+	for _, col := range cols {
+		duplicate := false
+		for _, gbyCol := range gbyCols {
+			if col.Equal(evalCtx, gbyCol) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			gbyCols = append(gbyCols, col)
+		}
+	}
+	return gbyCols
+}
+
+// tryToPushDownAgg returns the child with the pushed down Aggregation, the original child is returned when it's not
+// worth or not able to push down.
+func tryToPushDownAgg(oldAgg *logicalop.LogicalAggregation, aggFuncs []*aggregation.AggFuncDesc, gbyCols []*expression.Column,
+	join *logicalop.LogicalJoin, child base.LogicalPlan, childIdx int) (base.LogicalPlan, error) {
+	if aggregation.IsAllFirstRow(aggFuncs) {
+		return child, nil
+	}
+	// the join is re-associated in memo, don't push the Aggregation into the multi-way join.
+	if _, ok := child.GetWrappedLogicalPlan().(*logicalop.LogicalJoin); ok {
+		return child, nil
+	}
+	gbySchema := expression.NewSchema(gbyCols...)
+	for _, key := range child.Schema().PKOrUK {
+		// the child is unique on the group by columns already.
+		if gbySchema.ColumnsIndices(key) != nil {
+			return child, nil
+		}
+	}
+	ctx := join.SCtx()
+	nullGenerating := (join.JoinType == logicalop.LeftOuterJoin && childIdx == 1) ||
+		(join.JoinType == logicalop.RightOuterJoin && childIdx == 0)
+	agg := logicalop.LogicalAggregation{
+		GroupByItems:   expression.Column2Exprs(gbyCols),
+		PreferAggType:  oldAgg.PreferAggType,
+		PreferAggToCop: oldAgg.PreferAggToCop,
+	}.Init(ctx, oldAgg.QueryBlockOffset())
+	schema := expression.NewSchema(make([]*expression.Column, 0, len(aggFuncs)+len(gbyCols))...)
+	newAggFuncs := make([]*aggregation.AggFuncDesc, 0, len(aggFuncs)+len(gbyCols))
+	for _, aggFunc := range aggFuncs {
+		// the pushed down function computes the partial result, which is merged by the final mode function on the top.
+		newAggFuncs = append(newAggFuncs, aggFunc.Clone())
+		schema.Append(&expression.Column{
+			UniqueID: ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  aggFunc.RetTp,
+		})
+	}
+	for _, gbyCol := range gbyCols {
+		firstRow, err := aggregation.NewAggFuncDesc(ctx.GetExprCtx(), ast.AggFuncFirstRow, []expression.Expression{gbyCol}, false)
+		if err != nil {
+			return nil, err
+		}
+		newCol := gbyCol.Clone().(*expression.Column)
+		newCol.RetType = firstRow.RetTp
+		newAggFuncs = append(newAggFuncs, firstRow)
+		schema.Append(newCol)
+	}
+	agg.AggFuncs = newAggFuncs
+	agg.SetSchema(schema)
+	agg.SetOutputNames(aggOutputNames(child, len(aggFuncs), gbyCols))
+	agg.SetChildren(child)
+	// the Aggregation without group by items returns a row for the empty input, which can't be joined.
+	if len(agg.GroupByItems) == 0 {
+		agg.GroupByItems = []expression.Expression{&expression.Constant{
+			Value:   types.NewDatum(0),
+			RetType: types.NewFieldType(mysql.TypeLong)}}
+	}
+	if nullGenerating {
+		defaultValues := make([]types.Datum, 0, agg.Schema().Len())
+		for _, aggFunc := range agg.AggFuncs {
+			value, existsDefaultValue, err := aggFunc.EvalNullValueInOuterJoin(ctx.GetExprCtx(), child.Schema())
+			if err != nil || !existsDefaultValue {
+				return child, nil
+			}
+			defaultValues = append(defaultValues, value)
+		}
+		join.DefaultValues = defaultValues
+	}
+	for i, aggFunc := range aggFuncs {
+		arg := schema.Columns[i]
+		if nullGenerating {
+			// the partial results are null for the unmatched rows of the outer join.
+			arg = arg.Clone().(*expression.Column)
+			newFieldType := arg.RetType.Clone()
+			newFieldType.DelFlag(mysql.NotNullFlag)
+			arg.RetType = newFieldType
+		}
+		aggFunc.Args = []expression.Expression{arg}
+		aggFunc.Mode = aggregation.FinalMode
+	}
+	return agg, nil
+}
+
+// aggOutputNames hides the names of the partial results, the group by columns keep their names from the child.
+func aggOutputNames(child base.LogicalPlan, aggFuncCnt int, gbyCols []*expression.Column) types.NameSlice {
+	childNames := child.OutputNames()
+	names := make(types.NameSlice, 0, aggFuncCnt+len(gbyCols))
+	for range aggFuncCnt {
+		names = append(names, types.EmptyName)
+	}
+	for _, gbyCol := range gbyCols {
+		if idx := child.Schema().ColumnIndex(gbyCol); idx >= 0 && idx < len(childNames) {
+			names = append(names, childNames[idx])
+		} else {
+			names = append(names, types.EmptyName)
+		}
+	}
+	return names
+}
+
+// isDecomposableWithJoin checks whether the aggregate function can be computed by merging the partial results.
+func isDecomposableWithJoin(aggFunc *aggregation.AggFuncDesc) bool {
+	if len(aggFunc.OrderByItems) > 0 {
+		return false
+	}
+	switch aggFunc.Name {
+	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
+		return true
+	case ast.AggFuncSum, ast.AggFuncCount:
+		return !aggFunc.HasDistinct
+	default:
+		return false
+	}
+}
+
+func hasCountOrSum(aggFuncs []*aggregation.AggFuncDesc) bool {
+	for _, aggFunc := range aggFuncs {
+		if aggFunc.Name == ast.AggFuncSum || aggFunc.Name == ast.AggFuncCount {
+			return true
+		}
+	}
+	return false
+}
+
+// allArgsColumn checks whether the arguments are all columns, e.g. count(a) rather than count(*) or sum(a+1).
+func allArgsColumn(aggFunc *aggregation.AggFuncDesc) bool {
+	for _, arg := range aggFunc.Args {
+		if _, ok := arg.(*expression.Column); !ok {
+			return false
+		}
+	}
+	return true
+}
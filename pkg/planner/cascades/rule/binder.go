@@ -256,8 +256,14 @@ func (b *Binder) dfsMatch(p *pattern.Pattern, parentHolder base.LogicalPlan) boo
 		// we ensure that pattern len is equal to input child groups len.
 		childGroup := gE.Inputs[i]
 		b.traceIn(childPattern, childGroup)
-		// rebound the dynamic placeholder no matter whether it is CHANGED or NOT or NIL.
-		parentHolder.SetChild(i, b.pickGroupExpression(childPattern, childGroup))
+		childGE := b.pickGroupExpression(childPattern, childGroup)
+		// the exhausted group can't be matched, don't leave the nil placeholder in the holder, whose children
+		// are still referred by the later rules, like the output names of the single-child operators.
+		if childGE == nil {
+			return false
+		}
+		// rebound the dynamic placeholder no matter whether it is CHANGED or NOT.
+		parentHolder.SetChild(i, childGE)
 		// we can sure that childPattern and element in Subs[i] is match when arrive here, recursive for child.
 		if !b.dfsMatch(childPattern, parentHolder.Children()[i]) {
 			return false
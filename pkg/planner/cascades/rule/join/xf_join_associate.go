@@ -0,0 +1,124 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package join
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+)
+
+var _ rule.Rule = &XFJoinAssociate{}
+
+// XFJoinAssociate is the rule to re-associate the inner joins from (A ⋈ B) ⋈ C to A ⋈ (B ⋈ C), which explores the
+// bushy join orders in the memo. The output columns keep the same order, so no projection is needed on the top.
+//
+// Only the re-association to the right is applied, it's enough to reach all the bracketings of the same leaf order
+// from a left-deep tree, and it's guaranteed to terminate since the left child of the new top join is always shorter.
+type XFJoinAssociate struct {
+	*rule.BaseRule
+}
+
+// NewXFJoinAssociate creates a new XFJoinAssociate rule.
+func NewXFJoinAssociate() *XFJoinAssociate {
+	pa := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	left := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	left.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll), pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(left, pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	return &XFJoinAssociate{
+		BaseRule: rule.NewBaseRule(rule.XFJoinAssociate, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFJoinAssociate) ID() uint {
+	return uint(rule.XFJoinAssociate)
+}
+
+// PreCheck implements the Rule interface.
+func (*XFJoinAssociate) PreCheck(joinGE base.LogicalPlan) bool {
+	return canAssociate(joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin))
+}
+
+// XForm implements the Rule interface.
+func (*XFJoinAssociate) XForm(joinGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	topJoin := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	bottomGE := joinGE.Children()[0]
+	bottomJoin := bottomGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	if !canAssociate(bottomJoin) {
+		return nil, false, nil
+	}
+	a, b, c := bottomGE.Children()[0], bottomGE.Children()[1], joinGE.Children()[1]
+
+	conds := joinConditions(bottomJoin)
+	conds = append(conds, joinConditions(topJoin)...)
+	// sort the conditions, so that the joins re-associated from different orders share the same hash in memo.
+	slices.SortStableFunc(conds, func(i, j expression.Expression) int {
+		return bytes.Compare(i.CanonicalHashCode(), j.CanonicalHashCode())
+	})
+	bcSchema := expression.MergeSchema(b.Schema(), c.Schema())
+	bcConds := make([]expression.Expression, 0, len(conds))
+	topConds := make([]expression.Expression, 0, len(conds))
+	for _, cond := range conds {
+		if expression.ExprFromSchema(cond, bcSchema) {
+			bcConds = append(bcConds, cond)
+		} else {
+			topConds = append(topConds, cond)
+		}
+	}
+
+	newBottomJoin := logicalop.LogicalJoin{JoinType: logicalop.InnerJoin}.Init(topJoin.SCtx(), topJoin.QueryBlockOffset())
+	newBottomJoin.SetChildren(b, c)
+	newBottomJoin.SetSchema(bcSchema)
+	newBottomJoin.SetOutputNames(append(slices.Clone(b.OutputNames()), c.OutputNames()...))
+	newBottomJoin.AttachOnConds(bcConds)
+	// don't introduce the cartesian product, it's rarely better than the original order.
+	if len(newBottomJoin.EqualConditions) == 0 {
+		return nil, false, nil
+	}
+	newTopJoin := logicalop.LogicalJoin{JoinType: logicalop.InnerJoin}.Init(topJoin.SCtx(), topJoin.QueryBlockOffset())
+	newTopJoin.SetChildren(a, newBottomJoin)
+	newTopJoin.SetSchema(topJoin.Schema().Clone())
+	newTopJoin.SetOutputNames(topJoin.OutputNames())
+	newTopJoin.AttachOnConds(topConds)
+	return []base.LogicalPlan{newTopJoin}, false, nil
+}
+
+// canAssociate checks whether the join can be re-associated, the joins with hints are kept as what they are.
+func canAssociate(join *logicalop.LogicalJoin) bool {
+	// the straight_join() hint fixes the join order of the whole statement.
+	if join.SCtx().GetSessionVars().StmtCtx.StraightJoinOrder {
+		return false
+	}
+	return join.JoinType == logicalop.InnerJoin && !join.StraightJoin && !join.PreferJoinOrder &&
+		join.PreferJoinType == 0 && join.LeftPreferJoinType == 0 && join.RightPreferJoinType == 0 &&
+		len(join.NAEQConditions) == 0
+}
+
+func joinConditions(join *logicalop.LogicalJoin) []expression.Expression {
+	conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.LeftConditions)+
+		len(join.RightConditions)+len(join.OtherConditions))
+	for _, cond := range join.EqualConditions {
+		conds = append(conds, cond)
+	}
+	conds = append(conds, join.LeftConditions...)
+	conds = append(conds, join.RightConditions...)
+	return append(conds, join.OtherConditions...)
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package partition
+
+import (
+	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util/utilfuncp"
+)
+
+var _ rule.Rule = &XFPrunePartitionUnionAll{}
+
+// XFPrunePartitionUnionAll is the rule to prune the partitions of the PartitionUnionAll in static prune mode by the
+// Selection on the top, like the filters derived from the join conditions by XFPushSelDownJoin, which are not seen
+// by the partition pruning in the normalization phase.
+type XFPrunePartitionUnionAll struct {
+	*rule.BaseRule
+}
+
+// NewXFPrunePartitionUnionAll creates a new XFPrunePartitionUnionAll rule.
+func NewXFPrunePartitionUnionAll() *XFPrunePartitionUnionAll {
+	// the number of partitions varies, so the children of the PartitionUnionAll are read from its input groups.
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	pa.SetChildren(pattern.NewPattern(pattern.OperandPartitionUnionAll, pattern.EngineTiDBOnly))
+	return &XFPrunePartitionUnionAll{
+		BaseRule: rule.NewBaseRule(rule.XFPrunePartitionUnionAll, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPrunePartitionUnionAll) ID() uint {
+	return uint(rule.XFPrunePartitionUnionAll)
+}
+
+// XForm implements the Rule interface.
+func (*XFPrunePartitionUnionAll) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	unionGE := selGE.Children()[0].(*memo.GroupExpression)
+	union := unionGE.GetWrappedLogicalPlan().(*logicalop.LogicalPartitionUnionAll)
+	children := make([]base.LogicalPlan, 0, len(unionGE.Inputs))
+	for _, childG := range unionGE.Inputs {
+		childGE := childG.GetLogicalExpressions().Front().Value.(*memo.GroupExpression)
+		if _, ok := childGE.GetWrappedLogicalPlan().(*logicalop.DataSource); ok {
+			pruned, err := utilfuncp.PrunePartition4DataSource(childGE.GetWrappedLogicalPlan(), sel.Conditions)
+			if err != nil {
+				return nil, false, err
+			}
+			if pruned {
+				continue
+			}
+		}
+		children = append(children, childGE)
+	}
+	if len(children) == len(unionGE.Inputs) {
+		return nil, false, nil
+	}
+	if len(children) == 0 {
+		dual := logicalop.LogicalTableDual{RowCount: 0}.Init(sel.SCtx(), sel.QueryBlockOffset())
+		dual.SetSchema(union.Schema())
+		return []base.LogicalPlan{dual}, false, nil
+	}
+	var child base.LogicalPlan = children[0]
+	// the single partition replaces the union only when it outputs the same columns.
+	if len(children) > 1 || child.Schema().Len() != union.Schema().Len() {
+		newUnion := logicalop.LogicalPartitionUnionAll{}.Init(union.SCtx(), union.QueryBlockOffset())
+		newUnion.SetChildren(children...)
+		newUnion.SetSchema(union.Schema())
+		child = newUnion
+	}
+	newSel := logicalop.LogicalSelection{Conditions: sel.Conditions}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(child)
+	return []base.LogicalPlan{newSel}, false, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
+)
+
+var _ rule.Rule = &XFMergeAdjacentProjection{}
+
+// XFMergeAdjacentProjection is the rule to merge the adjacent Projections, the expressions of the top Projection
+// are rewritten on the child of the bottom Projection.
+type XFMergeAdjacentProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFMergeAdjacentProjection creates a new XFMergeAdjacentProjection rule.
+func NewXFMergeAdjacentProjection() *XFMergeAdjacentProjection {
+	pa := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	child := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	child.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(child)
+	return &XFMergeAdjacentProjection{
+		BaseRule: rule.NewBaseRule(rule.XFMergeAdjacentProjection, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFMergeAdjacentProjection) ID() uint {
+	return uint(rule.XFMergeAdjacentProjection)
+}
+
+// XForm implements the Rule interface.
+func (*XFMergeAdjacentProjection) XForm(projGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	childGE := projGE.Children()[0]
+	child := childGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	// the child expressions with side effects can't be evaluated more or less times than they are.
+	if expression.ExprsHasSideEffects(child.Exprs) {
+		return nil, false, nil
+	}
+	replace := make(map[string]*expression.Column)
+	for i, col := range child.Schema().Columns {
+		if colOrigin, ok := child.Exprs[i].(*expression.Column); ok {
+			replace[string(col.HashCode())] = colOrigin
+		}
+	}
+	newProj := (*proj).Init(proj.SCtx(), proj.QueryBlockOffset())
+	newProj.Exprs = make([]expression.Expression, 0, len(proj.Exprs))
+	for _, expr := range proj.Exprs {
+		newExpr := expr.Clone()
+		ruleutil.ResolveExprAndReplace(newExpr, replace)
+		newProj.Exprs = append(newProj.Exprs, ruleutil.ReplaceColumnOfExpr(newExpr, child.Exprs, child.Schema()))
+	}
+	newProj.SetChildren(childGE.Children()[0])
+	return []base.LogicalPlan{newProj}, false, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package projection
+
+import (
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+var _ rule.Rule = &XFPruneJoinColumns{}
+
+// XFPruneJoinColumns is the rule to prune the output columns of the Join which are not used by the Projection on
+// the top, like the columns of the outer side kept by the join decorrelated from the apply, so the Projection can be
+// eliminated when it just outputs the columns of the Join.
+type XFPruneJoinColumns struct {
+	*rule.BaseRule
+}
+
+// NewXFPruneJoinColumns creates a new XFPruneJoinColumns rule.
+func NewXFPruneJoinColumns() *XFPruneJoinColumns {
+	pa := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	join := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	join.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll), pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(join)
+	return &XFPruneJoinColumns{
+		BaseRule: rule.NewBaseRule(rule.XFPruneJoinColumns, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPruneJoinColumns) ID() uint {
+	return uint(rule.XFPruneJoinColumns)
+}
+
+// XForm implements the Rule interface.
+func (*XFPruneJoinColumns) XForm(projGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	joinGE := projGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	if proj.Proj4Expand {
+		return nil, false, nil
+	}
+	usedCols := expression.ExtractColumnsFromExpressions(nil, proj.Exprs, nil)
+	if len(usedCols) == 0 {
+		return nil, false, nil
+	}
+	if join.JoinType == logicalop.LeftOuterSemiJoin || join.JoinType == logicalop.AntiLeftOuterSemiJoin {
+		usedCols = append(usedCols, join.Schema().Columns[join.Schema().Len()-1])
+	}
+	used := expression.GetUsedList(join.SCtx().GetExprCtx().GetEvalCtx(), usedCols, join.Schema())
+	if !slices.Contains(used, false) {
+		return nil, false, nil
+	}
+	cols := make([]*expression.Column, 0, len(used))
+	for i, col := range join.Schema().Columns {
+		if used[i] {
+			cols = append(cols, col)
+		}
+	}
+	newJoin := join.Shallow()
+	newJoin.SetChildren(joinGE.Children()...)
+	newJoin.SetSchema(expression.NewSchema(cols...))
+	newJoin.SetOutputNames(joinOutputNames(joinGE.Children(), cols))
+	newProj := (*proj).Init(proj.SCtx(), proj.QueryBlockOffset())
+	newProj.SetChildren(newJoin)
+	return []base.LogicalPlan{newProj}, false, nil
+}
+
+// joinOutputNames collects the output names of the columns from the children of the join, the names of the join
+// itself may not be aligned with its schema, like the join simplified from the apply whose schema has been pruned.
+func joinOutputNames(children []base.LogicalPlan, cols []*expression.Column) types.NameSlice {
+	names := make(types.NameSlice, 0, len(cols))
+	for _, col := range cols {
+		name := types.EmptyName
+		for _, child := range children {
+			if idx := child.Schema().ColumnIndex(col); idx >= 0 && idx < len(child.OutputNames()) {
+				name = child.OutputNames()[idx]
+				break
+			}
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -22,6 +22,8 @@ const (
 	DefaultNone Type = iota
 	// XFJoinToApply refers to join to a apply rule.
 	XFJoinToApply
+	// XFJoinAssociate refers to re-associate the inner joins from (A ⋈ B) ⋈ C to A ⋈ (B ⋈ C).
+	XFJoinAssociate
 
 	// XFDeCorrelateSimpleApply try to decorate apply to a join when no corr predicates from inner side.
 	XFDeCorrelateSimpleApply
@@ -41,6 +43,23 @@ const (
 	XFPullCorrPredFromAgg1
 	// XFPullCorrPredFromAgg2 try to pull correlated expression from agg<selection> from inner child of an apply.
 	XFPullCorrPredFromAgg2
+
+	// XFPushSelDownJoin pushes the selection down through the join, and fills the conditions of the join.
+	XFPushSelDownJoin
+	// XFPushSelDownProjection pushes the selection down through the projection.
+	XFPushSelDownProjection
+	// XFPushSelDownAggregation pushes the selection on the group-by columns down through the aggregation.
+	XFPushSelDownAggregation
+	// XFMergeAdjacentSelection merges the adjacent selections into one.
+	XFMergeAdjacentSelection
+	// XFMergeAdjacentProjection merges the adjacent projections into one.
+	XFMergeAdjacentProjection
+	// XFPruneJoinColumns prunes the output columns of the join which are not used by the projection above it.
+	XFPruneJoinColumns
+	// XFPushAggDownJoin pushes the aggregation down to the children of the join as the partial aggregations.
+	XFPushAggDownJoin
+	// XFPrunePartitionUnionAll prunes the partitions of the static partition union by the selection above it.
+	XFPrunePartitionUnionAll
 	// XFMaximumRuleLength is the maximum rule length.
 	XFMaximumRuleLength
 )
@@ -50,6 +69,24 @@ func (tp *Type) String() string {
 	switch *tp {
 	case XFJoinToApply:
 		return "join_to_apply"
+	case XFJoinAssociate:
+		return "join_associate"
+	case XFPushSelDownJoin:
+		return "push_selection_down_join"
+	case XFPushSelDownProjection:
+		return "push_selection_down_projection"
+	case XFPushSelDownAggregation:
+		return "push_selection_down_aggregation"
+	case XFMergeAdjacentSelection:
+		return "merge_adjacent_selection"
+	case XFMergeAdjacentProjection:
+		return "merge_adjacent_projection"
+	case XFPruneJoinColumns:
+		return "prune_join_columns"
+	case XFPushAggDownJoin:
+		return "push_aggregation_down_join"
+	case XFPrunePartitionUnionAll:
+		return "prune_partition_union_all"
 	default:
 		return "default_none"
 	}
@@ -19,7 +19,12 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
 	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
 	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/agg"
 	"github.com/pingcap/tidb/pkg/planner/cascades/rule/apply/decorrelateapply"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/join"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/partition"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/projection"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule/selection"
 	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 )
 
@@ -35,7 +40,11 @@ const (
 
 // DefaultRuleSets indicates the all rule set.
 var DefaultRuleSets = map[pattern.Operand]*OperandRules{
-	pattern.OperandApply: OperandApplyRules,
+	pattern.OperandApply:       OperandApplyRules,
+	pattern.OperandJoin:        OperandJoinRules,
+	pattern.OperandSelection:   OperandSelectionRules,
+	pattern.OperandProjection:  OperandProjectionRules,
+	pattern.OperandAggregation: OperandAggregationRules,
 }
 
 // OperandRules wrapper all the rules rooted from one specified operator.
@@ -82,3 +91,52 @@ var OperandApplyRulesMap = map[SetType][]rule.Rule{
 var OperandApplyRulesList = []rule.Rule{
 	decorrelateapply.NewXFDeCorrelateSimpleApply(),
 }
+
+// OperandJoinRules is the rules rooted from a join operand.
+var OperandJoinRules = &OperandRules{OperandJoinRulesMap, OperandJoinRulesList}
+
+// OperandJoinRulesMap OperandJoinRules is the rules rooted from a join operand, organized as map, key is sub-set type.
+var OperandJoinRulesMap = map[SetType][]rule.Rule{}
+
+// OperandJoinRulesList OperandJoinRules is the rules rooted from a join operand, organized as list.
+var OperandJoinRulesList = []rule.Rule{
+	join.NewXFJoinAssociate(),
+}
+
+// OperandSelectionRules is the rules rooted from a selection operand.
+var OperandSelectionRules = &OperandRules{OperandSelectionRulesMap, OperandSelectionRulesList}
+
+// OperandSelectionRulesMap OperandSelectionRules is the rules rooted from a selection operand, organized as map, key is sub-set type.
+var OperandSelectionRulesMap = map[SetType][]rule.Rule{}
+
+// OperandSelectionRulesList OperandSelectionRules is the rules rooted from a selection operand, organized as list.
+var OperandSelectionRulesList = []rule.Rule{
+	selection.NewXFPushSelDownJoin(),
+	selection.NewXFPushSelDownProjection(),
+	selection.NewXFPushSelDownAggregation(),
+	selection.NewXFMergeAdjacentSelection(),
+	partition.NewXFPrunePartitionUnionAll(),
+}
+
+// OperandProjectionRules is the rules rooted from a projection operand.
+var OperandProjectionRules = &OperandRules{OperandProjectionRulesMap, OperandProjectionRulesList}
+
+// OperandProjectionRulesMap OperandProjectionRules is the rules rooted from a projection operand, organized as map, key is sub-set type.
+var OperandProjectionRulesMap = map[SetType][]rule.Rule{}
+
+// OperandProjectionRulesList OperandProjectionRules is the rules rooted from a projection operand, organized as list.
+var OperandProjectionRulesList = []rule.Rule{
+	projection.NewXFMergeAdjacentProjection(),
+	projection.NewXFPruneJoinColumns(),
+}
+
+// OperandAggregationRules is the rules rooted from an aggregation operand.
+var OperandAggregationRules = &OperandRules{OperandAggregationRulesMap, OperandAggregationRulesList}
+
+// OperandAggregationRulesMap OperandAggregationRules is the rules rooted from an aggregation operand, organized as map, key is sub-set type.
+var OperandAggregationRulesMap = map[SetType][]rule.Rule{}
+
+// OperandAggregationRulesList OperandAggregationRules is the rules rooted from an aggregation operand, organized as list.
+var OperandAggregationRulesList = []rule.Rule{
+	agg.NewXFPushAggDownJoin(),
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+)
+
+var _ rule.Rule = &XFMergeAdjacentSelection{}
+
+// XFMergeAdjacentSelection is the rule to merge the adjacent Selections, which are left by the pushed down filters.
+type XFMergeAdjacentSelection struct {
+	*rule.BaseRule
+}
+
+// NewXFMergeAdjacentSelection creates a new XFMergeAdjacentSelection rule.
+func NewXFMergeAdjacentSelection() *XFMergeAdjacentSelection {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineAll)
+	child := pattern.NewPattern(pattern.OperandSelection, pattern.EngineAll)
+	child.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(child)
+	return &XFMergeAdjacentSelection{
+		BaseRule: rule.NewBaseRule(rule.XFMergeAdjacentSelection, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFMergeAdjacentSelection) ID() uint {
+	return uint(rule.XFMergeAdjacentSelection)
+}
+
+// XForm implements the Rule interface.
+func (*XFMergeAdjacentSelection) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	childGE := selGE.Children()[0]
+	child := childGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	// the filters with the user variables see the rows filtered by the child, keep them apart.
+	for _, cond := range sel.Conditions {
+		if expression.HasGetSetVarFunc(cond) {
+			return nil, false, nil
+		}
+	}
+	conds := make([]expression.Expression, 0, len(sel.Conditions)+len(child.Conditions))
+	conds = append(conds, child.Conditions...)
+	conds = append(conds, sel.Conditions...)
+	newSel := logicalop.LogicalSelection{Conditions: expression.RemoveDupExprs(conds)}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(childGE.Children()[0])
+	return []base.LogicalPlan{newSel}, false, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+)
+
+var _ rule.Rule = &XFPushSelDownAggregation{}
+
+// XFPushSelDownAggregation is the rule to push the filters only on the group by columns through the Aggregation.
+// The constant filters are left for the normalization phase, which keeps them on both sides of the Aggregation,
+// pushing them here can't remove the Selection on the top.
+type XFPushSelDownAggregation struct {
+	*rule.BaseRule
+}
+
+// NewXFPushSelDownAggregation creates a new XFPushSelDownAggregation rule.
+func NewXFPushSelDownAggregation() *XFPushSelDownAggregation {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	agg := pattern.NewPattern(pattern.OperandAggregation, pattern.EngineTiDBOnly)
+	agg.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(agg)
+	return &XFPushSelDownAggregation{
+		BaseRule: rule.NewBaseRule(rule.XFPushSelDownAggregation, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPushSelDownAggregation) ID() uint {
+	return uint(rule.XFPushSelDownAggregation)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushSelDownAggregation) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	aggGE := selGE.Children()[0]
+	agg := aggGE.GetWrappedLogicalPlan().(*logicalop.LogicalAggregation)
+	exprsOriginal := make([]expression.Expression, 0, len(agg.AggFuncs))
+	for _, fun := range agg.AggFuncs {
+		exprsOriginal = append(exprsOriginal, fun.Args[0])
+	}
+	groupByColumns := expression.NewSchema(agg.GetGroupByCols()...)
+	pushedConds := make([]expression.Expression, 0, len(sel.Conditions))
+	remainConds := make([]expression.Expression, 0, len(sel.Conditions))
+	for _, cond := range sel.Conditions {
+		if _, ok := cond.(*expression.ScalarFunction); ok && !expression.HasGetSetVarFunc(cond) &&
+			expression.ExprFromSchema(cond, groupByColumns) {
+			pushedConds = append(pushedConds, expression.ColumnSubstitute(agg.SCtx().GetExprCtx(), cond, agg.Schema(), exprsOriginal))
+		} else {
+			remainConds = append(remainConds, cond)
+		}
+	}
+	if len(pushedConds) == 0 {
+		return nil, false, nil
+	}
+	newAgg := (*agg).Init(agg.SCtx(), agg.QueryBlockOffset())
+	newAgg.SetChildren(childWithSelection(sel, aggGE.Children()[0], pushedConds))
+	var newPlan base.LogicalPlan = newAgg
+	if len(remainConds) > 0 {
+		newSel := logicalop.LogicalSelection{Conditions: remainConds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+		newSel.SetChildren(newPlan)
+		newPlan = newSel
+	}
+	return []base.LogicalPlan{newPlan}, false, nil
+}
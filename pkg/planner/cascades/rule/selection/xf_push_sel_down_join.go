@@ -0,0 +1,136 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+)
+
+var _ rule.Rule = &XFPushSelDownJoin{}
+
+// XFPushSelDownJoin is the rule to push the Selection through the Join.
+//
+// For inner and semi joins, the filters are merged with the join conditions, and the ones from a single side are
+// pushed down as a Selection of that side, the others are kept as the join conditions. For outer joins, only the
+// filters from the outer side are pushed down, the others are kept in the Selection on the top.
+type XFPushSelDownJoin struct {
+	*rule.BaseRule
+}
+
+// NewXFPushSelDownJoin creates a new XFPushSelDownJoin rule.
+func NewXFPushSelDownJoin() *XFPushSelDownJoin {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	join := pattern.NewPattern(pattern.OperandJoin, pattern.EngineTiDBOnly)
+	join.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll), pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(join)
+	return &XFPushSelDownJoin{
+		BaseRule: rule.NewBaseRule(rule.XFPushSelDownJoin, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPushSelDownJoin) ID() uint {
+	return uint(rule.XFPushSelDownJoin)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushSelDownJoin) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	joinGE := selGE.Children()[0]
+	join := joinGE.GetWrappedLogicalPlan().(*logicalop.LogicalJoin)
+	if len(join.NAEQConditions) > 0 {
+		return nil, false, nil
+	}
+	left, right := joinGE.Children()[0], joinGE.Children()[1]
+	// the filters with the user variables are kept on the top, the evaluation of them depends on the row order.
+	pushedConds := make([]expression.Expression, 0, len(sel.Conditions))
+	var leftConds, rightConds, remainConds []expression.Expression
+	for _, cond := range sel.Conditions {
+		if expression.HasGetSetVarFunc(cond) {
+			remainConds = append(remainConds, cond)
+		} else {
+			pushedConds = append(pushedConds, cond)
+		}
+	}
+	if len(pushedConds) == 0 {
+		return nil, false, nil
+	}
+	newJoin := join.Shallow()
+	switch join.JoinType {
+	case logicalop.InnerJoin, logicalop.SemiJoin:
+		exprCtx := join.SCtx().GetExprCtx()
+		conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.LeftConditions)+
+			len(join.RightConditions)+len(join.OtherConditions)+len(pushedConds))
+		conds = append(conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+		conds = append(conds, join.LeftConditions...)
+		conds = append(conds, join.RightConditions...)
+		conds = append(conds, join.OtherConditions...)
+		conds = append(conds, pushedConds...)
+		conds = expression.ExtractFiltersFromDNFs(exprCtx, conds)
+		conds = expression.PropagateConstant(exprCtx, conds)
+		if dual := logicalop.Conds2TableDual(sel, conds); dual != nil && len(remainConds) == 0 {
+			return []base.LogicalPlan{dual}, false, nil
+		}
+		var eqConds []*expression.ScalarFunction
+		eqConds, leftConds, rightConds, newJoin.OtherConditions = join.ExtractOnCondition(conds, left.Schema(), right.Schema(), true, true)
+		newJoin.EqualConditions = eqConds
+		newJoin.LeftConditions, newJoin.RightConditions = nil, nil
+	case logicalop.LeftOuterJoin, logicalop.RightOuterJoin:
+		outer := left
+		if join.JoinType == logicalop.RightOuterJoin {
+			outer = right
+		}
+		outerConds := make([]expression.Expression, 0, len(pushedConds))
+		for _, cond := range pushedConds {
+			if expression.ExprFromSchema(cond, outer.Schema()) {
+				outerConds = append(outerConds, cond)
+			} else {
+				remainConds = append(remainConds, cond)
+			}
+		}
+		if len(outerConds) == 0 {
+			return nil, false, nil
+		}
+		if join.JoinType == logicalop.LeftOuterJoin {
+			leftConds = outerConds
+		} else {
+			rightConds = outerConds
+		}
+	default:
+		return nil, false, nil
+	}
+	newJoin.SetChildren(childWithSelection(sel, left, expression.RemoveDupExprs(leftConds)),
+		childWithSelection(sel, right, expression.RemoveDupExprs(rightConds)))
+	if len(remainConds) == 0 {
+		return []base.LogicalPlan{newJoin}, false, nil
+	}
+	newSel := logicalop.LogicalSelection{Conditions: remainConds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(newJoin)
+	return []base.LogicalPlan{newSel}, false, nil
+}
+
+// childWithSelection puts the pushed down conditions on the child as a new Selection.
+func childWithSelection(sel *logicalop.LogicalSelection, child base.LogicalPlan, conds []expression.Expression) base.LogicalPlan {
+	if len(conds) == 0 {
+		return child
+	}
+	newSel := logicalop.LogicalSelection{Conditions: conds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+	newSel.SetChildren(child)
+	return newSel
+}
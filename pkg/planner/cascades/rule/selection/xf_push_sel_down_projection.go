@@ -0,0 +1,83 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selection
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/planner/cascades/pattern"
+	"github.com/pingcap/tidb/pkg/planner/cascades/rule"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+)
+
+var _ rule.Rule = &XFPushSelDownProjection{}
+
+// XFPushSelDownProjection is the rule to push the Selection through the Projection, the filters are substituted
+// by the projected expressions, the ones which can't be substituted are kept in the Selection on the top.
+type XFPushSelDownProjection struct {
+	*rule.BaseRule
+}
+
+// NewXFPushSelDownProjection creates a new XFPushSelDownProjection rule.
+func NewXFPushSelDownProjection() *XFPushSelDownProjection {
+	pa := pattern.NewPattern(pattern.OperandSelection, pattern.EngineTiDBOnly)
+	proj := pattern.NewPattern(pattern.OperandProjection, pattern.EngineTiDBOnly)
+	proj.SetChildren(pattern.NewPattern(pattern.OperandAny, pattern.EngineAll))
+	pa.SetChildren(proj)
+	return &XFPushSelDownProjection{
+		BaseRule: rule.NewBaseRule(rule.XFPushSelDownProjection, pa),
+	}
+}
+
+// ID implements the Rule interface.
+func (*XFPushSelDownProjection) ID() uint {
+	return uint(rule.XFPushSelDownProjection)
+}
+
+// XForm implements the Rule interface.
+func (*XFPushSelDownProjection) XForm(selGE base.LogicalPlan) ([]base.LogicalPlan, bool, error) {
+	sel := selGE.GetWrappedLogicalPlan().(*logicalop.LogicalSelection)
+	projGE := selGE.Children()[0]
+	proj := projGE.GetWrappedLogicalPlan().(*logicalop.LogicalProjection)
+	for _, expr := range proj.Exprs {
+		if expression.HasAssignSetVarFunc(expr) {
+			return nil, false, nil
+		}
+	}
+	exprCtx := sel.SCtx().GetExprCtx()
+	pushedConds := make([]expression.Expression, 0, len(sel.Conditions))
+	remainConds := make([]expression.Expression, 0, len(sel.Conditions))
+	for _, cond := range sel.Conditions {
+		substituted, hasFailed, newCond := expression.ColumnSubstituteImpl(exprCtx, cond, proj.Schema(), proj.Exprs, true)
+		if substituted && !hasFailed && !expression.HasGetSetVarFunc(newCond) {
+			pushedConds = append(pushedConds, newCond)
+		} else {
+			remainConds = append(remainConds, cond)
+		}
+	}
+	if len(pushedConds) == 0 {
+		return nil, false, nil
+	}
+	// copy the projection with a new base plan, the projected expressions and schema are shared.
+	newProj := (*proj).Init(proj.SCtx(), proj.QueryBlockOffset())
+	newProj.SetChildren(childWithSelection(sel, projGE.Children()[0], pushedConds))
+	var newPlan base.LogicalPlan = newProj
+	if len(remainConds) > 0 {
+		newSel := logicalop.LogicalSelection{Conditions: remainConds}.Init(sel.SCtx(), sel.QueryBlockOffset())
+		newSel.SetChildren(newPlan)
+		newPlan = newSel
+	}
+	return []base.LogicalPlan{newPlan}, false, nil
+}
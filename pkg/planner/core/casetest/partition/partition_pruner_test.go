@@ -33,38 +33,38 @@ import (
 func TestHashPartitionPruner(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune", `return(true)`)
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune")
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	tk.MustExec("create database test_partition")
-	tk.MustExec("use test_partition")
-	tk.MustExec("drop table if exists t1, t2;")
-	tk.Session().GetSessionVars().EnableClusteredIndex = vardef.ClusteredIndexDefModeIntOnly
-	tk.MustExec("create table t2(id int, a int, b int, primary key(id, a)) partition by hash(id + a) partitions 10;")
-	tk.MustExec("create table t1(id int primary key, a int, b int) partition by hash(id) partitions 10;")
-	tk.MustExec("create table t3(id int, a int, b int, primary key(id, a)) partition by hash(id) partitions 10;")
-	tk.MustExec("create table t4(d datetime, a int, b int, primary key(d, a)) partition by hash(year(d)) partitions 10;")
-	tk.MustExec("create table t5(d date, a int, b int, primary key(d, a)) partition by hash(month(d)) partitions 10;")
-	tk.MustExec("create table t6(a int, b int) partition by hash(a) partitions 3;")
-	tk.MustExec("create table t7(a int, b int) partition by hash(a + b) partitions 10;")
-	tk.MustExec("create table t8(a int, b int) partition by hash(a) partitions 6;")
-	tk.MustExec("create table t9(a bit(1) default null, b int(11) default null) partition by hash(a) partitions 3;") //issue #22619
-	tk.MustExec("create table t10(a bigint unsigned) partition BY hash (a);")
-	tk.MustExec("create table t11(a int, b int) partition by hash(a + a + a + b) partitions 5")
-
-	var input []string
-	var output []struct {
-		SQL    string
-		Result []string
-	}
-	partitionPrunerData := getPartitionPrunerData()
-	partitionPrunerData.LoadTestCases(t, &input, &output)
-	for i, tt := range input {
-		testdata.OnRecord(func() {
-			output[i].SQL = tt
-			output[i].Result = testdata.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
-		})
-		tk.MustQuery(tt).Check(testkit.Rows(output[i].Result...))
-	}
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("create database test_partition")
+		tk.MustExec("use test_partition")
+		tk.MustExec("drop table if exists t1, t2;")
+		tk.Session().GetSessionVars().EnableClusteredIndex = vardef.ClusteredIndexDefModeIntOnly
+		tk.MustExec("create table t2(id int, a int, b int, primary key(id, a)) partition by hash(id + a) partitions 10;")
+		tk.MustExec("create table t1(id int primary key, a int, b int) partition by hash(id) partitions 10;")
+		tk.MustExec("create table t3(id int, a int, b int, primary key(id, a)) partition by hash(id) partitions 10;")
+		tk.MustExec("create table t4(d datetime, a int, b int, primary key(d, a)) partition by hash(year(d)) partitions 10;")
+		tk.MustExec("create table t5(d date, a int, b int, primary key(d, a)) partition by hash(month(d)) partitions 10;")
+		tk.MustExec("create table t6(a int, b int) partition by hash(a) partitions 3;")
+		tk.MustExec("create table t7(a int, b int) partition by hash(a + b) partitions 10;")
+		tk.MustExec("create table t8(a int, b int) partition by hash(a) partitions 6;")
+		tk.MustExec("create table t9(a bit(1) default null, b int(11) default null) partition by hash(a) partitions 3;") //issue #22619
+		tk.MustExec("create table t10(a bigint unsigned) partition BY hash (a);")
+		tk.MustExec("create table t11(a int, b int) partition by hash(a + a + a + b) partitions 5")
+
+		var input []string
+		var output []struct {
+			SQL    string
+			Result []string
+		}
+		partitionPrunerData := getPartitionPrunerData()
+		partitionPrunerData.LoadTestCasesByNameUnderCascades("TestHashPartitionPruner", t, &input, &output, cascades)
+		for i, tt := range input {
+			testdata.OnRecord(func() {
+				output[i].SQL = tt
+				output[i].Result = testdata.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+			})
+			tk.MustQuery(tt).Check(testkit.Rows(output[i].Result...))
+		}
+	})
 }
 
 type testTablePartitionInfo struct {
@@ -124,93 +124,96 @@ func checkPrunePartitionInfo(c *testing.T, query string, infos1 string, plan []s
 func TestListColumnsPartitionPruner(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune", `return(true)`)
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune")
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	tk.MustExec("set tidb_cost_model_version=2")
-	tk.MustExec("drop database if exists test_partition;")
-	tk.MustExec("create database test_partition")
-	tk.MustExec("use test_partition")
-	tk.MustExec("create table t1 (id int, a int, b int) partition by list columns (b,a) (partition p0 values in ((1,1),(2,2),(3,3),(4,4),(5,5)), partition p1 values in ((6,6),(7,7),(8,8),(9,9),(10,10),(null,10)));")
-	tk.MustExec("create table t2 (id int, a int, b int) partition by list columns (id,a,b) (partition p0 values in ((1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5)), partition p1 values in ((6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)));")
-	tk.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
-	tk.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
-
-	// tk1 use to test partition table with index.
-	tk1 := testkit.NewTestKit(t, store)
-	tk1.MustExec("set tidb_cost_model_version=2")
-	tk1.MustExec("drop database if exists test_partition_1;")
-	tk1.MustExec(`set @@session.tidb_regard_null_as_point=false`)
-	tk1.MustExec("create database test_partition_1")
-	tk1.MustExec("use test_partition_1")
-	tk1.MustExec("create table t1 (id int, a int, b int, unique key (a,b,id)) partition by list columns (b,a) (partition p0 values in ((1,1),(2,2),(3,3),(4,4),(5,5)), partition p1 values in ((6,6),(7,7),(8,8),(9,9),(10,10),(null,10)));")
-	tk1.MustExec("create table t2 (id int, a int, b int, unique key (a,b,id)) partition by list columns (id,a,b) (partition p0 values in ((1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5)), partition p1 values in ((6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)));")
-	tk1.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
-	tk1.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
-
-	// tk2 use to compare the result with normal table.
-	tk2 := testkit.NewTestKit(t, store)
-	tk2.MustExec("set tidb_cost_model_version=2")
-	tk2.MustExec("drop database if exists test_partition_2;")
-	tk2.MustExec(`set @@session.tidb_regard_null_as_point=false`)
-	tk2.MustExec("create database test_partition_2")
-	tk2.MustExec("use test_partition_2")
-	tk2.MustExec("create table t1 (id int, a int, b int)")
-	tk2.MustExec("create table t2 (id int, a int, b int)")
-	tk2.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
-	tk2.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
-
-	// Default RPC encoding may cause statistics explain result differ and then the test unstable.
-	tk1.MustExec("set @@tidb_enable_chunk_rpc = on")
-
-	var input []struct {
-		SQL    string
-		Pruner string
-	}
-	var output []struct {
-		SQL       string
-		Result    []string
-		Plan      []string
-		IndexPlan []string
-	}
-	partitionPrunerData := getPartitionPrunerData()
-	partitionPrunerData.LoadTestCases(t, &input, &output)
-	valid := false
-	for i, tt := range input {
-		// Test for table without index.
-		plan := tk.MustQuery("explain format = 'brief' " + tt.SQL)
-		planTree := testdata.ConvertRowsToStrings(plan.Rows())
-		// Test for table with index.
-		indexPlan := tk1.MustQuery("explain format = 'brief' " + tt.SQL)
-		indexPlanTree := testdata.ConvertRowsToStrings(indexPlan.Rows())
-		testdata.OnRecord(func() {
-			output[i].SQL = tt.SQL
-			output[i].Result = testdata.ConvertRowsToStrings(tk.MustQuery(tt.SQL).Sort().Rows())
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		store := tk.Session().GetStore()
+		tk.MustExec("set tidb_cost_model_version=2")
+		tk.MustExec("drop database if exists test_partition;")
+		tk.MustExec("create database test_partition")
+		tk.MustExec("use test_partition")
+		tk.MustExec("create table t1 (id int, a int, b int) partition by list columns (b,a) (partition p0 values in ((1,1),(2,2),(3,3),(4,4),(5,5)), partition p1 values in ((6,6),(7,7),(8,8),(9,9),(10,10),(null,10)));")
+		tk.MustExec("create table t2 (id int, a int, b int) partition by list columns (id,a,b) (partition p0 values in ((1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5)), partition p1 values in ((6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)));")
+		tk.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
+		tk.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
+
+		// tk1 use to test partition table with index.
+		tk1 := testkit.NewTestKit(t, store)
+		tk1.MustExec("set @@tidb_enable_cascades_planner = " + cascades)
+		tk1.MustExec("set tidb_cost_model_version=2")
+		tk1.MustExec("drop database if exists test_partition_1;")
+		tk1.MustExec(`set @@session.tidb_regard_null_as_point=false`)
+		tk1.MustExec("create database test_partition_1")
+		tk1.MustExec("use test_partition_1")
+		tk1.MustExec("create table t1 (id int, a int, b int, unique key (a,b,id)) partition by list columns (b,a) (partition p0 values in ((1,1),(2,2),(3,3),(4,4),(5,5)), partition p1 values in ((6,6),(7,7),(8,8),(9,9),(10,10),(null,10)));")
+		tk1.MustExec("create table t2 (id int, a int, b int, unique key (a,b,id)) partition by list columns (id,a,b) (partition p0 values in ((1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5)), partition p1 values in ((6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)));")
+		tk1.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
+		tk1.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
+
+		// tk2 use to compare the result with normal table.
+		tk2 := testkit.NewTestKit(t, store)
+		tk2.MustExec("set @@tidb_enable_cascades_planner = " + cascades)
+		tk2.MustExec("set tidb_cost_model_version=2")
+		tk2.MustExec("drop database if exists test_partition_2;")
+		tk2.MustExec(`set @@session.tidb_regard_null_as_point=false`)
+		tk2.MustExec("create database test_partition_2")
+		tk2.MustExec("use test_partition_2")
+		tk2.MustExec("create table t1 (id int, a int, b int)")
+		tk2.MustExec("create table t2 (id int, a int, b int)")
+		tk2.MustExec("insert into t1 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,10,null)")
+		tk2.MustExec("insert into t2 (id,a,b) values (1,1,1),(2,2,2),(3,3,3),(4,4,4),(5,5,5),(6,6,6),(7,7,7),(8,8,8),(9,9,9),(10,10,10),(null,null,null)")
+
+		// Default RPC encoding may cause statistics explain result differ and then the test unstable.
+		tk1.MustExec("set @@tidb_enable_chunk_rpc = on")
+
+		var input []struct {
+			SQL    string
+			Pruner string
+		}
+		var output []struct {
+			SQL       string
+			Result    []string
+			Plan      []string
+			IndexPlan []string
+		}
+		partitionPrunerData := getPartitionPrunerData()
+		partitionPrunerData.LoadTestCasesByNameUnderCascades("TestListColumnsPartitionPruner", t, &input, &output, cascades)
+		valid := false
+		for i, tt := range input {
 			// Test for table without index.
-			output[i].Plan = planTree
+			plan := tk.MustQuery("explain format = 'brief' " + tt.SQL)
+			planTree := testdata.ConvertRowsToStrings(plan.Rows())
 			// Test for table with index.
-			output[i].IndexPlan = indexPlanTree
-		})
-		// compare the plan.
-		plan.Check(testkit.Rows(output[i].Plan...))
-		indexPlan.Check(testkit.Rows(output[i].IndexPlan...))
-
-		// compare the pruner information.
-		checkPrunePartitionInfo(t, tt.SQL, tt.Pruner, planTree)
-		checkPrunePartitionInfo(t, tt.SQL, tt.Pruner, indexPlanTree)
-
-		// compare the result.
-		result := tk.MustQuery(tt.SQL).Sort()
-		idxResult := tk1.MustQuery(tt.SQL)
-		result.Check(idxResult.Sort().Rows())
-		result.Check(testkit.Rows(output[i].Result...))
-
-		// If the query doesn't specified the partition, compare the result with normal table
-		if !strings.Contains(tt.SQL, "partition(") {
-			result.Check(tk2.MustQuery(tt.SQL).Sort().Rows())
-			valid = true
+			indexPlan := tk1.MustQuery("explain format = 'brief' " + tt.SQL)
+			indexPlanTree := testdata.ConvertRowsToStrings(indexPlan.Rows())
+			testdata.OnRecord(func() {
+				output[i].SQL = tt.SQL
+				output[i].Result = testdata.ConvertRowsToStrings(tk.MustQuery(tt.SQL).Sort().Rows())
+				// Test for table without index.
+				output[i].Plan = planTree
+				// Test for table with index.
+				output[i].IndexPlan = indexPlanTree
+			})
+			// compare the plan.
+			plan.Check(testkit.Rows(output[i].Plan...))
+			indexPlan.Check(testkit.Rows(output[i].IndexPlan...))
+
+			// compare the pruner information.
+			checkPrunePartitionInfo(t, tt.SQL, tt.Pruner, planTree)
+			checkPrunePartitionInfo(t, tt.SQL, tt.Pruner, indexPlanTree)
+
+			// compare the result.
+			result := tk.MustQuery(tt.SQL).Sort()
+			idxResult := tk1.MustQuery(tt.SQL)
+			result.Check(idxResult.Sort().Rows())
+			result.Check(testkit.Rows(output[i].Result...))
+
+			// If the query doesn't specified the partition, compare the result with normal table
+			if !strings.Contains(tt.SQL, "partition(") {
+				result.Check(tk2.MustQuery(tt.SQL).Sort().Rows())
+				valid = true
+			}
 		}
-	}
-	require.True(t, valid)
+		require.True(t, valid)
+	})
 }
 
 func TestPointGetIntHandleNotFirst(t *testing.T) {
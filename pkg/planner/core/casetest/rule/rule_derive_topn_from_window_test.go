@@ -26,30 +26,30 @@ type Input []string
 
 // TiFlash cases. TopN pushed down to storage only when no partition by.
 func TestPushDerivedTopnFlash(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	dom := domain.GetDomain(tk.Session())
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		dom := domain.GetDomain(tk.Session())
 
-	tk.MustExec("set tidb_opt_derive_topn=1")
-	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t")
-	tk.MustExec("create table t(a int, b int, primary key(b,a))")
-	testkit.SetTiFlashReplica(t, dom, "test", "t")
-	tk.MustExec("set tidb_enforce_mpp=1")
-	tk.MustExec("set @@session.tidb_allow_mpp=ON;")
-	var input Input
-	var output []struct {
-		SQL  string
-		Plan []string
-	}
-	suiteData := GetDerivedTopNSuiteData()
-	suiteData.LoadTestCases(t, &input, &output)
-	for i, sql := range input {
-		plan := tk.MustQuery("explain format = 'brief' " + sql)
-		testdata.OnRecord(func() {
-			output[i].SQL = sql
-			output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
-		})
-		plan.Check(testkit.Rows(output[i].Plan...))
-	}
+		tk.MustExec("set tidb_opt_derive_topn=1")
+		tk.MustExec("use test")
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t(a int, b int, primary key(b,a))")
+		testkit.SetTiFlashReplica(t, dom, "test", "t")
+		tk.MustExec("set tidb_enforce_mpp=1")
+		tk.MustExec("set @@session.tidb_allow_mpp=ON;")
+		var input Input
+		var output []struct {
+			SQL  string
+			Plan []string
+		}
+		suiteData := GetDerivedTopNSuiteData()
+		suiteData.LoadTestCasesByNameUnderCascades("TestPushDerivedTopnFlash", t, &input, &output, cascades)
+		for i, sql := range input {
+			plan := tk.MustQuery("explain format = 'brief' " + sql)
+			testdata.OnRecord(func() {
+				output[i].SQL = sql
+				output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
+			})
+			plan.Check(testkit.Rows(output[i].Plan...))
+		}
+	})
 }
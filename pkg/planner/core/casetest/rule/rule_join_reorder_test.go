@@ -24,7 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func runJoinReorderTestData(t *testing.T, tk *testkit.TestKit, name string) {
+func runJoinReorderTestData(t *testing.T, tk *testkit.TestKit, name, cascades string) {
 	var input []string
 	var output []struct {
 		SQL     string
@@ -32,7 +32,7 @@ func runJoinReorderTestData(t *testing.T, tk *testkit.TestKit, name string) {
 		Warning []string
 	}
 	joinReorderSuiteData := GetJoinReorderSuiteData()
-	joinReorderSuiteData.LoadTestCasesByName(name, t, &input, &output)
+	joinReorderSuiteData.LoadTestCasesByNameUnderCascades(name, t, &input, &output, cascades)
 	require.Equal(t, len(input), len(output))
 	for i := range input {
 		testdata.OnRecord(func() {
@@ -47,62 +47,61 @@ func runJoinReorderTestData(t *testing.T, tk *testkit.TestKit, name string) {
 
 // test the global/session variable tidb_opt_enable_hash_join being set to no
 func TestOptEnableHashJoin(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	tk.MustExec("use test")
-	tk.MustExec("set tidb_opt_enable_hash_join=off")
-	tk.MustExec("create table t1(a int, b int, key(a));")
-	tk.MustExec("create table t2(a int, b int, key(a));")
-	tk.MustExec("create table t3(a int, b int, key(a));")
-	tk.MustExec("create table t4(a int, b int, key(a));")
-	runJoinReorderTestData(t, tk, "TestOptEnableHashJoin")
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("use test")
+		tk.MustExec("set tidb_opt_enable_hash_join=off")
+		tk.MustExec("create table t1(a int, b int, key(a));")
+		tk.MustExec("create table t2(a int, b int, key(a));")
+		tk.MustExec("create table t3(a int, b int, key(a));")
+		tk.MustExec("create table t4(a int, b int, key(a));")
+		runJoinReorderTestData(t, tk, "TestOptEnableHashJoin", cascades)
+	})
 }
 
 func TestJoinOrderHint4TiFlash(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t, t1, t2, t3;")
-	tk.MustExec("create table t(a int, b int, key(a));")
-	tk.MustExec("create table t1(a int, b int, key(a));")
-	tk.MustExec("create table t2(a int, b int, key(a));")
-	tk.MustExec("create table t3(a int, b int, key(a));")
-	tk.MustExec("create table t4(a int, b int, key(a));")
-	tk.MustExec("create table t5(a int, b int, key(a));")
-	tk.MustExec("create table t6(a int, b int, key(a));")
-	tk.MustExec("set @@tidb_enable_outer_join_reorder=true")
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("use test")
+		tk.MustExec("drop table if exists t, t1, t2, t3;")
+		tk.MustExec("create table t(a int, b int, key(a));")
+		tk.MustExec("create table t1(a int, b int, key(a));")
+		tk.MustExec("create table t2(a int, b int, key(a));")
+		tk.MustExec("create table t3(a int, b int, key(a));")
+		tk.MustExec("create table t4(a int, b int, key(a));")
+		tk.MustExec("create table t5(a int, b int, key(a));")
+		tk.MustExec("create table t6(a int, b int, key(a));")
+		tk.MustExec("set @@tidb_enable_outer_join_reorder=true")
 
-	// Create virtual tiflash replica info.
-	dom := domain.GetDomain(tk.Session())
-	testkit.SetTiFlashReplica(t, dom, "test", "t")
-	testkit.SetTiFlashReplica(t, dom, "test", "t1")
-	testkit.SetTiFlashReplica(t, dom, "test", "t2")
-	testkit.SetTiFlashReplica(t, dom, "test", "t3")
-	testkit.SetTiFlashReplica(t, dom, "test", "t4")
-	testkit.SetTiFlashReplica(t, dom, "test", "t5")
-	testkit.SetTiFlashReplica(t, dom, "test", "t6")
+		// Create virtual tiflash replica info.
+		dom := domain.GetDomain(tk.Session())
+		testkit.SetTiFlashReplica(t, dom, "test", "t")
+		testkit.SetTiFlashReplica(t, dom, "test", "t1")
+		testkit.SetTiFlashReplica(t, dom, "test", "t2")
+		testkit.SetTiFlashReplica(t, dom, "test", "t3")
+		testkit.SetTiFlashReplica(t, dom, "test", "t4")
+		testkit.SetTiFlashReplica(t, dom, "test", "t5")
+		testkit.SetTiFlashReplica(t, dom, "test", "t6")
 
-	tk.MustExec("set @@tidb_allow_mpp=1; set @@tidb_enforce_mpp=1;")
-	runJoinReorderTestData(t, tk, "TestJoinOrderHint4TiFlash")
+		tk.MustExec("set @@tidb_allow_mpp=1; set @@tidb_enforce_mpp=1;")
+		runJoinReorderTestData(t, tk, "TestJoinOrderHint4TiFlash", cascades)
+	})
 }
 
 func TestJoinOrderHint4DynamicPartitionTable(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune", `return(true)`)
 	defer failpoint.Disable("github.com/pingcap/tidb/pkg/planner/core/forceDynamicPrune")
-	store := testkit.CreateMockStore(t)
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("use test")
+		tk.MustExec("drop table if exists t, t1, t2, t3;")
+		tk.MustExec(`create table t(a int, b int) partition by hash(a) partitions 3`)
+		tk.MustExec(`create table t1(a int, b int) partition by hash(a) partitions 4`)
+		tk.MustExec(`create table t2(a int, b int) partition by hash(a) partitions 5`)
+		tk.MustExec(`create table t3(a int, b int) partition by hash(b) partitions 3`)
+		tk.MustExec(`create table t4(a int, b int) partition by hash(a) partitions 4`)
+		tk.MustExec(`create table t5(a int, b int) partition by hash(a) partitions 5`)
+		tk.MustExec(`create table t6(a int, b int) partition by hash(b) partitions 3`)
 
-	tk := testkit.NewTestKit(t, store)
-	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t, t1, t2, t3;")
-	tk.MustExec(`create table t(a int, b int) partition by hash(a) partitions 3`)
-	tk.MustExec(`create table t1(a int, b int) partition by hash(a) partitions 4`)
-	tk.MustExec(`create table t2(a int, b int) partition by hash(a) partitions 5`)
-	tk.MustExec(`create table t3(a int, b int) partition by hash(b) partitions 3`)
-	tk.MustExec(`create table t4(a int, b int) partition by hash(a) partitions 4`)
-	tk.MustExec(`create table t5(a int, b int) partition by hash(a) partitions 5`)
-	tk.MustExec(`create table t6(a int, b int) partition by hash(b) partitions 3`)
-
-	tk.MustExec(`set @@tidb_partition_prune_mode="dynamic"`)
-	tk.MustExec("set @@tidb_enable_outer_join_reorder=true")
-	runJoinReorderTestData(t, tk, "TestJoinOrderHint4DynamicPartitionTable")
+		tk.MustExec(`set @@tidb_partition_prune_mode="dynamic"`)
+		tk.MustExec("set @@tidb_enable_outer_join_reorder=true")
+		runJoinReorderTestData(t, tk, "TestJoinOrderHint4DynamicPartitionTable", cascades)
+	})
 }
@@ -22,65 +22,63 @@ import (
 )
 
 func TestOuter2Inner(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("use test")
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t1(a1 int, b1 int, c1 int)")
+		tk.MustExec("create table t2(a2 int, b2 int, c2 int)")
+		tk.MustExec("create table t3(a3 int, b3 int, c3 int)")
+		tk.MustExec("create table t4(a4 int, b4 int, c4 int)")
+		tk.MustExec("create table ti(i int)")
+		tk.MustExec("CREATE TABLE lineitem (L_PARTKEY INTEGER ,L_QUANTITY DECIMAL(15,2),L_EXTENDEDPRICE  DECIMAL(15,2))")
+		tk.MustExec("CREATE TABLE part(P_PARTKEY INTEGER,P_BRAND CHAR(10),P_CONTAINER CHAR(10))")
+		tk.MustExec("CREATE TABLE d (pk int, col_blob blob, col_blob_key blob, col_varchar_key varchar(1) , col_date date, col_int_key int)")
+		tk.MustExec("CREATE TABLE dd (pk int, col_blob blob, col_blob_key blob, col_date date, col_int_key int)")
+		tk.MustExec("create table t0 (a0 int, b0 char, c0 char(2))")
+		tk.MustExec("create table t11 (a1 int, b1 char, c1 char)")
 
-	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t")
-	tk.MustExec("create table t1(a1 int, b1 int, c1 int)")
-	tk.MustExec("create table t2(a2 int, b2 int, c2 int)")
-	tk.MustExec("create table t3(a3 int, b3 int, c3 int)")
-	tk.MustExec("create table t4(a4 int, b4 int, c4 int)")
-	tk.MustExec("create table ti(i int)")
-	tk.MustExec("CREATE TABLE lineitem (L_PARTKEY INTEGER ,L_QUANTITY DECIMAL(15,2),L_EXTENDEDPRICE  DECIMAL(15,2))")
-	tk.MustExec("CREATE TABLE part(P_PARTKEY INTEGER,P_BRAND CHAR(10),P_CONTAINER CHAR(10))")
-	tk.MustExec("CREATE TABLE d (pk int, col_blob blob, col_blob_key blob, col_varchar_key varchar(1) , col_date date, col_int_key int)")
-	tk.MustExec("CREATE TABLE dd (pk int, col_blob blob, col_blob_key blob, col_date date, col_int_key int)")
-	tk.MustExec("create table t0 (a0 int, b0 char, c0 char(2))")
-	tk.MustExec("create table t11 (a1 int, b1 char, c1 char)")
-
-	var input Input
-	var output []struct {
-		SQL  string
-		Plan []string
-	}
-	suiteData := GetOuter2InnerSuiteData()
-	suiteData.LoadTestCasesByName("TestOuter2Inner", t, &input, &output)
-	for i, sql := range input {
-		plan := tk.MustQuery("explain format = 'brief' " + sql)
-		testdata.OnRecord(func() {
-			output[i].SQL = sql
-			output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
-		})
-		plan.Check(testkit.Rows(output[i].Plan...))
-	}
+		var input Input
+		var output []struct {
+			SQL  string
+			Plan []string
+		}
+		suiteData := GetOuter2InnerSuiteData()
+		suiteData.LoadTestCasesByNameUnderCascades("TestOuter2Inner", t, &input, &output, cascades)
+		for i, sql := range input {
+			plan := tk.MustQuery("explain format = 'brief' " + sql)
+			testdata.OnRecord(func() {
+				output[i].SQL = sql
+				output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
+			})
+			plan.Check(testkit.Rows(output[i].Plan...))
+		}
+	})
 }
 
 // can not add this test case to TestOuter2Inner because the collation_connection is different
 func TestOuter2InnerIssue55886(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-
-	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t1")
-	tk.MustExec("drop table if exists t2")
-	tk.MustExec("create table t1(c_foveoe text, c_jbb text, c_cz text not null)")
-	tk.MustExec("create table t2(c_g7eofzlxn int)")
-	tk.MustExec("set collation_connection = 'latin1_bin'")
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		tk.MustExec("use test")
+		tk.MustExec("drop table if exists t1")
+		tk.MustExec("drop table if exists t2")
+		tk.MustExec("create table t1(c_foveoe text, c_jbb text, c_cz text not null)")
+		tk.MustExec("create table t2(c_g7eofzlxn int)")
+		tk.MustExec("set collation_connection = 'latin1_bin'")
 
-	var input Input
-	var output []struct {
-		SQL  string
-		Plan []string
-	}
-	suiteData := GetOuter2InnerSuiteData()
-	suiteData.LoadTestCasesByName("TestOuter2InnerIssue55886", t, &input, &output)
-	for i, sql := range input {
-		plan := tk.MustQuery("explain format = 'brief' " + sql)
-		testdata.OnRecord(func() {
-			output[i].SQL = sql
-			output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
-		})
-		plan.Check(testkit.Rows(output[i].Plan...))
-	}
+		var input Input
+		var output []struct {
+			SQL  string
+			Plan []string
+		}
+		suiteData := GetOuter2InnerSuiteData()
+		suiteData.LoadTestCasesByNameUnderCascades("TestOuter2InnerIssue55886", t, &input, &output, cascades)
+		for i, sql := range input {
+			plan := tk.MustQuery("explain format = 'brief' " + sql)
+			testdata.OnRecord(func() {
+				output[i].SQL = sql
+				output[i].Plan = testdata.ConvertRowsToStrings(plan.Rows())
+			})
+			plan.Check(testkit.Rows(output[i].Plan...))
+		}
+	})
 }
@@ -23,7 +23,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func runPredicatePushdownTestData(t *testing.T, tk *testkit.TestKit, name string) {
+func runPredicatePushdownTestData(t *testing.T, tk *testkit.TestKit, name, cascades string) {
 	var input []string
 	var output []struct {
 		SQL     string
@@ -31,7 +31,7 @@ func runPredicatePushdownTestData(t *testing.T, tk *testkit.TestKit, name string
 		Warning []string
 	}
 	predicatePushdownSuiteData := GetPredicatePushdownSuiteData()
-	predicatePushdownSuiteData.LoadTestCasesByName(name, t, &input, &output)
+	predicatePushdownSuiteData.LoadTestCasesByNameUnderCascades(name, t, &input, &output, cascades)
 	require.Equal(t, len(input), len(output))
 	for i := range input {
 		if strings.Contains(input[i], "set") {
@@ -49,11 +49,11 @@ func runPredicatePushdownTestData(t *testing.T, tk *testkit.TestKit, name string
 }
 
 func TestConstantPropagateWithCollation(t *testing.T) {
-	store := testkit.CreateMockStore(t)
-	tk := testkit.NewTestKit(t, store)
-	// create table
-	tk.MustExec("use test")
-	tk.MustExec("create table t (id int primary key, name varchar(20));")
+	testkit.RunTestUnderCascades(t, func(t *testing.T, tk *testkit.TestKit, cascades string) {
+		// create table
+		tk.MustExec("use test")
+		tk.MustExec("create table t (id int primary key, name varchar(20));")
 
-	runPredicatePushdownTestData(t, tk, "TestConstantPropagateWithCollation")
+		runPredicatePushdownTestData(t, tk, "TestConstantPropagateWithCollation", cascades)
+	})
 }
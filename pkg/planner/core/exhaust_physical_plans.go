@@ -886,6 +886,10 @@ func constructIndexMergeJoin(
 		if hasPrefixCol {
 			continue
 		}
+		// The merge join assumes the inner rows are sorted by all the index columns in the same order.
+		if path != nil && path.HasDescIdxCol() {
+			continue
+		}
 
 		// keyOff2KeyOffOrderByIdx is map the join keys offsets to [0, len(joinKeys)) ordered by the
 		// join key position in inner index.
@@ -1036,6 +1040,10 @@ func extractIndexJoinInnerChildPattern(p *logicalop.LogicalJoin, innerChild base
 	}
 childLoop:
 	for curChild := innerChild; curChild != nil; curChild = nextChild(curChild) {
+		// the memo group is looked through by its representative, whose children are the groups too.
+		if group, ok := curChild.(*memoGroupPlan); ok {
+			curChild = group.LogicalPlan
+		}
 		switch child := curChild.(type) {
 		case *logicalop.DataSource:
 			wrapper.ds = child
@@ -1083,7 +1091,7 @@ func buildDataSource2IndexScanByIndexJoinProp(
 		// on mvi, it will return many index rows which breaks handle-unique attribute here.
 		//
 		// the basic rule is that: mv index can be and can only be accessed by indexMerge operator. (embedded handle duplication)
-		if !isMVIndexPath(path) {
+		if !isMVIndexPath(path) && !isSearchIndexPath(path) {
 			return true // not a MVIndex path, it can successfully be index join probe side.
 		}
 		return false
@@ -1312,7 +1320,7 @@ func buildIndexJoinInner2IndexScan(
 		// on mvi, it will return many index rows which breaks handle-unique attribute here.
 		//
 		// the basic rule is that: mv index can be and can only be accessed by indexMerge operator. (embedded handle duplication)
-		if !isMVIndexPath(path) {
+		if !isMVIndexPath(path) && !isSearchIndexPath(path) {
 			return true // not a MVIndex path, it can successfully be index join probe side.
 		}
 		return false
@@ -1683,7 +1691,11 @@ func constructDS2IndexScanTask(
 		cop.commonHandleCols = ds.CommonHandleCols
 	}
 	is.initSchema(append(path.FullIdxCols, ds.CommonHandleCols...), cop.tablePlan != nil)
-	indexConds, tblConds := splitIndexFilterConditions(ds, filterConds, path.FullIdxCols, path.FullIdxColLens)
+	idxCols, idxColLens := path.FullIdxCols, path.FullIdxColLens
+	if cop.tablePlan == nil {
+		idxCols, idxColLens = appendIndexIncludeCols(ds, path.Index, idxCols, idxColLens)
+	}
+	indexConds, tblConds := splitIndexFilterConditions(ds, filterConds, idxCols, idxColLens)
 
 	// Note: due to a regression in JOB workload, we use the optimizer fix control to enable this for now.
 	//
@@ -131,6 +131,10 @@ func (p *PhysicalIndexScan) OperatorInfo(normalized bool) string {
 			// This branch is not needed in fact, we add this to prevent test result changes under planner/cascades/
 			buffer.WriteString(", stats:pseudo")
 		}
+		if p.cardinalityFeedback.Applied() {
+			buffer.WriteString(", cardinality feedback:")
+			buffer.WriteString(strconv.FormatFloat(p.cardinalityFeedback.Correction, 'f', 2, 64))
+		}
 	}
 	return buffer.String()
 }
@@ -258,6 +262,10 @@ func (p *PhysicalTableScan) OperatorInfo(normalized bool) string {
 			// This branch is not needed in fact, we add this to prevent test result changes under planner/cascades/
 			buffer.WriteString(", stats:pseudo")
 		}
+		if p.cardinalityFeedback.Applied() {
+			buffer.WriteString(", cardinality feedback:")
+			buffer.WriteString(strconv.FormatFloat(p.cardinalityFeedback.Correction, 'f', 2, 64))
+		}
 	}
 	if p.StoreType == kv.TiFlash && p.Table.GetPartitionInfo() != nil && p.IsMPPOrBatchCop && p.SCtx().GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
 		buffer.WriteString(", PartitionTableScan:true")
@@ -395,6 +403,9 @@ func (p *PhysicalUnionScan) ExplainInfo() string {
 
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSelection) ExplainInfo() string {
+	if len(p.rootRuntimeFilters) > 0 {
+		return p.explainRootRuntimeFilters()
+	}
 	exprStr := string(expression.SortedExplainExpressionList(p.SCtx().GetExprCtx().GetEvalCtx(), p.Conditions))
 	if p.TiFlashFineGrainedShuffleStreamCount > 0 {
 		exprStr += fmt.Sprintf(", stream_count: %d", p.TiFlashFineGrainedShuffleStreamCount)
@@ -404,12 +415,27 @@ func (p *PhysicalSelection) ExplainInfo() string {
 
 // ExplainNormalizedInfo implements Plan interface.
 func (p *PhysicalSelection) ExplainNormalizedInfo() string {
+	if len(p.rootRuntimeFilters) > 0 {
+		return p.explainRootRuntimeFilters()
+	}
 	if vardef.IgnoreInlistPlanDigest.Load() {
 		return string(expression.SortedExplainExpressionListIgnoreInlist(p.Conditions))
 	}
 	return string(expression.SortedExplainNormalizedExpressionList(p.Conditions))
 }
 
+func (p *PhysicalSelection) explainRootRuntimeFilters() string {
+	buffer := new(strings.Builder)
+	buffer.WriteString("runtime filter:")
+	for i, runtimeFilter := range p.rootRuntimeFilters {
+		if i != 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(runtimeFilter.ExplainInfo(false))
+	}
+	return buffer.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalProjection) ExplainInfo() string {
 	evalCtx := p.SCtx().GetExprCtx().GetEvalCtx()
@@ -463,6 +489,17 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	redact := p.SCtx().GetSessionVars().EnableRedactLog
+	var str strings.Builder
+	str.WriteString("expr:")
+	str.WriteString(p.Expr.StringWithCtx(p.SCtx().GetExprCtx().GetEvalCtx(), redact))
+	str.WriteString(", path:")
+	str.WriteString(p.Path)
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -726,6 +763,15 @@ func (p *PhysicalHashJoin) explainInfo(normalized bool) string {
 			buffer.WriteString(runtimeFilter.ExplainInfo(true))
 		}
 	}
+	if len(p.rootRuntimeFilters) > 0 {
+		buffer.WriteString(", runtime filter:")
+		for i, runtimeFilter := range p.rootRuntimeFilters {
+			if i != 0 {
+				buffer.WriteString(", ")
+			}
+			buffer.WriteString(runtimeFilter.ExplainInfo(true))
+		}
+	}
 	return buffer.String()
 }
 
@@ -1548,6 +1548,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
 			er.positionToScalarFunc(planCtx, v)
 		}, "")
+	case *ast.MatchAgainst:
+		withPlanCtx(func(planCtx *exprRewriterPlanCtx) {
+			er.matchAgainstToScalarFunc(planCtx, v)
+		}, "MATCH ... AGAINST requires plan context")
 	case *ast.IsNullExpr:
 		er.isNullToExpression(v)
 	case *ast.IsTruthExpr:
@@ -2128,6 +2132,49 @@ func (er *expressionRewriter) patternLikeOrIlikeToExpression(v *ast.PatternLikeO
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+// matchAgainstToScalarFunc builds the match function of MATCH ... AGAINST, whose arguments are the columns, the search
+// string, the search mode and the parser of the full-text index on the columns.
+func (er *expressionRewriter) matchAgainstToScalarFunc(planCtx *exprRewriterPlanCtx, v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = plannererrors.ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	l, colCnt := len(er.ctxStack), len(v.ColumnNames)
+	if _, ok := er.ctxStack[l-1].(*expression.Constant); !ok {
+		er.err = plannererrors.ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	tblInfo, idxInfo := findFullTextIndex(planCtx.builder, er.ctxStack[l-colCnt-1:l-1], er.ctxNameStk[l-colCnt-1:l-1])
+	if idxInfo == nil {
+		er.err = plannererrors.ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+		return
+	}
+	mode := expression.FullTextNaturalLanguageMode
+	if v.Modifier.IsBooleanMode() {
+		mode = expression.FullTextBooleanMode
+	}
+	args := make([]expression.Expression, 0, colCnt+3)
+	args = append(args, er.ctxStack[l-colCnt-1:]...)
+	args = append(args,
+		&expression.Constant{Value: types.NewStringDatum(mode), RetType: types.NewFieldType(mysql.TypeVarString)},
+		&expression.Constant{Value: types.NewStringDatum(string(idxInfo.FullTextInfo.ParserType)), RetType: types.NewFieldType(mysql.TypeVarString)},
+	)
+	function, err := er.newFunction(ast.Match, types.NewFieldType(mysql.TypeDouble), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	if search, ok := expression.GetFullTextSearch(function.(*expression.ScalarFunction)); ok {
+		if search.Stats, er.err = fullTextSearchStats(planCtx.builder.ctx, tblInfo, idxInfo, search); er.err != nil {
+			return
+		}
+	}
+	// The relevance depends on the statistics when the plan is built.
+	planCtx.builder.ctx.GetSessionVars().StmtCtx.SetSkipPlanCache("MATCH ... AGAINST depends on the statistics")
+	er.ctxStackPop(colCnt + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
 func (er *expressionRewriter) regexpToScalarFunc(v *ast.PatternRegexpExpr) {
 	l := len(er.ctxStack)
 	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[l-2:]...)
@@ -137,6 +137,19 @@ func findBestTask4LogicalShowDDLJobs(lp base.LogicalPlan, prop *property.Physica
 	return rt, 1, nil
 }
 
+func findBestTask4LogicalJSONTable(lp base.LogicalPlan, prop *property.PhysicalProperty, planCounter *base.PlanCounterTp, _ *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error) {
+	p := lp.(*logicalop.LogicalJSONTable)
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return base.InvalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{Expr: p.Expr, Path: p.Path, Columns: p.Columns}.Init(p.SCtx(), p.StatsInfo(), p.QueryBlockOffset())
+	jt.SetSchema(p.Schema())
+	planCounter.Dec(1)
+	rt := &RootTask{}
+	rt.SetPlan(jt)
+	return rt, 1, nil
+}
+
 // rebuildChildTasks rebuilds the childTasks to make the clock_th combination.
 func rebuildChildTasks(p *logicalop.BaseLogicalPlan, childTasks *[]base.Task, pp base.PhysicalPlan, childCnts []int64, planCounter int64, ts uint64, opt *optimizetrace.PhysicalOptimizeOp) error {
 	// The taskMap of children nodes should be rolled back first.
@@ -743,7 +756,8 @@ func compareCorrRatio(lhs, rhs *candidatePath) (int, float64) {
 func compareCandidates(sctx base.PlanContext, statsTbl *statistics.Table, tableInfo *model.TableInfo, prop *property.PhysicalProperty, lhs, rhs *candidatePath, preferRange bool) (int, bool) {
 	// Due to #50125, full scan on MVIndex has been disabled, so MVIndex path might lead to 'can't find a proper plan' error at the end.
 	// Avoid MVIndex path to exclude all other paths and leading to 'can't find a proper plan' error, see #49438 for an example.
-	if isMVIndexPath(lhs.path) || isMVIndexPath(rhs.path) {
+	// The same to the spatial and full-text index, which can only be accessed by IndexMerge.
+	if isMVIndexPath(lhs.path) || isMVIndexPath(rhs.path) || isSearchIndexPath(lhs.path) || isSearchIndexPath(rhs.path) {
 		return 0, false
 	}
 	// lhsPseudo == lhs has pseudo (no) stats for the table or index for the lhs path.
@@ -884,6 +898,9 @@ func isMatchProp(ds *logicalop.DataSource, path *util.AccessPath, prop *property
 	all, _ := prop.AllSameOrder()
 	// When the prop is empty or `all` is false, `isMatchProp` is better to be `false` because
 	// it needs not to keep order for index scan.
+	// But if some index columns are stored in descending order, the index can match the prop
+	// with mixed orders, e.g. index idx(a, b desc) matches `order by a, b desc` and `order by a desc, b`.
+	hasDescIdxCol := path.HasDescIdxCol()
 
 	// Basically, if `prop.SortItems` is the prefix of `path.IdxCols`, then `isMatchProp` is true. However, we need to consider
 	// the situations when some columns of `path.IdxCols` are evaluated as constant. For example:
@@ -896,14 +913,17 @@ func isMatchProp(ds *logicalop.DataSource, path *util.AccessPath, prop *property
 	// ```
 	// In the first two `SELECT` statements, `idx_a_b_c` matches the sort order. In the last two `SELECT` statements, `idx_d_c_b_a`
 	// matches the sort order. Hence, we use `path.ConstCols` to deal with the above situations.
-	if !prop.IsSortItemEmpty() && all && len(path.IdxCols) >= len(prop.SortItems) {
+	if !prop.IsSortItemEmpty() && (all || hasDescIdxCol) && len(path.IdxCols) >= len(prop.SortItems) {
 		isMatchProp = true
 		i := 0
-		for _, sortItem := range prop.SortItems {
+		reverse := false
+		for j, sortItem := range prop.SortItems {
 			found := false
 			for ; i < len(path.IdxCols); i++ {
 				if path.IdxColLens[i] == types.UnspecifiedLength && sortItem.Col.EqualColumn(path.IdxCols[i]) {
-					found = true
+					// All the sort items must be either in the same order or in the reversed order of the index columns.
+					found = j == 0 || reverse == (sortItem.Desc != path.IsIdxColDesc(i))
+					reverse = sortItem.Desc != path.IsIdxColDesc(i)
 					i++
 					break
 				}
@@ -920,6 +940,21 @@ func isMatchProp(ds *logicalop.DataSource, path *util.AccessPath, prop *property
 	return isMatchProp
 }
 
+// isDescIndexScan returns whether the index should be scanned in descending order of the keys
+// to keep the order of the prop. The prop must be matched by the path.
+func isDescIndexScan(path *util.AccessPath, prop *property.PhysicalProperty) bool {
+	desc := prop.SortItems[0].Desc
+	if !path.HasDescIdxCol() {
+		return desc
+	}
+	for i, col := range path.IdxCols {
+		if prop.SortItems[0].Col.EqualColumn(col) {
+			return desc != path.IsIdxColDesc(i)
+		}
+	}
+	return desc
+}
+
 // matchPropForIndexMergeAlternatives will match the prop with inside PartialAlternativeIndexPaths, and choose
 // 1 matched alternative to be a determined index merge partial path for each dimension in PartialAlternativeIndexPaths.
 // finally, after we collected the all decided index merge partial paths, we will output a concrete index merge path
@@ -1274,7 +1309,7 @@ func skylinePruning(ds *logicalop.DataSource, prop *property.PhysicalProperty) [
 		preferredPaths := make([]*candidatePath, 0, len(candidates))
 		var hasRangeScanPath bool
 		for _, c := range candidates {
-			if c.path.Forced || c.path.StoreType == kv.TiFlash || (c.path.Index != nil && (c.path.Index.Global || c.path.Index.MVIndex)) {
+			if c.path.Forced || c.path.StoreType == kv.TiFlash || (c.path.Index != nil && (c.path.Index.Global || c.path.Index.MVIndex || c.path.Index.IsSearchIndex())) {
 				preferredPaths = append(preferredPaths, c)
 				continue
 			}
@@ -2074,6 +2109,23 @@ func isIndexCoveringCondition(ds *logicalop.DataSource, condition expression.Exp
 	return true
 }
 
+// appendIndexIncludeCols appends the included columns of the index to the index columns.
+// Their values are stored in the index value, so they can be read by a single index scan.
+func appendIndexIncludeCols(ds *logicalop.DataSource, index *model.IndexInfo,
+	idxCols []*expression.Column, idxColLens []int) ([]*expression.Column, []int) {
+	if !index.HasIncludeColumns() {
+		return idxCols, idxColLens
+	}
+	cols, lens := slices.Clip(idxCols), slices.Clip(idxColLens)
+	for _, ic := range index.IncludeColumns {
+		if col := expression.IndexCol2Col(ds.Columns, ds.Schema().Columns, ic); col != nil {
+			cols = append(cols, col)
+			lens = append(lens, types.UnspecifiedLength)
+		}
+	}
+	return cols, lens
+}
+
 func isSingleScan(lp base.LogicalPlan, indexColumns []*expression.Column, idxColLens []int) bool {
 	ds := lp.(*logicalop.DataSource)
 	if !ds.SCtx().GetSessionVars().OptPrefixIndexSingleScan || ds.ColsRequiringFullLen == nil {
@@ -2113,6 +2165,11 @@ func convertToIndexScan(ds *logicalop.DataSource, prop *property.PhysicalPropert
 		// TODO: make IndexReader support accessing MVIndex directly.
 		return base.InvalidTask, nil
 	}
+	if candidate.path.Index.IsSearchIndex() {
+		// The spatial and full-text index store the cells or the tokens of the values instead of the values, so they
+		// can only be accessed by IndexMerge with the ranges of the cells or the tokens.
+		return base.InvalidTask, nil
+	}
 	if !candidate.path.IsSingleScan {
 		// If it's parent requires single read task, return max cost.
 		if prop.TaskTp == property.CopSingleReadTaskType {
@@ -2272,6 +2329,12 @@ func (is *PhysicalIndexScan) initSchema(idxExprCols []*expression.Column, isDoub
 			})
 		}
 	}
+	var includeCols []*expression.Column
+	if !isDoubleRead {
+		// The included columns are read from the index value when the index covers the query.
+		includeCols = is.buildIncludeCols()
+		indexCols = slices.Insert(indexCols, len(is.Index.Columns), includeCols...)
+	}
 	is.NeedCommonHandle = is.Table.IsCommonHandle
 
 	if is.NeedCommonHandle {
@@ -2279,7 +2342,7 @@ func (is *PhysicalIndexScan) initSchema(idxExprCols []*expression.Column, isDoub
 			indexCols = append(indexCols, idxExprCols[i])
 		}
 	}
-	setHandle := len(indexCols) > len(is.Index.Columns)
+	setHandle := len(indexCols) > len(is.Index.Columns)+len(includeCols)
 	if !setHandle {
 		for i, col := range is.Columns {
 			if (mysql.HasPriKeyFlag(col.GetFlag()) && is.Table.PKIsHandle) || col.ID == model.ExtraHandleID {
@@ -2330,6 +2393,25 @@ func (is *PhysicalIndexScan) initSchema(idxExprCols []*expression.Column, isDoub
 	is.SetSchema(expression.NewSchema(indexCols...))
 }
 
+// buildIncludeCols returns the included columns of the index required by the data source.
+// The columns of the clustered index are skipped since they are read from the handle.
+func (is *PhysicalIndexScan) buildIncludeCols() []*expression.Column {
+	var includeCols []*expression.Column
+	for _, ic := range is.Index.IncludeColumns {
+		tblCol := is.Table.Columns[ic.Offset]
+		if is.Table.HasClusteredIndex() && mysql.HasPriKeyFlag(tblCol.GetFlag()) {
+			continue
+		}
+		for _, col := range is.dataSourceSchema.Columns {
+			if col.ID == tblCol.ID {
+				includeCols = append(includeCols, col)
+				break
+			}
+		}
+	}
+	return includeCols
+}
+
 func (is *PhysicalIndexScan) addSelectionConditionForGlobalIndex(p *logicalop.DataSource, physPlanPartInfo *PhysPlanPartInfo, conditions []expression.Expression) ([]expression.Expression, error) {
 	if !is.Index.Global {
 		return conditions, nil
@@ -2896,7 +2978,8 @@ func convertToBatchPointGet(ds *logicalop.DataSource, prop *property.PhysicalPro
 		}
 		if !prop.IsSortItemEmpty() {
 			batchPointGetPlan.KeepOrder = true
-			batchPointGetPlan.Desc = prop.SortItems[0].Desc
+			// The index keys are sorted physically.
+			batchPointGetPlan.Desc = isDescIndexScan(candidate.path, prop)
 		}
 		if candidate.path.IsSingleScan {
 			batchPointGetPlan.accessCols = candidate.path.IdxCols
@@ -2987,6 +3070,7 @@ func getOriginalPhysicalTableScan(ds *logicalop.DataSource, prop *property.Physi
 		filterCondition: slices.Clone(path.TableFilters),
 	}.Init(ds.SCtx(), ds.QueryBlockOffset())
 	ts.SetSchema(ds.Schema().Clone())
+	ts.cardinalityFeedback = ds.CardinalityFeedback
 	rowCount := path.CountAfterAccess
 	// Add an arbitrary tolerance factor to account for comparison with floating point
 	if (prop.ExpectedCnt + cost.ToleranceFactor) < ds.StatsInfo().RowCount {
@@ -3031,6 +3115,7 @@ func getOriginalPhysicalIndexScan(ds *logicalop.DataSource, prop *property.Physi
 		constColsByCond:  path.ConstCols,
 		prop:             prop,
 	}.Init(ds.SCtx(), ds.QueryBlockOffset())
+	is.cardinalityFeedback = ds.CardinalityFeedback
 	rowCount := path.CountAfterAccess
 	is.initSchema(append(path.FullIdxCols, ds.CommonHandleCols...), !isSingleScan)
 
@@ -3050,7 +3135,7 @@ func getOriginalPhysicalIndexScan(ds *logicalop.DataSource, prop *property.Physi
 	// ScaleByExpectCnt only allows to scale the row count smaller than the table total row count.
 	// But for MV index, it's possible that the IndexRangeScan row count is larger than the table total row count.
 	// Please see the Case 2 in CalcTotalSelectivityForMVIdxPath for an example.
-	if idx.MVIndex && rowCount > ds.TableStats.RowCount {
+	if (idx.MVIndex || idx.IsSearchIndex()) && rowCount > ds.TableStats.RowCount {
 		is.SetStats(ds.TableStats.Scale(rowCount / ds.TableStats.RowCount))
 	} else {
 		is.SetStats(ds.TableStats.ScaleByExpectCnt(rowCount))
@@ -3060,7 +3145,7 @@ func getOriginalPhysicalIndexScan(ds *logicalop.DataSource, prop *property.Physi
 		is.usedStatsInfo = usedStats.GetUsedInfo(is.physicalTableID)
 	}
 	if isMatchProp {
-		is.Desc = prop.SortItems[0].Desc
+		is.Desc = isDescIndexScan(path, prop)
 		is.KeepOrder = true
 	}
 	return is
@@ -37,10 +37,11 @@ import (
 // If a field is not tagged, then it will be skipped.
 func GenHash64Equals4LogicalOps() ([]byte, error) {
 	var structures = []any{logicalop.LogicalJoin{}, logicalop.LogicalAggregation{}, logicalop.LogicalApply{},
-		logicalop.LogicalExpand{}, logicalop.LogicalLimit{}, logicalop.LogicalMaxOneRow{}, logicalop.DataSource{},
+		logicalop.LogicalExpand{}, logicalop.LogicalJSONTable{}, logicalop.LogicalLimit{}, logicalop.LogicalMaxOneRow{}, logicalop.DataSource{},
 		logicalop.LogicalMemTable{}, logicalop.LogicalUnionAll{}, logicalop.LogicalPartitionUnionAll{}, logicalop.LogicalProjection{},
 		logicalop.LogicalSelection{}, logicalop.LogicalSequence{}, logicalop.LogicalShow{}, logicalop.LogicalShowDDLJobs{},
 		logicalop.LogicalSort{}, logicalop.LogicalTableDual{}, logicalop.LogicalTopN{}, logicalop.LogicalUnionScan{}, logicalop.LogicalWindow{},
+		logicalop.LogicalLock{}, logicalop.LogicalCTE{}, logicalop.LogicalCTETable{},
 	}
 	c := new(cc)
 	c.write(codeGenHash64EqualsPrefix)
@@ -129,6 +130,8 @@ func logicalOpName2PlanCodecString(name string) string {
 		return "plancodec.TypeMaxOneRow"
 	case "DataSource":
 		return "plancodec.TypeDataSource"
+	case "LogicalJSONTable":
+		return "plancodec.TypeJSONTable"
 	case "LogicalMemTable":
 		return "plancodec.TypeMemTableScan"
 	case "LogicalUnionAll":
@@ -155,6 +158,12 @@ func logicalOpName2PlanCodecString(name string) string {
 		return "plancodec.TypeUnionScan"
 	case "LogicalWindow":
 		return "plancodec.TypeWindow"
+	case "LogicalLock":
+		return "plancodec.TypeLock"
+	case "LogicalCTE":
+		return "plancodec.TypeCTE"
+	case "LogicalCTETable":
+		return "plancodec.TypeCTETable"
 	default:
 		return ""
 	}
@@ -0,0 +1,310 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+)
+
+/*
+generateIndexMerge4FullTextIndex generates IndexMerge paths for full-text indexes.
+A full-text index stores an index entry for every distinct token of a row, so it can only be accessed by IndexMerge,
+which removes the duplicated handles. The ranges are the tokens which must be contained by the matched rows, and all
+the filters are kept as table filters to compute the relevance.
+For example:
+
+	create table t(a int, body text, fulltext index ft(body));
+	select * from t where match(body) against('distributed database');
+	->
+		IndexMerge(OR)
+			IndexRangeScan(ft, ["database","database"], ["distributed","distributed"])
+			Selection(match(body, "distributed database", ...))
+				TableRowIdScan(t)
+*/
+func generateIndexMerge4FullTextIndex(ds *logicalop.DataSource, normalPathCnt int, filters []expression.Expression) {
+	for idx := range normalPathCnt {
+		path := ds.PossibleAccessPaths[idx]
+		if !isSearchIndexPath(path) || path.Index.FullTextInfo == nil || !isInIndexMergeHints(ds, path.Index.Name.L) {
+			continue
+		}
+		var col *expression.Column
+		colInfo := ds.TableInfo.Columns[path.Index.Columns[0].Offset]
+		for _, c := range ds.TblCols {
+			if c.ID == colInfo.ID {
+				col = c
+				break
+			}
+		}
+		if col == nil {
+			continue
+		}
+		// Use the filter with the smallest count of the ranges.
+		var (
+			ranges ranger.Ranges
+			count  float64
+		)
+		for _, filter := range filters {
+			sf, ok := fullTextMatchFilter(ds, filter)
+			if !ok || !isFullTextIndexMatched(ds.TableInfo, path.Index, sf.GetArgs()[:len(sf.GetArgs())-3]) {
+				continue
+			}
+			search, ok := expression.GetFullTextSearch(sf)
+			if !ok {
+				continue
+			}
+			if rs, cnt, ok := fullTextAccessRanges(search); ok && (ranges == nil || cnt < count) {
+				ranges, count = rs, cnt
+			}
+		}
+		if ranges == nil {
+			continue
+		}
+		// The ranges are built from the value of the search string instead of the access conditions, so they can't be
+		// rebuilt for the plan cache.
+		ds.SCtx().GetSessionVars().StmtCtx.SetSkipPlanCache("full-text index ranges can't be rebuilt")
+
+		// Only the token is stored in the first column of the index, the other columns of the entries are NULL.
+		fullIdxCols := make([]*expression.Column, len(path.Index.Columns))
+		fullIdxColLens := make([]int, len(path.Index.Columns))
+		fullIdxCols[0] = col
+		for i := range fullIdxColLens {
+			fullIdxColLens[i] = types.UnspecifiedLength
+		}
+		partialPath := &util.AccessPath{
+			Index:          path.Index,
+			IdxCols:        []*expression.Column{col},
+			IdxColLens:     []int{types.UnspecifiedLength},
+			FullIdxCols:    fullIdxCols,
+			FullIdxColLens: fullIdxColLens,
+			Ranges:         ranges,
+		}
+		partialPath.CountAfterAccess = min(count, float64(ds.TableStats.HistColl.RealtimeCount))
+		partialPath.CountAfterIndex = partialPath.CountAfterAccess
+		ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, &util.AccessPath{
+			PartialIndexPaths: []*util.AccessPath{partialPath},
+			TableFilters:      filters,
+			CountAfterAccess:  partialPath.CountAfterAccess,
+		})
+	}
+}
+
+// fullTextMatchFilter returns the match function if the filter is only true for the rows with a positive relevance.
+// The supported filters are the match function itself, and the comparisons between the match function and a constant,
+// which are `match(...) > c` with c >= 0 and `match(...) >= c` with c > 0.
+func fullTextMatchFilter(ds *logicalop.DataSource, filter expression.Expression) (*expression.ScalarFunction, bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return nil, false
+	}
+	var (
+		match, bound expression.Expression
+		inclusive    bool
+	)
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.Match:
+		return sf, true
+	case ast.GT, ast.GE:
+		match, bound, inclusive = args[0], args[1], sf.FuncName.L == ast.GE
+	case ast.LT, ast.LE:
+		match, bound, inclusive = args[1], args[0], sf.FuncName.L == ast.LE
+	default:
+		return nil, false
+	}
+	matchFunc, ok := match.(*expression.ScalarFunction)
+	if !ok || matchFunc.FuncName.L != ast.Match {
+		return nil, false
+	}
+	c, ok := bound.(*expression.Constant)
+	if !ok {
+		return nil, false
+	}
+	evalCtx := ds.SCtx().GetExprCtx().GetEvalCtx()
+	d, err := c.Eval(evalCtx, chunk.Row{})
+	if err != nil || d.IsNull() {
+		return nil, false
+	}
+	f, err := d.ToFloat64(evalCtx.TypeCtx())
+	if err != nil || f < 0 || (inclusive && f == 0) {
+		return nil, false
+	}
+	return matchFunc, true
+}
+
+// isFullTextIndexMatched returns whether the columns are the same as the columns of the full-text index.
+func isFullTextIndexMatched(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, cols []expression.Expression) bool {
+	if len(cols) != len(idxInfo.Columns) {
+		return false
+	}
+	for _, idxCol := range idxInfo.Columns {
+		found := false
+		for _, expr := range cols {
+			if col, ok := expr.(*expression.Column); ok && col.ID == tblInfo.Columns[idxCol.Offset].ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// findFullTextIndex returns the public full-text index on the columns of MATCH, the columns must be the columns of the
+// same table.
+func findFullTextIndex(b *PlanBuilder, cols []expression.Expression, names types.NameSlice) (*model.TableInfo, *model.IndexInfo) {
+	for i, expr := range cols {
+		if _, ok := expr.(*expression.Column); !ok || names[i].OrigTblName.L == "" ||
+			names[i].DBName.L != names[0].DBName.L || names[i].TblName.L != names[0].TblName.L ||
+			names[i].OrigTblName.L != names[0].OrigTblName.L {
+			return nil, nil
+		}
+	}
+	tblInfo, err := b.is.TableInfoByName(names[0].DBName, names[0].OrigTblName)
+	if err != nil {
+		return nil, nil
+	}
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.FullTextInfo == nil || idxInfo.State != model.StatePublic || len(idxInfo.Columns) != len(cols) {
+			continue
+		}
+		matched := true
+		for _, idxCol := range idxInfo.Columns {
+			found := false
+			for _, name := range names {
+				if name.OrigColName.L == idxCol.Name.L {
+					found = true
+					break
+				}
+			}
+			if !found {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return tblInfo, idxInfo
+		}
+	}
+	return nil, nil
+}
+
+// fullTextTermRanges returns the ranges of the full-text index containing the rows which contain the term. A phrase
+// is accessed by its first token.
+func fullTextTermRanges(term expression.FullTextTerm) ranger.Ranges {
+	if term.Prefix {
+		return ranger.BuildFullTextIndexRanges(nil, term.Tokens[:1])
+	}
+	return ranger.BuildFullTextIndexRanges(term.Tokens[:1], nil)
+}
+
+// fullTextAccessRanges returns the ranges of the full-text index containing all the rows matching the search, and the
+// estimated count of the ranges. In the boolean mode, only the rarest required term is accessed if there are required
+// terms. Otherwise, a matched row contains at least one of the terms, so all the terms are accessed.
+func fullTextAccessRanges(search *expression.FullTextSearch) (ranger.Ranges, float64, bool) {
+	docFreq := func(i int) float64 {
+		if search.Stats == nil || i >= len(search.Stats.DocFreqs) {
+			return 0
+		}
+		return search.Stats.DocFreqs[i]
+	}
+	best := -1
+	for i, term := range search.Terms {
+		if term.Required && (best < 0 || docFreq(i) < docFreq(best)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return fullTextTermRanges(search.Terms[best]), docFreq(best), true
+	}
+	var (
+		tokens, prefixes []string
+		count            float64
+	)
+	for i, term := range search.Terms {
+		if term.Excluded {
+			continue
+		}
+		if term.Prefix {
+			prefixes = append(prefixes, term.Tokens[0])
+		} else {
+			tokens = append(tokens, term.Tokens[0])
+		}
+		count += docFreq(i)
+	}
+	if len(tokens) == 0 && len(prefixes) == 0 {
+		return nil, 0, false
+	}
+	return ranger.BuildFullTextIndexRanges(tokens, prefixes), count, true
+}
+
+// fullTextSearchStats returns the statistics of the full-text search, which are estimated from the statistics of the
+// full-text index. The statistics of the index are loaded synchronously if they are not loaded.
+func fullTextSearchStats(sctx base.PlanContext, tblInfo *model.TableInfo, idxInfo *model.IndexInfo, search *expression.FullTextSearch) (*expression.FullTextStats, error) {
+	statsTbl := getStatsTable(sctx, tblInfo, tblInfo.ID)
+	sessVars := sctx.GetSessionVars()
+	if _, loadNeeded := statsTbl.IndexIsLoadNeeded(idxInfo.ID); loadNeeded && !statsTbl.Pseudo && !sessVars.InRestrictedSQL &&
+		!sessVars.StmtCtx.IsSyncStatsFailed {
+		if syncWait := sessVars.StatsLoadSyncWait.Load(); syncWait > 0 {
+			item := model.StatsLoadItem{TableItemID: model.TableItemID{TableID: tblInfo.ID, ID: idxInfo.ID, IsIndex: true}, FullLoad: true}
+			if err := RequestLoadStats(sctx, []model.StatsLoadItem{item}, syncWait); err != nil {
+				return nil, err
+			}
+			if err := syncWaitStatsLoad(sctx); err != nil {
+				return nil, err
+			}
+			statsTbl = getStatsTable(sctx, tblInfo, tblInfo.ID)
+		}
+	}
+	stats := &expression.FullTextStats{
+		RowCount: float64(statsTbl.RealtimeCount),
+		DocFreqs: make([]float64, 0, len(search.Terms)),
+	}
+	if idx := statsTbl.GetIdx(idxInfo.ID); idx != nil && idx.IsFullLoad() {
+		if analyzeCount := statsTbl.GetAnalyzeRowCount(); analyzeCount > 0 {
+			stats.AvgDocLen = idx.TotalRowCount() / analyzeCount
+		}
+	}
+	for _, term := range search.Terms {
+		var docFreq float64
+		tokens := term.Tokens
+		if term.Prefix {
+			tokens = tokens[:1]
+		}
+		// The rows containing a phrase contain all its tokens.
+		for i, token := range tokens {
+			ranges := fullTextTermRanges(expression.FullTextTerm{Tokens: []string{token}, Prefix: term.Prefix})
+			cnt, _, err := cardinality.GetRowCountByIndexRanges(sctx, &statsTbl.HistColl, idxInfo.ID, ranges)
+			if err != nil {
+				return nil, err
+			}
+			if i == 0 || cnt < docFreq {
+				docFreq = cnt
+			}
+		}
+		stats.DocFreqs = append(stats.DocFreqs, docFreq)
+	}
+	return stats, nil
+}
@@ -81,7 +81,7 @@ func generateIndexMergePath(ds *logicalop.DataSource) error {
 
 	regularPathCount := len(ds.PossibleAccessPaths)
 
-	// Now we have 3 entry functions to generate IndexMerge paths:
+	// Now we have 5 entry functions to generate IndexMerge paths:
 	// 1. Generate AND type IndexMerge for non-MV indexes and all OR type IndexMerge.
 	var err error
 	if warningMsg, err = generateOtherIndexMerge(ds, regularPathCount, indexMergeConds); err != nil {
@@ -91,8 +91,12 @@ func generateIndexMergePath(ds *logicalop.DataSource) error {
 	if err := generateANDIndexMerge4MVIndex(ds, regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	// 3. Generate IndexMerge for spatial indexes. It can only use one index in an IndexMerge path.
+	generateIndexMerge4SpatialIndex(ds, regularPathCount, indexMergeConds)
+	// 4. Generate IndexMerge for full-text indexes. It can only use one index in an IndexMerge path.
+	generateIndexMerge4FullTextIndex(ds, regularPathCount, indexMergeConds)
 	oldIndexMergeCount := len(ds.PossibleAccessPaths)
-	// 3. Generate AND type IndexMerge for MV indexes. It can use multiple MV and non-MV indexes in an IndexMerge path.
+	// 5. Generate AND type IndexMerge for MV indexes. It can use multiple MV and non-MV indexes in an IndexMerge path.
 	if err := generateANDIndexMerge4ComposedIndex(ds, regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
@@ -245,6 +249,10 @@ func accessPathsForConds(
 			return nil
 		}
 	} else {
+		// The ranges of the spatial and full-text index can't be built from the conditions by the ranger.
+		if path.Index.IsSearchIndex() {
+			return nil
+		}
 		newPath.Index = path.Index
 		if !isInIndexMergeHints(ds, newPath.Index.Name.L) {
 			return nil
@@ -286,8 +294,8 @@ func generateANDIndexMerge4NormalIndex(ds *logicalop.DataSource, normalPathCnt i
 		if ds.PossibleAccessPaths[i].IsTablePath() {
 			continue
 		}
-		// since this code path is only for normal index, skip mv index here.
-		if ds.PossibleAccessPaths[i].Index.MVIndex {
+		// since this code path is only for normal index, skip mv index, spatial index and full-text index here.
+		if ds.PossibleAccessPaths[i].Index.MVIndex || ds.PossibleAccessPaths[i].Index.IsSearchIndex() {
 			continue
 		}
 		if !isSpecifiedInIndexMergeHints(ds, originalPath.Index.Name.L) {
@@ -641,6 +649,9 @@ func generateANDIndexMerge4ComposedIndex(ds *logicalop.DataSource, normalPathCnt
 				!isInIndexMergeHints(ds, ds.PossibleAccessPaths[idx].Index.Name.L)) {
 			continue
 		}
+		if isSearchIndexPath(ds.PossibleAccessPaths[idx]) {
+			continue
+		}
 		if isMVIndexPath(ds.PossibleAccessPaths[idx]) {
 			mvIndexPathCnt++
 		}
@@ -1376,3 +1387,8 @@ func unwrapJSONCast(expr expression.Expression) (expression.Expression, bool) {
 func isMVIndexPath(path *util.AccessPath) bool {
 	return !path.IsTablePath() && path.Index != nil && path.Index.MVIndex
 }
+
+// isSearchIndexPath returns whether the path is a spatial or full-text index, which can only be accessed by IndexMerge.
+func isSearchIndexPath(path *util.AccessPath) bool {
+	return !path.IsTablePath() && path.Index != nil && path.Index.IsSearchIndex()
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/util"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+)
+
+// spatialIndexSelectivity is the estimated selectivity of the ranges of a spatial index, which is the same as the
+// pseudo selectivity of a BETWEEN condition since there are no statistics for the spatial index.
+const spatialIndexSelectivity = 1.0 / 40
+
+/*
+generateIndexMerge4SpatialIndex generates IndexMerge paths for spatial indexes.
+A spatial index stores the cells covering the bounding box of a value instead of the value, and a row may have several
+index entries, so it can only be accessed by IndexMerge, which removes the duplicated handles. The ranges are the cells
+covering the search box of the filter, and all the filters are kept as table filters to check the values.
+For example:
+
+	create table t(g geometry not null srid 0, spatial index sidx(g));
+	select * from t where st_intersects(g, st_geomfromtext('POLYGON((0 0,0 1,1 1,1 0,0 0))'));
+	->
+		IndexMerge(OR)
+			IndexRangeScan(sidx, cells covering the box (0 0,1 1))
+			Selection(st_intersects(g, ...))
+				TableRowIdScan(t)
+*/
+func generateIndexMerge4SpatialIndex(ds *logicalop.DataSource, normalPathCnt int, filters []expression.Expression) {
+	for idx := range normalPathCnt {
+		path := ds.PossibleAccessPaths[idx]
+		if !isSearchIndexPath(path) || !path.Index.Spatial || !isInIndexMergeHints(ds, path.Index.Name.L) {
+			continue
+		}
+		colInfo := ds.TableInfo.Columns[path.Index.Columns[0].Offset]
+		// Only use the spatial index on a column with an SRID attribute. Otherwise, the rows may have different SRIDs,
+		// and the spatial functions return errors for the rows of a different SRID instead of skipping them.
+		srid, ok := colInfo.GetSRID()
+		if !ok {
+			continue
+		}
+		var col *expression.Column
+		for _, c := range ds.TblCols {
+			if c.ID == colInfo.ID {
+				col = c
+				break
+			}
+		}
+		if col == nil {
+			continue
+		}
+		// Use the smallest search box among the filters.
+		var (
+			box   types.GeomBox
+			found bool
+		)
+		for _, filter := range filters {
+			if b, ok := spatialSearchBox(ds, filter, col, srid); ok && (!found || b.Area() < box.Area()) {
+				box, found = b, true
+			}
+		}
+		if !found {
+			continue
+		}
+		// The ranges are built from the values of the constants instead of the access conditions, so they can't be
+		// rebuilt for the plan cache.
+		ds.SCtx().GetSessionVars().StmtCtx.SetSkipPlanCache("spatial index ranges can't be rebuilt")
+
+		partialPath := &util.AccessPath{
+			Index:          path.Index,
+			IdxCols:        []*expression.Column{col},
+			IdxColLens:     []int{types.UnspecifiedLength},
+			FullIdxCols:    []*expression.Column{col},
+			FullIdxColLens: []int{types.UnspecifiedLength},
+			Ranges:         ranger.BuildSpatialIndexRanges(srid, box),
+		}
+		partialPath.CountAfterAccess = float64(ds.TableStats.HistColl.RealtimeCount) * spatialIndexSelectivity
+		partialPath.CountAfterIndex = partialPath.CountAfterAccess
+		ds.PossibleAccessPaths = append(ds.PossibleAccessPaths, &util.AccessPath{
+			PartialIndexPaths: []*util.AccessPath{partialPath},
+			TableFilters:      filters,
+			CountAfterAccess:  partialPath.CountAfterAccess,
+		})
+	}
+}
+
+// spatialSearchBox returns a box which intersects the bounding boxes of all the values of col satisfying the filter.
+// The supported filters are the spatial relations between col and a constant geometry, and the distance between col
+// and a constant geometry which is less than a constant.
+func spatialSearchBox(ds *logicalop.DataSource, filter expression.Expression, col *expression.Column, srid uint32) (types.GeomBox, bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok {
+		return types.GeomBox{}, false
+	}
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.STIntersects, ast.STContains, ast.STWithin, ast.STEquals:
+		// All of them imply that the geometries intersect.
+		return spatialConstantBox(ds, args, col, srid)
+	case ast.LE, ast.LT:
+		return spatialDistanceBox(ds, args[0], args[1], col, srid)
+	case ast.GE, ast.GT:
+		return spatialDistanceBox(ds, args[1], args[0], col, srid)
+	}
+	return types.GeomBox{}, false
+}
+
+// spatialDistanceBox returns the search box of `distance <= maxDist`.
+func spatialDistanceBox(ds *logicalop.DataSource, distance, maxDist expression.Expression, col *expression.Column, srid uint32) (types.GeomBox, bool) {
+	sf, ok := distance.(*expression.ScalarFunction)
+	if !ok || (sf.FuncName.L != ast.STDistance && sf.FuncName.L != ast.STDistanceSphere) {
+		return types.GeomBox{}, false
+	}
+	dist, ok := spatialConstantFloat(ds, maxDist)
+	if !ok || dist < 0 || math.IsInf(dist, 0) {
+		return types.GeomBox{}, false
+	}
+	args := sf.GetArgs()
+	box, ok := spatialConstantBox(ds, args[:2], col, srid)
+	if !ok {
+		return types.GeomBox{}, false
+	}
+	if sf.FuncName.L == ast.STDistance {
+		return box.ExpandByDistance(srid, dist), true
+	}
+	radius := float64(types.DefaultSphereRadius)
+	if len(args) > 2 {
+		if radius, ok = spatialConstantFloat(ds, args[2]); !ok || !(radius > 0) || math.IsInf(radius, 0) {
+			return types.GeomBox{}, false
+		}
+	}
+	// Shrink the radius a little to cover the rounding errors of the distance.
+	return box.ExpandOnSphere(dist, radius*(1-1e-9)), true
+}
+
+// spatialConstantBox returns the bounding box of the constant geometry if args are col and a constant geometry of the
+// same SRID.
+func spatialConstantBox(ds *logicalop.DataSource, args []expression.Expression, col *expression.Column, srid uint32) (types.GeomBox, bool) {
+	var arg expression.Expression
+	if c, ok := args[0].(*expression.Column); ok && c.EqualColumn(col) {
+		arg = args[1]
+	} else if c, ok := args[1].(*expression.Column); ok && c.EqualColumn(col) {
+		arg = args[0]
+	} else {
+		return types.GeomBox{}, false
+	}
+	c, ok := arg.(*expression.Constant)
+	if !ok {
+		return types.GeomBox{}, false
+	}
+	d, err := c.Eval(ds.SCtx().GetExprCtx().GetEvalCtx(), chunk.Row{})
+	if err != nil || d.IsNull() {
+		return types.GeomBox{}, false
+	}
+	g, err := types.ParseGeometry("", d.GetBytes())
+	if err != nil || g.SRID != srid {
+		return types.GeomBox{}, false
+	}
+	return g.BoundingBox()
+}
+
+func spatialConstantFloat(ds *logicalop.DataSource, expr expression.Expression) (float64, bool) {
+	c, ok := expr.(*expression.Constant)
+	if !ok {
+		return 0, false
+	}
+	evalCtx := ds.SCtx().GetExprCtx().GetEvalCtx()
+	d, err := c.Eval(evalCtx, chunk.Row{})
+	if err != nil || d.IsNull() {
+		return 0, false
+	}
+	f, err := d.ToFloat64(evalCtx.TypeCtx())
+	if err != nil || math.IsNaN(f) {
+		return 0, false
+	}
+	return f, true
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"math/bits"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -452,6 +453,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			isTableName = true
 		default:
 			err = plannererrors.ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -495,6 +499,116 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 	}
 }
 
+// buildJSONTable builds the JSON_TABLE table function. The document expression
+// is rewritten against the outer schemas only, the columns of the tables
+// preceding the JSON_TABLE are pushed as outer schemas by buildJoin, so the
+// references to them become correlated columns.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName ast.CIStr) (base.LogicalPlan, error) {
+	if asName.L == "" {
+		return nil, plannererrors.ErrTableFunctionMustHaveAlias.GenWithStackByArgs()
+	}
+	if _, err := types.ParseJSONPathExpr(jt.Path); err != nil {
+		return nil, err
+	}
+
+	dual := logicalop.LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, plannererrors.ErrNotSupportedYet.GenWithStackByArgs("subquery in JSON_TABLE")
+	}
+	if expr.GetType(b.ctx.GetExprCtx().GetEvalCtx()).EvalType() != types.ETJson {
+		expr = expression.BuildCastFunction(b.ctx.GetExprCtx(), expr, types.NewFieldType(mysql.TypeJSON))
+	}
+
+	p := logicalop.LogicalJSONTable{Expr: expr, Path: jt.Path, Columns: jt.Columns}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	if err := b.buildJSONTableColumns(jt.Columns, asName, schema, &names); err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.SetOutputNames(names)
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTableColumns appends the columns of the COLUMNS clause to the schema
+// in pre-order, the columns of NESTED PATH are expanded in place.
+func (b *PlanBuilder) buildJSONTableColumns(cols []*ast.JSONTableColumn, asName ast.CIStr, schema *expression.Schema, names *types.NameSlice) error {
+	for _, col := range cols {
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			if _, err := types.ParseJSONPathExpr(col.Path); err != nil {
+				return err
+			}
+		}
+		var ft *types.FieldType
+		switch col.Tp {
+		case ast.JSONTableColumnNested:
+			if err := b.buildJSONTableColumns(col.Columns, asName, schema, names); err != nil {
+				return err
+			}
+			continue
+		case ast.JSONTableColumnOrdinality:
+			ft = types.NewFieldTypeBuilder().SetType(mysql.TypeLong).SetFlag(mysql.UnsignedFlag).SetFlen(10).
+				SetCharset(charset.CharsetBin).SetCollate(charset.CollationBin).BuildP()
+		default:
+			ft = b.completeJSONTableFieldType(col.FieldType)
+			for _, resp := range []*ast.JSONTableResponse{col.OnEmpty, col.OnError} {
+				if resp == nil || resp.Tp != ast.JSONTableResponseDefault || ft.GetType() != mysql.TypeJSON {
+					continue
+				}
+				if _, err := types.ParseBinaryJSONFromString(resp.Default); err != nil {
+					return err
+				}
+			}
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  ft,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			OrigTblName: asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return nil
+}
+
+// completeJSONTableFieldType fills the charset, collation, flen and decimal
+// which are not specified in the column type of JSON_TABLE.
+func (b *PlanBuilder) completeJSONTableFieldType(tp *types.FieldType) *types.FieldType {
+	ft := tp.Clone()
+	if ft.EvalType() == types.ETString {
+		if ft.GetCharset() == "" {
+			chs, coll := b.ctx.GetSessionVars().GetCharsetInfo()
+			ft.SetCharset(chs)
+			ft.SetCollate(coll)
+		} else if ft.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(ft.GetCharset())
+			if err == nil {
+				ft.SetCollate(coll)
+			}
+		}
+	} else {
+		ft.SetCharset(charset.CharsetBin)
+		ft.SetCollate(charset.CollationBin)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(defaultFlen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(defaultDecimal)
+	}
+	return ft
+}
+
 func setPreferredStoreType(ds *logicalop.DataSource, hintInfo *h.PlanHints) {
 	if hintInfo == nil {
 		return
@@ -568,10 +682,33 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		return nil, err
 	}
 
+	// The LATERAL derived table or the table function on the right side can
+	// refer to the columns of the left side.
+	lateral := isLateralSource(joinNode.Right)
+	if lateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if lateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	if lateral {
+		lateral = false
+		for _, corCol := range coreusage.ExtractCorrelatedCols4LogicalPlan(rightPlan) {
+			if leftPlan.Schema().Contains(&corCol.Column) {
+				lateral = true
+				break
+			}
+		}
+	}
+	if lateral && joinNode.Tp == ast.RightJoin {
+		return nil, plannererrors.ErrTableFunctionForbiddenJoinType.GenWithStackByArgs(joinNode.Right.(*ast.TableSource).AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*logicalop.LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -582,7 +719,20 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := logicalop.LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var (
+		joinPlan *logicalop.LogicalJoin
+		// resultPlan is the joinPlan itself, or the LogicalApply which embeds
+		// the joinPlan for the lateral references.
+		resultPlan base.LogicalPlan
+	)
+	if lateral {
+		b.optFlag = b.optFlag | rule.FlagBuildKeyInfo | rule.FlagDecorrelate
+		ap := logicalop.LogicalApply{}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = logicalop.LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.SetOutputNames(make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len()))
@@ -682,7 +832,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == logicalop.InnerJoin {
 			sel := logicalop.LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -692,7 +842,22 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (base.L
 		joinPlan.CartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// isLateralSource checks whether the node is a LATERAL derived table or a table
+// function like JSON_TABLE, which can refer to the preceding tables in the FROM
+// clause.
+func isLateralSource(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -2191,6 +2356,18 @@ func (a *havingWindowAndOrderbyExprResolver) Enter(n ast.Node) (node ast.Node, s
 	case *ast.WindowSpec:
 		a.inWindowSpec = true
 	case *driver.ParamMarkerExpr, *ast.ColumnNameExpr, *ast.ColumnName:
+	case *ast.MatchAgainst:
+		a.inExpr = true
+		if a.curClause == orderByClause && !a.inAggFunc && !a.inWindowFunc && !a.inWindowSpec {
+			// The columns of MATCH are resolved by their names, so make sure they are in the output of the projection.
+			// For example: select id from t order by match(body) against('tidb').
+			for _, name := range n.(*ast.MatchAgainst).ColumnNames {
+				if _, err := a.resolveFromPlan(&ast.ColumnNameExpr{Name: name}, a.p, false); err != nil {
+					a.err = err
+					return n, true
+				}
+			}
+		}
 	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
 		// Enter a new context, skip it.
 		// For example: select sum(c) + c + exists(select c from t) from t;
@@ -3680,6 +3857,9 @@ func (b *PlanBuilder) TableHints() *h.PlanHints {
 }
 
 func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p base.LogicalPlan, err error) {
+	if mvSel := b.rewriteByMaterializedView(ctx, sel); mvSel != nil {
+		sel = mvSel
+	}
 	b.pushSelectOffset(sel.QueryBlockOffset)
 	b.pushTableHints(sel.TableHints, sel.QueryBlockOffset)
 	defer func() {
@@ -5337,7 +5517,9 @@ func pruneAndBuildColPositionInfoForDelete(
 		tblInfo := tbl.Meta()
 		// If it's partitioned table, or has foreign keys, or is point get plan, we can't prune the columns, currently.
 		// nonPrunedSet will be nil if it's a point get or has foreign keys.
-		if tblInfo.GetPartitionInfo() != nil || hasFK || nonPruned == nil {
+		// The columns are not pruned either if there are partial indexes, whose conditions are evaluated on the full row.
+		if tblInfo.GetPartitionInfo() != nil || hasFK || nonPruned == nil ||
+			slices.ContainsFunc(tblInfo.Indices, (*model.IndexInfo).HasCondition) {
 			err = buildSingleTableColPosInfoForDelete(tbl, cols2PosInfo)
 			if err != nil {
 				return nil, nil, err
@@ -5692,7 +5874,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		for _, tl := range tableList {
 			tlW := b.resolveCtx.GetTableName(tl)
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tlW) || tlW.TableInfo.IsView() || tlW.TableInfo.IsSequence() {
+				if isCTE(tlW) || tlW.TableInfo.IsView() || tlW.TableInfo.IsSequence() || b.isReadOnlyMaterializedView(tlW.TableInfo) {
 					return nil, nil, false, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				foundListItem = true
@@ -5994,6 +6176,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if b.isReadOnlyMaterializedView(tableInfo) {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+			}
 			if sessionVars.User != nil {
 				authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6014,6 +6199,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (base
 			if tblW.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if b.isReadOnlyMaterializedView(tblW.TableInfo) {
+				return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -0,0 +1,523 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/privilege"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// buildCreateMaterializedView builds the SELECT statement of the materialized
+// view to check it, and fills the names and the types of the view columns.
+func (b *PlanBuilder) buildCreateMaterializedView(ctx context.Context, v *ast.CreateMaterializedViewStmt) error {
+	if err := checkForUserVariables(v.Select); err != nil {
+		return err
+	}
+	b.isCreateView = true
+	b.capFlag |= canExpandAST
+	defer func() {
+		b.capFlag &= ^canExpandAST
+		b.isCreateView = false
+	}()
+
+	if stmt := findStmtAsViewSchema(v.Select); stmt != nil {
+		stmt.AsViewSchema = true
+	}
+	plan, err := b.Build(ctx, resolve.NewNodeWWithCtx(v.Select, b.resolveCtx))
+	if err != nil {
+		return err
+	}
+	schema := plan.Schema()
+	if v.Cols == nil {
+		adjustOverlongViewColname(plan.(base.LogicalPlan))
+		v.Cols = make([]ast.CIStr, len(schema.Columns))
+		for i, name := range plan.OutputNames() {
+			v.Cols[i] = name.ColName
+		}
+	}
+	if len(v.Cols) != schema.Len() {
+		return dbterror.ErrViewWrongList
+	}
+	v.ColTypes = make([]*types.FieldType, len(schema.Columns))
+	for i, col := range schema.Columns {
+		v.ColTypes[i] = materializedViewColumnType(col.RetType)
+	}
+	return nil
+}
+
+// isReadOnlyMaterializedView checks whether the table is a materialized view
+// which can't be modified by the statement. The rows of the view are only
+// changed by the internal statements refreshing it.
+func (b *PlanBuilder) isReadOnlyMaterializedView(tblInfo *model.TableInfo) bool {
+	return tblInfo.IsMaterializedView() && !b.ctx.GetSessionVars().InRestrictedSQL
+}
+
+// materializedViewColumnType returns the type of the view column which stores
+// the values of the type.
+func materializedViewColumnType(tp *types.FieldType) *types.FieldType {
+	if tp.GetType() == mysql.TypeNull {
+		// Same as MySQL, the NULL column of CREATE TABLE ... SELECT is BINARY(0).
+		nt := types.NewFieldType(mysql.TypeString)
+		nt.SetFlen(0)
+		types.SetBinChsClnFlag(nt)
+		return nt
+	}
+	nt := tp.Clone()
+	nt.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag | mysql.ZerofillFlag))
+	return nt
+}
+
+// mviewRestoreFlags are the flags to restore the expressions for comparison.
+const mviewRestoreFlags = format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase |
+	format.RestoreNameBackQuotes | format.RestoreStringWithoutCharset
+
+// mviewExprNormalizer removes the qualifiers of the columns and lowercases the
+// column names, so that the expressions of the query and the view can be
+// compared by the restored text. The expressions which can't be matched by
+// the text make it invalid.
+type mviewExprNormalizer struct {
+	cols  []*ast.ColumnName
+	saved []ast.ColumnName
+	valid bool
+}
+
+func (n *mviewExprNormalizer) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.ColumnName:
+		n.cols = append(n.cols, x)
+		n.saved = append(n.saved, *x)
+		x.Schema, x.Table = ast.CIStr{}, ast.CIStr{}
+		x.Name = ast.NewCIStr(x.Name.L)
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, ast.ParamMarkerExpr, *ast.VariableExpr,
+		*ast.DefaultExpr, *ast.WindowFuncExpr:
+		n.valid = false
+		return in, true
+	}
+	return in, false
+}
+
+func (n *mviewExprNormalizer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, n.valid
+}
+
+// normalizeMViewExpr returns the normalized text of the expression, it returns
+// false if the expression can't be matched.
+func normalizeMViewExpr(expr ast.Node) (string, bool) {
+	n := &mviewExprNormalizer{valid: true}
+	expr.Accept(n)
+	defer func() {
+		for i, col := range n.cols {
+			*col = n.saved[i]
+		}
+	}()
+	if !n.valid {
+		return "", false
+	}
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(mviewRestoreFlags, &sb)); err != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
+
+// singleTableOfSelect returns the table read by the simple aggregate query.
+func singleTableOfSelect(sel *ast.SelectStmt) (*ast.TableName, *ast.TableSource) {
+	if sel.Kind != ast.SelectStmtKindSelect || sel.From == nil || sel.With != nil || sel.Distinct ||
+		len(sel.WindowSpecs) > 0 || sel.SelectIntoOpt != nil || len(sel.TableHints) > 0 ||
+		(sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) ||
+		(sel.GroupBy != nil && sel.GroupBy.Rollup) {
+		return nil, nil
+	}
+	join := sel.From.TableRefs
+	if join == nil || join.Right != nil {
+		return nil, nil
+	}
+	ts, ok := join.Left.(*ast.TableSource)
+	if !ok {
+		return nil, nil
+	}
+	tn, ok := ts.Source.(*ast.TableName)
+	if !ok || tn.Schema.L == "" || tn.AsOf != nil || tn.TableSample != nil || len(tn.PartitionNames) > 0 {
+		return nil, nil
+	}
+	return tn, ts
+}
+
+// resolveMViewByItem returns the select field expression if the GROUP BY or
+// ORDER BY item refers to a field by position or alias.
+func resolveMViewByItem(item ast.ExprNode, fields []*ast.SelectField, tblInfo *model.TableInfo, preferAlias bool) ast.ExprNode {
+	switch x := item.(type) {
+	case *ast.PositionExpr:
+		if x.P == nil && x.N >= 1 && x.N <= len(fields) && fields[x.N-1].WildCard == nil {
+			return fields[x.N-1].Expr
+		}
+		return nil
+	case *ast.ColumnNameExpr:
+		if x.Name.Table.L != "" || (!preferAlias && model.FindColumnInfo(tblInfo.Columns, x.Name.Name.L) != nil) {
+			return item
+		}
+		for _, f := range fields {
+			if f.AsName.L == x.Name.Name.L {
+				return f.Expr
+			}
+		}
+	}
+	return item
+}
+
+// mviewAgg is an aggregate function stored in the view.
+type mviewAgg struct {
+	offset int
+	name   string
+}
+
+// mviewSummary is the shape of the materialized view used by the rewrite.
+type mviewSummary struct {
+	updateTS uint64
+	valid    bool
+	where    string
+	grouped  bool
+	// groups are the normalized GROUP BY items.
+	groups map[string]struct{}
+	// cols are the offsets of the view columns by the normalized non-aggregate fields.
+	cols map[string]int
+	// aggs are the aggregate functions of the view by the normalized fields.
+	aggs map[string]mviewAgg
+}
+
+// mviewSummaries caches the summaries of the materialized views by table ID.
+var mviewSummaries sync.Map
+
+func getMViewSummary(mvInfo, baseInfo *model.TableInfo) *mviewSummary {
+	if v, ok := mviewSummaries.Load(mvInfo.ID); ok && v.(*mviewSummary).updateTS == mvInfo.UpdateTS {
+		return v.(*mviewSummary)
+	}
+	s := buildMViewSummary(mvInfo, baseInfo)
+	mviewSummaries.Store(mvInfo.ID, s)
+	return s
+}
+
+func buildMViewSummary(mvInfo, baseInfo *model.TableInfo) *mviewSummary {
+	s := &mviewSummary{updateTS: mvInfo.UpdateTS}
+	stmt, err := parser.New().ParseOneStmt(mvInfo.MaterializedView.Definition, "", "")
+	if err != nil {
+		return s
+	}
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.Having != nil || sel.Limit != nil {
+		return s
+	}
+	if tn, _ := singleTableOfSelect(sel); tn == nil {
+		return s
+	}
+	fields := sel.Fields.Fields
+	if len(fields) != len(mvInfo.Columns) {
+		return s
+	}
+	s.groups = make(map[string]struct{})
+	s.cols = make(map[string]int)
+	s.aggs = make(map[string]mviewAgg)
+	if sel.Where != nil {
+		if s.where, ok = normalizeMViewExpr(sel.Where); !ok {
+			return s
+		}
+	}
+	if sel.GroupBy != nil {
+		s.grouped = true
+		for _, item := range sel.GroupBy.Items {
+			expr := resolveMViewByItem(item.Expr, fields, baseInfo, false)
+			if expr == nil {
+				return s
+			}
+			text, ok := normalizeMViewExpr(expr)
+			if !ok {
+				return s
+			}
+			s.groups[text] = struct{}{}
+		}
+	}
+	for i, f := range fields {
+		if f.WildCard != nil {
+			return s
+		}
+		text, ok := normalizeMViewExpr(f.Expr)
+		if !ok {
+			continue
+		}
+		if agg, ok := f.Expr.(*ast.AggregateFuncExpr); ok {
+			name := strings.ToLower(agg.F)
+			switch name {
+			case ast.AggFuncCount, ast.AggFuncSum, ast.AggFuncMin, ast.AggFuncMax:
+				if !agg.Distinct {
+					s.aggs[text] = mviewAgg{offset: i, name: name}
+				}
+			}
+			continue
+		}
+		s.cols[text] = i
+	}
+	s.valid = true
+	return s
+}
+
+// rewriteByMaterializedView rewrites the aggregate query on a single table to
+// read a materialized view of the table, which has the same filter and groups
+// the rows by a superset of the GROUP BY items of the query. It returns nil if
+// no view matches. The result may be stale since the last refresh of the view,
+// so it's only enabled by tidb_opt_enable_materialized_view_rewrite.
+func (b *PlanBuilder) rewriteByMaterializedView(ctx context.Context, sel *ast.SelectStmt) *ast.SelectStmt {
+	sessVars := b.ctx.GetSessionVars()
+	if !sessVars.EnableMaterializedViewRewrite || sessVars.InRestrictedSQL || sessVars.StmtCtx.IsStaleness ||
+		b.capFlag&canExpandAST != 0 || b.buildingRecursivePartForCTE || sel.Having != nil {
+		return nil
+	}
+	tn, ts := singleTableOfSelect(sel)
+	if tn == nil {
+		return nil
+	}
+	mvIDs := b.is.GetTableMaterializedViews(tn.Schema.L, tn.Name.L)
+	if len(mvIDs) == 0 {
+		return nil
+	}
+	tbl, err := b.is.TableByName(ctx, tn.Schema, tn.Name)
+	if err != nil || !tbl.Meta().IsBaseTable() || tbl.Meta().TempTableType != model.TempTableNone {
+		return nil
+	}
+	baseInfo := tbl.Meta()
+	pm := privilege.GetPrivilegeManager(b.ctx)
+	for _, id := range mvIDs {
+		mv, ok := b.is.TableByID(ctx, id)
+		if !ok || !mv.Meta().IsMaterializedView() {
+			continue
+		}
+		db, ok := b.is.SchemaByID(mv.Meta().DBID)
+		if !ok {
+			continue
+		}
+		if pm != nil && !pm.RequestVerification(sessVars.ActiveRoles, db.Name.L, mv.Meta().Name.L, "", mysql.SelectPriv) {
+			continue
+		}
+		summary := getMViewSummary(mv.Meta(), baseInfo)
+		if !summary.valid {
+			continue
+		}
+		sql, ok := matchMaterializedView(sel, ts, baseInfo, db.Name, mv.Meta(), summary)
+		if !ok {
+			continue
+		}
+		p := parser.New()
+		p.SetParserConfig(sessVars.BuildParserConfig())
+		p.SetSQLMode(sessVars.SQLMode)
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		if err != nil {
+			continue
+		}
+		newSel := stmt.(*ast.SelectStmt)
+		newSel.QueryBlockOffset = sel.QueryBlockOffset
+		newSel.IsInBraces = sel.IsInBraces
+		// The query still requires the privilege on the base table.
+		var authErr error
+		if sessVars.User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.FastGenByArgs("SELECT", sessVars.User.AuthUsername, sessVars.User.AuthHostname, baseInfo.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, tn.Schema.L, baseInfo.Name.L, "", authErr)
+		sessVars.StmtCtx.SetSkipPlanCache("query is rewritten to read a materialized view")
+		return newSel
+	}
+	return nil
+}
+
+// matchMaterializedView returns the query reading the view which is equivalent
+// to the aggregate query.
+func matchMaterializedView(sel *ast.SelectStmt, ts *ast.TableSource, baseInfo *model.TableInfo,
+	mvSchema ast.CIStr, mvInfo *model.TableInfo, summary *mviewSummary) (string, bool) {
+	fields := sel.Fields.Fields
+	where := ""
+	if sel.Where != nil {
+		var ok bool
+		if where, ok = normalizeMViewExpr(sel.Where); !ok {
+			return "", false
+		}
+	}
+	if where != summary.where {
+		return "", false
+	}
+
+	// The query must group the rows by a subset of the view groups.
+	groups := make(map[string]struct{})
+	var groupItems []string
+	if sel.GroupBy != nil {
+		for _, item := range sel.GroupBy.Items {
+			expr := resolveMViewByItem(item.Expr, fields, baseInfo, false)
+			if expr == nil {
+				return "", false
+			}
+			text, ok := normalizeMViewExpr(expr)
+			if !ok {
+				return "", false
+			}
+			if _, ok := summary.groups[text]; !ok {
+				return "", false
+			}
+			if _, ok := groups[text]; !ok {
+				groups[text] = struct{}{}
+				groupItems = append(groupItems, text)
+			}
+		}
+	}
+	hasAgg := false
+	for _, f := range fields {
+		if f.WildCard != nil {
+			return "", false
+		}
+		if _, ok := f.Expr.(*ast.AggregateFuncExpr); ok {
+			hasAgg = true
+		}
+	}
+	if sel.GroupBy == nil && !hasAgg {
+		return "", false
+	}
+	exact := sel.GroupBy != nil == summary.grouped && len(groups) == len(summary.groups)
+
+	colName := func(offset int) string {
+		return quoteMViewName(mvInfo.Columns[offset].Name.O)
+	}
+	// mapExpr maps the expression of the query to the expression of the view columns.
+	mapExpr := func(expr ast.ExprNode) (string, bool) {
+		text, ok := normalizeMViewExpr(expr)
+		if !ok {
+			return "", false
+		}
+		if agg, ok := summary.aggs[text]; ok {
+			if exact {
+				return colName(agg.offset), true
+			}
+			switch agg.name {
+			case ast.AggFuncCount:
+				return "CAST(COALESCE(SUM(" + colName(agg.offset) + "), 0) AS SIGNED)", true
+			case ast.AggFuncSum:
+				return "SUM(" + colName(agg.offset) + ")", true
+			case ast.AggFuncMin:
+				return "MIN(" + colName(agg.offset) + ")", true
+			case ast.AggFuncMax:
+				return "MAX(" + colName(agg.offset) + ")", true
+			}
+			return "", false
+		}
+		offset, ok := summary.cols[text]
+		if !ok {
+			return "", false
+		}
+		if _, ok := groups[text]; !ok && !exact {
+			return "", false
+		}
+		return colName(offset), true
+	}
+
+	var sb strings.Builder
+	sb.WriteString("SELECT ")
+	for i, f := range fields {
+		expr, ok := mapExpr(f.Expr)
+		if !ok {
+			return "", false
+		}
+		name := f.AsName.O
+		if name == "" {
+			if col, ok := f.Expr.(*ast.ColumnNameExpr); ok {
+				name = col.Name.Name.O
+			} else {
+				name = f.Text()
+			}
+		}
+		if name == "" {
+			return "", false
+		}
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(expr + " AS " + quoteMViewName(name))
+	}
+	sb.WriteString(" FROM " + quoteMViewName(mvSchema.O) + "." + quoteMViewName(mvInfo.Name.O))
+	if ts.AsName.L != "" {
+		sb.WriteString(" AS " + quoteMViewName(ts.AsName.O))
+	}
+	if !exact && len(groupItems) > 0 {
+		sb.WriteString(" GROUP BY ")
+		for i, text := range groupItems {
+			offset, ok := summary.cols[text]
+			if !ok {
+				return "", false
+			}
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(colName(offset))
+		}
+	}
+	if sel.OrderBy != nil {
+		sb.WriteString(" ORDER BY ")
+		for i, item := range sel.OrderBy.Items {
+			var expr string
+			switch x := resolveMViewByItem(item.Expr, fields, baseInfo, true).(type) {
+			case nil:
+				return "", false
+			case *ast.PositionExpr:
+				return "", false
+			default:
+				var ok bool
+				if expr, ok = mapExpr(x); !ok {
+					return "", false
+				}
+			}
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(expr)
+			if item.Desc {
+				sb.WriteString(" DESC")
+			}
+		}
+	}
+	if sel.Limit != nil {
+		if _, ok := sel.Limit.Count.(ast.ParamMarkerExpr); ok {
+			return "", false
+		}
+		if _, ok := sel.Limit.Offset.(ast.ParamMarkerExpr); ok {
+			return "", false
+		}
+		sb.WriteString(" ")
+		if err := sel.Limit.Restore(format.NewRestoreCtx(mviewRestoreFlags, &sb)); err != nil {
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+func quoteMViewName(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
@@ -0,0 +1,264 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/pkg/expression"
+	cascadesbase "github.com/pingcap/tidb/pkg/planner/cascades/base"
+	"github.com/pingcap/tidb/pkg/planner/cascades/memo"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
+	"github.com/pingcap/tidb/pkg/planner/property"
+	"github.com/pingcap/tidb/pkg/planner/util/debugtrace"
+	"github.com/pingcap/tidb/pkg/planner/util/optimizetrace"
+	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
+)
+
+// memoGroupPlan stands for a memo group with more than one logical alternative in the physical optimization.
+// The last alternative is embedded to provide the schema, stats and output names of the group, FindBestTask
+// costs all the alternatives and keeps the best task for each required property, so the group is costed once
+// no matter how many parents refer to it.
+type memoGroupPlan struct {
+	base.LogicalPlan
+
+	alternatives []base.LogicalPlan
+	planIDsHash  uint64
+	tasks        map[string]base.Task
+}
+
+// GetPlanIDsHash implements the base.LogicalPlan interface.
+func (p *memoGroupPlan) GetPlanIDsHash() uint64 {
+	return p.planIDsHash
+}
+
+// SetPlanIDsHash implements the base.LogicalPlan interface.
+func (p *memoGroupPlan) SetPlanIDsHash(hash uint64) {
+	p.planIDsHash = hash
+}
+
+// FindBestTask implements the base.LogicalPlan interface.
+func (p *memoGroupPlan) FindBestTask(prop *property.PhysicalProperty, planCounter *base.PlanCounterTp,
+	opt *optimizetrace.PhysicalOptimizeOp) (bestTask base.Task, cntPlan int64, err error) {
+	if prop == nil {
+		return nil, 1, nil
+	}
+	key := string(prop.HashCode())
+	if task, ok := p.tasks[key]; ok {
+		planCounter.Dec(1)
+		return task, 1, nil
+	}
+	// the alternatives are costed from the last one, which is the most transformed one, so it's kept when the costs
+	// are the same, like the join whose unused columns are pruned.
+	for i := len(p.alternatives) - 1; i >= 0; i-- {
+		curTask, cnt, err := p.alternatives[i].FindBestTask(prop, planCounter, opt)
+		if err != nil {
+			return nil, 0, err
+		}
+		cntPlan += cnt
+		if planCounter.Empty() {
+			bestTask = curTask
+			break
+		}
+		if bestTask == nil {
+			bestTask = curTask
+			continue
+		}
+		if curIsBetter, err := compareTaskCost(curTask, bestTask, opt); err != nil {
+			return nil, 0, err
+		} else if curIsBetter {
+			bestTask = curTask
+		}
+	}
+	// the tasks of nth_plan() are rolled back by the alternatives themselves, don't cache them here.
+	if !p.SCtx().GetSessionVars().StmtCtx.StmtHints.TaskMapNeedBackUp() {
+		p.tasks[key] = bestTask
+	}
+	return bestTask, cntPlan, nil
+}
+
+// RollBackTaskMap implements the base.LogicalPlan interface.
+func (p *memoGroupPlan) RollBackTaskMap(ts uint64) {
+	for _, alternative := range p.alternatives {
+		alternative.RollBackTaskMap(ts)
+	}
+}
+
+// memoGroupInfo records the state of a memo group when it's converted into the logical plan for costing.
+type memoGroupInfo struct {
+	// plan is nil when the group is being built or none of its group expressions can be built.
+	plan  base.LogicalPlan
+	exprs []*memo.GroupExpression
+
+	derived bool
+	stats   *property.StatsInfo
+	reload  bool
+
+	prepared bool
+	props    [][]*expression.Column
+}
+
+// memoPlanBuilder converts the memo into a logical plan DAG, in which every group is a node shared by all its
+// parents, and derives the stats and possible properties once for each group.
+type memoPlanBuilder struct {
+	hasher cascadesbase.Hasher
+	groups map[*memo.Group]*memoGroupInfo
+}
+
+func physicalOptimize4Memo(mm *memo.Memo, planCounter *base.PlanCounterTp) (base.PhysicalPlan, float64, error) {
+	b := &memoPlanBuilder{
+		hasher: mm.GetHasher(),
+		groups: make(map[*memo.Group]*memoGroupInfo, mm.GetGroups().Len()),
+	}
+	root := mm.GetRootGroup()
+	logic := b.build(root)
+	if logic == nil {
+		return nil, 0, plannererrors.ErrInternal.GenWithStackByArgs("Can't build the logical plan from memo")
+	}
+	if logic.SCtx().GetSessionVars().StmtCtx.EnableOptimizerDebugTrace {
+		debugtrace.EnterContextCommon(logic.SCtx())
+		defer debugtrace.LeaveContextCommon(logic.SCtx())
+	}
+	if _, _, err := b.deriveStats(root, nil); err != nil {
+		return nil, 0, err
+	}
+	b.preparePossibleProperties(root)
+	return findBestPhysicalPlan(logic, planCounter)
+}
+
+// build sets the children of the group expressions as the plans of their input groups, the group expressions
+// referring to the groups being built are skipped, since they are in a cycle formed by group merging.
+func (b *memoPlanBuilder) build(g *memo.Group) base.LogicalPlan {
+	if info, ok := b.groups[g]; ok {
+		return info.plan
+	}
+	info := &memoGroupInfo{}
+	b.groups[g] = info
+	alternatives := make([]base.LogicalPlan, 0, g.GetLogicalExpressions().Len())
+	decorrelated := false
+	for elem := g.GetLogicalExpressions().Front(); elem != nil; elem = elem.Next() {
+		if _, ok := elem.Value.(*memo.GroupExpression).LogicalPlan.(*logicalop.LogicalApply); !ok {
+			decorrelated = true
+			break
+		}
+	}
+	for elem := g.GetLogicalExpressions().Front(); elem != nil; elem = elem.Next() {
+		ge := elem.Value.(*memo.GroupExpression)
+		// the apply is kept in memo for the further decorrelation, but it's not costed once the group has been
+		// decorrelated, since the decorrelation is always applied like the one in the normalization phase.
+		if _, ok := ge.LogicalPlan.(*logicalop.LogicalApply); ok && decorrelated {
+			continue
+		}
+		children := make([]base.LogicalPlan, 0, len(ge.Inputs))
+		for _, input := range ge.Inputs {
+			child := b.build(input)
+			if child == nil {
+				break
+			}
+			children = append(children, child)
+		}
+		if len(children) < len(ge.Inputs) {
+			continue
+		}
+		lp := ge.LogicalPlan
+		// the leaf group expressions keep their own children, like the CTE storage whose seed part is set as its
+		// child when its stats are derived in memo.
+		if len(ge.Inputs) > 0 {
+			lp.SetChildren(children...)
+		}
+		b.hasher.Reset()
+		for _, child := range children {
+			b.hasher.HashUint64(child.GetPlanIDsHash())
+		}
+		b.hasher.HashInt(lp.ID())
+		lp.SetPlanIDsHash(b.hasher.Sum64())
+		info.exprs = append(info.exprs, ge)
+		alternatives = append(alternatives, lp)
+	}
+	switch len(alternatives) {
+	case 0:
+		return nil
+	case 1:
+		info.plan = alternatives[0]
+	default:
+		b.hasher.Reset()
+		for _, alternative := range alternatives {
+			b.hasher.HashUint64(alternative.GetPlanIDsHash())
+		}
+		info.plan = &memoGroupPlan{
+			LogicalPlan:  alternatives[len(alternatives)-1],
+			alternatives: alternatives,
+			planIDsHash:  b.hasher.Sum64(),
+			tasks:        make(map[string]base.Task),
+		}
+	}
+	return info.plan
+}
+
+// deriveStats works like RecursiveDeriveStats, the stats of a group is the one derived by its last group
+// expression. The group expressions generated by the rules are appended to the group, the last one is the most
+// transformed one, like the join decorrelated from the apply, whose estimation is the one without cascades.
+func (b *memoPlanBuilder) deriveStats(g *memo.Group, colGroups [][]*expression.Column) (*property.StatsInfo, bool, error) {
+	info := b.groups[g]
+	if info.derived {
+		return info.stats, info.reload, nil
+	}
+	info.derived = true
+	for i, ge := range info.exprs {
+		lp := ge.LogicalPlan
+		cumColGroups := lp.ExtractColGroups(colGroups)
+		childStats := make([]*property.StatsInfo, 0, len(ge.Inputs))
+		childSchema := make([]*expression.Schema, 0, len(ge.Inputs))
+		reloads := make([]bool, 0, len(ge.Inputs))
+		for j, input := range ge.Inputs {
+			stats, reload, err := b.deriveStats(input, cumColGroups)
+			if err != nil {
+				return nil, false, err
+			}
+			childStats = append(childStats, stats)
+			childSchema = append(childSchema, lp.Children()[j].Schema())
+			reloads = append(reloads, reload)
+		}
+		stats, reload, err := lp.DeriveStats(childStats, lp.Schema(), childSchema, reloads)
+		if err != nil {
+			return nil, false, err
+		}
+		if i == len(info.exprs)-1 {
+			info.stats, info.reload = stats, reload
+		}
+	}
+	return info.stats, info.reload, nil
+}
+
+// preparePossibleProperties works like the one for the logical plan tree, the possible properties of a group
+// is the one prepared by its last group expression.
+func (b *memoPlanBuilder) preparePossibleProperties(g *memo.Group) [][]*expression.Column {
+	info := b.groups[g]
+	if info.prepared {
+		return info.props
+	}
+	info.prepared = true
+	for i, ge := range info.exprs {
+		lp := ge.LogicalPlan
+		childrenProperties := make([][][]*expression.Column, 0, len(ge.Inputs))
+		for _, input := range ge.Inputs {
+			childrenProperties = append(childrenProperties, b.preparePossibleProperties(input))
+		}
+		props := lp.PreparePossibleProperties(lp.Schema(), childrenProperties...)
+		if i == len(info.exprs)-1 {
+			info.props = props
+		}
+	}
+	return info.props
+}
@@ -70,7 +70,8 @@ type BaseLogicalPlan struct {
 func (p *BaseLogicalPlan) Hash64(h base2.Hasher) {
 	_, ok1 := p.self.(*LogicalSequence)
 	_, ok2 := p.self.(*LogicalMaxOneRow)
-	if !ok1 && !ok2 {
+	_, ok3 := p.self.(*LogicalLock)
+	if !ok1 && !ok2 && !ok3 {
 		intest.Assert(false, "Hash64 should not be called directly")
 	}
 	h.HashInt(p.ID())
@@ -80,7 +81,8 @@ func (p *BaseLogicalPlan) Hash64(h base2.Hasher) {
 func (p *BaseLogicalPlan) Equals(other any) bool {
 	_, ok1 := p.self.(*LogicalSequence)
 	_, ok2 := p.self.(*LogicalMaxOneRow)
-	if !ok1 && !ok2 {
+	_, ok3 := p.self.(*LogicalLock)
+	if !ok1 && !ok2 && !ok3 {
 		intest.Assert(false, "Equals should not be called directly")
 	}
 	if other == nil {
@@ -400,6 +400,38 @@ func (op *LogicalExpand) Equals(other any) bool {
 	return true
 }
 
+// Hash64 implements the Hash64Equals interface.
+func (op *LogicalJSONTable) Hash64(h base.Hasher) {
+	h.HashString(plancodec.TypeJSONTable)
+	op.LogicalSchemaProducer.Hash64(h)
+	op.Expr.Hash64(h)
+	h.HashString(op.Path)
+}
+
+// Equals implements the Hash64Equals interface, only receive *LogicalJSONTable pointer.
+func (op *LogicalJSONTable) Equals(other any) bool {
+	op2, ok := other.(*LogicalJSONTable)
+	if !ok {
+		return false
+	}
+	if op == nil {
+		return op2 == nil
+	}
+	if op2 == nil {
+		return false
+	}
+	if !op.LogicalSchemaProducer.Equals(&op2.LogicalSchemaProducer) {
+		return false
+	}
+	if !op.Expr.Equals(op2.Expr) {
+		return false
+	}
+	if op.Path != op2.Path {
+		return false
+	}
+	return true
+}
+
 // Hash64 implements the Hash64Equals interface.
 func (op *LogicalLimit) Hash64(h base.Hasher) {
 	h.HashString(plancodec.TypeLimit)
@@ -507,6 +539,7 @@ func (op *DataSource) Hash64(h base.Hasher) {
 			one.Hash64(h)
 		}
 	}
+	h.HashInt64(int64(op.PhysicalTableID))
 	h.HashInt64(int64(op.PreferStoreType))
 	h.HashBool(op.IsForUpdateRead)
 }
@@ -548,6 +581,9 @@ func (op *DataSource) Equals(other any) bool {
 			return false
 		}
 	}
+	if op.PhysicalTableID != op2.PhysicalTableID {
+		return false
+	}
 	if op.PreferStoreType != op2.PreferStoreType {
 		return false
 	}
@@ -1059,3 +1095,91 @@ func (op *LogicalWindow) Equals(other any) bool {
 	}
 	return true
 }
+
+// Hash64 implements the Hash64Equals interface.
+func (op *LogicalLock) Hash64(h base.Hasher) {
+	h.HashString(plancodec.TypeLock)
+	op.BaseLogicalPlan.Hash64(h)
+}
+
+// Equals implements the Hash64Equals interface, only receive *LogicalLock pointer.
+func (op *LogicalLock) Equals(other any) bool {
+	op2, ok := other.(*LogicalLock)
+	if !ok {
+		return false
+	}
+	if op == nil {
+		return op2 == nil
+	}
+	if op2 == nil {
+		return false
+	}
+	if !op.BaseLogicalPlan.Equals(&op2.BaseLogicalPlan) {
+		return false
+	}
+	return true
+}
+
+// Hash64 implements the Hash64Equals interface.
+func (op *LogicalCTE) Hash64(h base.Hasher) {
+	h.HashString(plancodec.TypeCTE)
+	op.LogicalSchemaProducer.Hash64(h)
+	op.CteAsName.Hash64(h)
+	op.CteName.Hash64(h)
+}
+
+// Equals implements the Hash64Equals interface, only receive *LogicalCTE pointer.
+func (op *LogicalCTE) Equals(other any) bool {
+	op2, ok := other.(*LogicalCTE)
+	if !ok {
+		return false
+	}
+	if op == nil {
+		return op2 == nil
+	}
+	if op2 == nil {
+		return false
+	}
+	if !op.LogicalSchemaProducer.Equals(&op2.LogicalSchemaProducer) {
+		return false
+	}
+	if !op.CteAsName.Equals(&op2.CteAsName) {
+		return false
+	}
+	if !op.CteName.Equals(&op2.CteName) {
+		return false
+	}
+	return true
+}
+
+// Hash64 implements the Hash64Equals interface.
+func (op *LogicalCTETable) Hash64(h base.Hasher) {
+	h.HashString(plancodec.TypeCTETable)
+	op.LogicalSchemaProducer.Hash64(h)
+	h.HashString(op.Name)
+	h.HashInt64(int64(op.IDForStorage))
+}
+
+// Equals implements the Hash64Equals interface, only receive *LogicalCTETable pointer.
+func (op *LogicalCTETable) Equals(other any) bool {
+	op2, ok := other.(*LogicalCTETable)
+	if !ok {
+		return false
+	}
+	if op == nil {
+		return op2 == nil
+	}
+	if op2 == nil {
+		return false
+	}
+	if !op.LogicalSchemaProducer.Equals(&op2.LogicalSchemaProducer) {
+		return false
+	}
+	if op.Name != op2.Name {
+		return false
+	}
+	if op.IDForStorage != op2.IDForStorage {
+		return false
+	}
+	return true
+}
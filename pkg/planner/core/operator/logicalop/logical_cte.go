@@ -33,11 +33,11 @@ import (
 
 // LogicalCTE is for CTE.
 type LogicalCTE struct {
-	LogicalSchemaProducer
+	LogicalSchemaProducer `hash64-equals:"true"`
 
 	Cte       *CTEClass
-	CteAsName ast.CIStr
-	CteName   ast.CIStr
+	CteAsName ast.CIStr `hash64-equals:"true"`
+	CteName   ast.CIStr `hash64-equals:"true"`
 	SeedStat  *property.StatsInfo
 
 	OnlyUsedAsStorage bool
@@ -25,11 +25,11 @@ import (
 
 // LogicalCTETable is for CTE table
 type LogicalCTETable struct {
-	LogicalSchemaProducer
+	LogicalSchemaProducer `hash64-equals:"true"`
 
 	SeedStat     *property.StatsInfo
-	Name         string
-	IDForStorage int
+	Name         string `hash64-equals:"true"`
+	IDForStorage int    `hash64-equals:"true"`
 
 	// SeedSchema is only used in columnStatsUsageCollector to get column mapping
 	SeedSchema *expression.Schema
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/planner/cardinality"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/constraint"
 	ruleutil "github.com/pingcap/tidb/pkg/planner/core/rule/util"
@@ -79,7 +80,8 @@ type DataSource struct {
 
 	// The data source may be a partition, rather than a real table.
 	PartitionDefIdx *int
-	PhysicalTableID int64
+	// PhysicalTableID tells the partitions of the same table apart in memo.
+	PhysicalTableID int64 `hash64-equals:"true"`
 	PartitionNames  []ast.CIStr
 
 	// handleCol represents the handle column for the datasource, either the
@@ -122,6 +124,10 @@ type DataSource struct {
 
 	// AskedColumnGroup is upper asked column groups for maintained of group ndv from composite index.
 	AskedColumnGroup [][]*expression.Column
+
+	// CardinalityFeedback is the cardinality feedback of PushedDownConds, it's nil if the cardinality feedback is
+	// disabled or there is no filter.
+	CardinalityFeedback *cardinality.FeedbackInfo
 }
 
 // Init initializes DataSource.
@@ -171,14 +171,18 @@ func (is *LogicalIndexScan) MatchIndexProp(prop *property.PhysicalProperty) (mat
 	if prop.IsSortItemEmpty() {
 		return true
 	}
-	if all, _ := prop.AllSameOrder(); !all {
+	// The index with descending columns can match the prop with mixed orders,
+	// e.g. index idx(a, b desc) matches `order by a, b desc` and `order by a desc, b`.
+	hasDescCol := is.Index.HasDescColumn()
+	if all, _ := prop.AllSameOrder(); !all && !hasDescCol {
 		return false
 	}
 	sctx := is.SCtx()
 	evalCtx := sctx.GetExprCtx().GetEvalCtx()
 	for i, col := range is.IdxCols {
 		if col.Equal(evalCtx, prop.SortItems[0].Col) {
-			return matchIndicesProp(sctx, is.IdxCols[i:], is.IdxColLens[i:], prop.SortItems)
+			return matchIndicesProp(sctx, is.IdxCols[i:], is.IdxColLens[i:], prop.SortItems) &&
+				(!hasDescCol || is.matchIndicesOrder(i, prop.SortItems))
 		} else if i >= is.EqCondCount {
 			break
 		}
@@ -186,6 +190,37 @@ func (is *LogicalIndexScan) MatchIndexProp(prop *property.PhysicalProperty) (mat
 	return false
 }
 
+// IsDescScan returns whether the index should be scanned in descending order of the keys
+// to keep the order of the prop. The prop must be matched by the index scan.
+func (is *LogicalIndexScan) IsDescScan(prop *property.PhysicalProperty) bool {
+	desc := prop.SortItems[0].Desc
+	evalCtx := is.SCtx().GetExprCtx().GetEvalCtx()
+	for i, col := range is.IdxCols {
+		if col.Equal(evalCtx, prop.SortItems[0].Col) {
+			return desc != is.isIdxColDesc(i)
+		}
+	}
+	return desc
+}
+
+// matchIndicesOrder checks whether the sort items are all in the same order or all in the
+// reversed order of the index columns starting from the offset.
+func (is *LogicalIndexScan) matchIndicesOrder(offset int, items []property.SortItem) bool {
+	reverse := items[0].Desc != is.isIdxColDesc(offset)
+	for i, item := range items {
+		if (item.Desc != is.isIdxColDesc(offset+i)) != reverse {
+			return false
+		}
+	}
+	return true
+}
+
+// isIdxColDesc returns whether the i-th column of IdxCols is stored in descending order.
+// IdxCols is a prefix of the index columns, and the handle columns are always in ascending order.
+func (is *LogicalIndexScan) isIdxColDesc(i int) bool {
+	return i < len(is.Index.Columns) && is.Index.Columns[i].Desc
+}
+
 // GetPKIsHandleCol gets the handle column if PKIsHandle.
 func (is *LogicalIndexScan) GetPKIsHandleCol(schema *expression.Schema) *expression.Column {
 	// We cannot use p.Source.GetPKIsHandleCol() here,
@@ -27,7 +27,7 @@ import (
 
 // LogicalLock represents a select lock plan.
 type LogicalLock struct {
-	BaseLogicalPlan
+	BaseLogicalPlan `hash64-equals:"true"`
 
 	Lock         *ast.SelectLockInfo
 	TblID2Handle map[int64][]util.HandleCols
@@ -53,6 +53,7 @@ var (
 	_ base.LogicalPlan = &LogicalMemTable{}
 	_ base.LogicalPlan = &LogicalShow{}
 	_ base.LogicalPlan = &LogicalShowDDLJobs{}
+	_ base.LogicalPlan = &LogicalJSONTable{}
 	_ base.LogicalPlan = &LogicalCTE{}
 	_ base.LogicalPlan = &LogicalCTETable{}
 	_ base.LogicalPlan = &LogicalSequence{}
@@ -162,6 +163,8 @@ func CanPushToCopImpl(lp base.LogicalPlan, storeTp kv.StoreType, considerDual bo
 	p := lp.GetBaseLogicalPlan().(*BaseLogicalPlan)
 	ret := true
 	for _, ch := range p.Children() {
+		// the child may be a memo group of the cascades planner, whose self is its representative alternative.
+		ch = ch.GetBaseLogicalPlan().(*BaseLogicalPlan).Self()
 		switch c := ch.(type) {
 		case *DataSource:
 			validDs := false
@@ -45,6 +45,9 @@ type ShowContents struct {
 	Partition         ast.CIStr           // Use for showing partition
 	Column            *ast.ColumnName     // Used for `desc table column`.
 	IndexName         ast.CIStr
+	Procedure         *ast.TableName       // Used for show create procedure.
+	Trigger           *ast.TableName       // Used for show create trigger.
+	Event             *ast.TableName       // Used for show create event.
 	ResourceGroupName string               // Used for showing resource group
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	User              *auth.UserIdentity   // Used for show grants.
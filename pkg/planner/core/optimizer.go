@@ -276,7 +276,7 @@ func checkStableResultMode(sctx base.PlanContext) bool {
 // The returned logical plan is necessary for generating plans for Common Table Expressions (CTEs).
 func doOptimize(ctx context.Context, sctx base.PlanContext, flag uint64, logic base.LogicalPlan) (
 	base.LogicalPlan, base.PhysicalPlan, float64, error) {
-	if sctx.GetSessionVars().GetSessionVars().EnableCascadesPlanner {
+	if sctx.GetSessionVars().GetEnableCascadesPlanner() {
 		return CascadesOptimize(ctx, sctx, flag, logic)
 	}
 	return VolcanoOptimize(ctx, sctx, flag, logic)
@@ -303,28 +303,12 @@ func CascadesOptimize(ctx context.Context, sctx base.PlanContext, flag uint64, l
 	if err != nil {
 		return nil, nil, 0, err
 	}
-	var (
-		physical base.PhysicalPlan
-		cost     = math.MaxFloat64
-	)
-	// At current phase, cascades just iterate every logic plan out for feeding physicalOptimize.
-	// TODO: In the near future, physicalOptimize will be refactored as receiving *Group as param directly.
-	cas.GetMemo().NewIterator().Each(func(oneLogic base.LogicalPlan) bool {
-		planCounter := base.PlanCounterTp(sessVars.StmtCtx.StmtHints.ForceNthPlan)
-		if planCounter == 0 {
-			planCounter = -1
-		}
-		tmpPhysical, tmpCost, tmpErr := physicalOptimize(oneLogic, &planCounter)
-		if tmpErr != nil {
-			err = tmpErr
-			return false
-		}
-		if tmpCost < cost {
-			physical = tmpPhysical
-			cost = tmpCost
-		}
-		return true
-	})
+	planCounter := base.PlanCounterTp(sessVars.StmtCtx.StmtHints.ForceNthPlan)
+	if planCounter == 0 {
+		planCounter = -1
+	}
+	// all the logical alternatives in memo are costed together, each group is costed once for one required property.
+	physical, cost, err := physicalOptimize4Memo(cas.GetMemo(), &planCounter)
 	if err != nil {
 		return nil, nil, 0, err
 	}
@@ -486,14 +470,19 @@ func postOptimize(ctx context.Context, sctx base.PlanContext, plan base.Physical
 }
 
 func generateRuntimeFilter(sctx base.PlanContext, plan base.PhysicalPlan) {
-	if !sctx.GetSessionVars().IsRuntimeFilterEnabled() || sctx.GetSessionVars().InRestrictedSQL {
+	sessVars := sctx.GetSessionVars()
+	// The runtime filters of the hash joins executed in TiDB are enabled by the RUNTIME_FILTER() hint.
+	enableRootRuntimeFilter := sessVars.StmtCtx.RuntimeFilterHint
+	if (!sessVars.IsRuntimeFilterEnabled() && !enableRootRuntimeFilter) || sessVars.InRestrictedSQL {
 		return
 	}
 	logutil.BgLogger().Debug("Start runtime filter generator")
 	rfGenerator := &RuntimeFilterGenerator{
-		rfIDGenerator:      &util.IDGenerator{},
-		columnUniqueIDToRF: map[int64][]*RuntimeFilter{},
-		parentPhysicalPlan: plan,
+		rfIDGenerator:           &util.IDGenerator{},
+		columnUniqueIDToRF:      map[int64][]*RuntimeFilter{},
+		parentPhysicalPlan:      plan,
+		enableMPPRuntimeFilter:  sessVars.IsRuntimeFilterEnabled(),
+		enableRootRuntimeFilter: enableRootRuntimeFilter,
 	}
 	startRFGenerator := time.Now()
 	rfGenerator.GenerateRuntimeFilter(plan)
@@ -1150,7 +1139,12 @@ func physicalOptimize(logic base.LogicalPlan, planCounter *base.PlanCounterTp) (
 	}
 
 	preparePossibleProperties(logic)
+	return findBestPhysicalPlan(logic, planCounter)
+}
 
+// findBestPhysicalPlan finds the best physical plan for the logical plan whose stats and possible properties
+// have been prepared.
+func findBestPhysicalPlan(logic base.LogicalPlan, planCounter *base.PlanCounterTp) (plan base.PhysicalPlan, cost float64, err error) {
 	prop := &property.PhysicalProperty{
 		TaskTp:      property.RootTaskType,
 		ExpectedCnt: math.MaxFloat64,
@@ -15,10 +15,13 @@
 package core
 
 import (
+	"slices"
+
 	"github.com/pingcap/tidb/pkg/expression"
 	tmodel "github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/planner/core/operator/logicalop"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/types"
 )
@@ -93,3 +96,29 @@ func handleDroppingForRange(pi *tmodel.PartitionInfo, partitionNames []ast.CIStr
 	}
 	return usedPartitions
 }
+
+// prunePartition4DataSource checks whether the partition read by the DataSource, which is one of the children of the
+// PartitionUnionAll in static prune mode, is excluded by the conditions.
+func prunePartition4DataSource(lp base.LogicalPlan, conds []expression.Expression) (bool, error) {
+	ds := lp.(*logicalop.DataSource)
+	if ds.PartitionDefIdx == nil {
+		return false, nil
+	}
+	pt, ok := ds.Table.(table.PartitionedTable)
+	if !ok {
+		return false, nil
+	}
+	s := PartitionProcessor{}
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return false, err
+	}
+	used, err := PartitionPruning(ds.SCtx(), pt, conds, ds.PartitionNames, ds.TblCols, names)
+	if err != nil {
+		return false, err
+	}
+	if len(used) == 1 && used[0] == FullRange {
+		return false, nil
+	}
+	return !slices.Contains(used, *ds.PartitionDefIdx), nil
+}
@@ -16,6 +16,7 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"unsafe"
@@ -780,6 +781,9 @@ type PhysicalIndexScan struct {
 	// usedStatsInfo records stats status of this physical table.
 	// It's for printing stats related information when display execution plan.
 	usedStatsInfo *stmtctx.UsedStatsInfoForTable `plan-cache-clone:"shallow"`
+
+	// cardinalityFeedback is the cardinality feedback of the filters on the DataSource.
+	cardinalityFeedback *cardinality.FeedbackInfo `plan-cache-clone:"shallow"`
 }
 
 // Clone implements op.PhysicalPlan interface.
@@ -960,6 +964,9 @@ type PhysicalTableScan struct {
 	// It's for printing stats related information when display execution plan.
 	usedStatsInfo *stmtctx.UsedStatsInfoForTable `plan-cache-clone:"shallow"`
 
+	// cardinalityFeedback is the cardinality feedback of the filters on the DataSource.
+	cardinalityFeedback *cardinality.FeedbackInfo `plan-cache-clone:"shallow"`
+
 	// for runtime filter
 	runtimeFilterList []*RuntimeFilter `plan-cache-clone:"must-nil"` // plan with runtime filter is not cached
 	maxWaitTimeMs     int
@@ -1023,6 +1030,11 @@ func (ts *PhysicalTableScan) IsPartition() (bool, int64) {
 	return ts.isPartition, ts.physicalTableID
 }
 
+// CardinalityFeedback returns the cardinality feedback of the filters on the DataSource.
+func (ts *PhysicalTableScan) CardinalityFeedback() *cardinality.FeedbackInfo {
+	return ts.cardinalityFeedback
+}
+
 // ResolveCorrelatedColumns resolves the correlated columns in range access.
 // We already limit range mem usage when building ranges in optimizer phase, so we don't need and shouldn't limit range
 // mem usage when rebuilding ranges during the execution phase.
@@ -1497,6 +1509,13 @@ type PhysicalHashJoin struct {
 
 	// for runtime filter
 	runtimeFilterList []*RuntimeFilter `plan-cache-clone:"must-nil"` // plan with runtime filter is not cached
+	// for runtime filter of the hash join executed in TiDB
+	rootRuntimeFilters []*RootRuntimeFilter `plan-cache-clone:"must-nil"` // plan with runtime filter is not cached
+}
+
+// RootRuntimeFilters returns the runtime filters built by the hash join executed in TiDB.
+func (p *PhysicalHashJoin) RootRuntimeFilters() []*RootRuntimeFilter {
+	return p.rootRuntimeFilters
 }
 
 // CanUseHashJoinV2 returns true if current join is supported by hash join v2
@@ -1557,6 +1576,7 @@ func (p *PhysicalHashJoin) Clone(newCtx base.PlanContext) (base.PhysicalPlan, er
 		clonedRF := rf.Clone()
 		cloned.runtimeFilterList = append(cloned.runtimeFilterList, clonedRF)
 	}
+	cloned.rootRuntimeFilters = slices.Clone(p.rootRuntimeFilters)
 	return cloned, nil
 }
 
@@ -1606,6 +1626,22 @@ func (p *PhysicalHashJoin) RightIsBuildSide() bool {
 	return p.InnerChildIdx != 0
 }
 
+// CloneWithOtherBuildSide returns a copy of the inner join which builds the hash table from the other child, and
+// shares the children with p. It's used by the adaptive execution to swap the build side at runtime.
+func (p *PhysicalHashJoin) CloneWithOtherBuildSide() (*PhysicalHashJoin, error) {
+	cloned, err := p.Clone(p.SCtx())
+	if err != nil {
+		return nil, err
+	}
+	swapped := cloned.(*PhysicalHashJoin)
+	swapped.SetChildren(p.Children()...)
+	swapped.InnerChildIdx = 1 - p.InnerChildIdx
+	swapped.OuterJoinKeys, swapped.InnerJoinKeys = swapped.InnerJoinKeys, swapped.OuterJoinKeys
+	// The runtime filters are pushed down to the probe side of p.
+	swapped.rootRuntimeFilters = nil
+	return swapped, nil
+}
+
 // NewPhysicalHashJoin creates a new PhysicalHashJoin from LogicalJoin.
 func NewPhysicalHashJoin(p *logicalop.LogicalJoin, innerIdx int, useOuterToBuild bool, newStats *property.StatsInfo, prop ...*property.PhysicalProperty) *PhysicalHashJoin {
 	leftJoinKeys, rightJoinKeys, isNullEQ, _ := p.GetJoinKeys()
@@ -2406,6 +2442,11 @@ func (p *PhysicalIndexScan) IsPartition() (bool, int64) {
 	return p.isPartition, p.physicalTableID
 }
 
+// CardinalityFeedback returns the cardinality feedback of the filters on the DataSource.
+func (p *PhysicalIndexScan) CardinalityFeedback() *cardinality.FeedbackInfo {
+	return p.cardinalityFeedback
+}
+
 // IsPointGetByUniqueKey checks whether is a point get by unique key.
 func (p *PhysicalIndexScan) IsPointGetByUniqueKey(tc types.Context) bool {
 	return len(p.Ranges) == 1 &&
@@ -2425,12 +2466,14 @@ type PhysicalSelection struct {
 	// Please see https://github.com/pingcap/tidb/issues/36243 for more details.
 	fromDataSource bool
 
-	// todo Since the feature of adding filter operators has not yet been implemented,
-	// the following code for this function will not be used for now.
-	// The flag indicates whether this Selection is used for RuntimeFilter
-	// True: Used for RuntimeFilter
-	// False: Only for normal conditions
-	// hasRFConditions bool
+	// rootRuntimeFilters are the runtime filters applied by this Selection, which is inserted into the pushed down
+	// plans of the probe side reader of a hash join executed in TiDB. Its conditions are filled when executing.
+	rootRuntimeFilters []*RootRuntimeFilter `plan-cache-clone:"must-nil"` // plan with runtime filter is not cached
+}
+
+// RootRuntimeFilters returns the runtime filters applied by the Selection.
+func (p *PhysicalSelection) RootRuntimeFilters() []*RootRuntimeFilter {
+	return p.rootRuntimeFilters
 }
 
 // Clone implements op.PhysicalPlan interface.
@@ -2443,6 +2486,7 @@ func (p *PhysicalSelection) Clone(newCtx base.PlanContext) (base.PhysicalPlan, e
 	}
 	cloned.BasePhysicalPlan = *base
 	cloned.Conditions = util.CloneExprs(p.Conditions)
+	cloned.rootRuntimeFilters = slices.Clone(p.rootRuntimeFilters)
 	return cloned, nil
 }
 
@@ -2751,6 +2795,44 @@ func (p *PhysicalShowDDLJobs) MemoryUsage() (sum int64) {
 	return p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInt64
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr    expression.Expression
+	Path    string
+	Columns []*ast.JSONTableColumn
+}
+
+// Clone implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) Clone(newCtx base.PlanContext) (base.PhysicalPlan, error) {
+	cloned := new(PhysicalJSONTable)
+	cloned.SetSCtx(newCtx)
+	base, err := p.physicalSchemaProducer.cloneWithSelf(newCtx, cloned)
+	if err != nil {
+		return nil, err
+	}
+	cloned.physicalSchemaProducer = *base
+	cloned.Expr = p.Expr.Clone()
+	cloned.Path = p.Path
+	cloned.Columns = p.Columns
+	return cloned, nil
+}
+
+// ExtractCorrelatedCols implements op.PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+	return p.physicalSchemaProducer.MemoryUsage() + p.Expr.MemoryUsage() + int64(len(p.Path)) +
+		size.SizeOfSlice + int64(cap(p.Columns))*size.SizeOfPointer
+}
+
 // BuildMergeJoinPlan builds a PhysicalMergeJoin from the given fields. Currently, it is only used for test purpose.
 func BuildMergeJoinPlan(ctx base.PlanContext, joinType logicalop.JoinType, leftKeys, rightKeys []*expression.Column) *PhysicalMergeJoin {
 	baseJoin := basePhysicalJoin{
@@ -94,6 +94,9 @@ func (op *PhysicalSelection) CloneForPlanCache(newCtx base.PlanContext) (base.Pl
 	}
 	cloned.BasePhysicalPlan = *basePlan
 	cloned.Conditions = cloneExpressionsForPlanCache(op.Conditions, nil)
+	if op.rootRuntimeFilters != nil {
+		return nil, false
+	}
 	return cloned, true
 }
 
@@ -175,6 +178,9 @@ func (op *PhysicalHashJoin) CloneForPlanCache(newCtx base.PlanContext) (base.Pla
 	if op.runtimeFilterList != nil {
 		return nil, false
 	}
+	if op.rootRuntimeFilters != nil {
+		return nil, false
+	}
 	return cloned, true
 }
 
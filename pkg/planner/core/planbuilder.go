@@ -558,7 +558,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node *resolve.NodeW) (base.Plan
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.AlterRangeStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CallStmt, *ast.ProcedureBlock,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt, *ast.NotifyStmt:
 		return b.buildSimple(ctx, node.Node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1339,11 +1341,11 @@ func getPossibleAccessPaths(ctx base.PlanContext, tableHints *hint.PlanHints, in
 		available = append(available, tablePath)
 	}
 
-	// If all available paths are Multi-Valued Index, it's possible that the only multi-valued index is inapplicable,
+	// If all available paths are Multi-Valued Index, spatial index or full-text index, it's possible that the only index is inapplicable,
 	// so that the table paths are still added here to avoid failing to find any physical plan.
 	allMVIIndexPath := true
 	for _, availablePath := range available {
-		if !isMVIndexPath(availablePath) {
+		if !isMVIndexPath(availablePath) && !isSearchIndexPath(availablePath) {
 			allMVIIndexPath = false
 		}
 	}
@@ -1783,6 +1785,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsSearchIndex() {
+			// Skip checking spatial and full-text index, which store the cells or the tokens instead of the values.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -2057,7 +2063,7 @@ func (b *PlanBuilder) getMustAnalyzedColumns(tbl *resolve.TableNameW, cols *calc
 		}
 		virtualExprs := make([]expression.Expression, 0, len(tblInfo.Columns))
 		for _, idx := range tblInfo.Indices {
-			if idx.State != model.StatePublic || idx.MVIndex || idx.IsColumnarIndex() {
+			if idx.State != model.StatePublic || idx.MVIndex || idx.IsSearchIndex() || idx.IsColumnarIndex() {
 				continue
 			}
 			for _, idxCol := range idx.Columns {
@@ -2328,10 +2334,14 @@ func getModifiedIndexesInfoForAnalyze(
 			specialGlobalIdxsInfo = append(specialGlobalIdxsInfo, originIdx)
 			continue
 		}
-		if originIdx.MVIndex {
+		if originIdx.MVIndex || originIdx.FullTextInfo != nil {
 			independentIdxsInfo = append(independentIdxsInfo, originIdx)
 			continue
 		}
+		if originIdx.Spatial {
+			stmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial index is not supported, skip %s", originIdx.Name.L))
+			continue
+		}
 		if originIdx.IsColumnarIndex() {
 			stmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing columnar index is not supported, skip %s", originIdx.Name.L))
 			continue
@@ -2789,6 +2799,14 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Spatial {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial index is not supported, skip %s", idx.Name.L))
+				continue
+			}
+			if idx.FullTextInfo != nil {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing full-text index is not supported in analyze version 1, skip %s", idx.Name.L))
+				continue
+			}
 			if idx.IsColumnarIndex() {
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing columnar index is not supported, skip %s", idx.Name.L))
 				continue
@@ -2869,6 +2887,14 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.Spatial {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial index is not supported, skip %s", idx.Name.L))
+			continue
+		}
+		if idx.FullTextInfo != nil {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing full-text index is not supported in analyze version 1, skip %s", idx.Name.L))
+			continue
+		}
 		if idx.IsColumnarIndex() {
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing columnar index is not supported, skip %s", idx.Name.L))
 			continue
@@ -2903,6 +2929,14 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Spatial {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing spatial index is not supported, skip %s", idx.Name.L))
+				continue
+			}
+			if idx.FullTextInfo != nil {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing full-text index is not supported in analyze version 1, skip %s", idx.Name.L))
+				continue
+			}
 			if idx.IsColumnarIndex() {
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf("analyzing columnar index is not supported, skip %s", idx.Name.L))
 				continue
@@ -3453,6 +3487,9 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			Limit:                 show.Limit,
 			ImportJobID:           show.ImportJobID,
 			SQLOrDigest:           show.SQLOrDigest,
+			Procedure:             show.Procedure,
+			Trigger:               show.Trigger,
+			Event:                 show.Event,
 		},
 	}.Init(b.ctx)
 	isView := false
@@ -3507,6 +3544,27 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 			err = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("SHOW VIEW", user.AuthUsername, user.AuthHostname, show.Table.Name.L)
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShowViewPriv, show.Table.Schema.L, show.Table.Name.L, "", err)
+	case ast.ShowCreateProcedure:
+		dbName, err := b.getRoutineSchema(show.Procedure.Schema)
+		if err != nil {
+			return nil, err
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv|mysql.AlterRoutinePriv|mysql.ExecutePriv,
+			dbName, "", "", b.routineAccessDeniedErr(dbName))
+	case ast.ShowCreateTrigger:
+		dbName, err := b.getRoutineSchema(show.Trigger.Schema)
+		if err != nil {
+			return nil, err
+		}
+		var authErr error
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, dbName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, dbName, "", "", authErr)
+	case ast.ShowCreateEvent:
+		if err := b.appendEventVisitInfo(show.Event); err != nil {
+			return nil, err
+		}
 	case ast.ShowBackups:
 		err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or BACKUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"BACKUP_ADMIN"}, false, err)
@@ -3552,6 +3610,10 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 		if tableInfo.Meta().TempTableType != model.TempTableNone {
 			return nil, plannererrors.ErrOptOnTemporaryTable.GenWithStackByArgs("show table distributions")
 		}
+	case ast.ShowTriggers, ast.ShowEvents, ast.ShowChangefeeds:
+		if p.DBName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
 	case ast.ShowReplicaStatus:
 		return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("SHOW {REPLICA | SLAVE} STATUS")
 	}
@@ -3567,8 +3629,16 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (base.P
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowTriggers {
+			// The pattern of `SHOW TRIGGERS` matches the table names.
+			patternCol = p.OutputNames()[2].ColName
+		} else if show.Tp == ast.ShowEvents {
+			// The pattern of `SHOW EVENTS` matches the event names.
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3736,10 +3806,75 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (base.
 			err := plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN or RESOURCE_GROUP_USER")
 			b.visitInfo = appendDynamicVisitInfo(b.visitInfo, []string{"RESOURCE_GROUP_ADMIN", "RESOURCE_GROUP_USER"}, false, err)
 		}
+	case *ast.ProcedureInfo:
+		dbName, err := b.getRoutineSchema(raw.ProcedureName.Schema)
+		if err != nil {
+			return nil, err
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, dbName, "", "", b.routineAccessDeniedErr(dbName))
+	case *ast.DropProcedureStmt:
+		dbName, err := b.getRoutineSchema(raw.ProcedureName.Schema)
+		if err != nil {
+			return nil, err
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, dbName, "", "", b.routineAccessDeniedErr(dbName))
+	case *ast.CallStmt:
+		dbName, err := b.getRoutineSchema(raw.Procedure.Schema)
+		if err != nil {
+			return nil, err
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ExecutePriv, dbName, "", "", b.routineAccessDeniedErr(dbName))
+	case *ast.CreateEventStmt:
+		if err := b.appendEventVisitInfo(raw.Event); err != nil {
+			return nil, err
+		}
+	case *ast.AlterEventStmt:
+		if err := b.appendEventVisitInfo(raw.Event); err != nil {
+			return nil, err
+		}
+		if raw.NewName != nil {
+			if err := b.appendEventVisitInfo(raw.NewName); err != nil {
+				return nil, err
+			}
+		}
+	case *ast.DropEventStmt:
+		if err := b.appendEventVisitInfo(raw.Event); err != nil {
+			return nil, err
+		}
 	}
 	return p, nil
 }
 
+// appendEventVisitInfo requires the EVENT privilege on the schema of the event.
+func (b *PlanBuilder) appendEventVisitInfo(event *ast.TableName) error {
+	dbName, err := b.getRoutineSchema(event.Schema)
+	if err != nil {
+		return err
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, dbName, "", "", b.routineAccessDeniedErr(dbName))
+	return nil
+}
+
+// getRoutineSchema returns the schema of a stored routine, it falls back to the current database.
+func (b *PlanBuilder) getRoutineSchema(schema ast.CIStr) (string, error) {
+	if schema.L != "" {
+		return schema.L, nil
+	}
+	dbName := b.ctx.GetSessionVars().CurrentDB
+	if dbName == "" {
+		return "", plannererrors.ErrNoDB
+	}
+	return strings.ToLower(dbName), nil
+}
+
+func (b *PlanBuilder) routineAccessDeniedErr(dbName string) error {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return nil
+	}
+	return plannererrors.ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, dbName)
+}
+
 func collectVisitInfoFromRevokeStmt(ctx context.Context, sctx base.PlanContext, vi []visitInfo, stmt *ast.RevokeStmt) ([]visitInfo, error) {
 	// To use REVOKE, you must have the GRANT OPTION privilege,
 	// and you must have the privileges that you are granting.
@@ -3963,6 +4098,12 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	if b.isReadOnlyMaterializedView(tableInfo) {
+		if insert.IsReplace {
+			return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "REPLACE")
+		}
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "INSERT")
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx.GetExprCtx(), tn.Schema, tableInfo)
 	if err != nil {
@@ -4454,6 +4595,9 @@ func (b *PlanBuilder) buildLoadData(ctx context.Context, ld *ast.LoadDataStmt) (
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DeletePriv, p.Table.Schema.O, p.Table.Name.O, "", deleteErr)
 	}
 	tableInfo := p.Table.TableInfo
+	if b.isReadOnlyMaterializedView(tableInfo) {
+		return nil, plannererrors.ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "LOAD DATA")
+	}
 	tableInPlan, ok := b.is.TableByID(ctx, tableInfo.ID)
 	if !ok {
 		db := b.ctx.GetSessionVars().CurrentDB
@@ -5213,6 +5357,16 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		if err := b.buildCreateMaterializedView(ctx, v); err != nil {
+			return nil, err
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5243,6 +5397,25 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, tableVal.Schema.L,
 				tableVal.Name.L, "", authErr)
 		}
+	case *ast.DropMaterializedViewStmt:
+		for _, view := range v.Views {
+			if b.ctx.GetSessionVars().User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
+					b.ctx.GetSessionVars().User.AuthHostname, view.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, view.Schema.L,
+				view.Name.L, "", authErr)
+		}
+	case *ast.RefreshMaterializedViewStmt:
+		// Refreshing replaces the rows of the view.
+		for _, priv := range []mysql.PrivilegeType{mysql.InsertPriv, mysql.DeletePriv} {
+			if b.ctx.GetSessionVars().User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs(strings.ToUpper(priv.String()), b.ctx.GetSessionVars().User.AuthUsername,
+					b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, priv, v.ViewName.Schema.L,
+				v.ViewName.Name.L, "", authErr)
+		}
 	case *ast.DropSequenceStmt:
 		for _, sequence := range v.Sequences {
 			if b.ctx.GetSessionVars().User != nil {
@@ -5252,6 +5425,55 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (base.Plan
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, sequence.Schema.L,
 				sequence.Name.L, "", authErr)
 		}
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		// The table of the trigger is unknown before execution, so the privilege is checked on the schema.
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Trigger.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Trigger.Schema.L, "", "", authErr)
+	case *ast.CreateChangefeedStmt:
+		// The changefeed reads all the changes of the table and changes its definition.
+		for _, priv := range []mysql.PrivilegeType{mysql.SelectPriv, mysql.AlterPriv} {
+			if b.ctx.GetSessionVars().User != nil {
+				authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs(strings.ToUpper(priv.String()), b.ctx.GetSessionVars().User.AuthUsername,
+					b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, priv, v.Table.Schema.L,
+				v.Table.Name.L, "", authErr)
+		}
+	case *ast.DropChangefeedStmt:
+		// The table of the changefeed is unknown before execution, so the privilege is checked on the schema.
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Changefeed.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, v.Changefeed.Schema.L, "", "", authErr)
+	case *ast.CreateStatisticsStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("ALTER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropStatisticsStmt:
+		// The table of the statistics is unknown before execution, so the privilege is checked on the current schema.
+		dbName := b.ctx.GetSessionVars().CurrentDB
+		if dbName == "" {
+			return nil, plannererrors.ErrNoDB
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = plannererrors.ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, dbName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, strings.ToLower(dbName), "", "", authErr)
 	case *ast.TruncateTableStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = plannererrors.ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5507,6 +5729,15 @@ func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt)
 	if err != nil {
 		return nil, err
 	}
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		if len(selectIntoInfo.Vars) != targetPlan.Schema().Len() {
+			return nil, plannererrors.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+		}
+		return &SelectInto{
+			TargetPlan: targetPlan,
+			IntoOpt:    selectIntoInfo,
+		}, nil
+	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", plannererrors.ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
 		TargetPlan:     targetPlan,
@@ -5667,6 +5898,18 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreateView:
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowChangefeeds:
+		names = []string{"Changefeed", "Table", "Id", "Sink_uri", "Format", "Checkpoint_ts", "Written_changes", "State", "Last_error", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar,
+			mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime}
+		flags = []uint{0, 0, 0, 0, 0, mysql.UnsignedFlag, 0, 0, 0, 0}
+	case ast.ShowCreateTrigger:
+		names = []string{"Trigger", "sql_mode", "SQL Original Statement", "character_set_client", "collation_connection", "Database Collation", "Created"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeTimestamp}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowGrants:
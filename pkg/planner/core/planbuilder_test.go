@@ -75,6 +75,7 @@ func TestShow(t *testing.T) {
 		ast.ShowProcessList,
 		ast.ShowCreateDatabase,
 		ast.ShowEvents,
+		ast.ShowChangefeeds,
 		ast.ShowMasterStatus,
 		ast.ShowBackups,
 		ast.ShowRestores,
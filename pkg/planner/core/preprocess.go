@@ -287,6 +287,14 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.flag |= inCreateOrDropTable
+		p.stmtTp = TypeDrop
+		p.checkDropTableNames(node.Views)
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -381,8 +389,47 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		// The trigger name is not a table, only its schema is filled.
+		if node.Trigger.Schema.L == "" {
+			currentDB := p.sctx.GetSessionVars().CurrentDB
+			if currentDB == "" {
+				p.err = errors.Trace(plannererrors.ErrNoDB)
+				return in, true
+			}
+			node.Trigger.Schema = ast.NewCIStr(currentDB)
+		}
+	case *ast.DropChangefeedStmt:
+		p.stmtTp = TypeDrop
+		// The changefeed name is not a table, only its schema is filled.
+		if node.Changefeed.Schema.L == "" {
+			currentDB := p.sctx.GetSessionVars().CurrentDB
+			if currentDB == "" {
+				p.err = errors.Trace(plannererrors.ErrNoDB)
+				return in, true
+			}
+			node.Changefeed.Schema = ast.NewCIStr(currentDB)
+		}
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
+	case *ast.ProcedureInfo, *ast.ProcedureBlock:
+		// The objects referenced by the procedure body are resolved when it's called.
+		return in, true
+	case *ast.CreateEventStmt, *ast.AlterEventStmt:
+		// The objects referenced by the event body are resolved when it's executed,
+		// and the schedule is evaluated by the executor.
+		return in, true
+	case *ast.CallStmt:
+		// The called procedure is not a function, only its arguments need to be preprocessed.
+		for i, arg := range node.Procedure.Args {
+			newArg, ok := arg.Accept(p)
+			if !ok {
+				return in, true
+			}
+			node.Procedure.Args[i] = newArg.(ast.ExprNode)
+		}
+		return in, true
 	case *ast.FuncCallExpr:
 		if node.FnName.L == ast.NextVal || node.FnName.L == ast.LastVal || node.FnName.L == ast.SetVal {
 			p.flag |= inSequenceFunction
@@ -614,9 +661,9 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
-	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
+	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *driver.ParamMarkerExpr:
 		if p.flag&inPrepare == 0 {
@@ -1045,6 +1092,31 @@ func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	switch sel := stmt.Select.(type) {
+	case *ast.SelectStmt:
+		p.checkCreateViewWithSelect(sel)
+	case *ast.SetOprStmt:
+		for _, selectStmt := range sel.SelectList.Selects {
+			p.checkCreateViewWithSelect(selectStmt)
+			if p.err != nil {
+				return
+			}
+		}
+	}
+}
+
 func (p *preprocessor) checkDropSequenceGrammar(stmt *ast.DropSequenceStmt) {
 	p.checkDropTableNames(stmt.Sequences)
 }
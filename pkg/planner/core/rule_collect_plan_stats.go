@@ -170,7 +170,7 @@ func (*CollectPredicateColumnsPoint) markAtLeastOneFullStatsLoadForEachTable(
 			return
 		}
 		for _, idx := range tbl.Indices {
-			if idx.State != model.StatePublic || idx.MVIndex {
+			if idx.State != model.StatePublic || idx.MVIndex || idx.IsSearchIndex() {
 				continue
 			}
 			// If any stats are already full loaded, we don't need to trigger stats loading on this table.
@@ -271,11 +271,15 @@ func RequestLoadStats(ctx base.PlanContext, neededHistItems []model.StatsLoadIte
 
 // SyncWaitStatsLoad sync-wait for stats load until timeout
 func SyncWaitStatsLoad(plan base.LogicalPlan) error {
-	stmtCtx := plan.SCtx().GetSessionVars().StmtCtx
+	return syncWaitStatsLoad(plan.SCtx())
+}
+
+func syncWaitStatsLoad(sctx base.PlanContext) error {
+	stmtCtx := sctx.GetSessionVars().StmtCtx
 	if len(stmtCtx.StatsLoad.NeededItems) <= 0 {
 		return nil
 	}
-	err := domain.GetDomain(plan.SCtx()).StatsHandle().SyncWaitStatsLoad(stmtCtx)
+	err := domain.GetDomain(sctx).StatsHandle().SyncWaitStatsLoad(stmtCtx)
 	if err != nil {
 		stmtCtx.IsSyncStatsFailed = true
 		if vardef.StatsLoadPseudoTimeout.Load() {
@@ -280,18 +280,23 @@ func (s *JoinReOrderSolver) optimizeRecursive(ctx base.PlanContext, p base.Logic
 				"We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid")
 		}
 
+		leadingGroup := curJoinGroup
 		if leadingHintInfo != nil && leadingHintInfo.LeadingJoinOrder != nil {
 			if useGreedy {
 				ok, leftJoinGroup := baseGroupSolver.generateLeadingJoinGroup(curJoinGroup, leadingHintInfo, hasOuterJoin)
 				if !ok {
 					ctx.GetSessionVars().StmtCtx.SetHintWarning(
 						"leading hint is inapplicable, check if the leading hint table is valid")
+					leadingGroup = nil
 				} else {
 					curJoinGroup = leftJoinGroup
 				}
 			} else {
 				ctx.GetSessionVars().StmtCtx.SetHintWarning("leading hint is inapplicable for the DP join reorder algorithm")
+				leadingGroup = nil
 			}
+		} else {
+			leadingGroup = nil
 		}
 
 		if useGreedy {
@@ -309,6 +314,9 @@ func (s *JoinReOrderSolver) optimizeRecursive(ctx base.PlanContext, p base.Logic
 		if err != nil {
 			return nil, err
 		}
+		if leadingGroup != nil && ctx.GetSessionVars().GetEnableCascadesPlanner() {
+			fixJoinOrder(p, leadingGroup)
+		}
 		schemaChanged := false
 		if len(p.Schema().Columns) != len(originalSchema.Columns) {
 			schemaChanged = true
@@ -404,6 +412,20 @@ type baseSingleGroupJoinOrderSolver struct {
 	*basicJoinGroupInfo
 }
 
+// fixJoinOrder marks the joins above the join group as straight joins, so the join order generated by the leading
+// hint is kept by the cascades planner, which re-associates the joins in memo.
+func fixJoinOrder(p base.LogicalPlan, joinGroup []base.LogicalPlan) {
+	if slices.Contains(joinGroup, p) {
+		return
+	}
+	if join, ok := p.(*logicalop.LogicalJoin); ok {
+		join.StraightJoin = true
+	}
+	for _, child := range p.Children() {
+		fixJoinOrder(child, joinGroup)
+	}
+}
+
 func (s *baseSingleGroupJoinOrderSolver) generateLeadingJoinGroup(curJoinGroup []base.LogicalPlan, hintInfo *h.PlanHints, hasOuterJoin bool) (bool, []base.LogicalPlan) {
 	var leadingJoinGroup []base.LogicalPlan
 	leftJoinGroup := make([]base.LogicalPlan, len(curJoinGroup))
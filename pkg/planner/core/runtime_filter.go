@@ -93,6 +93,10 @@ func NewRuntimeFilter(rfIDGenerator *util.IDGenerator, eqPredicate *expression.S
 	rfTypes := buildNode.SCtx().GetSessionVars().GetRuntimeFilterTypes()
 	result := make([]*RuntimeFilter, 0, len(rfTypes))
 	for _, rfType := range rfTypes {
+		// TiFlash doesn't support the bloom filter yet.
+		if rfType == variable.BloomFilter {
+			continue
+		}
 		rf := &RuntimeFilter{
 			id:          rfIDGenerator.GetNextID(),
 			buildNode:   buildNode,
@@ -34,6 +34,10 @@ type RuntimeFilterGenerator struct {
 	columnUniqueIDToRF            map[int64][]*RuntimeFilter
 	parentPhysicalPlan            base.PhysicalPlan
 	childIdxForParentPhysicalPlan int
+	// enableMPPRuntimeFilter indicates whether to generate the runtime filters of the hash joins in TiFlash.
+	enableMPPRuntimeFilter bool
+	// enableRootRuntimeFilter indicates whether to generate the runtime filters of the hash joins in TiDB.
+	enableRootRuntimeFilter bool
 }
 
 // GenerateRuntimeFilter is the root method.
@@ -61,7 +65,12 @@ PhysicalPlanTree:
 func (generator *RuntimeFilterGenerator) GenerateRuntimeFilter(plan base.PhysicalPlan) {
 	switch physicalPlan := plan.(type) {
 	case *PhysicalHashJoin:
-		generator.generateRuntimeFilterInterval(physicalPlan)
+		if generator.enableMPPRuntimeFilter {
+			generator.generateRuntimeFilterInterval(physicalPlan)
+		}
+		if generator.enableRootRuntimeFilter && physicalPlan.storeTp != kv.TiFlash {
+			generator.generateRootRuntimeFilter(physicalPlan)
+		}
 	case *PhysicalTableScan:
 		generator.assignRuntimeFilter(physicalPlan)
 	case *PhysicalTableReader:
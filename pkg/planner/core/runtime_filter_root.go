@@ -0,0 +1,221 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/executor/join/joinversion"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/planner/core/base"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// RootRuntimeFilter is a runtime filter of the hash join executed in TiDB, whose probe side reads from TiKV.
+// After the build side is finished, the hash join builds the filter from the values of the build key, and
+// pushes it down as the conditions of a Selection in the coprocessor requests of the probe side reader,
+// which are not sent until then. The coprocessor can't evaluate the bloom filter, so it is probed in TiDB
+// by the hash join on the probe side chunks before probing the hash table.
+// For example:
+// Query: select /*+ runtime_filter() */ * from t1, t2 where t1.a = t2.a
+// PhysicalPlanTree:
+//
+//	HashJoin_2(runtime filter:0[IN,BLOOM_FILTER] <- test.t2.a)
+//	├─TableReader_4(Build)
+//	│ └─TableFullScan_3(t2)
+//	└─TableReader_7(Probe)
+//	  └─Selection_8(runtime filter:0[IN] -> test.t1.a)
+//	    └─TableFullScan_6(t1)
+type RootRuntimeFilter struct {
+	// ID is unique in one query plan.
+	ID int
+	// PushDownTypes are the types of the filter pushed down as the coprocessor conditions of the TargetSelection.
+	PushDownTypes []RuntimeFilterType
+	// BloomFilter is whether the bloom filter of the build keys is probed in TiDB before probing the hash table.
+	BloomFilter bool
+	// EQCondIdx is the offset of the equal condition of the hash join the filter is built from.
+	EQCondIdx int
+	// SrcColumn is the build key column.
+	SrcColumn *expression.Column
+	// TargetColumn is the probe key column, its index is resolved against the schema of the scan below
+	// the TargetSelection.
+	TargetColumn *expression.Column
+	// TargetSelection is the Selection above the scan in the pushed down plans of the probe side reader.
+	// TargetColumn and TargetSelection are nil if no type of the filter is pushed down.
+	TargetSelection *PhysicalSelection
+}
+
+// ExplainInfo explain info of root runtime filter
+func (rf *RootRuntimeFilter) ExplainInfo(isBuildNode bool) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d[", rf.ID)
+	for i, rfType := range rf.PushDownTypes {
+		if i != 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(rfType.String())
+	}
+	// The bloom filter is only shown on the hash join since it's not pushed down.
+	if isBuildNode && rf.BloomFilter {
+		if len(rf.PushDownTypes) > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString(variable.BloomFilter.String())
+	}
+	builder.WriteString("]")
+	if isBuildNode {
+		fmt.Fprintf(&builder, " <- %s", rf.SrcColumn.String())
+	} else {
+		fmt.Fprintf(&builder, " -> %s", rf.TargetColumn.String())
+	}
+	return builder.String()
+}
+
+// generateRootRuntimeFilter constructs the runtime filters of a hash join executed in TiDB, whose probe side is a
+// TableReader or an IndexLookUpReader reading from TiKV. The bloom filter is probed in TiDB, so it's also
+// constructed if the other types can't be pushed down to the probe side.
+func (generator *RuntimeFilterGenerator) generateRootRuntimeFilter(hashJoinPlan *PhysicalHashJoin) {
+	// The runtime filters are only built by the hash join v2 executor.
+	sessVars := hashJoinPlan.SCtx().GetSessionVars()
+	if !sessVars.UseHashJoinV2 || !joinversion.IsHashJoinV2Supported() || !hashJoinPlan.CanUseHashJoinV2() {
+		return
+	}
+	// check hash join pattern
+	if !generator.matchRFJoinType(hashJoinPlan) {
+		return
+	}
+	rightIsBuildSide := hashJoinPlan.RightIsBuildSide()
+	probeSide := hashJoinPlan.Children()[0]
+	if !rightIsBuildSide {
+		probeSide = hashJoinPlan.Children()[1]
+	}
+	rfTypes := slices.Clone(sessVars.GetRuntimeFilterTypes())
+	slices.Sort(rfTypes)
+	bloomFilter := slices.Contains(rfTypes, variable.BloomFilter)
+	pushDownTypes := slices.DeleteFunc(rfTypes, func(tp RuntimeFilterType) bool {
+		return tp == variable.BloomFilter
+	})
+	ectx := hashJoinPlan.SCtx().GetExprCtx().GetEvalCtx()
+	for i, eqPredicate := range hashJoinPlan.EqualConditions {
+		if !generator.matchEQPredicate(ectx, eqPredicate, rightIsBuildSide) {
+			continue
+		}
+		srcColumn := eqPredicate.GetArgs()[1].(*expression.Column)
+		targetColumn := eqPredicate.GetArgs()[0].(*expression.Column)
+		if !rightIsBuildSide {
+			srcColumn, targetColumn = targetColumn, srcColumn
+		}
+		// The values of the build key are compared with the target column directly.
+		srcType, targetType := srcColumn.GetStaticType(), targetColumn.GetStaticType()
+		if srcType.EvalType() != targetType.EvalType() || srcType.GetCollate() != targetType.GetCollate() {
+			continue
+		}
+		var sel *PhysicalSelection
+		resolvedTarget := targetColumn
+		if len(pushDownTypes) > 0 {
+			sel, resolvedTarget = prepareRootRuntimeFilterTarget(probeSide, targetColumn)
+		}
+		if sel == nil && !bloomFilter {
+			continue
+		}
+		rf := &RootRuntimeFilter{
+			ID:          generator.rfIDGenerator.GetNextID(),
+			BloomFilter: bloomFilter,
+			EQCondIdx:   i,
+			SrcColumn:   srcColumn,
+		}
+		if sel != nil {
+			rf.PushDownTypes, rf.TargetColumn, rf.TargetSelection = pushDownTypes, resolvedTarget, sel
+			sel.rootRuntimeFilters = append(sel.rootRuntimeFilters, rf)
+		}
+		hashJoinPlan.rootRuntimeFilters = append(hashJoinPlan.rootRuntimeFilters, rf)
+	}
+}
+
+// prepareRootRuntimeFilterTarget returns the Selection in the pushed down plans of the probe side reader to apply
+// the runtime filter on the column, and the column resolved against the schema of the scan below the Selection.
+// Nil is returned if the runtime filter can't be pushed down to the reader.
+func prepareRootRuntimeFilterTarget(reader base.PhysicalPlan, col *expression.Column) (*PhysicalSelection, *expression.Column) {
+	switch x := reader.(type) {
+	case *PhysicalTableReader:
+		if x.StoreType != kv.TiKV {
+			return nil, nil
+		}
+		root, sel, target := insertRuntimeFilterSelection(x.tablePlan, x.TablePlans, col)
+		if sel != nil {
+			x.tablePlan, x.TablePlans = root, flattenPushDownPlan(root)
+		}
+		return sel, target
+	case *PhysicalIndexLookUpReader:
+		// The filter can't be applied before the pushed down limit.
+		if x.PushedLimit != nil {
+			return nil, nil
+		}
+		// Prefer the index side so that fewer rows are looked up. The string values in the index may be the
+		// collation keys, so they are always filtered on the table side.
+		if col.GetStaticType().EvalType() != types.ETString {
+			root, sel, target := insertRuntimeFilterSelection(x.indexPlan, x.IndexPlans, col)
+			if sel != nil {
+				x.indexPlan, x.IndexPlans = root, flattenPushDownPlan(root)
+				return sel, target
+			}
+		}
+		root, sel, target := insertRuntimeFilterSelection(x.tablePlan, x.TablePlans, col)
+		if sel != nil {
+			x.tablePlan, x.TablePlans = root, flattenPushDownPlan(root)
+		}
+		return sel, target
+	}
+	return nil, nil
+}
+
+// insertRuntimeFilterSelection inserts a Selection for the runtime filters above the scan of the pushed down plans,
+// whose conditions are filled when executing. The Selection is reused if it has been inserted by other runtime
+// filters. It returns the new root of the pushed down plans.
+func insertRuntimeFilterSelection(root base.PhysicalPlan, plans []base.PhysicalPlan, col *expression.Column) (
+	base.PhysicalPlan, *PhysicalSelection, *expression.Column) {
+	// The filter can't be applied before the pushed down limit or aggregation.
+	for _, p := range plans[1:] {
+		if _, ok := p.(*PhysicalSelection); !ok {
+			return root, nil, nil
+		}
+	}
+	scan := plans[0]
+	offset := scan.Schema().ColumnIndex(col)
+	if offset < 0 {
+		return root, nil, nil
+	}
+	target := col.Clone().(*expression.Column)
+	target.Index = offset
+	if len(plans) > 1 {
+		if sel := plans[1].(*PhysicalSelection); len(sel.rootRuntimeFilters) > 0 {
+			return root, sel, target
+		}
+	}
+	// The placeholder condition keeps the Selection valid if the filters are not built.
+	sel := PhysicalSelection{
+		Conditions: []expression.Expression{expression.NewOne()},
+	}.Init(scan.SCtx(), scan.StatsInfo(), scan.QueryBlockOffset())
+	sel.SetChildren(scan)
+	if len(plans) > 1 {
+		plans[1].SetChildren(sel)
+		return root, sel, target
+	}
+	return sel, sel, target
+}
@@ -135,6 +135,7 @@ func deriveStats4DataSource(lp base.LogicalPlan) (*property.StatsInfo, bool, err
 		ds.PushedDownConds[i] = expression.PushDownNot(exprCtx, expr)
 		ds.PushedDownConds[i] = expression.EliminateNoPrecisionLossCast(exprCtx, ds.PushedDownConds[i])
 	}
+	prunePartialIndexPaths(ds)
 	for _, path := range ds.AllPossibleAccessPaths {
 		if path.IsTablePath() {
 			continue
@@ -147,6 +148,7 @@ func deriveStats4DataSource(lp base.LogicalPlan) (*property.StatsInfo, bool, err
 	// TODO: Can we move ds.deriveStatsByFilter after pruning by heuristics? In this way some computation can be avoided
 	// when ds.PossibleAccessPaths are pruned.
 	ds.SetStats(deriveStatsByFilter(ds, ds.PushedDownConds, ds.AllPossibleAccessPaths))
+	applyCardinalityFeedback(ds)
 	// after heuristic pruning, the new path are stored into ds.PossibleAccessPaths.
 	err := derivePathStatsAndTryHeuristics(ds)
 	if err != nil {
@@ -171,6 +173,75 @@ func deriveStats4DataSource(lp base.LogicalPlan) (*property.StatsInfo, bool, err
 	return ds.StatsInfo(), true, nil
 }
 
+// prunePartialIndexPaths removes the paths of the partial indexes whose conditions
+// are not implied by the pushed down conditions, because such an index doesn't
+// cover all the rows the query may read. A condition is regarded as implied only
+// if each of its CNF items appears in the pushed down conditions.
+func prunePartialIndexPaths(ds *logicalop.DataSource) {
+	isPartial := func(path *util.AccessPath) bool {
+		return path.Index != nil && path.Index.HasCondition()
+	}
+	if !slices.ContainsFunc(ds.AllPossibleAccessPaths, isPartial) {
+		return
+	}
+	exprCtx := ds.SCtx().GetExprCtx()
+	evalCtx := exprCtx.GetEvalCtx()
+	// The output names of ds are not pruned together with its schema, so build
+	// the names from the remaining columns.
+	names := make(types.NameSlice, 0, len(ds.Columns))
+	for _, col := range ds.Columns {
+		names = append(names, &types.FieldName{DBName: ds.DBName, TblName: ds.TableInfo.Name, ColName: col.Name})
+	}
+	usable := make(map[int64]bool)
+	for _, path := range ds.AllPossibleAccessPaths {
+		if !isPartial(path) {
+			continue
+		}
+		if _, ok := usable[path.Index.ID]; ok {
+			continue
+		}
+		cond, err := expression.ParseSimpleExpr(exprCtx, path.Index.ConditionExprString,
+			expression.WithInputSchemaAndNames(ds.Schema(), names, nil))
+		implied := err == nil
+		if implied {
+			for _, item := range expression.SplitCNFItems(expression.PushDownNot(exprCtx, cond)) {
+				if !slices.ContainsFunc(ds.PushedDownConds, func(c expression.Expression) bool { return c.Equal(evalCtx, item) }) {
+					implied = false
+					break
+				}
+			}
+		}
+		if implied {
+			// The implication is decided by the constants of the query.
+			ds.SCtx().GetSessionVars().StmtCtx.SetSkipPlanCache("partial index is used")
+		} else if path.Forced {
+			ds.SCtx().GetSessionVars().StmtCtx.AppendWarning(errors.NewNoStackErrorf(
+				"partial index %s is inapplicable because its condition is not implied by the query", path.Index.Name.O))
+		}
+		usable[path.Index.ID] = implied
+	}
+	var tablePath *util.AccessPath
+	keep := func(paths []*util.AccessPath) []*util.AccessPath {
+		kept := make([]*util.AccessPath, 0, len(paths))
+		for _, path := range paths {
+			if !isPartial(path) || usable[path.Index.ID] {
+				kept = append(kept, path)
+			}
+		}
+		if len(kept) == 0 {
+			// All the hinted indexes are pruned, fall back to the table path.
+			if tablePath == nil {
+				tablePath = &util.AccessPath{StoreType: kv.TiKV}
+				fillContentForTablePath(tablePath, ds.TableInfo)
+			}
+			kept = append(kept, tablePath)
+		}
+		return kept
+	}
+	ds.AllPossibleAccessPaths = keep(ds.AllPossibleAccessPaths)
+	ds.PossibleAccessPaths = keep(ds.PossibleAccessPaths)
+}
+
 func fillIndexPath(ds *logicalop.DataSource, path *util.AccessPath, conds []expression.Expression) error {
 	if ds.SCtx().GetSessionVars().StmtCtx.EnableOptimizerDebugTrace {
 		debugtrace.EnterContextCommon(ds.SCtx())
@@ -483,6 +554,19 @@ func getGroupNDVs(ds *logicalop.DataSource) []property.GroupNDV {
 		}
 		return false
 	})
+	// The joint NDVs collected by CREATE STATISTICS are used if no index matches the column group.
+	for _, g := range colGroups {
+		ids := make([]int64, 0, len(g))
+		for _, col := range g {
+			ids = append(ids, col.UniqueID)
+		}
+		if slices.ContainsFunc(ndvs, func(ndv property.GroupNDV) bool { return slices.Equal(ndv.Cols, ids) }) {
+			continue
+		}
+		if ndv, ok := cardinality.EstimateGroupNDVByMultiColumnStats(tbl, ids); ok {
+			ndvs = append(ndvs, property.GroupNDV{Cols: ids, NDV: ndv})
+		}
+	}
 	return ndvs
 }
 
@@ -518,6 +602,9 @@ func initStats(ds *logicalop.DataSource) {
 		HistColl:     ds.StatisticTable.GenerateHistCollFromColumnInfo(ds.TableInfo, ds.TblCols),
 		StatsVersion: ds.StatisticTable.Version,
 	}
+	if ds.SCtx().GetSessionVars().EnableExtendedStats {
+		tableStats.HistColl.MultiColumnStats = ds.StatisticTable.ExtendedStats.MultiColumnStats()
+	}
 	if ds.StatisticTable.Pseudo {
 		tableStats.StatsVersion = statistics.PseudoVersion
 	}
@@ -564,6 +651,26 @@ func deriveStatsByFilter(ds *logicalop.DataSource, conds expression.CNFExprs, fi
 	return ds.TableStats.Scale(selectivity)
 }
 
+// applyCardinalityFeedback corrects the estimated rows of the filters on the DataSource by the cardinality feedback
+// learned from the executed statements.
+func applyCardinalityFeedback(ds *logicalop.DataSource) {
+	ds.CardinalityFeedback = nil
+	vars := ds.SCtx().GetSessionVars()
+	if !vars.EnableCardinalityFeedback || vars.InRestrictedSQL || len(ds.PushedDownConds) == 0 {
+		return
+	}
+	var store cardinality.FeedbackStore
+	if do := domain.GetDomain(ds.SCtx()); do != nil && do.CardinalityFeedback() != nil {
+		store = do.CardinalityFeedback()
+	}
+	rowCount, info := cardinality.ApplyFeedback(ds.SCtx(), store, ds.TableInfo.ID, ds.PushedDownConds,
+		ds.StatsInfo().RowCount, ds.TableStats.RowCount)
+	ds.CardinalityFeedback = info
+	if info.Applied() && ds.TableStats.RowCount > 0 {
+		ds.SetStats(ds.TableStats.Scale(rowCount / ds.TableStats.RowCount))
+	}
+}
+
 // We bind logic of derivePathStats and tryHeuristics together. When some path matches the heuristic rule, we don't need
 // to derive stats of subsequent paths. In this way we can save unnecessary computation of derivePathStats.
 func derivePathStatsAndTryHeuristics(ds *logicalop.DataSource) error {
@@ -602,7 +709,14 @@ func derivePathStatsAndTryHeuristics(ds *logicalop.DataSource) error {
 			path.IsSingleScan = true
 		} else {
 			deriveIndexPathStats(ds, path, ds.PushedDownConds, false)
-			path.IsSingleScan = isSingleScan(ds, path.FullIdxCols, path.FullIdxColLens)
+			idxCols, idxColLens := appendIndexIncludeCols(ds, path.Index, path.FullIdxCols, path.FullIdxColLens)
+			path.IsSingleScan = isSingleScan(ds, idxCols, idxColLens)
+			if path.IsSingleScan && len(idxCols) > len(path.FullIdxCols) {
+				// The filters on the included columns can be evaluated by the index scan.
+				var indexFilters []expression.Expression
+				indexFilters, path.TableFilters = splitIndexFilterConditions(ds, path.TableFilters, idxCols, idxColLens)
+				path.IndexFilters = append(path.IndexFilters, indexFilters...)
+			}
 		}
 		// step: 3
 		// Try some heuristic rules to select access path.
@@ -189,6 +189,8 @@ func toString(in base.Plan, strs []string, idxs []int) ([]string, []int) {
 		}
 	case *logicalop.LogicalShowDDLJobs, *PhysicalShowDDLJobs:
 		str = "ShowDDLJobs"
+	case *logicalop.LogicalJSONTable, *PhysicalJSONTable:
+		str = "JSONTable"
 	case *logicalop.LogicalSort, *PhysicalSort:
 		str = "Sort"
 	case *logicalop.LogicalJoin:
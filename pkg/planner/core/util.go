@@ -33,7 +33,6 @@ import (
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
-	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/set"
 	"github.com/pingcap/tidb/pkg/util/size"
 )
@@ -402,7 +401,7 @@ func EncodeUniqueIndexValuesForKey(ctx sessionctx.Context, tblInfo *model.TableI
 		}
 	}
 
-	encodedIdxVals, err := codec.EncodeKey(sc.TimeZone(), nil, idxVals...)
+	encodedIdxVals, err := tablecodec.EncodeIndexColumnValues(sc.TimeZone(), nil, idxInfo, idxVals)
 	err = sc.HandleError(err)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,424 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"go.uber.org/zap"
+)
+
+// The sources of the recommendations.
+const (
+	// SourceManual means the recommendation is made by `RECOMMEND INDEX RUN`.
+	SourceManual = "manual"
+	// SourceAuto means the recommendation is made by the automatic index advisor.
+	SourceAuto = "auto"
+)
+
+// The status of the recommendations.
+const (
+	// StatusRecommended means the index is recommended but not created yet.
+	StatusRecommended = "recommended"
+	// StatusVerifying means the index is created as an invisible index to be verified.
+	StatusVerifying = "verifying"
+	// StatusCreated means the index is created by the user.
+	StatusCreated = "created"
+	// StatusAccepted means the invisible index is verified and made visible.
+	StatusAccepted = "accepted"
+	// StatusRejected means the invisible index doesn't bring the estimated benefit and is dropped.
+	StatusRejected = "rejected"
+)
+
+const (
+	// minVerifyConfidence is the minimum confidence of a recommendation to be verified by an invisible index.
+	minVerifyConfidence = 0.3
+	// minVerifyRatio is the minimum ratio of the verified improvement to the estimated one to accept an index.
+	minVerifyRatio = 0.5
+)
+
+// Tracking represents how a recommendation evolves, it's saved in the
+// `tracking_details` column of `mysql.index_advisor_results`.
+type Tracking struct {
+	RecommendCount int
+	// BaselineLatency is the average latency of the impacted queries before the index is created, keyed by digest.
+	BaselineLatency     map[string]float64
+	CreatedAt           *time.Time `json:",omitempty"`
+	VerifiedImprovement float64
+}
+
+// RunAutoAdvisor runs the index advisor against the workload history and tracks the
+// previous recommendations. It's run periodically by the automatic index advisor.
+func RunAutoAdvisor(ctx context.Context, sctx sessionctx.Context) error {
+	opt := NewOptimizer(sctx)
+	if err := trackRecommendations(sctx, opt); err != nil {
+		return err
+	}
+
+	option := &Option{UseWorkloadRepo: true}
+	if err := fillOption(sctx, option, nil); err != nil {
+		return err
+	}
+	results, err := adviseIndexesWithOption(ctx, sctx, option)
+	if errors.Is(err, errNoQueries) || errors.Is(err, errEmptyQuerySet) {
+		advisorLogger().Info("no queries to run the auto index advisor")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	saveRecommendations(sctx, results, SourceAuto)
+
+	if vardef.AutoIndexAdvisorVerify.Load() {
+		return createInvisibleIndexes(sctx, opt)
+	}
+	return nil
+}
+
+// recommendConfidence returns how confident a recommendation is, it grows with
+// the number of runs recommending the index and the frequency of the impacted queries.
+func recommendConfidence(recommendCount, frequency int) float64 {
+	stability := float64(recommendCount) / float64(recommendCount+1)
+	support := math.Min(1, math.Log10(1+float64(frequency))/3)
+	return round(stability*support, 6)
+}
+
+func saveRecommendations(sctx sessionctx.Context, results []*Recommendation, source string) {
+	for _, r := range results {
+		q, err := json.Marshal(r.TopImpactedQueries)
+		if err != nil {
+			advisorLogger().Error("marshal top impacted queries failed", zap.Error(err))
+			continue
+		}
+		w, err := json.Marshal(r.WorkloadImpact)
+		if err != nil {
+			advisorLogger().Error("marshal workload impact failed", zap.Error(err))
+			continue
+		}
+		d, err := json.Marshal(r.IndexDetail)
+		if err != nil {
+			advisorLogger().Error("marshal index detail failed", zap.Error(err))
+			continue
+		}
+		indexColumns := strings.Join(r.IndexColumns, ",")
+		tracking, err := loadTracking(sctx, r.Database, r.Table, indexColumns)
+		if err != nil {
+			advisorLogger().Error("load tracking details failed", zap.Error(err))
+			continue
+		}
+		tracking.RecommendCount++
+		for _, query := range r.TopImpactedQueries {
+			if _, ok := tracking.BaselineLatency[query.Digest]; ok || query.Digest == "" {
+				continue
+			}
+			latency, err := avgQueryLatency(sctx, query.Digest, time.Now().Add(-24*time.Hour))
+			if err != nil {
+				advisorLogger().Warn("get the latency of the impacted query failed", zap.Error(err))
+				continue
+			}
+			if latency > 0 {
+				tracking.BaselineLatency[query.Digest] = latency
+			}
+		}
+		t, err := json.Marshal(tracking)
+		if err != nil {
+			advisorLogger().Error("marshal tracking details failed", zap.Error(err))
+			continue
+		}
+		confidence := recommendConfidence(tracking.RecommendCount, r.WorkloadImpact.ImpactedQueryFrequency)
+
+		template := `insert into mysql.index_advisor_results (
+                created_at, updated_at, schema_name, table_name, index_name,
+                index_columns, index_details, top_impacted_queries, workload_impact, extra,
+                source, estimated_improvement, confidence, tracking_details) values
+            (now(), now(), %?, %?, %?, %?, %?, %?, %?, null, %?, %?, %?, %?)
+            on duplicate key update
+            updated_at=now(), index_details=%?, top_impacted_queries=%?, workload_impact=%?,
+            estimated_improvement=%?, confidence=%?, tracking_details=%?`
+
+		if _, err := exec(sctx, template, r.Database, r.Table,
+			r.IndexName, indexColumns,
+			json.RawMessage(d), json.RawMessage(q), json.RawMessage(w),
+			source, r.WorkloadImpact.WorkloadImprovement, confidence, json.RawMessage(t),
+			json.RawMessage(d), json.RawMessage(q), json.RawMessage(w),
+			r.WorkloadImpact.WorkloadImprovement, confidence, json.RawMessage(t)); err != nil {
+			advisorLogger().Error("save advise result failed", zap.Error(err))
+		}
+	}
+}
+
+func loadTracking(sctx sessionctx.Context, schema, table, indexColumns string) (*Tracking, error) {
+	rows, err := exec(sctx, `select tracking_details from mysql.index_advisor_results
+			where schema_name = %? and table_name = %? and index_columns = %?`, schema, table, indexColumns)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return decodeTracking(nil, 0)
+	}
+	return decodeTracking(&rows[0], 0)
+}
+
+// decodeTracking decodes the tracking details in the column of the row, the row is nil for a new recommendation.
+func decodeTracking(row *chunk.Row, colIdx int) (*Tracking, error) {
+	tracking := new(Tracking)
+	if row != nil && !row.IsNull(colIdx) {
+		if err := json.Unmarshal([]byte(row.GetJSON(colIdx).String()), tracking); err != nil {
+			return nil, err
+		}
+	}
+	if tracking.BaselineLatency == nil {
+		tracking.BaselineLatency = make(map[string]float64)
+	}
+	return tracking, nil
+}
+
+// avgQueryLatency returns the average latency of the query with the digest since
+// the specified time, it returns 0 if the query isn't executed.
+func avgQueryLatency(sctx sessionctx.Context, digest string, since time.Time) (float64, error) {
+	rows, err := exec(sctx, `select sum(sum_latency) / sum(exec_count)
+			from information_schema.statements_summary_history
+			where digest = %? and summary_begin_time >= from_unixtime(%?)`, digest, since.Unix())
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 || rows[0].IsNull(0) {
+		return 0, nil
+	}
+	latency, err := rows[0].GetMyDecimal(0).ToFloat64()
+	if err != nil {
+		return 0, err
+	}
+	return latency, nil
+}
+
+// trackedRecommendation is a recommendation whose status is tracked by the automatic index advisor.
+type trackedRecommendation struct {
+	id        int64
+	schema    string
+	table     string
+	indexName string
+	columns   []string
+	status    string
+	queries   []*ImpactedQuery
+	tracking  *Tracking
+}
+
+// trackRecommendations updates the status of the recommendations: the recommended
+// indexes created by the user are marked as created, the invisible indexes being
+// verified are accepted or rejected, and the realized improvement of the created
+// indexes is updated.
+func trackRecommendations(sctx sessionctx.Context, opt Optimizer) error {
+	rows, err := exec(sctx, `select id, schema_name, table_name, index_name, index_columns, status,
+			top_impacted_queries, tracking_details from mysql.index_advisor_results
+			where status in (%?, %?, %?, %?)`, StatusRecommended, StatusVerifying, StatusCreated, StatusAccepted)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		r := &trackedRecommendation{
+			id:        row.GetInt64(0),
+			schema:    row.GetString(1),
+			table:     row.GetString(2),
+			indexName: row.GetString(3),
+			columns:   strings.Split(row.GetString(4), ","),
+			status:    row.GetString(5),
+		}
+		if !row.IsNull(6) {
+			if err := json.Unmarshal([]byte(row.GetJSON(6).String()), &r.queries); err != nil {
+				advisorLogger().Warn("unmarshal top impacted queries failed", zap.Int64("id", r.id), zap.Error(err))
+				continue
+			}
+		}
+		if r.tracking, err = decodeTracking(&row, 7); err != nil {
+			advisorLogger().Warn("unmarshal tracking details failed", zap.Int64("id", r.id), zap.Error(err))
+			continue
+		}
+
+		switch r.status {
+		case StatusRecommended:
+			err = trackCreation(sctx, opt, r)
+		case StatusVerifying:
+			err = verifyInvisibleIndex(sctx, opt, r)
+		default:
+			err = trackImprovement(sctx, r)
+		}
+		if err != nil {
+			advisorLogger().Warn("track the recommendation failed", zap.Int64("id", r.id), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// trackCreation marks the recommendation as created if the user has created the index.
+func trackCreation(sctx sessionctx.Context, opt Optimizer, r *trackedRecommendation) error {
+	exist, err := opt.PrefixContainIndex(NewIndex(r.schema, r.table, r.indexName, r.columns...))
+	if err != nil || !exist {
+		return err
+	}
+	now := time.Now()
+	r.status, r.tracking.CreatedAt = StatusCreated, &now
+	return updateTracking(sctx, r)
+}
+
+// verifyInvisibleIndex compares the plan cost of the impacted queries with and without
+// the invisible index. The index is made visible if the improvement is close to the
+// estimated one, otherwise it's dropped.
+func verifyInvisibleIndex(sctx sessionctx.Context, opt Optimizer, r *trackedRecommendation) error {
+	exist, err := opt.IndexNameExist(r.schema, r.table, strings.ToLower(r.indexName))
+	if err != nil {
+		return err
+	}
+	if !exist { // the index is dropped by the user
+		r.status = StatusRejected
+		return updateTracking(sctx, r)
+	}
+
+	var estimated, verified float64
+	for _, query := range r.queries {
+		costBefore, err := planCostWithInvisibleIndexes(sctx, opt, query.Query, false)
+		if err != nil {
+			return err
+		}
+		costAfter, err := planCostWithInvisibleIndexes(sctx, opt, query.Query, true)
+		if err != nil {
+			return err
+		}
+		if costBefore == 0 { // avoid NaN
+			costBefore += 0.1
+			costAfter += 0.1
+		}
+		estimated += query.Improvement
+		verified += (costBefore - costAfter) / costBefore
+	}
+	if len(r.queries) > 0 {
+		estimated /= float64(len(r.queries))
+		verified /= float64(len(r.queries))
+	}
+	r.tracking.VerifiedImprovement = round(verified, 6)
+
+	if verified > 0 && verified >= estimated*minVerifyRatio {
+		if _, err := exec(sctx, "ALTER TABLE %n.%n ALTER INDEX %n VISIBLE", r.schema, r.table, r.indexName); err != nil {
+			return err
+		}
+		now := time.Now()
+		r.status, r.tracking.CreatedAt = StatusAccepted, &now
+	} else {
+		if _, err := exec(sctx, "ALTER TABLE %n.%n DROP INDEX %n", r.schema, r.table, r.indexName); err != nil {
+			return err
+		}
+		r.status = StatusRejected
+	}
+	advisorLogger().Info("invisible index verified", zap.String("schema", r.schema), zap.String("table", r.table),
+		zap.String("index", r.indexName), zap.String("status", r.status),
+		zap.Float64("estimated-improvement", estimated), zap.Float64("verified-improvement", verified))
+	return updateTracking(sctx, r)
+}
+
+func planCostWithInvisibleIndexes(sctx sessionctx.Context, opt Optimizer, query string, useInvisible bool) (float64, error) {
+	original := sctx.GetSessionVars().OptimizerUseInvisibleIndexes
+	defer func() {
+		sctx.GetSessionVars().OptimizerUseInvisibleIndexes = original
+	}()
+	sctx.GetSessionVars().OptimizerUseInvisibleIndexes = useInvisible
+	return opt.QueryPlanCost(query)
+}
+
+// trackImprovement updates the realized improvement of the created index by comparing
+// the latency of the impacted queries before and after the index is created.
+func trackImprovement(sctx sessionctx.Context, r *trackedRecommendation) error {
+	if r.tracking.CreatedAt == nil {
+		return nil
+	}
+	var improvement float64
+	var n int
+	for digest, latencyBefore := range r.tracking.BaselineLatency {
+		latencyAfter, err := avgQueryLatency(sctx, digest, *r.tracking.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if latencyBefore == 0 || latencyAfter == 0 {
+			continue
+		}
+		improvement += 1 - latencyAfter/latencyBefore
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	_, err := exec(sctx, `update mysql.index_advisor_results set updated_at = now(), realized_improvement = %?
+			where id = %?`, round(improvement/float64(n), 6), r.id)
+	return err
+}
+
+func updateTracking(sctx sessionctx.Context, r *trackedRecommendation) error {
+	t, err := json.Marshal(r.tracking)
+	if err != nil {
+		return err
+	}
+	_, err = exec(sctx, `update mysql.index_advisor_results set updated_at = now(), status = %?, tracking_details = %?
+			where id = %?`, r.status, json.RawMessage(t), r.id)
+	return err
+}
+
+// createInvisibleIndexes creates the confident recommendations of the automatic index
+// advisor as invisible indexes, they are verified by the next run before becoming visible.
+func createInvisibleIndexes(sctx sessionctx.Context, opt Optimizer) error {
+	rows, err := exec(sctx, `select id, schema_name, table_name, index_name, index_columns, tracking_details
+			from mysql.index_advisor_results where source = %? and status = %? and confidence >= %?`,
+		SourceAuto, StatusRecommended, minVerifyConfidence)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		r := &trackedRecommendation{
+			id:        row.GetInt64(0),
+			schema:    row.GetString(1),
+			table:     row.GetString(2),
+			indexName: row.GetString(3),
+			columns:   strings.Split(row.GetString(4), ","),
+			status:    StatusVerifying,
+		}
+		if r.tracking, err = decodeTracking(&row, 5); err != nil {
+			return err
+		}
+		if exist, err := opt.IndexNameExist(r.schema, r.table, strings.ToLower(r.indexName)); err != nil || exist {
+			continue
+		}
+
+		args := []any{r.schema, r.table, r.indexName}
+		for _, col := range r.columns {
+			args = append(args, col)
+		}
+		template := "ALTER TABLE %n.%n ADD INDEX %n(" + strings.Repeat("%n, ", len(r.columns)-1) + "%n) INVISIBLE"
+		if _, err := exec(sctx, template, args...); err != nil {
+			advisorLogger().Warn("create invisible index failed", zap.String("schema", r.schema),
+				zap.String("table", r.table), zap.String("index", r.indexName), zap.Error(err))
+			continue
+		}
+		if err := updateTracking(sctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package indexadvisor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor/autoadvisor"
+	"github.com/pingcap/tidb/pkg/testkit"
+	s "github.com/pingcap/tidb/pkg/util/set"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoAdvisorTracking(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int)`)
+
+	querySet := s.NewSet[indexadvisor.Query]()
+	querySet.Add(indexadvisor.Query{SchemaName: "test", Text: "select * from t where a=1", Frequency: 1000})
+	ctx := context.WithValue(context.Background(), indexadvisor.TestKey("query_set"), querySet)
+	q := `select index_columns, source, status, confidence, tracking_details->'$.RecommendCount'
+		from mysql.index_advisor_results`
+
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a auto recommended 0.5 1"))
+	tk.MustQuery(`select estimated_improvement > 0 from mysql.index_advisor_results`).Check(testkit.Rows("1"))
+
+	// the confidence grows with the number of runs recommending the index
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a auto recommended 0.666667 2"))
+
+	// the recommendation made by RECOMMEND INDEX RUN is saved as manual
+	tk.MustQuery(`recommend index run for "select * from t where b=1"`)
+	tk.MustQuery(q + ` where index_columns='b'`).Check(testkit.Rows("b manual recommended 0.050172 1"))
+
+	// the index created by the user is tracked
+	tk.MustExec(`create index idx_a on t(a)`)
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q + ` where index_columns='a'`).Check(testkit.Rows("a auto created 0.666667 2"))
+	tk.MustQuery(`select tracking_details->'$.CreatedAt' is not null from mysql.index_advisor_results
+		where index_columns='a'`).Check(testkit.Rows("1"))
+}
+
+func TestAutoAdvisorVerifyInvisibleIndex(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`use test`)
+	tk.MustExec(`create table t (a int, b int, c int)`)
+	tk.MustExec(`insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3), (4, 4, 4)`)
+	tk.MustExec(`set global tidb_auto_index_advisor_verify = on`)
+	defer tk.MustExec(`set global tidb_auto_index_advisor_verify = default`)
+
+	querySet := s.NewSet[indexadvisor.Query]()
+	querySet.Add(indexadvisor.Query{SchemaName: "test", Text: "select * from t where a=1", Frequency: 1000})
+	querySet.Add(indexadvisor.Query{SchemaName: "test", Text: "select * from t where b=1", Frequency: 1})
+	ctx := context.WithValue(context.Background(), indexadvisor.TestKey("query_set"), querySet)
+	q := `select index_columns, status from mysql.index_advisor_results order by index_columns`
+
+	// only the confident recommendations are created as invisible indexes
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a verifying", "b recommended"))
+	tk.MustQuery(`select index_name, is_visible from information_schema.statistics
+		where table_schema='test' and table_name='t'`).Check(testkit.Rows("idx_a NO"))
+
+	// the invisible index is made visible after verified
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a accepted", "b recommended"))
+	tk.MustQuery(`select index_name, is_visible from information_schema.statistics
+		where table_schema='test' and table_name='t'`).Check(testkit.Rows("idx_a YES"))
+	tk.MustQuery(`select tracking_details->'$.VerifiedImprovement' > 0 from mysql.index_advisor_results
+		where index_columns='a'`).Check(testkit.Rows("1"))
+
+	// the verifying recommendation is rejected if the index is dropped by the user
+	querySet = s.NewSet[indexadvisor.Query]()
+	querySet.Add(indexadvisor.Query{SchemaName: "test", Text: "select * from t where b=1", Frequency: 1000})
+	ctx = context.WithValue(context.Background(), indexadvisor.TestKey("query_set"), querySet)
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a accepted", "b verifying"))
+	tk.MustExec(`alter table t drop index idx_b`)
+	require.NoError(t, indexadvisor.RunAutoAdvisor(ctx, tk.Session()))
+	tk.MustQuery(q).Check(testkit.Rows("a accepted", "b rejected"))
+}
+
+func TestAutoAdvisorScheduler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	scheduler := domain.GetDomain(tk.Session()).IndexAdvisorScheduler()
+	require.NotNil(t, scheduler)
+
+	tk.MustExec(`set global tidb_auto_index_advisor_interval = '1h'`)
+	tk.MustExec(`set global tidb_enable_auto_index_advisor = on`)
+	defer func() {
+		tk.MustExec(`set global tidb_enable_auto_index_advisor = default`)
+		tk.MustExec(`set global tidb_auto_index_advisor_interval = default`)
+	}()
+	tk.MustQuery(`select @@global.tidb_auto_index_advisor_interval`).Check(testkit.Rows("1h0m0s"))
+
+	// the first run is triggered once the timer is created
+	require.Eventually(t, func() bool {
+		timer, err := scheduler.Client().GetTimerByKey(context.Background(), autoadvisor.TimerKey)
+		if err != nil || !timer.Enable || timer.SchedPolicyExpr != "1h0m0s" || len(timer.SummaryData) == 0 {
+			return false
+		}
+		var summary map[string]any
+		require.NoError(t, json.Unmarshal(timer.SummaryData, &summary))
+		require.Nil(t, summary["last_error"])
+		return summary["last_run"] != nil
+	}, 30*time.Second, 100*time.Millisecond)
+
+	tk.MustExec(`set global tidb_enable_auto_index_advisor = off`)
+	require.Eventually(t, func() bool {
+		timer, err := scheduler.Client().GetTimerByKey(context.Background(), autoadvisor.TimerKey)
+		return err == nil && !timer.Enable
+	}, 10*time.Second, 100*time.Millisecond)
+}
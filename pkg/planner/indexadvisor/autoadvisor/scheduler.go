@@ -0,0 +1,272 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autoadvisor
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	timerapi "github.com/pingcap/tidb/pkg/timer/api"
+	timerrt "github.com/pingcap/tidb/pkg/timer/runtime"
+	"github.com/pingcap/tidb/pkg/timer/tablestore"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	// TimerKey is the key of the timer which schedules the automatic index advisor.
+	TimerKey       = "/tidb/index_advisor/auto"
+	timerHookClass = "tidb.index_advisor"
+)
+
+var checkSchedulerInterval = 10 * time.Second
+
+func init() {
+	if intest.InTest {
+		checkSchedulerInterval = 100 * time.Millisecond
+	}
+}
+
+// RunFunc runs the index advisor once against the workload history with the session.
+type RunFunc func(ctx context.Context, sctx sessionctx.Context) error
+
+// Scheduler runs the index advisor periodically on the owner node when the
+// `tidb_enable_auto_index_advisor` system variable is ON. The runs are
+// scheduled by a timer whose interval is `tidb_auto_index_advisor_interval`.
+type Scheduler struct {
+	store      *timerapi.TimerStore
+	cli        timerapi.TimerClient
+	pool       util.DestroyableSessionPool
+	run        RunFunc
+	leaderFunc func() bool
+	rt         *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     util.WaitGroupWrapper
+}
+
+// NewScheduler creates a new scheduler of the automatic index advisor.
+func NewScheduler(pool util.DestroyableSessionPool, etcd *clientv3.Client, run RunFunc, leaderFunc func() bool) *Scheduler {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		store:      store,
+		cli:        timerapi.NewDefaultTimerClient(store),
+		pool:       pool,
+		run:        run,
+		leaderFunc: leaderFunc,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+// Client returns the client to manage the timer of the automatic index advisor.
+func (s *Scheduler) Client() timerapi.TimerClient {
+	return s.cli
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Run(s.loop)
+}
+
+// Stop stops the scheduler.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	ticker := time.NewTicker(checkSchedulerInterval)
+	defer func() {
+		ticker.Stop()
+		s.pause()
+		logutil.BgLogger().Info("auto index advisor scheduler loop exited.")
+	}()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.leaderFunc == nil || !s.leaderFunc() {
+			s.pause()
+			continue
+		}
+		if err := s.syncTimer(); err != nil {
+			logutil.BgLogger().Warn("failed to sync the timer of auto index advisor", zap.Error(err))
+			continue
+		}
+		s.resume()
+	}
+}
+
+// syncTimer creates or updates the timer to match the system variables.
+func (s *Scheduler) syncTimer() error {
+	enable := vardef.EnableAutoIndexAdvisor.Load()
+	expr := vardef.AutoIndexAdvisorInterval.Load().String()
+	timer, err := s.cli.GetTimerByKey(s.ctx, TimerKey)
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		if !enable {
+			return nil
+		}
+		_, err = s.cli.CreateTimer(s.ctx, timerapi.TimerSpec{
+			Key:             TimerKey,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: expr,
+			HookClass:       timerHookClass,
+			Enable:          true,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	if timer.Enable == enable && timer.SchedPolicyExpr == expr {
+		return nil
+	}
+	return s.cli.UpdateTimer(s.ctx, timer.ID,
+		timerapi.WithSetEnable(enable),
+		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, expr))
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	s.rt = timerrt.NewTimerRuntimeBuilder("index_advisor", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(TimerKey)}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newAdvisorTimerHook(s.pool, s.run, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// advisorTimerSummary is the summary of the last run of the automatic index advisor.
+type advisorTimerSummary struct {
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+type advisorTimerHook struct {
+	pool   util.DestroyableSessionPool
+	run    RunFunc
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newAdvisorTimerHook(pool util.DestroyableSessionPool, run RunFunc, cli timerapi.TimerClient) *advisorTimerHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &advisorTimerHook{
+		pool:   pool,
+		run:    run,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*advisorTimerHook) Start() {}
+
+func (t *advisorTimerHook) Stop() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+func (*advisorTimerHook) OnPreSchedEvent(_ context.Context, _ timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	if !vardef.EnableAutoIndexAdvisor.Load() {
+		r.Delay = time.Minute
+	}
+	return
+}
+
+func (t *advisorTimerHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	if err := t.ctx.Err(); err != nil {
+		return err
+	}
+	timer := event.Timer()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", event.EventID()),
+		zap.Time("eventStart", timer.EventStart),
+	)
+	logger.Info("timer triggered to run auto index advisor")
+	t.wg.Add(1)
+	go t.runAdvisor(logger, timer, event.EventID())
+	return nil
+}
+
+// runAdvisor runs the index advisor and closes the timer event. The watermark
+// is moved to the start of the event, so the next run is one interval later.
+func (t *advisorTimerHook) runAdvisor(logger *zap.Logger, timer *timerapi.TimerRecord, eventID string) {
+	defer t.wg.Done()
+
+	now := time.Now()
+	summary := &advisorTimerSummary{LastRun: &now}
+	if err := t.runWithSession(); err != nil {
+		logger.Warn("failed to run auto index advisor", zap.Error(err))
+		summary.LastError = err.Error()
+	}
+
+	summaryData, err := json.Marshal(summary)
+	if err != nil {
+		logger.Error("marshal summary error", zap.Error(err))
+		return
+	}
+	if err = t.cli.CloseTimerEvent(t.ctx, timer.ID, eventID,
+		timerapi.WithSetWatermark(timer.EventStart), timerapi.WithSetSummaryData(summaryData)); err != nil {
+		logger.Error("CloseTimerEvent error", zap.Error(err))
+	}
+}
+
+func (t *advisorTimerHook) runWithSession() (err error) {
+	se, err := t.pool.Get()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			t.pool.Put(se)
+		} else {
+			// The session may be left in an unknown state, so it's not reused.
+			t.pool.Destroy(se)
+		}
+	}()
+	return t.run(t.ctx, se.(sessionctx.Context))
+}
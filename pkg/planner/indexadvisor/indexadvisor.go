@@ -16,7 +16,6 @@ package indexadvisor
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
@@ -24,7 +23,9 @@ import (
 	"strings"
 	"time"
 
+	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	s "github.com/pingcap/tidb/pkg/util/set"
@@ -43,6 +44,10 @@ type Option struct {
 	MaxNumQuery   int
 	Timeout       time.Duration
 	SpecifiedSQLs []string
+
+	// UseWorkloadRepo indicates whether to load the queries from the snapshots of the
+	// workload repository, the statements summary is used if there are no snapshots.
+	UseWorkloadRepo bool
 }
 
 // AdviseIndexes is the entry point for the index advisor.
@@ -55,7 +60,12 @@ func AdviseIndexes(ctx context.Context, sctx sessionctx.Context, userSQLs []stri
 		return nil, err
 	}
 
-	return adviseIndexesWithOption(ctx, sctx, option)
+	results, err = adviseIndexesWithOption(ctx, sctx, option)
+	if err != nil {
+		return nil, err
+	}
+	saveRecommendations(sctx, results, SourceManual)
+	return results, nil
 }
 
 func adviseIndexesWithOption(ctx context.Context, sctx sessionctx.Context,
@@ -103,8 +113,6 @@ func adviseIndexesWithOption(ctx context.Context, sctx sessionctx.Context,
 		return nil, err
 	}
 
-	saveRecommendations(sctx, results)
-
 	if len(results) == 0 {
 		indexableColsTmp := make([]string, 0, 5)
 		for _, col := range indexableColSet.ToList() {
@@ -147,11 +155,11 @@ func prepareQuerySet(ctx context.Context, sctx sessionctx.Context,
 			querySet = ctx.Value(TestKey("query_set")).(s.Set[Query])
 		} else {
 			var err error
-			if querySet, err = loadQuerySetFromStmtSummary(sctx, option); err != nil {
+			if querySet, err = loadQuerySet(sctx, option); err != nil {
 				return nil, err
 			}
 			if querySet.Size() == 0 {
-				return nil, errors.New("can't get any queries from statements_summary")
+				return nil, errNoQueries
 			}
 		}
 	}
@@ -171,16 +179,83 @@ func prepareQuerySet(ctx context.Context, sctx sessionctx.Context,
 		return nil, err
 	}
 	if querySet.Size() == 0 {
-		return nil, errors.New("empty query set after filtering invalid queries")
+		return nil, errEmptyQuerySet
 	}
 	advisorLogger().Info("finish query preparation", zap.Int("num_query", querySet.Size()))
 	return querySet, nil
 }
 
+// histStmtStatsTable is the table in the workload repository to save the snapshots of `TIDB_STATEMENTS_STATS`.
+const histStmtStatsTable = "HIST_TIDB_STATEMENTS_STATS"
+
+var (
+	// errNoQueries is returned when there are no queries in the workload history.
+	errNoQueries = errors.New("can't get any queries from statements_summary")
+	// errEmptyQuerySet is returned when all the queries are filtered out.
+	errEmptyQuerySet = errors.New("empty query set after filtering invalid queries")
+)
+
+func loadQuerySet(sctx sessionctx.Context, option *Option) (s.Set[Query], error) {
+	if option.UseWorkloadRepo {
+		querySet, err := loadQuerySetFromWorkloadRepo(sctx, option)
+		if err != nil {
+			return nil, err
+		}
+		if querySet.Size() > 0 {
+			return querySet, nil
+		}
+	}
+	return loadQuerySetFromStmtSummary(sctx, option)
+}
+
+// loadQuerySetFromWorkloadRepo loads the queries from the snapshots of `TIDB_STATEMENTS_STATS`
+// taken by the workload repository in the last day. The execution count in the snapshots is
+// cumulative, so the latest one of each instance is used.
+func loadQuerySetFromWorkloadRepo(sctx sessionctx.Context, option *Option) (s.Set[Query], error) {
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	if !is.TableExists(ast.NewCIStr(mysql.WorkloadSchema), ast.NewCIStr(histStmtStatsTable)) {
+		return s.NewSet[Query](), nil // the workload repository is disabled
+	}
+
+	template := `SELECT digest, any_value(schema_name) as schema_name,
+				any_value(query_sample_text) as query_sample_text,
+				sum(exec_count) as exec_count
+			FROM (
+				SELECT digest, any_value(ifnull(schema_name, "")) as schema_name,
+					any_value(query_sample_text) as query_sample_text,
+					cast(max(exec_count) as double) as exec_count
+				FROM %n.%n
+				WHERE stmt_type = "Select" AND
+					ts >= date_sub(now(), interval 1 day) AND
+					prepared = 0 AND
+					upper(ifnull(schema_name, "")) not in ("MYSQL", "INFORMATION_SCHEMA", "METRICS_SCHEMA", "PERFORMANCE_SCHEMA")
+				GROUP BY instance_id, digest
+			) t
+			GROUP BY digest
+			ORDER BY sum(exec_count) DESC
+			LIMIT %?`
+	rows, err := exec(sctx, template, mysql.WorkloadSchema, histStmtStatsTable, option.MaxNumQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	querySet := s.NewSet[Query]()
+	for _, r := range rows {
+		querySet.Add(Query{
+			Digest:     r.GetString(0),
+			SchemaName: r.GetString(1),
+			Text:       r.GetString(2),
+			Frequency:  int(r.GetFloat64(3)),
+		})
+	}
+	return querySet, nil
+}
+
 func loadQuerySetFromStmtSummary(sctx sessionctx.Context, option *Option) (s.Set[Query], error) {
 	template := `SELECT any_value(ifnull(schema_name, "")) as schema_name,
 				any_value(query_sample_text) as query_sample_text,
-				sum(cast(exec_count as double)) as exec_count
+				sum(cast(exec_count as double)) as exec_count,
+				digest
 			FROM information_schema.statements_summary_history
 			WHERE stmt_type = "Select" AND
 				summary_begin_time >= date_sub(now(), interval 1 day) AND
@@ -202,6 +277,7 @@ func loadQuerySetFromStmtSummary(sctx sessionctx.Context, option *Option) (s.Set
 		querySet.Add(Query{
 			SchemaName: schemaName,
 			Text:       queryText,
+			Digest:     r.GetString(3),
 			Frequency:  int(execCount),
 		})
 	}
@@ -262,8 +338,10 @@ func prepareRecommendation(indexes s.Set[Index], queries s.Set[Query], optimizer
 			if queryImprovement < 0.0001 {
 				continue // this query has no benefit
 			}
+			workloadImpact.ImpactedQueryFrequency += query.Frequency
 			impacts = append(impacts, &ImpactedQuery{
 				Query:       query.Text,
+				Digest:      query.Digest,
 				Improvement: queryImprovement,
 			})
 		}
@@ -326,37 +404,3 @@ func gracefulIndexName(opt Optimizer, schema, tableName string, cols []string) s
 	}
 	return indexName
 }
-
-func saveRecommendations(sctx sessionctx.Context, results []*Recommendation) {
-	for _, r := range results {
-		q, err := json.Marshal(r.TopImpactedQueries)
-		if err != nil {
-			advisorLogger().Error("marshal top impacted queries failed", zap.Error(err))
-			continue
-		}
-		w, err := json.Marshal(r.WorkloadImpact)
-		if err != nil {
-			advisorLogger().Error("marshal workload impact failed", zap.Error(err))
-			continue
-		}
-		d, err := json.Marshal(r.IndexDetail)
-		if err != nil {
-			advisorLogger().Error("marshal index detail failed", zap.Error(err))
-			continue
-		}
-
-		template := `insert into mysql.index_advisor_results (
-                created_at, updated_at, schema_name, table_name, index_name,
-                index_columns, index_details, top_impacted_queries, workload_impact, extra) values
-            (now(), now(), %?, %?, %?, %?, %?, %?, %?, null)
-            on duplicate key update
-            updated_at=now(), index_details=%?, top_impacted_queries=%?, workload_impact=%?`
-
-		if _, err := exec(sctx, template, r.Database, r.Table,
-			r.IndexName, strings.Join(r.IndexColumns, ","),
-			json.RawMessage(d), json.RawMessage(q), json.RawMessage(w),
-			json.RawMessage(d), json.RawMessage(q), json.RawMessage(w)); err != nil {
-			advisorLogger().Error("save advise result failed", zap.Error(err))
-		}
-	}
-}
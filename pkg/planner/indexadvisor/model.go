@@ -25,6 +25,7 @@ type Query struct { // DQL or DML
 	Alias      string
 	SchemaName string
 	Text       string
+	Digest     string // the digest of the original query text, used to track the latency of the query
 	Frequency  int
 }
 
@@ -138,12 +139,14 @@ func (c IndexSetCost) Less(other IndexSetCost) bool {
 // ImpactedQuery represents the impacted query.
 type ImpactedQuery struct {
 	Query       string
+	Digest      string
 	Improvement float64
 }
 
 // WorkloadImpact represents the workload impact.
 type WorkloadImpact struct {
-	WorkloadImprovement float64
+	WorkloadImprovement    float64
+	ImpactedQueryFrequency int // the total frequency of the queries which benefit from the index
 }
 
 // IndexDetail represents the detail of the index.
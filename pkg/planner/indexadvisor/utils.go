@@ -292,6 +292,9 @@ func RestoreSchemaName(defaultSchema string, sqls s.Set[Query], ignoreErr bool)
 		if sql.SchemaName == "" {
 			sql.SchemaName = defaultSchema
 		}
+		if sql.Digest == "" {
+			_, sql.Digest = NormalizeDigest(sql.Text)
+		}
 		stmt, err := ParseOneSQL(sql.Text)
 		if err != nil {
 			if ignoreErr {
@@ -386,6 +386,32 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx planctx.PlanContext,
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization for the statements in a trigger body.
+// The statements run inside the triggering statement, so the plan ids and the
+// tables of the statement context are not reset as buildLogicalPlan does.
+// The privileges are checked when the trigger is created.
+func OptimizeForTrigger(ctx context.Context, sctx planctx.PlanContext, node *resolve.NodeW, is infoschema.InfoSchema) (base.Plan, error) {
+	hintProcessor := hint.NewQBHintHandler(sctx.GetSessionVars().StmtCtx)
+	node.Node.Accept(hintProcessor)
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	builder.Init(sctx, is, hintProcessor)
+	p, err := builder.Build(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, err
+	}
+	logic, isLogicalPlan := p.(base.LogicalPlan)
+	if !isLogicalPlan {
+		return p, nil
+	}
+	core.RecheckCTE(logic)
+	finalPlan, _, err := core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+	return finalPlan, err
+}
+
 func allowInReadOnlyMode(sctx planctx.PlanContext, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -191,6 +191,17 @@ func (path *AccessPath) IsTiFlashSimpleTablePath() bool {
 	return path.StoreType == kv.TiFlash && path.Index == nil
 }
 
+// HasDescIdxCol returns true if it's an index path and some index columns are stored in descending order.
+func (path *AccessPath) HasDescIdxCol() bool {
+	return !path.IsTablePath() && path.Index != nil && path.Index.HasDescColumn()
+}
+
+// IsIdxColDesc returns whether the i-th column of IdxCols is stored in descending order.
+// The handle columns appended to IdxCols are always in ascending order.
+func (path *AccessPath) IsIdxColDesc(i int) bool {
+	return !path.IsTablePath() && path.Index != nil && i < len(path.Index.Columns) && path.Index.Columns[i].Desc
+}
+
 // SplitCorColAccessCondFromFilters move the necessary filter in the form of index_col = corrlated_col to access conditions.
 // The function consider the `idx_col_1 = const and index_col_2 = cor_col and index_col_3 = const` case.
 // It enables more index columns to be considered. The range will be rebuilt in 'ResolveCorrelatedColumns'.
@@ -56,6 +56,10 @@ var FindBestTask4LogicalShow func(lp base.LogicalPlan, prop *property.PhysicalPr
 var FindBestTask4LogicalShowDDLJobs func(lp base.LogicalPlan, prop *property.PhysicalProperty,
 	planCounter *base.PlanCounterTp, _ *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error)
 
+// FindBestTask4LogicalJSONTable will be called by LogicalJSONTable in logicalOp pkg.
+var FindBestTask4LogicalJSONTable func(lp base.LogicalPlan, prop *property.PhysicalProperty,
+	planCounter *base.PlanCounterTp, _ *optimizetrace.PhysicalOptimizeOp) (base.Task, int64, error)
+
 // FindBestTask4LogicalCTE will be called by LogicalCTE in logicalOp pkg.
 var FindBestTask4LogicalCTE func(lp base.LogicalPlan, prop *property.PhysicalProperty,
 	counter *base.PlanCounterTp, pop *optimizetrace.PhysicalOptimizeOp) (t base.Task, cntPlan int64, err error)
@@ -148,6 +152,10 @@ var DeriveStats4LogicalTableScan func(lp base.LogicalPlan) (_ *property.StatsInf
 var AddPrefix4ShardIndexes func(lp base.LogicalPlan, sc base.PlanContext,
 	conds []expression.Expression) []expression.Expression
 
+// PrunePartition4DataSource will be called by the partition pruning rule in cascades pkg.
+// It returns whether the partition read by the DataSource can be pruned by the conditions.
+var PrunePartition4DataSource func(ds base.LogicalPlan, conds []expression.Expression) (bool, error)
+
 // ApplyPredicateSimplification will be called by LogicalSelection in logicalOp pkg.
 var ApplyPredicateSimplification func(base.PlanContext, []expression.Expression) []expression.Expression
 
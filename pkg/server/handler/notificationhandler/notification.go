@@ -0,0 +1,129 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notificationhandler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/notification"
+	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/session"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultLimit   = 100
+	maxLimit       = 10000
+	defaultTimeout = 30 * time.Second
+	maxTimeout     = 5 * time.Minute
+)
+
+// pollInterval is the interval to check for new notifications during a long poll.
+var pollInterval = 200 * time.Millisecond
+
+// Response is the response of NotificationHandler.
+type Response struct {
+	Notifications []*notification.Notification `json:"notifications"`
+	// Position is the position to resume the subscription from.
+	Position notification.Position `json:"position"`
+}
+
+// NotificationHandler long polls the notifications of a channel.
+type NotificationHandler struct {
+	store kv.Storage
+}
+
+// NewNotificationHandler returns a new NotificationHandler.
+func NewNotificationHandler(store kv.Storage) *NotificationHandler {
+	return &NotificationHandler{store: store}
+}
+
+// ServeHTTP handles request of fetching the notifications of a channel.
+// The request returns the notifications after the position `since` as soon as
+// there are any, or an empty list after `timeout` seconds. The subscriber
+// passes the returned position as `since` in the next request, so every
+// notification is received at least once.
+func (h NotificationHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		handler.WriteError(w, errors.Errorf("This api only support GET method"))
+		return
+	}
+	channel := mux.Vars(req)["channel"]
+	var since notification.Position
+	if s := req.FormValue("since"); s != "" {
+		var err error
+		if since, err = notification.ParsePosition(s); err != nil {
+			handler.WriteError(w, err)
+			return
+		}
+	}
+	limit := defaultLimit
+	if s := req.FormValue("limit"); s != "" {
+		l, err := strconv.Atoi(s)
+		if err != nil || l <= 0 || l > maxLimit {
+			handler.WriteError(w, errors.Errorf("invalid limit '%s'", s))
+			return
+		}
+		limit = l
+	}
+	timeout := defaultTimeout
+	if s := req.FormValue("timeout"); s != "" {
+		sec, err := strconv.Atoi(s)
+		if err != nil || sec < 0 || time.Duration(sec)*time.Second > maxTimeout {
+			handler.WriteError(w, errors.Errorf("invalid timeout '%s'", s))
+			return
+		}
+		timeout = time.Duration(sec) * time.Second
+	}
+
+	se, err := session.CreateSession(h.store)
+	if err != nil {
+		handler.WriteError(w, err)
+		return
+	}
+	defer se.Close()
+
+	ctx := kv.WithInternalSourceType(req.Context(), kv.InternalTxnOthers)
+	deadline := time.Now().Add(timeout)
+	for {
+		if err = notification.Publish(ctx, se); err != nil {
+			logutil.Logger(ctx).Warn("failed to publish notifications", zap.Error(err))
+		}
+		notifications, err := notification.Fetch(ctx, se, channel, since, limit)
+		if err != nil {
+			handler.WriteError(w, err)
+			return
+		}
+		if len(notifications) > 0 || !time.Now().Before(deadline) {
+			resp := Response{Notifications: notifications, Position: since}
+			if len(notifications) > 0 {
+				resp.Position = notifications[len(notifications)-1].Position
+			}
+			handler.WriteData(w, resp)
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
@@ -55,6 +55,7 @@ import (
 	"github.com/pingcap/tidb/pkg/planner/core"
 	server2 "github.com/pingcap/tidb/pkg/server"
 	"github.com/pingcap/tidb/pkg/server/handler"
+	"github.com/pingcap/tidb/pkg/server/handler/notificationhandler"
 	"github.com/pingcap/tidb/pkg/server/handler/optimizor"
 	"github.com/pingcap/tidb/pkg/server/handler/tikvhandler"
 	"github.com/pingcap/tidb/pkg/server/internal/testserverclient"
@@ -1135,6 +1136,66 @@ func TestDDLHookHandler(t *testing.T) {
 	require.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
+func TestNotificationHandler(t *testing.T) {
+	ts := createBasicHTTPHandlerTestSuite()
+	ts.startServer(t)
+	defer ts.stopServer(t)
+
+	db, err := sql.Open("mysql", ts.GetDSN())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	dbt := testkit.NewDBTestKit(t, db)
+
+	fetch := func(path string) notificationhandler.Response {
+		resp, err := ts.FetchStatus(path)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, resp.Body.Close())
+		}()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var data notificationhandler.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&data))
+		return data
+	}
+
+	data := fetch("/notifications/ch?timeout=0")
+	require.Empty(t, data.Notifications)
+	require.Equal(t, "0-0", data.Position.String())
+
+	dbt.MustExec("notify ch, 'a'")
+	dbt.MustExec("notify ch, 'b'")
+	dbt.MustExec("notify other, 'c'")
+	data = fetch("/notifications/ch?timeout=0&limit=1")
+	require.Len(t, data.Notifications, 1)
+	require.Equal(t, "a", *data.Notifications[0].Payload)
+	data = fetch("/notifications/ch?timeout=0&since=" + data.Position.String())
+	require.Len(t, data.Notifications, 1)
+	require.Equal(t, "b", *data.Notifications[0].Payload)
+	require.Equal(t, data.Notifications[0].Position, data.Position)
+
+	// the request waits for the next notification
+	since := data.Position.String()
+	errCh := make(chan error, 1)
+	go func() {
+		time.Sleep(500 * time.Millisecond)
+		_, err := db.Exec("notify ch")
+		errCh <- err
+	}()
+	data = fetch("/notifications/ch?timeout=10&since=" + since)
+	require.NoError(t, <-errCh)
+	require.Len(t, data.Notifications, 1)
+	require.Nil(t, data.Notifications[0].Payload)
+
+	for _, path := range []string{"/notifications/ch?since=x", "/notifications/ch?limit=0", "/notifications/ch?timeout=-1"} {
+		resp, err := ts.FetchStatus(path)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		require.NoError(t, resp.Body.Close())
+	}
+}
+
 func TestWriteDBTablesData(t *testing.T) {
 	// No table in a schema.
 	info := infoschema.MockInfoSchema([]*model.TableInfo{})
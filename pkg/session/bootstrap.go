@@ -367,8 +367,8 @@ const (
 		name varchar(32) NOT NULL,
 		type tinyint(4) NOT NULL,
 		table_id bigint(64) NOT NULL,
-		column_ids varchar(32) NOT NULL,
-		stats blob DEFAULT NULL,
+		column_ids varchar(256) NOT NULL,
+		stats longblob DEFAULT NULL,
 		version bigint(64) unsigned NOT NULL,
 		status tinyint(4) NOT NULL,
 		PRIMARY KEY(name, table_id),
@@ -764,6 +764,12 @@ const (
        top_impacted_queries json, -- improvement, plan before and after this index, ...
        workload_impact json,      -- improvement and more details, ...
        extra json,                -- for the cloud env to save more info like RU, cost_saving, ...
+       source varchar(16) not null default 'manual',     -- manual or auto
+       status varchar(16) not null default 'recommended', -- recommended, verifying, created, accepted or rejected
+       estimated_improvement double default null,
+       confidence double default null,
+       realized_improvement double default null,
+       tracking_details json,     -- recommend count, baseline latency of the impacted queries, ...
        index idx_create(created_at),
        index idx_update(updated_at),
        unique index idx(schema_name, table_name, index_columns));`
@@ -788,6 +794,76 @@ const (
 		value json NOT NULL,
 		index idx_version_category_type (version, category, type),
 		index idx_table_id (table_id));`
+
+	// CreateRoutinesTable is a table to store stored routines such as procedures.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		route_schema varchar(64) COLLATE utf8mb4_general_ci NOT NULL,
+		name varchar(64) COLLATE utf8mb4_general_ci NOT NULL,
+		type enum('FUNCTION','PROCEDURE') NOT NULL,
+		definition longtext NOT NULL,
+		parameter_str longtext NOT NULL,
+		definer varchar(288) NOT NULL,
+		sql_mode varchar(1024) NOT NULL,
+		security_type enum('DEFINER','INVOKER') NOT NULL,
+		comment text NOT NULL,
+		character_set_client varchar(32) NOT NULL,
+		collation_connection varchar(32) NOT NULL,
+		database_collation varchar(32) NOT NULL,
+		created timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		PRIMARY KEY (route_schema, name, type));`
+
+	// CreateMViewLogTable is a table to store the groups changed in the base tables of
+	// the materialized views, which are consumed by the incremental refresh.
+	// A NULL group_key means the view must be refreshed completely.
+	// The log of a view is clustered in the order it's written, so that the refresh reads
+	// and purges it by the primary key. SHARD_ROW_ID_BITS can't be used with the clustered
+	// index, the writes of different views are spread by the mview_id prefix instead.
+	CreateMViewLogTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_mview_log (
+		mview_id bigint(20) NOT NULL,
+		commit_ts bigint(20) unsigned NOT NULL,
+		seq bigint(20) NOT NULL,
+		group_key blob,
+		PRIMARY KEY (mview_id, commit_ts, seq) CLUSTERED);`
+
+	// CreateChangefeedCheckpointTable is a table to store the progress of the changefeeds.
+	// The resolved_ts is set when a batch is claimed, and the changes committed in
+	// (checkpoint_ts, resolved_ts] are written by the batch.
+	CreateChangefeedCheckpointTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_changefeed_checkpoint (
+		changefeed_id bigint(20) NOT NULL PRIMARY KEY,
+		batch bigint(20) NOT NULL DEFAULT 1,
+		checkpoint_ts bigint(20) unsigned NOT NULL DEFAULT 0,
+		resolved_ts bigint(20) unsigned DEFAULT NULL,
+		written_changes bigint(20) NOT NULL DEFAULT 0,
+		last_error text,
+		update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP);`
+
+	// CreateChangefeedLogTable is a table to store the record keys changed in the tables
+	// of the changefeeds, which are logged by the transactions before they commit.
+	// The start_ts is the start ts of the transaction, and the changes of the keys are
+	// read from their committed versions by the changefeed.
+	CreateChangefeedLogTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_changefeed_log (
+		changefeed_id bigint(20) NOT NULL,
+		start_ts bigint(20) unsigned NOT NULL,
+		seq bigint(20) NOT NULL,
+		record_key blob NOT NULL,
+		PRIMARY KEY (changefeed_id, start_ts, seq) CLUSTERED);`
+
+	// CreateNotificationTable is a table to store the notifications sent by NOTIFY.
+	// The batch is set when the notification is published to the subscribers.
+	CreateNotificationTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_notification (
+		channel varchar(64) NOT NULL,
+		payload text,
+		batch bigint(20) DEFAULT NULL,
+		create_time timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		index idx_batch (batch),
+		index idx_channel_batch (channel, batch),
+		index idx_create_time (create_time));`
+
+	// CreateNotificationBatchTable is a table to store the last published batch of the notifications.
+	CreateNotificationBatchTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_notification_batch (
+		id int NOT NULL PRIMARY KEY,
+		batch bigint(20) NOT NULL DEFAULT 0);`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -1284,11 +1360,43 @@ const (
 	// version 247
 	// Add last_stats_histograms_version to mysql.stats_meta.
 	version247 = 247
+
+	// version 248
+	// Add mysql.routines to store stored procedures.
+	version248 = 248
+
+	// version 249
+	// Add mysql.tidb_mview_log to store the changes for the materialized views.
+	version249 = 249
+
+	// version 250
+	// Add mysql.tidb_changefeed_checkpoint for the changefeeds.
+	version250 = 250
+
+	// version 251
+	// Add mysql.tidb_notification and mysql.tidb_notification_batch for NOTIFY.
+	version251 = 251
+
+	// version 252
+	// Add columns to mysql.index_advisor_results to track the recommendations of the automatic index advisor.
+	version252 = 252
+
+	// version 253
+	// Enlarge `column_ids` and `stats` of mysql.stats_extended for the multi-column stats created by CREATE STATISTICS.
+	version253 = 253
+
+	// version 254
+	// Recreate mysql.tidb_mview_log with the clustered primary key (mview_id, commit_ts, seq).
+	version254 = 254
+
+	// version 255
+	// Add mysql.tidb_changefeed_log to log the record keys changed in the tables of the changefeeds.
+	version255 = 255
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version247
+var currentBootstrapVersion int64 = version255
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1471,6 +1579,14 @@ var (
 		upgradeToVer245,
 		upgradeToVer246,
 		upgradeToVer247,
+		upgradeToVer248,
+		upgradeToVer249,
+		upgradeToVer250,
+		upgradeToVer251,
+		upgradeToVer252,
+		upgradeToVer253,
+		upgradeToVer254,
+		upgradeToVer255,
 	}
 )
 
@@ -3477,6 +3593,101 @@ func upgradeToVer247(s sessiontypes.Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.stats_meta ADD COLUMN last_stats_histograms_version bigint unsigned DEFAULT NULL", infoschema.ErrColumnExists)
 }
 
+func upgradeToVer248(s sessiontypes.Session, ver int64) {
+	if ver >= version248 {
+		return
+	}
+	doReentrantDDL(s, CreateRoutinesTable)
+}
+
+func upgradeToVer249(s sessiontypes.Session, ver int64) {
+	if ver >= version249 {
+		return
+	}
+	doReentrantDDL(s, CreateMViewLogTable)
+}
+
+func upgradeToVer250(s sessiontypes.Session, ver int64) {
+	if ver >= version250 {
+		return
+	}
+	doReentrantDDL(s, CreateChangefeedCheckpointTable)
+}
+
+func upgradeToVer251(s sessiontypes.Session, ver int64) {
+	if ver >= version251 {
+		return
+	}
+	doReentrantDDL(s, CreateNotificationTable)
+	doReentrantDDL(s, CreateNotificationBatchTable)
+}
+
+func upgradeToVer252(s sessiontypes.Session, ver int64) {
+	if ver >= version252 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `source` varchar(16) NOT NULL DEFAULT 'manual'")
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `status` varchar(16) NOT NULL DEFAULT 'recommended'")
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `estimated_improvement` double DEFAULT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `confidence` double DEFAULT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `realized_improvement` double DEFAULT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.index_advisor_results ADD COLUMN IF NOT EXISTS `tracking_details` json")
+}
+
+func upgradeToVer253(s sessiontypes.Session, ver int64) {
+	if ver >= version253 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.stats_extended MODIFY COLUMN `column_ids` varchar(256) NOT NULL")
+	doReentrantDDL(s, "ALTER TABLE mysql.stats_extended MODIFY COLUMN `stats` longblob DEFAULT NULL")
+}
+
+func upgradeToVer254(s sessiontypes.Session, ver int64) {
+	if ver >= version254 {
+		return
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
+	// The primary key can't be changed by ALTER TABLE, so the log is recreated if it doesn't have the new columns.
+	if _, err := s.ExecuteInternal(ctx, "SELECT HIGH_PRIORITY `commit_ts` FROM mysql.tidb_mview_log LIMIT 0"); err == nil {
+		return
+	}
+	rs, err := s.ExecuteInternal(ctx, "SELECT DISTINCT mview_id FROM mysql.tidb_mview_log")
+	if err != nil {
+		logutil.BgLogger().Fatal("upgradeToVer254 error", zap.Error(err))
+		return
+	}
+	var mvIDs []int64
+	req := rs.NewChunk(nil)
+	for {
+		err = rs.Next(ctx, req)
+		if err != nil {
+			logutil.BgLogger().Fatal("upgradeToVer254 error", zap.Error(err))
+			return
+		}
+		if req.NumRows() == 0 {
+			break
+		}
+		for i := range req.NumRows() {
+			mvIDs = append(mvIDs, req.GetRow(i).GetInt64(0))
+		}
+		req.Reset()
+	}
+	terror.Call(rs.Close)
+	mustExecute(s, "DROP TABLE IF EXISTS mysql.tidb_mview_log")
+	doReentrantDDL(s, CreateMViewLogTable)
+	// The views with the changes logged before the upgrade are completely refreshed next time.
+	for _, id := range mvIDs {
+		mustExecute(s, "INSERT HIGH_PRIORITY IGNORE INTO mysql.tidb_mview_log VALUES (%?, 0, 0, NULL)", id)
+	}
+}
+
+func upgradeToVer255(s sessiontypes.Session, ver int64) {
+	if ver >= version255 {
+		return
+	}
+	doReentrantDDL(s, CreateChangefeedLogTable)
+}
+
 // initGlobalVariableIfNotExists initialize a global variable with specific val if it does not exist.
 func initGlobalVariableIfNotExists(s sessiontypes.Session, name string, val any) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBootstrap)
@@ -3633,6 +3844,18 @@ func doDDLWorks(s sessiontypes.Session) {
 	mustExecute(s, CreateKernelOptionsTable)
 	// create mysql.tidb_workload_values
 	mustExecute(s, CreateTiDBWorkloadValuesTable)
+	// create mysql.routines
+	mustExecute(s, CreateRoutinesTable)
+	// create mysql.tidb_mview_log
+	mustExecute(s, CreateMViewLogTable)
+	// create mysql.tidb_changefeed_checkpoint
+	mustExecute(s, CreateChangefeedCheckpointTable)
+	// create mysql.tidb_changefeed_log
+	mustExecute(s, CreateChangefeedLogTable)
+	// create mysql.tidb_notification
+	mustExecute(s, CreateNotificationTable)
+	// create mysql.tidb_notification_batch
+	mustExecute(s, CreateNotificationBatchTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
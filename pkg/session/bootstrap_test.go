@@ -239,6 +239,19 @@ func TestBootstrapWithError(t *testing.T) {
 	MustExec(t, se, "SELECT * from mysql.tidb_ttl_table_status")
 	// Check mysql.tidb_workload_values table
 	MustExec(t, se, "SELECT * from mysql.tidb_workload_values")
+	// Check mysql.routines table
+	MustExec(t, se, "SELECT * from mysql.routines")
+	// Check mysql.tidb_mview_log table
+	MustExec(t, se, "SELECT * from mysql.tidb_mview_log")
+	// Check mysql.tidb_changefeed_checkpoint table
+	MustExec(t, se, "SELECT * from mysql.tidb_changefeed_checkpoint")
+	// Check mysql.tidb_changefeed_log table
+	MustExec(t, se, "SELECT * from mysql.tidb_changefeed_log")
+	// Check mysql.tidb_notification and mysql.tidb_notification_batch tables
+	MustExec(t, se, "SELECT * from mysql.tidb_notification")
+	MustExec(t, se, "SELECT * from mysql.tidb_notification_batch")
+	// Check the tracking columns of mysql.index_advisor_results
+	MustExec(t, se, "SELECT source, status, estimated_improvement, confidence, realized_improvement, tracking_details from mysql.index_advisor_results")
 }
 
 func TestDDLTableCreateBackfillTable(t *testing.T) {
@@ -2502,6 +2515,61 @@ func TestTiDBUpgradeToVer240(t *testing.T) {
 	require.Contains(t, string(chk.GetRow(0).GetBytes(1)), "idx_schema_table_partition_state")
 }
 
+func TestTiDBUpgradeToVer254(t *testing.T) {
+	ctx := context.Background()
+	store, dom := CreateStoreAndBootstrap(t)
+	defer func() { require.NoError(t, store.Close()) }()
+
+	ver253 := version253
+	seV253 := CreateSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMutator(txn)
+	err = m.FinishBootstrap(int64(ver253))
+	require.NoError(t, err)
+	revertVersionAndVariables(t, seV253, ver253)
+	err = txn.Commit(ctx)
+	require.NoError(t, err)
+	store.SetOption(StoreBootstrappedKey, nil)
+
+	// Recreate the log without the primary key and log some changes.
+	MustExec(t, seV253, "drop table mysql.tidb_mview_log")
+	MustExec(t, seV253, "create table mysql.tidb_mview_log (mview_id bigint(20) NOT NULL, group_key blob, index idx_mview_id (mview_id))")
+	MustExec(t, seV253, "insert into mysql.tidb_mview_log values (1, 'a'), (1, NULL), (2, 'b')")
+
+	dom.Close()
+	domCurVer, err := BootstrapSession(store)
+	require.NoError(t, err)
+	defer domCurVer.Close()
+	seCurVer := CreateSessionAndSetID(t, store)
+	ver, err := getBootstrapVersion(seCurVer)
+	require.NoError(t, err)
+	require.Equal(t, currentBootstrapVersion, ver)
+
+	// The views with the changes logged before the upgrade are refreshed completely.
+	res := MustExecToRecodeSet(t, seCurVer, "select mview_id, commit_ts, seq, group_key from mysql.tidb_mview_log order by mview_id")
+	chk := res.NewChunk(nil)
+	err = res.Next(ctx, chk)
+	require.NoError(t, err)
+	require.Equal(t, 2, chk.NumRows())
+	for i, id := range []int64{1, 2} {
+		row := chk.GetRow(i)
+		require.Equal(t, id, row.GetInt64(0))
+		require.Equal(t, uint64(0), row.GetUint64(1))
+		require.Equal(t, int64(0), row.GetInt64(2))
+		require.True(t, row.IsNull(3))
+	}
+	require.NoError(t, res.Close())
+
+	res = MustExecToRecodeSet(t, seCurVer, "show create table mysql.tidb_mview_log")
+	chk = res.NewChunk(nil)
+	err = res.Next(ctx, chk)
+	require.NoError(t, err)
+	require.Equal(t, 1, chk.NumRows())
+	require.Contains(t, string(chk.GetRow(0).GetBytes(1)), "PRIMARY KEY (`mview_id`,`commit_ts`,`seq`) /*T![clustered_index] CLUSTERED */")
+	require.NoError(t, res.Close())
+}
+
 // testExampleAFunc is a example func for TestGetFuncName
 func testExampleAFunc(s sessiontypes.Session, i int64) {}
 
@@ -27,9 +27,11 @@ import (
 	stderrs "errors"
 	"fmt"
 	"iter"
+	"maps"
 	"math"
 	"math/rand"
 	"runtime/pprof"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -45,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
 	distsqlctx "github.com/pingcap/tidb/pkg/distsql/context"
+	"github.com/pingcap/tidb/pkg/disttask/changefeed"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/taskexecutor"
@@ -52,6 +55,7 @@ import (
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/errno"
+	"github.com/pingcap/tidb/pkg/eventscheduler"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/executor/staticrecordset"
 	"github.com/pingcap/tidb/pkg/expression"
@@ -78,6 +82,7 @@ import (
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
 	"github.com/pingcap/tidb/pkg/planner/core/base"
 	"github.com/pingcap/tidb/pkg/planner/core/resolve"
+	"github.com/pingcap/tidb/pkg/planner/indexadvisor"
 	planctx "github.com/pingcap/tidb/pkg/planner/planctx"
 	"github.com/pingcap/tidb/pkg/plugin"
 	"github.com/pingcap/tidb/pkg/privilege"
@@ -93,6 +98,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/sessiontxn/ssi"
 	"github.com/pingcap/tidb/pkg/statistics/handle/syncload"
 	"github.com/pingcap/tidb/pkg/statistics/handle/usage"
 	"github.com/pingcap/tidb/pkg/statistics/handle/usage/indexusage"
@@ -502,6 +508,17 @@ func (s *session) doCommit(ctx context.Context) error {
 		s.sessionVars.SetInTxn(false)
 		s.sessionVars.ClearDiskFullOpt()
 	}()
+	// check the rw-antidependencies of the serializable transaction, including the read-only one
+	if t := s.sessionVars.TxnCtx.SSI; t != nil {
+		writes, err := ssi.WriteKeys(s.txn.Transaction)
+		if err == nil {
+			err = t.Prepare(writes)
+		}
+		if err != nil {
+			s.RollbackTxn(ctx)
+			return err
+		}
+	}
 	// check if the transaction is read-only
 	if s.txn.IsReadOnly() {
 		return nil
@@ -533,6 +550,16 @@ func (s *session) doCommit(ctx context.Context) error {
 
 	sessVars := s.GetSessionVars()
 
+	// Log the changed record keys of the tables with changefeeds, the pipelined
+	// DML falls back to the standard mode for these tables.
+	if !s.txn.IsPipelined() && len(sessVars.TxnCtx.TableDeltaMap) > 0 {
+		physicalIDs := slices.Sorted(maps.Keys(sessVars.TxnCtx.TableDeltaMap))
+		is := sessiontxn.GetTxnManager(s).GetTxnInfoSchema()
+		if err = changefeed.LogChanges(ctx, s.GetTableCtx(), s.txn.Transaction, is, physicalIDs); err != nil {
+			return err
+		}
+	}
+
 	var commitTSChecker func(uint64) bool
 	if tables := sessVars.TxnCtx.CachedTables; len(tables) > 0 {
 		c := cachedTableRenewLease{tables: tables}
@@ -940,6 +967,8 @@ func (s *session) CommitTxn(ctx context.Context) error {
 		s.sessionVars.LastCommitTS = s.txn.lastCommitTS
 	}
 
+	s.finishSSITxn(err)
+
 	// record the TTLInsertRows in the metric
 	metrics.TTLInsertRowsCount.Add(float64(s.sessionVars.TxnCtx.InsertTTLRowsCount))
 
@@ -953,6 +982,32 @@ func (s *session) CommitTxn(ctx context.Context) error {
 	return err
 }
 
+// finishSSITxn finishes tracking the serializable transaction after it commits.
+func (s *session) finishSSITxn(commitErr error) {
+	t := s.sessionVars.TxnCtx.SSI
+	if t == nil {
+		return
+	}
+	s.sessionVars.TxnCtx.SSI = nil
+	if commitErr != nil {
+		t.Rollback()
+		return
+	}
+	commitTS := s.txn.lastCommitTS
+	if commitTS < t.StartTS() {
+		// The read-only transaction has no commit ts (lastCommitTS belongs to a former transaction), use the current ts to keep it tracked
+		// until the transactions concurrent with it are finished.
+		ver, err := s.store.CurrentVersion(kv.GlobalTxnScope)
+		if err != nil {
+			logutil.BgLogger().Warn("get current version for serializable txn failed", zap.Error(err))
+			t.Rollback()
+			return
+		}
+		commitTS = ver.Ver
+	}
+	t.Commit(commitTS)
+}
+
 func (s *session) RollbackTxn(ctx context.Context) {
 	r, ctx := tracing.StartRegionEx(ctx, "session.RollbackTxn")
 	defer r.End()
@@ -963,6 +1018,10 @@ func (s *session) RollbackTxn(ctx context.Context) {
 	if ctx.Value(inCloseSession{}) == nil {
 		s.cleanRetryInfo()
 	}
+	if t := s.sessionVars.TxnCtx.SSI; t != nil {
+		t.Rollback()
+		s.sessionVars.TxnCtx.SSI = nil
+	}
 	s.txn.changeToInvalid()
 	s.sessionVars.TxnCtx.Cleanup()
 	s.sessionVars.CleanupTxnReadTSIfUsed()
@@ -2620,6 +2679,7 @@ func (s *session) GetDistSQLCtx() *distsqlctx.DistSQLContext {
 			WarnHandler:     sc.WarnHandler,
 			InRestrictedSQL: sc.InRestrictedSQL,
 			Client:          s.GetClient(),
+			SSI:             vars.TxnCtx.SSI,
 
 			EnabledRateLimitAction: vars.EnabledRateLimitAction,
 			EnableChunkRPC:         vars.EnableChunkRPC,
@@ -3495,6 +3555,13 @@ func bootstrapSessionImpl(ctx context.Context, store kv.Storage, createSessionsI
 			return importinto.NewImportExecutor(ctx, task, param, store)
 		},
 	)
+	scheduler.RegisterSchedulerFactory(proto.Changefeed, changefeed.NewScheduler)
+	taskexecutor.RegisterTaskType(
+		proto.Changefeed,
+		func(ctx context.Context, task *proto.Task, param taskexecutor.Param) taskexecutor.TaskExecutor {
+			return changefeed.NewTaskExecutor(ctx, task, param, store)
+		},
+	)
 
 	concurrency := config.GetGlobalConfig().Performance.StatsLoadConcurrency
 	if concurrency == 0 {
@@ -3655,6 +3722,14 @@ func bootstrapSessionImpl(ctx context.Context, store kv.Storage, createSessionsI
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(func() (eventscheduler.Session, error) {
+		se, err := CreateSession(store)
+		if err != nil {
+			return nil, err
+		}
+		return se, nil
+	})
+	dom.StartIndexAdvisorScheduler(indexadvisor.RunAutoAdvisor)
 
 	dom.LoadSigningCertLoop(cfg.Security.SessionTokenSigningCert, cfg.Security.SessionTokenSigningKey)
 
@@ -3994,7 +4069,9 @@ func (s *session) PrepareTxnCtx(ctx context.Context) error {
 		}
 	}
 
-	if s.sessionVars.RetryInfo.Retrying {
+	// The reads and writes of the serializable snapshot isolation are only tracked in the pessimistic
+	// transactions, so the implicit transactions must be pessimistic too.
+	if s.sessionVars.RetryInfo.Retrying || s.sessionVars.SSIForNewTxn() {
 		txnMode = ast.Pessimistic
 	}
 
@@ -4632,6 +4709,15 @@ func (s *session) usePipelinedDmlOrWarn(ctx context.Context) bool {
 			)
 			return false
 		}
+		if len(tbl.Meta().Changefeeds) > 0 {
+			stmtCtx.AppendWarning(
+				errors.New(
+					"Pipelined DML can not be used on tables with changefeeds. " +
+						"Fallback to standard mode",
+				),
+			)
+			return false
+		}
 	}
 
 	// tidb_dml_type=bulk will invalidate the config pessimistic-auto-commit.
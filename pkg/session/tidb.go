@@ -389,4 +389,7 @@ func ResultSetToStringSlice(ctx context.Context, s types.Session, rs sqlexec.Rec
 // Session errors.
 var (
 	ErrForUpdateCantRetry = dbterror.ClassSession.NewStd(errno.ErrForUpdateCantRetry)
+	// ErrSSIOptimisticTxn is returned when a SERIALIZABLE transaction is started in the optimistic mode with
+	// tidb_enable_ssi, whose reads and writes aren't tracked.
+	ErrSSIOptimisticTxn = dbterror.ClassSession.NewStd(errno.ErrSSIOptimisticTxn)
 )
@@ -346,6 +346,10 @@ func (m *txnManager) newProviderWithRequest(r *sessiontxn.EnterNewTxnRequest) (s
 
 	switch txnMode {
 	case "", ast.Optimistic:
+		// The reads and writes of the optimistic transaction aren't tracked, it'd run in snapshot isolation silently.
+		if sessVars.SSIForNewTxn() {
+			return nil, ErrSSIOptimisticTxn.GenWithStackByArgs()
+		}
 		// When txnMode is 'OPTIMISTIC' or '', the transaction should be optimistic
 		provider := &m.reservedOptimisticProviders[0]
 		if old, ok := m.ctxProvider.(*isolation.OptimisticTxnContextProvider); ok && old == provider {
@@ -360,11 +364,11 @@ func (m *txnManager) newProviderWithRequest(r *sessiontxn.EnterNewTxnRequest) (s
 		case ast.ReadCommitted:
 			return isolation.NewPessimisticRCTxnContextProvider(m.sctx, r.CausalConsistencyOnly), nil
 		case ast.Serializable:
-			// The Oracle serializable isolation is actually SI in pessimistic mode.
+			// Without tidb_enable_ssi, the Oracle serializable isolation is actually SI in pessimistic mode.
 			// Do not update ForUpdateTS when the user is using the Serializable isolation level.
 			// It can be used temporarily on the few occasions when an Oracle-like isolation level is needed.
-			// Support for this does not mean that TiDB supports serializable isolation of MySQL.
-			// tidb_skip_isolation_level_check should still be disabled by default.
+			// With tidb_enable_ssi, the reads and writes are also tracked to abort the transactions that may
+			// break the serializability, but only against the transactions in the same TiDB instance.
 			return isolation.NewPessimisticSerializableTxnContextProvider(m.sctx, r.CausalConsistencyOnly), nil
 		default:
 			// We use Repeatable read for all other cases.
@@ -196,6 +196,13 @@ const (
 	// isolation level.
 	TiDBSkipIsolationLevelCheck = "tidb_skip_isolation_level_check"
 
+	// TiDBEnableSSI is used to control whether the SERIALIZABLE isolation level is supported by the
+	// serializable snapshot isolation for the pessimistic transactions. The read/write dependencies are
+	// tracked in the memory of each TiDB instance, so the conflicts are checked only between the transactions
+	// in the same TiDB instance, the transactions on different TiDB instances may still break the
+	// serializability. A warning is returned when it's turned on.
+	TiDBEnableSSI = "tidb_enable_ssi"
+
 	// TiDBLowResolutionTSO is used for reading data with low resolution TSO which is updated once every two seconds
 	TiDBLowResolutionTSO = "tidb_low_resolution_tso"
 
@@ -936,6 +943,9 @@ const (
 
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
+	// TiDBOptEnableMaterializedViewRewrite indicates whether the optimizer rewrites the aggregate queries
+	// to read the matching materialized views, the result may be stale since the last refresh of the view.
+	TiDBOptEnableMaterializedViewRewrite = "tidb_opt_enable_materialized_view_rewrite"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
 	TiDBLoadBasedReplicaReadThreshold = "tidb_load_based_replica_read_threshold"
 
@@ -1130,6 +1140,13 @@ const (
 	TiDBHistoricalStatsDuration = "tidb_historical_stats_duration"
 	// TiDBEnableHistoricalStatsForCapture indicates whether use historical stats in plan replayer capture
 	TiDBEnableHistoricalStatsForCapture = "tidb_enable_historical_stats_for_capture"
+	// TiDBEnableAutoIndexAdvisor indicates whether the index advisor runs periodically against the workload history.
+	TiDBEnableAutoIndexAdvisor = "tidb_enable_auto_index_advisor"
+	// TiDBAutoIndexAdvisorInterval indicates the interval between two runs of the automatic index advisor.
+	TiDBAutoIndexAdvisorInterval = "tidb_auto_index_advisor_interval"
+	// TiDBAutoIndexAdvisorVerify indicates whether the automatic index advisor creates the recommended indexes
+	// as invisible indexes to verify them before making them visible.
+	TiDBAutoIndexAdvisorVerify = "tidb_auto_index_advisor_verify"
 	// TiDBEnableResourceControl indicates whether resource control feature is enabled
 	TiDBEnableResourceControl = "tidb_enable_resource_control"
 	// TiDBResourceControlStrictMode indicates whether resource control strict mode is enabled.
@@ -1200,6 +1217,15 @@ const (
 	TiDBRuntimeFilterTypeName = "tidb_runtime_filter_type"
 	// TiDBRuntimeFilterModeName the mode of runtime filter, such as "OFF", "LOCAL"
 	TiDBRuntimeFilterModeName = "tidb_runtime_filter_mode"
+	// TiDBEnableAdaptiveExecution indicates whether the executors compare the observed row counts with the estimated
+	// ones at the checkpoints, and adjust the rest of the plan when the estimation is far from the real one.
+	TiDBEnableAdaptiveExecution = "tidb_enable_adaptive_execution"
+	// TiDBAdaptiveExecutionMisestimateRatio is the ratio between the observed row count and the estimated one, beyond
+	// which the estimation is regarded as a misestimation by the adaptive execution.
+	TiDBAdaptiveExecutionMisestimateRatio = "tidb_adaptive_execution_misestimate_ratio"
+	// TiDBEnableCardinalityFeedback indicates whether the estimated and actual rows of the filters on the tables are
+	// captured from EXPLAIN ANALYZE and slow queries, and the learned corrections are applied to the estimation.
+	TiDBEnableCardinalityFeedback = "tidb_enable_cardinality_feedback"
 	// TiDBSkipMissingPartitionStats controls how to handle missing partition stats when merging partition stats to global stats.
 	// When set to true, skip missing partition stats and continue to merge other partition stats to global stats.
 	// When set to false, give up merging partition stats to global stats.
@@ -1340,6 +1366,7 @@ const (
 	DefShardRowIDBits                       = 0
 	DefPreSplitRegions                      = 0
 	DefBlockEncryptionMode                  = "aes-128-ecb"
+	DefEventScheduler                       = false
 	DefTiDBAllowMPPExecution                = true
 	DefTiDBAllowTiFlashCop                  = false
 	DefTiDBHashExchangeWithNewCollation     = true
@@ -1378,6 +1405,7 @@ const (
 	DefTiDBDDLSlowOprThreshold              = 300
 	DefTiDBUseFastAnalyze                   = false
 	DefTiDBSkipIsolationLevelCheck          = false
+	DefTiDBEnableSSI                        = false
 	DefTiDBExpensiveQueryTimeThreshold      = 60      // 60s
 	DefTiDBExpensiveTxnTimeThreshold        = 60 * 10 // 10 minutes
 	DefTiDBScatterRegion                    = ScatterOff
@@ -1554,6 +1582,9 @@ const (
 	DefTiDBStoreBatchSize                             = 4
 	DefTiDBHistoricalStatsDuration                    = 7 * 24 * time.Hour
 	DefTiDBEnableHistoricalStatsForCapture            = false
+	DefTiDBEnableAutoIndexAdvisor                     = false
+	DefTiDBAutoIndexAdvisorInterval                   = 24 * time.Hour
+	DefTiDBAutoIndexAdvisorVerify                     = false
 	DefTiDBTTLJobScheduleWindowStartTime              = "00:00 +0000"
 	DefTiDBTTLJobScheduleWindowEndTime                = "23:59 +0000"
 	DefTiDBTTLScanWorkerCount                         = 4
@@ -1568,6 +1599,7 @@ const (
 	DefTiDBEnablePlanCacheForSubquery                 = true
 	DefTiDBLoadBasedReplicaReadThreshold              = time.Second
 	DefTiDBOptEnableLateMaterialization               = true
+	DefTiDBOptEnableMaterializedViewRewrite           = false
 	DefTiDBOptOrderingIdxSelThresh                    = 0.0
 	DefTiDBOptOrderingIdxSelRatio                     = -1
 	DefTiDBOptEnableMPPSharedCTEExecution             = false
@@ -1589,6 +1621,9 @@ const (
 	DefTiDBEnableFastCheckTable                       = true
 	DefRuntimeFilterType                              = "IN"
 	DefRuntimeFilterMode                              = "OFF"
+	DefTiDBEnableAdaptiveExecution                    = false
+	DefTiDBAdaptiveExecutionMisestimateRatio          = 10.0
+	DefTiDBEnableCardinalityFeedback                  = false
 	DefTiDBLockUnchangedKeys                          = true
 	DefTiDBEnableCheckConstraint                      = false
 	DefTiDBSkipMissingPartitionStats                  = true
@@ -1697,6 +1732,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(DefEventScheduler)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
@@ -1721,6 +1757,9 @@ var (
 	MaxPreparedStmtCountValue       = atomic.NewInt64(DefMaxPreparedStmtCount)
 	HistoricalStatsDuration         = atomic.NewDuration(DefTiDBHistoricalStatsDuration)
 	EnableHistoricalStatsForCapture = atomic.NewBool(DefTiDBEnableHistoricalStatsForCapture)
+	EnableAutoIndexAdvisor          = atomic.NewBool(DefTiDBEnableAutoIndexAdvisor)
+	AutoIndexAdvisorInterval        = atomic.NewDuration(DefTiDBAutoIndexAdvisorInterval)
+	AutoIndexAdvisorVerify          = atomic.NewBool(DefTiDBAutoIndexAdvisorVerify)
 	TTLRunningTasks                 = atomic.NewInt32(DefTiDBTTLRunningTasks)
 	// always set the default value to false because the resource control in kv-client is not inited
 	// It will be initialized to the right value after the first call of `rebuildSysVarCache`
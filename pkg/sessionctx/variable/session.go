@@ -49,6 +49,7 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx/sessionstates"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/vardef"
+	"github.com/pingcap/tidb/pkg/sessiontxn/ssi"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/dbterror/plannererrors"
@@ -257,6 +258,13 @@ type TxnCtxNoNeedToRestore struct {
 	// Read results cannot be directly written into pessimisticLockCache because failed statement need to rollback
 	// its pessimistic locks.
 	CurrentStmtPessimisticLockCache map[string][]byte
+
+	// MViewLogSeq is the sequence number of the last change logged for the materialized views in the transaction.
+	MViewLogSeq int64
+
+	// SSI tracks the reads and writes of the transaction for the serializable snapshot isolation.
+	// It's nil if the transaction isn't serializable.
+	SSI *ssi.Txn
 }
 
 // SavepointRecord indicates a transaction's savepoint record.
@@ -1326,6 +1334,10 @@ type SessionVars struct {
 	// Enable1PC indicates whether to enable the one-phase commit feature.
 	Enable1PC bool
 
+	// EnableSSI indicates whether the SERIALIZABLE isolation level is supported by the serializable
+	// snapshot isolation.
+	EnableSSI bool
+
 	// GuaranteeLinearizability indicates whether to guarantee linearizability
 	GuaranteeLinearizability bool
 
@@ -1593,6 +1605,9 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMaterializedViewRewrite indicates whether to rewrite the aggregate queries to read the materialized views.
+	EnableMaterializedViewRewrite bool
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -1645,6 +1660,17 @@ type SessionVars struct {
 	// Runtime filter mode: only support OFF, LOCAL now
 	runtimeFilterMode RuntimeFilterMode
 
+	// EnableAdaptiveExecution indicates whether the executors adjust the rest of the plan at the checkpoints when the
+	// observed row counts are far from the estimated ones.
+	EnableAdaptiveExecution bool
+	// AdaptiveExecutionMisestimateRatio is the ratio between the observed row count and the estimated one, beyond
+	// which the estimation is regarded as a misestimation.
+	AdaptiveExecutionMisestimateRatio float64
+
+	// EnableCardinalityFeedback indicates whether the cardinality feedback is captured from the executed statements
+	// and applied to the estimation.
+	EnableCardinalityFeedback bool
+
 	// Whether to lock duplicate keys in INSERT IGNORE and REPLACE statements,
 	// or unchanged unique keys in UPDATE statements, see PR #42210 and #42713
 	LockUnchangedKeys bool
@@ -2178,6 +2204,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		EnabledRateLimitAction:        vardef.DefTiDBEnableRateLimitAction,
 		EnableAsyncCommit:             vardef.DefTiDBEnableAsyncCommit,
 		Enable1PC:                     vardef.DefTiDBEnable1PC,
+		EnableSSI:                     vardef.DefTiDBEnableSSI,
 		GuaranteeLinearizability:      vardef.DefTiDBGuaranteeLinearizability,
 		AnalyzeVersion:                vardef.DefTiDBAnalyzeVersion,
 		EnableIndexMergeJoin:          vardef.DefTiDBEnableIndexMergeJoin,
@@ -2240,6 +2267,7 @@ func NewSessionVars(hctx HookContext) *SessionVars {
 		MaxPagingSize:      vardef.DefMaxPagingSize,
 	}
 	vars.DMLBatchSize = vardef.DefDMLBatchSize
+	vars.AdaptiveExecutionMisestimateRatio = vardef.DefTiDBAdaptiveExecutionMisestimateRatio
 	vars.AllowBatchCop = vardef.DefTiDBAllowBatchCop
 	vars.allowMPPExecution = vardef.DefTiDBAllowMPPExecution
 	vars.HashExchangeWithNewCollation = vardef.DefTiDBHashExchangeWithNewCollation
@@ -2523,6 +2551,11 @@ func (s *SessionVars) IsolationLevelForNewTxn() (isolation string) {
 	return
 }
 
+// SSIForNewTxn returns whether the new transaction uses the serializable snapshot isolation.
+func (s *SessionVars) SSIForNewTxn() bool {
+	return s.EnableSSI && s.IsolationLevelForNewTxn() == ast.Serializable
+}
+
 // SetTxnIsolationLevelOneShotStateForNextTxn sets the txnIsolationLevelOneShot.state for next transaction.
 func (s *SessionVars) SetTxnIsolationLevelOneShotStateForNextTxn() {
 	if isoLevelOneShot := &s.txnIsolationLevelOneShot; isoLevelOneShot.state != oneShotDef {
@@ -3866,10 +3899,11 @@ type RuntimeFilterType int64
 
 // In type of runtime filter, like "t.k1 in (?)"
 // MinMax type of runtime filter, like "t.k1 < ? and t.k1 > ?"
+// BloomFilter type of runtime filter, which tests the hash of "t.k1" against a bloom filter
 const (
 	In RuntimeFilterType = iota
 	MinMax
-	// todo BloomFilter, bf/in
+	BloomFilter
 )
 
 // String convert Runtime Filter Type to String name
@@ -3879,6 +3913,8 @@ func (rfType RuntimeFilterType) String() string {
 		return "IN"
 	case MinMax:
 		return "MIN_MAX"
+	case BloomFilter:
+		return "BLOOM_FILTER"
 	default:
 		return ""
 	}
@@ -3888,13 +3924,15 @@ func (rfType RuntimeFilterType) String() string {
 // If name is legal, it will return Runtime Filter Type and true
 // Else, it will return -1 and false
 // The second param means the convert is ok or not. True is ok, false means it is illegal name
-// At present, we only support two names: "IN" and "MIN_MAX"
+// At present, we support three names: "IN", "MIN_MAX" and "BLOOM_FILTER"
 func RuntimeFilterTypeStringToType(name string) (RuntimeFilterType, bool) {
 	switch name {
 	case "IN":
 		return In, true
 	case "MIN_MAX":
 		return MinMax, true
+	case "BLOOM_FILTER":
+		return BloomFilter, true
 	default:
 		return -1, false
 	}
@@ -1580,6 +1580,31 @@ var defaultSysVars = []*SysVar{
 			vardef.HistoricalStatsDuration.Store(d)
 			return nil
 		}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBEnableAutoIndexAdvisor, Value: BoolToOnOff(vardef.DefTiDBEnableAutoIndexAdvisor), Type: vardef.TypeBool,
+		SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			vardef.EnableAutoIndexAdvisor.Store(TiDBOptOn(s))
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BoolToOnOff(vardef.EnableAutoIndexAdvisor.Load()), nil
+		}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBAutoIndexAdvisorInterval, Value: vardef.DefTiDBAutoIndexAdvisorInterval.String(), Type: vardef.TypeDuration, MinValue: int64(time.Minute), MaxValue: uint64(time.Hour * 24 * 365),
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return vardef.AutoIndexAdvisorInterval.Load().String(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			d, err := time.ParseDuration(s)
+			if err != nil {
+				return err
+			}
+			vardef.AutoIndexAdvisorInterval.Store(d)
+			return nil
+		}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBAutoIndexAdvisorVerify, Value: BoolToOnOff(vardef.DefTiDBAutoIndexAdvisorVerify), Type: vardef.TypeBool,
+		SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			vardef.AutoIndexAdvisorVerify.Store(TiDBOptOn(s))
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BoolToOnOff(vardef.AutoIndexAdvisorVerify.Load()), nil
+		}},
 	{Scope: vardef.ScopeGlobal, Name: vardef.TiDBLowResolutionTSOUpdateInterval, Value: strconv.Itoa(vardef.DefTiDBLowResolutionTSOUpdateInterval), Type: vardef.TypeInt, MinValue: 10, MaxValue: 60000,
 		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 			vardef.LowResolutionTSOUpdateInterval.Store(uint32(TidbOptInt64(val, vardef.DefTiDBLowResolutionTSOUpdateInterval)))
@@ -1938,6 +1963,12 @@ var defaultSysVars = []*SysVar{
 			return nil
 		}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.BlockEncryptionMode, Value: vardef.DefBlockEncryptionMode, Type: vardef.TypeEnum, PossibleValues: []string{"aes-128-ecb", "aes-192-ecb", "aes-256-ecb", "aes-128-cbc", "aes-192-cbc", "aes-256-cbc", "aes-128-ofb", "aes-192-ofb", "aes-256-ofb", "aes-128-cfb", "aes-192-cfb", "aes-256-cfb"}},
+	{Scope: vardef.ScopeGlobal, Name: vardef.EventScheduler, Value: BoolToOnOff(vardef.DefEventScheduler), Type: vardef.TypeBool, SetGlobal: func(ctx context.Context, vars *SessionVars, s string) error {
+		vardef.EnableEventScheduler.Store(TiDBOptOn(s))
+		return nil
+	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
+		return BoolToOnOff(vardef.EnableEventScheduler.Load()), nil
+	}},
 	/* TiDB specific variables */
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBAllowMPPExecution, Type: vardef.TypeBool, Value: BoolToOnOff(vardef.DefTiDBAllowMPPExecution), Depended: true, SetSession: func(s *SessionVars, val string) error {
 		s.allowMPPExecution = TiDBOptOn(val)
@@ -2281,6 +2312,16 @@ var defaultSysVars = []*SysVar{
 			return nil
 		}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBSkipIsolationLevelCheck, Value: BoolToOnOff(vardef.DefTiDBSkipIsolationLevelCheck), Type: vardef.TypeBool},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableSSI, Value: BoolToOnOff(vardef.DefTiDBEnableSSI), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableSSI = TiDBOptOn(val)
+		return nil
+	}, Validation: func(vars *SessionVars, normalizedValue string, _ string, _ vardef.ScopeFlag) (string, error) {
+		// The read/write dependencies are only tracked in the memory of each TiDB instance.
+		if TiDBOptOn(normalizedValue) {
+			vars.StmtCtx.AppendWarning(ErrWarnSSILocalInstance.FastGenByArgs())
+		}
+		return normalizedValue, nil
+	}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableRateLimitAction, Value: BoolToOnOff(vardef.DefTiDBEnableRateLimitAction), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnabledRateLimitAction = TiDBOptOn(val)
 		return nil
@@ -3010,6 +3051,10 @@ var defaultSysVars = []*SysVar{
 		s.EnableLateMaterialization = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBOptEnableMaterializedViewRewrite, Value: BoolToOnOff(vardef.DefTiDBOptEnableMaterializedViewRewrite), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableMaterializedViewRewrite = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBLoadBasedReplicaReadThreshold, Value: vardef.DefTiDBLoadBasedReplicaReadThreshold.String(), Type: vardef.TypeDuration, MaxValue: uint64(time.Hour), SetSession: func(s *SessionVars, val string) error {
 		d, err := time.ParseDuration(val)
 		if err != nil {
@@ -3275,7 +3320,7 @@ var defaultSysVars = []*SysVar{
 			if ok {
 				return normalizedValue, nil
 			}
-			errMsg := fmt.Sprintf("incorrect value: %s. %s should be sepreated by , such as %s, also we only support IN and MIN_MAX now. ",
+			errMsg := fmt.Sprintf("incorrect value: %s. %s should be sepreated by , such as %s, also we only support IN, MIN_MAX and BLOOM_FILTER now. ",
 				originalValue, vardef.TiDBRuntimeFilterTypeName, vardef.DefRuntimeFilterType)
 			return normalizedValue, errors.New(errMsg)
 		},
@@ -3299,6 +3344,18 @@ var defaultSysVars = []*SysVar{
 			return nil
 		},
 	},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableAdaptiveExecution, Value: BoolToOnOff(vardef.DefTiDBEnableAdaptiveExecution), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableAdaptiveExecution = TiDBOptOn(val)
+		return nil
+	}},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBAdaptiveExecutionMisestimateRatio, Value: strconv.FormatFloat(vardef.DefTiDBAdaptiveExecutionMisestimateRatio, 'f', -1, 64), Type: vardef.TypeFloat, MinValue: 1, MaxValue: math.MaxUint64, SetSession: func(s *SessionVars, val string) error {
+		s.AdaptiveExecutionMisestimateRatio = tidbOptFloat64(val, vardef.DefTiDBAdaptiveExecutionMisestimateRatio)
+		return nil
+	}},
+	{Scope: vardef.ScopeGlobal | vardef.ScopeSession, Name: vardef.TiDBEnableCardinalityFeedback, Value: BoolToOnOff(vardef.DefTiDBEnableCardinalityFeedback), Type: vardef.TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableCardinalityFeedback = TiDBOptOn(val)
+		return nil
+	}},
 	{
 		Scope: vardef.ScopeGlobal | vardef.ScopeSession,
 		Name:  vardef.TiDBLockUnchangedKeys,
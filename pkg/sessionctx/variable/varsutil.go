@@ -114,6 +114,10 @@ func checkReadOnly(vars *SessionVars, normalizedValue string, originalValue stri
 }
 
 func checkIsolationLevel(vars *SessionVars, normalizedValue string, originalValue string, scope vardef.ScopeFlag) (string, error) {
+	// The optimistic transactions are still rejected when they start, since the txn mode may be changed after it.
+	if normalizedValue == "SERIALIZABLE" && TiDBOptOn(vars.systems[vardef.TiDBEnableSSI]) {
+		return normalizedValue, nil
+	}
 	if normalizedValue == "SERIALIZABLE" || normalizedValue == "READ-UNCOMMITTED" {
 		returnErr := ErrUnsupportedIsolationLevel.FastGenByArgs(normalizedValue)
 		if !TiDBOptOn(vars.systems[vardef.TiDBSkipIsolationLevelCheck]) {
@@ -22,9 +22,13 @@ import (
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/sessiontxn/ssi"
+	"github.com/pingcap/tidb/pkg/tablecodec"
 )
 
-// PessimisticSerializableTxnContextProvider provides txn context for isolation level oracle-like serializable
+// PessimisticSerializableTxnContextProvider provides txn context for isolation level oracle-like serializable.
+// When `tidb_enable_ssi` is on, the reads and writes of the transaction are tracked for the serializable
+// snapshot isolation, which aborts the transaction at commit if it may break the serializability.
 type PessimisticSerializableTxnContextProvider struct {
 	baseTxnContextProvider
 }
@@ -39,9 +43,15 @@ func NewPessimisticSerializableTxnContextProvider(sctx sessionctx.Context,
 			onInitializeTxnCtx: func(txnCtx *variable.TransactionContext) {
 				txnCtx.IsPessimistic = true
 				txnCtx.Isolation = ast.Serializable
+				if sctx.GetSessionVars().EnableSSI {
+					txnCtx.SSI = ssi.NewTxn(tableRangeOfKey)
+				}
 			},
 			onTxnActiveFunc: func(txn kv.Transaction, _ sessiontxn.EnterNewTxnType) {
 				txn.SetOption(kv.Pessimistic, true)
+				if t := sctx.GetSessionVars().TxnCtx.SSI; t != nil {
+					t.Start(txn.StartTS())
+				}
 			},
 		},
 	}
@@ -51,6 +61,34 @@ func NewPessimisticSerializableTxnContextProvider(sctx sessionctx.Context,
 	return provider
 }
 
+// GetSnapshotWithStmtReadTS gets snapshot with read ts
+func (p *PessimisticSerializableTxnContextProvider) GetSnapshotWithStmtReadTS() (kv.Snapshot, error) {
+	snapshot, err := p.baseTxnContextProvider.GetSnapshotWithStmtReadTS()
+	return p.trackSnapshot(snapshot), err
+}
+
+// GetSnapshotWithStmtForUpdateTS gets snapshot with for update ts
+func (p *PessimisticSerializableTxnContextProvider) GetSnapshotWithStmtForUpdateTS() (kv.Snapshot, error) {
+	snapshot, err := p.baseTxnContextProvider.GetSnapshotWithStmtForUpdateTS()
+	return p.trackSnapshot(snapshot), err
+}
+
+func (p *PessimisticSerializableTxnContextProvider) trackSnapshot(snapshot kv.Snapshot) kv.Snapshot {
+	if t := p.sctx.GetSessionVars().TxnCtx.SSI; t != nil && snapshot != nil {
+		return ssi.WrapSnapshot(snapshot, t)
+	}
+	return snapshot
+}
+
+// tableRangeOfKey returns the key range of the table the key belongs to.
+func tableRangeOfKey(key kv.Key) (kv.KeyRange, bool) {
+	tableID := tablecodec.DecodeTableID(key)
+	if tableID == 0 {
+		return kv.KeyRange{}, false
+	}
+	return kv.KeyRange{StartKey: tablecodec.EncodeTablePrefix(tableID), EndKey: tablecodec.EncodeTablePrefix(tableID + 1)}, true
+}
+
 // OnStmtErrorForNextAction is the hook that should be called when a new statement get an error
 func (p *PessimisticSerializableTxnContextProvider) OnStmtErrorForNextAction(ctx context.Context, point sessiontxn.StmtErrorHandlePoint, err error) (sessiontxn.StmtErrorAction, error) {
 	switch point {
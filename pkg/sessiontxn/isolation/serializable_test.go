@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	"github.com/pingcap/tidb/pkg/config"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/executor"
 	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -293,3 +294,85 @@ func initializePessimisticSerializableProvider(t *testing.T,
 	tk.MustExec("begin pessimistic")
 	return assert.CheckAndGetProvider(t)
 }
+
+func TestSerializableSnapshotIsolation(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table doctors(id int primary key, on_call int)")
+	tk.MustExec("insert into doctors values (1, 1), (2, 1)")
+
+	// SERIALIZABLE is rejected without tidb_enable_ssi.
+	tk.MustGetErrCode("set transaction_isolation = 'SERIALIZABLE'", 8048)
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	for _, tk := range []*testkit.TestKit{tk1, tk2} {
+		tk.MustExec("use test")
+		tk.MustExec("set tidb_enable_ssi = 1")
+		tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8279 tidb_enable_ssi only detects the read/write dependencies " +
+			"among the SERIALIZABLE transactions on the same TiDB instance, the transactions on different TiDB instances are not checked"))
+		tk.MustExec("set transaction_isolation = 'SERIALIZABLE'")
+		tk.MustQuery("select @@transaction_isolation").Check(testkit.Rows("SERIALIZABLE"))
+	}
+
+	// write skew: both doctors go off call if the other one is on call.
+	tk1.MustExec("begin")
+	tk2.MustExec("begin")
+	tk1.MustQuery("select count(*) from doctors where on_call = 1").Check(testkit.Rows("2"))
+	tk2.MustQuery("select count(*) from doctors where on_call = 1").Check(testkit.Rows("2"))
+	tk1.MustExec("update doctors set on_call = 0 where id = 1")
+	tk2.MustExec("update doctors set on_call = 0 where id = 2")
+	tk1.MustExec("commit")
+	err := tk2.ExecToErr("commit")
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+	require.True(t, kv.IsTxnRetryableError(err))
+	tk.MustQuery("select * from doctors order by id").Check(testkit.Rows("1 0", "2 1"))
+
+	// the retried transaction sees the change.
+	tk2.MustExec("begin")
+	tk2.MustQuery("select count(*) from doctors where on_call = 1").Check(testkit.Rows("1"))
+	tk2.MustExec("rollback")
+
+	// point reads of different rows don't conflict.
+	tk.MustExec("update doctors set on_call = 1")
+	tk1.MustExec("begin")
+	tk2.MustExec("begin")
+	tk1.MustQuery("select on_call from doctors where id = 1").Check(testkit.Rows("1"))
+	tk2.MustQuery("select on_call from doctors where id = 2").Check(testkit.Rows("1"))
+	tk1.MustExec("update doctors set on_call = 0 where id = 1")
+	tk2.MustExec("update doctors set on_call = 0 where id = 2")
+	tk1.MustExec("commit")
+	tk2.MustExec("commit")
+
+	// point reads are tracked as well.
+	tk.MustExec("update doctors set on_call = 1")
+	tk1.MustExec("begin")
+	tk2.MustExec("begin")
+	tk1.MustQuery("select on_call from doctors where id = 2").Check(testkit.Rows("1"))
+	tk2.MustQuery("select on_call from doctors where id = 1").Check(testkit.Rows("1"))
+	tk1.MustExec("update doctors set on_call = 0 where id = 1")
+	tk2.MustExec("update doctors set on_call = 0 where id = 2")
+	tk1.MustExec("commit")
+	err = tk2.ExecToErr("commit")
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+
+	// the auto-committed statements are tracked as well.
+	tk.MustExec("update doctors set on_call = 1")
+	tk1.MustExec("begin")
+	tk1.MustQuery("select count(*) from doctors where on_call = 1").Check(testkit.Rows("2"))
+	tk2.MustExec("update doctors set on_call = 0 where id = 2 and (select on_call from doctors where id = 1) = 1")
+	tk1.MustExec("update doctors set on_call = 0 where id = 1")
+	err = tk1.ExecToErr("commit")
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+
+	// the explicit optimistic transactions are rejected since their reads and writes aren't tracked,
+	// and the implicit ones are pessimistic.
+	tk1.MustGetErrCode("begin optimistic", errno.ErrSSIOptimisticTxn)
+	tk1.MustExec("set tidb_txn_mode = 'optimistic'")
+	tk1.MustGetErrCode("begin", errno.ErrSSIOptimisticTxn)
+	tk1.MustQuery("select * from doctors order by id").Check(testkit.Rows("1 1", "2 0"))
+	require.True(t, tk1.Session().GetSessionVars().TxnCtx.IsPessimistic)
+	tk1.MustExec("set tidb_txn_mode = 'pessimistic'")
+}
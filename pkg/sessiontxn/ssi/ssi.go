@@ -0,0 +1,359 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package ssi implements the serializable snapshot isolation (SSI) on top of
+// the snapshot isolation of the transactions.
+//
+// A serializable transaction records the key ranges it reads at its snapshot.
+// When it commits, its writes are checked against the reads of the concurrent
+// serializable transactions, and its reads against the writes of the committed
+// concurrent ones, to find the rw-antidependencies between them: T1 -rw-> T2
+// means T1 doesn't see a write of T2. Every non-serializable execution of the
+// snapshot isolation has a cycle with two consecutive rw-antidependencies
+// T1 -rw-> T2 -rw-> T3 between concurrent transactions, where T3 commits first.
+// So a transaction is aborted with ErrSerializationFailure when it commits if
+//   - it has both an incoming and an outgoing rw-antidependency, or
+//   - it has an outgoing rw-antidependency to a committing transaction that has
+//     an outgoing rw-antidependency.
+//
+// The check is conservative, a transaction may be aborted even if there is no
+// cycle. The transactions are tracked in a registry local to the TiDB instance,
+// so only the transactions in the same instance are checked against each other,
+// a cycle of the transactions across the TiDB instances is not detected.
+package ssi
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/kv"
+)
+
+// maxTrackedRanges is the max number of the read ranges tracked for a transaction.
+// When it's exceeded, the read ranges are widened to the whole tables.
+var maxTrackedRanges = 4096
+
+var globalRegistry = NewRegistry()
+
+// Registry tracks the serializable transactions.
+type Registry struct {
+	mu   sync.Mutex
+	txns map[*Txn]struct{}
+	// lostBeforeTS is the max commit ts of the transactions removed from the
+	// registry. A transaction starting before it may miss the conflicts with them.
+	lostBeforeTS uint64
+}
+
+// NewRegistry creates a Registry.
+func NewRegistry() *Registry {
+	return &Registry{txns: make(map[*Txn]struct{})}
+}
+
+// TableRangeFunc returns the key range of the table the key belongs to, ok is false if the
+// key doesn't belong to a table. The key format is owned by tablecodec, which can't be imported
+// here because its tests depend on this package through the statement context.
+type TableRangeFunc func(key kv.Key) (r kv.KeyRange, ok bool)
+
+// Txn is a serializable transaction.
+type Txn struct {
+	reg        *Registry
+	tableRange TableRangeFunc
+	startTS    uint64
+
+	readMu struct {
+		sync.Mutex
+		ranges []kv.KeyRange
+		sorted bool
+	}
+
+	// The fields below are protected by reg.mu.
+	writes    []kv.Key
+	prepared  bool
+	committed bool
+	commitTS  uint64
+	// inConflict means some concurrent transaction doesn't see a write of it.
+	inConflict bool
+	// outConflict means it doesn't see a write of some concurrent transaction.
+	outConflict bool
+	// lost means it may miss the conflicts with the transactions removed from the registry.
+	lost bool
+}
+
+// NewTxn creates a serializable transaction tracked by the global registry. The tableRange
+// is used to widen the read ranges to the whole tables when there are too many of them.
+// It isn't tracked until Start is called.
+func NewTxn(tableRange TableRangeFunc) *Txn {
+	return NewTxnWithRegistry(globalRegistry, tableRange)
+}
+
+// NewTxnWithRegistry creates a serializable transaction tracked by the registry.
+func NewTxnWithRegistry(reg *Registry, tableRange TableRangeFunc) *Txn {
+	return &Txn{reg: reg, tableRange: tableRange}
+}
+
+// Start starts tracking the transaction with the start ts.
+func (t *Txn) Start(startTS uint64) {
+	r := t.reg
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t.startTS = startTS
+	t.lost = startTS < r.lostBeforeTS
+	r.txns[t] = struct{}{}
+}
+
+// StartTS returns the start ts of the transaction, or 0 if it's not started.
+func (t *Txn) StartTS() uint64 {
+	return t.startTS
+}
+
+// TrackRanges records the key ranges read by the transaction.
+func (t *Txn) TrackRanges(ranges []kv.KeyRange) {
+	t.readMu.Lock()
+	defer t.readMu.Unlock()
+	for _, r := range ranges {
+		t.readMu.ranges = append(t.readMu.ranges, kv.KeyRange{
+			StartKey: slices.Clone(r.StartKey),
+			EndKey:   slices.Clone(r.EndKey),
+		})
+	}
+	t.readMu.sorted = false
+	if len(t.readMu.ranges) > maxTrackedRanges {
+		t.widenReads()
+	}
+}
+
+// TrackKeys records the keys read by the transaction.
+func (t *Txn) TrackKeys(keys []kv.Key) {
+	ranges := make([]kv.KeyRange, 0, len(keys))
+	for _, k := range keys {
+		ranges = append(ranges, kv.KeyRange{StartKey: k, EndKey: k.Next()})
+	}
+	t.TrackRanges(ranges)
+}
+
+// widenReads replaces the read ranges by the ranges of the tables they belong to.
+func (t *Txn) widenReads() {
+	for i, r := range t.readMu.ranges {
+		tableRange, ok := t.tableRange(r.StartKey)
+		if !ok {
+			continue
+		}
+		if len(r.EndKey) > 0 && bytes.Compare(r.EndKey, tableRange.EndKey) <= 0 {
+			t.readMu.ranges[i] = tableRange
+		}
+	}
+	t.sortReads()
+}
+
+// sortReads sorts and merges the read ranges.
+func (t *Txn) sortReads() {
+	if t.readMu.sorted {
+		return
+	}
+	ranges := t.readMu.ranges
+	slices.SortFunc(ranges, func(a, b kv.KeyRange) int {
+		return bytes.Compare(a.StartKey, b.StartKey)
+	})
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if len(last.EndKey) == 0 {
+				continue
+			}
+			if bytes.Compare(r.StartKey, last.EndKey) <= 0 {
+				if len(r.EndKey) == 0 || bytes.Compare(r.EndKey, last.EndKey) > 0 {
+					last.EndKey = r.EndKey
+				}
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	t.readMu.ranges = merged
+	t.readMu.sorted = true
+}
+
+// readsOverlap checks whether the transaction reads any of the sorted keys.
+func (t *Txn) readsOverlap(keys []kv.Key) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	t.readMu.Lock()
+	defer t.readMu.Unlock()
+	t.sortReads()
+	for _, r := range t.readMu.ranges {
+		i, _ := slices.BinarySearchFunc(keys, kv.Key(r.StartKey), func(k, target kv.Key) int {
+			return bytes.Compare(k, target)
+		})
+		if i < len(keys) && (len(r.EndKey) == 0 || bytes.Compare(keys[i], r.EndKey) < 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// concurrentWith checks whether the transaction commits after u starts.
+// The caller must hold reg.mu.
+func (t *Txn) concurrentWith(u *Txn) bool {
+	return !t.committed || t.commitTS > u.startTS
+}
+
+// Prepare checks the conflicts of the transaction with the writes before it
+// commits. The transaction must be rolled back if an error is returned.
+func (t *Txn) Prepare(writes []kv.Key) error {
+	r := t.reg
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.txns[t]; !ok {
+		return nil
+	}
+	slices.SortFunc(writes, func(a, b kv.Key) int {
+		return bytes.Compare(a, b)
+	})
+
+	in, out := t.inConflict, t.outConflict
+	var readers, writers []*Txn
+	for u := range r.txns {
+		if u == t || !u.concurrentWith(t) {
+			continue
+		}
+		if u.readsOverlap(writes) {
+			readers = append(readers, u)
+			in = true
+		}
+		if u.prepared && t.readsOverlap(u.writes) {
+			if u.outConflict {
+				return kv.ErrSerializationFailure.FastGenByArgs(t.startTS)
+			}
+			writers = append(writers, u)
+			out = true
+		}
+	}
+	if (in && out) || t.lost {
+		return kv.ErrSerializationFailure.FastGenByArgs(t.startTS)
+	}
+
+	for _, u := range readers {
+		u.outConflict = true
+	}
+	for _, u := range writers {
+		u.inConflict = true
+	}
+	t.inConflict, t.outConflict = in, out
+	t.writes = writes
+	t.prepared = true
+	return nil
+}
+
+// Commit marks the transaction committed with the commit ts.
+func (t *Txn) Commit(commitTS uint64) {
+	r := t.reg
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.txns[t]; !ok {
+		return
+	}
+	t.prepared = true
+	t.committed = true
+	t.commitTS = commitTS
+	r.gc()
+}
+
+// Rollback stops tracking the transaction.
+func (t *Txn) Rollback() {
+	r := t.reg
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.txns, t)
+	r.gc()
+}
+
+// gc removes the committed transactions that aren't concurrent with any
+// uncommitted transaction. The caller must hold r.mu.
+func (r *Registry) gc() {
+	minStartTS := uint64(0)
+	for u := range r.txns {
+		if !u.committed && (minStartTS == 0 || u.startTS < minStartTS) {
+			minStartTS = u.startTS
+		}
+	}
+	for u := range r.txns {
+		if u.committed && (minStartTS == 0 || u.commitTS < minStartTS) {
+			delete(r.txns, u)
+			r.lostBeforeTS = max(r.lostBeforeTS, u.commitTS)
+		}
+	}
+}
+
+// Len returns the number of the transactions in the registry.
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.txns)
+}
+
+// WriteKeys returns the keys written by the transaction.
+func WriteKeys(txn kv.Transaction) ([]kv.Key, error) {
+	iter, err := txn.GetMemBuffer().Iter(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var keys []kv.Key
+	for iter.Valid() {
+		keys = append(keys, iter.Key().Clone())
+		if err = iter.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// trackedSnapshot records the keys read from the snapshot.
+type trackedSnapshot struct {
+	kv.Snapshot
+	txn *Txn
+}
+
+// WrapSnapshot returns a snapshot recording the keys read by the transaction.
+func WrapSnapshot(snapshot kv.Snapshot, txn *Txn) kv.Snapshot {
+	return &trackedSnapshot{Snapshot: snapshot, txn: txn}
+}
+
+// Get implements the kv.Snapshot interface.
+func (s *trackedSnapshot) Get(ctx context.Context, k kv.Key) ([]byte, error) {
+	s.txn.TrackKeys([]kv.Key{k})
+	return s.Snapshot.Get(ctx, k)
+}
+
+// BatchGet implements the kv.Snapshot interface.
+func (s *trackedSnapshot) BatchGet(ctx context.Context, keys []kv.Key) (map[string][]byte, error) {
+	s.txn.TrackKeys(keys)
+	return s.Snapshot.BatchGet(ctx, keys)
+}
+
+// Iter implements the kv.Snapshot interface.
+func (s *trackedSnapshot) Iter(k kv.Key, upperBound kv.Key) (kv.Iterator, error) {
+	s.txn.TrackRanges([]kv.KeyRange{{StartKey: k, EndKey: upperBound}})
+	return s.Snapshot.Iter(k, upperBound)
+}
+
+// IterReverse implements the kv.Snapshot interface.
+func (s *trackedSnapshot) IterReverse(k kv.Key, lowerBound kv.Key) (kv.Iterator, error) {
+	s.txn.TrackRanges([]kv.KeyRange{{StartKey: lowerBound, EndKey: k}})
+	return s.Snapshot.IterReverse(k, lowerBound)
+}
@@ -0,0 +1,166 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssi
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/stretchr/testify/require"
+)
+
+func tableRange(key kv.Key) (kv.KeyRange, bool) {
+	tableID := tablecodec.DecodeTableID(key)
+	if tableID == 0 {
+		return kv.KeyRange{}, false
+	}
+	return kv.KeyRange{StartKey: tablecodec.EncodeTablePrefix(tableID), EndKey: tablecodec.EncodeTablePrefix(tableID + 1)}, true
+}
+
+func startTxn(reg *Registry, startTS uint64) *Txn {
+	t := NewTxnWithRegistry(reg, tableRange)
+	t.Start(startTS)
+	return t
+}
+
+func TestWriteSkew(t *testing.T) {
+	reg := NewRegistry()
+	a, b := kv.Key("a"), kv.Key("b")
+
+	// t1 reads b and writes a, t2 reads a and writes b.
+	t1 := startTxn(reg, 10)
+	t2 := startTxn(reg, 11)
+	t1.TrackKeys([]kv.Key{a, b})
+	t2.TrackKeys([]kv.Key{a, b})
+	require.NoError(t, t1.Prepare([]kv.Key{a}))
+	t1.Commit(20)
+	err := t2.Prepare([]kv.Key{b})
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+	require.True(t, kv.IsTxnRetryableError(err))
+	t2.Rollback()
+	require.Equal(t, 0, reg.Len())
+
+	// The retried t2 sees the write of t1.
+	t2 = startTxn(reg, 21)
+	t2.TrackKeys([]kv.Key{a, b})
+	require.NoError(t, t2.Prepare([]kv.Key{b}))
+	t2.Commit(22)
+	require.Equal(t, 0, reg.Len())
+}
+
+func TestNonConflictingTxns(t *testing.T) {
+	reg := NewRegistry()
+
+	// A single rw-antidependency is allowed.
+	t1 := startTxn(reg, 10)
+	t2 := startTxn(reg, 11)
+	t1.TrackKeys([]kv.Key{kv.Key("a")})
+	t2.TrackKeys([]kv.Key{kv.Key("b")})
+	require.NoError(t, t2.Prepare([]kv.Key{kv.Key("a")}))
+	t2.Commit(20)
+	require.NoError(t, t1.Prepare([]kv.Key{kv.Key("c")}))
+	t1.Commit(21)
+	require.Equal(t, 0, reg.Len())
+
+	// The transactions that aren't concurrent don't conflict.
+	t1 = startTxn(reg, 30)
+	t1.TrackKeys([]kv.Key{kv.Key("b")})
+	require.NoError(t, t1.Prepare([]kv.Key{kv.Key("a")}))
+	t1.Commit(31)
+	t2 = startTxn(reg, 32)
+	t2.TrackKeys([]kv.Key{kv.Key("a")})
+	require.NoError(t, t2.Prepare([]kv.Key{kv.Key("b")}))
+	t2.Commit(33)
+}
+
+func TestPhantom(t *testing.T) {
+	reg := NewRegistry()
+	start, end := tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(0)), tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(100))
+
+	// Both transactions count the rows in a range and insert a new one into it.
+	t1 := startTxn(reg, 10)
+	t2 := startTxn(reg, 11)
+	t1.TrackRanges([]kv.KeyRange{{StartKey: start, EndKey: end}})
+	t2.TrackRanges([]kv.KeyRange{{StartKey: start, EndKey: end}})
+	require.NoError(t, t1.Prepare([]kv.Key{tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(1))}))
+	t1.Commit(20)
+	err := t2.Prepare([]kv.Key{tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(2))})
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+	t2.Rollback()
+}
+
+func TestReadOnlyAnomaly(t *testing.T) {
+	reg := NewRegistry()
+	x, y := kv.Key("x"), kv.Key("y")
+
+	// t2 reads x and writes y, t1 writes x after t2 starts.
+	t1 := startTxn(reg, 10)
+	t2 := startTxn(reg, 11)
+	t2.TrackKeys([]kv.Key{x, y})
+	t1.TrackKeys([]kv.Key{x})
+	require.NoError(t, t1.Prepare([]kv.Key{x}))
+	t1.Commit(20)
+	// t3 sees the write of t1 but not the write of t2.
+	t3 := startTxn(reg, 21)
+	t3.TrackKeys([]kv.Key{x, y})
+	// t2 is the pivot of t3 -rw-> t2 -rw-> t1 and is aborted.
+	err := t2.Prepare([]kv.Key{y})
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+	t2.Rollback()
+	require.NoError(t, t3.Prepare(nil))
+	t3.Commit(30)
+	require.Equal(t, 0, reg.Len())
+}
+
+func TestLostConflicts(t *testing.T) {
+	reg := NewRegistry()
+	t1 := startTxn(reg, 10)
+	require.NoError(t, t1.Prepare([]kv.Key{kv.Key("a")}))
+	t1.Commit(20)
+	require.Equal(t, 0, reg.Len())
+
+	// t2 starts before t1 commits but isn't tracked until t1 is removed.
+	t2 := startTxn(reg, 15)
+	err := t2.Prepare(nil)
+	require.True(t, kv.ErrSerializationFailure.Equal(err))
+	t2.Rollback()
+
+	t3 := startTxn(reg, 21)
+	require.NoError(t, t3.Prepare(nil))
+	t3.Commit(22)
+}
+
+func TestWidenReads(t *testing.T) {
+	defer func(n int) {
+		maxTrackedRanges = n
+	}(maxTrackedRanges)
+	maxTrackedRanges = 2
+
+	reg := NewRegistry()
+	t1 := startTxn(reg, 10)
+	t1.TrackKeys([]kv.Key{
+		tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(1)),
+		tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(2)),
+		tablecodec.EncodeRowKeyWithHandle(2, kv.IntHandle(1)),
+	})
+	// The adjacent table ranges are merged.
+	require.Equal(t, []kv.KeyRange{
+		{StartKey: tablecodec.EncodeTablePrefix(1), EndKey: tablecodec.EncodeTablePrefix(3)},
+	}, t1.readMu.ranges)
+	require.True(t, t1.readsOverlap([]kv.Key{tablecodec.EncodeRowKeyWithHandle(1, kv.IntHandle(3))}))
+	require.False(t, t1.readsOverlap([]kv.Key{tablecodec.EncodeRowKeyWithHandle(3, kv.IntHandle(1))}))
+	t1.Rollback()
+}
@@ -120,6 +120,8 @@ type AnalyzeResults struct {
 	//
 	// The global index has only one key range, so an independent task is used to process it.
 	// Global index needs to update only the version at the table-level fields, just like mv index.
+	//
+	// The full-text index is the same as the mv index, a row has an index entry for every distinct token.
 	ForMVIndexOrGlobalIndex bool
 }
 
@@ -15,22 +15,30 @@
 package statistics
 
 import (
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
+	"math/bits"
+	"slices"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/util/codec"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
 
 // BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// sampleCount is the number of sampled rows if the Ordinals of the column samples are the offsets of
+// the rows they belong to, which is needed by the multi-column stats. Otherwise, it should be 0.
 func BuildExtendedStats(sctx sessionctx.Context,
-	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector) (*ExtendedStatsColl, error) {
-	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
+	tableID int64, cols []*model.ColumnInfo, collectors []*SampleCollector, sampleCount int) (*ExtendedStatsColl, error) {
+	const sql = "SELECT name, type, column_ids, stats FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
 
 	sqlExec := sctx.GetRestrictedSQLExecutor()
 	rows, _, err := sqlExec.ExecRestrictedSQL(kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats), nil, sql, tableID, ExtendedStatsAnalyzed, ExtendedStatsInited)
@@ -50,7 +58,14 @@ func BuildExtendedStats(sctx sessionctx.Context,
 			logutil.BgLogger().Error("invalid column_ids in mysql.stats_extended, skip collecting extended stats for this row", zap.String("column_ids", colIDs), zap.Error(err))
 			continue
 		}
-		item = fillExtendedStatsItemVals(sctx, item, cols, collectors)
+		if item.Tp == ast.StatsTypeMultiColumn {
+			item.MultiColumn, err = DecodeMultiColumnStats(item.ColIDs, row.GetString(3))
+			if err != nil {
+				logutil.BgLogger().Error("invalid stats in mysql.stats_extended, skip collecting extended stats for this row", zap.String("stats", row.GetString(3)), zap.Error(err))
+				continue
+			}
+		}
+		item = fillExtendedStatsItemVals(sctx, item, cols, collectors, sampleCount)
 		if item != nil {
 			statsColl.Stats[name] = item
 		}
@@ -61,12 +76,17 @@ func BuildExtendedStats(sctx sessionctx.Context,
 	return statsColl, nil
 }
 
-func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector) *ExtendedStatsItem {
+func fillExtendedStatsItemVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector, sampleCount int) *ExtendedStatsItem {
 	switch item.Tp {
 	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
 		return nil
 	case ast.StatsTypeCorrelation:
 		return fillExtStatsCorrVals(sctx, item, cols, collectors)
+	case ast.StatsTypeMultiColumn:
+		if sampleCount <= 0 {
+			return nil
+		}
+		return fillExtStatsMultiColumnVals(sctx, item, cols, collectors, sampleCount)
 	}
 	return nil
 }
@@ -140,3 +160,212 @@ func fillExtStatsCorrVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols
 	item.ScalarVals = (itemsCount*corrXYSum - corrXSum*corrXSum) / (itemsCount*corrX2Sum - corrXSum*corrXSum)
 	return item
 }
+
+// fillExtStatsMultiColumnVals builds the multi-column stats from the sampled rows. The Ordinals of the
+// column samples are the offsets of the rows, and the rows with NULL values in any column are skipped.
+func fillExtStatsMultiColumnVals(sctx sessionctx.Context, item *ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*SampleCollector, sampleCount int) *ExtendedStatsItem {
+	if item.MultiColumn == nil {
+		return nil
+	}
+	colOffsets := make([]int, 0, len(item.ColIDs))
+	for _, id := range item.ColIDs {
+		offset := slices.IndexFunc(cols, func(col *model.ColumnInfo) bool { return col.ID == id })
+		if offset < 0 || collectors[offset] == nil {
+			return nil
+		}
+		colOffsets = append(colOffsets, offset)
+	}
+	colNum := len(colOffsets)
+	if colNum < 2 || colNum > MaxMultiColumnStatsColumns {
+		return nil
+	}
+	loc := sctx.GetSessionVars().StmtCtx.TimeZone()
+	// sampledRows[i][j] is the encoded value of the j-th column of the i-th sampled row.
+	sampledRows := make([][][]byte, sampleCount)
+	for j, offset := range colOffsets {
+		for _, sample := range collectors[offset].Samples {
+			if sample.Ordinal < 0 || sample.Ordinal >= sampleCount {
+				continue
+			}
+			val, err := codec.EncodeKey(loc, nil, sample.Value)
+			if err != nil {
+				logutil.BgLogger().Warn("encode sampled value failed, skip collecting multi-column stats", zap.Error(err))
+				return nil
+			}
+			if sampledRows[sample.Ordinal] == nil {
+				sampledRows[sample.Ordinal] = make([][]byte, colNum)
+			}
+			sampledRows[sample.Ordinal][j] = val
+		}
+	}
+	rows := make([][][]byte, 0, sampleCount)
+	for _, row := range sampledRows {
+		if row != nil && !slices.ContainsFunc(row, func(val []byte) bool { return val == nil }) {
+			rows = append(rows, row)
+		}
+	}
+
+	stats := &MultiColumnStats{ColIDs: item.ColIDs, Kinds: item.MultiColumn.Kinds}
+	if len(rows) > 0 {
+		// The number of rows without NULL values in the whole table.
+		totalCount := collectors[colOffsets[0]].Count + collectors[colOffsets[0]].NullCount
+		rowCount := uint64(max(float64(totalCount)*float64(len(rows))/float64(sampleCount), float64(len(rows))))
+		if stats.HasKind(ast.StatsTypeCardinality) {
+			stats.NDVs = buildColumnGroupNDVs(item.ColIDs, rows, rowCount)
+		}
+		if stats.HasKind(ast.StatsTypeDependency) {
+			stats.Dependencies = buildFuncDependencies(item.ColIDs, rows)
+		}
+		if stats.HasKind(ast.StatsTypeMCV) {
+			stats.MCVs = buildMultiColumnMCVs(rows, sampleCount)
+		}
+	}
+	data, err := json.Marshal(stats)
+	if err != nil {
+		logutil.BgLogger().Warn("encode multi-column stats failed", zap.Error(err))
+		return nil
+	}
+	item.MultiColumn = stats
+	item.StringVals = string(data)
+	return item
+}
+
+// groupKey appends the values of the columns in the mask to the buffer.
+func groupKey(buf []byte, row [][]byte, mask int) []byte {
+	buf = buf[:0]
+	for j, val := range row {
+		if mask&(1<<j) != 0 {
+			buf = append(buf, val...)
+		}
+	}
+	return buf
+}
+
+func maskColIDs(colIDs []int64, mask int) []int64 {
+	ids := make([]int64, 0, bits.OnesCount(uint(mask)))
+	for j, id := range colIDs {
+		if mask&(1<<j) != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// buildColumnGroupNDVs estimates the joint NDVs of all the column groups with at least 2 columns.
+func buildColumnGroupNDVs(colIDs []int64, rows [][][]byte, rowCount uint64) []*ColumnGroupNDV {
+	var buf []byte
+	ndvs := make([]*ColumnGroupNDV, 0, 1<<len(colIDs))
+	for mask := 1; mask < 1<<len(colIDs); mask++ {
+		if bits.OnesCount(uint(mask)) < 2 {
+			continue
+		}
+		counts := make(map[string]int)
+		for _, row := range rows {
+			buf = groupKey(buf, row, mask)
+			counts[string(buf)]++
+		}
+		var onlyOnceItems uint64
+		for _, cnt := range counts {
+			if cnt == 1 {
+				onlyOnceItems++
+			}
+		}
+		sampleSize, sampleNDV := uint64(len(rows)), uint64(len(counts))
+		ndv := sampleNDV
+		if onlyOnceItems == sampleSize {
+			// Assume the column group is unique.
+			ndv = rowCount
+		} else if onlyOnceItems > 0 {
+			ndv = estimateNDVByGEE(sampleSize, sampleNDV, onlyOnceItems, rowCount)
+		}
+		ndvs = append(ndvs, &ColumnGroupNDV{ColIDs: maskColIDs(colIDs, mask), NDV: float64(ndv)})
+	}
+	return ndvs
+}
+
+// buildFuncDependencies calculates the degrees of the functional dependencies `X -> y` between the columns.
+// The degree is the fraction of the rows whose group of X values have only one y value.
+func buildFuncDependencies(colIDs []int64, rows [][][]byte) []*FuncDependency {
+	type depGroup struct {
+		first [][]byte
+		count int
+		// consistent[j] is whether all the rows in the group have the same value of the j-th column.
+		consistent []bool
+	}
+	var buf []byte
+	colNum := len(colIDs)
+	fullMask := 1<<colNum - 1
+	deps := make([]*FuncDependency, 0, colNum<<colNum)
+	for mask := 1; mask < fullMask; mask++ {
+		groups := make(map[string]*depGroup)
+		for _, row := range rows {
+			buf = groupKey(buf, row, mask)
+			g, ok := groups[string(buf)]
+			if !ok {
+				g = &depGroup{first: row, consistent: make([]bool, colNum)}
+				for j := range g.consistent {
+					g.consistent[j] = true
+				}
+				groups[string(buf)] = g
+			}
+			g.count++
+			for j := range colNum {
+				if mask&(1<<j) == 0 && g.consistent[j] && !bytes.Equal(row[j], g.first[j]) {
+					g.consistent[j] = false
+				}
+			}
+		}
+		from := maskColIDs(colIDs, mask)
+		for j := range colNum {
+			if mask&(1<<j) != 0 {
+				continue
+			}
+			supported := 0
+			for _, g := range groups {
+				if g.consistent[j] {
+					supported += g.count
+				}
+			}
+			deps = append(deps, &FuncDependency{From: from, To: colIDs[j], Degree: float64(supported) / float64(len(rows))})
+		}
+	}
+	return deps
+}
+
+// buildMultiColumnMCVs collects the value combinations appearing more than once in the sampled rows.
+func buildMultiColumnMCVs(rows [][][]byte, sampleCount int) []*MultiColumnMCV {
+	type mcvGroup struct {
+		key   string
+		row   [][]byte
+		count int
+	}
+	var buf []byte
+	fullMask := 1<<len(rows[0]) - 1
+	groups := make(map[string]*mcvGroup)
+	for _, row := range rows {
+		buf = groupKey(buf, row, fullMask)
+		g, ok := groups[string(buf)]
+		if !ok {
+			g = &mcvGroup{key: string(buf), row: row}
+			groups[g.key] = g
+		}
+		g.count++
+	}
+	candidates := make([]*mcvGroup, 0, len(groups))
+	for _, g := range groups {
+		if g.count > 1 {
+			candidates = append(candidates, g)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b *mcvGroup) int {
+		if c := cmp.Compare(b.count, a.count); c != 0 {
+			return c
+		}
+		return strings.Compare(a.key, b.key)
+	})
+	mcvs := make([]*MultiColumnMCV, 0, min(len(candidates), maxMultiColumnMCVs))
+	for _, g := range candidates[:min(len(candidates), maxMultiColumnMCVs)] {
+		mcvs = append(mcvs, &MultiColumnMCV{Values: g.row, Frequency: float64(g.count) / float64(sampleCount)})
+	}
+	return mcvs
+}
@@ -1082,6 +1082,66 @@ func TestExtStatsOnModifiedColumn(t *testing.T) {
 	require.Equal(t, "-1.000000", rows[0][5])
 }
 
+func TestMultiColumnStats(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("use test")
+	tk.MustExec("create table t(city varchar(16), zip varchar(16), country varchar(16))")
+	for i := 0; i < 200; i++ {
+		zip := i % 20
+		city := zip % 5
+		tk.MustExec(fmt.Sprintf("insert into t values('c%d', 'z%d', 'n%d')", city, zip, city%2))
+	}
+	err := tk.ExecToErr("create statistics s1 (correlation) on t(city, zip, country)")
+	require.Error(t, err)
+	err = tk.ExecToErr("create statistics s1 (ndistinct) on t(city)")
+	require.Error(t, err)
+	tk.MustExec("create statistics s1 (dependencies, ndistinct, mcv) on t(city, zip, country)")
+	tk.MustExec("analyze table t all columns")
+	rows := tk.MustQuery("show stats_extended where stats_name = 's1'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "[city,zip,country]", rows[0][3])
+	require.Equal(t, "cardinality,dependency,mcv", rows[0][4])
+
+	tbl, err := dom.InfoSchema().TableByName(context.Background(), ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	statsTbl := dom.StatsHandle().GetTableStats(tbl.Meta())
+	multiStats := statsTbl.ExtendedStats.MultiColumnStats()
+	require.Len(t, multiStats, 1)
+	ndv, ok := multiStats[0].GroupNDV([]int64{1, 2})
+	require.True(t, ok)
+	require.Equal(t, 20.0, ndv)
+	ndv, ok = multiStats[0].GroupNDV([]int64{1, 3})
+	require.True(t, ok)
+	require.Equal(t, 5.0, ndv)
+	require.Len(t, multiStats[0].MCVs, 20)
+
+	// zip -> city, so the selectivity of the city condition shouldn't be counted again.
+	tk.MustQuery("explain format = 'brief' select * from t where city = 'c1' and zip = 'z1'").CheckAt([]int{0, 1}, testkit.RowsWithSep("|",
+		"TableReader|10.00",
+		"└─Selection|10.00",
+		"  └─TableFullScan|200.00",
+	))
+	tk.MustQuery("explain format = 'brief' select * from t where city = 'c1' and zip = 'z1' and country = 'n1'").CheckAt([]int{0, 1}, testkit.RowsWithSep("|",
+		"TableReader|10.00",
+		"└─Selection|10.00",
+		"  └─TableFullScan|200.00",
+	))
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	tk.MustQuery("explain format = 'brief' select * from t where city = 'c1' and zip = 'z1'").CheckAt([]int{0, 1}, testkit.RowsWithSep("|",
+		"TableReader|2.00",
+		"└─Selection|2.00",
+		"  └─TableFullScan|200.00",
+	))
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+
+	tk.MustExec("drop statistics s1")
+	tk.MustQuery("select name, status from mysql.stats_extended where name = 's1'").Check(testkit.Rows("s1 2"))
+	err = tk.ExecToErr("drop statistics s1")
+	require.Error(t, err)
+}
+
 func TestCorrelationWithDefinedCollate(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	testKit := testkit.NewTestKit(t, store)
@@ -22,6 +22,7 @@ import (
 	"github.com/klauspost/compress/gzip"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/statistics"
@@ -65,6 +66,14 @@ func extendedStatsFromJSON(statsColl []*statsutil.JSONExtendedStats) *statistics
 			ScalarVals: js.ScalarVals,
 			StringVals: js.StringVals,
 		}
+		if item.Tp == ast.StatsTypeMultiColumn {
+			var err error
+			item.MultiColumn, err = statistics.DecodeMultiColumnStats(item.ColIDs, item.StringVals)
+			if err != nil {
+				logutil.BgLogger().Warn("decode multi-column stats failed", zap.String("stats", item.StringVals), zap.Error(err))
+				continue
+			}
+		}
 		stats.Stats[js.StatsName] = item
 	}
 	return stats
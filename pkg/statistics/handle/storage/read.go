@@ -291,6 +291,13 @@ func ExtendedStatsFromStorage(sctx sessionctx.Context, table *statistics.Table,
 			} else {
 				item.StringVals = statsStr
 			}
+			if item.Tp == ast.StatsTypeMultiColumn {
+				item.MultiColumn, err = statistics.DecodeMultiColumnStats(item.ColIDs, statsStr)
+				if err != nil {
+					statslogutil.StatsLogger().Error("decode multi-column stats failed", zap.String("stats", statsStr), zap.Error(err))
+					return nil, err
+				}
+			}
 			table.ExtendedStats.Stats[name] = item
 		}
 	}
@@ -252,10 +252,10 @@ func (s *statsReadWriter) SaveMetaToStorage(
 }
 
 // InsertExtendedStats inserts a record into mysql.stats_extended and update version in mysql.stats_meta.
-func (s *statsReadWriter) InsertExtendedStats(statsName string, colIDs []int64, tp int, tableID int64, ifNotExists bool) (err error) {
+func (s *statsReadWriter) InsertExtendedStats(statsName string, colIDs []int64, tp int, kinds []uint8, tableID int64, ifNotExists bool) (err error) {
 	var statsVer uint64
 	err = util.CallWithSCtx(s.statsHandler.SPool(), func(sctx sessionctx.Context) error {
-		statsVer, err = InsertExtendedStats(sctx, s.statsHandler, statsName, colIDs, tp, tableID, ifNotExists)
+		statsVer, err = InsertExtendedStats(sctx, s.statsHandler, statsName, colIDs, tp, kinds, tableID, ifNotExists)
 		return err
 	}, util.FlagWrapTxn)
 	if err == nil && statsVer != 0 {
@@ -162,15 +162,25 @@ func UpdateStatsMeta(
 }
 
 // InsertExtendedStats inserts a record into mysql.stats_extended and update version in mysql.stats_meta.
+// kinds are the kinds of stats to collect if tp is StatsTypeMultiColumn.
 func InsertExtendedStats(sctx sessionctx.Context,
 	statsCache types.StatsCache,
-	statsName string, colIDs []int64, tp int, tableID int64, ifNotExists bool) (statsVer uint64, err error) {
+	statsName string, colIDs []int64, tp int, kinds []uint8, tableID int64, ifNotExists bool) (statsVer uint64, err error) {
 	slices.Sort(colIDs)
 	bytes, err := json.Marshal(colIDs)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
 	strColIDs := string(bytes)
+	// The stats are filled by ANALYZE, only the kinds to collect are saved now.
+	var statsStr any
+	if tp == int(ast.StatsTypeMultiColumn) {
+		bytes, err = json.Marshal(&statistics.MultiColumnStats{Kinds: kinds})
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		statsStr = string(bytes)
+	}
 
 	// No need to use `exec.ExecuteInternal` since we have acquired the lock.
 	rows, _, err := statsutil.ExecRows(sctx, "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)", tableID, statistics.ExtendedStatsInited, statistics.ExtendedStatsAnalyzed)
@@ -210,8 +220,8 @@ func InsertExtendedStats(sctx sessionctx.Context,
 	// the record from the table, tidb-b should delete the cached item synchronously. While for tidb-c, it has to wait for
 	// next `Update()` to remove the cached item then.
 	removeExtendedStatsItem(statsCache, tableID, statsName)
-	const sql = "INSERT INTO mysql.stats_extended(name, type, table_id, column_ids, version, status) VALUES (%?, %?, %?, %?, %?, %?)"
-	if _, err = statsutil.Exec(sctx, sql, statsName, tp, tableID, strColIDs, version, statistics.ExtendedStatsInited); err != nil {
+	const sql = "INSERT INTO mysql.stats_extended(name, type, table_id, column_ids, stats, version, status) VALUES (%?, %?, %?, %?, %?, %?, %?)"
+	if _, err = statsutil.Exec(sctx, sql, statsName, tp, tableID, strColIDs, statsStr, version, statistics.ExtendedStatsInited); err != nil {
 		return 0, err
 	}
 	return
@@ -238,7 +248,7 @@ func SaveExtendedStatsToStorage(sctx sessionctx.Context,
 		switch item.Tp {
 		case ast.StatsTypeCardinality, ast.StatsTypeCorrelation:
 			statsStr = fmt.Sprintf("%f", item.ScalarVals)
-		case ast.StatsTypeDependency:
+		case ast.StatsTypeDependency, ast.StatsTypeMultiColumn:
 			statsStr = item.StringVals
 		}
 		// If isLoad is true, it's INSERT; otherwise, it's UPDATE.
@@ -424,7 +424,8 @@ type StatsReadWriter interface {
 	// Methods for extended stast.
 
 	// InsertExtendedStats inserts a record into mysql.stats_extended and update version in mysql.stats_meta.
-	InsertExtendedStats(statsName string, colIDs []int64, tp int, tableID int64, ifNotExists bool) (err error)
+	// kinds are the kinds of stats to collect if tp is StatsTypeMultiColumn.
+	InsertExtendedStats(statsName string, colIDs []int64, tp int, kinds []uint8, tableID int64, ifNotExists bool) (err error)
 
 	// MarkExtendedStatsDeleted update the status of mysql.stats_extended to be `deleted` and the version of mysql.stats_meta.
 	MarkExtendedStatsDeleted(statsName string, tableID int64, ifExists bool) (err error)
@@ -0,0 +1,123 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/codec"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// MaxMultiColumnStatsColumns is the max number of columns of the extended stats created by CREATE STATISTICS.
+const MaxMultiColumnStatsColumns = 8
+
+// maxMultiColumnMCVs is the max number of the most common values kept for a column group.
+const maxMultiColumnMCVs = 100
+
+// MultiColumnStats is the extended stats created by CREATE STATISTICS. It describes how the values
+// of several columns are correlated, which can't be told from the stats of every single column.
+type MultiColumnStats struct {
+	// ColIDs are the IDs of the columns in ascending order, they are the same as the ExtendedStatsItem's.
+	ColIDs []int64 `json:"-"`
+	// Kinds are the kinds of stats to collect, i.e. StatsTypeCardinality, StatsTypeDependency and StatsTypeMCV.
+	Kinds []uint8 `json:"kinds"`
+	// NDVs are the joint NDVs of all the column groups with at least 2 columns.
+	NDVs []*ColumnGroupNDV `json:"ndvs,omitempty"`
+	// Dependencies are the functional dependencies between the columns.
+	Dependencies []*FuncDependency `json:"dependencies,omitempty"`
+	// MCVs are the most common value combinations of all the columns, in descending order of frequency.
+	MCVs []*MultiColumnMCV `json:"mcvs,omitempty"`
+}
+
+// ColumnGroupNDV is the joint NDV of a column group.
+type ColumnGroupNDV struct {
+	ColIDs []int64 `json:"cols"`
+	NDV    float64 `json:"ndv"`
+}
+
+// FuncDependency is the functional dependency `From -> To`. Its Degree is the fraction of rows on which
+// the values of `From` determine the value of `To`, 1 means `To` is fully dependent on `From`.
+type FuncDependency struct {
+	From   []int64 `json:"from"`
+	To     int64   `json:"to"`
+	Degree float64 `json:"degree"`
+}
+
+// MultiColumnMCV is a most common value combination of the columns.
+type MultiColumnMCV struct {
+	// Values are the encoded values of the columns, see EncodeMultiColumnValue.
+	Values [][]byte `json:"values"`
+	// Frequency is the fraction of the rows having the values.
+	Frequency float64 `json:"freq"`
+}
+
+// DecodeMultiColumnStats decodes the multi-column stats stored in mysql.stats_extended.
+func DecodeMultiColumnStats(colIDs []int64, statsStr string) (*MultiColumnStats, error) {
+	stats := &MultiColumnStats{}
+	if err := json.Unmarshal([]byte(statsStr), stats); err != nil {
+		return nil, errors.Trace(err)
+	}
+	stats.ColIDs = colIDs
+	return stats, nil
+}
+
+// HasKind returns whether the kind of stats is collected.
+func (s *MultiColumnStats) HasKind(tp uint8) bool {
+	return slices.Contains(s.Kinds, tp)
+}
+
+// ColumnOffset returns the offset of the column in the ColIDs, or -1 if it's not found.
+func (s *MultiColumnStats) ColumnOffset(colID int64) int {
+	return slices.Index(s.ColIDs, colID)
+}
+
+// GroupNDV returns the joint NDV of the columns, colIDs must be sorted.
+func (s *MultiColumnStats) GroupNDV(colIDs []int64) (float64, bool) {
+	for _, ndv := range s.NDVs {
+		if slices.Equal(ndv.ColIDs, colIDs) {
+			return ndv.NDV, true
+		}
+	}
+	return 0, false
+}
+
+// MCVFrequency returns the frequency of the value combination of all the columns. If the values are
+// not in the MCV list, the frequency of the least common one in the list is returned as the upper bound.
+func (s *MultiColumnStats) MCVFrequency(values [][]byte) (freq float64, found bool) {
+	for _, mcv := range s.MCVs {
+		if slices.EqualFunc(mcv.Values, values, func(a, b []byte) bool { return string(a) == string(b) }) {
+			return mcv.Frequency, true
+		}
+	}
+	if len(s.MCVs) == 0 {
+		return 1, false
+	}
+	return s.MCVs[len(s.MCVs)-1].Frequency, false
+}
+
+// EncodeMultiColumnValue encodes the value of the column in the same way as the analyzed samples, so
+// that it can be compared with the values of the MCV list.
+func EncodeMultiColumnValue(loc *time.Location, ft *types.FieldType, d types.Datum) ([]byte, error) {
+	if ft.EvalType() == types.ETString && ft.GetType() != mysql.TypeEnum && ft.GetType() != mysql.TypeSet {
+		d = types.NewBytesDatum(collate.GetCollator(ft.GetCollate()).Key(d.GetString()))
+	}
+	return codec.EncodeKey(loc, nil, d)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/planner/planctx"
 	"github.com/pingcap/tidb/pkg/types"
@@ -212,6 +213,8 @@ type ExtendedStatsItem struct {
 	ColIDs     []int64
 	ScalarVals float64
 	Tp         uint8
+	// MultiColumn is decoded from StringVals if Tp is StatsTypeMultiColumn.
+	MultiColumn *MultiColumnStats
 }
 
 // ExtendedStatsColl is a collection of cached items for mysql.stats_extended records.
@@ -220,6 +223,25 @@ type ExtendedStatsColl struct {
 	LastUpdateVersion uint64
 }
 
+// MultiColumnStats returns the multi-column stats in the order of their names.
+func (coll *ExtendedStatsColl) MultiColumnStats() []*MultiColumnStats {
+	if coll == nil {
+		return nil
+	}
+	names := make([]string, 0, len(coll.Stats))
+	for name, item := range coll.Stats {
+		if item.Tp == ast.StatsTypeMultiColumn && item.MultiColumn != nil {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	stats := make([]*MultiColumnStats, 0, len(names))
+	for _, name := range names {
+		stats = append(stats, coll.Stats[name].MultiColumn)
+	}
+	return stats
+}
+
 // NewExtendedStatsColl allocate an ExtendedStatsColl struct.
 func NewExtendedStatsColl() *ExtendedStatsColl {
 	return &ExtendedStatsColl{Stats: make(map[string]*ExtendedStatsItem)}
@@ -266,6 +288,9 @@ type HistColl struct {
 	// For normal index, the column id is enough, as we already have in Idx2ColUniqueIDs. But currently, mv index needs more
 	// information to match the filter against the mv index columns, and we need this map to provide this information.
 	MVIdx2Columns map[int64][]*expression.Column
+	// MultiColumnStats are the multi-column stats of the table created by CREATE STATISTICS. They're used to
+	// estimate the selectivity and NDV of correlated columns instead of assuming the columns are independent.
+	MultiColumnStats []*MultiColumnStats
 }
 
 // NewHistColl creates a new HistColl.
@@ -773,7 +798,7 @@ func (coll *HistColl) GetAnalyzeRowCount() float64 {
 		if idx == nil {
 			continue
 		}
-		if idx.Info != nil && idx.Info.MVIndex {
+		if idx.Info != nil && (idx.Info.MVIndex || idx.Info.FullTextInfo != nil) {
 			continue
 		}
 		if idx.IsFullLoad() {
@@ -784,7 +809,8 @@ func (coll *HistColl) GetAnalyzeRowCount() float64 {
 }
 
 // GetScaledRealtimeAndModifyCnt scale the RealtimeCount and ModifyCount for some special indexes where the total row
-// count is different from the total row count of the table. Currently, only the mv index is this case.
+// count is different from the total row count of the table. Currently, only the mv index and the full-text index are this
+// case.
 // Because we will use the RealtimeCount and ModifyCount during the estimation for ranges on this index (like the upper
 // bound for the out-of-range estimation logic and the IncreaseFactor logic), we can't directly use the RealtimeCount and
 // ModifyCount of the table. Instead, we should scale them before using.
@@ -795,7 +821,7 @@ func (coll *HistColl) GetAnalyzeRowCount() float64 {
 func (coll *HistColl) GetScaledRealtimeAndModifyCnt(idxStats *Index) (realtimeCnt, modifyCnt int64) {
 	// In theory, we can apply this scale logic on all indexes. But currently, we only apply it on the mv index to avoid
 	// any unexpected changes caused by factors like precision difference.
-	if idxStats == nil || idxStats.Info == nil || !idxStats.Info.MVIndex || !idxStats.IsFullLoad() {
+	if idxStats == nil || idxStats.Info == nil || !(idxStats.Info.MVIndex || idxStats.Info.FullTextInfo != nil) || !idxStats.IsFullLoad() {
 		return coll.RealtimeCount, coll.ModifyCount
 	}
 	analyzeRowCount := coll.GetAnalyzeRowCount()
@@ -974,7 +1000,9 @@ func (coll *HistColl) GenerateHistCollFromColumnInfo(tblInfo *model.TableInfo, c
 	mvIdx2Columns := make(map[int64][]*expression.Column)
 	for id, idxHist := range coll.indices {
 		idxInfo := idxID2idxInfo[id]
-		if idxInfo == nil {
+		// The statistics of a full-text index are the document frequencies of the tokens, which can't be used to
+		// estimate the conditions on the indexed columns.
+		if idxInfo == nil || idxInfo.FullTextInfo != nil {
 			continue
 		}
 		ids := make([]int64, 0, len(idxInfo.Columns))
@@ -364,6 +364,11 @@ func tryBuildCountProcessor(e *closureExecutor, executors []*tipb.Executor) (boo
 		if err != nil {
 			return false, errors.Trace(err)
 		}
+		if e.idxScanCtx != nil && idx != 0 {
+			// Only the first index column can be counted from the index key directly,
+			// the others, such as the included columns, may be stored in the index value.
+			return false, nil
+		}
 		e.aggCtx.col = e.columnInfos[idx]
 		if e.aggCtx.col.PkHandle {
 			e.processor = &countStarProcessor{skipVal: skipVal(true), closureExecutor: e}
@@ -343,6 +343,14 @@ func castColumnValue(tc types.Context, ec errctx.Context, sqlMode mysql.SQLMode,
 	if returnErr && err != nil {
 		return casted, err
 	}
+	if err == nil && col.GetType() == mysql.TypeGeometry && !casted.IsNull() {
+		// The geometries stored in a column with SRID attribute must have the same SRID.
+		if srid, ok := col.GetSRID(); ok {
+			if geomSRID := types.GeometrySRID(casted.GetBytes()); geomSRID != srid {
+				return casted, types.ErrWrongSRIDForColumn.GenWithStackByArgs(col.Name.O, geomSRID, srid)
+			}
+		}
+	}
 	if err != nil && types.ErrTruncated.Equal(err) && col.GetType() != mysql.TypeSet && col.GetType() != mysql.TypeEnum {
 		str, err1 := val.ToString()
 		if err1 != nil {
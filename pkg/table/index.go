@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/pingcap/tidb/pkg/errctx"
+	"github.com/pingcap/tidb/pkg/expression/exprctx"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/types"
@@ -99,10 +100,13 @@ type Index interface {
 	GenIndexKey(ec errctx.Context, loc *time.Location, indexedValues []types.Datum, h kv.Handle, buf []byte) (key []byte, distinct bool, err error)
 	// GenIndexValue generates an index value.
 	GenIndexValue(ec errctx.Context, loc *time.Location, distinct bool, indexedValues []types.Datum, h kv.Handle, restoredData []types.Datum, buf []byte) ([]byte, error)
-	// FetchValues fetched index column values in a row.
+	// FetchValues fetched index column values in a row, followed by the values of the included columns.
 	// Param columns is a reused buffer, if it is not nil, FetchValues will fill the index values in it,
 	// and return the buffer, if it is nil, FetchValues will allocate the buffer instead.
 	FetchValues(row []types.Datum, columns []types.Datum) ([]types.Datum, error)
+	// MeetPartialCondition checks whether the row satisfies the condition of a partial index,
+	// only such rows are indexed. It always returns true for an index without condition.
+	MeetPartialCondition(ctx exprctx.EvalContext, row []types.Datum) (bool, error)
 }
 
 // IndexKVGenerator generates kv for an index.
@@ -21,6 +21,9 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/errctx"
+	"github.com/pingcap/tidb/pkg/expression"
+	"github.com/pingcap/tidb/pkg/expression/exprctx"
+	"github.com/pingcap/tidb/pkg/expression/exprstatic"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
@@ -28,9 +31,12 @@ import (
 	"github.com/pingcap/tidb/pkg/tablecodec"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/intest"
+	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/rowcodec"
 	"github.com/pingcap/tidb/pkg/util/tracing"
+	"go.uber.org/zap"
 )
 
 // index is the data structure for index data in the KV store.
@@ -43,6 +49,9 @@ type index struct {
 	// the collation global variable is initialized *after* `NewIndex()`.
 	initNeedRestoreData sync.Once
 	needRestoredData    bool
+	// condition is the predicate of a partial index, conditionErr is set if it can't be built.
+	condition    expression.Expression
+	conditionErr error
 }
 
 // NeedRestoredData checks whether the index columns needs restored data.
@@ -63,6 +72,14 @@ func NewIndex(physicalID int64, tblInfo *model.TableInfo, indexInfo *model.Index
 		tblInfo:  tblInfo,
 		phyTblID: physicalID,
 	}
+	if indexInfo.HasCondition() {
+		index.condition, index.conditionErr = expression.ParseSimpleExpr(exprstatic.NewExprContext(),
+			indexInfo.ConditionExprString, expression.WithTableInfo("", tblInfo))
+		if index.conditionErr != nil {
+			logutil.BgLogger().Error("wrong partial index condition", zap.String("index", indexInfo.Name.O),
+				zap.String("condition", indexInfo.ConditionExprString), zap.Error(index.conditionErr))
+		}
+	}
 	return index
 }
 
@@ -109,7 +126,15 @@ func (c *index) GenIndexValue(ec errctx.Context, loc *time.Location, distinct bo
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// For spatial index, the geometry value is replaced by the cells covering it.
+// For full-text index, the text values are replaced by their distinct tokens.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.Spatial {
+		return getSpatialIndexedValue(indexedValues[0])
+	}
+	if c.idxInfo.FullTextInfo != nil {
+		return getFullTextIndexedValue(c.idxInfo, indexedValues)
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -156,6 +181,40 @@ out:
 	return vals
 }
 
+// getSpatialIndexedValue returns the cells of the geometry value stored in the
+// spatial index. A NULL or empty geometry has no cells.
+func getSpatialIndexedValue(value types.Datum) [][]types.Datum {
+	if value.IsNull() {
+		return nil
+	}
+	cells, err := tablecodec.SpatialIndexCells(value.GetBytes())
+	if err != nil {
+		// The geometry values are validated before written, so the invalid value
+		// must be written by an old version and it's not indexed.
+		logutil.BgLogger().Warn("invalid geometry value for spatial index", zap.Error(err))
+		return nil
+	}
+	vals := make([][]types.Datum, 0, len(cells))
+	for _, cell := range cells {
+		vals = append(vals, []types.Datum{cell.Datum()})
+	}
+	return vals
+}
+
+// getFullTextIndexedValue returns the tokens of the text values stored in the
+// full-text index. The token is stored in the first index column, and the
+// other index columns are NULL.
+func getFullTextIndexedValue(idxInfo *model.IndexInfo, values []types.Datum) [][]types.Datum {
+	tokens := tablecodec.FullTextIndexTokens(idxInfo.FullTextInfo.ParserType, values)
+	vals := make([][]types.Datum, 0, len(tokens))
+	for _, token := range tokens {
+		val := make([]types.Datum, len(values))
+		val[0] = tablecodec.FullTextTokenDatum(token)
+		vals = append(vals, val)
+	}
+	return vals
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
@@ -513,7 +572,7 @@ func (c *index) Delete(ctx table.MutateContext, txn kv.Transaction, indexedValue
 func (c *index) GenIndexKVIter(ec errctx.Context, loc *time.Location, indexedValue []types.Datum,
 	h kv.Handle, handleRestoreData []types.Datum) table.IndexKVGenerator {
 	var mvIndexValues [][]types.Datum
-	if c.Meta().MVIndex {
+	if c.Meta().MVIndex || c.Meta().IsSearchIndex() {
 		mvIndexValues = c.getIndexedValue(indexedValue)
 		return table.NewMultiValueIndexKVGenerator(c, ec, loc, h, handleRestoreData, mvIndexValues)
 	}
@@ -669,8 +728,28 @@ func (c *index) FetchValues(r []types.Datum, vals []types.Datum) ([]types.Datum,
 	return fetchIndexRow(c.idxInfo, r, vals, nil)
 }
 
+// MeetPartialCondition implements table.Index interface.
+func (c *index) MeetPartialCondition(ctx exprctx.EvalContext, row []types.Datum) (bool, error) {
+	if !c.idxInfo.HasCondition() {
+		return true, nil
+	}
+	if c.conditionErr != nil {
+		return false, errors.Trace(c.conditionErr)
+	}
+	v, isNull, err := c.condition.EvalInt(ctx, chunk.MutRowFromDatums(row).ToRow())
+	if err != nil {
+		return false, err
+	}
+	return !isNull && v != 0, nil
+}
+
 func fetchIndexRow(idxInfo *model.IndexInfo, r, vals []types.Datum, opt table.IndexRowLayoutOption) ([]types.Datum, error) {
 	needLength := len(idxInfo.Columns)
+	if len(opt) == 0 {
+		// The values of the included columns follow the values of the index columns,
+		// they are needed to generate the index value.
+		needLength += len(idxInfo.IncludeColumns)
+	}
 	if vals == nil || cap(vals) < needLength {
 		vals = make([]types.Datum, needLength)
 	}
@@ -693,6 +772,12 @@ func fetchIndexRow(idxInfo *model.IndexInfo, r, vals []types.Datum, opt table.In
 		}
 		vals[i] = r[ic.Offset]
 	}
+	for i, ic := range idxInfo.IncludeColumns {
+		if ic.Offset < 0 || ic.Offset >= len(r) {
+			return nil, table.ErrIndexOutBound.GenWithStackByArgs(ic.Name, ic.Offset, r)
+		}
+		vals[len(idxInfo.Columns)+i] = r[ic.Offset]
+	}
 	return vals, nil
 }
 
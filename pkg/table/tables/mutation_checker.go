@@ -15,7 +15,9 @@
 package tables
 
 import (
+	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/pingcap/errors"
@@ -379,6 +381,30 @@ func compareIndexData(
 		decodedMutationDatum := indexData[i]
 		expectedDatum := input[offsetInRow]
 
+		if indexInfo.Spatial {
+			if !spatialIndexContainsCell(expectedDatum, decodedMutationDatum) {
+				err := ErrInconsistentIndexedValue.GenWithStackByArgs(
+					tableInfo.Name.O, indexInfo.Name.O, cols[offsetInTable].ColumnInfo.Name.O,
+					decodedMutationDatum.String(), expectedDatum.String(),
+				)
+				logutil.BgLogger().Error("inconsistent indexed value in index insertion", zap.Error(err))
+				return err
+			}
+			continue
+		}
+		if indexInfo.FullTextInfo != nil {
+			// Only the first index column stores the token, and the others are NULL.
+			if i == 0 && !fullTextIndexContainsToken(indexInfo, input, extraIndexLayout, decodedMutationDatum) {
+				err := ErrInconsistentIndexedValue.GenWithStackByArgs(
+					tableInfo.Name.O, indexInfo.Name.O, cols[offsetInTable].ColumnInfo.Name.O,
+					decodedMutationDatum.String(), expectedDatum.String(),
+				)
+				logutil.BgLogger().Error("inconsistent indexed value in index insertion", zap.Error(err))
+				return err
+			}
+			continue
+		}
+
 		tablecodec.TruncateIndexValue(
 			&expectedDatum, indexInfo.Columns[i],
 			cols[offsetInTable].ColumnInfo,
@@ -407,6 +433,34 @@ func compareIndexData(
 	return nil
 }
 
+// spatialIndexContainsCell checks whether the cell is one of the cells of the
+// geometry value in the spatial index.
+func spatialIndexContainsCell(rowVal types.Datum, idxVal types.Datum) bool {
+	for _, cell := range getSpatialIndexedValue(rowVal) {
+		if bytes.Equal(cell[0].GetBytes(), idxVal.GetBytes()) {
+			return true
+		}
+	}
+	return false
+}
+
+// fullTextIndexContainsToken checks whether the token is one of the tokens of
+// the text values in the full-text index.
+func fullTextIndexContainsToken(
+	indexInfo *model.IndexInfo, input []types.Datum, extraIndexLayout table.IndexRowLayoutOption, idxVal types.Datum,
+) bool {
+	values := make([]types.Datum, 0, len(indexInfo.Columns))
+	for i, col := range indexInfo.Columns {
+		offsetInRow := col.Offset
+		if len(extraIndexLayout) > 0 {
+			offsetInRow = extraIndexLayout[i]
+		}
+		values = append(values, input[offsetInRow])
+	}
+	_, found := slices.BinarySearch(tablecodec.FullTextIndexTokens(indexInfo.FullTextInfo.ParserType, values), string(idxVal.GetBytes()))
+	return found
+}
+
 // CompareIndexAndVal compare index valued and row value.
 func CompareIndexAndVal(tc types.Context, rowVal types.Datum, idxVal types.Datum, collator collate.Collator, cmpMVIndex bool) (int, error) {
 	var cmpRes int
@@ -546,6 +546,7 @@ func (t *TableCommon) rebuildUpdateRecordIndices(
 	h kv.Handle, touched []bool, oldData []types.Datum, newData []types.Datum,
 	opt *table.UpdateRecordOpt,
 ) error {
+	evalCtx := ctx.GetExprCtx().GetEvalCtx()
 	for _, idx := range t.DeletableIndices() {
 		if t.meta.IsCommonHandle && idx.Meta().Primary {
 			continue
@@ -553,18 +554,20 @@ func (t *TableCommon) rebuildUpdateRecordIndices(
 		if idx.Meta().IsColumnarIndex() {
 			continue
 		}
-		for _, ic := range idx.Meta().Columns {
-			if !touched[ic.Offset] {
-				continue
-			}
-			oldVs, err := idx.FetchValues(oldData, nil)
-			if err != nil {
-				return err
-			}
-			if err = idx.Delete(ctx, txn, oldVs, h); err != nil {
-				return err
-			}
-			break
+		oldMeet, newMeet, err := meetPartialConditionForUpdate(evalCtx, idx, oldData, newData)
+		if err != nil {
+			return err
+		}
+		// The old row is not indexed, or it stays in the index with the same key.
+		if !oldMeet || (newMeet && !isIndexTouched(idx.Meta(), touched)) {
+			continue
+		}
+		oldVs, err := idx.FetchValues(oldData, nil)
+		if err != nil {
+			return err
+		}
+		if err = idx.Delete(ctx, txn, oldVs, h); err != nil {
+			return err
 		}
 	}
 	createIdxOpt := opt.GetCreateIdxOpt()
@@ -578,14 +581,15 @@ func (t *TableCommon) rebuildUpdateRecordIndices(
 		if t.meta.IsCommonHandle && idx.Meta().Primary {
 			continue
 		}
-		untouched := true
-		for _, ic := range idx.Meta().Columns {
-			if !touched[ic.Offset] {
-				continue
-			}
-			untouched = false
-			break
+		oldMeet, newMeet, err := meetPartialConditionForUpdate(evalCtx, idx, oldData, newData)
+		if err != nil {
+			return err
 		}
+		if !newMeet {
+			continue
+		}
+		// A row moved into a partial index always needs a new index entry.
+		untouched := oldMeet && !isIndexTouched(idx.Meta(), touched)
 		if untouched && opt.SkipWriteUntouchedIndices() {
 			continue
 		}
@@ -600,6 +604,36 @@ func (t *TableCommon) rebuildUpdateRecordIndices(
 	return nil
 }
 
+// isIndexTouched checks whether any column of the index, including the included columns, is updated.
+func isIndexTouched(idxInfo *model.IndexInfo, touched []bool) bool {
+	for _, ic := range idxInfo.Columns {
+		if touched[ic.Offset] {
+			return true
+		}
+	}
+	for _, ic := range idxInfo.IncludeColumns {
+		if touched[ic.Offset] {
+			return true
+		}
+	}
+	return false
+}
+
+// meetPartialConditionForUpdate checks whether the old and the new row of an update
+// satisfy the condition of the index.
+func meetPartialConditionForUpdate(ctx exprctx.EvalContext, idx table.Index, oldData, newData []types.Datum) (oldMeet, newMeet bool, err error) {
+	if !idx.Meta().HasCondition() {
+		return true, true, nil
+	}
+	if oldMeet, err = idx.MeetPartialCondition(ctx, oldData); err != nil {
+		return false, false, err
+	}
+	if newMeet, err = idx.MeetPartialCondition(ctx, newData); err != nil {
+		return false, false, err
+	}
+	return oldMeet, newMeet, nil
+}
+
 // FindPrimaryIndex uses to find primary index in tableInfo.
 func FindPrimaryIndex(tblInfo *model.TableInfo) *model.IndexInfo {
 	var pkIdx *model.IndexInfo
@@ -931,6 +965,7 @@ func (t *TableCommon) addIndices(sctx table.MutateContext, recordID kv.Handle, r
 	writeBufs := sctx.GetMutateBuffers().GetWriteStmtBufs()
 	indexVals := writeBufs.IndexValsBuf
 	skipCheck := opt.DupKeyCheck() == table.DupKeyCheckSkip
+	evalCtx := sctx.GetExprCtx().GetEvalCtx()
 	for _, v := range t.Indices() {
 		if !IsIndexWritable(v) {
 			continue
@@ -941,6 +976,11 @@ func (t *TableCommon) addIndices(sctx table.MutateContext, recordID kv.Handle, r
 		if t.meta.IsCommonHandle && v.Meta().Primary {
 			continue
 		}
+		if meet, err := v.MeetPartialCondition(evalCtx, r); err != nil {
+			return nil, err
+		} else if !meet {
+			continue
+		}
 		// We declared `err` here to make sure `indexVals` is assigned with `=` instead of `:=`.
 		// The latter one will create a new variable that shadows the outside `indexVals` that makes `indexVals` outside
 		// always nil, and we cannot reuse it.
@@ -1200,6 +1240,12 @@ func (t *TableCommon) removeRowIndices(ctx table.MutateContext, txn kv.Transacti
 		if v.Meta().IsColumnarIndex() {
 			continue
 		}
+		// The columns are never pruned for a table with partial indexes, see pruneAndBuildColPositionInfoForDelete.
+		if meet, err := v.MeetPartialCondition(ctx.GetExprCtx().GetEvalCtx(), rec); err != nil {
+			return err
+		} else if !meet {
+			continue
+		}
 		var vals []types.Datum
 		if opt.HasIndexesLayout() {
 			vals, err = fetchIndexRow(v.Meta(), rec, nil, opt.GetIndexLayout(v.Meta().ID))
@@ -1229,6 +1275,7 @@ func (t *TableCommon) buildIndexForRow(ctx table.MutateContext, h kv.Handle, val
 	if _, err := idx.create(ctx, txn, vals, h, rsData, untouched, opt); err != nil {
 		if kv.ErrKeyExists.Equal(err) {
 			// Make error message consistent with MySQL.
+			vals = vals[:len(idx.Meta().Columns)]
 			tablecodec.TruncateIndexValues(t.meta, idx.Meta(), vals)
 			colStrVals, err1 := genIndexKeyStrs(vals)
 			if err1 != nil {
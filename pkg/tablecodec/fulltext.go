@@ -0,0 +1,169 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablecodec
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// The full-text index is an inverted index, which stores an index entry for
+// every distinct token of a row. The token is stored as the value of the first
+// index column, and the other index columns are NULL, so the rows containing a
+// token are the index entries with the token as prefix.
+//
+// The standard parser splits the text into words of letters, digits and
+// underscores like the InnoDB built-in parser, the words which are too short,
+// too long or in the stopword list are ignored. The CJK characters have no
+// word delimiters, so they are split into bigrams like the ngram parser. The
+// ngram parser splits all the text into bigrams.
+const (
+	// FullTextMinTokenSize is the min length of a word of the standard parser,
+	// which is the same as innodb_ft_min_token_size.
+	FullTextMinTokenSize = 3
+	// FullTextMaxTokenSize is the max length of a word of the standard parser,
+	// which is the same as innodb_ft_max_token_size.
+	FullTextMaxTokenSize = 84
+	// FullTextNgramTokenSize is the length of a token of the ngram parser,
+	// which is the same as the default ngram_token_size.
+	FullTextNgramTokenSize = 2
+)
+
+// fullTextStopwords is the default stopword list of InnoDB, see
+// INFORMATION_SCHEMA.INNODB_FT_DEFAULT_STOPWORD.
+var fullTextStopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {}, "de": {}, "en": {},
+	"for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {}, "la": {}, "of": {}, "on": {}, "or": {},
+	"that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "what": {}, "when": {}, "where": {}, "who": {}, "will": {},
+	"with": {}, "und": {}, "www": {},
+}
+
+// IsFullTextStopword returns whether the word is ignored by the standard parser.
+func IsFullTextStopword(word string) bool {
+	_, ok := fullTextStopwords[word]
+	return ok
+}
+
+// IsFullTextWordChar returns whether the character is a part of a word.
+func IsFullTextWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func isCJK(r rune) bool {
+	// The range covers the CJK symbols and the Kana, including the characters of the common script like 'ー'.
+	return (r >= 0x3000 && r <= 0x30ff) || unicode.In(r, unicode.Han, unicode.Hangul)
+}
+
+// appendNgrams appends the bigrams of the characters to the tokens. A single
+// character is a token itself.
+func appendNgrams(tokens []string, chars []rune) []string {
+	if len(chars) < FullTextNgramTokenSize {
+		return append(tokens, string(chars))
+	}
+	for i := 0; i+FullTextNgramTokenSize <= len(chars); i++ {
+		tokens = append(tokens, string(chars[i:i+FullTextNgramTokenSize]))
+	}
+	return tokens
+}
+
+// appendWord appends the tokens of a word, which is a run of the word
+// characters of the same kind.
+func appendWord(tokens []string, parser model.FullTextParserType, chars []rune) []string {
+	if len(chars) == 0 {
+		return tokens
+	}
+	if parser == model.FullTextParserTypeNgram || isCJK(chars[0]) {
+		return appendNgrams(tokens, chars)
+	}
+	if len(chars) < FullTextMinTokenSize || len(chars) > FullTextMaxTokenSize {
+		return tokens
+	}
+	word := string(chars)
+	if IsFullTextStopword(word) {
+		return tokens
+	}
+	return append(tokens, word)
+}
+
+// FullTextTokenize splits the text into tokens in order. The tokens are in
+// lower case, so the full-text search is case-insensitive.
+func FullTextTokenize(parser model.FullTextParserType, text string) []string {
+	var (
+		tokens []string
+		chars  []rune
+	)
+	for _, r := range strings.ToLower(text) {
+		if !IsFullTextWordChar(r) {
+			tokens = appendWord(tokens, parser, chars)
+			chars = chars[:0]
+			continue
+		}
+		// The standard parser splits the CJK characters from the other characters.
+		if parser != model.FullTextParserTypeNgram && len(chars) > 0 && isCJK(chars[0]) != isCJK(r) {
+			tokens = appendWord(tokens, parser, chars)
+			chars = chars[:0]
+		}
+		chars = append(chars, r)
+	}
+	return appendWord(tokens, parser, chars)
+}
+
+// FullTextTokenizePrefix splits the word before the truncation operator '*'
+// into tokens. Unlike FullTextTokenize, the word of the standard parser is kept
+// even if it is too short or a stopword, since it matches the longer words.
+func FullTextTokenizePrefix(parser model.FullTextParserType, word string) []string {
+	word = strings.ToLower(word)
+	if parser != model.FullTextParserTypeNgram && word != "" && !strings.ContainsFunc(word, isCJK) &&
+		!strings.ContainsFunc(word, func(r rune) bool { return !IsFullTextWordChar(r) }) {
+		return []string{word}
+	}
+	return FullTextTokenize(parser, word)
+}
+
+// FullTextIndexTokens returns the distinct tokens of the values of a row in
+// order, which are the index entries of the row. The NULL values are ignored.
+func FullTextIndexTokens(parser model.FullTextParserType, values []types.Datum) []string {
+	var tokens []string
+	for _, v := range values {
+		if v.IsNull() {
+			continue
+		}
+		tokens = append(tokens, FullTextTokenize(parser, v.GetString())...)
+	}
+	slices.Sort(tokens)
+	return slices.Compact(tokens)
+}
+
+// FullTextTokenDatum returns the datum of a token stored in the full-text index.
+func FullTextTokenDatum(token string) types.Datum {
+	return types.NewBytesDatum([]byte(token))
+}
+
+// FullTextPrefixEnd returns the smallest string which is larger than all the
+// strings with the prefix, or "" if there is no such string.
+func FullTextPrefixEnd(prefix string) string {
+	b := []byte(prefix)
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < 0xff {
+			b[i]++
+			return string(b[:i+1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablecodec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFullTextTokenize(t *testing.T) {
+	cases := []struct {
+		parser model.FullTextParserType
+		text   string
+		tokens []string
+	}{
+		{model.FullTextParserTypeStandard, "", nil},
+		{model.FullTextParserTypeStandard, "The quick brown fox jumps over the lazy dog", []string{"quick", "brown", "fox", "jumps", "over", "lazy", "dog"}},
+		{model.FullTextParserTypeStandard, "MySQL's full-text search, in 2024!", []string{"mysql", "full", "text", "search", "2024"}},
+		{model.FullTextParserTypeStandard, "a an it to go tidb_server", []string{"tidb_server"}},
+		{model.FullTextParserTypeStandard, strings.Repeat("x", FullTextMaxTokenSize+1) + " " + strings.Repeat("y", FullTextMaxTokenSize), []string{strings.Repeat("y", FullTextMaxTokenSize)}},
+		{model.FullTextParserTypeStandard, "数据库TiDB系统", []string{"数据", "据库", "tidb", "系统"}},
+		{model.FullTextParserTypeStandard, "中 文", []string{"中", "文"}},
+		{model.FullTextParserTypeStandard, "データベース", []string{"デー", "ータ", "タベ", "ベー", "ース"}},
+		{model.FullTextParserTypeNgram, "TiDB 数据库", []string{"ti", "id", "db", "数据", "据库"}},
+		{model.FullTextParserTypeNgram, "a, the", []string{"a", "th", "he"}},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.tokens, FullTextTokenize(c.parser, c.text), c.text)
+	}
+}
+
+func TestFullTextTokenizePrefix(t *testing.T) {
+	require.Equal(t, []string{"da"}, FullTextTokenizePrefix(model.FullTextParserTypeStandard, "DA"))
+	require.Equal(t, []string{"the"}, FullTextTokenizePrefix(model.FullTextParserTypeStandard, "the"))
+	require.Equal(t, []string{"数据", "据库"}, FullTextTokenizePrefix(model.FullTextParserTypeStandard, "数据库"))
+	require.Equal(t, []string{"da"}, FullTextTokenizePrefix(model.FullTextParserTypeNgram, "da"))
+	require.Equal(t, []string{"d"}, FullTextTokenizePrefix(model.FullTextParserTypeNgram, "d"))
+	require.Empty(t, FullTextTokenizePrefix(model.FullTextParserTypeStandard, ""))
+}
+
+func TestFullTextIndexTokens(t *testing.T) {
+	values := []types.Datum{
+		types.NewStringDatum("Database systems"),
+		types.NewDatum(nil),
+		types.NewStringDatum("distributed DATABASE"),
+	}
+	require.Equal(t, []string{"database", "distributed", "systems"}, FullTextIndexTokens(model.FullTextParserTypeStandard, values))
+	require.Empty(t, FullTextIndexTokens(model.FullTextParserTypeStandard, []types.Datum{types.NewStringDatum("to be or the with")}))
+
+	d := FullTextTokenDatum("database")
+	require.Equal(t, types.KindBytes, d.Kind())
+	require.Equal(t, []byte("database"), d.GetBytes())
+}
+
+func TestFullTextPrefixEnd(t *testing.T) {
+	require.Equal(t, "dau", FullTextPrefixEnd("dat"))
+	require.Equal(t, "b", FullTextPrefixEnd("a\xff\xff"))
+	require.Equal(t, "", FullTextPrefixEnd("\xff"))
+	require.Equal(t, "", FullTextPrefixEnd(""))
+}
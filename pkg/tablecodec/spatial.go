@@ -0,0 +1,171 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablecodec
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/types"
+)
+
+// The spatial index stores the bounding box of a geometry value as the cells
+// of a Hilbert curve over a 2^31 x 2^31 grid, which is similar to the S2 cell
+// ids. A cell of level L (0 <= L <= 31) is an aligned square of
+// 2^(31-L) x 2^(31-L) grid points, and its id is
+//
+//	d<<1 | 1<<(2*(31-L))
+//
+// where d is the Hilbert index of the first grid point of the cell. So the ids
+// of the descendant cells of a cell c are within [c.RangeMin(), c.RangeMax()],
+// and the rows whose bounding boxes intersect a cell are the rows indexed by
+// the descendants or the ancestors of the cell.
+const (
+	spatialGridBits = 31
+	spatialMaxLevel = spatialGridBits
+	// spatialAsinhBound is larger than asinh(math.MaxFloat64), Cartesian
+	// coordinates are mapped to the grid by asinh to cover all the float values
+	// while keeping the precision around the origin.
+	spatialAsinhBound = 711
+
+	// SpatialIndexWriteCells is the max number of cells to cover a geometry
+	// value in the spatial index.
+	SpatialIndexWriteCells = 4
+	// SpatialIndexQueryCells is the max number of cells to cover the search
+	// area of a query.
+	SpatialIndexQueryCells = 16
+)
+
+// SpatialCell is a cell of the spatial index.
+type SpatialCell uint64
+
+func (c SpatialCell) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// Level returns the level of the cell, level 0 is the whole grid.
+func (c SpatialCell) Level() int {
+	return spatialMaxLevel - bits.TrailingZeros64(uint64(c))/2
+}
+
+// RangeMin returns the min id of the descendant cells.
+func (c SpatialCell) RangeMin() SpatialCell {
+	return c - SpatialCell(c.lsb()-1)
+}
+
+// RangeMax returns the max id of the descendant cells.
+func (c SpatialCell) RangeMax() SpatialCell {
+	return c + SpatialCell(c.lsb()-1)
+}
+
+// Parent returns the ancestor of the cell at the level.
+func (c SpatialCell) Parent(level int) SpatialCell {
+	lsb := uint64(1) << (2 * (spatialMaxLevel - level))
+	return SpatialCell(uint64(c)&-lsb | lsb)
+}
+
+// Datum returns the datum of the cell stored in the spatial index.
+func (c SpatialCell) Datum() types.Datum {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(c))
+	return types.NewBytesDatum(buf)
+}
+
+// hilbertIndex returns the position of the grid point on the Hilbert curve.
+func hilbertIndex(x, y uint32) uint64 {
+	var d uint64
+	for s := uint32(1) << (spatialGridBits - 1); s > 0; s >>= 1 {
+		var rx, ry uint32
+		if x&s != 0 {
+			rx = 1
+		}
+		if y&s != 0 {
+			ry = 1
+		}
+		d += uint64(s) * uint64(s) * uint64((3*rx)^ry)
+		if ry == 0 {
+			if rx == 1 {
+				x, y = s-1-x, s-1-y
+			}
+			x, y = y, x
+		}
+	}
+	return d
+}
+
+func spatialGridCoordinate(v, minV, maxV float64) uint32 {
+	const maxCoordinate = 1<<spatialGridBits - 1
+	t := (v - minV) / (maxV - minV) * (1 << spatialGridBits)
+	if !(t > 0) {
+		return 0
+	}
+	if t >= maxCoordinate {
+		return maxCoordinate
+	}
+	return uint32(t)
+}
+
+// spatialGridPoint maps the coordinates to the grid. The mapping is monotonic
+// on each axis, so a box is mapped to a box of grid points.
+func spatialGridPoint(geographic bool, x, y float64) (gx, gy uint32) {
+	if geographic {
+		return spatialGridCoordinate(x, -180, 180), spatialGridCoordinate(y, -90, 90)
+	}
+	return spatialGridCoordinate(math.Asinh(x), -spatialAsinhBound, spatialAsinhBound),
+		spatialGridCoordinate(math.Asinh(y), -spatialAsinhBound, spatialAsinhBound)
+}
+
+// SpatialCovering returns at most maxCells cells of the same level which cover
+// the box in the spatial reference system of the SRID. The cells are sorted.
+func SpatialCovering(srid uint32, box types.GeomBox, maxCells int) []SpatialCell {
+	srs, _ := types.GetSpatialReferenceSystem(srid)
+	geographic := srs != nil && srs.Geographic
+	x0, y0 := spatialGridPoint(geographic, box.MinX, box.MinY)
+	x1, y1 := spatialGridPoint(geographic, box.MaxX, box.MaxY)
+	// Use the finest level whose cells covering the box are no more than maxCells.
+	shift := 0
+	for ; shift < spatialGridBits; shift++ {
+		n := (uint64(x1>>shift-x0>>shift) + 1) * (uint64(y1>>shift-y0>>shift) + 1)
+		if n <= uint64(maxCells) {
+			break
+		}
+	}
+	lsb := uint64(1) << (2 * shift)
+	cells := make([]SpatialCell, 0, maxCells)
+	for x := x0 >> shift; x <= x1>>shift; x++ {
+		for y := y0 >> shift; y <= y1>>shift; y++ {
+			d := hilbertIndex(x<<shift, y<<shift) &^ (lsb - 1)
+			cells = append(cells, SpatialCell(d<<1|lsb))
+		}
+	}
+	slices.Sort(cells)
+	return cells
+}
+
+// SpatialIndexCells returns the cells of the geometry value in the storage
+// format stored in the spatial index. An empty geometry has no cells.
+func SpatialIndexCells(data []byte) ([]SpatialCell, error) {
+	g, err := types.ParseGeometry("", data)
+	if err != nil {
+		return nil, err
+	}
+	box, ok := g.BoundingBox()
+	if !ok {
+		return nil, nil
+	}
+	return SpatialCovering(g.SRID, box, SpatialIndexWriteCells), nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tablecodec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHilbertIndex(t *testing.T) {
+	// The Hilbert curve visits every point of the grid exactly once, and the
+	// adjacent points on the curve are adjacent on the grid.
+	const n = 8
+	points := make(map[uint64][2]uint32, n*n)
+	for x := range uint32(n) {
+		for y := range uint32(n) {
+			// Scale the points to the top-left corner of the whole grid.
+			shift := spatialGridBits - 3
+			d := hilbertIndex(x<<shift, y<<shift) >> (2 * shift)
+			require.Less(t, d, uint64(n*n))
+			_, ok := points[d]
+			require.False(t, ok)
+			points[d] = [2]uint32{x, y}
+		}
+	}
+	for d := uint64(1); d < n*n; d++ {
+		p, q := points[d-1], points[d]
+		dist := math.Abs(float64(p[0])-float64(q[0])) + math.Abs(float64(p[1])-float64(q[1]))
+		require.Equal(t, 1.0, dist)
+	}
+}
+
+func TestSpatialCell(t *testing.T) {
+	cells := SpatialCovering(0, types.GeomBox{MinX: 1, MinY: 2, MaxX: 1, MaxY: 2}, SpatialIndexWriteCells)
+	require.Len(t, cells, 1)
+	leaf := cells[0]
+	require.Equal(t, spatialMaxLevel, leaf.Level())
+	require.Equal(t, leaf, leaf.RangeMin())
+	require.Equal(t, leaf, leaf.RangeMax())
+	require.Equal(t, leaf, leaf.Parent(spatialMaxLevel))
+
+	root := leaf.Parent(0)
+	require.Equal(t, 0, root.Level())
+	require.Equal(t, SpatialCell(1), root.RangeMin())
+	require.Equal(t, SpatialCell(math.MaxUint64>>1), root.RangeMax())
+	for level := range spatialMaxLevel {
+		parent := leaf.Parent(level)
+		require.Equal(t, level, parent.Level())
+		require.LessOrEqual(t, parent.RangeMin(), leaf)
+		require.GreaterOrEqual(t, parent.RangeMax(), leaf)
+		require.Equal(t, parent, leaf.Parent(level+1).Parent(level))
+	}
+
+	d := leaf.Datum()
+	require.Equal(t, types.KindBytes, d.Kind())
+	require.Len(t, d.GetBytes(), 8)
+}
+
+func TestSpatialCovering(t *testing.T) {
+	boxes := []types.GeomBox{
+		{MinX: 0, MinY: 0, MaxX: 1, MaxY: 1},
+		{MinX: -10, MinY: -20, MaxX: 30, MaxY: 40},
+		{MinX: -1e300, MinY: 5, MaxX: 1e300, MaxY: 6},
+		{MinX: 1e-10, MinY: -1e-10, MaxX: 2e-10, MaxY: 1e-10},
+	}
+	for _, srid := range []uint32{0, 4326} {
+		for _, box := range boxes {
+			for _, maxCells := range []int{SpatialIndexWriteCells, SpatialIndexQueryCells} {
+				cells := SpatialCovering(srid, box, maxCells)
+				require.NotEmpty(t, cells)
+				require.LessOrEqual(t, len(cells), maxCells)
+				for i := 1; i < len(cells); i++ {
+					require.Less(t, cells[i-1], cells[i])
+					require.Equal(t, cells[0].Level(), cells[i].Level())
+				}
+				// The corners of the box are covered by the cells.
+				geographic := srid == 4326
+				for _, p := range [][2]float64{{box.MinX, box.MinY}, {box.MinX, box.MaxY}, {box.MaxX, box.MinY}, {box.MaxX, box.MaxY}} {
+					x, y := spatialGridPoint(geographic, p[0], p[1])
+					leaf := SpatialCell(hilbertIndex(x, y)<<1 | 1)
+					require.Contains(t, cells, leaf.Parent(cells[0].Level()))
+				}
+			}
+		}
+	}
+}
+
+func TestSpatialIndexCells(t *testing.T) {
+	g, err := types.ParseGeometryFromWKT("", "LINESTRING(0 0,10 10)", 0, types.AxisOrderSRIDDefined)
+	require.NoError(t, err)
+	cells, err := SpatialIndexCells(g.Encode())
+	require.NoError(t, err)
+	require.NotEmpty(t, cells)
+	require.LessOrEqual(t, len(cells), SpatialIndexWriteCells)
+
+	g, err = types.ParseGeometryFromWKT("", "GEOMETRYCOLLECTION EMPTY", 0, types.AxisOrderSRIDDefined)
+	require.NoError(t, err)
+	cells, err = SpatialIndexCells(g.Encode())
+	require.NoError(t, err)
+	require.Empty(t, cells)
+
+	_, err = SpatialIndexCells([]byte{1, 2, 3})
+	require.Error(t, err)
+}
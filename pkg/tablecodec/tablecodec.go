@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"math"
+	"slices"
 	"strings"
 	"time"
 	"unicode/utf8"
@@ -663,7 +664,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.GetCollate())
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -722,6 +723,7 @@ func EncodeIndexSeekKey(tableID int64, idxID int64, encodedValue []byte) kv.Key
 }
 
 // CutIndexKey cuts encoded index key into colIDs to bytes slices map.
+// Values of descending index columns are converted to the ascending encoded form.
 // The returned value b is the remaining bytes of the key which would be empty if it is unique index or handle data
 // if it is non-unique index.
 func CutIndexKey(key kv.Key, colIDs []int64) (values map[int64][]byte, b []byte, err error) {
@@ -729,7 +731,7 @@ func CutIndexKey(key kv.Key, colIDs []int64) (values map[int64][]byte, b []byte,
 	values = make(map[int64][]byte, len(colIDs))
 	for _, id := range colIDs {
 		var val []byte
-		val, b, err = codec.CutOne(b)
+		val, b, err = codec.CutOneAsc(b)
 		if err != nil {
 			return nil, nil, errors.Trace(err)
 		}
@@ -744,13 +746,14 @@ func CutIndexPrefix(key kv.Key) []byte {
 }
 
 // CutIndexKeyTo cuts encoded index key into colIDs to bytes slices.
+// Values of descending index columns are converted to the ascending encoded form.
 // The caller should prepare the memory of the result values.
 func CutIndexKeyTo(key kv.Key, values [][]byte) (b []byte, err error) {
 	b = key[prefixLen+idLen:]
 	length := len(values)
 	for i := 0; i < length; i++ {
 		var val []byte
-		val, b, err = codec.CutOne(b)
+		val, b, err = codec.CutOneAsc(b)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -903,6 +906,60 @@ func decodeRestoredValuesV5(columns []rowcodec.ColInfo, results [][]byte, restor
 	return newResults, nil
 }
 
+// cutIndexKeyColumns cuts the values of the index columns in the key. The included columns requested
+// after the index columns in columns[:colsLen] are stored in the restored data instead of the key,
+// so the index columns are the datums in the key except the handle columns following them.
+func cutIndexKeyColumns(key kv.Key, colsLen int, handleColsLen int) (values [][]byte, b []byte, err error) {
+	b = key[prefixLen+idLen:]
+	values = make([][]byte, 0, colsLen+handleColsLen)
+	for len(b) > 0 {
+		var val []byte
+		val, b, err = codec.CutOne(b)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		values = append(values, val)
+	}
+	keyColsLen := max(min(colsLen, len(values)-handleColsLen), 0)
+	suffixLen := 0
+	for _, val := range values[keyColsLen:] {
+		suffixLen += len(val)
+	}
+	return values[:keyColsLen], key[len(key)-suffixLen:], nil
+}
+
+// indexHandleColumnsLen returns the number of the handle columns in the index key.
+// The int handle is a single column even if it isn't requested, while the common handle
+// columns must be requested together with the included columns.
+func indexHandleColumnsLen(segs IndexValueSegments, colsLen int, columns []rowcodec.ColInfo, isIntHandle bool) int {
+	// The handle of a distinct unique index is stored in the value.
+	if segs.IntHandle != nil || segs.CommonHandle != nil {
+		return 0
+	}
+	idx := len(columns)
+	for idx > colsLen && columns[idx-1].ID == model.ExtraPhysTblID {
+		idx--
+	}
+	if idx == colsLen && isIntHandle {
+		return 1
+	}
+	return idx - colsLen
+}
+
+// decodeIncludedValues decodes the values of the included columns, which are requested after
+// the index columns, from the restored data by the column IDs.
+func decodeIncludedValues(columns []rowcodec.ColInfo, results [][]byte, restoredVal []byte) ([][]byte, error) {
+	if len(columns) == 0 {
+		return results, nil
+	}
+	rd := rowcodec.NewByteDecoder(columns, nil, nil, nil)
+	values, err := rd.DecodeToBytesNoHandle(buildColumnIDOffsets(columns), restoredVal)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(results, values...), nil
+}
+
 func buildColumnIDOffsets(allCols []rowcodec.ColInfo) map[int64]int {
 	colIDOffsets := make(map[int64]int, len(allCols))
 	for i, col := range allCols {
@@ -1185,6 +1242,10 @@ func GetIndexKeyBuf(buf []byte, defaultCap int) []byte {
 // GenIndexKey generates index key using input physical table id
 func GenIndexKey(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
 	phyTblID int64, indexedValues []types.Datum, h kv.Handle, buf []byte) (key []byte, distinct bool, err error) {
+	// The values of the included columns are only stored in the index value.
+	if idxInfo.HasIncludeColumns() && len(indexedValues) > len(idxInfo.Columns) {
+		indexedValues = indexedValues[:len(idxInfo.Columns)]
+	}
 	if idxInfo.Unique {
 		// See https://dev.mysql.com/doc/refman/5.7/en/create-index.html
 		// A UNIQUE index creates a constraint such that all values in the index must be distinct.
@@ -1204,7 +1265,7 @@ func GenIndexKey(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.In
 	key = GetIndexKeyBuf(buf, RecordRowKeyLen+len(indexedValues)*9+9)
 	key = appendTableIndexPrefix(key, phyTblID)
 	key = codec.EncodeInt(key, idxInfo.ID)
-	key, err = codec.EncodeKey(loc, key, indexedValues...)
+	key, err = EncodeIndexColumnValues(loc, key, idxInfo, indexedValues)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1222,6 +1283,27 @@ func GenIndexKey(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.In
 	return
 }
 
+// EncodeIndexColumnValues appends the encoded index column values to b.
+// The values of descending index columns are encoded in descending order.
+// values can be a prefix of the index columns.
+func EncodeIndexColumnValues(loc *time.Location, b []byte, idxInfo *model.IndexInfo, values []types.Datum) ([]byte, error) {
+	if !idxInfo.HasDescColumn() {
+		return codec.EncodeKey(loc, b, values...)
+	}
+	var err error
+	for i := range values {
+		if i < len(idxInfo.Columns) && idxInfo.Columns[i].Desc {
+			b, err = codec.EncodeKeyDesc(loc, b, values[i])
+		} else {
+			b, err = codec.EncodeKey(loc, b, values[i])
+		}
+		if err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
 // TempIndexPrefix used to generate temporary index ID from index ID.
 const TempIndexPrefix = 0x7fff000000000000
 
@@ -1528,12 +1610,15 @@ func TempIndexValueIsUntouched(b []byte) bool {
 //	|
 //	|     Common Handle Segment: Exists when unique index used common handles.
 //	|     Global Index Segment:  Exists when index is global.
-//	|     New Collation Segment: Exists when new collation is used and index or handle contains non-binary string.
+//	|     New Collation Segment: Exists when new collation is used and index or handle contains non-binary string,
+//	|                            or when the index has included columns.
 //	|     In v4.0, restored data contains all the index values. For example, (a int, b char(10)) and index (a, b).
 //	|     The restored data contains both the values of a and b.
 //	|     In v5.0, restored data contains only non-binary data(except for char and _bin). In the above example, the restored data contains only the value of b.
 //	|     Besides, if the collation of b is _bin, then restored data is an integer indicate the spaces are truncated. Then we use sortKey
 //	|     and the restored data together to restore original data.
+//	|     The values of the included columns are stored in the restored data as well, they aren't in the index key.
+//	|     In v4.0, the restored data contains all the index values if the index has included columns.
 func GenIndexValuePortal(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
 	needRestoredData bool, distinct bool, untouched bool, indexedValues []types.Datum, h kv.Handle,
 	partitionID int64, restoredData []types.Datum, buf []byte) ([]byte, error) {
@@ -1569,6 +1654,10 @@ func TryGetCommonPkColumnRestoredIds(tbl *model.TableInfo) []int64 {
 func GenIndexValueForClusteredIndexVersion1(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
 	idxValNeedRestoredData bool, distinct bool, untouched bool, indexedValues []types.Datum, h kv.Handle,
 	partitionID int64, handleRestoredData []types.Datum, buf []byte) ([]byte, error) {
+	indexedValues, includedValues, err := splitIncludedValues(idxInfo, indexedValues)
+	if err != nil {
+		return nil, err
+	}
 	var idxVal []byte
 	if buf == nil {
 		idxVal = make([]byte, 0)
@@ -1587,9 +1676,9 @@ func GenIndexValueForClusteredIndexVersion1(loc *time.Location, tblInfo *model.T
 	if idxInfo.Global {
 		idxVal = encodePartitionID(idxVal, partitionID)
 	}
-	if idxValNeedRestoredData || len(handleRestoredData) > 0 {
-		colIds := make([]int64, 0, len(idxInfo.Columns))
-		allRestoredData := make([]types.Datum, 0, len(handleRestoredData)+len(idxInfo.Columns))
+	if idxValNeedRestoredData || len(handleRestoredData) > 0 || len(includedValues) > 0 {
+		colIds := make([]int64, 0, len(idxInfo.Columns)+len(includedValues))
+		allRestoredData := make([]types.Datum, 0, len(handleRestoredData)+len(idxInfo.Columns)+len(includedValues))
 		for i, idxCol := range idxInfo.Columns {
 			col := tblInfo.Columns[idxCol.Offset]
 			// If  the column is the primary key's column,
@@ -1607,6 +1696,16 @@ func GenIndexValueForClusteredIndexVersion1(loc *time.Location, tblInfo *model.T
 			}
 		}
 
+		for i, idxCol := range idxInfo.IncludeColumns {
+			col := tblInfo.Columns[idxCol.Offset]
+			// The included primary key's columns are read from the handle.
+			if mysql.HasPriKeyFlag(col.GetFlag()) {
+				continue
+			}
+			colIds = append(colIds, col.ID)
+			allRestoredData = append(allRestoredData, includedValues[i])
+		}
+
 		if len(handleRestoredData) > 0 {
 			pkColIDs := TryGetCommonPkColumnRestoredIds(tblInfo)
 			colIds = append(colIds, pkColIDs...)
@@ -1614,7 +1713,6 @@ func GenIndexValueForClusteredIndexVersion1(loc *time.Location, tblInfo *model.T
 		}
 
 		rd := rowcodec.Encoder{Enable: true}
-		var err error
 		idxVal, err = rd.Encode(loc, colIds, allRestoredData, nil, idxVal)
 		if err != nil {
 			return nil, err
@@ -1634,6 +1732,10 @@ func GenIndexValueForClusteredIndexVersion1(loc *time.Location, tblInfo *model.T
 func genIndexValueVersion0(loc *time.Location, tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
 	idxValNeedRestoredData bool, distinct bool, untouched bool, indexedValues []types.Datum, h kv.Handle,
 	partitionID int64, buf []byte) ([]byte, error) {
+	indexedValues, includedValues, err := splitIncludedValues(idxInfo, indexedValues)
+	if err != nil {
+		return nil, err
+	}
 	var idxVal []byte
 	if buf == nil {
 		idxVal = make([]byte, 0)
@@ -1651,15 +1753,14 @@ func genIndexValueVersion0(loc *time.Location, tblInfo *model.TableInfo, idxInfo
 		idxVal = encodePartitionID(idxVal, partitionID)
 		newEncode = true
 	}
-	if idxValNeedRestoredData {
-		colIds := make([]int64, len(idxInfo.Columns))
-		for i, col := range idxInfo.Columns {
-			colIds[i] = tblInfo.Columns[col.Offset].ID
+	if idxValNeedRestoredData || len(includedValues) > 0 {
+		colIds := make([]int64, 0, len(idxInfo.Columns)+len(includedValues))
+		for _, col := range slices.Concat(idxInfo.Columns, idxInfo.IncludeColumns) {
+			colIds = append(colIds, tblInfo.Columns[col.Offset].ID)
 		}
 		rd := rowcodec.Encoder{Enable: true}
 		// Encode row restored value.
-		var err error
-		idxVal, err = rd.Encode(loc, colIds, indexedValues, nil, idxVal)
+		idxVal, err = rd.Encode(loc, colIds, slices.Concat(indexedValues, includedValues), nil, idxVal)
 		if err != nil {
 			return nil, err
 		}
@@ -1710,7 +1811,7 @@ func genIndexValueVersion0(loc *time.Location, tblInfo *model.TableInfo, idxInfo
 
 // TruncateIndexValues truncates the index values created using only the leading part of column values.
 func TruncateIndexValues(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, indexedValues []types.Datum) {
-	for i := 0; i < len(indexedValues); i++ {
+	for i := 0; i < len(indexedValues) && i < len(idxInfo.Columns); i++ {
 		idxCol := idxInfo.Columns[i]
 		tblCol := tblInfo.Columns[idxCol.Offset]
 		TruncateIndexValue(&indexedValues[i], idxCol, tblCol)
@@ -1775,6 +1876,18 @@ func encodePartitionID(idxVal []byte, partitionID int64) []byte {
 	return idxVal
 }
 
+// splitIncludedValues splits the values of the included columns, which follow
+// the values of the index columns, from the indexed values.
+func splitIncludedValues(idxInfo *model.IndexInfo, indexedValues []types.Datum) (indexed, included []types.Datum, err error) {
+	if !idxInfo.HasIncludeColumns() {
+		return indexedValues, nil, nil
+	}
+	if len(indexedValues) != len(idxInfo.Columns)+len(idxInfo.IncludeColumns) {
+		return nil, nil, errors.Errorf("the values of the included columns of index %s are missing", idxInfo.Name.O)
+	}
+	return indexedValues[:len(idxInfo.Columns)], indexedValues[len(idxInfo.Columns):], nil
+}
+
 // IndexValueSegments use to store result of SplitIndexValue.
 type IndexValueSegments struct {
 	CommonHandle   []byte
@@ -1849,12 +1962,21 @@ func decodeIndexKvForClusteredIndexVersion1(key, value []byte, colsLen int, hdSt
 	var handle kv.Handle
 	var err error
 	segs := splitIndexValueForClusteredIndexVersion1(value)
-	resultValues, keySuffix, err = CutIndexKeyNew(key, colsLen)
+	if segs.RestoredValues != nil {
+		resultValues, keySuffix, err = cutIndexKeyColumns(key, colsLen, indexHandleColumnsLen(segs, colsLen, columns, false))
+	} else {
+		resultValues, keySuffix, err = CutIndexKeyNew(key, colsLen)
+	}
 	if err != nil {
 		return nil, err
 	}
 	if segs.RestoredValues != nil {
-		resultValues, err = decodeRestoredValuesV5(columns[:colsLen], resultValues, segs.RestoredValues)
+		keyColsLen := len(resultValues)
+		resultValues, err = decodeRestoredValuesV5(columns[:keyColsLen], resultValues, segs.RestoredValues)
+		if err != nil {
+			return nil, err
+		}
+		resultValues, err = decodeIncludedValues(columns[keyColsLen:colsLen], resultValues, segs.RestoredValues)
 		if err != nil {
 			return nil, err
 		}
@@ -1899,11 +2021,17 @@ func decodeIndexKvGeneral(key, value []byte, colsLen int, hdStatus HandleStatus,
 	var handle kv.Handle
 	var err error
 	segs := splitIndexValueForIndexValueVersion0(value)
-	resultValues, keySuffix, err = CutIndexKeyNew(key, colsLen)
+	if segs.RestoredValues != nil {
+		// The index value version 0 is used by the tables without the clustered common handle.
+		resultValues, keySuffix, err = cutIndexKeyColumns(key, colsLen, indexHandleColumnsLen(segs, colsLen, columns, true))
+	} else {
+		resultValues, keySuffix, err = CutIndexKeyNew(key, colsLen)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if segs.RestoredValues != nil { // new collation
+	if segs.RestoredValues != nil { // new collation or included columns
+		// The restored data contains the values of all the index columns and the included columns.
 		resultValues, err = decodeRestoredValues(columns[:colsLen], segs.RestoredValues)
 		if err != nil {
 			return nil, err
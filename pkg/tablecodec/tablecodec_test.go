@@ -24,6 +24,8 @@ import (
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/keyspacepb"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
@@ -744,3 +746,76 @@ func TestV2TableCodec(t *testing.T) {
 	tbid = DecodeTableID(key)
 	require.Equal(t, int64(0), tbid)
 }
+
+func TestIndexValueWithIncludeColumns(t *testing.T) {
+	ft := types.NewFieldType(mysql.TypeLonglong)
+	pkFt := types.NewFieldType(mysql.TypeLonglong)
+	pkFt.AddFlag(mysql.PriKeyFlag)
+	tblInfo := &model.TableInfo{
+		ID: 1,
+		Columns: []*model.ColumnInfo{
+			{ID: 1, Name: ast.NewCIStr("id"), Offset: 0, FieldType: *pkFt},
+			{ID: 2, Name: ast.NewCIStr("a"), Offset: 1, FieldType: *ft},
+			{ID: 3, Name: ast.NewCIStr("b"), Offset: 2, FieldType: *ft},
+		},
+	}
+	idxInfo := &model.IndexInfo{
+		ID:             1,
+		Name:           ast.NewCIStr("idx"),
+		Columns:        []*model.IndexColumn{{Name: ast.NewCIStr("a"), Offset: 1, Length: types.UnspecifiedLength}},
+		IncludeColumns: []*model.IndexColumn{{Name: ast.NewCIStr("b"), Offset: 2, Length: types.UnspecifiedLength}},
+	}
+	// The values of the included columns follow the values of the index columns.
+	indexedValues := []types.Datum{types.NewIntDatum(10), types.NewIntDatum(20)}
+	keyCol, includeCol, handleCol := rowcodec.ColInfo{ID: 2, Ft: ft}, rowcodec.ColInfo{ID: 3, Ft: ft}, rowcodec.ColInfo{ID: 1, Ft: pkFt}
+	intHandle := kv.IntHandle(30)
+	encodedPK, err := codec.EncodeKey(time.UTC, nil, types.NewIntDatum(30))
+	require.NoError(t, err)
+	commonHandle, err := kv.NewCommonHandle(encodedPK)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		isCommonHandle bool
+		distinct       bool
+		handle         kv.Handle
+	}{
+		{false, false, intHandle},
+		{false, true, intHandle},
+		{true, false, commonHandle},
+		{true, true, commonHandle},
+	} {
+		tblInfo.IsCommonHandle, tblInfo.CommonHandleVersion = tc.isCommonHandle, 0
+		idxInfo.Unique = tc.distinct
+		if tc.isCommonHandle {
+			tblInfo.CommonHandleVersion = 1
+		}
+		key, _, err := GenIndexKey(time.UTC, tblInfo, idxInfo, tblInfo.ID, indexedValues, tc.handle, nil)
+		require.NoError(t, err)
+		value, err := GenIndexValuePortal(time.UTC, tblInfo, idxInfo, false, tc.distinct, false, indexedValues, tc.handle, 0, nil, nil)
+		require.NoError(t, err)
+
+		// The included columns are stored in the restored data and decoded by the column IDs.
+		segs := SplitIndexValue(value)
+		require.NotNil(t, segs.RestoredValues)
+		restored, err := decodeRestoredValues([]rowcodec.ColInfo{includeCol}, segs.RestoredValues)
+		require.NoError(t, err)
+		_, d, err := codec.DecodeOne(restored[0])
+		require.NoError(t, err)
+		require.Equal(t, int64(20), d.GetInt64())
+
+		values, err := DecodeIndexKV(key, value, 2, HandleDefault, []rowcodec.ColInfo{keyCol, includeCol, handleCol})
+		require.NoError(t, err)
+		require.Len(t, values, 3)
+		for i, expected := range []int64{10, 20, 30} {
+			_, d, err := codec.DecodeOne(values[i])
+			require.NoError(t, err)
+			require.Equal(t, expected, d.GetInt64())
+		}
+		values, err = DecodeIndexKV(key, value, 1, HandleNotNeeded, []rowcodec.ColInfo{keyCol})
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		_, d, err = codec.DecodeOne(values[0])
+		require.NoError(t, err)
+		require.Equal(t, int64(10), d.GetInt64())
+	}
+}
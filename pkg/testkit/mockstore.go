@@ -231,6 +231,19 @@ func CreateMockStoreAndDomain(t testing.TB, opts ...mockstore.MockTiKVStoreOptio
 	return store, dom
 }
 
+// RunTestUnderCascades runs the test with the cascades planner off and on, each time with a new mock store. The value
+// of tidb_enable_cascades_planner is passed to the test, so that it can load the expected results of the planner.
+func RunTestUnderCascades(t *testing.T, testFunc func(t *testing.T, tk *TestKit, cascades string), opts ...mockstore.MockTiKVStoreOption) {
+	for _, cascades := range []string{"off", "on"} {
+		t.Run("cascades="+cascades, func(t *testing.T) {
+			store := CreateMockStore(t, opts...)
+			tk := NewTestKit(t, store)
+			tk.MustExec("set @@tidb_enable_cascades_planner = " + cascades)
+			testFunc(t, tk, cascades)
+		})
+	}
+}
+
 func bootstrap4DistExecution(t testing.TB, store kv.Storage, lease time.Duration) *domain.Domain {
 	session.SetSchemaLease(lease)
 	session.DisableStats4Test()
@@ -50,8 +50,10 @@ type testCases struct {
 
 // TestData stores all the data of a test suite.
 type TestData struct {
-	input          []testCases
-	output         []testCases
+	input  []testCases
+	output []testCases
+	// cascadesOutput is the output of the test cases run with the cascades planner, it's loaded from the xut file.
+	cascadesOutput []testCases
 	filePathPrefix string
 	funcMap        map[string]int
 }
@@ -78,6 +80,25 @@ func loadTestSuiteData(dir, suiteName string) (res TestData, err error) {
 			return res, fmt.Errorf("Input name of the %d-case %s does not match output %s", i, test.Name, res.output[i].Name)
 		}
 	}
+	res.cascadesOutput, err = loadTestSuiteCases(fmt.Sprintf("%s_xut.json", res.filePathPrefix))
+	if os.IsNotExist(err) {
+		// The suite isn't run with the cascades planner yet, the names are kept for recording its output.
+		res.cascadesOutput = make([]testCases, 0, len(res.input))
+		for _, test := range res.input {
+			res.cascadesOutput = append(res.cascadesOutput, testCases{Name: test.Name})
+		}
+		return res, nil
+	} else if err != nil {
+		return res, err
+	}
+	if len(res.input) != len(res.cascadesOutput) {
+		return res, fmt.Errorf("Number of test input cases %d does not match test cascades output cases %d", len(res.input), len(res.cascadesOutput))
+	}
+	for i, test := range res.input {
+		if test.Name != res.cascadesOutput[i].Name {
+			return res, fmt.Errorf("Input name of the %d-case %s does not match cascades output %s", i, test.Name, res.cascadesOutput[i].Name)
+		}
+	}
 	return res, nil
 }
 
@@ -165,6 +186,20 @@ func (td *TestData) LoadTestCases(t *testing.T, in any, out any) {
 
 // LoadTestCasesByName loads the test cases for a test function by its name.
 func (td *TestData) LoadTestCasesByName(caseName string, t *testing.T, in any, out any) {
+	td.loadTestCasesByName(caseName, t, in, out, td.output)
+}
+
+// LoadTestCasesByNameUnderCascades loads the test cases for a test function run by testkit.RunTestUnderCascades, the
+// output is loaded from the xut file of the suite if the cascades planner is on.
+func (td *TestData) LoadTestCasesByNameUnderCascades(caseName string, t *testing.T, in any, out any, cascades string) {
+	output := td.output
+	if cascades == "on" {
+		output = td.cascadesOutput
+	}
+	td.loadTestCasesByName(caseName, t, in, out, output)
+}
+
+func (td *TestData) loadTestCasesByName(caseName string, t *testing.T, in any, out any, output []testCases) {
 	casesIdx, ok := td.funcMap[caseName]
 	require.Truef(t, ok, "Case name: %s", caseName)
 	require.NoError(t, json.Unmarshal(*td.input[casesIdx].Cases, in))
@@ -176,23 +211,30 @@ func (td *TestData) LoadTestCasesByName(caseName string, t *testing.T, in any, o
 			v.Set(reflect.MakeSlice(v.Type(), inputLen, inputLen))
 		}
 	} else {
-		require.NoError(t, json.Unmarshal(*td.output[casesIdx].Cases, out))
+		require.NotNilf(t, output[casesIdx].Cases, "Output of case name: %s", caseName)
+		require.NoError(t, json.Unmarshal(*output[casesIdx].Cases, out))
 	}
 
-	td.output[casesIdx].decodedOut = out
+	output[casesIdx].decodedOut = out
 }
 
 func (td *TestData) generateOutputIfNeeded() error {
 	if !record {
 		return nil
 	}
+	if err := generateOutput(td.output, fmt.Sprintf("%s_out.json", td.filePathPrefix)); err != nil {
+		return err
+	}
+	return generateOutput(td.cascadesOutput, fmt.Sprintf("%s_xut.json", td.filePathPrefix))
+}
 
+func generateOutput(output []testCases, filePath string) error {
 	buf := new(bytes.Buffer)
 	enc := json.NewEncoder(buf)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")
 	isRecord4ThisSuite := false
-	for i, test := range td.output {
+	for i, test := range output {
 		if test.decodedOut != nil {
 			// Only update the results for the related test cases.
 			isRecord4ThisSuite = true
@@ -204,18 +246,18 @@ func (td *TestData) generateOutputIfNeeded() error {
 			copy(res, buf.Bytes())
 			buf.Reset()
 			rm := json.RawMessage(res)
-			td.output[i].Cases = &rm
+			output[i].Cases = &rm
 		}
 	}
 	// Skip the record for the unrelated test files.
 	if !isRecord4ThisSuite {
 		return nil
 	}
-	err := enc.Encode(td.output)
+	err := enc.Encode(output)
 	if err != nil {
 		return err
 	}
-	file, err := os.Create(fmt.Sprintf("%s_out.json", td.filePathPrefix))
+	file, err := os.Create(filePath)
 	if err != nil {
 		return err
 	}
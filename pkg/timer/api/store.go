@@ -168,6 +168,8 @@ type TimerUpdate struct {
 	Tags OptionalVal[[]string]
 	// Enable indicates to set the timer's `Enable` field.
 	Enable OptionalVal[bool]
+	// Data indicates to set the timer's `Data` field.
+	Data OptionalVal[[]byte]
 	// TimeZone indicates to set the timer's `TimeZone` field.
 	TimeZone OptionalVal[string]
 	// SchedPolicyType indicates to set the timer's `SchedPolicyType` field.
@@ -218,6 +220,10 @@ func (u *TimerUpdate) apply(record *TimerRecord) (*TimerRecord, error) {
 		record.Enable = v
 	}
 
+	if v, ok := u.Data.Get(); ok {
+		record.Data = v
+	}
+
 	if v, ok := u.TimeZone.Get(); ok {
 		record.TimeZone = v
 		record.Location = getMemStoreTimeZoneLoc(record.TimeZone)
@@ -246,6 +246,7 @@ func TestTimerUpdate(t *testing.T) {
 	now := time.Now()
 	update = &TimerUpdate{
 		Enable:          NewOptionalVal(true),
+		Data:            NewOptionalVal([]byte("timerdata1")),
 		TimeZone:        NewOptionalVal("UTC"),
 		SchedPolicyType: NewOptionalVal(SchedEventInterval),
 		SchedPolicyExpr: NewOptionalVal("5h"),
@@ -273,6 +274,7 @@ func TestTimerUpdate(t *testing.T) {
 	record, err := update.apply(tm)
 	require.NoError(t, err)
 	require.True(t, record.Enable)
+	require.Equal(t, []byte("timerdata1"), record.Data)
 	require.Equal(t, "UTC", record.TimeZone)
 	require.Equal(t, time.UTC, record.Location)
 	require.Equal(t, SchedEventInterval, record.SchedPolicyType)
@@ -0,0 +1,147 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+)
+
+const (
+	// ArchiveFormatCSV archives the expired rows to CSV files.
+	ArchiveFormatCSV = "csv"
+	// ArchiveFormatParquet archives the expired rows to parquet files.
+	ArchiveFormatParquet = "parquet"
+
+	archiveFormatParam = "format"
+)
+
+// ArchiveTarget is the parsed `TTL_ARCHIVE_TARGET` of a table.
+type ArchiveTarget struct {
+	// Schema and Table are set if the expired rows are archived to a table.
+	Schema ast.CIStr
+	Table  ast.CIStr
+	// StorageURL is set if the expired rows are archived to an external storage.
+	// The `format` parameter is removed from it.
+	StorageURL string
+	// Format is the format of the files in the external storage.
+	Format string
+}
+
+// IsStorage returns whether the expired rows are archived to an external storage.
+func (t *ArchiveTarget) IsStorage() bool {
+	return t.StorageURL != ""
+}
+
+// ParseArchiveTarget parses the `TTL_ARCHIVE_TARGET` of a table. A target with a scheme such as `s3://bucket/prefix`
+// is an external storage, and the file format is specified by the `format` parameter which is `csv` by default.
+// Otherwise, the target is the name of a table and the schema of the TTL table is used if it is omitted.
+func ParseArchiveTarget(target string, defaultSchema ast.CIStr) (*ArchiveTarget, error) {
+	if strings.Contains(target, "://") {
+		return parseArchiveStorage(target)
+	}
+
+	names, err := splitArchiveTableName(target)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 1 {
+		return &ArchiveTarget{Schema: defaultSchema, Table: ast.NewCIStr(names[0])}, nil
+	}
+	return &ArchiveTarget{Schema: ast.NewCIStr(names[0]), Table: ast.NewCIStr(names[1])}, nil
+}
+
+func parseArchiveStorage(target string) (*ArchiveTarget, error) {
+	u, err := storage.ParseRawURL(target)
+	if err != nil {
+		return nil, err
+	}
+
+	query := u.Query()
+	format := strings.ToLower(query.Get(archiveFormatParam))
+	switch format {
+	case "":
+		format = ArchiveFormatCSV
+	case ArchiveFormatCSV, ArchiveFormatParquet:
+	default:
+		return nil, errors.Errorf("unsupported format '%s'", format)
+	}
+	query.Del(archiveFormatParam)
+	u.RawQuery = query.Encode()
+
+	storageURL := u.String()
+	if _, err = storage.ParseBackend(storageURL, nil); err != nil {
+		return nil, err
+	}
+	return &ArchiveTarget{StorageURL: storageURL, Format: format}, nil
+}
+
+// splitArchiveTableName splits a table name like `db.tbl`, `tbl` or "`db`.`tbl`".
+func splitArchiveTableName(target string) ([]string, error) {
+	var names []string
+	var sb strings.Builder
+	quoted, afterQuote := false, false
+	for i := 0; i < len(target); i++ {
+		c := target[i]
+		switch {
+		case c == '`' && quoted && i+1 < len(target) && target[i+1] == '`':
+			sb.WriteByte(c)
+			i++
+		case c == '`' && quoted:
+			quoted, afterQuote = false, true
+		case c == '`' && sb.Len() == 0 && !afterQuote:
+			quoted = true
+		case c == '.' && !quoted:
+			names = append(names, sb.String())
+			sb.Reset()
+			afterQuote = false
+		case afterQuote || c == '`':
+			return nil, errors.Errorf("invalid table name '%s'", target)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	if quoted {
+		return nil, errors.Errorf("invalid table name '%s'", target)
+	}
+	names = append(names, sb.String())
+
+	if len(names) > 2 {
+		return nil, errors.Errorf("invalid table name '%s'", target)
+	}
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" {
+			return nil, errors.Errorf("invalid table name '%s'", target)
+		}
+	}
+	return names, nil
+}
+
+// ArchiveColumns returns the columns of the TTL table which are copied to the archive target.
+// The generated columns are excluded because they are generated again in the target table.
+func ArchiveColumns(tbl *model.TableInfo) []*model.ColumnInfo {
+	columns := make([]*model.ColumnInfo, 0, len(tbl.Columns))
+	for _, col := range tbl.Columns {
+		if col.State != model.StatePublic || col.IsGenerated() {
+			continue
+		}
+		columns = append(columns, col)
+	}
+	return columns
+}
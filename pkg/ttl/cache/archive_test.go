@@ -0,0 +1,81 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseArchiveTarget(t *testing.T) {
+	defaultSchema := ast.NewCIStr("test")
+	cases := []struct {
+		target string
+		schema string
+		table  string
+		url    string
+		format string
+		err    bool
+	}{
+		{target: "t_archive", schema: "test", table: "t_archive"},
+		{target: "db1.t_archive", schema: "db1", table: "t_archive"},
+		{target: "`db.1`.`t``a`", schema: "db.1", table: "t`a"},
+		{target: "`db1`.t_archive", schema: "db1", table: "t_archive"},
+		{target: "s3://bucket/prefix", url: "s3://bucket/prefix", format: cache.ArchiveFormatCSV},
+		{target: "s3://bucket/prefix?format=PARQUET&endpoint=http%3A%2F%2F127.0.0.1", url: "s3://bucket/prefix?endpoint=http%3A%2F%2F127.0.0.1", format: cache.ArchiveFormatParquet},
+		{target: "local:///tmp/archive?format=csv", url: "local:///tmp/archive", format: cache.ArchiveFormatCSV},
+		{target: "s3://bucket/prefix?format=json", err: true},
+		{target: "unknown://bucket/prefix", err: true},
+		{target: "", err: true},
+		{target: "db1.", err: true},
+		{target: "a.b.c", err: true},
+		{target: "`db1", err: true},
+		{target: "`db1`x.t", err: true},
+	}
+
+	for _, c := range cases {
+		target, err := cache.ParseArchiveTarget(c.target, defaultSchema)
+		if c.err {
+			require.Error(t, err, c.target)
+			continue
+		}
+		require.NoError(t, err, c.target)
+		require.Equal(t, c.url != "", target.IsStorage(), c.target)
+		require.Equal(t, c.url, target.StorageURL, c.target)
+		require.Equal(t, c.format, target.Format, c.target)
+		require.Equal(t, c.schema, target.Schema.O, c.target)
+		require.Equal(t, c.table, target.Table.O, c.target)
+	}
+}
+
+func TestArchiveColumns(t *testing.T) {
+	tbl := &model.TableInfo{
+		Columns: []*model.ColumnInfo{
+			{Name: ast.NewCIStr("id"), State: model.StatePublic},
+			{Name: ast.NewCIStr("v"), State: model.StatePublic, GeneratedExprString: "`id` + 1"},
+			{Name: ast.NewCIStr("t"), State: model.StatePublic},
+			{Name: ast.NewCIStr("new"), State: model.StateWriteOnly},
+		},
+	}
+
+	cols := cache.ArchiveColumns(tbl)
+	require.Len(t, cols, 2)
+	require.Equal(t, "id", cols[0].Name.O)
+	require.Equal(t, "t", cols[1].Name.O)
+}
@@ -197,8 +197,13 @@ func SetMockExpireTime(ctx context.Context, tm time.Time) context.Context {
 	return context.WithValue(ctx, mockExpireTimeKey{}, tm)
 }
 
-// EvalExpireTime returns the expired time.
+// EvalExpireTime returns the expired time. If the interval is empty, the TTL column stores the expiry time of each row
+// and the expired time is the current time.
 func EvalExpireTime(now time.Time, interval string, unit ast.TimeUnitType) (time.Time, error) {
+	if interval == "" {
+		return now.Truncate(time.Second), nil
+	}
+
 	// Firstly, we should use the UTC time zone to compute the expired time to avoid time shift caused by DST.
 	// The start time should be a time with the same datetime string as `now` but it is in the UTC timezone.
 	// For example, if global timezone is `Asia/Shanghai` with a string format `2020-01-01 08:00:00 +0800`.
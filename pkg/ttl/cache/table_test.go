@@ -284,6 +284,12 @@ func TestEvalTTLExpireTime(t *testing.T) {
 	require.Equal(t, "2023-01-01 15:00:01.000000", tm.Format("2006-01-02 15:04:05.000000"))
 	require.Same(t, time.UTC, tm.Location())
 
+	// the current time is the expired time for the per-row expiry column
+	tm, err = cache.EvalExpireTime(now, "", ast.TimeUnitDay)
+	require.NoError(t, err)
+	require.Equal(t, "2023-01-02 15:00:01.000000", tm.Format("2006-01-02 15:04:05.000000"))
+	require.Same(t, time.UTC, tm.Location())
+
 	// test for string interval format
 	tm, err = cache.EvalExpireTime(time.Unix(0, 0).In(tzBerlin), "'1:3'", ast.TimeUnitHourMinute)
 	require.NoError(t, err)
@@ -114,6 +114,10 @@ type TTLTaskState struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	// ArchivedRows is the count of the rows archived before deletion, it's a part of SuccessRows.
+	ArchivedRows uint64 `json:"archived_rows,omitempty"`
+	// FilteredRows is the count of the expired rows kept because they don't match the TTL condition.
+	FilteredRows uint64 `json:"filtered_rows,omitempty"`
 
 	ScanTaskErr string `json:"scan_task_err"`
 
@@ -46,6 +46,8 @@ var (
 	ScannedExpiredRows       prometheus.Counter
 	DeleteSuccessExpiredRows prometheus.Counter
 	DeleteErrorExpiredRows   prometheus.Counter
+	ArchivedExpiredRows      prometheus.Counter
+	FilteredExpiredRows      prometheus.Counter
 
 	RunningJobsCnt    prometheus.Gauge
 	CancellingJobsCnt prometheus.Gauge
@@ -113,6 +115,10 @@ func InitMetricsVars() {
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblOK})
 	DeleteErrorExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
 		prometheus.Labels{metrics.LblSQLType: "delete", metrics.LblResult: metrics.LblError})
+	ArchivedExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
+		prometheus.Labels{metrics.LblSQLType: "archive", metrics.LblResult: metrics.LblOK})
+	FilteredExpiredRows = metrics.TTLProcessedExpiredRowsCounter.With(
+		prometheus.Labels{metrics.LblSQLType: "select", metrics.LblResult: "filtered"})
 
 	RunningJobsCnt = metrics.TTLJobStatus.With(prometheus.Labels{metrics.LblType: "running"})
 	CancellingJobsCnt = metrics.TTLJobStatus.With(prometheus.Labels{metrics.LblType: "cancelling"})
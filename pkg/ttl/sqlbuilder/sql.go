@@ -82,6 +82,7 @@ type SQLBuilder struct {
 
 	isReadOnly         bool
 	hasWriteExpireCond bool
+	hasWriteTTLCond    bool
 }
 
 // NewSQLBuilder creates a new TTLSQLBuilder
@@ -102,6 +103,11 @@ func (b *SQLBuilder) Build() (string, error) {
 		return "", errors.New("expire condition not write")
 	}
 
+	if !b.isReadOnly && b.ttlCondition() != "" && !b.hasWriteTTLCond {
+		// check whether the TTL condition has been written to make sure the rows not matching it are kept.
+		return "", errors.New("TTL condition not write")
+	}
+
 	if b.state != writeDone {
 		b.state = writeDone
 	}
@@ -111,11 +117,64 @@ func (b *SQLBuilder) Build() (string, error) {
 
 // WriteSelect writes a select statement to select key columns without any condition
 func (b *SQLBuilder) WriteSelect() error {
+	return b.WriteSelectColumns(b.tbl.KeyColumns)
+}
+
+// WriteSelectColumns writes a select statement to select the specified columns without any condition
+func (b *SQLBuilder) WriteSelectColumns(cols []*model.ColumnInfo) error {
+	if b.state != writeBegin {
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+	if err := b.writeSelectFrom(cols, false); err != nil {
+		return err
+	}
+	b.state = writeSelOrDel
+	b.isReadOnly = true
+	return nil
+}
+
+// WriteSelectWithTTLCondition writes a select statement to select key columns and whether the row matches the TTL
+// condition as the last column without any condition. It's the same as `WriteSelect` if the table has no TTL condition.
+func (b *SQLBuilder) WriteSelectWithTTLCondition() error {
 	if b.state != writeBegin {
 		return errors.Errorf("invalid state: %v", b.state)
 	}
+	if err := b.writeSelectFrom(b.tbl.KeyColumns, true); err != nil {
+		return err
+	}
+	b.state = writeSelOrDel
+	b.isReadOnly = true
+	return nil
+}
+
+// WriteInsertSelect writes a statement to copy the specified columns to the target table without any condition
+func (b *SQLBuilder) WriteInsertSelect(targetSchema, targetTable ast.CIStr, cols []*model.ColumnInfo) error {
+	if b.state != writeBegin {
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+	b.restoreCtx.WritePlain("INSERT INTO ")
+	tn := ast.TableName{Schema: targetSchema, Name: targetTable}
+	if err := tn.Restore(b.restoreCtx); err != nil {
+		return err
+	}
+	b.restoreCtx.WritePlain(" ")
+	b.writeColNames(cols, true)
+	b.restoreCtx.WritePlain(" ")
+	if err := b.writeSelectFrom(cols, false); err != nil {
+		return err
+	}
+	b.state = writeSelOrDel
+	return nil
+}
+
+func (b *SQLBuilder) writeSelectFrom(cols []*model.ColumnInfo, withTTLCond bool) error {
 	b.restoreCtx.WritePlain("SELECT LOW_PRIORITY SQL_NO_CACHE ")
-	b.writeColNames(b.tbl.KeyColumns, false)
+	b.writeColNames(cols, false)
+	if cond := b.ttlCondition(); withTTLCond && cond != "" {
+		b.restoreCtx.WritePlain(", (")
+		b.restoreCtx.WritePlain(cond)
+		b.restoreCtx.WritePlain(") IS TRUE")
+	}
 	b.restoreCtx.WritePlain(" FROM ")
 	if err := b.writeTblName(); err != nil {
 		return err
@@ -125,8 +184,6 @@ func (b *SQLBuilder) WriteSelect() error {
 		b.restoreCtx.WriteName(par.Name.O)
 		b.restoreCtx.WritePlain(")")
 	}
-	b.state = writeSelOrDel
-	b.isReadOnly = true
 	return nil
 }
 
@@ -188,6 +245,30 @@ func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	return nil
 }
 
+// WriteTTLCondition writes the `WHERE` condition of the TTL config if it exists
+func (b *SQLBuilder) WriteTTLCondition() error {
+	cond := b.ttlCondition()
+	if cond == "" {
+		return nil
+	}
+
+	switch b.state {
+	case writeSelOrDel:
+		b.restoreCtx.WritePlain(" WHERE ")
+		b.state = writeWhere
+	case writeWhere:
+		b.restoreCtx.WritePlain(" AND ")
+	default:
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+
+	b.restoreCtx.WritePlain("(")
+	b.restoreCtx.WritePlain(cond)
+	b.restoreCtx.WritePlain(")")
+	b.hasWriteTTLCond = true
+	return nil
+}
+
 // WriteInCondition writes an IN condition
 func (b *SQLBuilder) WriteInCondition(cols []*model.ColumnInfo, dps ...[]types.Datum) error {
 	switch b.state {
@@ -245,6 +326,14 @@ func (b *SQLBuilder) WriteLimit(n int) error {
 	return nil
 }
 
+// ttlCondition returns the `WHERE` condition of the TTL config, it's empty if the table has no TTL condition.
+func (b *SQLBuilder) ttlCondition() string {
+	if b.tbl.TTLInfo == nil {
+		return ""
+	}
+	return b.tbl.TTLInfo.Condition
+}
+
 func (b *SQLBuilder) writeTblName() error {
 	tn := ast.TableName{Schema: b.tbl.Schema, Name: b.tbl.Name}
 	return tn.Restore(b.restoreCtx)
@@ -409,7 +498,9 @@ func (g *ScanQueryGenerator) buildSQL() (string, error) {
 	}
 
 	b := NewSQLBuilder(g.tbl)
-	if err := b.WriteSelect(); err != nil {
+	// The rows not matching the TTL condition are also selected to continue the scan from the last row, they are
+	// filtered out by the result of the condition in the last column.
+	if err := b.WriteSelectWithTTLCondition(); err != nil {
 		return "", err
 	}
 	if len(g.stack) > 0 {
@@ -474,9 +565,73 @@ func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.
 		return "", err
 	}
 
+	if err := b.WriteTTLCondition(); err != nil {
+		return "", err
+	}
+
 	if err := b.WriteLimit(len(rows)); err != nil {
 		return "", err
 	}
 
 	return b.Build()
 }
+
+// BuildArchiveInsertSQL builds a SQL to copy the expired rows to the archive table
+func BuildArchiveInsertSQL(tbl *cache.PhysicalTable, target *cache.ArchiveTarget, cols []*model.ColumnInfo, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive SQL with empty rows")
+	}
+
+	if target.IsStorage() {
+		return "", errors.New("Cannot build archive SQL for an external storage")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteInsertSelect(target.Schema, target.Table, cols); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteTTLCondition(); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
+
+// BuildArchiveSelectSQL builds a SQL to select the expired rows which are archived to an external storage
+func BuildArchiveSelectSQL(tbl *cache.PhysicalTable, cols []*model.ColumnInfo, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteSelectColumns(cols); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteTTLCondition(); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteOrderBy(tbl.KeyColumns, false); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
@@ -930,6 +930,112 @@ func TestBuildDeleteSQL(t *testing.T) {
 	}
 }
 
+func TestBuildArchiveSQL(t *testing.T) {
+	t1 := &cache.PhysicalTable{
+		Schema: ast.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name: ast.NewCIStr("t1"),
+		},
+		KeyColumns: []*model.ColumnInfo{
+			{Name: ast.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeInt24)},
+		},
+		TimeColumn: &model.ColumnInfo{
+			Name:      ast.NewCIStr("time"),
+			FieldType: *types.NewFieldType(mysql.TypeDatetime),
+		},
+	}
+
+	t2 := &cache.PhysicalTable{
+		Schema: ast.NewCIStr("test2"),
+		TableInfo: &model.TableInfo{
+			Name: ast.NewCIStr("t2"),
+		},
+		Partition:    ast.NewCIStr("p0"),
+		PartitionDef: &model.PartitionDefinition{Name: ast.NewCIStr("p0")},
+		KeyColumns: []*model.ColumnInfo{
+			{Name: ast.NewCIStr("a"), FieldType: *types.NewFieldType(mysql.TypeInt24)},
+			{Name: ast.NewCIStr("b"), FieldType: *types.NewFieldType(mysql.TypeVarchar)},
+		},
+		TimeColumn: &model.ColumnInfo{
+			Name:      ast.NewCIStr("time"),
+			FieldType: *types.NewFieldType(mysql.TypeDatetime),
+		},
+	}
+
+	cols1 := []*model.ColumnInfo{t1.KeyColumns[0], t1.TimeColumn, {Name: ast.NewCIStr("v")}}
+	cols2 := []*model.ColumnInfo{t2.KeyColumns[0], t2.KeyColumns[1], t2.TimeColumn}
+	target := &cache.ArchiveTarget{Schema: ast.NewCIStr("archive"), Table: ast.NewCIStr("t_archive")}
+	expire := time.UnixMilli(0).In(time.UTC)
+
+	sql, err := sqlbuilder.BuildArchiveInsertSQL(t1, target, cols1, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `archive`.`t_archive` (`id`, `time`, `v`) SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `time`, `v` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0)", sql)
+
+	sql, err = sqlbuilder.BuildArchiveInsertSQL(t2, target, cols2, [][]types.Datum{d(1, "a")}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `archive`.`t_archive` (`a`, `b`, `time`) SELECT LOW_PRIORITY SQL_NO_CACHE `a`, `b`, `time` FROM `test2`.`t2` PARTITION(`p0`) WHERE (`a`, `b`) IN ((1, 'a')) AND `time` < FROM_UNIXTIME(0)", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSelectSQL(t1, cols1, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `time`, `v` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) ORDER BY `id` ASC", sql)
+
+	_, err = sqlbuilder.BuildArchiveInsertSQL(t1, target, cols1, nil, expire)
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildArchiveInsertSQL(t1, &cache.ArchiveTarget{StorageURL: "s3://bucket/prefix"}, cols1, [][]types.Datum{d(1)}, expire)
+	require.Error(t, err)
+	_, err = sqlbuilder.BuildArchiveSelectSQL(t1, cols1, nil, expire)
+	require.Error(t, err)
+}
+
+func TestBuildSQLWithTTLCondition(t *testing.T) {
+	tbl := &cache.PhysicalTable{
+		Schema: ast.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name:    ast.NewCIStr("t1"),
+			TTLInfo: &model.TTLInfo{ColumnName: ast.NewCIStr("expire_at"), Condition: "`status` = _utf8mb4'closed'"},
+		},
+		KeyColumns: []*model.ColumnInfo{
+			{Name: ast.NewCIStr("id"), FieldType: *types.NewFieldType(mysql.TypeInt24)},
+		},
+		TimeColumn: &model.ColumnInfo{
+			Name:      ast.NewCIStr("expire_at"),
+			FieldType: *types.NewFieldType(mysql.TypeDatetime),
+		},
+	}
+	cols := []*model.ColumnInfo{tbl.KeyColumns[0], tbl.TimeColumn}
+	target := &cache.ArchiveTarget{Schema: ast.NewCIStr("archive"), Table: ast.NewCIStr("t_archive")}
+	expire := time.UnixMilli(0).In(time.UTC)
+
+	// the rows not matching the condition are also scanned to continue the scan from the last row
+	g, err := sqlbuilder.NewScanQueryGenerator(tbl, expire, nil, nil)
+	require.NoError(t, err)
+	sql, err := g.NextSQL(nil, 3)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, (`status` = _utf8mb4'closed') IS TRUE FROM `test`.`t1` WHERE `expire_at` < FROM_UNIXTIME(0) ORDER BY `id` ASC LIMIT 3", sql)
+	sql, err = g.NextSQL([][]types.Datum{d(1), d(2), d(3)}, 3)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, (`status` = _utf8mb4'closed') IS TRUE FROM `test`.`t1` WHERE `id` > 3 AND `expire_at` < FROM_UNIXTIME(0) ORDER BY `id` ASC LIMIT 3", sql)
+
+	sql, err = sqlbuilder.BuildDeleteSQL(tbl, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `id` IN (1, 2) AND `expire_at` < FROM_UNIXTIME(0) AND (`status` = _utf8mb4'closed') LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveInsertSQL(tbl, target, cols, [][]types.Datum{d(1)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT INTO `archive`.`t_archive` (`id`, `expire_at`) SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `expire_at` FROM `test`.`t1` WHERE `id` IN (1) AND `expire_at` < FROM_UNIXTIME(0) AND (`status` = _utf8mb4'closed')", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSelectSQL(tbl, cols, [][]types.Datum{d(1)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id`, `expire_at` FROM `test`.`t1` WHERE `id` IN (1) AND `expire_at` < FROM_UNIXTIME(0) AND (`status` = _utf8mb4'closed') ORDER BY `id` ASC", sql)
+
+	// the delete SQL must contain the TTL condition
+	b := sqlbuilder.NewSQLBuilder(tbl)
+	require.NoError(t, b.WriteDelete())
+	require.NoError(t, b.WriteExpireCondition(expire))
+	_, err = b.Build()
+	require.EqualError(t, err, "TTL condition not write")
+}
+
 func d(vs ...any) []types.Datum {
 	datums := make([]types.Datum, len(vs))
 	for i, v := range vs {
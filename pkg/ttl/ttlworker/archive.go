@@ -0,0 +1,219 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/sqlbuilder"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// archiveNullValue is the value of NULL in the archived CSV files.
+const archiveNullValue = `\N`
+
+// ttlArchiver copies the expired rows to the archive target of a TTL table before they are deleted.
+type ttlArchiver struct {
+	tbl     *cache.PhysicalTable
+	jobID   string
+	target  *cache.ArchiveTarget
+	columns []*model.ColumnInfo
+	store   storage.ExternalStorage
+}
+
+func newTTLArchiver(ctx context.Context, tbl *cache.PhysicalTable, jobID string) (*ttlArchiver, error) {
+	target, err := cache.ParseArchiveTarget(tbl.TTLInfo.ArchiveTarget, tbl.Schema)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &ttlArchiver{
+		tbl:     tbl,
+		jobID:   jobID,
+		target:  target,
+		columns: cache.ArchiveColumns(tbl.TableInfo),
+	}
+	if target.IsStorage() {
+		backend, err := storage.ParseBackend(target.StorageURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		if a.store, err = storage.NewWithDefaultOpt(ctx, backend); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// ArchiveAndDelete archives the expired rows and deletes them in one transaction. The rows are never deleted if they
+// are not archived successfully. For a table target, the rows are copied by `INSERT ... SELECT` in the same transaction
+// as the deletion. For an external storage, the rows are written to a file named by the batch, so a retried batch
+// overwrites the file written by the failed attempt.
+// It returns the count of the archived rows, and whether the batch should be retried if an error occurs.
+func (a *ttlArchiver) ArchiveAndDelete(ctx context.Context, se *ttlTableSession, rows [][]types.Datum, deleteSQL string) (int, bool, error) {
+	if a.target.IsStorage() {
+		return a.archiveToStorageAndDelete(ctx, se, rows, deleteSQL)
+	}
+
+	sql, err := sqlbuilder.BuildArchiveInsertSQL(a.tbl, a.target, a.columns, rows, se.expire)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var archived int
+	needRetry, err := se.RunInTxnWithCheck(ctx, func() error {
+		if _, err := se.ExecuteSQL(ctx, sql); err != nil {
+			return err
+		}
+		archived = int(se.GetSessionVars().StmtCtx.AffectedRows())
+		_, err := se.ExecuteSQL(ctx, deleteSQL)
+		return err
+	})
+	if err != nil {
+		return 0, needRetry, err
+	}
+	return archived, false, nil
+}
+
+func (a *ttlArchiver) archiveToStorageAndDelete(ctx context.Context, se *ttlTableSession, rows [][]types.Datum, deleteSQL string) (int, bool, error) {
+	sql, err := sqlbuilder.BuildArchiveSelectSQL(a.tbl, a.columns, rows, se.expire)
+	if err != nil {
+		return 0, false, err
+	}
+
+	var archived int
+	needRetry, err := se.RunInTxnWithCheck(ctx, func() error {
+		expiredRows, err := se.ExecuteSQL(ctx, sql)
+		if err != nil {
+			return err
+		}
+		if len(expiredRows) > 0 {
+			if err = a.writeFile(ctx, a.fileName(sql), expiredRows); err != nil {
+				return err
+			}
+		}
+		archived = len(expiredRows)
+		_, err = se.ExecuteSQL(ctx, deleteSQL)
+		return err
+	})
+	if err != nil {
+		return 0, needRetry, err
+	}
+	return archived, false, nil
+}
+
+// fileName returns the name of the file for a batch. The select SQL contains the keys of the rows and the expire time,
+// so the name is stable when the same batch is retried.
+func (a *ttlArchiver) fileName(sql string) string {
+	sum := sha256.Sum256([]byte(sql))
+	name := fmt.Sprintf("%s.%s", a.tbl.Schema.O, a.tbl.Name.O)
+	if a.tbl.Partition.L != "" {
+		name = fmt.Sprintf("%s.%s", name, a.tbl.Partition.O)
+	}
+	return fmt.Sprintf("%s/%s/%s.%s", name, a.jobID, hex.EncodeToString(sum[:16]), a.target.Format)
+}
+
+func (a *ttlArchiver) writeFile(ctx context.Context, name string, rows []chunk.Row) error {
+	var buf bytes.Buffer
+	var err error
+	switch a.target.Format {
+	case cache.ArchiveFormatParquet:
+		err = a.encodeParquet(&buf, rows)
+	default:
+		err = a.encodeCSV(&buf, rows)
+	}
+	if err != nil {
+		return err
+	}
+	return a.store.WriteFile(ctx, name, buf.Bytes())
+}
+
+func (a *ttlArchiver) encodeCSV(buf *bytes.Buffer, rows []chunk.Row) error {
+	w := csv.NewWriter(buf)
+	record := make([]string, len(a.columns))
+	for i, col := range a.columns {
+		record[i] = col.Name.O
+	}
+	if err := w.Write(record); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, row := range rows {
+		for i, col := range a.columns {
+			val, err := a.columnValue(row, i, col)
+			if err != nil {
+				return err
+			}
+			if val == nil {
+				record[i] = archiveNullValue
+			} else {
+				record[i] = *val
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
+
+func (a *ttlArchiver) encodeParquet(buf *bytes.Buffer, rows []chunk.Row) error {
+	md := make([]string, len(a.columns))
+	for i, col := range a.columns {
+		md[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", col.Name.O)
+	}
+	w, err := writer.NewCSVWriterFromWriter(md, buf, 1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	record := make([]*string, len(a.columns))
+	for _, row := range rows {
+		for i, col := range a.columns {
+			if record[i], err = a.columnValue(row, i, col); err != nil {
+				return err
+			}
+		}
+		if err = w.WriteString(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(w.WriteStop())
+}
+
+// columnValue returns the string value of a column in the selected row, it returns nil for NULL.
+func (*ttlArchiver) columnValue(row chunk.Row, idx int, col *model.ColumnInfo) (*string, error) {
+	if row.IsNull(idx) {
+		return nil, nil
+	}
+	d := row.GetDatum(idx, &col.FieldType)
+	val, err := d.ToString()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &val, nil
+}
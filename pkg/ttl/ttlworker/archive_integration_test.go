@@ -0,0 +1,166 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/ttlworker"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTTLArchiveDDL(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t_archive(id int primary key, t datetime, v varchar(32))")
+
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime) TTL_ACTION='ARCHIVE'",
+		"[ddl:8150]Cannot set TTL_ACTION on a table without TTL config")
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime) TTL_ARCHIVE_TARGET='t_archive'",
+		"[ddl:8150]Cannot set TTL_ARCHIVE_TARGET on a table without TTL config")
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE'",
+		"[ddl:8266]Invalid TTL_ARCHIVE_TARGET '': TTL_ARCHIVE_TARGET is required when TTL_ACTION is 'ARCHIVE'")
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE' TTL_ARCHIVE_TARGET='t_not_exist'",
+		"[ddl:8266]Invalid TTL_ARCHIVE_TARGET 't_not_exist': the target table doesn't exist")
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime, v varchar(32), v2 int) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE' TTL_ARCHIVE_TARGET='t_archive'",
+		"[ddl:8266]Invalid TTL_ARCHIVE_TARGET 't_archive': column 'v2' doesn't exist in the target table")
+	tk.MustGetErrMsg("create table t(id int primary key, t datetime) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE' TTL_ARCHIVE_TARGET='s3://bucket/prefix?format=json'",
+		"[ddl:8266]Invalid TTL_ARCHIVE_TARGET 's3://bucket/prefix?format=json': unsupported format 'json'")
+
+	tk.MustExec("create table t(id int primary key, t datetime, v varchar(32), v2 int as (id + 1)) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='archive' TTL_ARCHIVE_TARGET='test.t_archive'")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `t` datetime DEFAULT NULL,\n" +
+		"  `v` varchar(32) DEFAULT NULL,\n" +
+		"  `v2` int(11) GENERATED ALWAYS AS (`id` + 1) VIRTUAL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`t` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='24h' */ /*T![ttl] TTL_ACTION='ARCHIVE' */ /*T![ttl] TTL_ARCHIVE_TARGET='test.t_archive' */"))
+
+	// the archive settings are kept when other TTL options are altered
+	tk.MustExec("alter table t TTL=`t`+INTERVAL 2 DAY")
+	tbl, err := dom.InfoSchema().TableInfoByName(ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	require.True(t, tbl.TTLInfo.IsArchive())
+	require.Equal(t, "test.t_archive", tbl.TTLInfo.ArchiveTarget)
+
+	tk.MustGetErrMsg("alter table t TTL_ARCHIVE_TARGET='t'",
+		"[ddl:8266]Invalid TTL_ARCHIVE_TARGET 't': the target cannot be the TTL table itself")
+	tk.MustExec("alter table t TTL_ARCHIVE_TARGET='local:///tmp/archive?format=parquet'")
+	tk.MustExec("alter table t TTL_ACTION='DELETE'")
+	tbl, err = dom.InfoSchema().TableInfoByName(ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	require.False(t, tbl.TTLInfo.IsArchive())
+	require.Equal(t, "local:///tmp/archive?format=parquet", tbl.TTLInfo.ArchiveTarget)
+
+	tk.MustExec("create table t2(id int primary key, t datetime)")
+	tk.MustGetErrMsg("alter table t2 TTL_ACTION='ARCHIVE'", "[ddl:8150]Cannot set TTL_ACTION on a table without TTL config")
+}
+
+func TestTTLArchiveToTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create database archive")
+	tk.MustExec("create table archive.t_archive(id int primary key, t datetime, v varchar(32), v2 int as (id + 1), archived_at timestamp default current_timestamp)")
+	tk.MustExec("create table t(id int primary key, t datetime, v varchar(32), v2 int as (id + 1)) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE' TTL_ARCHIVE_TARGET='archive.t_archive'")
+	tk.MustExec("insert into t(id, t, v) values (1, '2020-01-01 00:00:00', 'a'), (2, '2020-01-02 00:00:00', NULL), (3, '2099-01-01 00:00:00', 'c')")
+
+	tbl := getArchivePhysicalTable(t, dom, "t")
+	se := sessionFactory(t, dom)()
+	defer se.Close()
+
+	expire := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	// row 3 is not expired, so it is neither archived nor deleted
+	retryRows, successRows, errorRows, archivedRows := ttlworker.DoDeleteForTest(context.Background(), se, tbl, "job1", expire,
+		[][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(2)}, {types.NewIntDatum(3)}})
+	require.Empty(t, retryRows)
+	require.Equal(t, uint64(3), successRows)
+	require.Equal(t, uint64(0), errorRows)
+	require.Equal(t, uint64(2), archivedRows)
+	tk.MustQuery("select id, t, v, v2 from archive.t_archive order by id").Check(testkit.Rows(
+		"1 2020-01-01 00:00:00 a 2",
+		"2 2020-01-02 00:00:00 <nil> 3",
+	))
+	tk.MustQuery("select id from t").Check(testkit.Rows("3"))
+
+	// the rows are not deleted if they can't be archived
+	tk.MustExec("insert into t(id, t, v) values (4, '2020-01-01 00:00:00', 'd')")
+	tk.MustExec("insert into archive.t_archive(id) values (4)")
+	retryRows, successRows, errorRows, archivedRows = ttlworker.DoDeleteForTest(context.Background(), se, tbl, "job1", expire,
+		[][]types.Datum{{types.NewIntDatum(4)}})
+	require.Len(t, retryRows, 1)
+	require.Equal(t, uint64(0), successRows+errorRows+archivedRows)
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("3", "4"))
+}
+
+func TestTTLArchiveToStorage(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	for _, format := range []string{cache.ArchiveFormatCSV, cache.ArchiveFormatParquet} {
+		dir := t.TempDir()
+		tk.MustExec("drop table if exists t")
+		tk.MustExec("create table t(id int primary key, t datetime, v varchar(32)) TTL=`t`+INTERVAL 1 DAY TTL_ACTION='ARCHIVE' TTL_ARCHIVE_TARGET='local://" + dir + "?format=" + format + "'")
+		tk.MustExec("insert into t values (1, '2020-01-01 00:00:00', 'a,b'), (2, '2020-01-02 00:00:00', NULL), (3, '2099-01-01 00:00:00', 'c')")
+
+		tbl := getArchivePhysicalTable(t, dom, "t")
+		se := sessionFactory(t, dom)()
+		expire := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		rows := [][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(2)}, {types.NewIntDatum(3)}}
+		retryRows, successRows, errorRows, archivedRows := ttlworker.DoDeleteForTest(context.Background(), se, tbl, "job1", expire, rows)
+		se.Close()
+		require.Empty(t, retryRows)
+		require.Equal(t, uint64(3), successRows)
+		require.Equal(t, uint64(0), errorRows)
+		require.Equal(t, uint64(2), archivedRows)
+		tk.MustQuery("select id from t").Check(testkit.Rows("3"))
+
+		files, err := filepath.Glob(filepath.Join(dir, "test.t", "job1", "*."+format))
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		content, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		if format == cache.ArchiveFormatCSV {
+			require.Equal(t, strings.Join([]string{
+				"id,t,v",
+				`1,2020-01-01 00:00:00,"a,b"`,
+				`2,2020-01-02 00:00:00,\N`,
+				"",
+			}, "\n"), string(content))
+		} else {
+			require.True(t, strings.HasPrefix(string(content), "PAR1"))
+		}
+	}
+}
+
+func getArchivePhysicalTable(t *testing.T, dom *domain.Domain, name string) *cache.PhysicalTable {
+	tblInfo, err := dom.InfoSchema().TableInfoByName(ast.NewCIStr("test"), ast.NewCIStr(name))
+	require.NoError(t, err)
+	tbl, err := cache.NewPhysicalTable(ast.NewCIStr("test"), tblInfo, ast.NewCIStr(""))
+	require.NoError(t, err)
+	return tbl
+}
@@ -0,0 +1,63 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ttlworker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/meta/model"
+	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/ttl/cache"
+	"github.com/pingcap/tidb/pkg/ttl/session"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// DoDeleteForTest runs a delete task for the rows, and returns the rows to retry and the statistics of the task.
+func DoDeleteForTest(ctx context.Context, se session.Session, tbl *cache.PhysicalTable, jobID string, expire time.Time,
+	rows [][]types.Datum) (retryRows [][]types.Datum, successRows, errorRows, archivedRows uint64) {
+	task := &ttlDeleteTask{
+		jobID:      jobID,
+		tbl:        tbl,
+		expire:     expire,
+		rows:       rows,
+		statistics: &ttlStatistics{},
+	}
+	retryRows = task.doDelete(ctx, se)
+	return retryRows, task.statistics.SuccessRows.Load(), task.statistics.ErrorRows.Load(), task.statistics.ArchivedRows.Load()
+}
+
+func TestTTLArchiverFileName(t *testing.T) {
+	tbl := &cache.PhysicalTable{
+		Schema:    ast.NewCIStr("test"),
+		TableInfo: &model.TableInfo{Name: ast.NewCIStr("t")},
+	}
+	a := &ttlArchiver{
+		tbl:    tbl,
+		jobID:  "job1",
+		target: &cache.ArchiveTarget{StorageURL: "local:///tmp/archive", Format: cache.ArchiveFormatCSV},
+	}
+
+	name := a.fileName("select 1")
+	require.Regexp(t, `^test\.t/job1/[0-9a-f]{32}\.csv$`, name)
+	require.Equal(t, name, a.fileName("select 1"))
+	require.NotEqual(t, name, a.fileName("select 2"))
+
+	tbl.Partition = ast.NewCIStr("p0")
+	a.target.Format = cache.ArchiveFormatParquet
+	require.Regexp(t, `^test\.t\.p0/job1/[0-9a-f]{32}\.parquet$`, a.fileName("select 1"))
+}
@@ -138,6 +138,18 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 	}()
 
 	se := newTableSession(rawSe, t.tbl, t.expire)
+	var archiver *ttlArchiver
+	if t.tbl.TTLInfo.IsArchive() {
+		var err error
+		if archiver, err = newTTLArchiver(ctx, t.tbl, t.jobID); err != nil {
+			// the rows are retried later because the archive target may be unavailable temporarily.
+			t.taskLogger(logutil.Logger(ctx)).Warn(
+				"create TTL archiver failed",
+				zap.Error(err),
+			)
+			return
+		}
+	}
 	for len(leftRows) > 0 && ctx.Err() == nil {
 		maxBatch := vardef.TTLDeleteBatchSize.Load()
 		var delBatch [][]types.Datum
@@ -173,7 +185,13 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 		tracer.EnterPhase(metrics.PhaseOther)
 
 		sqlStart := time.Now()
-		_, needRetry, err := se.ExecuteSQLWithCheck(ctx, sql)
+		var needRetry bool
+		var archived int
+		if archiver != nil {
+			archived, needRetry, err = archiver.ArchiveAndDelete(ctx, se, delBatch, sql)
+		} else {
+			_, needRetry, err = se.ExecuteSQLWithCheck(ctx, sql)
+		}
 		sqlInterval := time.Since(sqlStart)
 		if err != nil {
 			metrics.DeleteErrorDuration.Observe(sqlInterval.Seconds())
@@ -197,6 +215,9 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 
 		metrics.DeleteSuccessDuration.Observe(sqlInterval.Seconds())
 		t.statistics.IncSuccessRows(len(delBatch))
+		if archived > 0 {
+			t.statistics.IncArchivedRows(archived)
+		}
 	}
 	return retryRows
 }
@@ -1011,6 +1011,10 @@ type TTLSummary struct {
 	TotalRows   uint64 `json:"total_rows"`
 	SuccessRows uint64 `json:"success_rows"`
 	ErrorRows   uint64 `json:"error_rows"`
+	// ArchivedRows is the count of the rows archived before deletion, it's a part of SuccessRows.
+	ArchivedRows uint64 `json:"archived_rows,omitempty"`
+	// FilteredRows is the count of the expired rows kept because they don't match the TTL condition.
+	FilteredRows uint64 `json:"filtered_rows,omitempty"`
 
 	TotalScanTask     int `json:"total_scan_task"`
 	ScheduledScanTask int `json:"scheduled_scan_task"`
@@ -1041,6 +1045,8 @@ func summarizeTaskResult(tasks []*cache.TTLTask) (*TTLSummary, error) {
 			summary.TotalRows += t.State.TotalRows
 			summary.SuccessRows += t.State.SuccessRows
 			summary.ErrorRows += t.State.ErrorRows
+			summary.ArchivedRows += t.State.ArchivedRows
+			summary.FilteredRows += t.State.FilteredRows
 			if len(t.State.ScanTaskErr) > 0 {
 				allErr = multierr.Append(allErr, errors.New(t.State.ScanTaskErr))
 			}
@@ -44,6 +44,10 @@ type ttlStatistics struct {
 	TotalRows   atomic.Uint64
 	SuccessRows atomic.Uint64
 	ErrorRows   atomic.Uint64
+	// ArchivedRows is the count of the rows archived before deletion, it's a part of SuccessRows.
+	ArchivedRows atomic.Uint64
+	// FilteredRows is the count of the expired rows kept because they don't match the TTL condition.
+	FilteredRows atomic.Uint64
 }
 
 func (s *ttlStatistics) IncTotalRows(cnt int) {
@@ -61,10 +65,22 @@ func (s *ttlStatistics) IncErrorRows(cnt int) {
 	s.ErrorRows.Add(uint64(cnt))
 }
 
+func (s *ttlStatistics) IncArchivedRows(cnt int) {
+	metrics.ArchivedExpiredRows.Add(float64(cnt))
+	s.ArchivedRows.Add(uint64(cnt))
+}
+
+func (s *ttlStatistics) IncFilteredRows(cnt int) {
+	metrics.FilteredExpiredRows.Add(float64(cnt))
+	s.FilteredRows.Add(uint64(cnt))
+}
+
 func (s *ttlStatistics) Reset() {
 	s.SuccessRows.Store(0)
 	s.ErrorRows.Store(0)
 	s.TotalRows.Store(0)
+	s.ArchivedRows.Store(0)
+	s.FilteredRows.Store(0)
 }
 
 func (s *ttlStatistics) String() string {
@@ -112,11 +128,32 @@ func (t *ttlScanTask) result(err error) *ttlScanTaskExecResult {
 func (t *ttlScanTask) getDatumRows(rows []chunk.Row) [][]types.Datum {
 	datums := make([][]types.Datum, len(rows))
 	for i, row := range rows {
-		datums[i] = row.GetDatumRow(t.tbl.KeyColumnTypes)
+		// the result of the TTL condition may be selected after the key columns
+		datums[i] = make([]types.Datum, len(t.tbl.KeyColumnTypes))
+		for j, ft := range t.tbl.KeyColumnTypes {
+			datums[i][j] = row.GetDatum(j, ft)
+		}
 	}
 	return datums
 }
 
+// getExpiredRows returns the rows matching the TTL condition, which is selected after the key columns.
+// All the rows are returned if the table has no TTL condition.
+func (t *ttlScanTask) getExpiredRows(rows []chunk.Row, datums [][]types.Datum) [][]types.Datum {
+	if !t.tbl.TTLInfo.HasCondition() {
+		return datums
+	}
+
+	condIdx := len(t.tbl.KeyColumnTypes)
+	expired := make([][]types.Datum, 0, len(datums))
+	for i, row := range rows {
+		if row.GetInt64(condIdx) != 0 {
+			expired = append(expired, datums[i])
+		}
+	}
+	return expired
+}
+
 func (t *ttlScanTask) taskLogger(l *zap.Logger) *zap.Logger {
 	return l.With(
 		zap.String("jobID", t.JobID),
@@ -274,12 +311,20 @@ func (t *ttlScanTask) doScan(ctx context.Context, delCh chan<- *ttlDeleteTask, s
 			continue
 		}
 
+		expiredRows := t.getExpiredRows(rows, lastResult)
+		if filtered := len(lastResult) - len(expiredRows); filtered > 0 {
+			t.statistics.IncFilteredRows(filtered)
+		}
+		if len(expiredRows) == 0 {
+			continue
+		}
+
 		delTask := &ttlDeleteTask{
 			jobID:      t.JobID,
 			scanID:     t.ScanID,
 			tbl:        t.tbl,
 			expire:     t.ExpireTime,
-			rows:       lastResult,
+			rows:       expiredRows,
 			statistics: t.statistics,
 		}
 
@@ -288,7 +333,7 @@ func (t *ttlScanTask) doScan(ctx context.Context, delCh chan<- *ttlDeleteTask, s
 		case <-ctx.Done():
 			return t.result(ctx.Err())
 		case delCh <- delTask:
-			t.statistics.IncTotalRows(len(lastResult))
+			t.statistics.IncTotalRows(len(expiredRows))
 		}
 		tracer.EnterPhase(metrics.PhaseOther)
 	}
@@ -27,6 +27,7 @@ import (
 	"github.com/pingcap/tidb/pkg/testkit/testflag"
 	"github.com/pingcap/tidb/pkg/ttl/cache"
 	"github.com/pingcap/tidb/pkg/ttl/ttlworker"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -97,3 +98,93 @@ func TestCancelWhileScan(t *testing.T) {
 	close(delCh)
 	wg.Wait()
 }
+
+func TestScanWithTTLCondition(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, expire_at datetime, status varchar(16)) TTL = expire_at WHERE (status = 'closed')")
+	tk.MustExec("insert into t values " +
+		"(1, '2020-01-01 00:00:00', 'closed'), " +
+		"(2, '2020-01-01 00:00:00', 'open'), " +
+		"(3, '2099-01-01 00:00:00', 'closed'), " +
+		"(4, '2020-01-01 00:00:00', NULL), " +
+		"(5, '2020-01-02 00:00:00', 'closed')")
+	tbl := getArchivePhysicalTable(t, dom, "t")
+	expire := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	delCh := make(chan *ttlworker.TTLDeleteTask, 16)
+	ttlTask := ttlworker.NewTTLScanTask(context.Background(), tbl, &cache.TTLTask{
+		JobID:      "test",
+		TableID:    tbl.ID,
+		ScanID:     1,
+		ExpireTime: expire,
+		Status:     cache.TaskStatusRunning,
+		State:      &cache.TTLTaskState{},
+	})
+	ttlTask.DoScan(context.Background(), delCh, dom.SysSessionPool())
+	close(delCh)
+
+	var ids []int64
+	for task := range delCh {
+		for _, row := range task.Rows() {
+			ids = append(ids, row[0].GetInt64())
+		}
+	}
+	require.Equal(t, []int64{1, 5}, ids)
+	require.Equal(t, uint64(2), ttlTask.FilteredRows())
+
+	// the rows which don't match the condition are kept even if they are passed to the delete task
+	se := sessionFactory(t, dom)()
+	defer se.Close()
+	rows := [][]types.Datum{{types.NewIntDatum(1)}, {types.NewIntDatum(2)}, {types.NewIntDatum(4)}}
+	retryRows, _, errorRows, _ := ttlworker.DoDeleteForTest(context.Background(), se, tbl, "test", expire, rows)
+	require.Empty(t, retryRows)
+	require.Equal(t, uint64(0), errorRows)
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("2", "3", "4", "5"))
+}
+
+func TestTTLConditionDDL(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrMsg("create table t (id int primary key, expire_at datetime) TTL = expire_at WHERE (status = 'closed')",
+		"[ddl:8267]Invalid TTL condition '`status` = _utf8mb4'closed'': unknown column 'status'")
+	tk.MustGetErrMsg("create table t (id int primary key, expire_at datetime, status int) TTL = expire_at WHERE (status = (select 1))",
+		"[ddl:8267]Invalid TTL condition '`status` = (select 1)': subqueries, variables and non-deterministic functions are not allowed")
+	tk.MustGetErrMsg("create table t (id int primary key, expire_at datetime, status int) TTL = expire_at WHERE (status > rand())",
+		"[ddl:8267]Invalid TTL condition '`status` > rand()': subqueries, variables and non-deterministic functions are not allowed")
+	tk.MustGetErrMsg("create table t (id int primary key, expire_at datetime, status int) TTL = expire_at WHERE (sum(status) > 1)",
+		"[ddl:8267]Invalid TTL condition 'sum(`status`) > 1': aggregate and window functions are not allowed")
+	tk.MustGetErrMsg("create table t (id int primary key, expire_at int) TTL = expire_at",
+		"[ddl:8148]Field 'expire_at' is of a not supported type for TTL config, expect DATETIME, DATE or TIMESTAMP")
+
+	tk.MustExec("create table t (id int primary key, expire_at datetime, status varchar(16), v int) TTL = expire_at WHERE (status = 'closed' and v > 1)")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `expire_at` datetime DEFAULT NULL,\n" +
+		"  `status` varchar(16) DEFAULT NULL,\n" +
+		"  `v` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`expire_at` WHERE (`status` = _utf8mb4'closed' and `v` > 1) */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='24h' */"))
+	tbl, err := dom.InfoSchema().TableInfoByName(ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	require.True(t, tbl.TTLInfo.IsPerRowExpiry())
+	require.Equal(t, "`status` = _utf8mb4'closed' and `v` > 1", tbl.TTLInfo.Condition)
+
+	// the columns referenced by the condition can't be dropped or renamed
+	tk.MustGetErrMsg("alter table t drop column v", "[ddl:8149]Cannot drop column 'v': needed in TTL config")
+	tk.MustGetErrMsg("alter table t rename column status to state",
+		"[ddl:8267]Invalid TTL condition '`status` = _utf8mb4'closed' and `v` > 1': cannot rename column 'status' referenced by it")
+	tk.MustGetErrMsg("alter table t change column v v2 int",
+		"[ddl:8267]Invalid TTL condition '`status` = _utf8mb4'closed' and `v` > 1': cannot rename column 'v' referenced by it")
+	tk.MustExec("alter table t modify column v bigint")
+
+	tk.MustExec("alter table t TTL = expire_at + INTERVAL 1 DAY")
+	tbl, err = dom.InfoSchema().TableInfoByName(ast.NewCIStr("test"), ast.NewCIStr("t"))
+	require.NoError(t, err)
+	require.False(t, tbl.TTLInfo.IsPerRowExpiry())
+	require.False(t, tbl.TTLInfo.HasCondition())
+	tk.MustExec("alter table t drop column v")
+}
@@ -572,3 +572,13 @@ func (t *ttlScanTask) DoScan(ctx context.Context, delCh chan<- *TTLDeleteTask, s
 
 // TTLDeleteTask is an exported version of `ttlDeleteTask` for test.
 type TTLDeleteTask = ttlDeleteTask
+
+// Rows returns the rows of the delete task for test.
+func (t *ttlDeleteTask) Rows() [][]types.Datum {
+	return t.rows
+}
+
+// FilteredRows returns the count of the expired rows filtered by the TTL condition for test.
+func (t *ttlScanTask) FilteredRows() uint64 {
+	return t.statistics.FilteredRows.Load()
+}
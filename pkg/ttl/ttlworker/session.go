@@ -245,24 +245,45 @@ type ttlTableSession struct {
 }
 
 func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) ([]chunk.Row, bool, error) {
+	var result []chunk.Row
+	shouldRetry, err := s.RunInTxnWithCheck(ctx, func() error {
+		rows, err := s.ExecuteSQL(ctx, sql)
+		if err != nil {
+			return err
+		}
+
+		result = rows
+		return nil
+	})
+
+	if err != nil {
+		return nil, shouldRetry, err
+	}
+
+	return result, false, nil
+}
+
+// RunInTxnWithCheck runs the function in an optimistic txn and checks the TTL settings of the table after it.
+// The function should execute at least one SQL because the meta used by the txn is determined by the first query.
+// It returns whether the function should be retried if an error occurs.
+func (s *ttlTableSession) RunInTxnWithCheck(ctx context.Context, fn func() error) (bool, error) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
 
 	tracer.EnterPhase(metrics.PhaseOther)
 	if !vardef.EnableTTLJob.Load() {
-		return nil, false, errors.New("global TTL job is disabled")
+		return false, errors.New("global TTL job is disabled")
 	}
 
 	if err := s.ResetWithGlobalTimeZone(ctx); err != nil {
-		return nil, false, err
+		return false, err
 	}
 
-	var result []chunk.Row
 	shouldRetry := true
 	err := s.RunInTxn(ctx, func() error {
 		tracer.EnterPhase(metrics.PhaseQuery)
 		defer tracer.EnterPhase(tracer.Phase())
-		rows, err := s.ExecuteSQL(ctx, sql)
+		err := fn()
 		tracer.EnterPhase(metrics.PhaseCheckTTL)
 		// We must check the configuration after ExecuteSQL because of MDL and the meta the current transaction used
 		// can only be determined after executed one query.
@@ -271,19 +292,14 @@ func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) (
 			return errors.Annotatef(validateErr, "table '%s.%s' meta changed, should abort current job", s.tbl.Schema, s.tbl.Name)
 		}
 
-		if err != nil {
-			return err
-		}
-
-		result = rows
-		return nil
+		return err
 	}, session.TxnModeOptimistic)
 
 	if err != nil {
-		return nil, shouldRetry, err
+		return shouldRetry, err
 	}
 
-	return result, false, nil
+	return false, nil
 }
 
 func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.PhysicalTable, expire time.Time) error {
@@ -324,6 +340,14 @@ func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.Physical
 		return errors.New("time column name changed")
 	}
 
+	if newTblInfo.TTLInfo.Action != tbl.TTLInfo.Action || newTblInfo.TTLInfo.ArchiveTarget != tbl.TTLInfo.ArchiveTarget {
+		return errors.New("TTL action changed")
+	}
+
+	if newTblInfo.TTLInfo.Condition != tbl.TTLInfo.Condition {
+		return errors.New("TTL condition changed")
+	}
+
 	if newTblInfo.TTLInfo.IntervalExprStr != tbl.TTLInfo.IntervalExprStr ||
 		newTblInfo.TTLInfo.IntervalTimeUnit != tbl.TTLInfo.IntervalTimeUnit {
 		newExpireTime, err := newTTLTbl.EvalExpireTime(ctx, s, s.Now())
@@ -767,6 +767,9 @@ func (t *runningScanTask) dumpNewTaskState() *cache.TTLTaskState {
 		TotalRows:   t.statistics.TotalRows.Load(),
 		SuccessRows: t.statistics.SuccessRows.Load(),
 		ErrorRows:   t.statistics.ErrorRows.Load(),
+
+		ArchivedRows: t.statistics.ArchivedRows.Load(),
+		FilteredRows: t.statistics.FilteredRows.Load(),
 	}
 
 	if prevState := t.TTLTask.State; prevState != nil {
@@ -775,6 +778,8 @@ func (t *runningScanTask) dumpNewTaskState() *cache.TTLTaskState {
 		state.TotalRows += prevState.SuccessRows + prevState.ErrorRows
 		state.SuccessRows += prevState.SuccessRows
 		state.ErrorRows += prevState.ErrorRows
+		state.ArchivedRows += prevState.ArchivedRows
+		state.FilteredRows += prevState.FilteredRows
 	}
 
 	if r := t.result; r != nil && r.err != nil {
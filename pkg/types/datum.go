@@ -1059,6 +1059,8 @@ func (d *Datum) ConvertTo(ctx Context, target *FieldType) (Datum, error) {
 		return d.convertToMysqlJSON(target)
 	case mysql.TypeTiDBVectorFloat32:
 		return d.convertToVectorFloat32(ctx, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1874,6 +1876,25 @@ func (d *Datum) convertToVectorFloat32(_ Context, target *FieldType) (ret Datum,
 	return ret, errors.Trace(err)
 }
 
+// convertToGeometry checks the value is a geometry in the storage format, and
+// it's the subtype of the target.
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+		g, err := ParseGeometry("", d.GetBytes())
+		if err != nil {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		if tp := target.GetGeometryType(); tp != GeometryTypeGeometry && tp != g.Type {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		ret.SetBytes(d.GetBytes())
+	default:
+		return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+	}
+	return ret, nil
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(ctx Context) (int64, error) {
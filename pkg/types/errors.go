@@ -98,4 +98,24 @@ var (
 	ErrJSONBadOneOrAllArg = dbterror.ClassTypes.NewStd(mysql.ErrJSONBadOneOrAllArg)
 	// ErrJSONVacuousPath is returned for path expressions that are not allowed in that context.
 	ErrJSONVacuousPath = dbterror.ClassTypes.NewStd(mysql.ErrJSONVacuousPath)
+	// ErrCantCreateGeometryObject is returned when the value can't be converted to the geometry column.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
+	// ErrGISInvalidData is returned when the input isn't a valid geometry for the spatial function.
+	ErrGISInvalidData = dbterror.ClassTypes.NewStd(mysql.ErrGISInvalidData)
+	// ErrGISDifferentSRIDs is returned when the geometries of a binary spatial function are in different SRIDs.
+	ErrGISDifferentSRIDs = dbterror.ClassTypes.NewStd(mysql.ErrGISDifferentSRIDs)
+	// ErrGISUnsupportedArgument is returned when the spatial function doesn't support the geometry types.
+	ErrGISUnsupportedArgument = dbterror.ClassTypes.NewStd(mysql.ErrGISUnsupportedArgument)
+	// ErrSRSNotFound is returned when the SRID isn't a known spatial reference system.
+	ErrSRSNotFound = dbterror.ClassTypes.NewStd(mysql.ErrSRSNotFound)
+	// ErrLongitudeOutOfRange is returned when the longitude of a geographic point is out of range.
+	ErrLongitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLongitudeOutOfRange)
+	// ErrLatitudeOutOfRange is returned when the latitude of a geographic point is out of range.
+	ErrLatitudeOutOfRange = dbterror.ClassTypes.NewStd(mysql.ErrLatitudeOutOfRange)
+	// ErrNotImplementedForGeographicSRS is returned when the spatial function doesn't support geographic SRS.
+	ErrNotImplementedForGeographicSRS = dbterror.ClassTypes.NewStd(mysql.ErrNotImplementedForGeographicSRS)
+	// ErrWrongSRIDForColumn is returned when the SRID of the geometry doesn't match the SRID of the column.
+	ErrWrongSRIDForColumn = dbterror.ClassTypes.NewStd(mysql.ErrWrongSRIDForColumn)
+	// ErrNonPositiveRadius is returned when the sphere radius isn't positive.
+	ErrNonPositiveRadius = dbterror.ClassTypes.NewStd(mysql.ErrNonPositiveRadius)
 )
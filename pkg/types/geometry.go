@@ -0,0 +1,989 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/parser/types"
+)
+
+// GeometryType is the subtype of the GEOMETRY type.
+type GeometryType = types.GeometryType
+
+// Geometry subtypes.
+const (
+	GeometryTypeGeometry           = types.GeometryTypeGeometry
+	GeometryTypePoint              = types.GeometryTypePoint
+	GeometryTypeLineString         = types.GeometryTypeLineString
+	GeometryTypePolygon            = types.GeometryTypePolygon
+	GeometryTypeMultiPoint         = types.GeometryTypeMultiPoint
+	GeometryTypeMultiLineString    = types.GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon       = types.GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection = types.GeometryTypeGeometryCollection
+)
+
+// maxGeometryDepth is the max nesting depth of the geometry collections.
+const maxGeometryDepth = 64
+
+// SpatialReferenceSystem is a spatial reference system which can be referred
+// by the SRID of the geometries.
+type SpatialReferenceSystem struct {
+	SRID uint32
+	Name string
+	// Geographic indicates the coordinates are longitudes and latitudes on
+	// the ellipsoid, otherwise they are on a Cartesian plane.
+	Geographic bool
+	// LatLong indicates the axis order of the WKT and WKB representations is
+	// latitude first.
+	LatLong bool
+	// SemiMajorAxis and InverseFlattening define the ellipsoid of the
+	// geographic spatial reference system.
+	SemiMajorAxis     float64
+	InverseFlattening float64
+}
+
+var spatialReferenceSystems = map[uint32]*SpatialReferenceSystem{
+	0:    {SRID: 0, Name: ""},
+	3857: {SRID: 3857, Name: "WGS 84 / Pseudo-Mercator"},
+	4326: {SRID: 4326, Name: "WGS 84", Geographic: true, LatLong: true, SemiMajorAxis: 6378137, InverseFlattening: 298.257223563},
+}
+
+// AxisOrder is the axis order of the WKT and WKB in the geographic spatial
+// reference systems.
+type AxisOrder int
+
+// Axis orders.
+const (
+	AxisOrderSRIDDefined AxisOrder = iota
+	AxisOrderLatLong
+	AxisOrderLongLat
+)
+
+// ParseAxisOrder parses the axis order from the options of the spatial
+// functions, e.g. 'axis-order=long-lat'.
+func ParseAxisOrder(fn string, options string) (AxisOrder, error) {
+	order := AxisOrderSRIDDefined
+	for _, opt := range strings.Split(options, ",") {
+		if strings.TrimSpace(opt) == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(opt, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "axis-order") {
+			return order, ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "srid-defined":
+			order = AxisOrderSRIDDefined
+		case "lat-long":
+			order = AxisOrderLatLong
+		case "long-lat":
+			order = AxisOrderLongLat
+		default:
+			return order, ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+	}
+	return order, nil
+}
+
+// swapAxes returns whether the coordinates of the WKT and WKB are in the
+// latitude-longitude order.
+func (srs *SpatialReferenceSystem) swapAxes(order AxisOrder) bool {
+	if srs == nil || !srs.Geographic {
+		return false
+	}
+	switch order {
+	case AxisOrderLatLong:
+		return true
+	case AxisOrderLongLat:
+		return false
+	}
+	return srs.LatLong
+}
+
+// GetSpatialReferenceSystem returns the spatial reference system of the SRID.
+// SRID 0 is the Cartesian plane without units.
+func GetSpatialReferenceSystem(srid uint32) (*SpatialReferenceSystem, bool) {
+	srs, ok := spatialReferenceSystems[srid]
+	return srs, ok
+}
+
+// GeomPoint is the coordinates of a point. In the geographic spatial reference
+// systems, X is the longitude and Y is the latitude in degrees.
+type GeomPoint struct {
+	X, Y float64
+}
+
+// Geometry is a geometry value in a spatial reference system.
+//
+// The POINT and LINESTRING are represented by Points. The rings of the POLYGON
+// and the elements of the MULTIPOINT, MULTILINESTRING, MULTIPOLYGON and
+// GEOMCOLLECTION are represented by Parts.
+//
+// The storage format is the same as MySQL:
+// 4 byte       - SRID in little endian
+// N byte       - WKB in little endian, the coordinates are always in the
+// longitude-latitude order for the geographic spatial reference systems
+type Geometry struct {
+	SRID   uint32
+	Type   GeometryType
+	Points []GeomPoint
+	Parts  []Geometry
+}
+
+// IsEmpty returns whether the geometry is an empty geometry collection.
+func (g Geometry) IsEmpty() bool {
+	if g.Type != GeometryTypeGeometryCollection {
+		return false
+	}
+	for _, part := range g.Parts {
+		if !part.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// TypeName returns the upper case name of the geometry type.
+func (g Geometry) TypeName() string {
+	return strings.ToUpper(g.Type.String())
+}
+
+// SetSRID sets the SRID of the geometry and all its parts.
+func (g *Geometry) SetSRID(srid uint32) {
+	g.SRID = srid
+	for i := range g.Parts {
+		g.Parts[i].SetSRID(srid)
+	}
+}
+
+// ParseGeometry decodes the geometry from the storage format. fn is the name
+// of the function reports the error.
+func ParseGeometry(fn string, data []byte) (Geometry, error) {
+	if len(data) < 4 {
+		return Geometry{}, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	srid := binary.LittleEndian.Uint32(data)
+	srs, ok := GetSpatialReferenceSystem(srid)
+	if !ok {
+		return Geometry{}, ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	g, err := parseWKB(fn, data[4:], srs, false)
+	if err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+// GeometrySRID returns the SRID of the geometry in the storage format, the
+// data must have been validated.
+func GeometrySRID(data []byte) uint32 {
+	return binary.LittleEndian.Uint32(data)
+}
+
+// ParseGeometryFromWKB parses the geometry from WKB in the axis order.
+func ParseGeometryFromWKB(fn string, wkb []byte, srid uint32, order AxisOrder) (Geometry, error) {
+	srs, ok := GetSpatialReferenceSystem(srid)
+	if !ok {
+		return Geometry{}, ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	return parseWKB(fn, wkb, srs, srs.swapAxes(order))
+}
+
+func parseWKB(fn string, wkb []byte, srs *SpatialReferenceSystem, swap bool) (Geometry, error) {
+	r := wkbReader{data: wkb, swap: swap}
+	g, ok := r.readGeometry(srs.SRID, 0)
+	if !ok || r.pos != len(r.data) {
+		return Geometry{}, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	if err := g.validate(fn, srs); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+// Encode encodes the geometry into the storage format.
+func (g Geometry) Encode() []byte {
+	buf := binary.LittleEndian.AppendUint32(make([]byte, 0, 4+g.wkbSize()), g.SRID)
+	return g.appendWKB(buf, false)
+}
+
+// WKB returns the WKB of the geometry in the axis order.
+func (g Geometry) WKB(order AxisOrder) []byte {
+	srs, _ := GetSpatialReferenceSystem(g.SRID)
+	return g.appendWKB(make([]byte, 0, g.wkbSize()), srs.swapAxes(order))
+}
+
+// NewPointGeometry returns a POINT in the SRID 0.
+func NewPointGeometry(x, y float64) Geometry {
+	return Geometry{Type: GeometryTypePoint, Points: []GeomPoint{{X: x, Y: y}}}
+}
+
+// NewGeometryFromParts constructs the LINESTRING, POLYGON, MULTIPOINT,
+// MULTILINESTRING, MULTIPOLYGON or GEOMCOLLECTION from the parts. The parts of
+// the LINESTRING must be points, and the parts of the POLYGON must be
+// linestrings.
+func NewGeometryFromParts(fn string, tp GeometryType, parts []Geometry) (Geometry, error) {
+	g := Geometry{Type: tp}
+	for _, part := range parts {
+		if part.SRID != parts[0].SRID {
+			return Geometry{}, ErrGISDifferentSRIDs.GenWithStackByArgs(fn, parts[0].SRID, part.SRID)
+		}
+		g.SRID = part.SRID
+	}
+	var partType GeometryType
+	switch tp {
+	case GeometryTypeLineString, GeometryTypeMultiPoint:
+		partType = GeometryTypePoint
+	case GeometryTypePolygon, GeometryTypeMultiLineString:
+		partType = GeometryTypeLineString
+	case GeometryTypeMultiPolygon:
+		partType = GeometryTypePolygon
+	}
+	for _, part := range parts {
+		if tp != GeometryTypeGeometryCollection && part.Type != partType {
+			return Geometry{}, ErrGISUnsupportedArgument.GenWithStackByArgs(fn)
+		}
+	}
+	if tp == GeometryTypeLineString {
+		for _, part := range parts {
+			g.Points = append(g.Points, part.Points[0])
+		}
+	} else {
+		g.Parts = parts
+	}
+	srs, ok := GetSpatialReferenceSystem(g.SRID)
+	if !ok {
+		return Geometry{}, ErrSRSNotFound.GenWithStackByArgs(g.SRID)
+	}
+	if err := g.validate(fn, srs); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+// validate checks the geometry is well-formed, and the coordinates are in the
+// range of the geographic spatial reference system.
+func (g Geometry) validate(fn string, srs *SpatialReferenceSystem) error {
+	for _, p := range g.Points {
+		if math.IsNaN(p.X) || math.IsNaN(p.Y) || math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) {
+			return ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+		if !srs.Geographic {
+			continue
+		}
+		if p.X <= -180 || p.X > 180 {
+			return ErrLongitudeOutOfRange.GenWithStackByArgs(p.X, fn, -180.0, 180.0)
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return ErrLatitudeOutOfRange.GenWithStackByArgs(p.Y, fn, -90.0, 90.0)
+		}
+	}
+	switch g.Type {
+	case GeometryTypePoint:
+		if len(g.Points) != 1 {
+			return ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+	case GeometryTypeLineString:
+		if len(g.Points) < 2 {
+			return ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+	case GeometryTypePolygon:
+		if len(g.Parts) == 0 {
+			return ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+		for _, ring := range g.Parts {
+			n := len(ring.Points)
+			if ring.Type != GeometryTypeLineString || n < 4 || ring.Points[0] != ring.Points[n-1] {
+				return ErrGISInvalidData.GenWithStackByArgs(fn)
+			}
+		}
+	case GeometryTypeMultiPoint, GeometryTypeMultiLineString, GeometryTypeMultiPolygon:
+		if len(g.Parts) == 0 {
+			return ErrGISInvalidData.GenWithStackByArgs(fn)
+		}
+		for _, part := range g.Parts {
+			if part.Type != g.Type-GeometryTypeMultiPoint+GeometryTypePoint {
+				return ErrGISInvalidData.GenWithStackByArgs(fn)
+			}
+		}
+	}
+	for _, part := range g.Parts {
+		if err := part.validate(fn, srs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// primitives returns the points, linestrings and polygons of the geometry.
+func (g Geometry) primitives(buf []Geometry) []Geometry {
+	switch g.Type {
+	case GeometryTypePoint, GeometryTypeLineString, GeometryTypePolygon:
+		return append(buf, g)
+	}
+	for _, part := range g.Parts {
+		buf = part.primitives(buf)
+	}
+	return buf
+}
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+)
+
+type wkbReader struct {
+	data []byte
+	pos  int
+	swap bool
+}
+
+func (r *wkbReader) readUint32(order binary.ByteOrder) (uint32, bool) {
+	if r.pos+4 > len(r.data) {
+		return 0, false
+	}
+	v := order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, true
+}
+
+func (r *wkbReader) readPoints(order binary.ByteOrder, n uint32) ([]GeomPoint, bool) {
+	if uint64(len(r.data)-r.pos) < uint64(n)*16 {
+		return nil, false
+	}
+	points := make([]GeomPoint, n)
+	for i := range points {
+		x := math.Float64frombits(order.Uint64(r.data[r.pos:]))
+		y := math.Float64frombits(order.Uint64(r.data[r.pos+8:]))
+		if r.swap {
+			x, y = y, x
+		}
+		points[i] = GeomPoint{X: x, Y: y}
+		r.pos += 16
+	}
+	return points, true
+}
+
+func (r *wkbReader) readGeometry(srid uint32, depth int) (Geometry, bool) {
+	if depth > maxGeometryDepth || r.pos >= len(r.data) {
+		return Geometry{}, false
+	}
+	var order binary.ByteOrder
+	switch r.data[r.pos] {
+	case wkbBigEndian:
+		order = binary.BigEndian
+	case wkbLittleEndian:
+		order = binary.LittleEndian
+	default:
+		return Geometry{}, false
+	}
+	r.pos++
+	tp, ok := r.readUint32(order)
+	if !ok || tp < uint32(GeometryTypePoint) || tp > uint32(GeometryTypeGeometryCollection) {
+		return Geometry{}, false
+	}
+	g := Geometry{SRID: srid, Type: GeometryType(tp)}
+	switch g.Type {
+	case GeometryTypePoint:
+		g.Points, ok = r.readPoints(order, 1)
+		return g, ok
+	case GeometryTypeLineString:
+		n, ok := r.readUint32(order)
+		if !ok {
+			return g, false
+		}
+		g.Points, ok = r.readPoints(order, n)
+		return g, ok
+	case GeometryTypePolygon:
+		n, ok := r.readUint32(order)
+		if !ok || uint64(len(r.data)-r.pos) < uint64(n)*4 {
+			return g, false
+		}
+		g.Parts = make([]Geometry, n)
+		for i := range g.Parts {
+			cnt, ok := r.readUint32(order)
+			if !ok {
+				return g, false
+			}
+			g.Parts[i] = Geometry{SRID: srid, Type: GeometryTypeLineString}
+			if g.Parts[i].Points, ok = r.readPoints(order, cnt); !ok {
+				return g, false
+			}
+		}
+		return g, true
+	}
+	n, ok := r.readUint32(order)
+	// Every element has at least 5 bytes header.
+	if !ok || uint64(len(r.data)-r.pos) < uint64(n)*5 {
+		return g, false
+	}
+	g.Parts = make([]Geometry, n)
+	for i := range g.Parts {
+		if g.Parts[i], ok = r.readGeometry(srid, depth+1); !ok {
+			return g, false
+		}
+	}
+	return g, true
+}
+
+func (g Geometry) wkbSize() int {
+	size := 5
+	switch g.Type {
+	case GeometryTypePoint:
+		return size + 16
+	case GeometryTypeLineString:
+		return size + 4 + 16*len(g.Points)
+	case GeometryTypePolygon:
+		size += 4
+		for _, ring := range g.Parts {
+			size += 4 + 16*len(ring.Points)
+		}
+		return size
+	}
+	size += 4
+	for _, part := range g.Parts {
+		size += part.wkbSize()
+	}
+	return size
+}
+
+func appendWKBPoints(buf []byte, points []GeomPoint, swap bool) []byte {
+	for _, p := range points {
+		x, y := p.X, p.Y
+		if swap {
+			x, y = y, x
+		}
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(x))
+		buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(y))
+	}
+	return buf
+}
+
+func (g Geometry) appendWKB(buf []byte, swap bool) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case GeometryTypePoint:
+		return appendWKBPoints(buf, g.Points, swap)
+	case GeometryTypeLineString:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Points)))
+		return appendWKBPoints(buf, g.Points, swap)
+	case GeometryTypePolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Parts)))
+		for _, ring := range g.Parts {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(len(ring.Points)))
+			buf = appendWKBPoints(buf, ring.Points, swap)
+		}
+		return buf
+	}
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Parts)))
+	for _, part := range g.Parts {
+		buf = part.appendWKB(buf, swap)
+	}
+	return buf
+}
+
+var wktGeometryTypes = map[string]GeometryType{
+	"POINT":              GeometryTypePoint,
+	"LINESTRING":         GeometryTypeLineString,
+	"POLYGON":            GeometryTypePolygon,
+	"MULTIPOINT":         GeometryTypeMultiPoint,
+	"MULTILINESTRING":    GeometryTypeMultiLineString,
+	"MULTIPOLYGON":       GeometryTypeMultiPolygon,
+	"GEOMETRYCOLLECTION": GeometryTypeGeometryCollection,
+	"GEOMCOLLECTION":     GeometryTypeGeometryCollection,
+}
+
+// ParseGeometryFromWKT parses the geometry from WKT in the axis order.
+func ParseGeometryFromWKT(fn string, wkt string, srid uint32, order AxisOrder) (Geometry, error) {
+	srs, ok := GetSpatialReferenceSystem(srid)
+	if !ok {
+		return Geometry{}, ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	p := wktParser{s: wkt, swap: srs.swapAxes(order)}
+	g, ok := p.parseGeometry(srid, 0)
+	p.skipSpaces()
+	if !ok || p.pos != len(p.s) {
+		return Geometry{}, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	if err := g.validate(fn, srs); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s    string
+	pos  int
+	swap bool
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\r\n", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// consume consumes the byte c if it's the next non-space byte.
+func (p *wktParser) consume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) peek(c byte) bool {
+	p.skipSpaces()
+	return p.pos < len(p.s) && p.s[p.pos] == c
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() (float64, bool) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	v, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	return v, err == nil
+}
+
+func (p *wktParser) point() (GeomPoint, bool) {
+	x, ok := p.number()
+	if !ok {
+		return GeomPoint{}, false
+	}
+	y, ok := p.number()
+	if p.swap {
+		x, y = y, x
+	}
+	return GeomPoint{X: x, Y: y}, ok
+}
+
+// points parses the parenthesized point list.
+func (p *wktParser) points() ([]GeomPoint, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var points []GeomPoint
+	for {
+		pt, ok := p.point()
+		if !ok {
+			return nil, false
+		}
+		points = append(points, pt)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return points, p.consume(')')
+}
+
+// list parses the parenthesized list, the elements are parsed by fn.
+func (p *wktParser) list(fn func() (Geometry, bool)) ([]Geometry, bool) {
+	if !p.consume('(') {
+		return nil, false
+	}
+	var parts []Geometry
+	for {
+		part, ok := fn()
+		if !ok {
+			return nil, false
+		}
+		parts = append(parts, part)
+		if !p.consume(',') {
+			break
+		}
+	}
+	return parts, p.consume(')')
+}
+
+func (p *wktParser) body(srid uint32, tp GeometryType, depth int) (Geometry, bool) {
+	g := Geometry{SRID: srid, Type: tp}
+	var ok bool
+	switch tp {
+	case GeometryTypePoint:
+		if !p.consume('(') {
+			return g, false
+		}
+		pt, ok := p.point()
+		g.Points = []GeomPoint{pt}
+		return g, ok && p.consume(')')
+	case GeometryTypeLineString:
+		g.Points, ok = p.points()
+	case GeometryTypePolygon:
+		g.Parts, ok = p.list(func() (Geometry, bool) {
+			return p.body(srid, GeometryTypeLineString, depth)
+		})
+	case GeometryTypeMultiPoint:
+		g.Parts, ok = p.list(func() (Geometry, bool) {
+			// The parentheses around the points are optional.
+			if p.peek('(') {
+				return p.body(srid, GeometryTypePoint, depth)
+			}
+			pt, ok := p.point()
+			return Geometry{SRID: srid, Type: GeometryTypePoint, Points: []GeomPoint{pt}}, ok
+		})
+	case GeometryTypeMultiLineString, GeometryTypeMultiPolygon:
+		g.Parts, ok = p.list(func() (Geometry, bool) {
+			return p.body(srid, tp-GeometryTypeMultiPoint+GeometryTypePoint, depth)
+		})
+	case GeometryTypeGeometryCollection:
+		if p.word() == "EMPTY" {
+			return g, true
+		}
+		start := p.pos
+		if p.consume('(') && p.consume(')') {
+			return g, true
+		}
+		p.pos = start
+		g.Parts, ok = p.list(func() (Geometry, bool) {
+			return p.parseGeometry(srid, depth+1)
+		})
+	}
+	return g, ok
+}
+
+func (p *wktParser) parseGeometry(srid uint32, depth int) (Geometry, bool) {
+	tp, ok := wktGeometryTypes[p.word()]
+	if !ok || depth > maxGeometryDepth {
+		return Geometry{}, false
+	}
+	return p.body(srid, tp, depth)
+}
+
+// WKT returns the WKT of the geometry in the axis order.
+func (g Geometry) WKT(order AxisOrder) string {
+	srs, _ := GetSpatialReferenceSystem(g.SRID)
+	return string(g.appendWKT(nil, srs.swapAxes(order)))
+}
+
+func appendGeometryCoordinate(buf []byte, v float64) []byte {
+	abs := math.Abs(v)
+	if abs == 0 {
+		return append(buf, '0')
+	}
+	if abs >= 1e-5 && abs < 1e15 {
+		return strconv.AppendFloat(buf, v, 'f', -1, 64)
+	}
+	s := strconv.FormatFloat(v, 'g', -1, 64)
+	return append(buf, strings.Replace(s, "e+", "e", 1)...)
+}
+
+func appendWKTPoints(buf []byte, points []GeomPoint, swap bool) []byte {
+	buf = append(buf, '(')
+	for i, p := range points {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		x, y := p.X, p.Y
+		if swap {
+			x, y = y, x
+		}
+		buf = appendGeometryCoordinate(buf, x)
+		buf = append(buf, ' ')
+		buf = appendGeometryCoordinate(buf, y)
+	}
+	return append(buf, ')')
+}
+
+func (g Geometry) appendWKTBody(buf []byte, swap bool) []byte {
+	switch g.Type {
+	case GeometryTypePoint, GeometryTypeLineString:
+		return appendWKTPoints(buf, g.Points, swap)
+	case GeometryTypeGeometryCollection:
+		if len(g.Parts) == 0 {
+			return append(buf, " EMPTY"...)
+		}
+	}
+	buf = append(buf, '(')
+	for i, part := range g.Parts {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		if g.Type == GeometryTypeGeometryCollection {
+			buf = part.appendWKT(buf, swap)
+		} else {
+			buf = part.appendWKTBody(buf, swap)
+		}
+	}
+	return append(buf, ')')
+}
+
+func (g Geometry) appendWKT(buf []byte, swap bool) []byte {
+	if g.Type == GeometryTypeGeometryCollection {
+		buf = append(buf, "GEOMETRYCOLLECTION"...)
+	} else {
+		buf = append(buf, g.TypeName()...)
+	}
+	return g.appendWKTBody(buf, swap)
+}
+
+var geoJSONTypeNames = [...]string{
+	GeometryTypePoint:              "Point",
+	GeometryTypeLineString:         "LineString",
+	GeometryTypePolygon:            "Polygon",
+	GeometryTypeMultiPoint:         "MultiPoint",
+	GeometryTypeMultiLineString:    "MultiLineString",
+	GeometryTypeMultiPolygon:       "MultiPolygon",
+	GeometryTypeGeometryCollection: "GeometryCollection",
+}
+
+var geoJSONTypes = map[string]GeometryType{
+	"Point":              GeometryTypePoint,
+	"LineString":         GeometryTypeLineString,
+	"Polygon":            GeometryTypePolygon,
+	"MultiPoint":         GeometryTypeMultiPoint,
+	"MultiLineString":    GeometryTypeMultiLineString,
+	"MultiPolygon":       GeometryTypeMultiPolygon,
+	"GeometryCollection": GeometryTypeGeometryCollection,
+}
+
+// GeoJSON options of ST_AsGeoJSON.
+const (
+	GeoJSONOptionBBox     = 1
+	GeoJSONOptionShortCRS = 2
+	GeoJSONOptionLongCRS  = 4
+)
+
+// GeoJSON returns the GeoJSON of the geometry, the coordinates are rounded to
+// maxDecimals digits after the decimal point.
+func (g Geometry) GeoJSON(maxDecimals int, options int) BinaryJSON {
+	obj := g.geoJSONObject(maxDecimals)
+	if options&GeoJSONOptionBBox != 0 && !g.IsEmpty() {
+		minP, maxP := g.envelope()
+		obj["bbox"] = []any{roundCoordinate(minP.X, maxDecimals), roundCoordinate(minP.Y, maxDecimals),
+			roundCoordinate(maxP.X, maxDecimals), roundCoordinate(maxP.Y, maxDecimals)}
+	}
+	if options&(GeoJSONOptionShortCRS|GeoJSONOptionLongCRS) != 0 && g.SRID != 0 {
+		name := "EPSG:" + strconv.FormatUint(uint64(g.SRID), 10)
+		if options&GeoJSONOptionLongCRS != 0 {
+			name = "urn:ogc:def:crs:EPSG::" + strconv.FormatUint(uint64(g.SRID), 10)
+		}
+		obj["crs"] = map[string]any{"type": "name", "properties": map[string]any{"name": name}}
+	}
+	return CreateBinaryJSON(obj)
+}
+
+func roundCoordinate(v float64, maxDecimals int) float64 {
+	if maxDecimals >= 17 {
+		return v
+	}
+	pow := math.Pow10(maxDecimals)
+	if r := math.Round(v*pow) / pow; !math.IsInf(r, 0) && !math.IsNaN(r) {
+		return r
+	}
+	return v
+}
+
+func geoJSONCoordinates(points []GeomPoint, maxDecimals int) []any {
+	coords := make([]any, 0, len(points))
+	for _, p := range points {
+		coords = append(coords, []any{roundCoordinate(p.X, maxDecimals), roundCoordinate(p.Y, maxDecimals)})
+	}
+	return coords
+}
+
+func (g Geometry) geoJSONCoordinates(maxDecimals int) any {
+	switch g.Type {
+	case GeometryTypePoint:
+		return geoJSONCoordinates(g.Points, maxDecimals)[0]
+	case GeometryTypeLineString:
+		return geoJSONCoordinates(g.Points, maxDecimals)
+	}
+	coords := make([]any, 0, len(g.Parts))
+	for _, part := range g.Parts {
+		coords = append(coords, part.geoJSONCoordinates(maxDecimals))
+	}
+	return coords
+}
+
+func (g Geometry) geoJSONObject(maxDecimals int) map[string]any {
+	obj := map[string]any{"type": geoJSONTypeNames[g.Type]}
+	if g.Type != GeometryTypeGeometryCollection {
+		obj["coordinates"] = g.geoJSONCoordinates(maxDecimals)
+		return obj
+	}
+	geometries := make([]any, 0, len(g.Parts))
+	for _, part := range g.Parts {
+		geometries = append(geometries, part.geoJSONObject(maxDecimals))
+	}
+	obj["geometries"] = geometries
+	return obj
+}
+
+// ParseGeometryFromGeoJSON parses the geometry from GeoJSON. The coordinates
+// with more than 2 dimensions are rejected if rejectHigherDims is true,
+// otherwise the higher dimensions are ignored.
+func ParseGeometryFromGeoJSON(fn string, j BinaryJSON, srid uint32, rejectHigherDims bool) (Geometry, error) {
+	srs, ok := GetSpatialReferenceSystem(srid)
+	if !ok {
+		return Geometry{}, ErrSRSNotFound.GenWithStackByArgs(srid)
+	}
+	p := geoJSONParser{srid: srid, rejectHigherDims: rejectHigherDims}
+	g, ok := p.parseObject(j, 0)
+	if !ok {
+		return Geometry{}, ErrGISInvalidData.GenWithStackByArgs(fn)
+	}
+	if err := g.validate(fn, srs); err != nil {
+		return Geometry{}, err
+	}
+	return g, nil
+}
+
+type geoJSONParser struct {
+	srid             uint32
+	rejectHigherDims bool
+}
+
+func (p *geoJSONParser) member(obj BinaryJSON, key string) (BinaryJSON, bool) {
+	if obj.TypeCode != JSONTypeCodeObject {
+		return BinaryJSON{}, false
+	}
+	return obj.objectSearchKey([]byte(key))
+}
+
+func jsonNumber(j BinaryJSON) (float64, bool) {
+	switch j.TypeCode {
+	case JSONTypeCodeFloat64:
+		return j.GetFloat64(), true
+	case JSONTypeCodeInt64:
+		return float64(j.GetInt64()), true
+	case JSONTypeCodeUint64:
+		return float64(j.GetUint64()), true
+	}
+	return 0, false
+}
+
+func (p *geoJSONParser) point(j BinaryJSON) (GeomPoint, bool) {
+	if j.TypeCode != JSONTypeCodeArray || j.GetElemCount() < 2 || (p.rejectHigherDims && j.GetElemCount() > 2) {
+		return GeomPoint{}, false
+	}
+	for i := range j.GetElemCount() {
+		if _, ok := jsonNumber(j.ArrayGetElem(i)); !ok {
+			return GeomPoint{}, false
+		}
+	}
+	x, _ := jsonNumber(j.ArrayGetElem(0))
+	y, _ := jsonNumber(j.ArrayGetElem(1))
+	return GeomPoint{X: x, Y: y}, true
+}
+
+func (p *geoJSONParser) points(j BinaryJSON) ([]GeomPoint, bool) {
+	if j.TypeCode != JSONTypeCodeArray {
+		return nil, false
+	}
+	points := make([]GeomPoint, 0, j.GetElemCount())
+	for i := range j.GetElemCount() {
+		pt, ok := p.point(j.ArrayGetElem(i))
+		if !ok {
+			return nil, false
+		}
+		points = append(points, pt)
+	}
+	return points, true
+}
+
+func (p *geoJSONParser) coordinates(tp GeometryType, j BinaryJSON) (Geometry, bool) {
+	g := Geometry{SRID: p.srid, Type: tp}
+	var ok bool
+	switch tp {
+	case GeometryTypePoint:
+		var pt GeomPoint
+		pt, ok = p.point(j)
+		g.Points = []GeomPoint{pt}
+	case GeometryTypeLineString:
+		g.Points, ok = p.points(j)
+	default:
+		if j.TypeCode != JSONTypeCodeArray {
+			return g, false
+		}
+		partType := GeometryTypeLineString
+		if tp != GeometryTypePolygon {
+			partType = tp - GeometryTypeMultiPoint + GeometryTypePoint
+		}
+		g.Parts = make([]Geometry, j.GetElemCount())
+		for i := range g.Parts {
+			if g.Parts[i], ok = p.coordinates(partType, j.ArrayGetElem(i)); !ok {
+				return g, false
+			}
+		}
+		ok = true
+	}
+	return g, ok
+}
+
+func (p *geoJSONParser) parseObject(obj BinaryJSON, depth int) (Geometry, bool) {
+	tpVal, ok := p.member(obj, "type")
+	if !ok || tpVal.TypeCode != JSONTypeCodeString || depth > maxGeometryDepth {
+		return Geometry{}, false
+	}
+	switch name := string(tpVal.GetString()); name {
+	case "Feature":
+		geom, ok := p.member(obj, "geometry")
+		if !ok {
+			return Geometry{}, false
+		}
+		return p.parseObject(geom, depth+1)
+	case "FeatureCollection":
+		features, ok := p.member(obj, "features")
+		if !ok || features.TypeCode != JSONTypeCodeArray {
+			return Geometry{}, false
+		}
+		g := Geometry{SRID: p.srid, Type: GeometryTypeGeometryCollection, Parts: make([]Geometry, features.GetElemCount())}
+		for i := range g.Parts {
+			if g.Parts[i], ok = p.parseObject(features.ArrayGetElem(i), depth+1); !ok {
+				return g, false
+			}
+		}
+		return g, true
+	case "GeometryCollection":
+		geometries, ok := p.member(obj, "geometries")
+		if !ok || geometries.TypeCode != JSONTypeCodeArray {
+			return Geometry{}, false
+		}
+		g := Geometry{SRID: p.srid, Type: GeometryTypeGeometryCollection, Parts: make([]Geometry, geometries.GetElemCount())}
+		for i := range g.Parts {
+			if g.Parts[i], ok = p.parseObject(geometries.ArrayGetElem(i), depth+1); !ok {
+				return g, false
+			}
+		}
+		return g, true
+	default:
+		tp, ok := geoJSONTypes[name]
+		if !ok {
+			return Geometry{}, false
+		}
+		coords, ok := p.member(obj, "coordinates")
+		if !ok {
+			return Geometry{}, false
+		}
+		return p.coordinates(tp, coords)
+	}
+}
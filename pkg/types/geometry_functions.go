@@ -0,0 +1,613 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"math"
+	"slices"
+)
+
+// The relations of the geometries are computed on the plane. For the
+// geographic spatial reference systems, the plane is the longitude-latitude
+// plane, which is accurate enough for the geometries that don't cross the
+// antimeridian or the poles.
+
+// Locations of a point relative to a geometry.
+const (
+	locExterior = iota
+	locBoundary
+	locInterior
+)
+
+// DefaultSphereRadius is the default radius of ST_Distance_Sphere in meters.
+const DefaultSphereRadius = 6370986
+
+// geometryEpsilon is the relative tolerance of the collinearity test.
+const geometryEpsilon = 1e-12
+
+func (p GeomPoint) sub(q GeomPoint) GeomPoint {
+	return GeomPoint{X: p.X - q.X, Y: p.Y - q.Y}
+}
+
+func (p GeomPoint) lerp(q GeomPoint, t float64) GeomPoint {
+	return GeomPoint{X: p.X + (q.X-p.X)*t, Y: p.Y + (q.Y-p.Y)*t}
+}
+
+func cross(a, b GeomPoint) float64 {
+	return a.X*b.Y - a.Y*b.X
+}
+
+func dot(a, b GeomPoint) float64 {
+	return a.X*b.X + a.Y*b.Y
+}
+
+func (p GeomPoint) distance(q GeomPoint) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+// onSegment returns whether p is on the segment ab.
+func onSegment(p, a, b GeomPoint) bool {
+	ab, ap := b.sub(a), p.sub(a)
+	if math.Abs(cross(ab, ap)) > geometryEpsilon*math.Hypot(ab.X, ab.Y)*math.Hypot(ap.X, ap.Y) {
+		return false
+	}
+	d := dot(ab, ap)
+	return d >= 0 && d <= dot(ab, ab)
+}
+
+// segmentDistance returns the distance from p to the segment ab.
+func segmentDistance(p, a, b GeomPoint) float64 {
+	ab := b.sub(a)
+	l := dot(ab, ab)
+	if l == 0 {
+		return p.distance(a)
+	}
+	t := math.Max(0, math.Min(1, dot(p.sub(a), ab)/l))
+	return p.distance(a.lerp(b, t))
+}
+
+// segmentIntersections returns the parameters on the segment ab of its
+// intersections with the segment cd.
+func segmentIntersections(buf []float64, a, b, c, d GeomPoint) []float64 {
+	ab, cd, ac := b.sub(a), d.sub(c), c.sub(a)
+	denom := cross(ab, cd)
+	if denom != 0 {
+		t, u := cross(ac, cd)/denom, cross(ac, ab)/denom
+		if t >= 0 && t <= 1 && u >= 0 && u <= 1 {
+			buf = append(buf, t)
+		}
+		return buf
+	}
+	l := dot(ab, ab)
+	if l == 0 || !onLine(c, a, b) {
+		return buf
+	}
+	// The segments are collinear, returns the overlapped interval.
+	t0, t1 := dot(ac, ab)/l, dot(d.sub(a), ab)/l
+	if t0 > t1 {
+		t0, t1 = t1, t0
+	}
+	if t0 <= 1 && t1 >= 0 {
+		buf = append(buf, math.Max(t0, 0), math.Min(t1, 1))
+	}
+	return buf
+}
+
+func onLine(p, a, b GeomPoint) bool {
+	ab, ap := b.sub(a), p.sub(a)
+	return math.Abs(cross(ab, ap)) <= geometryEpsilon*math.Hypot(ab.X, ab.Y)*math.Hypot(ap.X, ap.Y)
+}
+
+func segmentsIntersect(a, b, c, d GeomPoint) bool {
+	return len(segmentIntersections(nil, a, b, c, d)) > 0 || onSegment(a, c, d) || onSegment(c, a, b)
+}
+
+// locateInRing returns the location of p relative to the area of the ring.
+func locateInRing(p GeomPoint, ring []GeomPoint) int {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return locBoundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < a.X+(p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			inside = !inside
+		}
+	}
+	if inside {
+		return locInterior
+	}
+	return locExterior
+}
+
+// locate returns the location of p relative to the point, linestring or
+// polygon.
+func (g Geometry) locate(p GeomPoint) int {
+	switch g.Type {
+	case GeometryTypePoint:
+		if g.Points[0] == p {
+			return locInterior
+		}
+	case GeometryTypeLineString:
+		first, last := g.Points[0], g.Points[len(g.Points)-1]
+		if first != last && (p == first || p == last) {
+			return locBoundary
+		}
+		for i := 1; i < len(g.Points); i++ {
+			if onSegment(p, g.Points[i-1], g.Points[i]) {
+				return locInterior
+			}
+		}
+	case GeometryTypePolygon:
+		loc := locateInRing(p, g.Parts[0].Points)
+		if loc != locInterior {
+			return loc
+		}
+		for _, hole := range g.Parts[1:] {
+			switch locateInRing(p, hole.Points) {
+			case locBoundary:
+				return locBoundary
+			case locInterior:
+				return locExterior
+			}
+		}
+		return locInterior
+	}
+	return locExterior
+}
+
+// locatePrimitives returns the location of p relative to the union of the
+// primitives.
+func locatePrimitives(p GeomPoint, prims []Geometry) int {
+	loc := locExterior
+	for _, prim := range prims {
+		loc = max(loc, prim.locate(p))
+		if loc == locInterior {
+			break
+		}
+	}
+	return loc
+}
+
+// vertices returns all the points of the primitive.
+func (g Geometry) vertices() []GeomPoint {
+	if g.Type != GeometryTypePolygon {
+		return g.Points
+	}
+	var points []GeomPoint
+	for _, ring := range g.Parts {
+		points = append(points, ring.Points...)
+	}
+	return points
+}
+
+// forEachSegment calls fn with every segment of the primitive until fn
+// returns false.
+func (g Geometry) forEachSegment(fn func(a, b GeomPoint) bool) bool {
+	lines := [][]GeomPoint{g.Points}
+	if g.Type == GeometryTypePolygon {
+		lines = lines[:0]
+		for _, ring := range g.Parts {
+			lines = append(lines, ring.Points)
+		}
+	}
+	for _, line := range lines {
+		for i := 1; i < len(line); i++ {
+			if !fn(line[i-1], line[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// forEachSubSegmentMidpoint splits the segment ab at its intersections with
+// the segments of the primitives, and calls fn with the midpoints of the
+// sub-segments until fn returns false.
+func forEachSubSegmentMidpoint(a, b GeomPoint, prims []Geometry, fn func(GeomPoint) bool) bool {
+	params := []float64{0, 1}
+	for _, prim := range prims {
+		prim.forEachSegment(func(c, d GeomPoint) bool {
+			params = segmentIntersections(params, a, b, c, d)
+			return true
+		})
+	}
+	slices.Sort(params)
+	for i := 1; i < len(params); i++ {
+		if params[i] > params[i-1] && !fn(a.lerp(b, (params[i-1]+params[i])/2)) {
+			return false
+		}
+	}
+	return true
+}
+
+// covers returns whether every point of b is in the closure of a.
+func covers(primsA, primsB []Geometry) bool {
+	if len(primsA) == 0 || len(primsB) == 0 {
+		return false
+	}
+	notExterior := func(p GeomPoint) bool {
+		return locatePrimitives(p, primsA) != locExterior
+	}
+	hasPolygon := slices.ContainsFunc(primsA, func(g Geometry) bool { return g.Type == GeometryTypePolygon })
+	for _, primB := range primsB {
+		if !all(primB.vertices(), notExterior) {
+			return false
+		}
+		if !primB.forEachSegment(func(c, d GeomPoint) bool {
+			return forEachSubSegmentMidpoint(c, d, primsA, notExterior)
+		}) {
+			return false
+		}
+		if primB.Type != GeometryTypePolygon {
+			continue
+		}
+		// The boundary of a mustn't pass through the interior of the polygon.
+		if !hasPolygon {
+			return false
+		}
+		notInteriorOfB := func(p GeomPoint) bool {
+			return primB.locate(p) != locInterior
+		}
+		for _, primA := range primsA {
+			if primA.Type != GeometryTypePolygon {
+				continue
+			}
+			if !all(primA.vertices(), notInteriorOfB) {
+				return false
+			}
+			if !primA.forEachSegment(func(c, d GeomPoint) bool {
+				return forEachSubSegmentMidpoint(c, d, []Geometry{primB}, notInteriorOfB)
+			}) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func all(points []GeomPoint, fn func(GeomPoint) bool) bool {
+	for _, p := range points {
+		if !fn(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// interiorsIntersect returns whether some point of b is in the interior of
+// a, it's only called when a covers b.
+func interiorsIntersect(primsA, primsB []Geometry) bool {
+	interior := func(p GeomPoint) bool {
+		return locatePrimitives(p, primsA) == locInterior
+	}
+	for _, primB := range primsB {
+		// The interior of the polygon is in the closure of a, so it
+		// intersects the interior of a.
+		if primB.Type == GeometryTypePolygon || slices.ContainsFunc(primB.vertices(), interior) {
+			return true
+		}
+		if !primB.forEachSegment(func(c, d GeomPoint) bool {
+			return forEachSubSegmentMidpoint(c, d, primsA, func(p GeomPoint) bool { return !interior(p) })
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkSameSRID(fn string, a, b Geometry) error {
+	if a.SRID != b.SRID {
+		return ErrGISDifferentSRIDs.GenWithStackByArgs(fn, a.SRID, b.SRID)
+	}
+	return nil
+}
+
+// Contains returns whether b is in a and their interiors intersect.
+func (g Geometry) Contains(fn string, b Geometry) (bool, error) {
+	if err := checkSameSRID(fn, g, b); err != nil {
+		return false, err
+	}
+	primsA, primsB := g.primitives(nil), b.primitives(nil)
+	return covers(primsA, primsB) && interiorsIntersect(primsA, primsB), nil
+}
+
+// Equals returns whether the geometries are spatially equal.
+func (g Geometry) Equals(fn string, b Geometry) (bool, error) {
+	if err := checkSameSRID(fn, g, b); err != nil {
+		return false, err
+	}
+	if g.IsEmpty() || b.IsEmpty() {
+		return g.IsEmpty() && b.IsEmpty(), nil
+	}
+	primsA, primsB := g.primitives(nil), b.primitives(nil)
+	return covers(primsA, primsB) && covers(primsB, primsA), nil
+}
+
+// Intersects returns whether the geometries have at least one point in common.
+func (g Geometry) Intersects(fn string, b Geometry) (bool, error) {
+	if err := checkSameSRID(fn, g, b); err != nil {
+		return false, err
+	}
+	return intersects(g.primitives(nil), b.primitives(nil)), nil
+}
+
+func intersects(primsA, primsB []Geometry) bool {
+	for _, primA := range primsA {
+		for _, primB := range primsB {
+			if slices.ContainsFunc(primA.vertices(), func(p GeomPoint) bool { return primB.locate(p) != locExterior }) ||
+				slices.ContainsFunc(primB.vertices(), func(p GeomPoint) bool { return primA.locate(p) != locExterior }) {
+				return true
+			}
+			if !primA.forEachSegment(func(a, b GeomPoint) bool {
+				return primB.forEachSegment(func(c, d GeomPoint) bool {
+					return !segmentsIntersect(a, b, c, d)
+				})
+			}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (g Geometry) pointsOnly() bool {
+	return g.Type == GeometryTypePoint || g.Type == GeometryTypeMultiPoint
+}
+
+// Distance returns the minimum distance between the geometries, the distance
+// of the geographic spatial reference system is the geodesic distance in
+// meters. The second return value is false if any of the geometries is empty.
+func (g Geometry) Distance(fn string, b Geometry) (float64, bool, error) {
+	if err := checkSameSRID(fn, g, b); err != nil {
+		return 0, false, err
+	}
+	primsA, primsB := g.primitives(nil), b.primitives(nil)
+	if len(primsA) == 0 || len(primsB) == 0 {
+		return 0, false, nil
+	}
+	srs, _ := GetSpatialReferenceSystem(g.SRID)
+	if srs != nil && srs.Geographic {
+		if !g.pointsOnly() || !b.pointsOnly() {
+			return 0, false, ErrNotImplementedForGeographicSRS.GenWithStackByArgs(fn, g.TypeName()+", "+b.TypeName())
+		}
+		dist := math.Inf(1)
+		for _, pa := range primsA {
+			for _, pb := range primsB {
+				dist = math.Min(dist, srs.geodesicDistance(pa.Points[0], pb.Points[0]))
+			}
+		}
+		return dist, true, nil
+	}
+	if intersects(primsA, primsB) {
+		return 0, true, nil
+	}
+	return math.Min(minDistance(primsA, primsB), minDistance(primsB, primsA)), true, nil
+}
+
+// minDistance returns the min distance from the vertices of a to b.
+func minDistance(primsA, primsB []Geometry) float64 {
+	dist := math.Inf(1)
+	for _, primA := range primsA {
+		for _, p := range primA.vertices() {
+			for _, primB := range primsB {
+				if primB.Type == GeometryTypePoint {
+					dist = math.Min(dist, p.distance(primB.Points[0]))
+					continue
+				}
+				primB.forEachSegment(func(c, d GeomPoint) bool {
+					dist = math.Min(dist, segmentDistance(p, c, d))
+					return true
+				})
+			}
+		}
+	}
+	return dist
+}
+
+// geodesicDistance returns the distance on the ellipsoid in meters by the
+// Andoyer-Lambert formula, which is accurate to a few meters.
+func (srs *SpatialReferenceSystem) geodesicDistance(p, q GeomPoint) float64 {
+	if p == q {
+		return 0
+	}
+	lon1, lat1 := p.X*math.Pi/180, p.Y*math.Pi/180
+	lon2, lat2 := q.X*math.Pi/180, q.Y*math.Pi/180
+	f := 1 / srs.InverseFlattening
+	sinLat1, cosLat1 := math.Sincos(lat1)
+	sinLat2, cosLat2 := math.Sincos(lat2)
+	cosD := math.Max(-1, math.Min(1, sinLat1*sinLat2+cosLat1*cosLat2*math.Cos(lon2-lon1)))
+	d := math.Acos(cosD)
+	sinD := math.Sin(d)
+	k := (sinLat1 - sinLat2) * (sinLat1 - sinLat2)
+	l := (sinLat1 + sinLat2) * (sinLat1 + sinLat2)
+	var h, gg float64
+	if 1-cosD != 0 {
+		h = (d + 3*sinD) / (1 - cosD)
+	}
+	if 1+cosD != 0 {
+		gg = (d - 3*sinD) / (1 + cosD)
+	}
+	return srs.SemiMajorAxis * (d - f/4*(h*k+gg*l))
+}
+
+// DistanceSphere returns the minimum spherical distance between the points or
+// multipoints in meters. The coordinates of the SRID 0 are interpreted as the
+// longitudes and latitudes.
+func (g Geometry) DistanceSphere(fn string, b Geometry, radius float64) (float64, error) {
+	if err := checkSameSRID(fn, g, b); err != nil {
+		return 0, err
+	}
+	if !g.pointsOnly() || !b.pointsOnly() {
+		return 0, ErrGISUnsupportedArgument.GenWithStackByArgs(fn)
+	}
+	if srs, _ := GetSpatialReferenceSystem(g.SRID); srs == nil || (g.SRID != 0 && !srs.Geographic) {
+		return 0, ErrGISUnsupportedArgument.GenWithStackByArgs(fn)
+	}
+	if radius <= 0 {
+		return 0, ErrNonPositiveRadius.GenWithStackByArgs(fn)
+	}
+	geographic, _ := GetSpatialReferenceSystem(4326)
+	primsA, primsB := g.primitives(nil), b.primitives(nil)
+	for _, prim := range append(slices.Clip(primsA), primsB...) {
+		if err := prim.validate(fn, geographic); err != nil {
+			return 0, err
+		}
+	}
+	dist := math.Inf(1)
+	for _, pa := range primsA {
+		for _, pb := range primsB {
+			dist = math.Min(dist, haversine(pa.Points[0], pb.Points[0], radius))
+		}
+	}
+	return dist, nil
+}
+
+func haversine(p, q GeomPoint, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat, dLon := lat2-lat1, (q.X-p.X)*math.Pi/180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func ringArea(ring []GeomPoint) float64 {
+	var area float64
+	for i := 1; i < len(ring); i++ {
+		area += cross(ring[i-1], ring[i])
+	}
+	return math.Abs(area) / 2
+}
+
+// Area returns the area of the polygon or multipolygon, the area of the
+// polygons in a geometry collection are summed up.
+func (g Geometry) Area(fn string) (float64, error) {
+	if g.Type != GeometryTypePolygon && g.Type != GeometryTypeMultiPolygon && g.Type != GeometryTypeGeometryCollection {
+		return 0, ErrGISUnsupportedArgument.GenWithStackByArgs(fn)
+	}
+	if srs, _ := GetSpatialReferenceSystem(g.SRID); srs != nil && srs.Geographic {
+		return 0, ErrNotImplementedForGeographicSRS.GenWithStackByArgs(fn, g.TypeName())
+	}
+	var area float64
+	for _, prim := range g.primitives(nil) {
+		if prim.Type != GeometryTypePolygon {
+			continue
+		}
+		area += ringArea(prim.Parts[0].Points)
+		for _, hole := range prim.Parts[1:] {
+			area -= ringArea(hole.Points)
+		}
+	}
+	return area, nil
+}
+
+// Length returns the length of the linestring or multilinestring, the length
+// of the geographic spatial reference system is the geodesic length in meters.
+func (g Geometry) Length(fn string) (float64, error) {
+	if g.Type != GeometryTypeLineString && g.Type != GeometryTypeMultiLineString {
+		return 0, ErrGISUnsupportedArgument.GenWithStackByArgs(fn)
+	}
+	srs, _ := GetSpatialReferenceSystem(g.SRID)
+	var length float64
+	for _, prim := range g.primitives(nil) {
+		prim.forEachSegment(func(a, b GeomPoint) bool {
+			if srs != nil && srs.Geographic {
+				length += srs.geodesicDistance(a, b)
+			} else {
+				length += a.distance(b)
+			}
+			return true
+		})
+	}
+	return length, nil
+}
+
+// envelope returns the min and max coordinates of the geometry.
+func (g Geometry) envelope() (minP, maxP GeomPoint) {
+	minP = GeomPoint{X: math.Inf(1), Y: math.Inf(1)}
+	maxP = GeomPoint{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, prim := range g.primitives(nil) {
+		for _, p := range prim.vertices() {
+			minP = GeomPoint{X: math.Min(minP.X, p.X), Y: math.Min(minP.Y, p.Y)}
+			maxP = GeomPoint{X: math.Max(maxP.X, p.X), Y: math.Max(maxP.Y, p.Y)}
+		}
+	}
+	return minP, maxP
+}
+
+// GeomBox is an axis-aligned bounding box. In the geographic spatial reference
+// systems, X is the longitude and Y is the latitude in degrees.
+type GeomBox struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// BoundingBox returns the bounding box of the geometry. The second return value
+// is false if the geometry is empty.
+func (g Geometry) BoundingBox() (GeomBox, bool) {
+	minP, maxP := g.envelope()
+	if minP.X > maxP.X || minP.Y > maxP.Y {
+		return GeomBox{}, false
+	}
+	return GeomBox{MinX: minP.X, MinY: minP.Y, MaxX: maxP.X, MaxY: maxP.Y}, true
+}
+
+// Area returns the area of the box.
+func (b GeomBox) Area() float64 {
+	return (b.MaxX - b.MinX) * (b.MaxY - b.MinY)
+}
+
+// Expand returns a box covering all the points within the planar distance dist
+// from the box.
+func (b GeomBox) Expand(dist float64) GeomBox {
+	return GeomBox{MinX: b.MinX - dist, MinY: b.MinY - dist, MaxX: b.MaxX + dist, MaxY: b.MaxY + dist}
+}
+
+// ExpandOnSphere returns a box of longitudes and latitudes covering all the
+// points within the distance dist from the box on a sphere of the radius.
+func (b GeomBox) ExpandOnSphere(dist, radius float64) GeomBox {
+	theta := dist / radius * 180 / math.Pi
+	ret := GeomBox{MinX: -180, MinY: b.MinY - theta, MaxX: 180, MaxY: b.MaxY + theta}
+	if ret.MinY <= -90 || ret.MaxY >= 90 {
+		// The distance reaches a pole, so all the longitudes are covered.
+		ret.MinY, ret.MaxY = math.Max(ret.MinY, -90), math.Min(ret.MaxY, 90)
+		return ret
+	}
+	maxLat := math.Max(math.Abs(b.MinY), math.Abs(b.MaxY)) * math.Pi / 180
+	sinDLon := math.Sin(dist/radius) / math.Cos(maxLat)
+	if sinDLon >= 1 {
+		return ret
+	}
+	dLon := math.Asin(sinDLon) * 180 / math.Pi
+	if b.MinX-dLon < -180 || b.MaxX+dLon > 180 {
+		// The box crosses the antimeridian.
+		return ret
+	}
+	ret.MinX, ret.MaxX = b.MinX-dLon, b.MaxX+dLon
+	return ret
+}
+
+// ExpandByDistance returns a box covering all the points whose distance from
+// the box is within dist, measured the same as Distance.
+func (b GeomBox) ExpandByDistance(srid uint32, dist float64) GeomBox {
+	srs, _ := GetSpatialReferenceSystem(srid)
+	if srs == nil || !srs.Geographic {
+		return b.Expand(dist)
+	}
+	// The geodesic distance is no less than the distance on the sphere of the
+	// minimum radius of curvature, and a margin covers the approximation of
+	// geodesicDistance.
+	f := 1 / srs.InverseFlattening
+	radius := srs.SemiMajorAxis * (1 - f) * (1 - f) * 0.99
+	return b.ExpandOnSphere(dist, radius)
+}
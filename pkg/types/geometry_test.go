@@ -0,0 +1,221 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryWKT(t *testing.T) {
+	tests := []struct {
+		wkt    string
+		output string
+		tp     types.GeometryType
+	}{
+		{"POINT(1 2)", "POINT(1 2)", types.GeometryTypePoint},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)", types.GeometryTypePoint},
+		{"LINESTRING(0 0,1 1,2 0)", "LINESTRING(0 0,1 1,2 0)", types.GeometryTypeLineString},
+		{"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))", types.GeometryTypePolygon},
+		{"MULTIPOINT(1 1,2 2)", "MULTIPOINT((1 1),(2 2))", types.GeometryTypeMultiPoint},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))", types.GeometryTypeMultiPoint},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))", types.GeometryTypeMultiLineString},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))", types.GeometryTypeMultiPolygon},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", types.GeometryTypeGeometryCollection},
+		{"GEOMCOLLECTION()", "GEOMETRYCOLLECTION EMPTY", types.GeometryTypeGeometryCollection},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY", types.GeometryTypeGeometryCollection},
+	}
+	for _, tt := range tests {
+		g, err := types.ParseGeometryFromWKT("st_geomfromtext", tt.wkt, 0, types.AxisOrderSRIDDefined)
+		require.NoError(t, err, tt.wkt)
+		require.Equal(t, tt.tp, g.Type, tt.wkt)
+		require.Equal(t, tt.output, g.WKT(types.AxisOrderSRIDDefined), tt.wkt)
+
+		// The storage format and WKB can be decoded to the same geometry.
+		decoded, err := types.ParseGeometry("", g.Encode())
+		require.NoError(t, err)
+		require.Equal(t, g.Encode(), decoded.Encode())
+		decoded, err = types.ParseGeometryFromWKB("", g.WKB(types.AxisOrderSRIDDefined), 0, types.AxisOrderSRIDDefined)
+		require.NoError(t, err)
+		require.Equal(t, g.Encode(), decoded.Encode())
+	}
+
+	invalid := []string{
+		"",
+		"POINT",
+		"POINT(1)",
+		"POINT(1 2",
+		"POINT(1 2) x",
+		"LINESTRING(0 0)",
+		"POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))",
+		"CIRCLE(0 0)",
+		"MULTIPOINT(POINT(1 1))",
+	}
+	for _, wkt := range invalid {
+		_, err := types.ParseGeometryFromWKT("st_geomfromtext", wkt, 0, types.AxisOrderSRIDDefined)
+		require.True(t, types.ErrGISInvalidData.Equal(err), wkt)
+	}
+
+	_, err := types.ParseGeometryFromWKT("st_geomfromtext", "POINT(1 2)", 1234, types.AxisOrderSRIDDefined)
+	require.True(t, types.ErrSRSNotFound.Equal(err))
+}
+
+func TestGeometryGeographicAxisOrder(t *testing.T) {
+	// SRID 4326 is in the latitude-longitude order.
+	g, err := types.ParseGeometryFromWKT("st_geomfromtext", "POINT(30 120)", 4326, types.AxisOrderSRIDDefined)
+	require.NoError(t, err)
+	require.Equal(t, []types.GeomPoint{{X: 120, Y: 30}}, g.Points)
+	require.Equal(t, "POINT(30 120)", g.WKT(types.AxisOrderSRIDDefined))
+	require.Equal(t, "POINT(120 30)", g.WKT(types.AxisOrderLongLat))
+
+	g2, err := types.ParseGeometryFromWKT("st_geomfromtext", "POINT(120 30)", 4326, types.AxisOrderLongLat)
+	require.NoError(t, err)
+	require.Equal(t, g, g2)
+
+	order, err := types.ParseAxisOrder("st_astext", "axis-order=long-lat")
+	require.NoError(t, err)
+	require.Equal(t, types.AxisOrderLongLat, order)
+	_, err = types.ParseAxisOrder("st_astext", "axis-order=north-east")
+	require.True(t, types.ErrGISInvalidData.Equal(err))
+
+	_, err = types.ParseGeometryFromWKT("st_geomfromtext", "POINT(91 0)", 4326, types.AxisOrderSRIDDefined)
+	require.True(t, types.ErrLatitudeOutOfRange.Equal(err))
+	_, err = types.ParseGeometryFromWKT("st_geomfromtext", "POINT(0 181)", 4326, types.AxisOrderSRIDDefined)
+	require.True(t, types.ErrLongitudeOutOfRange.Equal(err))
+}
+
+func TestGeometryGeoJSON(t *testing.T) {
+	g, err := types.ParseGeometryFromWKT("st_geomfromtext", "POLYGON((0 0,2 0,2 2,0 0))", 0, types.AxisOrderSRIDDefined)
+	require.NoError(t, err)
+	require.Equal(t, `{"coordinates": [[[0.0, 0.0], [2.0, 0.0], [2.0, 2.0], [0.0, 0.0]]], "type": "Polygon"}`, g.GeoJSON(20, 0).String())
+	require.Equal(t, `{"bbox": [0.0, 0.0, 2.0, 2.0], "coordinates": [[[0.0, 0.0], [2.0, 0.0], [2.0, 2.0], [0.0, 0.0]]], "type": "Polygon"}`,
+		g.GeoJSON(20, types.GeoJSONOptionBBox).String())
+
+	p := types.NewPointGeometry(1.23456, 2)
+	require.Equal(t, `{"coordinates": [1.23, 2.0], "type": "Point"}`, p.GeoJSON(2, 0).String())
+
+	j, err := types.ParseBinaryJSONFromString(`{"type": "LineString", "coordinates": [[102.0, 0.0], [103.0, 1.0]]}`)
+	require.NoError(t, err)
+	g, err = types.ParseGeometryFromGeoJSON("st_geomfromgeojson", j, 4326, true)
+	require.NoError(t, err)
+	require.Equal(t, types.GeometryTypeLineString, g.Type)
+	require.Equal(t, uint32(4326), g.SRID)
+	require.Equal(t, []types.GeomPoint{{X: 102, Y: 0}, {X: 103, Y: 1}}, g.Points)
+
+	j, err = types.ParseBinaryJSONFromString(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2]}, "properties": {}}`)
+	require.NoError(t, err)
+	g, err = types.ParseGeometryFromGeoJSON("st_geomfromgeojson", j, 0, true)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT(types.AxisOrderSRIDDefined))
+
+	j, err = types.ParseBinaryJSONFromString(`{"type": "Point", "coordinates": [1, 2, 3]}`)
+	require.NoError(t, err)
+	_, err = types.ParseGeometryFromGeoJSON("st_geomfromgeojson", j, 0, true)
+	require.Error(t, err)
+	g, err = types.ParseGeometryFromGeoJSON("st_geomfromgeojson", j, 0, false)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT(types.AxisOrderSRIDDefined))
+}
+
+func TestGeometryRelations(t *testing.T) {
+	parse := func(wkt string) types.Geometry {
+		g, err := types.ParseGeometryFromWKT("", wkt, 0, types.AxisOrderSRIDDefined)
+		require.NoError(t, err)
+		return g
+	}
+	square := parse("POLYGON((0 0,4 0,4 4,0 4,0 0))")
+	tests := []struct {
+		b          string
+		contains   bool
+		intersects bool
+	}{
+		{"POINT(2 2)", true, true},
+		{"POINT(0 2)", false, true},
+		{"POINT(5 5)", false, false},
+		{"LINESTRING(1 1,3 3)", true, true},
+		{"LINESTRING(0 0,4 0)", false, true},
+		{"LINESTRING(3 3,5 5)", false, true},
+		{"POLYGON((1 1,3 1,3 3,1 3,1 1))", true, true},
+		{"POLYGON((0 0,4 0,4 4,0 4,0 0))", true, true},
+		{"POLYGON((5 5,6 5,6 6,5 5))", false, false},
+		{"MULTIPOINT(1 1,2 2)", true, true},
+		{"MULTIPOINT(1 1,5 5)", false, true},
+	}
+	for _, tt := range tests {
+		b := parse(tt.b)
+		contains, err := square.Contains("", b)
+		require.NoError(t, err)
+		require.Equal(t, tt.contains, contains, tt.b)
+		intersects, err := square.Intersects("", b)
+		require.NoError(t, err)
+		require.Equal(t, tt.intersects, intersects, tt.b)
+	}
+
+	equals, err := square.Equals("", parse("POLYGON((4 4,0 4,0 0,4 0,4 4))"))
+	require.NoError(t, err)
+	require.True(t, equals)
+
+	other, err := types.ParseGeometryFromWKT("", "POINT(1 1)", 4326, types.AxisOrderSRIDDefined)
+	require.NoError(t, err)
+	_, err = square.Contains("st_contains", other)
+	require.True(t, types.ErrGISDifferentSRIDs.Equal(err))
+}
+
+func TestGeometryMeasures(t *testing.T) {
+	parse := func(wkt string, srid uint32) types.Geometry {
+		g, err := types.ParseGeometryFromWKT("", wkt, srid, types.AxisOrderSRIDDefined)
+		require.NoError(t, err)
+		return g
+	}
+	d, ok, err := parse("POINT(0 0)", 0).Distance("", parse("POINT(3 4)", 0))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 5.0, d)
+	d, ok, err = parse("LINESTRING(0 0,10 0)", 0).Distance("", parse("POINT(5 3)", 0))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 3.0, d)
+	_, ok, err = parse("POINT(0 0)", 0).Distance("", parse("GEOMETRYCOLLECTION EMPTY", 0))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// One degree of the latitude on the WGS 84 ellipsoid is about 110.6km at the equator.
+	d, ok, err = parse("POINT(0 0)", 4326).Distance("", parse("POINT(1 0)", 4326))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.InDelta(t, 110574, d, 10)
+
+	// Beijing to Shanghai.
+	d, err = parse("POINT(116.4074 39.9042)", 0).DistanceSphere("", parse("POINT(121.4737 31.2304)", 0), types.DefaultSphereRadius)
+	require.NoError(t, err)
+	require.InDelta(t, 1067000, d, 1000)
+	_, err = parse("POINT(0 0)", 0).DistanceSphere("", parse("POINT(1 1)", 0), 0)
+	require.True(t, types.ErrNonPositiveRadius.Equal(err))
+	_, err = parse("POINT(200 0)", 0).DistanceSphere("", parse("POINT(1 1)", 0), types.DefaultSphereRadius)
+	require.True(t, types.ErrLongitudeOutOfRange.Equal(err))
+
+	area, err := parse("POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 2,1 1))", 0).Area("")
+	require.NoError(t, err)
+	require.Equal(t, 15.0, area)
+	_, err = parse("POLYGON((0 0,1 0,1 1,0 0))", 4326).Area("st_area")
+	require.True(t, types.ErrNotImplementedForGeographicSRS.Equal(err))
+
+	length, err := parse("LINESTRING(0 0,3 4,3 10)", 0).Length("")
+	require.NoError(t, err)
+	require.Equal(t, 11.0, length)
+}
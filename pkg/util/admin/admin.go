@@ -119,9 +119,14 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 // CheckRecordAndIndex is exported for testing.
 func CheckRecordAndIndex(ctx context.Context, sessCtx sessionctx.Context, txn kv.Transaction, t table.Table, idx table.Index) error {
 	sc := sessCtx.GetSessionVars().StmtCtx
-	cols := make([]*table.Column, len(idx.Meta().Columns))
+	idxCols := make([]*table.Column, len(idx.Meta().Columns))
 	for i, col := range idx.Meta().Columns {
-		cols[i] = t.Cols()[col.Offset]
+		idxCols[i] = t.Cols()[col.Offset]
+	}
+	// The condition of a partial index is evaluated on the full row.
+	cols := idxCols
+	if idx.Meta().HasCondition() {
+		cols = t.Cols()
 	}
 
 	ir := func() *consistency.Reporter {
@@ -155,6 +160,16 @@ func CheckRecordAndIndex(ctx context.Context, sessCtx sessionctx.Context, txn kv
 
 	startKey := tablecodec.EncodeRecordKey(t.RecordPrefix(), kv.IntHandle(math.MinInt64))
 	filterFunc := func(h1 kv.Handle, vals1 []types.Datum, cols []*table.Column) (bool, error) {
+		if idx.Meta().HasCondition() {
+			meet, err := idx.MeetPartialCondition(sessCtx.GetExprCtx().GetEvalCtx(), vals1)
+			if err != nil || !meet {
+				return err == nil, errors.Trace(err)
+			}
+			if vals1, err = idx.FetchValues(vals1, nil); err != nil {
+				return false, errors.Trace(err)
+			}
+			vals1, cols = vals1[:len(idxCols)], idxCols
+		}
 		for i, val := range vals1 {
 			col := cols[i]
 			if val.IsNull() {
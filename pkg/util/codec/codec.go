@@ -49,6 +49,9 @@ const (
 	jsonFlag          byte = 10
 	vectorFloat32Flag byte = 20
 	maxFlag           byte = 250
+	// descFlag is followed by a bitwise reversed key encoded value, see EncodeKeyDesc.
+	// `maxFlag + 1` is reserved for PrefixNext of MaxValue, so we skip it.
+	descFlag byte = 252
 )
 
 // IntHandleFlag is only used to encode int handle key.
@@ -304,6 +307,25 @@ func EncodeKey(loc *time.Location, b []byte, v ...types.Datum) ([]byte, error) {
 	return encode(loc, b, v, true)
 }
 
+// EncodeKeyDesc appends the encoded values to byte slice b, returns the appended
+// slice. It guarantees the encoded value is in descending order for comparison.
+// Each value is encoded as descFlag followed by the bitwise reversed result of EncodeKey,
+// so it can still be cut and decoded without knowing the order.
+// Note that MinNotNull is encoded as a prefix of non-null values, it's only meaningful
+// as the exclusive end of a key range after PrefixNext.
+func EncodeKeyDesc(loc *time.Location, b []byte, v ...types.Datum) (_ []byte, err error) {
+	for i := range v {
+		b = append(b, descFlag)
+		n := len(b)
+		b, err = encode(loc, b, v[i:i+1], true)
+		if err != nil {
+			return b, errors.Trace(err)
+		}
+		reverseBytes(b[n:])
+	}
+	return b, nil
+}
+
 // EncodeValue appends the encoded values to byte slice b, returning the appended
 // slice. It does not guarantee the order for comparison.
 func EncodeValue(loc *time.Location, b []byte, v ...types.Datum) ([]byte, error) {
@@ -340,7 +362,7 @@ func encodeHashChunkRowIdx(typeCtx types.Context, row chunk.Row, tp *types.Field
 			f = 0
 		}
 		b = unsafe.Slice((*byte)(unsafe.Pointer(&f)), unsafe.Sizeof(f))
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		flag = compactBytesFlag
 		b = row.GetBytes(idx)
 		b = ConvertByCollation(b, tp)
@@ -480,7 +502,7 @@ func SerializeKeys(typeCtx types.Context, chk *chunk.Chunk, tp *types.FieldType,
 			}
 			serializedKeysVector[logicalRowIndex] = append(serializedKeysVector[logicalRowIndex], unsafe.Slice((*byte)(unsafe.Pointer(&f)), sizeFloat64)...)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		for logicalRowIndex, physicalRowIndex := range usedRows {
 			if canSkip(physicalRowIndex) {
 				continue
@@ -698,7 +720,7 @@ func HashChunkSelected(typeCtx types.Context, h []hash.Hash64, chk *chunk.Chunk,
 			_, _ = h[i].Write(buf)
 			_, _ = h[i].Write(b)
 		}
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		for i := range rows {
 			if sel != nil && !sel[i] {
 				continue
@@ -1044,6 +1066,13 @@ func DecodeOne(b []byte) (remain []byte, d types.Datum, err error) {
 	flag := b[0]
 	b = b[1:]
 	switch flag {
+	case descFlag:
+		var asc []byte
+		asc, b, err = cutDesc(b)
+		if err != nil {
+			return b, d, errors.Trace(err)
+		}
+		_, d, err = DecodeOne(asc)
 	case intFlag:
 		var v int64
 		b, v, err = DecodeInt(b)
@@ -1122,6 +1151,15 @@ func DecodeAsDateTime(b []byte, tp byte, loc *time.Location) (remain []byte, d t
 	if len(b) < 1 {
 		return nil, d, errors.New("invalid encoded key")
 	}
+	if b[0] == descFlag {
+		var asc []byte
+		asc, remain, err = cutDesc(b[1:])
+		if err != nil {
+			return nil, d, errors.Trace(err)
+		}
+		_, d, err = DecodeAsDateTime(asc, tp, loc)
+		return remain, d, err
+	}
 	flag := b[0]
 	b = b[1:]
 	var v uint64
@@ -1161,6 +1199,15 @@ func DecodeAsFloat32(b []byte, tp byte) (remain []byte, d types.Datum, err error
 	if len(b) < 1 || tp != mysql.TypeFloat {
 		return nil, d, errors.New("invalid encoded key")
 	}
+	if b[0] == descFlag {
+		var asc []byte
+		asc, remain, err = cutDesc(b[1:])
+		if err != nil {
+			return nil, d, errors.Trace(err)
+		}
+		_, d, err = DecodeAsFloat32(asc, tp)
+		return remain, d, err
+	}
 	flag := b[0]
 	b = b[1:]
 	if flag != floatFlag {
@@ -1185,6 +1232,50 @@ func CutOne(b []byte) (data []byte, remain []byte, err error) {
 	return b[:l], b[l:], nil
 }
 
+// CutOneAsc is like CutOne, but if the first value is encoded by EncodeKeyDesc,
+// the returned data is converted to the ascending form produced by EncodeKey.
+func CutOneAsc(b []byte) (data []byte, remain []byte, err error) {
+	if len(b) > 0 && b[0] == descFlag {
+		return cutDesc(b[1:])
+	}
+	return CutOne(b)
+}
+
+// IsDescEncoded returns whether the first value in b is encoded by EncodeKeyDesc.
+func IsDescEncoded(b []byte) bool {
+	return len(b) > 0 && b[0] == descFlag
+}
+
+// cutDesc cuts a bitwise reversed value following the descFlag,
+// and returns a copy of it in the ascending form.
+func cutDesc(b []byte) (asc []byte, remain []byte, err error) {
+	l, err := peekDesc(b)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	asc = make([]byte, l)
+	copy(asc, b[:l])
+	reverseBytes(asc)
+	return asc, b[l:], nil
+}
+
+// peekDesc peeks the length of a bitwise reversed value following the descFlag.
+// The encoded values are self-delimiting, so only a prefix of b is reversed to peek,
+// which is doubled until it covers the value. It keeps the cost proportional to the
+// length of the value instead of the remaining key.
+func peekDesc(b []byte) (int, error) {
+	var buf [32]byte
+	asc := buf[:0]
+	for n := min(len(b), len(buf)); ; n = min(len(b), 2*n) {
+		asc = append(asc[:0], b[:n]...)
+		reverseBytes(asc)
+		l, err := peek(asc)
+		if err == nil || n == len(b) {
+			return l, err
+		}
+	}
+}
+
 // CutColumnID cuts the column ID from b.
 // It will return the remains as byte slice and column ID
 func CutColumnID(b []byte) (remain []byte, n int64, err error) {
@@ -1221,6 +1312,8 @@ func peek(b []byte) (length int, err error) {
 	var l int
 	switch flag {
 	case NilFlag:
+	case descFlag:
+		l, err = peekDesc(b)
 	case intFlag, uintFlag, floatFlag, durationFlag:
 		// Those types are stored in 8 bytes.
 		l = 8
@@ -1330,6 +1423,13 @@ func (decoder *Decoder) DecodeOne(b []byte, colIdx int, ft *types.FieldType) (re
 	flag := b[0]
 	b = b[1:]
 	switch flag {
+	case descFlag:
+		var asc []byte
+		asc, b, err = cutDesc(b)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		_, err = decoder.DecodeOne(asc, colIdx, ft)
 	case intFlag:
 		var v int64
 		b, v, err = DecodeInt(b)
@@ -21,6 +21,7 @@ import (
 	"hash/crc32"
 	"hash/fnv"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -227,6 +228,64 @@ func TestCodecKeyCompare(t *testing.T) {
 	}
 }
 
+func TestCodecKeyDesc(t *testing.T) {
+	table := []struct {
+		Left  types.Datum
+		Right types.Datum
+	}{
+		{types.NewIntDatum(-1), types.NewIntDatum(1)},
+		{types.NewUintDatum(1), types.NewUintDatum(math.MaxUint64)},
+		{types.NewFloat64Datum(3.12), types.NewFloat64Datum(3.15)},
+		{types.NewStringDatum("abc"), types.NewStringDatum("abcd")},
+		{types.NewStringDatum("abcdefgh"), types.NewStringDatum("abcdefghi")},
+		{types.NewBytesDatum([]byte{0x01, 0x00}), types.NewBytesDatum([]byte{0x01, 0x00, 0xFF})},
+		{types.NewDatum(nil), types.NewIntDatum(math.MinInt64)},
+		{types.NewDatum(nil), types.MinNotNullDatum()},
+		{types.NewIntDatum(math.MaxInt64), types.MaxValueDatum()},
+		{types.NewDatum(parseTime(t, "2011-11-11 00:00:00")), types.NewDatum(parseTime(t, "2011-11-11 00:00:01"))},
+	}
+	for _, datums := range table {
+		// The descending encoding reverses the order of values, and the following values don't affect it.
+		b1, err := EncodeKeyDesc(time.Local, nil, datums.Left)
+		require.NoError(t, err)
+		b1, err = EncodeKey(time.Local, b1, types.NewIntDatum(math.MinInt64))
+		require.NoError(t, err)
+		b2, err := EncodeKeyDesc(time.Local, nil, datums.Right)
+		require.NoError(t, err)
+		b2, err = EncodeKey(time.Local, b2, types.NewIntDatum(math.MaxInt64))
+		require.NoError(t, err)
+		require.Equalf(t, -1, bytes.Compare(b2, b1), "%v - %v", datums.Left, datums.Right)
+	}
+
+	row := types.MakeDatums(1, "abc", nil, 3.5, []byte{0x00, 0xFF}, uint64(10), strings.Repeat("abcdefgh", 20), "tail")
+	var b []byte
+	var err error
+	for i, d := range row {
+		if i%2 == 0 {
+			b, err = EncodeKeyDesc(time.Local, b, d)
+		} else {
+			b, err = EncodeKey(time.Local, b, d)
+		}
+		require.NoError(t, err)
+	}
+	decoded, err := Decode(b, len(row))
+	require.NoError(t, err)
+	require.Equal(t, len(row), len(decoded))
+	remain := b
+	for i := range row {
+		var data []byte
+		data, remain, err = CutOneAsc(remain)
+		require.NoError(t, err)
+		expect, err := EncodeKey(time.Local, nil, row[i])
+		require.NoError(t, err)
+		require.Equal(t, expect, data)
+		expect, err = EncodeKey(time.Local, nil, decoded[i])
+		require.NoError(t, err)
+		require.Equal(t, expect, data)
+	}
+	require.Len(t, remain, 0)
+}
+
 func TestNumberCodec(t *testing.T) {
 	tblInt64 := []int64{
 		math.MinInt64,
@@ -382,6 +382,20 @@ var (
 	ErrInvalidAttributesSpec = ClassDDL.NewStd(mysql.ErrInvalidAttributesSpec)
 	// ErrFunctionalIndexOnJSONOrGeometryFunction returns when creating expression index and the type of the expression is JSON.
 	ErrFunctionalIndexOnJSONOrGeometryFunction = ClassDDL.NewStd(mysql.ErrFunctionalIndexOnJSONOrGeometryFunction)
+	// ErrSpatialFunctionalIndex returns when creating a spatial index on an expression.
+	ErrSpatialFunctionalIndex = ClassDDL.NewStd(mysql.ErrSpatialFunctionalIndex)
+	// ErrSpatialCantHaveNull returns when the column of a spatial index is nullable.
+	ErrSpatialCantHaveNull = ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrSpatialMustHaveGeomCol returns when the column of a spatial index is not a geometry column.
+	ErrSpatialMustHaveGeomCol = ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrSpatialUselessIndex is a warning when the column of a spatial index has no SRID attribute.
+	ErrSpatialUselessIndex = ClassDDL.NewStd(mysql.ErrSpatialUselessIndex)
+	// ErrFulltextFunctionalIndex returns when creating a full-text index on an expression.
+	ErrFulltextFunctionalIndex = ClassDDL.NewStd(mysql.ErrFulltextFunctionalIndex)
+	// ErrBadFtColumn returns when the column of a full-text index is not a non-binary string column.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrFulltextNotSupportedWithPartitioning returns when creating a full-text index on a partitioned table.
+	ErrFulltextNotSupportedWithPartitioning = ClassDDL.NewStd(mysql.ErrFulltextNotSupportedWithPartitioning)
 	// ErrDependentByFunctionalIndex returns when the dropped column depends by expression index.
 	ErrDependentByFunctionalIndex = ClassDDL.NewStd(mysql.ErrDependentByFunctionalIndex)
 	// ErrFunctionalIndexOnBlob when the expression of expression index returns blob or text.
@@ -457,6 +471,25 @@ var (
 	ErrUnsupportedTTLReferencedByFK = ClassDDL.NewStd(mysql.ErrUnsupportedTTLReferencedByFK)
 	// ErrUnsupportedPrimaryKeyTypeWithTTL returns when create or alter a table with TTL options but the primary key is not supported
 	ErrUnsupportedPrimaryKeyTypeWithTTL = ClassDDL.NewStd(mysql.ErrUnsupportedPrimaryKeyTypeWithTTL)
+	// ErrInvalidTTLArchiveTarget returns when the archive target of a TTL table is invalid
+	ErrInvalidTTLArchiveTarget = ClassDDL.NewStd(mysql.ErrInvalidTTLArchiveTarget)
+	// ErrInvalidTTLCondition returns when the condition of a TTL table is invalid
+	ErrInvalidTTLCondition = ClassDDL.NewStd(mysql.ErrInvalidTTLCondition)
+
+	// ErrChangefeedExists returns when the changefeed to create already exists
+	ErrChangefeedExists = ClassDDL.NewStd(mysql.ErrChangefeedExists)
+	// ErrChangefeedNotExists returns when the changefeed doesn't exist
+	ErrChangefeedNotExists = ClassDDL.NewStd(mysql.ErrChangefeedNotExists)
+	// ErrInvalidChangefeed returns when the changefeed to create is invalid
+	ErrInvalidChangefeed = ClassDDL.NewStd(mysql.ErrInvalidChangefeed)
+	// ErrInvalidPartialIndex returns when the condition of a partial index is invalid
+	ErrInvalidPartialIndex = ClassDDL.NewStd(mysql.ErrInvalidPartialIndex)
+	// ErrDependentByPartialIndex returns when the dropped or renamed column is referenced by a partial index condition.
+	ErrDependentByPartialIndex = ClassDDL.NewStd(mysql.ErrDependentByPartialIndex)
+	// ErrInvalidIncludeColumn returns when the included column of an index is invalid
+	ErrInvalidIncludeColumn = ClassDDL.NewStd(mysql.ErrInvalidIncludeColumn)
+	// ErrDependentByIncludeColumn returns when the dropped or modified column is included by an index.
+	ErrDependentByIncludeColumn = ClassDDL.NewStd(mysql.ErrDependentByIncludeColumn)
 
 	// ErrNotSupportedYet returns when tidb does not support this feature.
 	ErrNotSupportedYet = ClassDDL.NewStd(mysql.ErrNotSupportedYet)
@@ -512,6 +545,19 @@ var (
 	ErrEngineAttributeInvalidFormat = ClassDDL.NewStd(mysql.ErrEngineAttributeInvalidFormat)
 	// ErrStorageClassInvalidSpec is reserved for future use.
 	ErrStorageClassInvalidSpec = ClassDDL.NewStd(mysql.ErrStorageClassInvalidSpec)
+
+	// ErrTrgAlreadyExists is returned when creating a trigger whose name is used in the schema.
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist is returned when dropping a trigger which doesn't exist.
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgInWrongSchema is returned when the trigger and its table are in different schemas.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema is returned when creating a trigger on a system table.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrReferencedTrgDoesNotExist is returned when the trigger in FOLLOWS or PRECEDES doesn't exist.
+	ErrReferencedTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrReferencedTrgDoesNotExist)
 )
 
 // ReorgRetryableErrCodes are the error codes that are retryable for reorganization.
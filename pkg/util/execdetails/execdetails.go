@@ -1004,6 +1004,8 @@ const (
 	TpFKCascadeRuntimeStats
 	// TpRURuntimeStats is the tp for RURuntimeStats
 	TpRURuntimeStats
+	// TpAdaptiveRuntimeStats is the tp for the runtime stats of the adaptive execution checkpoints.
+	TpAdaptiveRuntimeStats
 )
 
 // RuntimeStats is used to express the executor runtime information.
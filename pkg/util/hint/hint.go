@@ -123,6 +123,8 @@ const (
 	HintNoIndexMerge = "no_index_merge"
 	// HintMaxExecutionTime specifies the max allowed execution time in milliseconds
 	HintMaxExecutionTime = "max_execution_time"
+	// HintRuntimeFilter is a hint to enable the runtime filters of the hash joins executed in TiDB.
+	HintRuntimeFilter = "runtime_filter"
 
 	// HintFlagSemiJoinRewrite corresponds to HintSemiJoinRewrite.
 	HintFlagSemiJoinRewrite uint64 = 1 << iota
@@ -209,6 +211,7 @@ type StmtHints struct {
 	AllowInSubqToJoinAndAgg bool
 	NoIndexMergeHint        bool
 	StraightJoinOrder       bool
+	RuntimeFilterHint       bool
 	// EnableCascadesPlanner is use cascades planner for a single query only.
 	EnableCascadesPlanner bool
 	// ForceNthPlan indicates the PlanCounterTp number for finding physical plan.
@@ -260,6 +263,7 @@ func (sh *StmtHints) Clone() *StmtHints {
 		AllowInSubqToJoinAndAgg:        sh.AllowInSubqToJoinAndAgg,
 		NoIndexMergeHint:               sh.NoIndexMergeHint,
 		StraightJoinOrder:              sh.StraightJoinOrder,
+		RuntimeFilterHint:              sh.RuntimeFilterHint,
 		EnableCascadesPlanner:          sh.EnableCascadesPlanner,
 		ForceNthPlan:                   sh.ForceNthPlan,
 		ResourceGroup:                  sh.ResourceGroup,
@@ -298,7 +302,7 @@ func ParseStmtHints(hints []*ast.TableOptimizerHint,
 	}
 	hintOffs := make(map[string]int, len(hints))
 	var forceNthPlan *ast.TableOptimizerHint
-	var memoryQuotaHintCnt, useToJAHintCnt, useCascadesHintCnt, noIndexMergeHintCnt, readReplicaHintCnt, maxExecutionTimeCnt, forceNthPlanCnt, straightJoinHintCnt, resourceGroupHintCnt int
+	var memoryQuotaHintCnt, useToJAHintCnt, useCascadesHintCnt, noIndexMergeHintCnt, readReplicaHintCnt, maxExecutionTimeCnt, forceNthPlanCnt, straightJoinHintCnt, resourceGroupHintCnt, runtimeFilterHintCnt int
 	setVars := make(map[string]string)
 	setVarsOffs := make([]int, 0, len(hints))
 	for i, hint := range hints {
@@ -318,6 +322,9 @@ func ParseStmtHints(hints []*ast.TableOptimizerHint,
 		case "no_index_merge":
 			hintOffs[hint.HintName.L] = i
 			noIndexMergeHintCnt++
+		case "runtime_filter":
+			hintOffs[hint.HintName.L] = i
+			runtimeFilterHintCnt++
 		case "read_consistent_replica":
 			hintOffs[hint.HintName.L] = i
 			readReplicaHintCnt++
@@ -444,6 +451,14 @@ func ParseStmtHints(hints []*ast.TableOptimizerHint,
 		}
 		stmtHints.NoIndexMergeHint = true
 	}
+	// Handle RUNTIME_FILTER
+	if runtimeFilterHintCnt != 0 {
+		if runtimeFilterHintCnt > 1 {
+			warn := errors.NewNoStackError("RUNTIME_FILTER() is defined more than once, only the last definition takes effect")
+			warns = append(warns, warn)
+		}
+		stmtHints.RuntimeFilterHint = true
+	}
 	// Handle straight_join
 	if straightJoinHintCnt != 0 {
 		if straightJoinHintCnt > 1 {
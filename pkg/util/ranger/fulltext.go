@@ -0,0 +1,81 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranger
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// BuildFullTextIndexRanges builds the ranges of the full-text index which
+// contain all the rows containing any of the tokens, or any token starting
+// with one of the prefixes. The token is stored in the first index column, so
+// the ranges are on the first column only.
+func BuildFullTextIndexRanges(tokens, prefixes []string) Ranges {
+	// interval is [low, high), and an empty high means unbounded.
+	type interval struct {
+		low, high string
+	}
+	intervals := make([]interval, 0, len(tokens)+len(prefixes))
+	for _, token := range tokens {
+		// token + "\x00" is the smallest string larger than token.
+		intervals = append(intervals, interval{token, token + "\x00"})
+	}
+	for _, prefix := range prefixes {
+		intervals = append(intervals, interval{prefix, tablecodec.FullTextPrefixEnd(prefix)})
+	}
+	if len(intervals) == 0 {
+		return nil
+	}
+	slices.SortFunc(intervals, func(a, b interval) int {
+		return cmp.Compare(a.low, b.low)
+	})
+	merged := intervals[:1]
+	for _, in := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if last.high == "" {
+			break
+		}
+		if in.low <= last.high {
+			if in.high == "" || in.high > last.high {
+				last.high = in.high
+			}
+			continue
+		}
+		merged = append(merged, in)
+	}
+	ranges := make(Ranges, 0, len(merged))
+	for _, in := range merged {
+		ran := &Range{
+			LowVal:    []types.Datum{tablecodec.FullTextTokenDatum(in.low)},
+			Collators: []collate.Collator{collate.GetBinaryCollator()},
+		}
+		switch in.high {
+		case in.low + "\x00":
+			ran.HighVal = []types.Datum{tablecodec.FullTextTokenDatum(in.low)}
+		case "":
+			ran.HighVal = []types.Datum{types.MaxValueDatum()}
+		default:
+			ran.HighVal = []types.Datum{tablecodec.FullTextTokenDatum(in.high)}
+			ran.HighExclude = true
+		}
+		ranges = append(ranges, ran)
+	}
+	return ranges
+}
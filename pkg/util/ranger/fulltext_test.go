@@ -0,0 +1,40 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranger_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/util/ranger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildFullTextIndexRanges(t *testing.T) {
+	cases := []struct {
+		tokens   []string
+		prefixes []string
+		ranges   string
+	}{
+		{nil, nil, "[]"},
+		{[]string{"tidb", "database", "tidb"}, nil, `[["database","database"] ["tidb","tidb"]]`},
+		{[]string{"database", "datum"}, []string{"dat"}, `[["dat","dau")]`},
+		{[]string{"tidb"}, []string{"data", "dat"}, `[["dat","dau") ["tidb","tidb"]]`},
+		{[]string{"zzz"}, []string{"\xff", "abc"}, `[["abc","abd") ["zzz","zzz"] ["\xff",+inf]]`},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.ranges, fmt.Sprintf("%v", ranger.BuildFullTextIndexRanges(c.tokens, c.prefixes)), c.ranges)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranger
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/collate"
+)
+
+// BuildSpatialIndexRanges builds the ranges of the spatial index which contain
+// all the rows whose bounding boxes intersect the box. The box is covered by
+// the cells of the spatial index, and a row intersects a cell if it's indexed
+// by a descendant or an ancestor of the cell.
+func BuildSpatialIndexRanges(srid uint32, box types.GeomBox) Ranges {
+	type interval struct {
+		low, high tablecodec.SpatialCell
+	}
+	cells := tablecodec.SpatialCovering(srid, box, tablecodec.SpatialIndexQueryCells)
+	intervals := make([]interval, 0, len(cells)*4)
+	for _, cell := range cells {
+		intervals = append(intervals, interval{cell.RangeMin(), cell.RangeMax()})
+		for level := cell.Level() - 1; level >= 0; level-- {
+			parent := cell.Parent(level)
+			intervals = append(intervals, interval{parent, parent})
+		}
+	}
+	slices.SortFunc(intervals, func(a, b interval) int {
+		return cmp.Compare(a.low, b.low)
+	})
+	merged := intervals[:1]
+	for _, in := range intervals[1:] {
+		last := &merged[len(merged)-1]
+		if in.low <= last.high+1 {
+			last.high = max(last.high, in.high)
+			continue
+		}
+		merged = append(merged, in)
+	}
+	ranges := make(Ranges, 0, len(merged))
+	for _, in := range merged {
+		ranges = append(ranges, &Range{
+			LowVal:    []types.Datum{in.low.Datum()},
+			HighVal:   []types.Datum{in.high.Datum()},
+			Collators: []collate.Collator{collate.GetBinaryCollator()},
+		})
+	}
+	return ranges
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ranger_test
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/ranger"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSpatialIndexRanges(t *testing.T) {
+	randomBox := func(r *rand.Rand, srid uint32) types.GeomBox {
+		scale := 1000.0
+		if srid == 4326 {
+			scale = 90
+		}
+		x, y := (r.Float64()*2-1)*scale, (r.Float64()*2-1)*scale
+		w, h := r.Float64()*scale/float64(1+r.Intn(100)), r.Float64()*scale/float64(1+r.Intn(100))
+		return types.GeomBox{MinX: x, MinY: y, MaxX: x + w, MaxY: y + h}
+	}
+	inRanges := func(ranges ranger.Ranges, cell tablecodec.SpatialCell) bool {
+		d := cell.Datum()
+		v := d.GetBytes()
+		for _, ran := range ranges {
+			if bytes.Compare(ran.LowVal[0].GetBytes(), v) <= 0 && bytes.Compare(v, ran.HighVal[0].GetBytes()) <= 0 {
+				return true
+			}
+		}
+		return false
+	}
+	r := rand.New(rand.NewSource(1))
+	for _, srid := range []uint32{0, 4326} {
+		for range 200 {
+			query := randomBox(r, srid)
+			ranges := ranger.BuildSpatialIndexRanges(srid, query)
+			require.NotEmpty(t, ranges)
+			for i := 1; i < len(ranges); i++ {
+				require.Less(t, bytes.Compare(ranges[i-1].HighVal[0].GetBytes(), ranges[i].LowVal[0].GetBytes()), 0)
+			}
+			for range 20 {
+				box := randomBox(r, srid)
+				if box.MaxX < query.MinX || box.MinX > query.MaxX || box.MaxY < query.MinY || box.MinY > query.MaxY {
+					continue
+				}
+				// A row whose bounding box intersects the query box must be in the ranges.
+				found := false
+				for _, cell := range tablecodec.SpatialCovering(srid, box, tablecodec.SpatialIndexWriteCells) {
+					if inRanges(ranges, cell) {
+						found = true
+						break
+					}
+				}
+				require.True(t, found, "box %v, query %v", box, query)
+			}
+		}
+	}
+}
@@ -268,9 +268,10 @@ func (ran *Range) Encode(ec errctx.Context, loc *time.Location, lowBuffer, highB
 // PrefixEqualLen tells you how long the prefix of the range is a point.
 // e.g. If this range is (1 2 3, 1 2 +inf), then the return value is 2.
 func (ran *Range) PrefixEqualLen(tc types.Context) (int, error) {
-	// Here, len(ran.LowVal) always equal to len(ran.HighVal)
-	for i := range len(ran.LowVal) {
-		cmp, err := ran.LowVal[i].Compare(tc, &ran.HighVal[i], ran.Collators[i])
+	// Usually len(ran.LowVal) equals to len(ran.HighVal), except for the ranges split by SplitByDirection.
+	length := min(len(ran.LowVal), len(ran.HighVal))
+	for i := range length {
+		cmp, err := ran.LowVal[i].Compare(tc, &ran.HighVal[i], ran.collator(i))
 		if err != nil {
 			return 0, errors.Trace(err)
 		}
@@ -278,7 +279,107 @@ func (ran *Range) PrefixEqualLen(tc types.Context) (int, error) {
 			return i, nil
 		}
 	}
-	return len(ran.LowVal), nil
+	return length, nil
+}
+
+// SplitByDirection splits the ranges of an index whose columns are stored in different orders.
+// descs tells whether each index column is stored in descending order.
+// For each result range, the columns starting from the first one that the low and high values differ in
+// are stored in the same order, so it can be converted to a continuous key range.
+// e.g. For index (a, b desc), the range [1 2, 3 4] is split into [1 2, 1], (1, 3) and [3, 3 4].
+// Note that the low and high values of the result ranges may have different lengths. A shorter value
+// means that the remaining columns are unbounded on that side, so the result ranges should only be used
+// to build key ranges.
+func (rs Ranges) SplitByDirection(tc types.Context, descs []bool) (Ranges, error) {
+	result := make(Ranges, 0, len(rs))
+	var err error
+	for _, ran := range rs {
+		result, err = ran.splitByDirection(tc, descs, 0, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// splitByDirection splits the range and appends the result to `result`.
+// The first `from` columns of the low and high values are known to be equal.
+func (ran *Range) splitByDirection(tc types.Context, descs []bool, from int, result Ranges) (Ranges, error) {
+	lowLen, highLen := len(ran.LowVal), len(ran.HighVal)
+	d := from
+	for ; d < min(lowLen, highLen); d++ {
+		cmp, err := ran.LowVal[d].Compare(tc, &ran.HighVal[d], ran.collator(d))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if cmp != 0 {
+			break
+		}
+	}
+	lowDone, highDone := d == lowLen, d == highLen
+	if lowDone != highDone && ((lowDone && ran.LowExclude) || (highDone && ran.HighExclude)) {
+		// e.g. (1, 1 2] excludes everything starting with 1, so it's empty.
+		return result, nil
+	}
+	if sameDirection(descs, d, max(lowLen, highLen)) {
+		return append(result, ran), nil
+	}
+	var err error
+	// The part whose d-th column equals to the low value.
+	if !lowDone && lowLen > d+1 {
+		part := &Range{
+			LowVal:      ran.LowVal,
+			LowExclude:  ran.LowExclude,
+			HighVal:     ran.LowVal[:d+1],
+			HighExclude: false,
+			Collators:   ran.Collators,
+		}
+		result, err = part.splitByDirection(tc, descs, d+1, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// The part whose d-th column is between the low and high values, the remaining columns are unbounded.
+	mid := &Range{
+		LowVal:      ran.LowVal[:min(lowLen, d+1)],
+		LowExclude:  ran.LowExclude || lowLen > d+1,
+		HighVal:     ran.HighVal[:min(highLen, d+1)],
+		HighExclude: ran.HighExclude || highLen > d+1,
+		Collators:   ran.Collators,
+	}
+	result = append(result, mid)
+	// The part whose d-th column equals to the high value.
+	if !highDone && highLen > d+1 {
+		part := &Range{
+			LowVal:      ran.HighVal[:d+1],
+			LowExclude:  false,
+			HighVal:     ran.HighVal,
+			HighExclude: ran.HighExclude,
+			Collators:   ran.Collators,
+		}
+		result, err = part.splitByDirection(tc, descs, d+1, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (ran *Range) collator(i int) collate.Collator {
+	if i < len(ran.Collators) {
+		return ran.Collators[i]
+	}
+	return collate.GetBinaryCollator()
+}
+
+// sameDirection checks whether the columns in [start, end) are stored in the same order.
+func sameDirection(descs []bool, start, end int) bool {
+	for i := start + 1; i < end && i < len(descs); i++ {
+		if descs[i] != descs[start] {
+			return false
+		}
+	}
+	return true
 }
 
 // EmptyRangeSize is the size of empty range.
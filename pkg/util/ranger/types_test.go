@@ -615,3 +615,59 @@ func TestIntersectionOverlap(t *testing.T) {
 	// cleanup
 	domain.GetDomain(ctx).StatsHandle().Close()
 }
+
+func TestSplitByDirection(t *testing.T) {
+	tc := types.DefaultStmtNoWarningContext
+	ints := func(vals ...int64) []types.Datum {
+		datums := make([]types.Datum, 0, len(vals))
+		for _, v := range vals {
+			datums = append(datums, types.NewIntDatum(v))
+		}
+		return datums
+	}
+	tests := []struct {
+		ran    ranger.Range
+		descs  []bool
+		expect []string
+	}{
+		{
+			ran:    ranger.Range{LowVal: ints(1, 2), HighVal: ints(3, 4)},
+			descs:  []bool{false, true},
+			expect: []string{"[1 2,1]", "(1,3)", "[3,3 4]"},
+		},
+		{
+			ran:    ranger.Range{LowVal: ints(1, 2), HighVal: ints(3, 4)},
+			descs:  []bool{true, true},
+			expect: []string{"[1 2,3 4]"},
+		},
+		{
+			ran:    ranger.Range{LowVal: ints(1, 2), HighVal: ints(1, 4), LowExclude: true},
+			descs:  []bool{false, true},
+			expect: []string{"(1 2,1 4]"},
+		},
+		{
+			ran:    ranger.Range{LowVal: ints(1, 2, 3), HighVal: ints(1, 4, 5), HighExclude: true},
+			descs:  []bool{false, false, true},
+			expect: []string{"[1 2 3,1 2]", "(1 2,1 4)", "[1 4,1 4 5)"},
+		},
+		{
+			ran:    ranger.Range{LowVal: ints(1, 2), HighVal: ints(2), LowExclude: true},
+			descs:  []bool{false, true},
+			expect: []string{"(1 2,1]", "(1,2]"},
+		},
+		{
+			ran:    ranger.Range{LowVal: ints(1), HighVal: ints(1, 2), LowExclude: true},
+			descs:  []bool{false, true},
+			expect: []string{},
+		},
+	}
+	for _, tt := range tests {
+		ranges, err := ranger.Ranges{&tt.ran}.SplitByDirection(tc, tt.descs)
+		require.NoError(t, err)
+		actual := make([]string, 0, len(ranges))
+		for _, ran := range ranges {
+			actual = append(actual, ran.String())
+		}
+		require.Equal(t, tt.expect, actual, tt.ran.String())
+	}
+}
@@ -0,0 +1,344 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloadlearning
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessiontxn"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlescape"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+)
+
+const (
+	// The type of the cardinality feedback of the predicates on the tables
+	cardinalityFeedback = "CardinalityFeedback"
+	// maxPendingCardinalityFeedback is the max number of the predicates captured between two flushes, the feedback of
+	// the other predicates is dropped.
+	maxPendingCardinalityFeedback = 10000
+	// CardinalityFeedbackTTL is how long the learned feedback is kept if the predicate is not executed again.
+	CardinalityFeedbackTTL = 7 * 24 * time.Hour
+)
+
+// CardinalityFeedback is the accumulated estimated and actual rows of a predicate on a table.
+type CardinalityFeedback struct {
+	Fingerprint string  `json:"fingerprint"`
+	Predicate   string  `json:"predicate"`
+	EstRows     float64 `json:"est_rows"`
+	ActRows     float64 `json:"act_rows"`
+	Count       int64   `json:"count"`
+}
+
+// Correction returns the ratio of the actual rows to the estimated rows.
+func (f *CardinalityFeedback) Correction() float64 {
+	return math.Max(f.ActRows, 1) / math.Max(f.EstRows, 1)
+}
+
+func (f *CardinalityFeedback) merge(other *CardinalityFeedback) {
+	f.EstRows += other.EstRows
+	f.ActRows += other.ActRows
+	f.Count += other.Count
+}
+
+type cardinalityFeedbackKey struct {
+	tableID     int64
+	fingerprint string
+}
+
+// CardinalityFeedbackStore captures the cardinality feedback of the executed statements, persists it to the table
+// "mysql.tidb_workload_values", and provides the learned corrections to the optimizer.
+type CardinalityFeedbackStore struct {
+	sysSessionPool util.DestroyableSessionPool
+
+	mu sync.RWMutex
+	// pending is the feedback captured since the last flush.
+	pending map[cardinalityFeedbackKey]*CardinalityFeedback
+	// learned is the feedback loaded from the storage.
+	learned map[cardinalityFeedbackKey]*CardinalityFeedback
+}
+
+// NewCardinalityFeedbackStore creates a new CardinalityFeedbackStore.
+func NewCardinalityFeedbackStore(pool util.DestroyableSessionPool) *CardinalityFeedbackStore {
+	return &CardinalityFeedbackStore{
+		sysSessionPool: pool,
+		pending:        make(map[cardinalityFeedbackKey]*CardinalityFeedback),
+		learned:        make(map[cardinalityFeedbackKey]*CardinalityFeedback),
+	}
+}
+
+// Record records the estimated and actual rows of a predicate on a table.
+func (s *CardinalityFeedbackStore) Record(tableID int64, fingerprint, predicate string, estRows, actRows float64) {
+	key := cardinalityFeedbackKey{tableID: tableID, fingerprint: fingerprint}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	feedback, ok := s.pending[key]
+	if !ok {
+		if len(s.pending) >= maxPendingCardinalityFeedback {
+			return
+		}
+		feedback = &CardinalityFeedback{Fingerprint: fingerprint, Predicate: predicate}
+		s.pending[key] = feedback
+	}
+	feedback.merge(&CardinalityFeedback{EstRows: estRows, ActRows: actRows, Count: 1})
+}
+
+// GetCorrection implements the cardinality.FeedbackStore interface.
+func (s *CardinalityFeedbackStore) GetCorrection(tableID int64, fingerprint string) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	feedback, ok := s.learned[cardinalityFeedbackKey{tableID: tableID, fingerprint: fingerprint}]
+	if !ok {
+		return 0, false
+	}
+	return feedback.Correction(), true
+}
+
+// Flush saves the pending feedback as the delta rows, which are merged into the saved feedback of the same predicates
+// later, so the feedback flushed by multiple TiDB instances at the same time is not lost. The feedback which is not
+// updated within CardinalityFeedbackTTL is removed. Then the learned feedback is reloaded from the storage, including
+// the feedback saved by the other TiDB instances.
+func (s *CardinalityFeedbackStore) Flush() {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[cardinalityFeedbackKey]*CardinalityFeedback)
+	s.mu.Unlock()
+	if len(pending) == 0 {
+		s.Load()
+		return
+	}
+
+	se, err := s.sysSessionPool.Get()
+	if err != nil {
+		logutil.BgLogger().Warn("get system session failed when saving cardinality feedback", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err == nil { // only recycle when no error
+			s.sysSessionPool.Put(se)
+		} else {
+			// Note: Otherwise, the session will be leaked.
+			s.sysSessionPool.Destroy(se)
+		}
+	}()
+	sctx := se.(sessionctx.Context)
+	if err = s.save(sctx, pending); err != nil {
+		logutil.BgLogger().Warn("save cardinality feedback failed", zap.Error(err))
+		return
+	}
+	// The delta rows are kept if the merge fails, e.g. it conflicts with another instance, they're merged next time.
+	if mergeErr := s.merge(sctx, pending); mergeErr != nil {
+		logutil.BgLogger().Info("merge cardinality feedback failed", zap.Error(mergeErr))
+	}
+	if err = s.load(sctx); err != nil {
+		logutil.BgLogger().Warn("load cardinality feedback failed", zap.Error(err))
+	}
+}
+
+// save inserts the feedback as the delta rows and removes the expired feedback.
+func (*CardinalityFeedbackStore) save(sctx sessionctx.Context, feedbacks map[cardinalityFeedbackKey]*CardinalityFeedback) error {
+	exec := sctx.GetRestrictedSQLExecutor()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnWorkloadLearning)
+	if err := sessiontxn.NewTxn(ctx, sctx); err != nil {
+		return err
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	version := txn.StartTS()
+	expired := oracle.GoTimeToTS(oracle.GetTimeFromTS(version).Add(-CardinalityFeedbackTTL))
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil,
+		"delete from mysql.tidb_workload_values where category = %? and type = %? and version < %?",
+		feedbackCategory, cardinalityFeedback, expired)
+	if err != nil {
+		return err
+	}
+	if err = insertCardinalityFeedback(ctx, exec, version, feedbacks); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+// merge merges the saved rows of every predicate in keys into one row. The rows are deleted by their ids, so the rows
+// inserted by other instances in the meantime are kept, and the concurrent merges of the same rows conflict.
+func (*CardinalityFeedbackStore) merge(sctx sessionctx.Context, keys map[cardinalityFeedbackKey]*CardinalityFeedback) error {
+	exec := sctx.GetRestrictedSQLExecutor()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnWorkloadLearning)
+	if err := sessiontxn.NewTxn(ctx, sctx); err != nil {
+		return err
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	tableIDs := make(map[int64]struct{}, len(keys))
+	for key := range keys {
+		tableIDs[key.tableID] = struct{}{}
+	}
+	ids := make([]int64, 0, len(keys))
+	merged := make(map[cardinalityFeedbackKey]*CardinalityFeedback, len(keys))
+	err = readCardinalityFeedback(ctx, exec, slices.Collect(maps.Keys(tableIDs)), func(id int64, key cardinalityFeedbackKey, feedback *CardinalityFeedback) {
+		if _, ok := keys[key]; !ok {
+			return
+		}
+		ids = append(ids, id)
+		if m, ok := merged[key]; ok {
+			m.merge(feedback)
+		} else {
+			merged[key] = feedback
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if len(ids) == len(merged) {
+		// Every predicate has only one row.
+		return txn.Rollback()
+	}
+	for batch := range slices.Chunk(ids, batchInsertSize) {
+		_, _, err = exec.ExecRestrictedSQL(ctx, nil, "delete from mysql.tidb_workload_values where id in ("+joinInt64s(batch)+")")
+		if err != nil {
+			return err
+		}
+	}
+	if err = insertCardinalityFeedback(ctx, exec, txn.StartTS(), merged); err != nil {
+		return err
+	}
+	return txn.Commit(ctx)
+}
+
+func insertCardinalityFeedback(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, version uint64, feedbacks map[cardinalityFeedbackKey]*CardinalityFeedback) error {
+	i := 0
+	sql := new(strings.Builder)
+	for key, feedback := range feedbacks {
+		value, err := json.Marshal(feedback)
+		if err != nil {
+			return err
+		}
+		if i%batchInsertSize == 0 {
+			if sql.Len() > 0 {
+				if _, _, err := exec.ExecRestrictedSQL(ctx, nil, sql.String()); err != nil {
+					return err
+				}
+				sql.Reset()
+			}
+			sqlescape.MustFormatSQL(sql, "insert into mysql.tidb_workload_values (version, category, type, table_id, value) values ")
+		} else {
+			sql.WriteString(", ")
+		}
+		sqlescape.MustFormatSQL(sql, "(%?, %?, %?, %?, %?)",
+			version, feedbackCategory, cardinalityFeedback, key.tableID, json.RawMessage(value))
+		i++
+	}
+	if sql.Len() > 0 {
+		if _, _, err := exec.ExecRestrictedSQL(ctx, nil, sql.String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// joinInt64s formats the integers as a comma separated list for the SQL.
+func joinInt64s(vals []int64) string {
+	strs := make([]string, 0, len(vals))
+	for _, v := range vals {
+		strs = append(strs, strconv.FormatInt(v, 10))
+	}
+	return strings.Join(strs, ", ")
+}
+
+// readCardinalityFeedback reads the saved rows of the feedback on the tables, or on all the tables if tableIDs is nil.
+func readCardinalityFeedback(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, tableIDs []int64,
+	fn func(id int64, key cardinalityFeedbackKey, feedback *CardinalityFeedback)) error {
+	sql := new(strings.Builder)
+	sqlescape.MustFormatSQL(sql, "select id, table_id, value from mysql.tidb_workload_values where category = %? and type = %?",
+		feedbackCategory, cardinalityFeedback)
+	if tableIDs != nil {
+		sql.WriteString(" and table_id in (" + joinInt64s(tableIDs) + ")")
+	}
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql.String())
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		tableID := row.GetInt64(1)
+		value := row.GetJSON(2).String()
+		feedback := &CardinalityFeedback{}
+		if err := json.Unmarshal([]byte(value), feedback); err != nil {
+			logutil.BgLogger().Warn("failed to unmarshal cardinality feedback",
+				zap.Int64("table_id", tableID),
+				zap.String("value", value),
+				zap.Error(err))
+			continue
+		}
+		fn(row.GetInt64(0), cardinalityFeedbackKey{tableID: tableID, fingerprint: feedback.Fingerprint}, feedback)
+	}
+	return nil
+}
+
+// Load reloads the learned feedback from the storage.
+func (s *CardinalityFeedbackStore) Load() {
+	se, err := s.sysSessionPool.Get()
+	if err != nil {
+		logutil.BgLogger().Warn("get system session failed when loading cardinality feedback", zap.Error(err))
+		return
+	}
+	defer func() {
+		if err == nil { // only recycle when no error
+			s.sysSessionPool.Put(se)
+		} else {
+			// Note: Otherwise, the session will be leaked.
+			s.sysSessionPool.Destroy(se)
+		}
+	}()
+	if err = s.load(se.(sessionctx.Context)); err != nil {
+		logutil.BgLogger().Warn("load cardinality feedback failed", zap.Error(err))
+	}
+}
+
+// load reads the feedback of every predicate, the rows of the same predicate which are not merged yet are summed up.
+func (s *CardinalityFeedbackStore) load(sctx sessionctx.Context) error {
+	exec := sctx.GetRestrictedSQLExecutor()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnWorkloadLearning)
+	learned := make(map[cardinalityFeedbackKey]*CardinalityFeedback)
+	err := readCardinalityFeedback(ctx, exec, nil, func(_ int64, key cardinalityFeedbackKey, feedback *CardinalityFeedback) {
+		if l, ok := learned[key]; ok {
+			l.merge(feedback)
+		} else {
+			learned[key] = feedback
+		}
+	})
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.learned = learned
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package workloadlearning_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/workloadlearning"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCardinalityFeedbackStore(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	feedbackStore := workloadlearning.NewCardinalityFeedbackStore(dom.SysSessionPool())
+	feedbackStore.Record(1, "fp", "eq(test.t.a, 1)", 10, 100)
+	feedbackStore.Record(1, "fp", "eq(test.t.a, 1)", 10, 300)
+	// The pending feedback is not used until it's flushed.
+	_, ok := feedbackStore.GetCorrection(1, "fp")
+	require.False(t, ok)
+	feedbackStore.Flush()
+	correction, ok := feedbackStore.GetCorrection(1, "fp")
+	require.True(t, ok)
+	require.Equal(t, 20.0, correction)
+	tk.MustQuery("select table_id, json_extract(value, '$.count') from mysql.tidb_workload_values where type = 'CardinalityFeedback'").
+		Check(testkit.Rows("1 2"))
+
+	// The new feedback is merged into the learned one.
+	feedbackStore.Record(1, "fp", "eq(test.t.a, 1)", 20, 0)
+	feedbackStore.Flush()
+	correction, ok = feedbackStore.GetCorrection(1, "fp")
+	require.True(t, ok)
+	require.Equal(t, 10.0, correction)
+
+	// The feedback saved by other instances is loaded.
+	other := workloadlearning.NewCardinalityFeedbackStore(dom.SysSessionPool())
+	other.Load()
+	correction, ok = other.GetCorrection(1, "fp")
+	require.True(t, ok)
+	require.Equal(t, 10.0, correction)
+	_, ok = other.GetCorrection(2, "fp")
+	require.False(t, ok)
+	// The feedback of the same predicate is kept in one row.
+	tk.MustQuery("select table_id, json_extract(value, '$.count') from mysql.tidb_workload_values where type = 'CardinalityFeedback'").
+		Check(testkit.Rows("1 3"))
+
+	// The feedback flushed by multiple instances is merged instead of overwritten.
+	other.Record(1, "fp", "eq(test.t.a, 1)", 10, 10)
+	feedbackStore.Record(1, "fp", "eq(test.t.a, 1)", 10, 10)
+	other.Flush()
+	feedbackStore.Flush()
+	correction, ok = feedbackStore.GetCorrection(1, "fp")
+	require.True(t, ok)
+	require.Equal(t, 7.0, correction)
+	tk.MustQuery("select table_id, json_extract(value, '$.count') from mysql.tidb_workload_values where type = 'CardinalityFeedback'").
+		Check(testkit.Rows("1 5"))
+}
+
+func TestCardinalityFeedbackFromExplainAnalyze(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (1, 3), (1, 4), (1, 5), (2, 1)")
+	for range 4 {
+		tk.MustExec("insert into t select * from t")
+	}
+
+	// The feedback is not captured unless it's enabled.
+	tk.MustQuery("explain analyze select * from t where a = 1")
+	dom.CardinalityFeedback().Flush()
+	tk.MustQuery("select count(*) from mysql.tidb_workload_values where type = 'CardinalityFeedback'").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = on")
+	tk.MustQuery("explain analyze select * from t where a = 1")
+	dom.CardinalityFeedback().Flush()
+	rows := tk.MustQuery("explain format = 'brief' select * from t where a = 1").Rows()
+	var scan []any
+	for _, row := range rows {
+		if strings.Contains(row[0].(string), "TableFullScan") {
+			scan = row
+		}
+	}
+	require.NotNil(t, scan)
+	require.Contains(t, scan[4], "cardinality feedback:")
+	require.Equal(t, "80.00", rows[0][1])
+
+	// The correction is only applied to the same predicate.
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 2").Rows()
+	for _, row := range rows {
+		require.NotContains(t, row[4], "cardinality feedback:")
+	}
+
+	// The correction is not applied if the feedback is disabled.
+	tk.MustExec("set @@tidb_enable_cardinality_feedback = off")
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1").Rows()
+	for _, row := range rows {
+		require.NotContains(t, row[4], "cardinality feedback:")
+	}
+}